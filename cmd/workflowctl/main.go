@@ -0,0 +1,210 @@
+// Command workflowctl keeps workflow definitions in files: it exports
+// workflows as YAML or JSON and plans or applies definition files against a
+// running server through its /api/workflow-definitions endpoints.
+//
+// Usage:
+//
+//	workflowctl export -id <workflow-id> [-format yaml|json] [-o file]
+//	workflowctl plan -f purchase.yaml
+//	workflowctl apply -f purchase.yaml [-yes]
+//
+// The server and credentials come from -server and -token, or the
+// WORKFLOWCTL_SERVER and WORKFLOWCTL_TOKEN environment variables. The token is
+// an admin's access token.
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"workflow-approval/package/workflow_definition/domain"
+)
+
+const usage = `Usage:
+  workflowctl export -id <workflow-id> [-format yaml|json] [-o file]
+  workflowctl plan -f <definition file>
+  workflowctl apply -f <definition file> [-yes]
+
+Run "workflowctl <command> -h" for the flags of a command.
+`
+
+func main() {
+	if len(os.Args) < 2 {
+		fmt.Fprint(os.Stderr, usage)
+		os.Exit(2)
+	}
+
+	var err error
+	switch os.Args[1] {
+	case "export":
+		err = runExport(os.Args[2:])
+	case "plan":
+		err = runPlan(os.Args[2:], false)
+	case "apply":
+		err = runPlan(os.Args[2:], true)
+	default:
+		fmt.Fprint(os.Stderr, usage)
+		os.Exit(2)
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Error:", err)
+		os.Exit(1)
+	}
+}
+
+// client calls the server's workflow definition endpoints
+type client struct {
+	server string
+	token  string
+	http   *http.Client
+}
+
+// newFlagSet returns the flags of a command with the connection flags every command has
+func newFlagSet(name string) (*flag.FlagSet, *client) {
+	c := &client{http: &http.Client{Timeout: time.Minute}}
+	fs := flag.NewFlagSet(name, flag.ExitOnError)
+	fs.StringVar(&c.server, "server", envOr("WORKFLOWCTL_SERVER", "http://localhost:8080"), "server URL")
+	fs.StringVar(&c.token, "token", os.Getenv("WORKFLOWCTL_TOKEN"), "admin access token")
+	return fs, c
+}
+
+func envOr(key, fallback string) string {
+	if v := os.Getenv(key); v != "" {
+		return v
+	}
+	return fallback
+}
+
+func runExport(args []string) error {
+	fs, c := newFlagSet("export")
+	id := fs.String("id", "", "ID of the workflow to export")
+	format := fs.String("format", "yaml", "yaml or json")
+	out := fs.String("o", "", "file to write (default standard output)")
+	fs.Parse(args)
+	if *id == "" {
+		return errors.New("-id is required")
+	}
+
+	body, err := c.do(http.MethodGet, "/api/workflow-definitions/"+url.PathEscape(*id)+"?format="+url.QueryEscape(*format), nil, "")
+	if err != nil {
+		return err
+	}
+	if *out == "" {
+		_, err = os.Stdout.Write(body)
+		return err
+	}
+	return os.WriteFile(*out, body, 0o644)
+}
+
+// runPlan prints the plan of a definition file and, for apply, applies it
+// once confirmed
+func runPlan(args []string, apply bool) error {
+	name := "plan"
+	if apply {
+		name = "apply"
+	}
+	fs, c := newFlagSet(name)
+	file := fs.String("f", "", "definition file (.yaml, .yml or .json)")
+	yes := fs.Bool("yes", false, "apply without asking for confirmation")
+	fs.Parse(args)
+	if *file == "" {
+		return errors.New("-f is required")
+	}
+
+	data, err := os.ReadFile(*file)
+	if err != nil {
+		return err
+	}
+	format := domain.FormatYAML
+	if strings.EqualFold(filepath.Ext(*file), ".json") {
+		format = domain.FormatJSON
+	}
+
+	plan, err := c.post("/api/workflow-definitions/plan?format="+string(format), data, format)
+	if err != nil {
+		return err
+	}
+	fmt.Print(plan.String())
+	if !apply || !plan.HasChanges() {
+		return nil
+	}
+
+	if !*yes {
+		fmt.Print("\nApply these changes? Only \"yes\" is accepted: ")
+		answer, _ := bufio.NewReader(os.Stdin).ReadString('\n')
+		if strings.TrimSpace(answer) != "yes" {
+			return errors.New("apply cancelled")
+		}
+	}
+
+	// The server plans again when applying, so changes made since are not overwritten unseen
+	applied, err := c.post("/api/workflow-definitions/apply?format="+string(format), data, format)
+	if err != nil {
+		return err
+	}
+	if fmt.Sprint(applied.Changes) != fmt.Sprint(plan.Changes) {
+		fmt.Print("\nThe workflow changed after planning; applied instead:\n" + applied.String())
+	}
+	fmt.Printf("\nApplied %d change(s) to workflow %s.\n", len(applied.Changes), applied.WorkflowID)
+	return nil
+}
+
+func (c *client) post(path string, data []byte, format domain.Format) (*domain.Plan, error) {
+	body, err := c.do(http.MethodPost, path, data, format.ContentType())
+	if err != nil {
+		return nil, err
+	}
+	var resp struct {
+		Data domain.Plan `json:"data"`
+	}
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return nil, fmt.Errorf("unexpected response: %v", err)
+	}
+	return &resp.Data, nil
+}
+
+// do sends a request and returns the response body, turning error responses into errors
+func (c *client) do(method, path string, data []byte, contentType string) ([]byte, error) {
+	req, err := http.NewRequest(method, strings.TrimRight(c.server, "/")+path, bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+	if c.token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.token)
+	}
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode >= 300 {
+		var failure struct {
+			Error string `json:"error"`
+		}
+		if json.Unmarshal(body, &failure) == nil && failure.Error != "" {
+			return nil, fmt.Errorf("%s (HTTP %d)", failure.Error, resp.StatusCode)
+		}
+		return nil, fmt.Errorf("HTTP %d", resp.StatusCode)
+	}
+	return body, nil
+}
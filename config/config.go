@@ -12,10 +12,21 @@ import (
 
 // Config holds all configuration for the application
 type Config struct {
-	App      AppConfig      `yaml:"app"`
-	Database DatabaseConfig `yaml:"database"`
-	JWT      JWTConfig      `yaml:"jwt"`
-	Logging  LoggingConfig  `yaml:"logging"`
+	App         AppConfig        `yaml:"app"`
+	Database    DatabaseConfig   `yaml:"database"`
+	JWT         JWTConfig        `yaml:"jwt"`
+	OIDC        OIDCConfig       `yaml:"oidc"`
+	Login       LoginConfig      `yaml:"login_protection"`
+	TwoFactor   TwoFactorConfig  `yaml:"two_factor"`
+	Mail        MailConfig       `yaml:"mail"`
+	Account     AccountConfig    `yaml:"account"`
+	Storage     StorageConfig    `yaml:"storage"`
+	Attachments AttachmentConfig `yaml:"attachments"`
+	Comments    CommentConfig    `yaml:"comments"`
+	Requests    RequestConfig    `yaml:"requests"`
+	Exports     ExportConfig     `yaml:"exports"`
+	Metrics     MetricsConfig    `yaml:"metrics"`
+	Logging     LoggingConfig    `yaml:"logging"`
 }
 
 // AppConfig holds application configuration
@@ -40,11 +51,139 @@ type DatabaseConfig struct {
 	ConnMaxLifetime int    `yaml:"conn_max_lifetime"`
 }
 
+// DefaultJWTSecret is the placeholder secret shipped in config.yaml and .env.example
+const DefaultJWTSecret = "your-super-secret-key-change-in-production"
+
 // JWTConfig holds JWT configuration
 type JWTConfig struct {
-	Secret     string `yaml:"secret"`
-	Expiration int    `yaml:"expiration"`
-	Issuer     string `yaml:"issuer"`
+	Algorithm        string `yaml:"algorithm"` // HS256, RS256 or EdDSA
+	Secret           string `yaml:"secret"`    // HS256 only
+	Expiration       int    `yaml:"expiration"`
+	Issuer           string `yaml:"issuer"`
+	Audience         string `yaml:"audience"`          // Defaults to issuer
+	KeysDir          string `yaml:"keys_dir"`          // Persisted RS256/EdDSA keys
+	RotationInterval int    `yaml:"rotation_interval"` // Hours between signing key rotations, 0 disables
+}
+
+// OIDCConfig holds OpenID Connect (SSO) configuration
+type OIDCConfig struct {
+	Enabled          bool               `yaml:"enabled"`
+	IssuerURL        string             `yaml:"issuer_url"`
+	ClientID         string             `yaml:"client_id"`
+	ClientSecret     string             `yaml:"client_secret"`
+	RedirectURL      string             `yaml:"redirect_url"`
+	Scopes           []string           `yaml:"scopes"`
+	DefaultActorCode string             `yaml:"default_actor_code"`
+	ClaimMappings    []OIDCClaimMapping `yaml:"claim_mappings"`
+}
+
+// OIDCClaimMapping maps a claim value to an actor code and/or the admin role
+type OIDCClaimMapping struct {
+	Claim     string `yaml:"claim"`
+	Value     string `yaml:"value"`
+	ActorCode string `yaml:"actor_code"`
+	IsAdmin   bool   `yaml:"is_admin"`
+}
+
+// GetRotationInterval returns the key rotation interval as time.Duration
+func (j *JWTConfig) GetRotationInterval() time.Duration {
+	return time.Duration(j.RotationInterval) * time.Hour
+}
+
+// Validate rejects insecure JWT settings; the well-known default secret is refused in production
+func (j *JWTConfig) Validate(env string) error {
+	if j.Algorithm == "" || j.Algorithm == "HS256" {
+		if env == "production" && (j.Secret == "" || j.Secret == DefaultJWTSecret) {
+			return fmt.Errorf("jwt.secret must be changed from the default when using HS256 in production")
+		}
+	}
+	return nil
+}
+
+// LoginConfig holds brute-force protection settings for /auth/login
+type LoginConfig struct {
+	MaxAccountFailures int `yaml:"max_account_failures"` // Failures before the account is locked
+	MaxIPFailures      int `yaml:"max_ip_failures"`      // Failures before the client IP is blocked
+	FailureWindow      int `yaml:"failure_window"`       // Minutes after which failures are forgotten
+	LockoutDuration    int `yaml:"lockout_duration"`     // Minutes a lock lasts
+	BaseDelay          int `yaml:"base_delay"`           // Seconds to wait after the first failure, doubled per failure
+	MaxDelay           int `yaml:"max_delay"`            // Upper bound of the progressive delay in seconds
+}
+
+// TwoFactorConfig holds TOTP two-factor authentication settings
+type TwoFactorConfig struct {
+	Issuer           string  `yaml:"issuer"`             // Account issuer shown in authenticator apps
+	RequireForAdmins bool    `yaml:"require_for_admins"` // Admins must enroll and pass TOTP at login
+	ChallengeTTL     int     `yaml:"challenge_ttl"`      // Minutes to enter the code after the password
+	StepUpAmount     float64 `yaml:"step_up_amount"`     // Approving requests at or above this amount needs step-up, 0 disables
+	StepUpMaxAge     int     `yaml:"step_up_max_age"`    // Minutes a TOTP verification satisfies step-up
+}
+
+// MailConfig holds outgoing email settings
+type MailConfig struct {
+	Driver   string `yaml:"driver"` // "log" prints messages, "smtp" delivers them
+	Host     string `yaml:"host"`
+	Port     int    `yaml:"port"`
+	Username string `yaml:"username"`
+	Password string `yaml:"password"`
+	From     string `yaml:"from"`
+}
+
+// AccountConfig holds password reset and email verification settings
+type AccountConfig struct {
+	BaseURL              string `yaml:"base_url"`               // Frontend URL used in emailed links
+	ResetTokenTTL        int    `yaml:"reset_token_ttl"`        // Minutes a password reset link is valid
+	VerificationTokenTTL int    `yaml:"verification_token_ttl"` // Hours an email verification link is valid
+	ResendCooldown       int    `yaml:"resend_cooldown"`        // Seconds between two emails of the same kind
+}
+
+// StorageConfig selects where uploaded files are kept
+type StorageConfig struct {
+	Driver   string   `yaml:"driver"`    // "local" or "s3"
+	LocalDir string   `yaml:"local_dir"` // Root directory of the local driver
+	S3       S3Config `yaml:"s3"`
+}
+
+// S3Config holds the settings of an S3-compatible bucket
+type S3Config struct {
+	Endpoint  string `yaml:"endpoint"`
+	Region    string `yaml:"region"`
+	Bucket    string `yaml:"bucket"`
+	AccessKey string `yaml:"access_key"`
+	SecretKey string `yaml:"secret_key"`
+	PathStyle bool   `yaml:"path_style"` // Required by MinIO and most self-hosted stores
+}
+
+// AttachmentConfig holds request attachment limits
+type AttachmentConfig struct {
+	MaxSizeMB    int      `yaml:"max_size_mb"`   // Per file
+	AllowedTypes []string `yaml:"allowed_types"` // MIME types, "image/*" allows a family; empty uses the defaults
+}
+
+// CommentConfig holds request comment limits
+type CommentConfig struct {
+	EditWindow int `yaml:"edit_window"` // Minutes after posting the author may edit
+	MaxLength  int `yaml:"max_length"`  // Characters per comment
+}
+
+// RequestConfig holds request lifecycle settings
+type RequestConfig struct {
+	DraftTTLDays int    `yaml:"draft_ttl_days"` // Drafts unchanged this long are deleted, 0 keeps them
+	BaseCurrency string `yaml:"base_currency"`  // ISO-4217 currency step thresholds are expressed in
+}
+
+// ExportConfig holds request export settings
+type ExportConfig struct {
+	MaxSyncRows    int `yaml:"max_sync_rows"`   // Larger exports must run as background jobs
+	RetentionHours int `yaml:"retention_hours"` // How long finished export files can be downloaded
+	PollInterval   int `yaml:"poll_interval"`   // Seconds between checks for queued export jobs
+	RunTimeout     int `yaml:"run_timeout"`     // Minutes after which a job still running is failed as interrupted, 0 never
+}
+
+// MetricsConfig holds the Prometheus /metrics endpoint settings
+type MetricsConfig struct {
+	Enabled bool   `yaml:"enabled"` // Serve GET /metrics
+	Token   string `yaml:"token"`   // Bearer token scrapers must send; empty leaves /metrics open
 }
 
 // LoggingConfig holds logging configuration
@@ -109,9 +248,83 @@ func Load(path string) (*Config, error) {
 			ConnMaxLifetime: getEnvInt("DB_CONN_MAX_LIFETIME", 300),
 		},
 		JWT: JWTConfig{
-			Secret:     getEnvString("JWT_SECRET", "your-super-secret-key-change-in-production"),
-			Expiration: getEnvInt("JWT_EXPIRATION", 24),
-			Issuer:     getEnvString("JWT_ISSUER", "workflow-approval-system"),
+			Algorithm:        getEnvString("JWT_ALGORITHM", "RS256"),
+			Secret:           getEnvString("JWT_SECRET", DefaultJWTSecret),
+			Expiration:       getEnvInt("JWT_EXPIRATION", 24),
+			Issuer:           getEnvString("JWT_ISSUER", "workflow-approval-system"),
+			Audience:         getEnvString("JWT_AUDIENCE", ""),
+			KeysDir:          getEnvString("JWT_KEYS_DIR", "data/jwt-keys"),
+			RotationInterval: getEnvInt("JWT_ROTATION_INTERVAL", 720),
+		},
+		OIDC: OIDCConfig{
+			Enabled:          getEnvBool("OIDC_ENABLED", false),
+			IssuerURL:        getEnvString("OIDC_ISSUER_URL", ""),
+			ClientID:         getEnvString("OIDC_CLIENT_ID", ""),
+			ClientSecret:     getEnvString("OIDC_CLIENT_SECRET", ""),
+			RedirectURL:      getEnvString("OIDC_REDIRECT_URL", "http://localhost:8080/auth/oidc/callback"),
+			DefaultActorCode: getEnvString("OIDC_DEFAULT_ACTOR_CODE", ""),
+		},
+		Login: LoginConfig{
+			MaxAccountFailures: getEnvInt("LOGIN_MAX_ACCOUNT_FAILURES", 5),
+			MaxIPFailures:      getEnvInt("LOGIN_MAX_IP_FAILURES", 20),
+			FailureWindow:      getEnvInt("LOGIN_FAILURE_WINDOW", 15),
+			LockoutDuration:    getEnvInt("LOGIN_LOCKOUT_DURATION", 15),
+			BaseDelay:          getEnvInt("LOGIN_BASE_DELAY", 1),
+			MaxDelay:           getEnvInt("LOGIN_MAX_DELAY", 30),
+		},
+		TwoFactor: TwoFactorConfig{
+			Issuer:           getEnvString("TWO_FACTOR_ISSUER", "Workflow Approval"),
+			RequireForAdmins: getEnvBool("TWO_FACTOR_REQUIRE_FOR_ADMINS", false),
+			ChallengeTTL:     getEnvInt("TWO_FACTOR_CHALLENGE_TTL", 5),
+			StepUpAmount:     getEnvFloat("TWO_FACTOR_STEP_UP_AMOUNT", 0),
+			StepUpMaxAge:     getEnvInt("TWO_FACTOR_STEP_UP_MAX_AGE", 5),
+		},
+		Mail: MailConfig{
+			Driver:   getEnvString("MAIL_DRIVER", "log"),
+			Host:     getEnvString("MAIL_HOST", ""),
+			Port:     getEnvInt("MAIL_PORT", 587),
+			Username: getEnvString("MAIL_USERNAME", ""),
+			Password: getEnvString("MAIL_PASSWORD", ""),
+			From:     getEnvString("MAIL_FROM", "no-reply@example.com"),
+		},
+		Account: AccountConfig{
+			BaseURL:              getEnvString("ACCOUNT_BASE_URL", "http://localhost:8080"),
+			ResetTokenTTL:        getEnvInt("ACCOUNT_RESET_TOKEN_TTL", 30),
+			VerificationTokenTTL: getEnvInt("ACCOUNT_VERIFICATION_TOKEN_TTL", 48),
+			ResendCooldown:       getEnvInt("ACCOUNT_RESEND_COOLDOWN", 60),
+		},
+		Storage: StorageConfig{
+			Driver:   getEnvString("STORAGE_DRIVER", "local"),
+			LocalDir: getEnvString("STORAGE_LOCAL_DIR", "data/attachments"),
+			S3: S3Config{
+				Endpoint:  getEnvString("STORAGE_S3_ENDPOINT", ""),
+				Region:    getEnvString("STORAGE_S3_REGION", "us-east-1"),
+				Bucket:    getEnvString("STORAGE_S3_BUCKET", ""),
+				AccessKey: getEnvString("STORAGE_S3_ACCESS_KEY", ""),
+				SecretKey: getEnvString("STORAGE_S3_SECRET_KEY", ""),
+				PathStyle: getEnvBool("STORAGE_S3_PATH_STYLE", false),
+			},
+		},
+		Attachments: AttachmentConfig{
+			MaxSizeMB: getEnvInt("ATTACHMENTS_MAX_SIZE_MB", 10),
+		},
+		Comments: CommentConfig{
+			EditWindow: getEnvInt("COMMENTS_EDIT_WINDOW", 15),
+			MaxLength:  getEnvInt("COMMENTS_MAX_LENGTH", 5000),
+		},
+		Requests: RequestConfig{
+			DraftTTLDays: getEnvInt("REQUESTS_DRAFT_TTL_DAYS", 30),
+			BaseCurrency: getEnvString("REQUESTS_BASE_CURRENCY", "IDR"),
+		},
+		Exports: ExportConfig{
+			MaxSyncRows:    getEnvInt("EXPORTS_MAX_SYNC_ROWS", 10000),
+			RetentionHours: getEnvInt("EXPORTS_RETENTION_HOURS", 24),
+			PollInterval:   getEnvInt("EXPORTS_POLL_INTERVAL", 5),
+			RunTimeout:     getEnvInt("EXPORTS_RUN_TIMEOUT", 60),
+		},
+		Metrics: MetricsConfig{
+			Enabled: getEnvBool("METRICS_ENABLED", true),
+			Token:   getEnvString("METRICS_TOKEN", ""),
 		},
 		Logging: LoggingConfig{
 			Level:  getEnvString("LOGGING_LEVEL", "debug"),
@@ -168,6 +381,9 @@ func (c *Config) overrideFromEnv() {
 	}
 
 	// JWT config
+	if algorithm := os.Getenv("JWT_ALGORITHM"); algorithm != "" {
+		c.JWT.Algorithm = algorithm
+	}
 	if secret := os.Getenv("JWT_SECRET"); secret != "" {
 		c.JWT.Secret = secret
 	}
@@ -177,6 +393,175 @@ func (c *Config) overrideFromEnv() {
 	if issuer := os.Getenv("JWT_ISSUER"); issuer != "" {
 		c.JWT.Issuer = issuer
 	}
+	if audience := os.Getenv("JWT_AUDIENCE"); audience != "" {
+		c.JWT.Audience = audience
+	}
+	if keysDir := os.Getenv("JWT_KEYS_DIR"); keysDir != "" {
+		c.JWT.KeysDir = keysDir
+	}
+	if rotation := os.Getenv("JWT_ROTATION_INTERVAL"); rotation != "" {
+		fmt.Sscanf(rotation, "%d", &c.JWT.RotationInterval)
+	}
+
+	// OIDC config
+	if enabled := os.Getenv("OIDC_ENABLED"); enabled != "" {
+		c.OIDC.Enabled = enabled == "true" || enabled == "1"
+	}
+	if issuerURL := os.Getenv("OIDC_ISSUER_URL"); issuerURL != "" {
+		c.OIDC.IssuerURL = issuerURL
+	}
+	if clientID := os.Getenv("OIDC_CLIENT_ID"); clientID != "" {
+		c.OIDC.ClientID = clientID
+	}
+	if clientSecret := os.Getenv("OIDC_CLIENT_SECRET"); clientSecret != "" {
+		c.OIDC.ClientSecret = clientSecret
+	}
+	if redirectURL := os.Getenv("OIDC_REDIRECT_URL"); redirectURL != "" {
+		c.OIDC.RedirectURL = redirectURL
+	}
+	if defaultActor := os.Getenv("OIDC_DEFAULT_ACTOR_CODE"); defaultActor != "" {
+		c.OIDC.DefaultActorCode = defaultActor
+	}
+
+	// Login protection config
+	if maxAccount := os.Getenv("LOGIN_MAX_ACCOUNT_FAILURES"); maxAccount != "" {
+		fmt.Sscanf(maxAccount, "%d", &c.Login.MaxAccountFailures)
+	}
+	if maxIP := os.Getenv("LOGIN_MAX_IP_FAILURES"); maxIP != "" {
+		fmt.Sscanf(maxIP, "%d", &c.Login.MaxIPFailures)
+	}
+	if window := os.Getenv("LOGIN_FAILURE_WINDOW"); window != "" {
+		fmt.Sscanf(window, "%d", &c.Login.FailureWindow)
+	}
+	if lockout := os.Getenv("LOGIN_LOCKOUT_DURATION"); lockout != "" {
+		fmt.Sscanf(lockout, "%d", &c.Login.LockoutDuration)
+	}
+	if baseDelay := os.Getenv("LOGIN_BASE_DELAY"); baseDelay != "" {
+		fmt.Sscanf(baseDelay, "%d", &c.Login.BaseDelay)
+	}
+	if maxDelay := os.Getenv("LOGIN_MAX_DELAY"); maxDelay != "" {
+		fmt.Sscanf(maxDelay, "%d", &c.Login.MaxDelay)
+	}
+
+	// Two-factor config
+	if issuer := os.Getenv("TWO_FACTOR_ISSUER"); issuer != "" {
+		c.TwoFactor.Issuer = issuer
+	}
+	if required := os.Getenv("TWO_FACTOR_REQUIRE_FOR_ADMINS"); required != "" {
+		c.TwoFactor.RequireForAdmins = required == "true" || required == "1"
+	}
+	if ttl := os.Getenv("TWO_FACTOR_CHALLENGE_TTL"); ttl != "" {
+		fmt.Sscanf(ttl, "%d", &c.TwoFactor.ChallengeTTL)
+	}
+	if amount := os.Getenv("TWO_FACTOR_STEP_UP_AMOUNT"); amount != "" {
+		fmt.Sscanf(amount, "%f", &c.TwoFactor.StepUpAmount)
+	}
+	if maxAge := os.Getenv("TWO_FACTOR_STEP_UP_MAX_AGE"); maxAge != "" {
+		fmt.Sscanf(maxAge, "%d", &c.TwoFactor.StepUpMaxAge)
+	}
+
+	// Mail config
+	if driver := os.Getenv("MAIL_DRIVER"); driver != "" {
+		c.Mail.Driver = driver
+	}
+	if host := os.Getenv("MAIL_HOST"); host != "" {
+		c.Mail.Host = host
+	}
+	if port := os.Getenv("MAIL_PORT"); port != "" {
+		fmt.Sscanf(port, "%d", &c.Mail.Port)
+	}
+	if username := os.Getenv("MAIL_USERNAME"); username != "" {
+		c.Mail.Username = username
+	}
+	if password := os.Getenv("MAIL_PASSWORD"); password != "" {
+		c.Mail.Password = password
+	}
+	if from := os.Getenv("MAIL_FROM"); from != "" {
+		c.Mail.From = from
+	}
+
+	// Account config
+	if baseURL := os.Getenv("ACCOUNT_BASE_URL"); baseURL != "" {
+		c.Account.BaseURL = baseURL
+	}
+	if ttl := os.Getenv("ACCOUNT_RESET_TOKEN_TTL"); ttl != "" {
+		fmt.Sscanf(ttl, "%d", &c.Account.ResetTokenTTL)
+	}
+	if ttl := os.Getenv("ACCOUNT_VERIFICATION_TOKEN_TTL"); ttl != "" {
+		fmt.Sscanf(ttl, "%d", &c.Account.VerificationTokenTTL)
+	}
+	if cooldown := os.Getenv("ACCOUNT_RESEND_COOLDOWN"); cooldown != "" {
+		fmt.Sscanf(cooldown, "%d", &c.Account.ResendCooldown)
+	}
+
+	// Storage config
+	if driver := os.Getenv("STORAGE_DRIVER"); driver != "" {
+		c.Storage.Driver = driver
+	}
+	if dir := os.Getenv("STORAGE_LOCAL_DIR"); dir != "" {
+		c.Storage.LocalDir = dir
+	}
+	if endpoint := os.Getenv("STORAGE_S3_ENDPOINT"); endpoint != "" {
+		c.Storage.S3.Endpoint = endpoint
+	}
+	if region := os.Getenv("STORAGE_S3_REGION"); region != "" {
+		c.Storage.S3.Region = region
+	}
+	if bucket := os.Getenv("STORAGE_S3_BUCKET"); bucket != "" {
+		c.Storage.S3.Bucket = bucket
+	}
+	if accessKey := os.Getenv("STORAGE_S3_ACCESS_KEY"); accessKey != "" {
+		c.Storage.S3.AccessKey = accessKey
+	}
+	if secretKey := os.Getenv("STORAGE_S3_SECRET_KEY"); secretKey != "" {
+		c.Storage.S3.SecretKey = secretKey
+	}
+	if pathStyle := os.Getenv("STORAGE_S3_PATH_STYLE"); pathStyle != "" {
+		c.Storage.S3.PathStyle = pathStyle == "true" || pathStyle == "1"
+	}
+
+	// Attachment config
+	if maxSize := os.Getenv("ATTACHMENTS_MAX_SIZE_MB"); maxSize != "" {
+		fmt.Sscanf(maxSize, "%d", &c.Attachments.MaxSizeMB)
+	}
+
+	// Comment config
+	if window := os.Getenv("COMMENTS_EDIT_WINDOW"); window != "" {
+		fmt.Sscanf(window, "%d", &c.Comments.EditWindow)
+	}
+	if maxLength := os.Getenv("COMMENTS_MAX_LENGTH"); maxLength != "" {
+		fmt.Sscanf(maxLength, "%d", &c.Comments.MaxLength)
+	}
+
+	// Request config
+	if ttl := os.Getenv("REQUESTS_DRAFT_TTL_DAYS"); ttl != "" {
+		fmt.Sscanf(ttl, "%d", &c.Requests.DraftTTLDays)
+	}
+	if currency := os.Getenv("REQUESTS_BASE_CURRENCY"); currency != "" {
+		c.Requests.BaseCurrency = currency
+	}
+
+	// Export config
+	if maxRows := os.Getenv("EXPORTS_MAX_SYNC_ROWS"); maxRows != "" {
+		fmt.Sscanf(maxRows, "%d", &c.Exports.MaxSyncRows)
+	}
+	if retention := os.Getenv("EXPORTS_RETENTION_HOURS"); retention != "" {
+		fmt.Sscanf(retention, "%d", &c.Exports.RetentionHours)
+	}
+	if interval := os.Getenv("EXPORTS_POLL_INTERVAL"); interval != "" {
+		fmt.Sscanf(interval, "%d", &c.Exports.PollInterval)
+	}
+	if timeout := os.Getenv("EXPORTS_RUN_TIMEOUT"); timeout != "" {
+		fmt.Sscanf(timeout, "%d", &c.Exports.RunTimeout)
+	}
+
+	// Metrics config
+	if enabled := os.Getenv("METRICS_ENABLED"); enabled != "" {
+		c.Metrics.Enabled = enabled == "true" || enabled == "1"
+	}
+	if token := os.Getenv("METRICS_TOKEN"); token != "" {
+		c.Metrics.Token = token
+	}
 
 	// Logging config
 	if level := os.Getenv("LOGGING_LEVEL"); level != "" {
@@ -205,6 +590,16 @@ func getEnvInt(key string, defaultValue int) int {
 	return defaultValue
 }
 
+// getEnvFloat returns environment variable as float64 or default value
+func getEnvFloat(key string, defaultValue float64) float64 {
+	if value := os.Getenv(key); value != "" {
+		if floatVal, err := strconv.ParseFloat(value, 64); err == nil {
+			return floatVal
+		}
+	}
+	return defaultValue
+}
+
 // getEnvBool returns environment variable as bool or default value
 func getEnvBool(key string, defaultValue bool) bool {
 	if value := os.Getenv(key); value != "" {
@@ -0,0 +1,21 @@
+package middleware
+
+import (
+	"github.com/gofiber/fiber/v2"
+)
+
+// RequireAdmin rejects callers whose credentials do not carry the is_admin flag.
+// It must run after the JWT or API key middleware has populated the context.
+func RequireAdmin() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		if !GetIsAdminFromContext(c) {
+			return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+				"success": false,
+				"data":    nil,
+				"error":   "Admin privileges are required",
+				"code":    "ADMIN_REQUIRED",
+			})
+		}
+		return c.Next()
+	}
+}
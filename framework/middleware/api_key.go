@@ -0,0 +1,215 @@
+package middleware
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+
+	apiKeyDomain "workflow-approval/package/api_key/domain"
+	apiKeyPorts "workflow-approval/package/api_key/ports"
+	apiKeyUsecase "workflow-approval/package/api_key/usecase"
+	tenantPorts "workflow-approval/package/tenant/ports"
+	"workflow-approval/utils/jwtauth"
+)
+
+const (
+	// APIKeyHeader is the header service integrations use to present an API key
+	APIKeyHeader = "X-API-Key"
+
+	// OnBehalfOfHeader lets a permitted API key create requests for a specific user
+	OnBehalfOfHeader = "X-On-Behalf-Of"
+
+	// AuthTypeJWT marks callers authenticated with a Bearer JWT
+	AuthTypeJWT = "jwt"
+
+	// AuthTypeAPIKey marks callers authenticated with an API key (service principal)
+	AuthTypeAPIKey = "api_key"
+)
+
+// NewAuthMiddleware accepts either a Bearer JWT or an API key on the same route group.
+// API keys are read from the X-API-Key header or an "Authorization: ApiKey <key>" header.
+func NewAuthMiddleware(jwtManager *jwtauth.Manager, apiKeyService apiKeyPorts.APIKeyService, tenants tenantPorts.TenantService) fiber.Handler {
+	jwtHandler := NewJWTMiddleware(jwtManager, tenants)
+	apiKeyHandler := NewAPIKeyMiddleware(apiKeyService)
+
+	return func(c *fiber.Ctx) error {
+		if extractAPIKey(c) != "" {
+			return apiKeyHandler(c)
+		}
+		return jwtHandler(c)
+	}
+}
+
+// NewAPIKeyMiddleware creates a middleware authenticating service principals by API key
+func NewAPIKeyMiddleware(apiKeyService apiKeyPorts.APIKeyService) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		rawKey := extractAPIKey(c)
+		if rawKey == "" {
+			return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+				"success": false,
+				"data":    nil,
+				"error":   "API key is required",
+				"code":    "MISSING_API_KEY",
+			})
+		}
+
+		key, err := apiKeyService.Authenticate(c.Context(), rawKey)
+		if err != nil {
+			return handleAPIKeyError(c, err)
+		}
+
+		// The key itself is the service principal
+		c.Locals("user_id", key.ID)
+		c.Locals("user_email", "")
+		c.Locals("is_admin", false)
+		c.Locals("actor_id", "")
+		c.Locals("actor_ids", []string(nil))
+		c.Locals("auth_type", AuthTypeAPIKey)
+		c.Locals("is_super_admin", false)
+		c.Locals("api_key", key)
+		setTenant(c, key.TenantID)
+
+		// Acting on behalf of a user is only allowed when creating requests
+		if onBehalfOf := c.Get(OnBehalfOfHeader); onBehalfOf != "" {
+			if c.Method() != fiber.MethodPost || strings.TrimRight(c.Path(), "/") != "/api/requests" {
+				return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+					"success": false,
+					"data":    nil,
+					"error":   "X-On-Behalf-Of is only supported when creating requests",
+					"code":    "ON_BEHALF_NOT_ALLOWED",
+				})
+			}
+
+			user, err := apiKeyService.ResolveOnBehalfOf(c.Context(), key, onBehalfOf)
+			if err != nil {
+				return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+					"success": false,
+					"data":    nil,
+					"error":   err.Error(),
+					"code":    "ON_BEHALF_NOT_ALLOWED",
+				})
+			}
+
+			c.Locals("user_id", user.ID)
+			c.Locals("user_email", user.Email)
+			c.Locals("actor_id", user.PrimaryActorID())
+			c.Locals("actor_ids", user.ActorIDs())
+			c.Locals("on_behalf_of", key.ID)
+			return c.Next()
+		}
+
+		// A key is no approver: it belongs to no actor and can't be resolved as a step's user
+		if isDecisionRoute(c.Method(), c.Path()) {
+			return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+				"success": false,
+				"data":    nil,
+				"error":   "API keys cannot approve or reject requests",
+				"code":    "API_KEY_CANNOT_APPROVE",
+			})
+		}
+
+		permission := requiredPermission(c.Method(), c.Path())
+		if !key.HasPermission(permission) {
+			return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+				"success": false,
+				"data":    nil,
+				"error":   "API key is missing the required permission: " + permission,
+				"code":    "INSUFFICIENT_SCOPE",
+			})
+		}
+
+		return c.Next()
+	}
+}
+
+// handleAPIKeyError maps API key authentication failures to responses
+func handleAPIKeyError(c *fiber.Ctx, err error) error {
+	switch {
+	case errors.Is(err, apiKeyUsecase.ErrAPIKeyRevoked):
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"success": false,
+			"data":    nil,
+			"error":   "API key has been revoked",
+			"code":    "API_KEY_REVOKED",
+		})
+	case errors.Is(err, apiKeyUsecase.ErrAPIKeyExpired):
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"success": false,
+			"data":    nil,
+			"error":   "API key has expired",
+			"code":    "API_KEY_EXPIRED",
+		})
+	case errors.Is(err, apiKeyUsecase.ErrInvalidAPIKey):
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"success": false,
+			"data":    nil,
+			"error":   "Invalid API key",
+			"code":    "INVALID_API_KEY",
+		})
+	}
+
+	return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+		"success": false,
+		"data":    nil,
+		"error":   "Failed to authenticate API key",
+	})
+}
+
+// extractAPIKey reads the API key from X-API-Key or "Authorization: ApiKey <key>"
+func extractAPIKey(c *fiber.Ctx) string {
+	if key := c.Get(APIKeyHeader); key != "" {
+		return key
+	}
+	parts := strings.SplitN(c.Get("Authorization"), " ", 2)
+	if len(parts) == 2 && strings.EqualFold(parts[0], "apikey") {
+		return strings.TrimSpace(parts[1])
+	}
+	return ""
+}
+
+// isDecisionRoute reports whether a route approves or rejects requests, one at a time or in bulk
+func isDecisionRoute(method, path string) bool {
+	if method != fiber.MethodPost {
+		return false
+	}
+	segments := strings.Split(strings.Trim(strings.TrimPrefix(path, "/api"), "/"), "/")
+	if segments[0] != "requests" {
+		return false
+	}
+	if len(segments) == 2 && (segments[1] == "bulk-approve" || segments[1] == "bulk-reject") {
+		return true
+	}
+	return len(segments) == 3 && (segments[2] == "approve" || segments[2] == "reject")
+}
+
+// requiredPermission maps a route under /api to the permission an API key needs for it.
+// GET requests need "<resource>:read", everything else "<resource>:write".
+func requiredPermission(method, path string) string {
+	segments := strings.Split(strings.Trim(strings.TrimPrefix(path, "/api"), "/"), "/")
+	resource := segments[0]
+	if resource == "profile" {
+		resource = "users"
+	}
+
+	if method == fiber.MethodGet || method == fiber.MethodHead {
+		return resource + ":read"
+	}
+	return resource + ":write"
+}
+
+// GetAuthTypeFromContext retrieves how the caller authenticated (jwt or api_key)
+func GetAuthTypeFromContext(c *fiber.Ctx) string {
+	if authType := c.Locals("auth_type"); authType != nil {
+		return authType.(string)
+	}
+	return ""
+}
+
+// GetAPIKeyFromContext retrieves the authenticated API key, or nil for JWT callers
+func GetAPIKeyFromContext(c *fiber.Ctx) *apiKeyDomain.APIKey {
+	if key, ok := c.Locals("api_key").(*apiKeyDomain.APIKey); ok {
+		return key
+	}
+	return nil
+}
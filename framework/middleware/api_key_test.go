@@ -0,0 +1,209 @@
+package middleware
+
+import (
+	"context"
+	"io"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+
+	apiKeyDomain "workflow-approval/package/api_key/domain"
+	apiKeyUsecase "workflow-approval/package/api_key/usecase"
+	userDomain "workflow-approval/package/user/domain"
+)
+
+// fakeAPIKeyService authenticates the raw keys of its map, failing those in errs
+type fakeAPIKeyService struct {
+	keys  map[string]*apiKeyDomain.APIKey
+	errs  map[string]error
+	users map[string]*userDomain.User
+}
+
+func (s *fakeAPIKeyService) CreateAPIKey(ctx context.Context, name string, permissions, workflowIDs []string, expiresAt *time.Time, createdBy string) (*apiKeyDomain.APIKey, string, error) {
+	return nil, "", nil
+}
+
+func (s *fakeAPIKeyService) GetAPIKey(ctx context.Context, id string) (*apiKeyDomain.APIKey, error) {
+	return nil, apiKeyUsecase.ErrAPIKeyNotFound
+}
+
+func (s *fakeAPIKeyService) ListAPIKeys(ctx context.Context) ([]*apiKeyDomain.APIKey, error) {
+	return nil, nil
+}
+
+func (s *fakeAPIKeyService) RevokeAPIKey(ctx context.Context, id string) (*apiKeyDomain.APIKey, error) {
+	return nil, nil
+}
+
+func (s *fakeAPIKeyService) Authenticate(ctx context.Context, rawKey string) (*apiKeyDomain.APIKey, error) {
+	if err, ok := s.errs[rawKey]; ok {
+		return nil, err
+	}
+	if key, ok := s.keys[rawKey]; ok {
+		return key, nil
+	}
+	return nil, apiKeyUsecase.ErrInvalidAPIKey
+}
+
+func (s *fakeAPIKeyService) ResolveOnBehalfOf(ctx context.Context, key *apiKeyDomain.APIKey, userID string) (*userDomain.User, error) {
+	if !key.HasPermission(apiKeyDomain.PermissionRequestsCreateOnBehalf) {
+		return nil, apiKeyUsecase.ErrOnBehalfNotPermitted
+	}
+	if user, ok := s.users[userID]; ok {
+		return user, nil
+	}
+	return nil, apiKeyUsecase.ErrOnBehalfUserNotFound
+}
+
+// newAPIKeyTestApp serves every route behind the API key middleware and
+// answers with the caller the middleware put in the context
+func newAPIKeyTestApp(service *fakeAPIKeyService) *fiber.App {
+	app := fiber.New()
+	app.Use(NewAPIKeyMiddleware(service))
+	app.All("/*", func(c *fiber.Ctx) error {
+		return c.SendString(GetUserIDFromContext(c))
+	})
+	return app
+}
+
+// doAPIKeyRequest sends a request with rawKey and returns the status and body
+func doAPIKeyRequest(t *testing.T, app *fiber.App, method, path, rawKey string, headers map[string]string) (int, string) {
+	t.Helper()
+	req := httptest.NewRequest(method, path, nil)
+	if rawKey != "" {
+		req.Header.Set(APIKeyHeader, rawKey)
+	}
+	for name, value := range headers {
+		req.Header.Set(name, value)
+	}
+	resp, err := app.Test(req)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	body, _ := io.ReadAll(resp.Body)
+	return resp.StatusCode, string(body)
+}
+
+func TestAPIKeysCannotApprove(t *testing.T) {
+	service := &fakeAPIKeyService{keys: map[string]*apiKeyDomain.APIKey{
+		"full": {ID: "key-1", Permissions: apiKeyDomain.StringList{"requests:*"}},
+	}}
+	app := newAPIKeyTestApp(service)
+
+	for _, path := range []string{"/api/requests/req-1/approve", "/api/requests/req-1/reject", "/api/requests/bulk-approve", "/api/requests/bulk-reject/"} {
+		if status, body := doAPIKeyRequest(t, app, fiber.MethodPost, path, "full", nil); status != fiber.StatusForbidden || !strings.Contains(body, "API_KEY_CANNOT_APPROVE") {
+			t.Errorf("%s: expected 403 API_KEY_CANNOT_APPROVE, got %d %s", path, status, body)
+		}
+	}
+	if status, _ := doAPIKeyRequest(t, app, fiber.MethodPost, "/api/requests/req-1/submit", "full", nil); status != fiber.StatusOK {
+		t.Errorf("Expected other request routes allowed, got %d", status)
+	}
+}
+
+func TestRequiredPermission(t *testing.T) {
+	tests := []struct {
+		method string
+		path   string
+		want   string
+	}{
+		{fiber.MethodGet, "/api/requests", "requests:read"},
+		{fiber.MethodGet, "/api/requests/req-1/history", "requests:read"},
+		{fiber.MethodHead, "/api/requests/req-1", "requests:read"},
+		{fiber.MethodPost, "/api/requests", "requests:write"},
+		{fiber.MethodPut, "/api/requests/req-1", "requests:write"},
+		{fiber.MethodDelete, "/api/requests/req-1", "requests:write"},
+		{fiber.MethodPost, "/api/requests/req-1/submit", "requests:write"},
+		{fiber.MethodGet, "/api/workflows/wf-1/steps", "workflows:read"},
+		{fiber.MethodPost, "/api/workflows", "workflows:write"},
+		{fiber.MethodGet, "/api/profile", "users:read"},
+		{fiber.MethodPut, "/api/profile/password", "users:write"},
+	}
+	for _, tt := range tests {
+		if got := requiredPermission(tt.method, tt.path); got != tt.want {
+			t.Errorf("requiredPermission(%s %s): expected %s, got %s", tt.method, tt.path, tt.want, got)
+		}
+	}
+}
+
+func TestAPIKeyAuthenticationErrors(t *testing.T) {
+	service := &fakeAPIKeyService{
+		keys: map[string]*apiKeyDomain.APIKey{"reader": {ID: "key-1", Permissions: apiKeyDomain.StringList{apiKeyDomain.PermissionRequestsRead}}},
+		errs: map[string]error{
+			"revoked": apiKeyUsecase.ErrAPIKeyRevoked,
+			"expired": apiKeyUsecase.ErrAPIKeyExpired,
+		},
+	}
+	app := newAPIKeyTestApp(service)
+
+	tests := []struct {
+		rawKey string
+		status int
+		code   string
+	}{
+		{"revoked", fiber.StatusUnauthorized, "API_KEY_REVOKED"},
+		{"expired", fiber.StatusUnauthorized, "API_KEY_EXPIRED"},
+		{"unknown", fiber.StatusUnauthorized, "INVALID_API_KEY"},
+		{"", fiber.StatusUnauthorized, "MISSING_API_KEY"},
+		{"reader", fiber.StatusOK, ""},
+	}
+	for _, tt := range tests {
+		status, body := doAPIKeyRequest(t, app, fiber.MethodGet, "/api/requests", tt.rawKey, nil)
+		if status != tt.status || !strings.Contains(body, tt.code) {
+			t.Errorf("Key %q: expected %d %s, got %d %s", tt.rawKey, tt.status, tt.code, status, body)
+		}
+	}
+
+	// The same key is accepted from "Authorization: ApiKey <key>"
+	if status, _ := doAPIKeyRequest(t, app, fiber.MethodGet, "/api/requests", "", map[string]string{"Authorization": "ApiKey reader"}); status != fiber.StatusOK {
+		t.Errorf("Expected the Authorization header to be accepted, got %d", status)
+	}
+}
+
+func TestAPIKeyOnBehalfOf(t *testing.T) {
+	requester := userDomain.NewUser("requester@example.com", "", "Requester", false, nil)
+	service := &fakeAPIKeyService{
+		keys: map[string]*apiKeyDomain.APIKey{
+			"integration": {ID: "key-1", Permissions: apiKeyDomain.StringList{apiKeyDomain.PermissionRequestsWrite, apiKeyDomain.PermissionRequestsCreateOnBehalf}},
+			"writer":      {ID: "key-2", Permissions: apiKeyDomain.StringList{apiKeyDomain.PermissionRequestsWrite}},
+		},
+		users: map[string]*userDomain.User{requester.ID: requester},
+	}
+	app := newAPIKeyTestApp(service)
+	onBehalf := map[string]string{OnBehalfOfHeader: requester.ID}
+
+	// Creating a request runs as the user
+	status, body := doAPIKeyRequest(t, app, fiber.MethodPost, "/api/requests", "integration", onBehalf)
+	if status != fiber.StatusOK || body != requester.ID {
+		t.Errorf("Expected the request created as %s, got %d %q", requester.ID, status, body)
+	}
+
+	// Without the header the key itself is the caller
+	if status, body := doAPIKeyRequest(t, app, fiber.MethodPost, "/api/requests", "integration", nil); status != fiber.StatusOK || body != "key-1" {
+		t.Errorf("Expected the key as the caller, got %d %q", status, body)
+	}
+
+	tests := []struct {
+		name   string
+		method string
+		path   string
+		rawKey string
+		userID string
+	}{
+		{"Approving on behalf", fiber.MethodPost, "/api/requests/req-1/approve", "integration", requester.ID},
+		{"Bulk approving on behalf", fiber.MethodPost, "/api/requests/bulk-approve", "integration", requester.ID},
+		{"Reading on behalf", fiber.MethodGet, "/api/requests", "integration", requester.ID},
+		{"Key without permission", fiber.MethodPost, "/api/requests", "writer", requester.ID},
+		{"Unknown user", fiber.MethodPost, "/api/requests", "integration", "missing"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			status, body := doAPIKeyRequest(t, app, tt.method, tt.path, tt.rawKey, map[string]string{OnBehalfOfHeader: tt.userID})
+			if status != fiber.StatusForbidden || !strings.Contains(body, "ON_BEHALF_NOT_ALLOWED") {
+				t.Errorf("Expected 403 ON_BEHALF_NOT_ALLOWED, got %d %s", status, body)
+			}
+		})
+	}
+}
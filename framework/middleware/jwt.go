@@ -7,24 +7,14 @@ import (
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/golang-jwt/jwt/v4"
-)
-
-// JWTClaims represents the JWT claims structure
-type JWTClaims struct {
-	UserID  string `json:"user_id"`
-	Email   string `json:"email"`
-	IsAdmin bool   `json:"is_admin"`
-	ActorID string `json:"actor_id"`
-	jwt.RegisteredClaims
-}
 
-// JWTConfig holds the JWT middleware configuration
-type JWTConfig struct {
-	Secret string
-}
+	tenantPorts "workflow-approval/package/tenant/ports"
+	"workflow-approval/utils/jwtauth"
+)
 
-// NewJWTMiddleware creates a new JWT middleware
-func NewJWTMiddleware(secret string) fiber.Handler {
+// NewJWTMiddleware creates a new JWT middleware; tenants validates the
+// X-Tenant-ID a super admin sends to act inside another tenant
+func NewJWTMiddleware(jwtManager *jwtauth.Manager, tenants tenantPorts.TenantService) fiber.Handler {
 	return func(c *fiber.Ctx) error {
 		// Get the Authorization header
 		authHeader := c.Get("Authorization")
@@ -60,51 +50,57 @@ func NewJWTMiddleware(secret string) fiber.Handler {
 			})
 		}
 
-		// Parse and validate the token
-		token, err := jwt.ParseWithClaims(tokenString, &JWTClaims{}, func(token *jwt.Token) (interface{}, error) {
-			// Validate the signing method
-			if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-				return nil, jwt.ErrSignatureInvalid
-			}
-			return []byte(secret), nil
-		})
-
-		// Handle different token validation errors
+		// Parse and validate the token (signature, kid, expiry, issuer, audience)
+		claims, err := jwtManager.Validate(tokenString)
 		if err != nil {
 			return handleTokenError(c, err)
 		}
 
-		// Extract claims
-		claims, ok := token.Claims.(*JWTClaims)
-		if !ok || !token.Valid {
-			return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
-				"success": false,
-				"data":    nil,
-				"error":   "Invalid token claims",
-				"code":    "INVALID_CLAIMS",
-			})
+		// Store user info in context locals
+		c.Locals("user_id", claims.UserID)
+		c.Locals("user_email", claims.Email)
+		c.Locals("is_admin", claims.IsAdmin)
+		c.Locals("actor_id", claims.ActorID)
+		c.Locals("actor_ids", claims.Actors())
+		c.Locals("auth_type", AuthTypeJWT)
+		c.Locals("is_super_admin", claims.SuperAdmin)
+		if claims.MFAAt != nil {
+			c.Locals("mfa_at", claims.MFAAt.Time)
 		}
+		setTenant(c, claims.Tenant())
 
-		// Check token expiration manually (additional check)
-		if claims.ExpiresAt != nil && claims.ExpiresAt.Before(time.Now()) {
-			return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+		if tenantID := c.Get(TenantHeader); tenantID != "" && tenantID != claims.Tenant() {
+			if err := switchTenant(c, tenants, tenantID); err != nil {
+				return err
+			}
+		}
+
+		// Accounts created by an admin must replace their initial password first
+		if claims.PasswordChangeRequired && !isPasswordChangeRoute(c) {
+			return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
 				"success": false,
 				"data":    nil,
-				"error":   "Token has expired",
-				"code":    "TOKEN_EXPIRED",
+				"error":   "You must change your password before continuing",
+				"code":    "PASSWORD_CHANGE_REQUIRED",
 			})
 		}
 
-		// Store user info in context locals
-		c.Locals("user_id", claims.UserID)
-		c.Locals("user_email", claims.Email)
-		c.Locals("is_admin", claims.IsAdmin)
-		c.Locals("actor_id", claims.ActorID)
-
 		return c.Next()
 	}
 }
 
+// isPasswordChangeRoute reports whether a route stays reachable while a password change is pending
+func isPasswordChangeRoute(c *fiber.Ctx) bool {
+	path := strings.TrimRight(c.Path(), "/")
+	switch {
+	case c.Method() == fiber.MethodGet && path == "/api/profile":
+		return true
+	case c.Method() == fiber.MethodPut && path == "/api/profile/password":
+		return true
+	}
+	return false
+}
+
 // handleTokenError provides detailed error messages for different token validation failures
 func handleTokenError(c *fiber.Ctx, err error) error {
 	var jwtErr *jwt.ValidationError
@@ -141,6 +137,34 @@ func handleTokenError(c *fiber.Ctx, err error) error {
 			})
 		}
 
+		// Issuer or audience not ours
+		if jwtErr.Errors&jwt.ValidationErrorIssuer != 0 {
+			return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+				"success": false,
+				"data":    nil,
+				"error":   "Token was issued by an unknown issuer",
+				"code":    "INVALID_ISSUER",
+			})
+		}
+		if jwtErr.Errors&jwt.ValidationErrorAudience != 0 {
+			return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+				"success": false,
+				"data":    nil,
+				"error":   "Token is not intended for this service",
+				"code":    "INVALID_AUDIENCE",
+			})
+		}
+
+		// Signing key (kid) not known, e.g. retired after rotation
+		if errors.Is(err, jwtauth.ErrUnknownSigningKey) {
+			return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+				"success": false,
+				"data":    nil,
+				"error":   "Token was signed with an unknown or retired key",
+				"code":    "UNKNOWN_SIGNING_KEY",
+			})
+		}
+
 		// Malformed token
 		if jwtErr.Errors&jwt.ValidationErrorMalformed != 0 {
 			return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
@@ -224,3 +248,19 @@ func GetActorIDFromContext(c *fiber.Ctx) string {
 	}
 	return ""
 }
+
+// GetActorIDsFromContext retrieves every actor the caller is a member of from Fiber context locals
+func GetActorIDsFromContext(c *fiber.Ctx) []string {
+	if actorIDs, ok := c.Locals("actor_ids").([]string); ok {
+		return actorIDs
+	}
+	return nil
+}
+
+// GetMFAAtFromContext retrieves when the caller last passed a second factor, nil if never
+func GetMFAAtFromContext(c *fiber.Ctx) *time.Time {
+	if mfaAt, ok := c.Locals("mfa_at").(time.Time); ok {
+		return &mfaAt
+	}
+	return nil
+}
@@ -0,0 +1,54 @@
+package middleware
+
+import (
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+
+	"workflow-approval/utils/metrics"
+)
+
+var (
+	httpRequestDuration = metrics.Default.NewHistogramVec("http_request_duration_seconds",
+		"Time taken to serve HTTP requests.", metrics.DurationBuckets, "method", "route", "status")
+	httpRequestSize = metrics.Default.NewHistogramVec("http_request_size_bytes",
+		"Size of HTTP request bodies.", metrics.SizeBuckets, "method", "route", "status")
+	httpResponseSize = metrics.Default.NewHistogramVec("http_response_size_bytes",
+		"Size of HTTP response bodies.", metrics.SizeBuckets, "method", "route", "status")
+)
+
+// Metrics records the duration, request and response size of every request,
+// labelled with the matched route pattern (e.g. /api/requests/:id) rather than
+// the path so the number of series stays bounded. Requests no route matched
+// carry the pattern of the last middleware they went through.
+// It must be registered before recover so panics are counted as 500s.
+func Metrics() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		start := time.Now()
+		err := c.Next()
+
+		// An error returned here only becomes a response in the error handler,
+		// after this middleware, so its status is derived the same way
+		status := c.Response().StatusCode()
+		if err != nil {
+			status = fiber.StatusInternalServerError
+			var fiberErr *fiber.Error
+			if errors.As(err, &fiberErr) {
+				status = fiberErr.Code
+			}
+		}
+
+		responseSize := len(c.Response().Body())
+		if n := c.Response().Header.ContentLength(); n > responseSize {
+			responseSize = n
+		}
+
+		labels := []string{c.Method(), c.Route().Path, strconv.Itoa(status)}
+		httpRequestDuration.With(labels...).Observe(time.Since(start).Seconds())
+		httpRequestSize.With(labels...).Observe(float64(len(c.Request().Body())))
+		httpResponseSize.With(labels...).Observe(float64(responseSize))
+		return err
+	}
+}
@@ -0,0 +1,29 @@
+package middleware
+
+import (
+	"github.com/gofiber/fiber/v2"
+
+	reqDomain "workflow-approval/package/request/domain"
+	reqPorts "workflow-approval/package/request/ports"
+)
+
+// CanSeeRequest applies a request's access rules to the caller: API keys
+// restricted to workflows only reach requests of those workflows, and a draft
+// is private to its requester until they submit it.
+func CanSeeRequest(c *fiber.Ctx, request *reqDomain.Request) bool {
+	if key := GetAPIKeyFromContext(c); key != nil && !key.AllowsWorkflow(request.WorkflowID) {
+		return false
+	}
+	return !request.IsDraft() || request.RequesterID == GetUserIDFromContext(c)
+}
+
+// RequestVisible loads a request to check CanSeeRequest, for routes acting on
+// a request or what belongs to it (history, attachments, comments). Missing
+// requests are reported as visible so the service returns its usual error.
+func RequestVisible(c *fiber.Ctx, requests reqPorts.RequestService, requestID string) bool {
+	request, err := requests.GetRequest(c.Context(), requestID)
+	if err != nil {
+		return true
+	}
+	return CanSeeRequest(c, request)
+}
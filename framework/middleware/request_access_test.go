@@ -0,0 +1,57 @@
+package middleware
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+
+	apiKeyDomain "workflow-approval/package/api_key/domain"
+	reqDomain "workflow-approval/package/request/domain"
+)
+
+func TestCanSeeRequest(t *testing.T) {
+	pending := &reqDomain.Request{ID: "req-1", WorkflowID: "wf-1", RequesterID: "user-1", Status: reqDomain.StatusPending}
+	draft := &reqDomain.Request{ID: "req-2", WorkflowID: "wf-1", RequesterID: "user-1", Status: reqDomain.StatusDraft}
+	scoped := &apiKeyDomain.APIKey{WorkflowIDs: []string{"wf-2"}}
+
+	tests := []struct {
+		name    string
+		userID  string
+		key     *apiKeyDomain.APIKey
+		request *reqDomain.Request
+		want    bool
+	}{
+		{"Submitted request", "user-2", nil, pending, true},
+		{"Own draft", "user-1", nil, draft, true},
+		{"Someone else's draft", "user-2", nil, draft, false},
+		{"API key outside its workflows", "", scoped, pending, false},
+		{"API key within its workflows", "", &apiKeyDomain.APIKey{WorkflowIDs: []string{"wf-1"}}, pending, true},
+		{"API key acting for the draft's requester", "user-1", &apiKeyDomain.APIKey{}, draft, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := fiber.New()
+			app.Get("/", func(c *fiber.Ctx) error {
+				if tt.userID != "" {
+					c.Locals("user_id", tt.userID)
+				}
+				if tt.key != nil {
+					c.Locals("api_key", tt.key)
+				}
+				if CanSeeRequest(c, tt.request) {
+					return c.SendStatus(fiber.StatusOK)
+				}
+				return c.SendStatus(fiber.StatusNotFound)
+			})
+
+			resp, err := app.Test(httptest.NewRequest("GET", "/", nil))
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			if got := resp.StatusCode == fiber.StatusOK; got != tt.want {
+				t.Errorf("Expected visible=%v, got %v", tt.want, got)
+			}
+		})
+	}
+}
@@ -1,6 +1,9 @@
 package router
 
 import (
+	"crypto/subtle"
+	"log"
+	"strings"
 	"time"
 
 	"github.com/gofiber/fiber/v2"
@@ -10,22 +13,58 @@ import (
 
 	"workflow-approval/framework/middleware"
 	actorHandler "workflow-approval/package/actor/handler"
+	apiKeyHandler "workflow-approval/package/api_key/handler"
+	apiKeyPorts "workflow-approval/package/api_key/ports"
+	attachmentHandler "workflow-approval/package/attachment/handler"
 	authHandler "workflow-approval/package/auth/handler"
+	commentHandler "workflow-approval/package/comment/handler"
+	exchangeRateHandler "workflow-approval/package/exchange_rate/handler"
+	exportHandler "workflow-approval/package/export/handler"
+	importHandler "workflow-approval/package/import/handler"
+	notificationHandler "workflow-approval/package/notification/handler"
+	organizationHandler "workflow-approval/package/organization/handler"
+	reportHandler "workflow-approval/package/report/handler"
 	requestHandler "workflow-approval/package/request/handler"
+	tenantHandler "workflow-approval/package/tenant/handler"
+	tenantPorts "workflow-approval/package/tenant/ports"
 	userHandler "workflow-approval/package/user/handler"
 	workflowHandler "workflow-approval/package/workflow/handler"
+	workflowDefinitionHandler "workflow-approval/package/workflow_definition/handler"
 	workflowStepHandler "workflow-approval/package/workflow_step/handler"
+	workflowValidationHandler "workflow-approval/package/workflow_validation/handler"
+	"workflow-approval/utils/jwtauth"
+	"workflow-approval/utils/metrics"
 )
 
 // Config holds the router configuration
 type Config struct {
-	JWTSecret           string
+	JWTManager          *jwtauth.Manager
 	AuthHandler         *authHandler.AuthHandler
+	OIDCHandler         *authHandler.OIDCHandler // nil when OIDC login is disabled
+	TwoFactorHandler    *authHandler.TwoFactorHandler
+	AccountHandler      *authHandler.AccountHandler
 	UserHandler         *userHandler.UserHandler
 	WorkflowHandler     *workflowHandler.WorkflowHandler
 	WorkflowStepHandler *workflowStepHandler.WorkflowStepHandler
+	DefinitionHandler   *workflowDefinitionHandler.DefinitionHandler
+	ValidationHandler   *workflowValidationHandler.ValidationHandler
 	RequestHandler      *requestHandler.RequestHandler
 	ActorHandler        *actorHandler.ActorHandler
+	APIKeyHandler       *apiKeyHandler.APIKeyHandler
+	OrganizationHandler *organizationHandler.OrganizationHandler
+	TenantHandler       *tenantHandler.TenantHandler
+	AttachmentHandler   *attachmentHandler.AttachmentHandler
+	CommentHandler      *commentHandler.CommentHandler
+	NotificationHandler *notificationHandler.NotificationHandler
+	ExchangeRateHandler *exchangeRateHandler.ExchangeRateHandler
+	ExportHandler       *exportHandler.ExportHandler
+	ImportHandler       *importHandler.ImportHandler
+	ReportHandler       *reportHandler.ReportHandler
+	TenantService       tenantPorts.TenantService
+	APIKeyService       apiKeyPorts.APIKeyService
+	BodyLimit           int               // Maximum request body in bytes; 0 keeps Fiber's 4MB default
+	Metrics             *metrics.Registry // nil disables GET /metrics
+	MetricsToken        string            // Bearer token required on /metrics; empty leaves it open
 }
 
 // Setup configures the Fiber application with all routes
@@ -35,11 +74,15 @@ func Setup(cfg Config) *fiber.App {
 		ReadTimeout:  30 * time.Second,
 		WriteTimeout: 30 * time.Second,
 		ErrorHandler: customErrorHandler,
+		BodyLimit:    cfg.BodyLimit,
 	})
 
 	// =========================================
 	// Global Middleware
 	// =========================================
+	if cfg.Metrics != nil {
+		app.Use(middleware.Metrics())
+	}
 	app.Use(recover.New())
 	app.Use(logger.New(logger.Config{
 		Format: "[${time}] ${status} - ${latency} ${method} ${path}\n",
@@ -47,7 +90,7 @@ func Setup(cfg Config) *fiber.App {
 	app.Use(cors.New(cors.Config{
 		AllowOrigins: "*",
 		AllowMethods: "GET,POST,PUT,DELETE,OPTIONS",
-		AllowHeaders: "Origin,Content-Type,Accept,Authorization",
+		AllowHeaders: "Origin,Content-Type,Accept,Authorization,X-API-Key,X-On-Behalf-Of,X-Tenant-ID",
 	}))
 
 	// =========================================
@@ -61,17 +104,36 @@ func Setup(cfg Config) *fiber.App {
 		})
 	})
 
+	// =========================================
+	// Prometheus Metrics - open unless a scrape token is configured
+	// =========================================
+	if cfg.Metrics != nil {
+		app.Get("/metrics", metricsHandler(cfg.Metrics, cfg.MetricsToken))
+	}
+
+	// =========================================
+	// JWKS (Public) - keys for verifying tokens issued by this service
+	// =========================================
+	app.Get("/.well-known/jwks.json", func(c *fiber.Ctx) error {
+		c.Set(fiber.HeaderCacheControl, "public, max-age=300")
+		return c.JSON(cfg.JWTManager.JWKS())
+	})
+
 	// =========================================
 	// Authentication Routes (Public - No JWT Required)
 	// =========================================
 	auth := app.Group("/auth")
 	cfg.AuthHandler.Routes(auth)
+	cfg.AccountHandler.Routes(auth)
+	if cfg.OIDCHandler != nil {
+		cfg.OIDCHandler.Routes(auth.Group("/oidc"))
+	}
 
 	// =========================================
-	// Protected Routes (JWT Authentication Required)
+	// Protected Routes (JWT or API Key Authentication Required)
 	// =========================================
-	jwtMiddleware := middleware.NewJWTMiddleware(cfg.JWTSecret)
-	api := app.Group("/api", jwtMiddleware)
+	authMiddleware := middleware.NewAuthMiddleware(cfg.JWTManager, cfg.APIKeyService, cfg.TenantService)
+	api := app.Group("/api", authMiddleware)
 
 	// =========================================
 	// Actor Routes
@@ -89,20 +151,125 @@ func Setup(cfg Config) *fiber.App {
 	workflowSteps := workflows.Group("/:id/steps")
 	cfg.WorkflowStepHandler.Routes(workflowSteps)
 
+	// Dry-run routing of a hypothetical request (Admin Only)
+	cfg.RequestHandler.SimulationRoutes(workflows.Group("/:id/simulate", middleware.RequireAdmin()))
+
+	// Issues keeping a workflow from taking requests (Admin Only)
+	cfg.ValidationHandler.Routes(workflows.Group("/:id/validation", middleware.RequireAdmin()))
+
+	// Workflow definitions as code (Admin Only) - export, plan and apply
+	definitions := api.Group("/workflow-definitions", middleware.RequireAdmin())
+	cfg.DefinitionHandler.Routes(definitions)
+
 	// =========================================
 	// Request Routes
 	// =========================================
 	requests := api.Group("/requests")
+
+	// Exports (CSV / XLSX), mounted before /:id so "export" isn't taken for a request ID
+	cfg.ExportHandler.Routes(requests.Group("/export"))
+
 	cfg.RequestHandler.Routes(requests)
 
+	// Nested Request Attachments
+	requestAttachments := requests.Group("/:id/attachments")
+	cfg.AttachmentHandler.Routes(requestAttachments)
+
+	// Nested Request Comments and the activity timeline
+	requestComments := requests.Group("/:id/comments")
+	cfg.CommentHandler.Routes(requestComments)
+	cfg.CommentHandler.TimelineRoutes(requests)
+
+	// =========================================
+	// Notification Routes (the caller's own notifications)
+	// =========================================
+	notifications := api.Group("/notifications")
+	cfg.NotificationHandler.Routes(notifications)
+
+	// =========================================
+	// Exchange Rate Routes - readable by everyone, maintained by admins
+	// =========================================
+	exchangeRates := api.Group("/exchange-rates")
+	cfg.ExchangeRateHandler.Routes(exchangeRates)
+	cfg.ExchangeRateHandler.AdminRoutes(exchangeRates.Group("", middleware.RequireAdmin()))
+
 	// =========================================
 	// User Routes
 	// =========================================
 	cfg.UserHandler.Routes(api)
 
+	// User management (Admin only)
+	users := api.Group("/users", middleware.RequireAdmin())
+	cfg.UserHandler.AdminRoutes(users)
+
+	// Password change and email verification for the signed-in user
+	cfg.AccountHandler.ProfileRoutes(api.Group("/profile"))
+
+	// Two-factor self-service (TOTP enrollment, recovery codes, step-up)
+	cfg.TwoFactorHandler.Routes(api.Group("/profile/2fa"))
+
+	// =========================================
+	// Authentication Management Routes (Admin Only)
+	// =========================================
+	authAdmin := api.Group("/auth", middleware.RequireAdmin())
+	cfg.AuthHandler.AdminRoutes(authAdmin)
+
+	// =========================================
+	// API Key Routes (Admin Only)
+	// =========================================
+	apiKeys := api.Group("/api-keys", middleware.RequireAdmin())
+	cfg.APIKeyHandler.Routes(apiKeys)
+
+	// =========================================
+	// Org Chart Routes (Admin Only) - departments, managers, approver lookups
+	// =========================================
+	org := api.Group("/org", middleware.RequireAdmin())
+	cfg.OrganizationHandler.AdminRoutes(org)
+
+	// =========================================
+	// Import Routes (Admin Only) - bulk CSV onboarding
+	// =========================================
+	imports := api.Group("/imports", middleware.RequireAdmin())
+	cfg.ImportHandler.Routes(imports)
+
+	// =========================================
+	// Report Routes (Admin Only) - throughput, level times, actor rates, aging, amount bands
+	// =========================================
+	reports := api.Group("/reports", middleware.RequireAdmin())
+	cfg.ReportHandler.Routes(reports)
+
+	// =========================================
+	// Tenant Routes (Super Admin Only) - tenant management, cross-tenant views
+	// =========================================
+	tenants := api.Group("/tenants", middleware.RequireSuperAdmin())
+	cfg.TenantHandler.Routes(tenants)
+
 	return app
 }
 
+// metricsHandler writes the registry in the Prometheus text format. A failing
+// scrape hook is logged but the other metrics are still served.
+func metricsHandler(registry *metrics.Registry, token string) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		if token != "" {
+			given := strings.TrimPrefix(c.Get(fiber.HeaderAuthorization), "Bearer ")
+			if subtle.ConstantTimeCompare([]byte(given), []byte(token)) != 1 {
+				return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+					"success": false,
+					"data":    nil,
+					"error":   "Invalid metrics token",
+				})
+			}
+		}
+
+		c.Set(fiber.HeaderContentType, metrics.ContentType)
+		if err := registry.Write(c.Context(), c.Response().BodyWriter()); err != nil {
+			log.Printf("Warning: metrics scrape hook failed: %v", err)
+		}
+		return nil
+	}
+}
+
 // customErrorHandler handles errors globally
 func customErrorHandler(c *fiber.Ctx, err error) error {
 	code := fiber.StatusInternalServerError
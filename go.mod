@@ -1,6 +1,6 @@
 module workflow-approval
 
-go 1.19
+go 1.23
 
 require (
 	github.com/gofiber/fiber/v2 v2.49.0
@@ -1,6 +1,7 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"os"
@@ -17,24 +18,72 @@ import (
 	actorHandler "workflow-approval/package/actor/handler"
 	actorRepo "workflow-approval/package/actor/repository"
 	actorUsecase "workflow-approval/package/actor/usecase"
+	apiKeyHandler "workflow-approval/package/api_key/handler"
+	apiKeyRepo "workflow-approval/package/api_key/repository"
+	apiKeyUsecase "workflow-approval/package/api_key/usecase"
 	approvalHistoryRepo "workflow-approval/package/approval_history/repository"
 	approvalHistoryUsecase "workflow-approval/package/approval_history/usecase"
+	attachmentDomain "workflow-approval/package/attachment/domain"
+	attachmentHandler "workflow-approval/package/attachment/handler"
+	attachmentRepo "workflow-approval/package/attachment/repository"
+	attachmentUsecase "workflow-approval/package/attachment/usecase"
+	authDomain "workflow-approval/package/auth/domain"
 	authHandler "workflow-approval/package/auth/handler"
 	authRepo "workflow-approval/package/auth/repository"
 	authUsecase "workflow-approval/package/auth/usecase"
+	commentDomain "workflow-approval/package/comment/domain"
+	commentHandler "workflow-approval/package/comment/handler"
+	commentRepo "workflow-approval/package/comment/repository"
+	commentUsecase "workflow-approval/package/comment/usecase"
+	exchangeRateHandler "workflow-approval/package/exchange_rate/handler"
+	exchangeRateRepo "workflow-approval/package/exchange_rate/repository"
+	exchangeRateUsecase "workflow-approval/package/exchange_rate/usecase"
+	exportHandler "workflow-approval/package/export/handler"
+	exportRepo "workflow-approval/package/export/repository"
+	exportUsecase "workflow-approval/package/export/usecase"
+	importHandler "workflow-approval/package/import/handler"
+	importPorts "workflow-approval/package/import/ports"
+	importRepo "workflow-approval/package/import/repository"
+	importUsecase "workflow-approval/package/import/usecase"
+	notificationHandler "workflow-approval/package/notification/handler"
+	notificationRepo "workflow-approval/package/notification/repository"
+	notificationUsecase "workflow-approval/package/notification/usecase"
+	orgHandler "workflow-approval/package/organization/handler"
+	orgRepo "workflow-approval/package/organization/repository"
+	orgUsecase "workflow-approval/package/organization/usecase"
+	reportHandler "workflow-approval/package/report/handler"
+	reportRepo "workflow-approval/package/report/repository"
+	reportUsecase "workflow-approval/package/report/usecase"
+	reqDomain "workflow-approval/package/request/domain"
 	reqHandler "workflow-approval/package/request/handler"
 	reqRepo "workflow-approval/package/request/repository"
 	reqUsecase "workflow-approval/package/request/usecase"
+	tenantHandler "workflow-approval/package/tenant/handler"
+	tenantRepo "workflow-approval/package/tenant/repository"
+	tenantUsecase "workflow-approval/package/tenant/usecase"
 	userHandler "workflow-approval/package/user/handler"
 	userRepo "workflow-approval/package/user/repository"
 	userUsecase "workflow-approval/package/user/usecase"
 	wfHandler "workflow-approval/package/workflow/handler"
 	wfRepo "workflow-approval/package/workflow/repository"
 	wfUsecase "workflow-approval/package/workflow/usecase"
+	definitionHandler "workflow-approval/package/workflow_definition/handler"
+	definitionPorts "workflow-approval/package/workflow_definition/ports"
+	definitionRepo "workflow-approval/package/workflow_definition/repository"
+	definitionUsecase "workflow-approval/package/workflow_definition/usecase"
 	stepHandler "workflow-approval/package/workflow_step/handler"
 	stepRepo "workflow-approval/package/workflow_step/repository"
 	stepUsecase "workflow-approval/package/workflow_step/usecase"
+	validationHandler "workflow-approval/package/workflow_validation/handler"
+	validationUsecase "workflow-approval/package/workflow_validation/usecase"
+	"workflow-approval/utils/jwtauth"
 	"workflow-approval/utils/jwthelper"
+	"workflow-approval/utils/mailer"
+	"workflow-approval/utils/metrics"
+	"workflow-approval/utils/money"
+	"workflow-approval/utils/oidc"
+	"workflow-approval/utils/storage"
+	"workflow-approval/utils/tenancy"
 )
 
 func main() {
@@ -50,8 +99,14 @@ func main() {
 		log.Fatalf("Failed to connect to database: %v", err)
 	}
 
+	// Step thresholds are expressed in the base currency; other currencies convert into it
+	baseCurrency, err := money.ParseCurrency(cfg.Requests.BaseCurrency)
+	if err != nil {
+		log.Fatalf("Invalid requests.base_currency: %v", err)
+	}
+
 	// Run migrations using raw SQL for MariaDB compatibility
-	if err := runMigrations(db); err != nil {
+	if err := runMigrations(db, baseCurrency); err != nil {
 		log.Fatalf("Failed to run migrations: %v", err)
 	}
 
@@ -62,38 +117,290 @@ func main() {
 	requestRepository := reqRepo.NewRequestRepository(db)
 	actorRepository := actorRepo.NewActorRepository(db)
 	approvalHistoryRepository := approvalHistoryRepo.NewApprovalHistoryRepository(db)
+	apiKeyRepository := apiKeyRepo.NewAPIKeyRepository(db)
+	tenantRepository := tenantRepo.NewTenantRepository(db)
+	departmentRepository := orgRepo.NewDepartmentRepository(db)
+	approverLookupRepository := orgRepo.NewApproverLookupRepository(db)
+	exchangeRateRepository := exchangeRateRepo.NewExchangeRateRepository(db)
 
 	// Initialize services
-	userService := userUsecase.NewUserService(userRepository, actorRepository)
 	workflowService := wfUsecase.NewWorkflowService(workflowRepository)
 	workflowStepService := stepUsecase.NewWorkflowStepService(workflowStepRepository, actorRepository, workflowRepository)
 	approvalHistoryService := approvalHistoryUsecase.NewApprovalHistoryService(approvalHistoryRepository)
-	requestService := reqUsecase.NewRequestService(requestRepository, workflowRepository, workflowStepRepository, approvalHistoryRepository)
+	approverResolver := orgUsecase.NewApproverResolver(userRepository, departmentRepository, approverLookupRepository)
+	exchangeRateService := exchangeRateUsecase.NewExchangeRateService(exchangeRateRepository, baseCurrency)
+	workflowValidator := validationUsecase.NewValidatorService(workflowRepository, workflowStepRepository, actorRepository, userRepository)
+	organizationService := orgUsecase.NewOrganizationService(departmentRepository, approverLookupRepository, userRepository)
+	tenantService := tenantUsecase.NewTenantService(tenantRepository)
 	actorService := actorUsecase.NewActorService(actorRepository)
+	apiKeyService := apiKeyUsecase.NewAPIKeyService(apiKeyRepository, userRepository, workflowRepository)
+
+	// Initialize attachment storage
+	fileStorage, err := storage.New(storage.Config{
+		Driver:   cfg.Storage.Driver,
+		LocalDir: cfg.Storage.LocalDir,
+		S3: storage.S3Config{
+			Endpoint:  cfg.Storage.S3.Endpoint,
+			Region:    cfg.Storage.S3.Region,
+			Bucket:    cfg.Storage.S3.Bucket,
+			AccessKey: cfg.Storage.S3.AccessKey,
+			SecretKey: cfg.Storage.S3.SecretKey,
+			PathStyle: cfg.Storage.S3.PathStyle,
+		},
+	})
+	if err != nil {
+		log.Fatalf("Invalid storage configuration: %v", err)
+	}
+	attachmentPolicy := attachmentDomain.DefaultAttachmentPolicy()
+	if cfg.Attachments.MaxSizeMB > 0 {
+		attachmentPolicy.MaxSize = int64(cfg.Attachments.MaxSizeMB) << 20
+	}
+	if len(cfg.Attachments.AllowedTypes) > 0 {
+		attachmentPolicy.AllowedTypes = cfg.Attachments.AllowedTypes
+	}
+	attachmentRepository := attachmentRepo.NewAttachmentRepository(db)
+	attachmentService := attachmentUsecase.NewAttachmentService(attachmentRepository, requestRepository, fileStorage, attachmentPolicy)
+
+	// Initialize comments; @mentions become in-app notifications
+	notificationRepository := notificationRepo.NewNotificationRepository(db)
+	notificationService := notificationUsecase.NewNotificationService(notificationRepository)
+	commentPolicy := commentDomain.DefaultCommentPolicy()
+	if cfg.Comments.EditWindow > 0 {
+		commentPolicy.EditWindow = time.Duration(cfg.Comments.EditWindow) * time.Minute
+	}
+	if cfg.Comments.MaxLength > 0 {
+		commentPolicy.MaxLength = cfg.Comments.MaxLength
+	}
+	commentRepository := commentRepo.NewCommentRepository(db)
+	commentService := commentUsecase.NewCommentService(commentRepository, requestRepository, approvalHistoryRepository, userRepository, notificationService, commentPolicy)
+
+	// Deleted requests take their attachments and comments with them
+	requestService := reqUsecase.NewRequestService(requestRepository, workflowRepository, workflowStepRepository, approvalHistoryRepository, reqDomain.StepUpPolicy{
+		Amount: money.FromFloat(cfg.TwoFactor.StepUpAmount),
+		MaxAge: time.Duration(cfg.TwoFactor.StepUpMaxAge) * time.Minute,
+	}, approverResolver, exchangeRateService, workflowValidator, attachmentService, commentService)
+
+	// Stale drafts are deleted with their attachments and comments
+	draftJanitor := reqUsecase.NewDraftJanitor(requestRepository,
+		time.Duration(cfg.Requests.DraftTTLDays)*24*time.Hour, time.Hour,
+		attachmentService, commentService)
+	draftJanitor.Start(tenancy.WithAllTenants(context.Background()))
+	defer draftJanitor.Stop()
+
+	// Request exports; large ones run as background jobs whose files are kept in storage
+	exportJobRepository := exportRepo.NewExportJobRepository(db)
+	exportService := exportUsecase.NewExportService(exportJobRepository, requestRepository, approvalHistoryRepository, userRepository, workflowRepository, fileStorage)
+	exportWorker := exportUsecase.NewExportWorker(exportJobRepository, exportService, fileStorage,
+		time.Duration(cfg.Exports.RetentionHours)*time.Hour, time.Duration(cfg.Exports.PollInterval)*time.Second,
+		time.Duration(cfg.Exports.RunTimeout)*time.Minute)
+	exportWorker.Start(tenancy.WithAllTenants(context.Background()))
+	defer exportWorker.Stop()
+
+	// Approval reports, aggregated from requests and approval history
+	reportService := reportUsecase.NewReportService(reportRepo.NewReportRepository(db))
 
 	// Initialize auth services
-	jwtExpiry := time.Duration(cfg.JWT.Expiration) * time.Hour
-	jwtHelper := jwthelper.NewJWTHelper(cfg.JWT.Secret, jwtExpiry)
+	if err := cfg.JWT.Validate(cfg.App.Env); err != nil {
+		log.Fatalf("Invalid JWT configuration: %v", err)
+	}
+	jwtManager, err := jwtauth.NewManager(jwtauth.Config{
+		Algorithm:        cfg.JWT.Algorithm,
+		Secret:           cfg.JWT.Secret,
+		Issuer:           cfg.JWT.Issuer,
+		Audience:         cfg.JWT.Audience,
+		Expiration:       time.Duration(cfg.JWT.Expiration) * time.Hour,
+		KeysDir:          cfg.JWT.KeysDir,
+		RotationInterval: cfg.JWT.GetRotationInterval(),
+	})
+	if err != nil {
+		log.Fatalf("Failed to initialize JWT signing keys: %v", err)
+	}
+	jwtManager.StartRotation()
+	defer jwtManager.Stop()
+	jwtHelper := jwthelper.NewJWTHelper(jwtManager)
 	authRepository := authRepo.NewAuthRepository(userRepository)
-	authService := authUsecase.NewAuthService(authRepository, jwtHelper)
+	loginAttemptRepository := authRepo.NewLoginAttemptRepository(db)
+	authAuditLogRepository := authRepo.NewAuthAuditLogRepository(db)
+	loginPolicy := authDomain.LoginProtectionPolicy{
+		MaxAccountFailures: cfg.Login.MaxAccountFailures,
+		MaxIPFailures:      cfg.Login.MaxIPFailures,
+		FailureWindow:      time.Duration(cfg.Login.FailureWindow) * time.Minute,
+		LockoutDuration:    time.Duration(cfg.Login.LockoutDuration) * time.Minute,
+		BaseDelay:          time.Duration(cfg.Login.BaseDelay) * time.Second,
+		MaxDelay:           time.Duration(cfg.Login.MaxDelay) * time.Second,
+	}
+	if loginPolicy.FailureWindow <= 0 || loginPolicy.LockoutDuration <= 0 {
+		loginPolicy = authDomain.DefaultLoginProtectionPolicy()
+	}
+	twoFactorPolicy := authDomain.TwoFactorPolicy{
+		Issuer:           cfg.TwoFactor.Issuer,
+		RequireForAdmins: cfg.TwoFactor.RequireForAdmins,
+		ChallengeTTL:     time.Duration(cfg.TwoFactor.ChallengeTTL) * time.Minute,
+	}
+	if twoFactorPolicy.Issuer == "" {
+		twoFactorPolicy.Issuer = authDomain.DefaultTwoFactorPolicy().Issuer
+	}
+	if twoFactorPolicy.ChallengeTTL <= 0 {
+		twoFactorPolicy.ChallengeTTL = authDomain.DefaultTwoFactorPolicy().ChallengeTTL
+	}
+	twoFactorChallengeRepository := authRepo.NewTwoFactorChallengeRepository(db)
+	authService := authUsecase.NewAuthService(authRepository, loginAttemptRepository, authAuditLogRepository, twoFactorChallengeRepository, loginPolicy, twoFactorPolicy, jwtHelper)
+	twoFactorService := authUsecase.NewTwoFactorService(authRepository, loginAttemptRepository, authAuditLogRepository, loginPolicy, twoFactorPolicy)
+
+	// Initialize password reset and email verification
+	mail, err := mailer.New(mailer.Config{
+		Driver:   cfg.Mail.Driver,
+		Host:     cfg.Mail.Host,
+		Port:     cfg.Mail.Port,
+		Username: cfg.Mail.Username,
+		Password: cfg.Mail.Password,
+		From:     cfg.Mail.From,
+	})
+	if err != nil {
+		log.Fatalf("Invalid mail configuration: %v", err)
+	}
+	accountPolicy := authDomain.AccountPolicy{
+		BaseURL:              cfg.Account.BaseURL,
+		ResetTokenTTL:        time.Duration(cfg.Account.ResetTokenTTL) * time.Minute,
+		VerificationTokenTTL: time.Duration(cfg.Account.VerificationTokenTTL) * time.Hour,
+		ResendCooldown:       time.Duration(cfg.Account.ResendCooldown) * time.Second,
+	}
+	if accountPolicy.BaseURL == "" {
+		accountPolicy.BaseURL = authDomain.DefaultAccountPolicy().BaseURL
+	}
+	if accountPolicy.ResetTokenTTL <= 0 {
+		accountPolicy.ResetTokenTTL = authDomain.DefaultAccountPolicy().ResetTokenTTL
+	}
+	if accountPolicy.VerificationTokenTTL <= 0 {
+		accountPolicy.VerificationTokenTTL = authDomain.DefaultAccountPolicy().VerificationTokenTTL
+	}
+	userTokenRepository := authRepo.NewUserTokenRepository(db)
+	accountService := authUsecase.NewAccountService(authRepository, userTokenRepository, loginAttemptRepository, authAuditLogRepository, mail, accountPolicy)
+	userService := userUsecase.NewUserService(userRepository, actorRepository, workflowStepRepository, accountService)
+
+	// Bulk imports create records through the same services as the API, bound to one transaction
+	importTransactor := importRepo.NewTransactor(db, func(tx *gorm.DB) importPorts.Services {
+		actors := actorRepo.NewActorRepository(tx)
+		users := userRepo.NewUserRepository(tx)
+		workflows := wfRepo.NewWorkflowRepository(tx)
+		steps := stepRepo.NewWorkflowStepRepository(tx)
+		return importPorts.Services{
+			Actors:       actorUsecase.NewActorService(actors),
+			Users:        userUsecase.NewUserService(users, actors, steps, nil),
+			Workflows:    wfUsecase.NewWorkflowService(workflows),
+			Steps:        stepUsecase.NewWorkflowStepService(steps, actors, workflows),
+			ActorRepo:    actors,
+			UserRepo:     users,
+			WorkflowRepo: workflows,
+			StepRepo:     steps,
+			RequestRepo:  reqRepo.NewRequestRepository(tx),
+			HistoryRepo:  approvalHistoryRepo.NewApprovalHistoryRepository(tx),
+		}
+	})
+	importService := importUsecase.NewImportService(importTransactor, exchangeRateService, accountService)
+
+	// Workflow definitions are applied through the workflow and step services in one transaction
+	definitionTransactor := definitionRepo.NewTransactor(db, func(tx *gorm.DB) definitionPorts.Services {
+		actors := actorRepo.NewActorRepository(tx)
+		workflows := wfRepo.NewWorkflowRepository(tx)
+		steps := stepRepo.NewWorkflowStepRepository(tx)
+		return definitionPorts.Services{
+			Workflows:    wfUsecase.NewWorkflowService(workflows),
+			Steps:        stepUsecase.NewWorkflowStepService(steps, actors, workflows),
+			ActorRepo:    actors,
+			WorkflowRepo: workflows,
+			StepRepo:     steps,
+		}
+	})
+	definitionService := definitionUsecase.NewDefinitionService(definitionTransactor)
+
+	// Initialize OIDC login (optional)
+	var oidcHTTPHandler *authHandler.OIDCHandler
+	if cfg.OIDC.Enabled {
+		oidcClient := oidc.NewClient(oidc.Config{
+			IssuerURL:    cfg.OIDC.IssuerURL,
+			ClientID:     cfg.OIDC.ClientID,
+			ClientSecret: cfg.OIDC.ClientSecret,
+			RedirectURL:  cfg.OIDC.RedirectURL,
+			Scopes:       cfg.OIDC.Scopes,
+		}, nil)
+		claimRules := make([]authDomain.ClaimMappingRule, 0, len(cfg.OIDC.ClaimMappings))
+		for _, m := range cfg.OIDC.ClaimMappings {
+			claimRules = append(claimRules, authDomain.ClaimMappingRule{
+				Claim:     m.Claim,
+				Value:     m.Value,
+				ActorCode: m.ActorCode,
+				IsAdmin:   m.IsAdmin,
+			})
+		}
+		oidcStateRepository := authRepo.NewOIDCStateRepository(db)
+		oidcService := authUsecase.NewOIDCService(oidcClient, authRepository, actorRepository, authAuditLogRepository, oidcStateRepository, authService, claimRules, cfg.OIDC.DefaultActorCode)
+		oidcHTTPHandler = authHandler.NewOIDCHandler(oidcService, jwtHelper)
+	}
 
 	// Initialize handlers
 	authHTTPHandler := authHandler.NewAuthHandler(authService, jwtHelper)
+	twoFactorHTTPHandler := authHandler.NewTwoFactorHandler(twoFactorService, jwtHelper)
+	accountHTTPHandler := authHandler.NewAccountHandler(accountService, jwtHelper)
 	userHTTPHandler := userHandler.NewUserHandler(userService)
 	workflowHTTPHandler := wfHandler.NewWorkflowHandler(workflowService)
 	workflowStepHTTPHandler := stepHandler.NewWorkflowStepHandler(workflowStepService)
+	definitionHTTPHandler := definitionHandler.NewDefinitionHandler(definitionService)
+	validationHTTPHandler := validationHandler.NewValidationHandler(workflowValidator)
 	requestHTTPHandler := reqHandler.NewRequestHandler(requestService, approvalHistoryService)
 	actorHTTPHandler := actorHandler.NewActorHandler(actorService)
+	apiKeyHTTPHandler := apiKeyHandler.NewAPIKeyHandler(apiKeyService)
+	organizationHTTPHandler := orgHandler.NewOrganizationHandler(organizationService)
+	tenantHTTPHandler := tenantHandler.NewTenantHandler(tenantService, userService, requestService)
+	attachmentHTTPHandler := attachmentHandler.NewAttachmentHandler(attachmentService, requestService)
+	commentHTTPHandler := commentHandler.NewCommentHandler(commentService, requestService)
+	notificationHTTPHandler := notificationHandler.NewNotificationHandler(notificationService)
+	exchangeRateHTTPHandler := exchangeRateHandler.NewExchangeRateHandler(exchangeRateService)
+	exportHTTPHandler := exportHandler.NewExportHandler(exportService, cfg.Exports.MaxSyncRows)
+	importHTTPHandler := importHandler.NewImportHandler(importService)
+	reportHTTPHandler := reportHandler.NewReportHandler(reportService)
+
+	// Prometheus metrics; the pool statistics and pending requests are read on each scrape
+	var metricsRegistry *metrics.Registry
+	if cfg.Metrics.Enabled {
+		sqlDB, err := db.DB()
+		if err != nil {
+			log.Fatalf("Failed to get sql.DB for metrics: %v", err)
+		}
+		metricsRegistry = metrics.Default
+		metricsRegistry.RegisterDBStats(sqlDB)
+		metricsRegistry.OnScrape(reqUsecase.PendingRequestsHook(requestRepository))
+	}
 
 	// Setup router
 	app := router.Setup(router.Config{
-		JWTSecret:           cfg.JWT.Secret,
+		JWTManager:          jwtManager,
 		AuthHandler:         authHTTPHandler,
+		OIDCHandler:         oidcHTTPHandler,
+		TwoFactorHandler:    twoFactorHTTPHandler,
+		AccountHandler:      accountHTTPHandler,
 		UserHandler:         userHTTPHandler,
 		WorkflowHandler:     workflowHTTPHandler,
 		WorkflowStepHandler: workflowStepHTTPHandler,
+		DefinitionHandler:   definitionHTTPHandler,
+		ValidationHandler:   validationHTTPHandler,
 		RequestHandler:      requestHTTPHandler,
 		ActorHandler:        actorHTTPHandler,
+		APIKeyHandler:       apiKeyHTTPHandler,
+		OrganizationHandler: organizationHTTPHandler,
+		TenantHandler:       tenantHTTPHandler,
+		AttachmentHandler:   attachmentHTTPHandler,
+		CommentHandler:      commentHTTPHandler,
+		NotificationHandler: notificationHTTPHandler,
+		ExchangeRateHandler: exchangeRateHTTPHandler,
+		ExportHandler:       exportHTTPHandler,
+		ImportHandler:       importHTTPHandler,
+		ReportHandler:       reportHTTPHandler,
+		TenantService:       tenantService,
+		APIKeyService:       apiKeyService,
+		BodyLimit:           int(attachmentPolicy.MaxSize) + 1<<20, // Room for the multipart envelope
+		Metrics:             metricsRegistry,
+		MetricsToken:        cfg.Metrics.Token,
 	})
 
 	// Start server in a goroutine
@@ -140,11 +447,17 @@ func initDatabase(cfg *config.Config) (*gorm.DB, error) {
 	sqlDB.SetMaxIdleConns(cfg.Database.MaxIdleConns)
 	sqlDB.SetConnMaxLifetime(cfg.Database.GetConnMaxLifetime())
 
+	// Scope every query on tenant-owned tables to the caller's tenant
+	if err := db.Use(tenancy.Plugin{}); err != nil {
+		return nil, fmt.Errorf("failed to register tenancy plugin: %w", err)
+	}
+
 	return db, nil
 }
 
-// runMigrations runs database migrations using raw SQL for MariaDB compatibility
-func runMigrations(db *gorm.DB) error {
+// runMigrations runs database migrations using raw SQL for MariaDB compatibility.
+// Requests created before currencies existed are taken to be in baseCurrency.
+func runMigrations(db *gorm.DB, baseCurrency money.Currency) error {
 	// Get database name from config - we need to create DB first if it doesn't exist
 	dbName := "workflow_approval"
 
@@ -197,6 +510,51 @@ func runMigrations(db *gorm.DB) error {
 		return fmt.Errorf("failed to create users table: %w", err)
 	}
 
+	// Add OIDC identity columns if they don't exist (for existing tables)
+	alterUsersOIDCSQL := `
+	ALTER TABLE users
+	ADD COLUMN IF NOT EXISTS auth_provider VARCHAR(20) NOT NULL DEFAULT 'local' AFTER actor_id,
+	ADD COLUMN IF NOT EXISTS oidc_subject VARCHAR(255) NULL AFTER auth_provider,
+	ADD UNIQUE INDEX IF NOT EXISTS idx_oidc_subject (oidc_subject)
+	`
+	if err := db.Exec(alterUsersOIDCSQL).Error; err != nil {
+		log.Printf("Warning: failed to add OIDC columns to users: %v", err)
+	}
+
+	// Add TOTP two-factor columns if they don't exist (for existing tables)
+	alterUsersTOTPSQL := `
+	ALTER TABLE users
+	ADD COLUMN IF NOT EXISTS totp_secret VARCHAR(64) NULL,
+	ADD COLUMN IF NOT EXISTS totp_enabled BOOLEAN NOT NULL DEFAULT FALSE,
+	ADD COLUMN IF NOT EXISTS totp_last_counter BIGINT NOT NULL DEFAULT 0,
+	ADD COLUMN IF NOT EXISTS recovery_codes TEXT NULL
+	`
+	if err := db.Exec(alterUsersTOTPSQL).Error; err != nil {
+		log.Printf("Warning: failed to add TOTP columns to users: %v", err)
+	}
+
+	// Add account lifecycle columns if they don't exist (for existing tables)
+	alterUsersAccountSQL := `
+	ALTER TABLE users
+	ADD COLUMN IF NOT EXISTS email_verified_at DATETIME NULL,
+	ADD COLUMN IF NOT EXISTS must_change_password BOOLEAN NOT NULL DEFAULT FALSE,
+	ADD COLUMN IF NOT EXISTS password_changed_at DATETIME NULL
+	`
+	if err := db.Exec(alterUsersAccountSQL).Error; err != nil {
+		log.Printf("Warning: failed to add account columns to users: %v", err)
+	}
+
+	// Add user deactivation columns if they don't exist (for existing tables)
+	alterUsersActiveSQL := `
+	ALTER TABLE users
+	ADD COLUMN IF NOT EXISTS is_active BOOLEAN NOT NULL DEFAULT TRUE,
+	ADD COLUMN IF NOT EXISTS deactivated_at DATETIME NULL,
+	ADD INDEX IF NOT EXISTS idx_users_actor_active (actor_id, is_active)
+	`
+	if err := db.Exec(alterUsersActiveSQL).Error; err != nil {
+		log.Printf("Warning: failed to add deactivation columns to users: %v", err)
+	}
+
 	// Insert default admin user if not exists
 	insertAdminSQL := `
 	INSERT IGNORE INTO users (id, email, password, name, is_admin, actor_id, created_at, updated_at)
@@ -293,6 +651,476 @@ func runMigrations(db *gorm.DB) error {
 		log.Printf("Warning: failed to add user_id column to approval_history: %v", err)
 	}
 
+	// Create api_keys table (only the SHA-256 hash of each key is stored)
+	createAPIKeysSQL := `
+	CREATE TABLE IF NOT EXISTS api_keys (
+		id VARCHAR(36) PRIMARY KEY,
+		name VARCHAR(255) NOT NULL,
+		prefix VARCHAR(16) NOT NULL UNIQUE,
+		key_hash VARCHAR(64) NOT NULL,
+		permissions TEXT,
+		workflow_ids TEXT,
+		created_by VARCHAR(36),
+		expires_at DATETIME NULL,
+		last_used_at DATETIME NULL,
+		revoked_at DATETIME NULL,
+		created_at DATETIME,
+		updated_at DATETIME
+	) ENGINE=InnoDB DEFAULT CHARSET=utf8 COLLATE=utf8_general_ci
+	`
+	if err := db.Exec(createAPIKeysSQL).Error; err != nil {
+		return fmt.Errorf("failed to create api_keys table: %w", err)
+	}
+
+	// Create login_attempts table (failed-login tracking per account and per IP)
+	createLoginAttemptsSQL := `
+	CREATE TABLE IF NOT EXISTS login_attempts (
+		attempt_key VARCHAR(255) PRIMARY KEY,
+		failures INT NOT NULL DEFAULT 0,
+		last_failure_at DATETIME,
+		locked_until DATETIME NULL
+	) ENGINE=InnoDB DEFAULT CHARSET=utf8 COLLATE=utf8_general_ci
+	`
+	if err := db.Exec(createLoginAttemptsSQL).Error; err != nil {
+		return fmt.Errorf("failed to create login_attempts table: %w", err)
+	}
+
+	// Create auth_audit_log table
+	createAuthAuditLogSQL := `
+	CREATE TABLE IF NOT EXISTS auth_audit_log (
+		id VARCHAR(36) PRIMARY KEY,
+		event VARCHAR(30) NOT NULL,
+		user_id VARCHAR(36) NULL,
+		email VARCHAR(255),
+		ip_address VARCHAR(45),
+		user_agent VARCHAR(500),
+		detail VARCHAR(500),
+		created_at DATETIME,
+		INDEX idx_user_id (user_id),
+		INDEX idx_email (email),
+		INDEX idx_created_at (created_at)
+	) ENGINE=InnoDB DEFAULT CHARSET=utf8 COLLATE=utf8_general_ci
+	`
+	if err := db.Exec(createAuthAuditLogSQL).Error; err != nil {
+		return fmt.Errorf("failed to create auth_audit_log table: %w", err)
+	}
+
+	// Create user_tokens table (password reset and email verification links)
+	createUserTokensSQL := `
+	CREATE TABLE IF NOT EXISTS user_tokens (
+		id VARCHAR(36) PRIMARY KEY,
+		user_id VARCHAR(36) NOT NULL,
+		purpose VARCHAR(30) NOT NULL,
+		token_hash VARCHAR(64) NOT NULL,
+		expires_at DATETIME NOT NULL,
+		used_at DATETIME NULL,
+		created_at DATETIME,
+		UNIQUE INDEX idx_token_hash (token_hash),
+		INDEX idx_user_purpose (user_id, purpose, created_at)
+	) ENGINE=InnoDB DEFAULT CHARSET=utf8 COLLATE=utf8_general_ci
+	`
+	if err := db.Exec(createUserTokensSQL).Error; err != nil {
+		return fmt.Errorf("failed to create user_tokens table: %w", err)
+	}
+
+	// Create actor_members table (many-to-many users <-> actors)
+	createActorMembersSQL := `
+	CREATE TABLE IF NOT EXISTS actor_members (
+		id VARCHAR(36) PRIMARY KEY,
+		user_id VARCHAR(36) NOT NULL,
+		actor_id VARCHAR(36) NOT NULL,
+		is_primary BOOLEAN NOT NULL DEFAULT FALSE,
+		is_backup BOOLEAN NOT NULL DEFAULT FALSE,
+		created_at DATETIME,
+		UNIQUE INDEX idx_actor_members_user_actor (user_id, actor_id),
+		INDEX idx_actor_members_actor_id (actor_id)
+	) ENGINE=InnoDB DEFAULT CHARSET=utf8 COLLATE=utf8_general_ci
+	`
+	if err := db.Exec(createActorMembersSQL).Error; err != nil {
+		return fmt.Errorf("failed to create actor_members table: %w", err)
+	}
+
+	// Move the legacy single users.actor_id into actor_members as a primary
+	// membership, then clear it so later runs don't re-add removed memberships
+	if err := db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec(`
+		INSERT IGNORE INTO actor_members (id, user_id, actor_id, is_primary, is_backup, created_at)
+		SELECT UUID(), id, actor_id, TRUE, FALSE, NOW() FROM users WHERE actor_id IS NOT NULL AND actor_id <> ''
+		`).Error; err != nil {
+			return err
+		}
+		return tx.Exec("UPDATE users SET actor_id = NULL WHERE actor_id IS NOT NULL").Error
+	}); err != nil {
+		return fmt.Errorf("failed to migrate users.actor_id to actor_members: %w", err)
+	}
+
+	// Add org chart columns to users if they don't exist (for existing tables)
+	alterUsersOrgSQL := `
+	ALTER TABLE users
+	ADD COLUMN IF NOT EXISTS manager_id VARCHAR(36) NULL,
+	ADD COLUMN IF NOT EXISTS department_id VARCHAR(36) NULL,
+	ADD INDEX IF NOT EXISTS idx_users_manager_id (manager_id),
+	ADD INDEX IF NOT EXISTS idx_users_department_id (department_id)
+	`
+	if err := db.Exec(alterUsersOrgSQL).Error; err != nil {
+		log.Printf("Warning: failed to add org chart columns to users: %v", err)
+	}
+
+	// Create departments table
+	createDepartmentsSQL := `
+	CREATE TABLE IF NOT EXISTS departments (
+		id VARCHAR(36) PRIMARY KEY,
+		code VARCHAR(50) NOT NULL UNIQUE,
+		name VARCHAR(255) NOT NULL,
+		parent_id VARCHAR(36) NULL,
+		head_user_id VARCHAR(36) NULL,
+		created_at DATETIME,
+		updated_at DATETIME,
+		INDEX idx_departments_parent_id (parent_id),
+		INDEX idx_departments_head_user_id (head_user_id)
+	) ENGINE=InnoDB DEFAULT CHARSET=utf8 COLLATE=utf8_general_ci
+	`
+	if err := db.Exec(createDepartmentsSQL).Error; err != nil {
+		return fmt.Errorf("failed to create departments table: %w", err)
+	}
+
+	// Create approver_lookups table (request field value -> approver)
+	createApproverLookupsSQL := `
+	CREATE TABLE IF NOT EXISTS approver_lookups (
+		id VARCHAR(36) PRIMARY KEY,
+		field VARCHAR(100) NOT NULL,
+		value VARCHAR(255) NOT NULL,
+		user_id VARCHAR(36) NOT NULL,
+		created_at DATETIME,
+		updated_at DATETIME,
+		UNIQUE INDEX idx_approver_lookups_field_value (field, value),
+		INDEX idx_approver_lookups_user_id (user_id)
+	) ENGINE=InnoDB DEFAULT CHARSET=utf8 COLLATE=utf8_general_ci
+	`
+	if err := db.Exec(createApproverLookupsSQL).Error; err != nil {
+		return fmt.Errorf("failed to create approver_lookups table: %w", err)
+	}
+
+	// Add dynamic assignee columns to workflow_steps if they don't exist
+	alterStepsAssigneeSQL := `
+	ALTER TABLE workflow_steps
+	ADD COLUMN IF NOT EXISTS assignee_type VARCHAR(30) NOT NULL DEFAULT 'actor',
+	ADD COLUMN IF NOT EXISTS manager_level INT NOT NULL DEFAULT 0,
+	ADD COLUMN IF NOT EXISTS lookup_field VARCHAR(100) NOT NULL DEFAULT ''
+	`
+	if err := db.Exec(alterStepsAssigneeSQL).Error; err != nil {
+		log.Printf("Warning: failed to add assignee columns to workflow_steps: %v", err)
+	}
+
+	// Add custom fields and resolved approvers to requests if they don't exist
+	alterRequestsApproversSQL := `
+	ALTER TABLE requests
+	ADD COLUMN IF NOT EXISTS fields TEXT NULL,
+	ADD COLUMN IF NOT EXISTS approvers TEXT NULL
+	`
+	if err := db.Exec(alterRequestsApproversSQL).Error; err != nil {
+		log.Printf("Warning: failed to add approver columns to requests: %v", err)
+	}
+
+	// Add the submission time to requests; requests created before drafts existed were submitted on creation
+	alterRequestsSubmittedSQL := `
+	ALTER TABLE requests
+	ADD COLUMN IF NOT EXISTS submitted_at DATETIME NULL,
+	ADD INDEX IF NOT EXISTS idx_requests_status_updated_at (status, updated_at)
+	`
+	if err := db.Exec(alterRequestsSubmittedSQL).Error; err != nil {
+		log.Printf("Warning: failed to add submitted_at column to requests: %v", err)
+	}
+	if err := db.Exec("UPDATE requests SET submitted_at = created_at WHERE submitted_at IS NULL AND status <> 'DRAFT'").Error; err != nil {
+		log.Printf("Warning: failed to backfill submitted_at on requests: %v", err)
+	}
+
+	// Store amounts as fixed-point decimals with their currency, exchange rate and
+	// base currency amount; existing requests are in the base currency
+	alterRequestsCurrencySQL := fmt.Sprintf(`
+	ALTER TABLE requests
+	MODIFY COLUMN amount DECIMAL(18,4) NOT NULL,
+	ADD COLUMN IF NOT EXISTS currency VARCHAR(3) NOT NULL DEFAULT '%s',
+	ADD COLUMN IF NOT EXISTS exchange_rate DECIMAL(18,8) NOT NULL DEFAULT 0,
+	ADD COLUMN IF NOT EXISTS base_amount DECIMAL(18,4) NOT NULL DEFAULT 0
+	`, baseCurrency)
+	if err := db.Exec(alterRequestsCurrencySQL).Error; err != nil {
+		log.Printf("Warning: failed to add currency columns to requests: %v", err)
+	}
+	if err := db.Exec("UPDATE requests SET exchange_rate = 1, base_amount = amount WHERE exchange_rate = 0 AND status <> 'DRAFT'").Error; err != nil {
+		log.Printf("Warning: failed to backfill base amounts on requests: %v", err)
+	}
+
+	// Index request titles and descriptions for ?q= search, and the columns listings sort by
+	alterRequestsSearchSQL := `
+	ALTER TABLE requests
+	ADD FULLTEXT INDEX IF NOT EXISTS idx_requests_title_description (title, description),
+	ADD INDEX IF NOT EXISTS idx_requests_created_at (created_at),
+	ADD INDEX IF NOT EXISTS idx_requests_base_amount (base_amount)
+	`
+	if err := db.Exec(alterRequestsSearchSQL).Error; err != nil {
+		log.Printf("Warning: failed to add search indexes to requests: %v", err)
+	}
+
+	// Index the (created_at, id) keys that cursor pagination seeks on; InnoDB
+	// secondary indexes end with the primary key, so created_at is enough
+	for table, index := range map[string]string{
+		"workflows":        "idx_workflows_created_at (created_at)",
+		"actors":           "idx_actors_created_at (created_at)",
+		"approval_history": "idx_approval_history_request_created_at (request_id, created_at)",
+	} {
+		if err := db.Exec(fmt.Sprintf("ALTER TABLE %s ADD INDEX IF NOT EXISTS %s", table, index)).Error; err != nil {
+			log.Printf("Warning: failed to add cursor index to %s: %v", table, err)
+		}
+	}
+
+	// Index the date ranges reports filter on: submission for requests, decision time for history
+	for table, index := range map[string]string{
+		"requests":         "idx_requests_submitted_at (submitted_at)",
+		"approval_history": "idx_approval_history_created_at (created_at)",
+	} {
+		if err := db.Exec(fmt.Sprintf("ALTER TABLE %s ADD INDEX IF NOT EXISTS %s", table, index)).Error; err != nil {
+			log.Printf("Warning: failed to add report index to %s: %v", table, err)
+		}
+	}
+
+	// Add the request form schema to workflows if it doesn't exist
+	alterWorkflowsFormSQL := `
+	ALTER TABLE workflows
+	ADD COLUMN IF NOT EXISTS form_schema TEXT NULL
+	`
+	if err := db.Exec(alterWorkflowsFormSQL).Error; err != nil {
+		log.Printf("Warning: failed to add form_schema column to workflows: %v", err)
+	}
+
+	// Create tenants table with the default tenant owning all pre-existing data
+	createTenantsSQL := `
+	CREATE TABLE IF NOT EXISTS tenants (
+		id VARCHAR(36) PRIMARY KEY,
+		code VARCHAR(50) NOT NULL UNIQUE,
+		name VARCHAR(255) NOT NULL,
+		created_at DATETIME,
+		updated_at DATETIME
+	) ENGINE=InnoDB DEFAULT CHARSET=utf8 COLLATE=utf8_general_ci
+	`
+	if err := db.Exec(createTenantsSQL).Error; err != nil {
+		return fmt.Errorf("failed to create tenants table: %w", err)
+	}
+	if err := db.Exec(
+		"INSERT IGNORE INTO tenants (id, code, name, created_at, updated_at) VALUES (?, 'default', 'Default', NOW(), NOW())",
+		tenancy.DefaultTenantID,
+	).Error; err != nil {
+		return fmt.Errorf("failed to create default tenant: %w", err)
+	}
+
+	// Add tenant_id to every tenant-owned table; existing rows join the default tenant
+	for _, table := range []string{
+		"actors", "users", "actor_members", "workflows", "workflow_steps", "requests",
+		"approval_history", "api_keys", "departments", "approver_lookups",
+		"auth_audit_log", "user_tokens", "login_attempts",
+	} {
+		// login_attempts is keyed by attempt_key and has no id column
+		after := "id"
+		if table == "login_attempts" {
+			after = "attempt_key"
+		}
+		alterTenantSQL := fmt.Sprintf(`
+		ALTER TABLE %[1]s
+		ADD COLUMN IF NOT EXISTS tenant_id VARCHAR(36) NOT NULL DEFAULT '%[2]s' AFTER %[3]s,
+		ADD INDEX IF NOT EXISTS idx_%[1]s_tenant_id (tenant_id)
+		`, table, tenancy.DefaultTenantID, after)
+		if err := db.Exec(alterTenantSQL).Error; err != nil {
+			return fmt.Errorf("failed to add tenant_id to %s: %w", table, err)
+		}
+	}
+
+	// Authentication records written before they had a tenant move to their user's
+	backfillAuthTenantSQL := []string{
+		"UPDATE auth_audit_log a JOIN users u ON u.id = a.user_id SET a.tenant_id = u.tenant_id WHERE a.tenant_id <> u.tenant_id",
+		"UPDATE user_tokens t JOIN users u ON u.id = t.user_id SET t.tenant_id = u.tenant_id WHERE t.tenant_id <> u.tenant_id",
+		"UPDATE login_attempts l JOIN users u ON l.attempt_key = CONCAT('account:', LOWER(u.email)) SET l.tenant_id = u.tenant_id WHERE l.tenant_id <> u.tenant_id",
+		"UPDATE login_attempts l JOIN users u ON l.attempt_key = CONCAT('2fa:', u.id) SET l.tenant_id = u.tenant_id WHERE l.tenant_id <> u.tenant_id",
+	}
+	for _, stmt := range backfillAuthTenantSQL {
+		if err := db.Exec(stmt).Error; err != nil {
+			return fmt.Errorf("failed to backfill authentication tenants: %w", err)
+		}
+	}
+
+	// Codes are unique per tenant instead of globally
+	tenantUniqueIndexes := []string{
+		"ALTER TABLE actors DROP INDEX IF EXISTS code",
+		"ALTER TABLE actors ADD UNIQUE INDEX IF NOT EXISTS idx_actors_tenant_code (tenant_id, code)",
+		"ALTER TABLE departments DROP INDEX IF EXISTS code",
+		"ALTER TABLE departments ADD UNIQUE INDEX IF NOT EXISTS idx_departments_tenant_code (tenant_id, code)",
+		"ALTER TABLE approver_lookups DROP INDEX IF EXISTS idx_approver_lookups_field_value",
+		"ALTER TABLE approver_lookups ADD UNIQUE INDEX IF NOT EXISTS idx_approver_lookups_tenant_field_value (tenant_id, field, value)",
+	}
+	for _, stmt := range tenantUniqueIndexes {
+		if err := db.Exec(stmt).Error; err != nil {
+			log.Printf("Warning: failed to update tenant unique index (%s): %v", stmt, err)
+		}
+	}
+
+	// Super admins manage tenants; the default admin becomes one while none exists
+	if err := db.Exec("ALTER TABLE users ADD COLUMN IF NOT EXISTS is_super_admin BOOLEAN NOT NULL DEFAULT FALSE").Error; err != nil {
+		log.Printf("Warning: failed to add is_super_admin column to users: %v", err)
+	}
+	promoteSuperAdminSQL := `
+	UPDATE users SET is_super_admin = TRUE
+	WHERE id = 'b693fdee-f2bb-11f0-8cc1-7a447c8d071a'
+	AND (SELECT COUNT(*) FROM (SELECT id FROM users WHERE is_super_admin = TRUE) AS super_admins) = 0
+	`
+	if err := db.Exec(promoteSuperAdminSQL).Error; err != nil {
+		log.Printf("Warning: failed to promote default admin to super admin: %v", err)
+	}
+
+	// Create attachments table (file metadata; content lives in the configured storage)
+	createAttachmentsSQL := fmt.Sprintf(`
+	CREATE TABLE IF NOT EXISTS attachments (
+		id VARCHAR(36) PRIMARY KEY,
+		tenant_id VARCHAR(36) NOT NULL DEFAULT '%s',
+		request_id VARCHAR(36) NOT NULL,
+		file_name VARCHAR(255) NOT NULL,
+		content_type VARCHAR(100) NOT NULL,
+		size BIGINT NOT NULL,
+		sha256 CHAR(64) NOT NULL,
+		storage_key VARCHAR(255) NOT NULL,
+		uploaded_by VARCHAR(36) NOT NULL,
+		created_at DATETIME,
+		INDEX idx_attachments_tenant_id (tenant_id),
+		INDEX idx_attachments_request_id (request_id)
+	) ENGINE=InnoDB DEFAULT CHARSET=utf8 COLLATE=utf8_general_ci
+	`, tenancy.DefaultTenantID)
+	if err := db.Exec(createAttachmentsSQL).Error; err != nil {
+		return fmt.Errorf("failed to create attachments table: %w", err)
+	}
+
+	// Create comments table (threads are one level deep: replies point to a top-level comment)
+	createCommentsSQL := fmt.Sprintf(`
+	CREATE TABLE IF NOT EXISTS comments (
+		id VARCHAR(36) PRIMARY KEY,
+		tenant_id VARCHAR(36) NOT NULL DEFAULT '%s',
+		request_id VARCHAR(36) NOT NULL,
+		parent_id VARCHAR(36),
+		author_id VARCHAR(36) NOT NULL,
+		body TEXT NOT NULL,
+		mentions TEXT,
+		edited_at DATETIME,
+		deleted_at DATETIME,
+		created_at DATETIME,
+		updated_at DATETIME,
+		INDEX idx_comments_tenant_id (tenant_id),
+		INDEX idx_comments_request_id (request_id),
+		INDEX idx_comments_parent_id (parent_id)
+	) ENGINE=InnoDB DEFAULT CHARSET=utf8 COLLATE=utf8_general_ci
+	`, tenancy.DefaultTenantID)
+	if err := db.Exec(createCommentsSQL).Error; err != nil {
+		return fmt.Errorf("failed to create comments table: %w", err)
+	}
+
+	// Create notifications table (in-app notifications, e.g. comment mentions)
+	createNotificationsSQL := fmt.Sprintf(`
+	CREATE TABLE IF NOT EXISTS notifications (
+		id VARCHAR(36) PRIMARY KEY,
+		tenant_id VARCHAR(36) NOT NULL DEFAULT '%s',
+		user_id VARCHAR(36) NOT NULL,
+		type VARCHAR(30) NOT NULL,
+		request_id VARCHAR(36) NOT NULL,
+		comment_id VARCHAR(36),
+		actor_id VARCHAR(36) NOT NULL,
+		message VARCHAR(500) NOT NULL,
+		read_at DATETIME,
+		created_at DATETIME,
+		INDEX idx_notifications_tenant_id (tenant_id),
+		INDEX idx_notifications_user_read (user_id, read_at)
+	) ENGINE=InnoDB DEFAULT CHARSET=utf8 COLLATE=utf8_general_ci
+	`, tenancy.DefaultTenantID)
+	if err := db.Exec(createNotificationsSQL).Error; err != nil {
+		return fmt.Errorf("failed to create notifications table: %w", err)
+	}
+
+	// Create exchange_rates table; rates convert request currencies into the base currency
+	createExchangeRatesSQL := fmt.Sprintf(`
+	CREATE TABLE IF NOT EXISTS exchange_rates (
+		id VARCHAR(36) PRIMARY KEY,
+		tenant_id VARCHAR(36) NOT NULL DEFAULT '%s',
+		currency VARCHAR(3) NOT NULL,
+		rate DECIMAL(18,8) NOT NULL,
+		updated_by VARCHAR(36) NOT NULL,
+		created_at DATETIME,
+		updated_at DATETIME,
+		UNIQUE INDEX idx_exchange_rates_tenant_currency (tenant_id, currency)
+	) ENGINE=InnoDB DEFAULT CHARSET=utf8 COLLATE=utf8_general_ci
+	`, tenancy.DefaultTenantID)
+	if err := db.Exec(createExchangeRatesSQL).Error; err != nil {
+		return fmt.Errorf("failed to create exchange_rates table: %w", err)
+	}
+
+	// Create export_jobs table (request exports run in the background)
+	createExportJobsSQL := fmt.Sprintf(`
+	CREATE TABLE IF NOT EXISTS export_jobs (
+		id VARCHAR(36) PRIMARY KEY,
+		tenant_id VARCHAR(36) NOT NULL DEFAULT '%s',
+		requested_by VARCHAR(36) NOT NULL,
+		format VARCHAR(10) NOT NULL,
+		filter TEXT,
+		status VARCHAR(20) NOT NULL,
+		row_count INT NOT NULL DEFAULT 0,
+		file_size BIGINT NOT NULL DEFAULT 0,
+		storage_key VARCHAR(255),
+		error VARCHAR(500),
+		created_at DATETIME,
+		started_at DATETIME,
+		completed_at DATETIME,
+		expires_at DATETIME,
+		INDEX idx_export_jobs_tenant_id (tenant_id),
+		INDEX idx_export_jobs_requested_by (requested_by),
+		INDEX idx_export_jobs_status_created (status, created_at)
+	) ENGINE=InnoDB DEFAULT CHARSET=utf8 COLLATE=utf8_general_ci
+	`, tenancy.DefaultTenantID)
+	if err := db.Exec(createExportJobsSQL).Error; err != nil {
+		return fmt.Errorf("failed to create export_jobs table: %w", err)
+	}
+
+	// Create two_factor_challenges and oidc_login_states tables (logins in
+	// progress, kept in the database so any instance can complete them)
+	createTwoFactorChallengesSQL := fmt.Sprintf(`
+	CREATE TABLE IF NOT EXISTS two_factor_challenges (
+		id VARCHAR(36) PRIMARY KEY,
+		tenant_id VARCHAR(36) NOT NULL DEFAULT '%s',
+		user_id VARCHAR(36) NOT NULL,
+		token_hash VARCHAR(64) NOT NULL,
+		first_factor VARCHAR(20) NOT NULL,
+		enrollment BOOLEAN NOT NULL DEFAULT FALSE,
+		failures INT NOT NULL DEFAULT 0,
+		expires_at DATETIME NOT NULL,
+		created_at DATETIME,
+		UNIQUE INDEX idx_two_factor_challenges_token_hash (token_hash),
+		INDEX idx_two_factor_challenges_tenant_id (tenant_id),
+		INDEX idx_two_factor_challenges_expires_at (expires_at)
+	) ENGINE=InnoDB DEFAULT CHARSET=utf8 COLLATE=utf8_general_ci
+	`, tenancy.DefaultTenantID)
+	if err := db.Exec(createTwoFactorChallengesSQL).Error; err != nil {
+		return fmt.Errorf("failed to create two_factor_challenges table: %w", err)
+	}
+
+	createOIDCLoginStatesSQL := `
+	CREATE TABLE IF NOT EXISTS oidc_login_states (
+		id VARCHAR(36) PRIMARY KEY,
+		state_hash VARCHAR(64) NOT NULL,
+		nonce VARCHAR(64) NOT NULL,
+		code_verifier VARCHAR(128) NOT NULL,
+		expires_at DATETIME NOT NULL,
+		created_at DATETIME,
+		UNIQUE INDEX idx_oidc_login_states_state_hash (state_hash),
+		INDEX idx_oidc_login_states_expires_at (expires_at)
+	) ENGINE=InnoDB DEFAULT CHARSET=utf8 COLLATE=utf8_general_ci
+	`
+	if err := db.Exec(createOIDCLoginStatesSQL).Error; err != nil {
+		return fmt.Errorf("failed to create oidc_login_states table: %w", err)
+	}
+
 	// Re-enable foreign key checks
 	db.Exec("SET FOREIGN_KEY_CHECKS=1")
 
@@ -9,8 +9,9 @@ import (
 // Actor represents an actor in the workflow system
 type Actor struct {
 	ID        string    `json:"id" gorm:"primaryKey;size:36"`
+	TenantID  string    `json:"tenant_id" gorm:"size:36;not null;uniqueIndex:idx_actors_tenant_code"`
 	Name      string    `json:"name" gorm:"size:255;not null"`
-	Code      string    `json:"code" gorm:"size:50;uniqueIndex;not null"`
+	Code      string    `json:"code" gorm:"size:50;uniqueIndex:idx_actors_tenant_code;not null"` // Unique per tenant
 	CreatedAt time.Time `json:"created_at"`
 	UpdatedAt time.Time `json:"updated_at"`
 }
@@ -5,6 +5,7 @@ import (
 
 	"workflow-approval/package/actor/domain/dto"
 	"workflow-approval/package/actor/ports"
+	"workflow-approval/utils/pagination"
 )
 
 // ActorHandler handles HTTP requests for actor operations
@@ -22,7 +23,7 @@ func NewActorHandler(actorService ports.ActorService) *ActorHandler {
 // Routes defines all routes for actor module
 // Mounts routes under /api/actors
 func (h *ActorHandler) Routes(group fiber.Router) {
-	// GET /api/actors - Get all actors
+	// GET /api/actors - Get all actors (a page of them with page, limit or cursor)
 	group.Get("/", h.GetAllActors)
 
 	// GET /api/actors/:id - Get actor by ID
@@ -38,9 +39,14 @@ func (h *ActorHandler) Routes(group fiber.Router) {
 	group.Delete("/:id", h.DeleteActor)
 }
 
-// GetAllActors handles getting all actors
-// GET /api/actors
+// GetAllActors handles getting all actors, or a page of them when any of
+// page, limit or cursor is given
+// GET /api/actors?page=&limit=&cursor=&total=
 func (h *ActorHandler) GetAllActors(c *fiber.Ctx) error {
+	if c.Query("page") != "" || c.Query("limit") != "" || c.Query("cursor") != "" {
+		return h.listActors(c)
+	}
+
 	actors, err := h.actorService.GetAllActors(c.Context())
 	if err != nil {
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
@@ -57,6 +63,35 @@ func (h *ActorHandler) GetAllActors(c *fiber.Ctx) error {
 	})
 }
 
+// listActors responds with a page of actors, by offset or by cursor
+func (h *ActorHandler) listActors(c *fiber.Ctx) error {
+	page, err := pagination.Parse(c.Query("page"), c.Query("limit"), c.Query("cursor"), c.Query("total"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"success": false,
+			"data":    nil,
+			"error":   err.Error(),
+		})
+	}
+
+	actors, result, err := h.actorService.ListActors(c.Context(), page)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"success": false,
+			"data":    nil,
+			"error":   err.Error(),
+		})
+	}
+
+	data := fiber.Map(result.Meta(c.Path(), string(c.Request().URI().QueryString())))
+	data["actors"] = dto.ToActorResponseList(actors)
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"success": true,
+		"data":    data,
+		"error":   nil,
+	})
+}
+
 // GetActorByID handles getting an actor by ID
 // GET /api/actors/:id
 func (h *ActorHandler) GetActorByID(c *fiber.Ctx) error {
@@ -4,6 +4,7 @@ import (
 	"context"
 
 	"workflow-approval/package/actor/domain"
+	"workflow-approval/utils/pagination"
 )
 
 // ActorRepository defines the interface for actor data access
@@ -12,6 +13,7 @@ type ActorRepository interface {
 	GetByID(ctx context.Context, id string) (*domain.Actor, error)
 	GetByCode(ctx context.Context, code string) (*domain.Actor, error)
 	GetAll(ctx context.Context) ([]*domain.Actor, error)
+	List(ctx context.Context, page pagination.Page) ([]*domain.Actor, pagination.Result, error)
 	Update(ctx context.Context, actor *domain.Actor) error
 	Delete(ctx context.Context, id string) error
 }
@@ -21,6 +23,8 @@ type ActorService interface {
 	CreateActor(ctx context.Context, name, code string) (*domain.Actor, error)
 	GetActorByID(ctx context.Context, id string) (*domain.Actor, error)
 	GetAllActors(ctx context.Context) ([]*domain.Actor, error)
+	// ListActors retrieves a page of actors, oldest first
+	ListActors(ctx context.Context, page pagination.Page) ([]*domain.Actor, pagination.Result, error)
 	UpdateActor(ctx context.Context, id string, name string) (*domain.Actor, error)
 	DeleteActor(ctx context.Context, id string) error
 }
@@ -3,11 +3,13 @@ package repository
 import (
 	"context"
 	"errors"
+	"time"
 
 	"gorm.io/gorm"
 
 	"workflow-approval/package/actor/domain"
 	"workflow-approval/package/actor/ports"
+	"workflow-approval/utils/pagination"
 )
 
 var (
@@ -73,6 +75,28 @@ func (r *ActorRepositoryImpl) GetAll(ctx context.Context) ([]*domain.Actor, erro
 	return actors, nil
 }
 
+// List retrieves a page of actors, oldest first
+func (r *ActorRepositoryImpl) List(ctx context.Context, page pagination.Page) ([]*domain.Actor, pagination.Result, error) {
+	var actors []*domain.Actor
+	var total int64
+
+	if page.WithTotal {
+		if err := r.db.WithContext(ctx).Model(&domain.Actor{}).Count(&total).Error; err != nil {
+			return nil, pagination.Result{}, err
+		}
+	}
+
+	if err := pagination.Apply(r.db.WithContext(ctx), page, false).Find(&actors).Error; err != nil {
+		return nil, pagination.Result{}, err
+	}
+
+	actors, result := pagination.Finish(actors, page, func(a *domain.Actor) (time.Time, string) {
+		return a.CreatedAt, a.ID
+	})
+	result.Total = total
+	return actors, result, nil
+}
+
 // Update updates an existing actor
 func (r *ActorRepositoryImpl) Update(ctx context.Context, actor *domain.Actor) error {
 	result := r.db.WithContext(ctx).Save(actor)
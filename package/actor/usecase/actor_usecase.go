@@ -8,6 +8,7 @@ import (
 	"workflow-approval/package/actor/domain"
 	"workflow-approval/package/actor/ports"
 	"workflow-approval/package/actor/repository"
+	"workflow-approval/utils/pagination"
 )
 
 var (
@@ -70,6 +71,11 @@ func (s *ActorServiceImpl) GetAllActors(ctx context.Context) ([]*domain.Actor, e
 	return s.actorRepo.GetAll(ctx)
 }
 
+// ListActors retrieves a page of actors, oldest first
+func (s *ActorServiceImpl) ListActors(ctx context.Context, page pagination.Page) ([]*domain.Actor, pagination.Result, error) {
+	return s.actorRepo.List(ctx, page.Normalized())
+}
+
 // UpdateActor updates an actor's name
 func (s *ActorServiceImpl) UpdateActor(ctx context.Context, id string, name string) (*domain.Actor, error) {
 	if name == "" {
@@ -0,0 +1,153 @@
+package domain
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"strings"
+	"time"
+
+	"workflow-approval/utils"
+)
+
+// KeyPrefix is prepended to every generated API key so leaked keys are easy to identify
+const KeyPrefix = "wfa"
+
+// Permissions that can be granted to an API key
+const (
+	PermissionRequestsRead           = "requests:read"
+	PermissionRequestsWrite          = "requests:write"
+	PermissionRequestsCreateOnBehalf = "requests:create_on_behalf"
+	PermissionWorkflowsRead          = "workflows:read"
+	PermissionWorkflowsWrite         = "workflows:write"
+	PermissionActorsRead             = "actors:read"
+	PermissionActorsWrite            = "actors:write"
+	PermissionUsersRead              = "users:read"
+	PermissionUsersWrite             = "users:write"
+)
+
+// AllPermissions lists every permission an admin may grant to an API key
+var AllPermissions = []string{
+	PermissionRequestsRead,
+	PermissionRequestsWrite,
+	PermissionRequestsCreateOnBehalf,
+	PermissionWorkflowsRead,
+	PermissionWorkflowsWrite,
+	PermissionActorsRead,
+	PermissionActorsWrite,
+	PermissionUsersRead,
+	PermissionUsersWrite,
+}
+
+// APIKey represents an admin-managed credential used by service-to-service integrations.
+// Only the SHA-256 hash of the key is stored; the prefix allows lookup and identification.
+type APIKey struct {
+	ID          string     `json:"id" gorm:"primaryKey;size:36"`
+	TenantID    string     `json:"tenant_id" gorm:"size:36;not null;index"`
+	Name        string     `json:"name" gorm:"size:255;not null"`
+	Prefix      string     `json:"prefix" gorm:"size:16;uniqueIndex;not null"`
+	KeyHash     string     `json:"-" gorm:"size:64;not null"`
+	Permissions StringList `json:"permissions" gorm:"type:text"`
+	WorkflowIDs StringList `json:"workflow_ids" gorm:"type:text"` // Empty means all workflows
+	CreatedBy   string     `json:"created_by" gorm:"size:36"`
+	ExpiresAt   *time.Time `json:"expires_at"`
+	LastUsedAt  *time.Time `json:"last_used_at"`
+	RevokedAt   *time.Time `json:"revoked_at"`
+	CreatedAt   time.Time  `json:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at"`
+}
+
+// NewAPIKey creates a new APIKey instance
+func NewAPIKey(name, prefix, keyHash string, permissions, workflowIDs []string, createdBy string, expiresAt *time.Time) *APIKey {
+	now := utils.TimeNowUTC()
+	return &APIKey{
+		ID:          utils.GenerateUUID(),
+		Name:        name,
+		Prefix:      prefix,
+		KeyHash:     keyHash,
+		Permissions: permissions,
+		WorkflowIDs: workflowIDs,
+		CreatedBy:   createdBy,
+		ExpiresAt:   expiresAt,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+}
+
+// TableName returns the table name for GORM
+func (APIKey) TableName() string {
+	return "api_keys"
+}
+
+// IsRevoked checks if the key has been revoked
+func (k *APIKey) IsRevoked() bool {
+	return k.RevokedAt != nil
+}
+
+// IsExpired checks if the key has passed its expiry time
+func (k *APIKey) IsExpired(now time.Time) bool {
+	return k.ExpiresAt != nil && !now.Before(*k.ExpiresAt)
+}
+
+// HasPermission checks if the key grants the given permission.
+// A "<resource>:*" grant covers every action on that resource.
+func (k *APIKey) HasPermission(permission string) bool {
+	resource := permission
+	if i := strings.Index(permission, ":"); i >= 0 {
+		resource = permission[:i]
+	}
+	for _, p := range k.Permissions {
+		if p == permission || p == resource+":*" {
+			return true
+		}
+	}
+	return false
+}
+
+// AllowsWorkflow checks if the key is scoped to the given workflow
+func (k *APIKey) AllowsWorkflow(workflowID string) bool {
+	if len(k.WorkflowIDs) == 0 {
+		return true
+	}
+	for _, id := range k.WorkflowIDs {
+		if id == workflowID {
+			return true
+		}
+	}
+	return false
+}
+
+// StringList is a list of strings stored as a JSON array in a text column
+type StringList []string
+
+// Value implements driver.Valuer interface for GORM
+func (l StringList) Value() (driver.Value, error) {
+	if l == nil {
+		return []byte(`[]`), nil
+	}
+	return json.Marshal(l)
+}
+
+// Scan implements sql.Scanner interface for GORM
+func (l *StringList) Scan(value interface{}) error {
+	if value == nil {
+		*l = StringList{}
+		return nil
+	}
+
+	var bytes []byte
+	switch v := value.(type) {
+	case []byte:
+		bytes = v
+	case string:
+		bytes = []byte(v)
+	default:
+		return errors.New("type assertion to []byte or string failed")
+	}
+
+	if len(bytes) == 0 {
+		*l = StringList{}
+		return nil
+	}
+	return json.Unmarshal(bytes, l)
+}
@@ -0,0 +1,11 @@
+package dto
+
+import "time"
+
+// CreateAPIKeyRequest represents the create API key request body
+type CreateAPIKeyRequest struct {
+	Name        string     `json:"name"`
+	Permissions []string   `json:"permissions"`
+	WorkflowIDs []string   `json:"workflow_ids"`
+	ExpiresAt   *time.Time `json:"expires_at"`
+}
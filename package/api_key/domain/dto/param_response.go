@@ -0,0 +1,61 @@
+package dto
+
+import "workflow-approval/package/api_key/domain"
+
+// APIKeyResponse represents the API key response (never includes the secret)
+type APIKeyResponse struct {
+	ID          string   `json:"id"`
+	Name        string   `json:"name"`
+	Prefix      string   `json:"prefix"`
+	Permissions []string `json:"permissions"`
+	WorkflowIDs []string `json:"workflow_ids"`
+	CreatedBy   string   `json:"created_by"`
+	ExpiresAt   *string  `json:"expires_at"`
+	LastUsedAt  *string  `json:"last_used_at"`
+	RevokedAt   *string  `json:"revoked_at"`
+	CreatedAt   string   `json:"created_at"`
+}
+
+// CreatedAPIKeyResponse is returned once on creation and carries the plaintext key
+type CreatedAPIKeyResponse struct {
+	*APIKeyResponse
+	Key string `json:"key"`
+}
+
+// ToAPIKeyResponse converts an APIKey to APIKeyResponse
+func ToAPIKeyResponse(k *domain.APIKey) *APIKeyResponse {
+	if k == nil {
+		return nil
+	}
+	resp := &APIKeyResponse{
+		ID:          k.ID,
+		Name:        k.Name,
+		Prefix:      k.Prefix,
+		Permissions: k.Permissions,
+		WorkflowIDs: k.WorkflowIDs,
+		CreatedBy:   k.CreatedBy,
+		CreatedAt:   k.CreatedAt.Format("2006-01-02T15:04:05Z"),
+	}
+	if k.ExpiresAt != nil {
+		s := k.ExpiresAt.Format("2006-01-02T15:04:05Z")
+		resp.ExpiresAt = &s
+	}
+	if k.LastUsedAt != nil {
+		s := k.LastUsedAt.Format("2006-01-02T15:04:05Z")
+		resp.LastUsedAt = &s
+	}
+	if k.RevokedAt != nil {
+		s := k.RevokedAt.Format("2006-01-02T15:04:05Z")
+		resp.RevokedAt = &s
+	}
+	return resp
+}
+
+// ToAPIKeyResponseList converts a list of APIKey to APIKeyResponse
+func ToAPIKeyResponseList(keys []*domain.APIKey) []*APIKeyResponse {
+	responses := make([]*APIKeyResponse, len(keys))
+	for i, k := range keys {
+		responses[i] = ToAPIKeyResponse(k)
+	}
+	return responses
+}
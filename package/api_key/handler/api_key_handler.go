@@ -0,0 +1,154 @@
+package handler
+
+import (
+	"errors"
+
+	"github.com/gofiber/fiber/v2"
+
+	"workflow-approval/package/api_key/domain/dto"
+	"workflow-approval/package/api_key/ports"
+	"workflow-approval/package/api_key/usecase"
+)
+
+// APIKeyHandler handles HTTP requests for API key management
+type APIKeyHandler struct {
+	apiKeyService ports.APIKeyService
+}
+
+// NewAPIKeyHandler creates a new APIKeyHandler instance
+func NewAPIKeyHandler(apiKeyService ports.APIKeyService) *APIKeyHandler {
+	return &APIKeyHandler{
+		apiKeyService: apiKeyService,
+	}
+}
+
+// Routes defines all routes for API key module
+// Mounts routes under /api/api-keys (admin only)
+func (h *APIKeyHandler) Routes(group fiber.Router) {
+	// GET /api/api-keys - List all API keys
+	group.Get("", h.List)
+
+	// POST /api/api-keys - Create a new API key (plaintext key is returned once)
+	group.Post("", h.Create)
+
+	// GET /api/api-keys/:id - Get a specific API key
+	group.Get("/:id", h.Get)
+
+	// DELETE /api/api-keys/:id - Revoke an API key
+	group.Delete("/:id", h.Revoke)
+}
+
+// Create creates a new API key
+// POST /api/api-keys
+func (h *APIKeyHandler) Create(c *fiber.Ctx) error {
+	var req dto.CreateAPIKeyRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"success": false,
+			"data":    nil,
+			"error":   "Invalid request body",
+		})
+	}
+
+	createdBy := c.Locals("user_id").(string)
+
+	key, rawKey, err := h.apiKeyService.CreateAPIKey(c.Context(), req.Name, req.Permissions, req.WorkflowIDs, req.ExpiresAt, createdBy)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"success": false,
+			"data":    nil,
+			"error":   err.Error(),
+		})
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
+		"success": true,
+		"data": dto.CreatedAPIKeyResponse{
+			APIKeyResponse: dto.ToAPIKeyResponse(key),
+			Key:            rawKey,
+		},
+		"error": nil,
+	})
+}
+
+// List retrieves all API keys
+// GET /api/api-keys
+func (h *APIKeyHandler) List(c *fiber.Ctx) error {
+	keys, err := h.apiKeyService.ListAPIKeys(c.Context())
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"success": false,
+			"data":    nil,
+			"error":   "Failed to list API keys",
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"success": true,
+		"data":    dto.ToAPIKeyResponseList(keys),
+		"error":   nil,
+	})
+}
+
+// Get retrieves an API key by ID
+// GET /api/api-keys/:id
+func (h *APIKeyHandler) Get(c *fiber.Ctx) error {
+	id := c.Params("id")
+	if id == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"success": false,
+			"data":    nil,
+			"error":   "API key ID is required",
+		})
+	}
+
+	key, err := h.apiKeyService.GetAPIKey(c.Context(), id)
+	if err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"success": false,
+			"data":    nil,
+			"error":   "API key not found",
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"success": true,
+		"data":    dto.ToAPIKeyResponse(key),
+		"error":   nil,
+	})
+}
+
+// Revoke revokes an API key
+// DELETE /api/api-keys/:id
+func (h *APIKeyHandler) Revoke(c *fiber.Ctx) error {
+	id := c.Params("id")
+	if id == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"success": false,
+			"data":    nil,
+			"error":   "API key ID is required",
+		})
+	}
+
+	key, err := h.apiKeyService.RevokeAPIKey(c.Context(), id)
+	if err != nil {
+		if errors.Is(err, usecase.ErrAPIKeyNotFound) {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"success": false,
+				"data":    nil,
+				"error":   "API key not found",
+			})
+		}
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"success": false,
+			"data":    nil,
+			"error":   err.Error(),
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"success": true,
+		"data":    dto.ToAPIKeyResponse(key),
+		"error":   nil,
+	})
+}
@@ -0,0 +1,45 @@
+package ports
+
+import (
+	"context"
+	"time"
+
+	"workflow-approval/package/api_key/domain"
+	userDomain "workflow-approval/package/user/domain"
+	wfDomain "workflow-approval/package/workflow/domain"
+)
+
+// APIKeyRepository defines the interface for API key data access
+type APIKeyRepository interface {
+	Create(ctx context.Context, key *domain.APIKey) error
+	GetByID(ctx context.Context, id string) (*domain.APIKey, error)
+	GetByPrefix(ctx context.Context, prefix string) (*domain.APIKey, error)
+	List(ctx context.Context) ([]*domain.APIKey, error)
+	Update(ctx context.Context, key *domain.APIKey) error
+	TouchLastUsed(ctx context.Context, id string, usedAt time.Time) error
+}
+
+// UserRepository defines the user lookups needed to act on behalf of users
+type UserRepository interface {
+	GetByID(ctx context.Context, id string) (*userDomain.User, error)
+}
+
+// WorkflowRepository defines the workflow lookups needed to validate key scopes
+type WorkflowRepository interface {
+	GetByID(ctx context.Context, id string) (*wfDomain.Workflow, error)
+}
+
+// APIKeyService defines the interface for API key business logic
+type APIKeyService interface {
+	// CreateAPIKey creates a key and returns it together with the plaintext secret, which is never stored
+	CreateAPIKey(ctx context.Context, name string, permissions, workflowIDs []string, expiresAt *time.Time, createdBy string) (*domain.APIKey, string, error)
+	GetAPIKey(ctx context.Context, id string) (*domain.APIKey, error)
+	ListAPIKeys(ctx context.Context) ([]*domain.APIKey, error)
+	RevokeAPIKey(ctx context.Context, id string) (*domain.APIKey, error)
+
+	// Authenticate resolves a raw key presented by a client into an active APIKey
+	Authenticate(ctx context.Context, rawKey string) (*domain.APIKey, error)
+
+	// ResolveOnBehalfOf returns the user a key wants to act for, checking the key may do so
+	ResolveOnBehalfOf(ctx context.Context, key *domain.APIKey, userID string) (*userDomain.User, error)
+}
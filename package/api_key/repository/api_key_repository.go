@@ -0,0 +1,79 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+
+	"workflow-approval/package/api_key/domain"
+	"workflow-approval/package/api_key/ports"
+	"workflow-approval/utils"
+)
+
+var ErrAPIKeyNotFound = errors.New("api key not found")
+
+// APIKeyRepositoryImpl implements APIKeyRepository interface
+type APIKeyRepositoryImpl struct {
+	db *gorm.DB
+}
+
+// NewAPIKeyRepository creates a new APIKeyRepositoryImpl instance
+func NewAPIKeyRepository(db *gorm.DB) ports.APIKeyRepository {
+	return &APIKeyRepositoryImpl{db: db}
+}
+
+// Create creates a new API key
+func (r *APIKeyRepositoryImpl) Create(ctx context.Context, key *domain.APIKey) error {
+	return r.db.WithContext(ctx).Create(key).Error
+}
+
+// GetByID retrieves an API key by ID
+func (r *APIKeyRepositoryImpl) GetByID(ctx context.Context, id string) (*domain.APIKey, error) {
+	var key domain.APIKey
+	result := r.db.WithContext(ctx).First(&key, "id = ?", id)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, ErrAPIKeyNotFound
+		}
+		return nil, result.Error
+	}
+	return &key, nil
+}
+
+// GetByPrefix retrieves an API key by its public prefix
+func (r *APIKeyRepositoryImpl) GetByPrefix(ctx context.Context, prefix string) (*domain.APIKey, error) {
+	var key domain.APIKey
+	result := r.db.WithContext(ctx).First(&key, "prefix = ?", prefix)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, ErrAPIKeyNotFound
+		}
+		return nil, result.Error
+	}
+	return &key, nil
+}
+
+// List retrieves all API keys, newest first
+func (r *APIKeyRepositoryImpl) List(ctx context.Context) ([]*domain.APIKey, error) {
+	var keys []*domain.APIKey
+	err := r.db.WithContext(ctx).
+		Order("created_at DESC").
+		Find(&keys).Error
+	return keys, err
+}
+
+// Update updates an existing API key
+func (r *APIKeyRepositoryImpl) Update(ctx context.Context, key *domain.APIKey) error {
+	key.UpdatedAt = utils.TimeNowUTC()
+	return r.db.WithContext(ctx).Save(key).Error
+}
+
+// TouchLastUsed records when a key was last used without rewriting the whole row
+func (r *APIKeyRepositoryImpl) TouchLastUsed(ctx context.Context, id string, usedAt time.Time) error {
+	return r.db.WithContext(ctx).
+		Model(&domain.APIKey{}).
+		Where("id = ?", id).
+		UpdateColumn("last_used_at", usedAt).Error
+}
@@ -0,0 +1,216 @@
+package usecase
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"strings"
+	"time"
+
+	"workflow-approval/package/api_key/domain"
+	"workflow-approval/package/api_key/ports"
+	"workflow-approval/package/api_key/repository"
+	userDomain "workflow-approval/package/user/domain"
+	"workflow-approval/utils"
+	"workflow-approval/utils/tenancy"
+)
+
+var (
+	ErrAPIKeyNameRequired        = errors.New("api key name is required")
+	ErrAPIKeyPermissionsRequired = errors.New("at least one permission is required")
+	ErrAPIKeyUnknownPermission   = errors.New("unknown permission")
+	ErrAPIKeyExpiryInPast        = errors.New("expires_at must be in the future")
+	ErrAPIKeyWorkflowNotFound    = errors.New("workflow in api key scope not found")
+	ErrAPIKeyNotFound            = errors.New("api key not found")
+	ErrAPIKeyAlreadyRevoked      = errors.New("api key is already revoked")
+	ErrInvalidAPIKey             = errors.New("invalid api key")
+	ErrAPIKeyRevoked             = errors.New("api key has been revoked")
+	ErrAPIKeyExpired             = errors.New("api key has expired")
+	ErrOnBehalfNotPermitted      = errors.New("api key is not permitted to act on behalf of users")
+	ErrOnBehalfUserNotFound      = errors.New("on-behalf-of user not found")
+	ErrOnBehalfUserInactive      = errors.New("on-behalf-of user is deactivated")
+)
+
+// lastUsedResolution limits how often last_used_at is written for a busy key
+const lastUsedResolution = time.Minute
+
+// APIKeyServiceImpl implements APIKeyService interface
+type APIKeyServiceImpl struct {
+	apiKeyRepo   ports.APIKeyRepository
+	userRepo     ports.UserRepository
+	workflowRepo ports.WorkflowRepository
+}
+
+// NewAPIKeyService creates a new APIKeyServiceImpl instance
+func NewAPIKeyService(apiKeyRepo ports.APIKeyRepository, userRepo ports.UserRepository, workflowRepo ports.WorkflowRepository) ports.APIKeyService {
+	return &APIKeyServiceImpl{
+		apiKeyRepo:   apiKeyRepo,
+		userRepo:     userRepo,
+		workflowRepo: workflowRepo,
+	}
+}
+
+// CreateAPIKey creates a new API key and returns the plaintext key exactly once
+func (s *APIKeyServiceImpl) CreateAPIKey(ctx context.Context, name string, permissions, workflowIDs []string, expiresAt *time.Time, createdBy string) (*domain.APIKey, string, error) {
+	if strings.TrimSpace(name) == "" {
+		return nil, "", ErrAPIKeyNameRequired
+	}
+	if len(permissions) == 0 {
+		return nil, "", ErrAPIKeyPermissionsRequired
+	}
+	for _, p := range permissions {
+		if !isKnownPermission(p) {
+			return nil, "", ErrAPIKeyUnknownPermission
+		}
+	}
+	if expiresAt != nil && !expiresAt.After(utils.TimeNowUTC()) {
+		return nil, "", ErrAPIKeyExpiryInPast
+	}
+
+	// Validate workflow scope
+	for _, workflowID := range workflowIDs {
+		if _, err := s.workflowRepo.GetByID(ctx, workflowID); err != nil {
+			return nil, "", ErrAPIKeyWorkflowNotFound
+		}
+	}
+
+	prefix, secret, err := generateKeyMaterial()
+	if err != nil {
+		return nil, "", err
+	}
+	rawKey := domain.KeyPrefix + "_" + prefix + "_" + secret
+
+	key := domain.NewAPIKey(name, prefix, hashKey(rawKey), permissions, workflowIDs, createdBy, expiresAt)
+	if err := s.apiKeyRepo.Create(ctx, key); err != nil {
+		return nil, "", err
+	}
+
+	return key, rawKey, nil
+}
+
+// GetAPIKey retrieves an API key by ID
+func (s *APIKeyServiceImpl) GetAPIKey(ctx context.Context, id string) (*domain.APIKey, error) {
+	key, err := s.apiKeyRepo.GetByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, repository.ErrAPIKeyNotFound) {
+			return nil, ErrAPIKeyNotFound
+		}
+		return nil, err
+	}
+	return key, nil
+}
+
+// ListAPIKeys retrieves all API keys
+func (s *APIKeyServiceImpl) ListAPIKeys(ctx context.Context) ([]*domain.APIKey, error) {
+	return s.apiKeyRepo.List(ctx)
+}
+
+// RevokeAPIKey revokes an API key; revoked keys are kept for auditing
+func (s *APIKeyServiceImpl) RevokeAPIKey(ctx context.Context, id string) (*domain.APIKey, error) {
+	key, err := s.GetAPIKey(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if key.IsRevoked() {
+		return nil, ErrAPIKeyAlreadyRevoked
+	}
+
+	now := utils.TimeNowUTC()
+	key.RevokedAt = &now
+	if err := s.apiKeyRepo.Update(ctx, key); err != nil {
+		return nil, err
+	}
+	return key, nil
+}
+
+// Authenticate validates a raw API key of the form wfa_<prefix>_<secret>
+func (s *APIKeyServiceImpl) Authenticate(ctx context.Context, rawKey string) (*domain.APIKey, error) {
+	parts := strings.SplitN(rawKey, "_", 3)
+	if len(parts) != 3 || parts[0] != domain.KeyPrefix || parts[1] == "" || parts[2] == "" {
+		return nil, ErrInvalidAPIKey
+	}
+
+	// The key decides the tenant, so it is looked up across tenants
+	key, err := s.apiKeyRepo.GetByPrefix(tenancy.WithAllTenants(ctx), parts[1])
+	if err != nil {
+		if errors.Is(err, repository.ErrAPIKeyNotFound) {
+			return nil, ErrInvalidAPIKey
+		}
+		return nil, err
+	}
+
+	// Compare hashes in constant time
+	if subtle.ConstantTimeCompare([]byte(hashKey(rawKey)), []byte(key.KeyHash)) != 1 {
+		return nil, ErrInvalidAPIKey
+	}
+
+	now := utils.TimeNowUTC()
+	if key.IsRevoked() {
+		return nil, ErrAPIKeyRevoked
+	}
+	if key.IsExpired(now) {
+		return nil, ErrAPIKeyExpired
+	}
+
+	// Track usage, but avoid a write on every single call
+	if key.LastUsedAt == nil || now.Sub(*key.LastUsedAt) >= lastUsedResolution {
+		if err := s.apiKeyRepo.TouchLastUsed(tenancy.WithTenant(ctx, key.TenantID), key.ID, now); err == nil {
+			key.LastUsedAt = &now
+		}
+	}
+
+	return key, nil
+}
+
+// ResolveOnBehalfOf returns the user the key acts for when creating requests
+func (s *APIKeyServiceImpl) ResolveOnBehalfOf(ctx context.Context, key *domain.APIKey, userID string) (*userDomain.User, error) {
+	if !key.HasPermission(domain.PermissionRequestsCreateOnBehalf) {
+		return nil, ErrOnBehalfNotPermitted
+	}
+
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, ErrOnBehalfUserNotFound
+	}
+	if !user.IsActive {
+		return nil, ErrOnBehalfUserInactive
+	}
+	return user, nil
+}
+
+// generateKeyMaterial returns a random public prefix and secret part for a new key
+func generateKeyMaterial() (string, string, error) {
+	prefixBytes := make([]byte, 6)
+	if _, err := rand.Read(prefixBytes); err != nil {
+		return "", "", err
+	}
+	secretBytes := make([]byte, 32)
+	if _, err := rand.Read(secretBytes); err != nil {
+		return "", "", err
+	}
+	// The prefix is hex so it never contains "_", keeping wfa_<prefix>_<secret> parseable
+	return hex.EncodeToString(prefixBytes), base64.RawURLEncoding.EncodeToString(secretBytes), nil
+}
+
+// hashKey returns the hex SHA-256 digest stored for a key
+func hashKey(rawKey string) string {
+	sum := sha256.Sum256([]byte(rawKey))
+	return hex.EncodeToString(sum[:])
+}
+
+// isKnownPermission checks a permission against the grantable set, allowing "<resource>:*"
+func isKnownPermission(permission string) bool {
+	for _, p := range domain.AllPermissions {
+		if p == permission {
+			return true
+		}
+		if strings.HasSuffix(permission, ":*") && strings.HasPrefix(p, strings.TrimSuffix(permission, "*")) {
+			return true
+		}
+	}
+	return false
+}
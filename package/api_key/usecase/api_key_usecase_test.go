@@ -0,0 +1,258 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"workflow-approval/package/api_key/domain"
+	"workflow-approval/package/api_key/ports"
+	"workflow-approval/package/api_key/repository"
+	userDomain "workflow-approval/package/user/domain"
+	wfDomain "workflow-approval/package/workflow/domain"
+	"workflow-approval/utils"
+)
+
+// MockAPIKeyRepository implements APIKeyRepository for testing
+type MockAPIKeyRepository struct {
+	keys    map[string]*domain.APIKey
+	touched int
+}
+
+func NewMockAPIKeyRepository() *MockAPIKeyRepository {
+	return &MockAPIKeyRepository{keys: make(map[string]*domain.APIKey)}
+}
+
+func (m *MockAPIKeyRepository) Create(ctx context.Context, key *domain.APIKey) error {
+	m.keys[key.ID] = key
+	return nil
+}
+
+func (m *MockAPIKeyRepository) GetByID(ctx context.Context, id string) (*domain.APIKey, error) {
+	if k, ok := m.keys[id]; ok {
+		return k, nil
+	}
+	return nil, repository.ErrAPIKeyNotFound
+}
+
+func (m *MockAPIKeyRepository) GetByPrefix(ctx context.Context, prefix string) (*domain.APIKey, error) {
+	for _, k := range m.keys {
+		if k.Prefix == prefix {
+			return k, nil
+		}
+	}
+	return nil, repository.ErrAPIKeyNotFound
+}
+
+func (m *MockAPIKeyRepository) List(ctx context.Context) ([]*domain.APIKey, error) {
+	keys := make([]*domain.APIKey, 0, len(m.keys))
+	for _, k := range m.keys {
+		keys = append(keys, k)
+	}
+	return keys, nil
+}
+
+func (m *MockAPIKeyRepository) Update(ctx context.Context, key *domain.APIKey) error {
+	m.keys[key.ID] = key
+	return nil
+}
+
+func (m *MockAPIKeyRepository) TouchLastUsed(ctx context.Context, id string, usedAt time.Time) error {
+	m.touched++
+	return nil
+}
+
+// MockUserRepository implements UserRepository for testing
+type MockUserRepository struct {
+	users map[string]*userDomain.User
+}
+
+func (m *MockUserRepository) GetByID(ctx context.Context, id string) (*userDomain.User, error) {
+	if u, ok := m.users[id]; ok {
+		return u, nil
+	}
+	return nil, errors.New("user not found")
+}
+
+// MockWorkflowRepository implements WorkflowRepository for testing
+type MockWorkflowRepository struct {
+	ids map[string]bool
+}
+
+func (m *MockWorkflowRepository) GetByID(ctx context.Context, id string) (*wfDomain.Workflow, error) {
+	if m.ids[id] {
+		return &wfDomain.Workflow{ID: id}, nil
+	}
+	return nil, errors.New("workflow not found")
+}
+
+// Interface compliance
+var (
+	_ ports.APIKeyRepository   = (*MockAPIKeyRepository)(nil)
+	_ ports.UserRepository     = (*MockUserRepository)(nil)
+	_ ports.WorkflowRepository = (*MockWorkflowRepository)(nil)
+)
+
+func newTestAPIKeyService(repo *MockAPIKeyRepository, users map[string]*userDomain.User) ports.APIKeyService {
+	return NewAPIKeyService(repo, &MockUserRepository{users: users}, &MockWorkflowRepository{ids: map[string]bool{"wf-1": true}})
+}
+
+func TestCreateAPIKey(t *testing.T) {
+	ctx := context.Background()
+	repo := NewMockAPIKeyRepository()
+	service := newTestAPIKeyService(repo, nil)
+
+	key, rawKey, err := service.CreateAPIKey(ctx, "billing", []string{domain.PermissionRequestsWrite}, []string{"wf-1"}, nil, "admin-1")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if !strings.HasPrefix(rawKey, domain.KeyPrefix+"_"+key.Prefix+"_") {
+		t.Errorf("Expected the raw key to start with wfa_<prefix>_, got %q (prefix %q)", rawKey, key.Prefix)
+	}
+	if key.KeyHash != hashKey(rawKey) || strings.Contains(key.KeyHash, rawKey) {
+		t.Errorf("Expected only the SHA-256 of the key stored, got %q", key.KeyHash)
+	}
+	if strings.Contains(key.Prefix, "_") {
+		t.Errorf("Expected a prefix without separators, got %q", key.Prefix)
+	}
+
+	past := utils.TimeNowUTC().Add(-time.Hour)
+	tests := []struct {
+		name        string
+		permissions []string
+		workflowIDs []string
+		expiresAt   *time.Time
+		want        error
+	}{
+		{"No permissions", nil, nil, nil, ErrAPIKeyPermissionsRequired},
+		{"Unknown permission", []string{"requests:delete"}, nil, nil, ErrAPIKeyUnknownPermission},
+		{"Unknown workflow in scope", []string{domain.PermissionRequestsRead}, []string{"wf-missing"}, nil, ErrAPIKeyWorkflowNotFound},
+		{"Expiry in the past", []string{domain.PermissionRequestsRead}, nil, &past, ErrAPIKeyExpiryInPast},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, _, err := service.CreateAPIKey(ctx, "key", tt.permissions, tt.workflowIDs, tt.expiresAt, "admin-1"); err != tt.want {
+				t.Errorf("Expected %v, got %v", tt.want, err)
+			}
+		})
+	}
+}
+
+func TestAuthenticateAPIKey(t *testing.T) {
+	ctx := context.Background()
+	repo := NewMockAPIKeyRepository()
+	service := newTestAPIKeyService(repo, nil)
+
+	key, rawKey, err := service.CreateAPIKey(ctx, "billing", []string{domain.PermissionRequestsRead}, nil, nil, "admin-1")
+	if err != nil {
+		t.Fatalf("CreateAPIKey failed: %v", err)
+	}
+
+	authenticated, err := service.Authenticate(ctx, rawKey)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if authenticated.ID != key.ID || authenticated.LastUsedAt == nil || repo.touched != 1 {
+		t.Errorf("Expected key %s authenticated and its use recorded", key.ID)
+	}
+	if _, err := service.Authenticate(ctx, rawKey); err != nil || repo.touched != 1 {
+		t.Errorf("Expected a second use within a minute not to be written again, got %v and %d writes", err, repo.touched)
+	}
+
+	// The prefix finds the key, but the whole key must match its hash
+	forged := rawKey[:len(rawKey)-1] + "x"
+	if strings.HasSuffix(rawKey, "x") {
+		forged = rawKey[:len(rawKey)-1] + "y"
+	}
+	for _, raw := range []string{forged, "wfa_000000000000_secret", "wfa_" + key.Prefix, "sk_" + key.Prefix + "_secret", ""} {
+		if _, err := service.Authenticate(ctx, raw); err != ErrInvalidAPIKey {
+			t.Errorf("Authenticate(%q): expected ErrInvalidAPIKey, got %v", raw, err)
+		}
+	}
+
+	t.Run("Expired key", func(t *testing.T) {
+		_, rawKey, err := service.CreateAPIKey(ctx, "expiring", []string{domain.PermissionRequestsRead}, nil, nil, "admin-1")
+		if err != nil {
+			t.Fatalf("CreateAPIKey failed: %v", err)
+		}
+		stored, _ := repo.GetByPrefix(ctx, strings.Split(rawKey, "_")[1])
+		expired := utils.TimeNowUTC().Add(-time.Second)
+		stored.ExpiresAt = &expired
+
+		if _, err := service.Authenticate(ctx, rawKey); err != ErrAPIKeyExpired {
+			t.Errorf("Expected ErrAPIKeyExpired, got %v", err)
+		}
+	})
+
+	t.Run("Revoked key", func(t *testing.T) {
+		if _, err := service.RevokeAPIKey(ctx, key.ID); err != nil {
+			t.Fatalf("RevokeAPIKey failed: %v", err)
+		}
+		if _, err := service.Authenticate(ctx, rawKey); err != ErrAPIKeyRevoked {
+			t.Errorf("Expected ErrAPIKeyRevoked, got %v", err)
+		}
+		if _, err := service.RevokeAPIKey(ctx, key.ID); err != ErrAPIKeyAlreadyRevoked {
+			t.Errorf("Expected ErrAPIKeyAlreadyRevoked, got %v", err)
+		}
+	})
+}
+
+func TestResolveOnBehalfOf(t *testing.T) {
+	ctx := context.Background()
+	active := userDomain.NewUser("requester@example.com", "", "Requester", false, nil)
+	inactive := userDomain.NewUser("gone@example.com", "", "Gone", false, nil)
+	inactive.IsActive = false
+	service := newTestAPIKeyService(NewMockAPIKeyRepository(), map[string]*userDomain.User{active.ID: active, inactive.ID: inactive})
+
+	permitted := &domain.APIKey{Permissions: domain.StringList{domain.PermissionRequestsCreateOnBehalf}}
+	tests := []struct {
+		name   string
+		key    *domain.APIKey
+		userID string
+		want   error
+	}{
+		{"Permitted", permitted, active.ID, nil},
+		{"Key without permission", &domain.APIKey{Permissions: domain.StringList{domain.PermissionRequestsWrite}}, active.ID, ErrOnBehalfNotPermitted},
+		{"Unknown user", permitted, "missing", ErrOnBehalfUserNotFound},
+		{"Deactivated user", permitted, inactive.ID, ErrOnBehalfUserInactive},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			user, err := service.ResolveOnBehalfOf(ctx, tt.key, tt.userID)
+			if err != tt.want {
+				t.Fatalf("Expected %v, got %v", tt.want, err)
+			}
+			if err == nil && user.ID != tt.userID {
+				t.Errorf("Expected user %s, got %s", tt.userID, user.ID)
+			}
+		})
+	}
+}
+
+func TestAPIKeyScopes(t *testing.T) {
+	key := &domain.APIKey{
+		Permissions: domain.StringList{domain.PermissionRequestsRead, "workflows:*"},
+		WorkflowIDs: domain.StringList{"wf-1", "wf-2"},
+	}
+
+	for permission, want := range map[string]bool{
+		domain.PermissionRequestsRead:           true,
+		domain.PermissionRequestsWrite:          false,
+		domain.PermissionRequestsCreateOnBehalf: false,
+		domain.PermissionWorkflowsWrite:         true,
+		domain.PermissionUsersRead:              false,
+	} {
+		if got := key.HasPermission(permission); got != want {
+			t.Errorf("HasPermission(%s): expected %v, got %v", permission, want, got)
+		}
+	}
+
+	if !key.AllowsWorkflow("wf-1") || key.AllowsWorkflow("wf-3") {
+		t.Errorf("Expected the key limited to wf-1 and wf-2")
+	}
+	if unscoped := (&domain.APIKey{}); !unscoped.AllowsWorkflow("wf-3") {
+		t.Errorf("Expected a key without workflow scope to allow every workflow")
+	}
+}
@@ -18,6 +18,7 @@ const (
 // Tracks who approved/rejected, when, which workflow step, and any comments
 type ApprovalHistory struct {
 	ID         string         `json:"id" gorm:"primaryKey;size:36"`
+	TenantID   string         `json:"tenant_id" gorm:"size:36;not null;index"`
 	RequestID  string         `json:"request_id" gorm:"size:36;not null;index"`
 	WorkflowID string         `json:"workflow_id" gorm:"size:36;not null"`
 	StepLevel  int            `json:"step_level" gorm:"not null"`
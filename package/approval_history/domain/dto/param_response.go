@@ -34,6 +34,7 @@ func ToApprovalHistoryResponse(h *domain.ApprovalHistory, actorName, actorCode,
 		ActorID:      h.ActorID,
 		ActorName:    actorName,
 		ActorCode:    actorCode,
+		UserID:       h.UserID,
 		Action:       string(h.Action),
 		Comment:      h.Comment,
 		CreatedAt:    h.CreatedAt.Format("2006-01-02 15:04:05"),
@@ -5,6 +5,7 @@ import (
 
 	"workflow-approval/package/approval_history/domain"
 	"workflow-approval/package/approval_history/domain/dto"
+	"workflow-approval/utils/pagination"
 )
 
 // ApprovalHistoryRepository defines the interface for approval history data access
@@ -25,6 +26,12 @@ type ApprovalHistoryRepository interface {
 
 	// GetByRequestIDOrdered retrieves all approval history for a request ordered by created_at ascending
 	GetByRequestIDOrdered(ctx context.Context, requestID string) ([]*domain.ApprovalHistory, error)
+
+	// ListByRequestID retrieves a page of approval history for a request ordered by created_at ascending
+	ListByRequestID(ctx context.Context, requestID string, page pagination.Page) ([]*domain.ApprovalHistory, pagination.Result, error)
+
+	// ListByRequestIDs retrieves the approval history of several requests ordered by created_at ascending
+	ListByRequestIDs(ctx context.Context, requestIDs []string) ([]*domain.ApprovalHistory, error)
 }
 
 // ApprovalHistoryService defines the interface for approval history business logic
@@ -34,6 +41,9 @@ type ApprovalHistoryService interface {
 	// GetHistoryByRequestID retrieves all approval history for a request with details
 	GetHistoryByRequestID(ctx context.Context, requestID string) ([]*dto.ApprovalHistoryResponse, error)
 
+	// ListHistoryByRequestID retrieves a page of approval history for a request, oldest first
+	ListHistoryByRequestID(ctx context.Context, requestID string, page pagination.Page) ([]*dto.ApprovalHistoryResponse, pagination.Result, error)
+
 	// CreateApprovalHistory creates a new approval history entry
 	CreateApprovalHistory(ctx context.Context, requestID, workflowID string, stepLevel int, actorID, userID string, action domain.ApprovalAction, comment string) error
 }
@@ -5,6 +5,7 @@ package mocks
 import (
 	context "context"
 	domain "workflow-approval/package/approval_history/domain"
+	pagination "workflow-approval/utils/pagination"
 
 	mock "github.com/stretchr/testify/mock"
 )
@@ -248,6 +249,108 @@ func (_c *ApprovalHistoryRepository_GetByRequestIDOrdered_Call) Return(_a0 []*do
 	return _c
 }
 
+// ListByRequestID provides a mock function with given fields: ctx, requestID, page
+func (_m *ApprovalHistoryRepository) ListByRequestID(ctx context.Context, requestID string, page pagination.Page) ([]*domain.ApprovalHistory, pagination.Result, error) {
+	ret := _m.Called(ctx, requestID, page)
+
+	var r0 []*domain.ApprovalHistory
+	if rf, ok := ret.Get(0).(func(context.Context, string, pagination.Page) []*domain.ApprovalHistory); ok {
+		r0 = rf(ctx, requestID, page)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*domain.ApprovalHistory)
+		}
+	}
+
+	var r1 pagination.Result
+	if rf, ok := ret.Get(1).(func(context.Context, string, pagination.Page) pagination.Result); ok {
+		r1 = rf(ctx, requestID, page)
+	} else {
+		r1 = ret.Get(1).(pagination.Result)
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(context.Context, string, pagination.Page) error); ok {
+		r2 = rf(ctx, requestID, page)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// ApprovalHistoryRepository_ListByRequestID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListByRequestID'
+type ApprovalHistoryRepository_ListByRequestID_Call struct {
+	*mock.Call
+}
+
+// ListByRequestID is a helper method to define mock.On call
+//  - ctx context.Context
+//  - requestID string
+//  - page pagination.Page
+func (_e *ApprovalHistoryRepository_Expecter) ListByRequestID(ctx interface{}, requestID interface{}, page interface{}) *ApprovalHistoryRepository_ListByRequestID_Call {
+	return &ApprovalHistoryRepository_ListByRequestID_Call{Call: _e.mock.On("ListByRequestID", ctx, requestID, page)}
+}
+
+func (_c *ApprovalHistoryRepository_ListByRequestID_Call) Run(run func(ctx context.Context, requestID string, page pagination.Page)) *ApprovalHistoryRepository_ListByRequestID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(pagination.Page))
+	})
+	return _c
+}
+
+func (_c *ApprovalHistoryRepository_ListByRequestID_Call) Return(_a0 []*domain.ApprovalHistory, _a1 pagination.Result, _a2 error) *ApprovalHistoryRepository_ListByRequestID_Call {
+	_c.Call.Return(_a0, _a1, _a2)
+	return _c
+}
+
+// ListByRequestIDs provides a mock function with given fields: ctx, requestIDs
+func (_m *ApprovalHistoryRepository) ListByRequestIDs(ctx context.Context, requestIDs []string) ([]*domain.ApprovalHistory, error) {
+	ret := _m.Called(ctx, requestIDs)
+
+	var r0 []*domain.ApprovalHistory
+	if rf, ok := ret.Get(0).(func(context.Context, []string) []*domain.ApprovalHistory); ok {
+		r0 = rf(ctx, requestIDs)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*domain.ApprovalHistory)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, []string) error); ok {
+		r1 = rf(ctx, requestIDs)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ApprovalHistoryRepository_ListByRequestIDs_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListByRequestIDs'
+type ApprovalHistoryRepository_ListByRequestIDs_Call struct {
+	*mock.Call
+}
+
+// ListByRequestIDs is a helper method to define mock.On call
+//  - ctx context.Context
+//  - requestIDs []string
+func (_e *ApprovalHistoryRepository_Expecter) ListByRequestIDs(ctx interface{}, requestIDs interface{}) *ApprovalHistoryRepository_ListByRequestIDs_Call {
+	return &ApprovalHistoryRepository_ListByRequestIDs_Call{Call: _e.mock.On("ListByRequestIDs", ctx, requestIDs)}
+}
+
+func (_c *ApprovalHistoryRepository_ListByRequestIDs_Call) Run(run func(ctx context.Context, requestIDs []string)) *ApprovalHistoryRepository_ListByRequestIDs_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]string))
+	})
+	return _c
+}
+
+func (_c *ApprovalHistoryRepository_ListByRequestIDs_Call) Return(_a0 []*domain.ApprovalHistory, _a1 error) *ApprovalHistoryRepository_ListByRequestIDs_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
 type mockConstructorTestingTNewApprovalHistoryRepository interface {
 	mock.TestingT
 	Cleanup(func())
@@ -6,6 +6,7 @@ import (
 	context "context"
 	domain "workflow-approval/package/approval_history/domain"
 	dto "workflow-approval/package/approval_history/domain/dto"
+	pagination "workflow-approval/utils/pagination"
 
 	mock "github.com/stretchr/testify/mock"
 )
@@ -114,6 +115,61 @@ func (_c *ApprovalHistoryService_GetHistoryByRequestID_Call) Return(_a0 []*dto.A
 	return _c
 }
 
+// ListHistoryByRequestID provides a mock function with given fields: ctx, requestID, page
+func (_m *ApprovalHistoryService) ListHistoryByRequestID(ctx context.Context, requestID string, page pagination.Page) ([]*dto.ApprovalHistoryResponse, pagination.Result, error) {
+	ret := _m.Called(ctx, requestID, page)
+
+	var r0 []*dto.ApprovalHistoryResponse
+	if rf, ok := ret.Get(0).(func(context.Context, string, pagination.Page) []*dto.ApprovalHistoryResponse); ok {
+		r0 = rf(ctx, requestID, page)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*dto.ApprovalHistoryResponse)
+		}
+	}
+
+	var r1 pagination.Result
+	if rf, ok := ret.Get(1).(func(context.Context, string, pagination.Page) pagination.Result); ok {
+		r1 = rf(ctx, requestID, page)
+	} else {
+		r1 = ret.Get(1).(pagination.Result)
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(context.Context, string, pagination.Page) error); ok {
+		r2 = rf(ctx, requestID, page)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// ApprovalHistoryService_ListHistoryByRequestID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListHistoryByRequestID'
+type ApprovalHistoryService_ListHistoryByRequestID_Call struct {
+	*mock.Call
+}
+
+// ListHistoryByRequestID is a helper method to define mock.On call
+//  - ctx context.Context
+//  - requestID string
+//  - page pagination.Page
+func (_e *ApprovalHistoryService_Expecter) ListHistoryByRequestID(ctx interface{}, requestID interface{}, page interface{}) *ApprovalHistoryService_ListHistoryByRequestID_Call {
+	return &ApprovalHistoryService_ListHistoryByRequestID_Call{Call: _e.mock.On("ListHistoryByRequestID", ctx, requestID, page)}
+}
+
+func (_c *ApprovalHistoryService_ListHistoryByRequestID_Call) Run(run func(ctx context.Context, requestID string, page pagination.Page)) *ApprovalHistoryService_ListHistoryByRequestID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(pagination.Page))
+	})
+	return _c
+}
+
+func (_c *ApprovalHistoryService_ListHistoryByRequestID_Call) Return(_a0 []*dto.ApprovalHistoryResponse, _a1 pagination.Result, _a2 error) *ApprovalHistoryService_ListHistoryByRequestID_Call {
+	_c.Call.Return(_a0, _a1, _a2)
+	return _c
+}
+
 type mockConstructorTestingTNewApprovalHistoryService interface {
 	mock.TestingT
 	Cleanup(func())
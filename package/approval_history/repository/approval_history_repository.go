@@ -3,11 +3,13 @@ package repository
 import (
 	"context"
 	"errors"
+	"time"
 
 	"gorm.io/gorm"
 
 	"workflow-approval/package/approval_history/domain"
 	"workflow-approval/package/approval_history/ports"
+	"workflow-approval/utils/pagination"
 )
 
 // ApprovalHistoryRepositoryImpl implements ApprovalHistoryRepository interface
@@ -68,5 +70,41 @@ func (r *ApprovalHistoryRepositoryImpl) GetByRequestIDOrdered(ctx context.Contex
 	return histories, err
 }
 
+// ListByRequestIDs retrieves the approval history of several requests ordered by created_at ascending
+func (r *ApprovalHistoryRepositoryImpl) ListByRequestIDs(ctx context.Context, requestIDs []string) ([]*domain.ApprovalHistory, error) {
+	var histories []*domain.ApprovalHistory
+	if len(requestIDs) == 0 {
+		return histories, nil
+	}
+	err := r.db.WithContext(ctx).
+		Where("request_id IN ?", requestIDs).
+		Order("created_at ASC").
+		Find(&histories).Error
+	return histories, err
+}
+
+// ListByRequestID retrieves a page of approval history for a request ordered by created_at ascending
+func (r *ApprovalHistoryRepositoryImpl) ListByRequestID(ctx context.Context, requestID string, page pagination.Page) ([]*domain.ApprovalHistory, pagination.Result, error) {
+	var histories []*domain.ApprovalHistory
+	var total int64
+
+	query := r.db.WithContext(ctx).Model(&domain.ApprovalHistory{}).Where("request_id = ?", requestID)
+	if page.WithTotal {
+		if err := query.Count(&total).Error; err != nil {
+			return nil, pagination.Result{}, err
+		}
+	}
+
+	if err := pagination.Apply(query, page, false).Find(&histories).Error; err != nil {
+		return nil, pagination.Result{}, err
+	}
+
+	histories, result := pagination.Finish(histories, page, func(h *domain.ApprovalHistory) (time.Time, string) {
+		return h.CreatedAt, h.ID
+	})
+	result.Total = total
+	return histories, result, nil
+}
+
 // ErrApprovalHistoryNotFound is returned when the approval history is not found
 var ErrApprovalHistoryNotFound = gorm.ErrRecordNotFound
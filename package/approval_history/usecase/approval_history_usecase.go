@@ -6,6 +6,7 @@ import (
 	"workflow-approval/package/approval_history/domain"
 	"workflow-approval/package/approval_history/domain/dto"
 	"workflow-approval/package/approval_history/ports"
+	"workflow-approval/utils/pagination"
 )
 
 // ApprovalHistoryServiceImpl implements ApprovalHistoryService interface
@@ -30,6 +31,23 @@ func (s *ApprovalHistoryServiceImpl) GetHistoryByRequestID(
 		return nil, err
 	}
 
+	return toHistoryResponses(histories), nil
+}
+
+// ListHistoryByRequestID retrieves a page of approval history for a request, oldest first
+func (s *ApprovalHistoryServiceImpl) ListHistoryByRequestID(
+	ctx context.Context,
+	requestID string,
+	page pagination.Page,
+) ([]*dto.ApprovalHistoryResponse, pagination.Result, error) {
+	histories, result, err := s.historyRepo.ListByRequestID(ctx, requestID, page.Normalized())
+	if err != nil {
+		return nil, pagination.Result{}, err
+	}
+	return toHistoryResponses(histories), result, nil
+}
+
+func toHistoryResponses(histories []*domain.ApprovalHistory) []*dto.ApprovalHistoryResponse {
 	responses := make([]*dto.ApprovalHistoryResponse, len(histories))
 	for i, h := range histories {
 		responses[i] = &dto.ApprovalHistoryResponse{
@@ -44,8 +62,7 @@ func (s *ApprovalHistoryServiceImpl) GetHistoryByRequestID(
 			CreatedAt:  h.CreatedAt.Format("2006-01-02 15:04:05"),
 		}
 	}
-
-	return responses, nil
+	return responses
 }
 
 // CreateApprovalHistory creates a new approval history entry
@@ -0,0 +1,87 @@
+package domain
+
+import (
+	"strings"
+	"time"
+
+	"workflow-approval/utils"
+)
+
+// Attachment is a file uploaded to a request, e.g. a quote or an invoice.
+// The content lives in the configured storage under StorageKey.
+type Attachment struct {
+	ID          string    `json:"id" gorm:"primaryKey;size:36"`
+	TenantID    string    `json:"tenant_id" gorm:"size:36;not null;index"`
+	RequestID   string    `json:"request_id" gorm:"size:36;not null;index"`
+	FileName    string    `json:"file_name" gorm:"size:255;not null"`
+	ContentType string    `json:"content_type" gorm:"size:100;not null"`
+	Size        int64     `json:"size" gorm:"not null"`
+	SHA256      string    `json:"sha256" gorm:"column:sha256;size:64;not null"` // Hex digest of the content
+	StorageKey  string    `json:"-" gorm:"size:255;not null"`
+	UploadedBy  string    `json:"uploaded_by" gorm:"size:36;not null"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+// NewAttachment creates a new Attachment instance stored under tenant/request/id
+func NewAttachment(tenantID, requestID, fileName, contentType, uploadedBy string) *Attachment {
+	id := utils.GenerateUUID()
+	return &Attachment{
+		ID:          id,
+		TenantID:    tenantID,
+		RequestID:   requestID,
+		FileName:    fileName,
+		ContentType: contentType,
+		StorageKey:  tenantID + "/" + requestID + "/" + id,
+		UploadedBy:  uploadedBy,
+		CreatedAt:   utils.TimeNowUTC(),
+	}
+}
+
+// TableName returns the table name for GORM
+func (Attachment) TableName() string {
+	return "attachments"
+}
+
+// AttachmentPolicy limits what can be uploaded
+type AttachmentPolicy struct {
+	MaxSize      int64    // Bytes per file
+	AllowedTypes []string // MIME types; "image/*" allows a whole family
+}
+
+// DefaultAttachmentPolicy returns the policy used when nothing is configured
+func DefaultAttachmentPolicy() AttachmentPolicy {
+	return AttachmentPolicy{
+		MaxSize: 10 << 20,
+		AllowedTypes: []string{
+			"application/pdf",
+			"image/*",
+			"text/plain",
+			"text/csv",
+			"application/vnd.openxmlformats-officedocument.spreadsheetml.sheet",
+			"application/vnd.openxmlformats-officedocument.wordprocessingml.document",
+		},
+	}
+}
+
+// AllowsType reports whether files of the content type may be uploaded
+func (p AttachmentPolicy) AllowsType(contentType string) bool {
+	contentType = baseType(contentType)
+	for _, allowed := range p.AllowedTypes {
+		allowed = strings.ToLower(strings.TrimSpace(allowed))
+		if allowed == contentType {
+			return true
+		}
+		if family, ok := strings.CutSuffix(allowed, "/*"); ok && strings.HasPrefix(contentType, family+"/") {
+			return true
+		}
+	}
+	return false
+}
+
+// baseType strips parameters such as "; charset=utf-8" from a MIME type
+func baseType(contentType string) string {
+	if i := strings.IndexByte(contentType, ';'); i >= 0 {
+		contentType = contentType[:i]
+	}
+	return strings.ToLower(strings.TrimSpace(contentType))
+}
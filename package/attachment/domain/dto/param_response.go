@@ -0,0 +1,41 @@
+package dto
+
+import "workflow-approval/package/attachment/domain"
+
+// AttachmentResponse represents the attachment response
+type AttachmentResponse struct {
+	ID          string `json:"id"`
+	RequestID   string `json:"request_id"`
+	FileName    string `json:"file_name"`
+	ContentType string `json:"content_type"`
+	Size        int64  `json:"size"`
+	SHA256      string `json:"sha256"`
+	UploadedBy  string `json:"uploaded_by"`
+	CreatedAt   string `json:"created_at"`
+}
+
+// ToAttachmentResponse converts an Attachment to AttachmentResponse
+func ToAttachmentResponse(a *domain.Attachment) *AttachmentResponse {
+	if a == nil {
+		return nil
+	}
+	return &AttachmentResponse{
+		ID:          a.ID,
+		RequestID:   a.RequestID,
+		FileName:    a.FileName,
+		ContentType: a.ContentType,
+		Size:        a.Size,
+		SHA256:      a.SHA256,
+		UploadedBy:  a.UploadedBy,
+		CreatedAt:   a.CreatedAt.Format("2006-01-02T15:04:05Z"),
+	}
+}
+
+// ToAttachmentResponseList converts a list of Attachment to AttachmentResponse
+func ToAttachmentResponseList(attachments []*domain.Attachment) []*AttachmentResponse {
+	responses := make([]*AttachmentResponse, len(attachments))
+	for i, a := range attachments {
+		responses[i] = ToAttachmentResponse(a)
+	}
+	return responses
+}
@@ -0,0 +1,181 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+
+	"workflow-approval/framework/middleware"
+	"workflow-approval/package/attachment/domain/dto"
+	"workflow-approval/package/attachment/ports"
+	"workflow-approval/package/attachment/usecase"
+	reqPorts "workflow-approval/package/request/ports"
+)
+
+// AttachmentHandler handles HTTP requests for request attachments
+type AttachmentHandler struct {
+	attachmentService ports.AttachmentService
+	requestService    reqPorts.RequestService
+}
+
+// NewAttachmentHandler creates a new AttachmentHandler instance
+func NewAttachmentHandler(attachmentService ports.AttachmentService, requestService reqPorts.RequestService) *AttachmentHandler {
+	return &AttachmentHandler{
+		attachmentService: attachmentService,
+		requestService:    requestService,
+	}
+}
+
+// Routes defines all routes for attachments
+// Mounts routes under /api/requests/:id/attachments
+func (h *AttachmentHandler) Routes(group fiber.Router) {
+	// GET /api/requests/:id/attachments - List the request's attachments
+	group.Get("", h.List)
+
+	// POST /api/requests/:id/attachments - Upload a file (multipart field "file"; only if DRAFT or PENDING)
+	group.Post("", h.Upload)
+
+	// GET /api/requests/:id/attachments/:attachmentId - Download an attachment
+	group.Get("/:attachmentId", h.Download)
+
+	// DELETE /api/requests/:id/attachments/:attachmentId - Delete an attachment (only if DRAFT or PENDING)
+	group.Delete("/:attachmentId", h.Delete)
+}
+
+// List handles listing the attachments of a request
+// GET /api/requests/:id/attachments
+func (h *AttachmentHandler) List(c *fiber.Ctx) error {
+	requestID := c.Params("id")
+	if !middleware.RequestVisible(c, h.requestService, requestID) {
+		return attachmentError(c, usecase.ErrRequestNotFound)
+	}
+
+	attachments, err := h.attachmentService.List(c.Context(), requestID)
+	if err != nil {
+		return attachmentError(c, err)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"success": true,
+		"data": fiber.Map{
+			"request_id":  requestID,
+			"attachments": dto.ToAttachmentResponseList(attachments),
+		},
+		"error": nil,
+	})
+}
+
+// Upload handles uploading a file to a request
+// POST /api/requests/:id/attachments
+func (h *AttachmentHandler) Upload(c *fiber.Ctx) error {
+	requestID := c.Params("id")
+	if !middleware.RequestVisible(c, h.requestService, requestID) {
+		return attachmentError(c, usecase.ErrRequestNotFound)
+	}
+
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"success": false,
+			"data":    nil,
+			"error":   "Multipart field \"file\" is required",
+		})
+	}
+	file, err := fileHeader.Open()
+	if err != nil {
+		return attachmentError(c, err)
+	}
+	defer file.Close()
+
+	userID := c.Locals("user_id").(string)
+	attachment, err := h.attachmentService.Upload(c.Context(), requestID, userID, fileHeader.Filename, fileHeader.Size, file)
+	if err != nil {
+		return attachmentError(c, err)
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
+		"success": true,
+		"data":    dto.ToAttachmentResponse(attachment),
+		"error":   nil,
+	})
+}
+
+// Download streams an attachment's content
+// GET /api/requests/:id/attachments/:attachmentId
+func (h *AttachmentHandler) Download(c *fiber.Ctx) error {
+	requestID := c.Params("id")
+	if !middleware.RequestVisible(c, h.requestService, requestID) {
+		return attachmentError(c, usecase.ErrRequestNotFound)
+	}
+
+	attachment, content, err := h.attachmentService.Open(c.Context(), requestID, c.Params("attachmentId"))
+	if err != nil {
+		return attachmentError(c, err)
+	}
+
+	// Always download, never render: uploaded HTML or SVG must not run in the API's origin
+	c.Set(fiber.HeaderContentType, attachment.ContentType)
+	c.Set(fiber.HeaderContentDisposition, fmt.Sprintf(`attachment; filename="%s"; filename*=UTF-8''%s`,
+		asciiFileName(attachment.FileName), url.PathEscape(attachment.FileName)))
+	c.Set(fiber.HeaderXContentTypeOptions, "nosniff")
+	c.Set(fiber.HeaderETag, strconv.Quote(attachment.SHA256))
+	return c.Status(fiber.StatusOK).SendStream(content, int(attachment.Size))
+}
+
+// Delete handles deleting an attachment
+// DELETE /api/requests/:id/attachments/:attachmentId
+func (h *AttachmentHandler) Delete(c *fiber.Ctx) error {
+	requestID := c.Params("id")
+	if !middleware.RequestVisible(c, h.requestService, requestID) {
+		return attachmentError(c, usecase.ErrRequestNotFound)
+	}
+
+	if err := h.attachmentService.Delete(c.Context(), requestID, c.Params("attachmentId")); err != nil {
+		return attachmentError(c, err)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"success": true,
+		"data":    fiber.Map{"message": "Attachment deleted successfully"},
+		"error":   nil,
+	})
+}
+
+// asciiFileName replaces characters that can't appear in a quoted header value
+func asciiFileName(name string) string {
+	out := []rune(name)
+	for i, r := range out {
+		if r > 0x7e || r < 0x20 || r == '"' || r == '\\' {
+			out[i] = '_'
+		}
+	}
+	return string(out)
+}
+
+// attachmentError maps attachment service errors to HTTP responses
+func attachmentError(c *fiber.Ctx, err error) error {
+	status := fiber.StatusInternalServerError
+	message := "Failed to process attachment"
+	switch {
+	case errors.Is(err, usecase.ErrRequestNotFound),
+		errors.Is(err, usecase.ErrAttachmentNotFound):
+		status, message = fiber.StatusNotFound, err.Error()
+	case errors.Is(err, usecase.ErrRequestNotEditable):
+		status, message = fiber.StatusConflict, err.Error()
+	case errors.Is(err, usecase.ErrFileTooLarge):
+		status, message = fiber.StatusRequestEntityTooLarge, err.Error()
+	case errors.Is(err, usecase.ErrFileTypeNotAllowed):
+		status, message = fiber.StatusUnsupportedMediaType, err.Error()
+	case errors.Is(err, usecase.ErrFileEmpty):
+		status, message = fiber.StatusBadRequest, err.Error()
+	}
+
+	return c.Status(status).JSON(fiber.Map{
+		"success": false,
+		"data":    nil,
+		"error":   message,
+	})
+}
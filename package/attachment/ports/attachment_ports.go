@@ -0,0 +1,30 @@
+package ports
+
+import (
+	"context"
+	"io"
+
+	"workflow-approval/package/attachment/domain"
+)
+
+// AttachmentRepository defines the interface for attachment metadata access
+type AttachmentRepository interface {
+	Create(ctx context.Context, attachment *domain.Attachment) error
+	GetByID(ctx context.Context, id string) (*domain.Attachment, error)
+	ListByRequestID(ctx context.Context, requestID string) ([]*domain.Attachment, error)
+	Delete(ctx context.Context, id string) error
+}
+
+// AttachmentService defines the interface for attachment business logic.
+// Files can be added and removed while the request is a draft or pending; they
+// can be downloaded for as long as the request exists.
+type AttachmentService interface {
+	// Upload stores size bytes from r; the content type is detected from the data and file name
+	Upload(ctx context.Context, requestID, uploadedBy, fileName string, size int64, r io.Reader) (*domain.Attachment, error)
+	List(ctx context.Context, requestID string) ([]*domain.Attachment, error)
+	// Open returns the attachment and its content; the caller must close the reader
+	Open(ctx context.Context, requestID, id string) (*domain.Attachment, io.ReadCloser, error)
+	Delete(ctx context.Context, requestID, id string) error
+	// PurgeRequest deletes all attachments of a request regardless of its status (implements reqPorts.RequestPurgeHook)
+	PurgeRequest(ctx context.Context, requestID string) error
+}
@@ -0,0 +1,59 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"gorm.io/gorm"
+
+	"workflow-approval/package/attachment/domain"
+	"workflow-approval/package/attachment/ports"
+)
+
+var ErrAttachmentNotFound = errors.New("attachment not found")
+
+// AttachmentRepositoryImpl implements AttachmentRepository interface
+type AttachmentRepositoryImpl struct {
+	db *gorm.DB
+}
+
+// NewAttachmentRepository creates a new AttachmentRepositoryImpl instance
+func NewAttachmentRepository(db *gorm.DB) ports.AttachmentRepository {
+	return &AttachmentRepositoryImpl{db: db}
+}
+
+// Create creates a new attachment record
+func (r *AttachmentRepositoryImpl) Create(ctx context.Context, attachment *domain.Attachment) error {
+	return r.db.WithContext(ctx).Create(attachment).Error
+}
+
+// GetByID retrieves an attachment by ID
+func (r *AttachmentRepositoryImpl) GetByID(ctx context.Context, id string) (*domain.Attachment, error) {
+	var attachment domain.Attachment
+	result := r.db.WithContext(ctx).First(&attachment, "id = ?", id)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, ErrAttachmentNotFound
+		}
+		return nil, result.Error
+	}
+	return &attachment, nil
+}
+
+// ListByRequestID retrieves the attachments of a request, oldest first
+func (r *AttachmentRepositoryImpl) ListByRequestID(ctx context.Context, requestID string) ([]*domain.Attachment, error) {
+	var attachments []*domain.Attachment
+	result := r.db.WithContext(ctx).
+		Where("request_id = ?", requestID).
+		Order("created_at ASC").
+		Find(&attachments)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return attachments, nil
+}
+
+// Delete deletes an attachment record by ID
+func (r *AttachmentRepositoryImpl) Delete(ctx context.Context, id string) error {
+	return r.db.WithContext(ctx).Delete(&domain.Attachment{}, "id = ?", id).Error
+}
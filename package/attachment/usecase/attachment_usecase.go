@@ -0,0 +1,236 @@
+package usecase
+
+import (
+	"bufio"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"log"
+	"mime"
+	"net/http"
+	"path/filepath"
+	"strings"
+
+	"workflow-approval/package/attachment/domain"
+	"workflow-approval/package/attachment/ports"
+	"workflow-approval/package/attachment/repository"
+	reqPorts "workflow-approval/package/request/ports"
+	reqRepo "workflow-approval/package/request/repository"
+	"workflow-approval/utils/storage"
+)
+
+var (
+	ErrAttachmentNotFound = errors.New("attachment not found")
+	ErrRequestNotFound    = errors.New("request not found")
+	ErrRequestNotEditable = errors.New("attachments can only be changed while the request is a draft or pending")
+	ErrFileEmpty          = errors.New("file is empty")
+	ErrFileTooLarge       = errors.New("file exceeds the maximum attachment size")
+	ErrFileTypeNotAllowed = errors.New("file type is not allowed")
+)
+
+// AttachmentServiceImpl implements AttachmentService interface
+type AttachmentServiceImpl struct {
+	attachmentRepo ports.AttachmentRepository
+	requestRepo    reqPorts.RequestRepository
+	store          storage.Storage
+	policy         domain.AttachmentPolicy
+}
+
+// NewAttachmentService creates a new AttachmentServiceImpl instance
+func NewAttachmentService(attachmentRepo ports.AttachmentRepository, requestRepo reqPorts.RequestRepository, store storage.Storage, policy domain.AttachmentPolicy) ports.AttachmentService {
+	return &AttachmentServiceImpl{
+		attachmentRepo: attachmentRepo,
+		requestRepo:    requestRepo,
+		store:          store,
+		policy:         policy,
+	}
+}
+
+// Upload checks the file against the policy, streams it to storage while
+// hashing it, and records its metadata
+func (s *AttachmentServiceImpl) Upload(ctx context.Context, requestID, uploadedBy, fileName string, size int64, r io.Reader) (*domain.Attachment, error) {
+	if size <= 0 {
+		return nil, ErrFileEmpty
+	}
+	if s.policy.MaxSize > 0 && size > s.policy.MaxSize {
+		return nil, ErrFileTooLarge
+	}
+
+	request, err := s.requestRepo.GetByID(ctx, requestID)
+	if err != nil {
+		if errors.Is(err, reqRepo.ErrRequestNotFound) {
+			return nil, ErrRequestNotFound
+		}
+		return nil, err
+	}
+	if !request.IsEditable() {
+		return nil, ErrRequestNotEditable
+	}
+
+	// Sniff the type from the first bytes rather than trusting the client
+	br := bufio.NewReaderSize(r, 512)
+	head, _ := br.Peek(512)
+	fileName = cleanFileName(fileName)
+	contentType := detectContentType(head, fileName)
+	if !s.policy.AllowsType(contentType) {
+		return nil, ErrFileTypeNotAllowed
+	}
+
+	attachment := domain.NewAttachment(request.TenantID, requestID, fileName, contentType, uploadedBy)
+	hash := sha256.New()
+	body := io.TeeReader(io.LimitReader(br, size), hash)
+	if err := s.store.Put(ctx, attachment.StorageKey, body, size, contentType); err != nil {
+		return nil, err
+	}
+	attachment.Size = size
+	attachment.SHA256 = hex.EncodeToString(hash.Sum(nil))
+
+	if err := s.attachmentRepo.Create(ctx, attachment); err != nil {
+		if delErr := s.store.Delete(ctx, attachment.StorageKey); delErr != nil {
+			log.Printf("Warning: failed to remove stored attachment %s: %v", attachment.StorageKey, delErr)
+		}
+		return nil, err
+	}
+
+	return attachment, nil
+}
+
+// List retrieves the attachments of a request
+func (s *AttachmentServiceImpl) List(ctx context.Context, requestID string) ([]*domain.Attachment, error) {
+	if _, err := s.requestRepo.GetByID(ctx, requestID); err != nil {
+		if errors.Is(err, reqRepo.ErrRequestNotFound) {
+			return nil, ErrRequestNotFound
+		}
+		return nil, err
+	}
+	return s.attachmentRepo.ListByRequestID(ctx, requestID)
+}
+
+// Open retrieves an attachment of a request with its content
+func (s *AttachmentServiceImpl) Open(ctx context.Context, requestID, id string) (*domain.Attachment, io.ReadCloser, error) {
+	attachment, err := s.get(ctx, requestID, id)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	content, err := s.store.Get(ctx, attachment.StorageKey)
+	if err != nil {
+		if errors.Is(err, storage.ErrNotFound) {
+			return nil, nil, ErrAttachmentNotFound
+		}
+		return nil, nil, err
+	}
+	return attachment, content, nil
+}
+
+// Delete removes an attachment from a draft or pending request. The record goes first
+// so a storage failure leaves at worst an unreferenced object behind.
+func (s *AttachmentServiceImpl) Delete(ctx context.Context, requestID, id string) error {
+	request, err := s.requestRepo.GetByID(ctx, requestID)
+	if err != nil {
+		if errors.Is(err, reqRepo.ErrRequestNotFound) {
+			return ErrRequestNotFound
+		}
+		return err
+	}
+	if !request.IsEditable() {
+		return ErrRequestNotEditable
+	}
+
+	attachment, err := s.get(ctx, requestID, id)
+	if err != nil {
+		return err
+	}
+	if err := s.attachmentRepo.Delete(ctx, attachment.ID); err != nil {
+		return err
+	}
+	if err := s.store.Delete(ctx, attachment.StorageKey); err != nil {
+		log.Printf("Warning: failed to remove stored attachment %s: %v", attachment.StorageKey, err)
+	}
+	return nil
+}
+
+// get loads an attachment and checks it belongs to the request
+func (s *AttachmentServiceImpl) get(ctx context.Context, requestID, id string) (*domain.Attachment, error) {
+	attachment, err := s.attachmentRepo.GetByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, repository.ErrAttachmentNotFound) {
+			return nil, ErrAttachmentNotFound
+		}
+		return nil, err
+	}
+	if attachment.RequestID != requestID {
+		return nil, ErrAttachmentNotFound
+	}
+	return attachment, nil
+}
+
+// extensionTypes covers attachment formats missing from Go's built-in MIME table
+var extensionTypes = map[string]string{
+	".csv":  "text/csv",
+	".txt":  "text/plain",
+	".xlsx": "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet",
+	".docx": "application/vnd.openxmlformats-officedocument.wordprocessingml.document",
+	".pptx": "application/vnd.openxmlformats-officedocument.presentationml.presentation",
+}
+
+// detectContentType sniffs the MIME type of the content. Formats the sniffer
+// only knows by their container (Office documents are zip files, CSV is plain
+// text) take the more specific type of the file extension.
+func detectContentType(head []byte, fileName string) string {
+	sniffed := http.DetectContentType(head)
+	ext := strings.ToLower(filepath.Ext(fileName))
+	byExtension, ok := extensionTypes[ext]
+	if !ok {
+		byExtension = mime.TypeByExtension(ext)
+	}
+	if byExtension == "" {
+		return sniffed
+	}
+
+	switch {
+	case sniffed == "application/zip" && strings.HasPrefix(byExtension, "application/vnd.openxmlformats-officedocument."):
+		return byExtension
+	case strings.HasPrefix(sniffed, "text/plain") && strings.HasPrefix(byExtension, "text/"):
+		return byExtension
+	}
+	return sniffed
+}
+
+// cleanFileName keeps the base name of an uploaded file, without directories
+func cleanFileName(name string) string {
+	name = filepath.Base(strings.ReplaceAll(name, "\\", "/"))
+	name = strings.Map(func(r rune) rune {
+		if r < 0x20 || r == 0x7f || r == '"' {
+			return -1
+		}
+		return r
+	}, name)
+	if name == "" || name == "." || name == "/" {
+		name = "attachment"
+	}
+	if runes := []rune(name); len(runes) > 255 {
+		name = string(runes[len(runes)-255:])
+	}
+	return name
+}
+
+// PurgeRequest removes every attachment of a request that is being deleted,
+// records and stored content alike
+func (s *AttachmentServiceImpl) PurgeRequest(ctx context.Context, requestID string) error {
+	attachments, err := s.attachmentRepo.ListByRequestID(ctx, requestID)
+	if err != nil {
+		return err
+	}
+	for _, attachment := range attachments {
+		if err := s.store.Delete(ctx, attachment.StorageKey); err != nil {
+			return err
+		}
+		if err := s.attachmentRepo.Delete(ctx, attachment.ID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
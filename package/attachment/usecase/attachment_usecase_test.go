@@ -0,0 +1,238 @@
+package usecase
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"testing"
+	"time"
+
+	"workflow-approval/package/attachment/domain"
+	"workflow-approval/package/attachment/repository"
+	reqDomain "workflow-approval/package/request/domain"
+	reqRepo "workflow-approval/package/request/repository"
+	reqUsecase "workflow-approval/package/request/usecase"
+	"workflow-approval/utils/pagination"
+	"workflow-approval/utils/storage"
+	"workflow-approval/utils/storage/s3test"
+)
+
+// mockAttachmentRepository keeps attachments in memory
+type mockAttachmentRepository struct {
+	attachments map[string]*domain.Attachment
+}
+
+func (m *mockAttachmentRepository) Create(ctx context.Context, attachment *domain.Attachment) error {
+	m.attachments[attachment.ID] = attachment
+	return nil
+}
+
+func (m *mockAttachmentRepository) GetByID(ctx context.Context, id string) (*domain.Attachment, error) {
+	if a, ok := m.attachments[id]; ok {
+		return a, nil
+	}
+	return nil, repository.ErrAttachmentNotFound
+}
+
+func (m *mockAttachmentRepository) ListByRequestID(ctx context.Context, requestID string) ([]*domain.Attachment, error) {
+	var result []*domain.Attachment
+	for _, a := range m.attachments {
+		if a.RequestID == requestID {
+			result = append(result, a)
+		}
+	}
+	return result, nil
+}
+
+func (m *mockAttachmentRepository) Delete(ctx context.Context, id string) error {
+	delete(m.attachments, id)
+	return nil
+}
+
+// mockRequestRepository serves fixed requests
+type mockRequestRepository struct {
+	requests map[string]*reqDomain.Request
+}
+
+func (m *mockRequestRepository) Create(ctx context.Context, request *reqDomain.Request) error {
+	m.requests[request.ID] = request
+	return nil
+}
+
+func (m *mockRequestRepository) GetByID(ctx context.Context, id string) (*reqDomain.Request, error) {
+	if r, ok := m.requests[id]; ok {
+		return r, nil
+	}
+	return nil, reqRepo.ErrRequestNotFound
+}
+
+func (m *mockRequestRepository) GetByIDForUpdate(ctx context.Context, id string) (*reqDomain.Request, error) {
+	return m.GetByID(ctx, id)
+}
+
+func (m *mockRequestRepository) Update(ctx context.Context, request *reqDomain.Request) error {
+	m.requests[request.ID] = request
+	return nil
+}
+
+func (m *mockRequestRepository) Delete(ctx context.Context, id string) error {
+	delete(m.requests, id)
+	return nil
+}
+
+func (m *mockRequestRepository) List(ctx context.Context, filter reqDomain.RequestFilter, page pagination.Page) ([]*reqDomain.Request, pagination.Result, error) {
+	return nil, pagination.Result{}, nil
+}
+
+func (m *mockRequestRepository) ListDraftsUpdatedBefore(ctx context.Context, before time.Time, limit int) ([]*reqDomain.Request, error) {
+	return nil, nil
+}
+
+func (m *mockRequestRepository) CountPendingByWorkflow(ctx context.Context) (map[string]int64, error) {
+	return nil, nil
+}
+
+var pdfContent = []byte("%PDF-1.4\n1 0 obj << /Type /Catalog >> endobj\n%%EOF")
+
+func newTestAttachmentService(store storage.Storage) (*AttachmentServiceImpl, *mockRequestRepository) {
+	requests := &mockRequestRepository{requests: map[string]*reqDomain.Request{
+		"req-pending":  {ID: "req-pending", TenantID: "tenant-1", Status: reqDomain.StatusPending},
+		"req-approved": {ID: "req-approved", TenantID: "tenant-1", Status: reqDomain.StatusApproved},
+	}}
+	policy := domain.DefaultAttachmentPolicy()
+	policy.MaxSize = 1024
+	service := NewAttachmentService(&mockAttachmentRepository{attachments: map[string]*domain.Attachment{}}, requests, store, policy)
+	return service.(*AttachmentServiceImpl), requests
+}
+
+func TestAttachmentUpload(t *testing.T) {
+	ctx := context.Background()
+	service, _ := newTestAttachmentService(storage.NewLocalStorage(t.TempDir()))
+
+	tests := []struct {
+		name      string
+		requestID string
+		fileName  string
+		content   []byte
+		wantErr   error
+	}{
+		{"PDF is accepted", "req-pending", "quote.pdf", pdfContent, nil},
+		{"Executable is refused whatever its name", "req-pending", "invoice.pdf", []byte("MZ\x90\x00\x03\x00\x00\x00\x04\x00"), ErrFileTypeNotAllowed},
+		{"Oversized file is refused", "req-pending", "big.txt", bytes.Repeat([]byte("a"), 2048), ErrFileTooLarge},
+		{"Empty file is refused", "req-pending", "empty.txt", nil, ErrFileEmpty},
+		{"Finished request is read-only", "req-approved", "quote.pdf", pdfContent, ErrRequestNotEditable},
+		{"Unknown request", "req-missing", "quote.pdf", pdfContent, ErrRequestNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			attachment, err := service.Upload(ctx, tt.requestID, "user-1", tt.fileName, int64(len(tt.content)), bytes.NewReader(tt.content))
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Expected %v, got %v", tt.wantErr, err)
+			}
+			if tt.wantErr != nil {
+				return
+			}
+			sum := sha256.Sum256(tt.content)
+			if attachment.SHA256 != hex.EncodeToString(sum[:]) {
+				t.Errorf("Expected sha256 %x, got %s", sum, attachment.SHA256)
+			}
+			if attachment.ContentType != "application/pdf" || attachment.Size != int64(len(tt.content)) {
+				t.Errorf("Expected application/pdf of %d bytes, got %s of %d", len(tt.content), attachment.ContentType, attachment.Size)
+			}
+		})
+	}
+}
+
+func TestAttachmentLifecycleOnS3(t *testing.T) {
+	ctx := context.Background()
+	server := s3test.NewServer("attachments", "us-east-1", "access", "secret")
+	defer server.Close()
+	store := storage.NewS3Storage(storage.S3Config{
+		Endpoint:  server.URL(),
+		Bucket:    "attachments",
+		AccessKey: "access",
+		SecretKey: "secret",
+		PathStyle: true,
+	}, nil)
+	service, requests := newTestAttachmentService(store)
+
+	attachment, err := service.Upload(ctx, "req-pending", "user-1", `C:\Users\me\Quote "Q1".pdf`, int64(len(pdfContent)), bytes.NewReader(pdfContent))
+	if err != nil {
+		t.Fatalf("Upload: %v", err)
+	}
+	if attachment.FileName != "Quote Q1.pdf" {
+		t.Errorf("Expected the cleaned base name, got %q", attachment.FileName)
+	}
+	if _, ok := server.Object("tenant-1/req-pending/" + attachment.ID); !ok {
+		t.Fatal("Expected the object under tenant/request/attachment")
+	}
+
+	t.Run("Download returns the content", func(t *testing.T) {
+		_, content, err := service.Open(ctx, "req-pending", attachment.ID)
+		if err != nil {
+			t.Fatalf("Open: %v", err)
+		}
+		defer content.Close()
+		got, _ := io.ReadAll(content)
+		if !bytes.Equal(got, pdfContent) {
+			t.Errorf("Expected the uploaded content, got %q", got)
+		}
+	})
+
+	t.Run("Attachment is not reachable through another request", func(t *testing.T) {
+		if _, _, err := service.Open(ctx, "req-approved", attachment.ID); !errors.Is(err, ErrAttachmentNotFound) {
+			t.Errorf("Expected ErrAttachmentNotFound, got %v", err)
+		}
+	})
+
+	t.Run("Delete is refused once the request is decided", func(t *testing.T) {
+		requests.requests["req-pending"].Status = reqDomain.StatusRejected
+		defer func() { requests.requests["req-pending"].Status = reqDomain.StatusPending }()
+		if err := service.Delete(ctx, "req-pending", attachment.ID); !errors.Is(err, ErrRequestNotEditable) {
+			t.Errorf("Expected ErrRequestNotEditable, got %v", err)
+		}
+	})
+
+	t.Run("Delete removes record and object", func(t *testing.T) {
+		if err := service.Delete(ctx, "req-pending", attachment.ID); err != nil {
+			t.Fatalf("Delete: %v", err)
+		}
+		if _, ok := server.Object("tenant-1/req-pending/" + attachment.ID); ok {
+			t.Error("Expected the object to be removed")
+		}
+		if list, _ := service.List(ctx, "req-pending"); len(list) != 0 {
+			t.Errorf("Expected no attachments left, got %d", len(list))
+		}
+	})
+}
+
+func TestDeleteRequestPurgesAttachments(t *testing.T) {
+	ctx := context.Background()
+	store := storage.NewLocalStorage(t.TempDir())
+	service, requests := newTestAttachmentService(store)
+
+	attachment, err := service.Upload(ctx, "req-pending", "user-1", "quote.pdf", int64(len(pdfContent)), bytes.NewReader(pdfContent))
+	if err != nil {
+		t.Fatalf("Upload failed: %v", err)
+	}
+
+	// DeleteRequest only needs the request repository and its purge hooks
+	requestService := reqUsecase.NewRequestService(requests, nil, nil, nil, reqDomain.StepUpPolicy{}, nil, nil, nil, service)
+	if err := requestService.DeleteRequest(ctx, "req-pending"); err != nil {
+		t.Fatalf("DeleteRequest failed: %v", err)
+	}
+
+	if _, ok := requests.requests["req-pending"]; ok {
+		t.Error("Expected the request to be deleted")
+	}
+	if remaining, _ := service.attachmentRepo.ListByRequestID(ctx, "req-pending"); len(remaining) != 0 {
+		t.Errorf("Expected the attachment rows to be deleted, got %d", len(remaining))
+	}
+	if _, err := store.Get(ctx, attachment.StorageKey); !errors.Is(err, storage.ErrNotFound) {
+		t.Errorf("Expected the stored file to be deleted, got %v", err)
+	}
+}
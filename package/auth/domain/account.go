@@ -0,0 +1,124 @@
+package domain
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	userDomain "workflow-approval/package/user/domain"
+	"workflow-approval/utils"
+	"workflow-approval/utils/mailer"
+)
+
+// MinPasswordLength is the minimum length of a local password
+const MinPasswordLength = 8
+
+// TokenPurpose tells what a user token may be redeemed for
+type TokenPurpose string
+
+const (
+	TokenPurposePasswordReset     TokenPurpose = "PASSWORD_RESET"
+	TokenPurposeEmailVerification TokenPurpose = "EMAIL_VERIFICATION"
+)
+
+// UserToken is a single-use, expiring token sent to a user by email.
+// Only the SHA-256 hash of the token is stored; the plaintext exists only in the email.
+type UserToken struct {
+	ID        string       `json:"id" gorm:"primaryKey;size:36"`
+	TenantID  string       `json:"tenant_id" gorm:"size:36;not null;index"`
+	UserID    string       `json:"user_id" gorm:"size:36;not null;index"`
+	Purpose   TokenPurpose `json:"purpose" gorm:"size:30;not null"`
+	TokenHash string       `json:"-" gorm:"size:64;not null;uniqueIndex"`
+	ExpiresAt time.Time    `json:"expires_at"`
+	UsedAt    *time.Time   `json:"used_at"`
+	CreatedAt time.Time    `json:"created_at"`
+}
+
+// NewUserToken creates a new UserToken for the hash of a generated token
+func NewUserToken(userID string, purpose TokenPurpose, tokenHash string, ttl time.Duration) *UserToken {
+	now := utils.TimeNowUTC()
+	return &UserToken{
+		ID:        utils.GenerateUUID(),
+		UserID:    userID,
+		Purpose:   purpose,
+		TokenHash: tokenHash,
+		ExpiresAt: now.Add(ttl),
+		CreatedAt: now,
+	}
+}
+
+// TableName returns the table name for GORM
+func (UserToken) TableName() string {
+	return "user_tokens"
+}
+
+// IsUsable reports whether the token has neither been used nor expired
+func (t *UserToken) IsUsable(now time.Time) bool {
+	return t.UsedAt == nil && now.Before(t.ExpiresAt)
+}
+
+// HashUserToken returns the stored form of a user token
+func HashUserToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// AccountPolicy configures password reset and email verification
+type AccountPolicy struct {
+	BaseURL              string        // Frontend URL the emailed links point to
+	ResetTokenTTL        time.Duration // How long a password reset link is valid
+	VerificationTokenTTL time.Duration // How long an email verification link is valid
+	ResendCooldown       time.Duration // Minimum time between two emails of the same kind to a user
+}
+
+// DefaultAccountPolicy returns the policy used when nothing is configured
+func DefaultAccountPolicy() AccountPolicy {
+	return AccountPolicy{
+		BaseURL:              "http://localhost:8080",
+		ResetTokenTTL:        30 * time.Minute,
+		VerificationTokenTTL: 48 * time.Hour,
+		ResendCooldown:       time.Minute,
+	}
+}
+
+// TTL returns how long a token of the given purpose is valid
+func (p AccountPolicy) TTL(purpose TokenPurpose) time.Duration {
+	if purpose == TokenPurposePasswordReset {
+		return p.ResetTokenTTL
+	}
+	return p.VerificationTokenTTL
+}
+
+// link builds the frontend URL carrying a token
+func (p AccountPolicy) link(path, token string) string {
+	return strings.TrimRight(p.BaseURL, "/") + path + "?token=" + url.QueryEscape(token)
+}
+
+// PasswordResetMessage builds the email carrying a password reset link
+func (p AccountPolicy) PasswordResetMessage(user *userDomain.User, token string) mailer.Message {
+	return mailer.Message{
+		To:      user.Email,
+		Subject: "Reset your password",
+		Body: fmt.Sprintf("Hello %s,\n\n"+
+			"A password reset was requested for your account. Open the link below to choose a new password:\n\n"+
+			"%s\n\n"+
+			"The link expires in %s and can be used once. If you did not request a reset, you can ignore this email.\n",
+			user.Name, p.link("/reset-password", token), p.ResetTokenTTL),
+	}
+}
+
+// EmailVerificationMessage builds the email carrying an email verification link
+func (p AccountPolicy) EmailVerificationMessage(user *userDomain.User, token string) mailer.Message {
+	return mailer.Message{
+		To:      user.Email,
+		Subject: "Verify your email address",
+		Body: fmt.Sprintf("Hello %s,\n\n"+
+			"Please confirm your email address by opening the link below:\n\n"+
+			"%s\n\n"+
+			"The link expires in %s.\n",
+			user.Name, p.link("/verify-email", token), p.VerificationTokenTTL),
+	}
+}
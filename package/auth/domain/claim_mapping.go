@@ -0,0 +1,53 @@
+package domain
+
+import "strings"
+
+// ClaimMappingRule maps an OIDC claim value to an actor and/or the admin role.
+// Rules are evaluated in order; the first matching rule with an ActorCode decides
+// the actor, and any matching rule with IsAdmin grants admin.
+type ClaimMappingRule struct {
+	Claim     string // Claim name, dotted paths address nested claims (e.g. "realm_access.roles")
+	Value     string // Expected value; list claims match when any element equals it
+	ActorCode string // Actor assigned when the rule matches (optional)
+	IsAdmin   bool   // Grant admin when the rule matches
+}
+
+// ClaimSource provides claim values by name
+type ClaimSource interface {
+	Strings(name string) []string
+}
+
+// Matches reports whether the claims satisfy the rule (case-insensitive)
+func (r ClaimMappingRule) Matches(claims ClaimSource) bool {
+	for _, value := range claims.Strings(r.Claim) {
+		if strings.EqualFold(value, r.Value) {
+			return true
+		}
+	}
+	return false
+}
+
+// ClaimMapping is the outcome of evaluating mapping rules against a set of claims
+type ClaimMapping struct {
+	Matched   bool
+	ActorCode string
+	IsAdmin   bool
+}
+
+// EvaluateClaimMapping applies rules in order to the given claims
+func EvaluateClaimMapping(rules []ClaimMappingRule, claims ClaimSource) ClaimMapping {
+	var mapping ClaimMapping
+	for _, rule := range rules {
+		if !rule.Matches(claims) {
+			continue
+		}
+		mapping.Matched = true
+		if rule.IsAdmin {
+			mapping.IsAdmin = true
+		}
+		if mapping.ActorCode == "" && rule.ActorCode != "" {
+			mapping.ActorCode = rule.ActorCode
+		}
+	}
+	return mapping
+}
@@ -5,3 +5,36 @@ type LoginRequest struct {
 	Email    string `json:"email"`
 	Password string `json:"password"`
 }
+
+// TwoFactorLoginRequest represents the second stage of a login
+type TwoFactorLoginRequest struct {
+	ChallengeToken string `json:"challenge_token"`
+	Code           string `json:"code"` // TOTP code or recovery code
+}
+
+// TwoFactorCodeRequest represents a request confirmed with a TOTP (or recovery) code
+type TwoFactorCodeRequest struct {
+	Code string `json:"code"`
+}
+
+// ForgotPasswordRequest asks for a password reset link
+type ForgotPasswordRequest struct {
+	Email string `json:"email"`
+}
+
+// ResetPasswordRequest sets a new password with an emailed reset token
+type ResetPasswordRequest struct {
+	Token       string `json:"token"`
+	NewPassword string `json:"new_password"`
+}
+
+// ChangePasswordRequest changes the password of the signed-in user
+type ChangePasswordRequest struct {
+	CurrentPassword string `json:"current_password"`
+	NewPassword     string `json:"new_password"`
+}
+
+// VerifyEmailRequest confirms an email address with an emailed token
+type VerifyEmailRequest struct {
+	Token string `json:"token"`
+}
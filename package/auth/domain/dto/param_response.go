@@ -1,13 +1,22 @@
 package dto
 
-import "workflow-approval/package/user/domain"
+import (
+	authDomain "workflow-approval/package/auth/domain"
+	"workflow-approval/package/user/domain"
+)
 
 // UserResponse represents the user response
 type UserResponse struct {
-	ID      string `json:"id"`
-	Email   string `json:"email"`
-	Name    string `json:"name"`
-	IsAdmin bool   `json:"is_admin"`
+	ID                 string   `json:"id"`
+	Email              string   `json:"email"`
+	Name               string   `json:"name"`
+	IsAdmin            bool     `json:"is_admin"`
+	IsSuperAdmin       bool     `json:"is_super_admin"`
+	TenantID           string   `json:"tenant_id"`
+	ActorIDs           []string `json:"actor_ids"` // Actors the user can approve for, primary first
+	TOTPEnabled        bool     `json:"totp_enabled"`
+	EmailVerified      bool     `json:"email_verified"`
+	MustChangePassword bool     `json:"must_change_password"` // The token only allows changing the password
 }
 
 // ToUserResponse converts a User to UserResponse
@@ -16,15 +25,36 @@ func ToUserResponse(u *domain.User) *UserResponse {
 		return nil
 	}
 	return &UserResponse{
-		ID:      u.ID,
-		Email:   u.Email,
-		Name:    u.Name,
-		IsAdmin: u.IsAdmin,
+		ID:                 u.ID,
+		Email:              u.Email,
+		Name:               u.Name,
+		IsAdmin:            u.IsAdmin,
+		IsSuperAdmin:       u.IsSuperAdmin,
+		TenantID:           u.TenantID,
+		ActorIDs:           u.ActorIDs(),
+		TOTPEnabled:        u.TOTPEnabled,
+		EmailVerified:      u.IsEmailVerified(),
+		MustChangePassword: u.MustChangePassword,
 	}
 }
 
 // AuthResponse represents the authentication response
 type AuthResponse struct {
-	User  *UserResponse `json:"user"`
-	Token string        `json:"token"`
+	User          *UserResponse `json:"user"`
+	Token         string        `json:"token"`
+	RecoveryCodes []string      `json:"recovery_codes,omitempty"` // Shown once, when TOTP was enrolled during login
+}
+
+// TwoFactorChallengeResponse is returned by /auth/login when a second factor is required
+type TwoFactorChallengeResponse struct {
+	TwoFactorRequired  bool                       `json:"two_factor_required"`
+	ChallengeToken     string                     `json:"challenge_token"`
+	ExpiresIn          int                        `json:"expires_in"` // Seconds
+	EnrollmentRequired bool                       `json:"enrollment_required"`
+	Enrollment         *authDomain.TOTPEnrollment `json:"enrollment,omitempty"`
+}
+
+// RecoveryCodesResponse lists newly issued recovery codes
+type RecoveryCodesResponse struct {
+	RecoveryCodes []string `json:"recovery_codes"`
 }
@@ -0,0 +1,73 @@
+package domain
+
+import (
+	"time"
+
+	userDomain "workflow-approval/package/user/domain"
+	"workflow-approval/utils"
+)
+
+// PendingTwoFactor is a login that passed its first factor and waits for the
+// second one. It is stored so any instance can complete the login; only the
+// SHA-256 hash of the challenge token is kept, as for user tokens.
+type PendingTwoFactor struct {
+	ID          string    `json:"id" gorm:"primaryKey;size:36"`
+	TenantID    string    `json:"tenant_id" gorm:"size:36;not null;index"` // The user's tenant, which the login completes in
+	UserID      string    `json:"user_id" gorm:"size:36;not null"`
+	TokenHash   string    `json:"-" gorm:"size:64;not null;uniqueIndex"`
+	FirstFactor string    `json:"first_factor" gorm:"size:20;not null"` // password or oidc
+	Enrollment  bool      `json:"enrollment" gorm:"not null;default:false"`
+	Failures    int       `json:"failures" gorm:"not null;default:0"`
+	ExpiresAt   time.Time `json:"expires_at"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+// NewPendingTwoFactor creates a new PendingTwoFactor for the hash of a challenge token
+func NewPendingTwoFactor(user *userDomain.User, tokenHash, firstFactor string, enrollment bool, ttl time.Duration) *PendingTwoFactor {
+	now := utils.TimeNowUTC()
+	return &PendingTwoFactor{
+		ID:          utils.GenerateUUID(),
+		TenantID:    user.TenantID,
+		UserID:      user.ID,
+		TokenHash:   tokenHash,
+		FirstFactor: firstFactor,
+		Enrollment:  enrollment,
+		ExpiresAt:   now.Add(ttl),
+		CreatedAt:   now,
+	}
+}
+
+// TableName returns the table name for GORM
+func (PendingTwoFactor) TableName() string {
+	return "two_factor_challenges"
+}
+
+// OIDCLoginState is what the OIDC callback needs to finish a login started on
+// any instance. Logins have no tenant until the user is known, so states are
+// not tenant scoped; the state itself is stored hashed.
+type OIDCLoginState struct {
+	ID           string    `json:"id" gorm:"primaryKey;size:36"`
+	StateHash    string    `json:"-" gorm:"size:64;not null;uniqueIndex"`
+	Nonce        string    `json:"-" gorm:"size:64;not null"`
+	CodeVerifier string    `json:"-" gorm:"size:128;not null"`
+	ExpiresAt    time.Time `json:"expires_at"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+// NewOIDCLoginState creates a new OIDCLoginState for the hash of a state
+func NewOIDCLoginState(stateHash, nonce, codeVerifier string, ttl time.Duration) *OIDCLoginState {
+	now := utils.TimeNowUTC()
+	return &OIDCLoginState{
+		ID:           utils.GenerateUUID(),
+		StateHash:    stateHash,
+		Nonce:        nonce,
+		CodeVerifier: codeVerifier,
+		ExpiresAt:    now.Add(ttl),
+		CreatedAt:    now,
+	}
+}
+
+// TableName returns the table name for GORM
+func (OIDCLoginState) TableName() string {
+	return "oidc_login_states"
+}
@@ -0,0 +1,123 @@
+package domain
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"math/big"
+	"strings"
+	"time"
+
+	userDomain "workflow-approval/package/user/domain"
+)
+
+// RecoveryCodeCount is the number of recovery codes issued at a time
+const RecoveryCodeCount = 10
+
+// recoveryCodeAlphabet omits characters that are easily confused (0/o, 1/l/i)
+const recoveryCodeAlphabet = "abcdefghjkmnpqrstuvwxyz23456789"
+
+// TwoFactorPolicy configures when a second factor is required at login
+type TwoFactorPolicy struct {
+	Issuer           string        // Shown in authenticator apps
+	RequireForAdmins bool          // Admins must enroll and use TOTP to log in
+	ChallengeTTL     time.Duration // How long a password-verified login may wait for the code
+}
+
+// DefaultTwoFactorPolicy returns the policy used when nothing is configured
+func DefaultTwoFactorPolicy() TwoFactorPolicy {
+	return TwoFactorPolicy{
+		Issuer:       "Workflow Approval",
+		ChallengeTTL: 5 * time.Minute,
+	}
+}
+
+// RequiresTwoFactor reports whether the user must pass a second factor to log in
+func (p TwoFactorPolicy) RequiresTwoFactor(user *userDomain.User) bool {
+	return user.TOTPEnabled || p.RequiresEnrollment(user)
+}
+
+// RequiresEnrollment reports whether the policy forbids the user from running without TOTP
+func (p TwoFactorPolicy) RequiresEnrollment(user *userDomain.User) bool {
+	return p.RequireForAdmins && user.IsAdmin
+}
+
+// TOTPEnrollment holds the data an authenticator app needs to enroll
+type TOTPEnrollment struct {
+	Secret          string `json:"secret"`
+	ProvisioningURI string `json:"provisioning_uri"` // otpauth:// URI, render as QR code
+}
+
+// TwoFactorStatus describes a user's two-factor setup
+type TwoFactorStatus struct {
+	Enabled                bool `json:"enabled"`
+	Required               bool `json:"required"`
+	RecoveryCodesRemaining int  `json:"recovery_codes_remaining"`
+}
+
+// GenerateRecoveryCodes returns new plaintext recovery codes and the encoded
+// hashes to store on the user; plaintext codes are shown once and never stored
+func GenerateRecoveryCodes() ([]string, string, error) {
+	codes := make([]string, RecoveryCodeCount)
+	hashes := make([]string, RecoveryCodeCount)
+	for i := range codes {
+		buf := make([]byte, 10)
+		for j := range buf {
+			// rand.Int draws uniformly; a byte modulo 31 would favour the first letters
+			n, err := rand.Int(rand.Reader, big.NewInt(int64(len(recoveryCodeAlphabet))))
+			if err != nil {
+				return nil, "", err
+			}
+			buf[j] = recoveryCodeAlphabet[n.Int64()]
+		}
+		codes[i] = string(buf[:5]) + "-" + string(buf[5:])
+		hashes[i] = HashRecoveryCode(codes[i])
+	}
+
+	encoded, err := json.Marshal(hashes)
+	if err != nil {
+		return nil, "", err
+	}
+	return codes, string(encoded), nil
+}
+
+// HashRecoveryCode returns the stored form of a recovery code; case, spaces and dashes are ignored
+func HashRecoveryCode(code string) string {
+	normalized := strings.ToLower(strings.NewReplacer("-", "", " ", "").Replace(strings.TrimSpace(code)))
+	sum := sha256.Sum256([]byte(normalized))
+	return hex.EncodeToString(sum[:])
+}
+
+// RemainingRecoveryCodes returns how many unused recovery codes the user has
+func RemainingRecoveryCodes(user *userDomain.User) int {
+	return len(decodeRecoveryCodes(user.RecoveryCodes))
+}
+
+// ConsumeRecoveryCode removes the matching code from the user and reports whether it matched
+func ConsumeRecoveryCode(user *userDomain.User, code string) bool {
+	hashes := decodeRecoveryCodes(user.RecoveryCodes)
+	hash := HashRecoveryCode(code)
+	for i, stored := range hashes {
+		if subtle.ConstantTimeCompare([]byte(stored), []byte(hash)) == 1 {
+			remaining := append(hashes[:i:i], hashes[i+1:]...)
+			encoded, _ := json.Marshal(remaining)
+			user.RecoveryCodes = string(encoded)
+			return true
+		}
+	}
+	return false
+}
+
+// decodeRecoveryCodes parses the stored hashes, treating empty or invalid data as none
+func decodeRecoveryCodes(encoded string) []string {
+	if encoded == "" {
+		return nil
+	}
+	var hashes []string
+	if err := json.Unmarshal([]byte(encoded), &hashes); err != nil {
+		return nil
+	}
+	return hashes
+}
@@ -0,0 +1,266 @@
+package handler
+
+import (
+	"errors"
+
+	"github.com/gofiber/fiber/v2"
+
+	"workflow-approval/framework/middleware"
+	"workflow-approval/package/auth/domain/dto"
+	"workflow-approval/package/auth/ports"
+	"workflow-approval/package/auth/usecase"
+	"workflow-approval/utils/jwthelper"
+)
+
+// AccountHandler handles HTTP requests for password reset, password change and email verification
+type AccountHandler struct {
+	accountService ports.AccountService
+	jwtHelper      *jwthelper.JWTHelper
+}
+
+// NewAccountHandler creates a new AccountHandler instance
+func NewAccountHandler(accountService ports.AccountService, jwtHelper *jwthelper.JWTHelper) *AccountHandler {
+	return &AccountHandler{
+		accountService: accountService,
+		jwtHelper:      jwtHelper,
+	}
+}
+
+// Routes defines the public account recovery routes
+// Mounts routes under /auth
+func (h *AccountHandler) Routes(group fiber.Router) {
+	// POST /auth/password/forgot - Email a password reset link
+	group.Post("/password/forgot", h.ForgotPassword)
+
+	// POST /auth/password/reset - Set a new password with a reset token
+	group.Post("/password/reset", h.ResetPassword)
+
+	// POST /auth/verify-email - Confirm an email address with a verification token
+	group.Post("/verify-email", h.VerifyEmail)
+}
+
+// ProfileRoutes defines the signed-in user's account routes
+// Mounts routes under /api/profile (user sessions only, not API keys)
+func (h *AccountHandler) ProfileRoutes(group fiber.Router) {
+	// PUT /api/profile/password - Change password, returns a fresh token
+	group.Put("/password", requireAccountSession, h.ChangePassword)
+
+	// POST /api/profile/verify-email - Send a new email verification link
+	group.Post("/verify-email", requireAccountSession, h.ResendVerification)
+}
+
+// ForgotPassword emails a reset link; the response is the same whether or not the account exists
+// POST /auth/password/forgot
+// Request Body: {"email": "user@example.com"}
+func (h *AccountHandler) ForgotPassword(c *fiber.Ctx) error {
+	var req dto.ForgotPasswordRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"success": false,
+			"data":    nil,
+			"error":   "Invalid request body",
+		})
+	}
+
+	if req.Email == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"success": false,
+			"data":    nil,
+			"error":   "Email is required",
+		})
+	}
+
+	if err := h.accountService.RequestPasswordReset(c.Context(), req.Email, clientInfo(c)); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"success": false,
+			"data":    nil,
+			"error":   "Failed to request password reset",
+		})
+	}
+
+	return c.Status(fiber.StatusAccepted).JSON(fiber.Map{
+		"success": true,
+		"data": fiber.Map{
+			"message": "If an account exists for this email, a password reset link has been sent",
+		},
+		"error": nil,
+	})
+}
+
+// ResetPassword sets a new password with an emailed reset token
+// POST /auth/password/reset
+// Request Body: {"token": "...", "new_password": "..."}
+func (h *AccountHandler) ResetPassword(c *fiber.Ctx) error {
+	var req dto.ResetPasswordRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"success": false,
+			"data":    nil,
+			"error":   "Invalid request body",
+		})
+	}
+
+	if req.Token == "" || req.NewPassword == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"success": false,
+			"data":    nil,
+			"error":   "Token and new password are required",
+		})
+	}
+
+	if err := h.accountService.ResetPassword(c.Context(), req.Token, req.NewPassword, clientInfo(c)); err != nil {
+		return accountError(c, err)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"success": true,
+		"data": fiber.Map{
+			"message": "Password has been reset, please log in with the new password",
+		},
+		"error": nil,
+	})
+}
+
+// VerifyEmail confirms the email address of the token's user
+// POST /auth/verify-email
+// Request Body: {"token": "..."}
+func (h *AccountHandler) VerifyEmail(c *fiber.Ctx) error {
+	var req dto.VerifyEmailRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"success": false,
+			"data":    nil,
+			"error":   "Invalid request body",
+		})
+	}
+
+	if req.Token == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"success": false,
+			"data":    nil,
+			"error":   "Token is required",
+		})
+	}
+
+	user, err := h.accountService.VerifyEmail(c.Context(), req.Token, clientInfo(c))
+	if err != nil {
+		return accountError(c, err)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"success": true,
+		"data":    dto.ToUserResponse(user),
+		"error":   nil,
+	})
+}
+
+// ChangePassword changes the password of the current user and returns a token
+// without the pending password change restriction
+// PUT /api/profile/password
+// Request Body: {"current_password": "...", "new_password": "..."}
+func (h *AccountHandler) ChangePassword(c *fiber.Ctx) error {
+	var req dto.ChangePasswordRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"success": false,
+			"data":    nil,
+			"error":   "Invalid request body",
+		})
+	}
+
+	if req.CurrentPassword == "" || req.NewPassword == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"success": false,
+			"data":    nil,
+			"error":   "Current and new password are required",
+		})
+	}
+
+	user, err := h.accountService.ChangePassword(c.Context(), c.Locals("user_id").(string), req.CurrentPassword, req.NewPassword, clientInfo(c))
+	if err != nil {
+		return accountError(c, err)
+	}
+
+	// Keep a second factor passed in this session so step-up still applies
+	var token string
+	if mfaAt := middleware.GetMFAAtFromContext(c); mfaAt != nil {
+		token, err = h.jwtHelper.GenerateMFAJWT(user, *mfaAt)
+	} else {
+		token, err = h.jwtHelper.GenerateJWT(user)
+	}
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"success": false,
+			"data":    nil,
+			"error":   "Failed to generate token",
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"success": true,
+		"data": dto.AuthResponse{
+			User:  dto.ToUserResponse(user),
+			Token: token,
+		},
+		"error": nil,
+	})
+}
+
+// ResendVerification sends a new email verification link to the current user
+// POST /api/profile/verify-email
+func (h *AccountHandler) ResendVerification(c *fiber.Ctx) error {
+	if err := h.accountService.ResendEmailVerification(c.Context(), c.Locals("user_id").(string)); err != nil {
+		return accountError(c, err)
+	}
+
+	return c.Status(fiber.StatusAccepted).JSON(fiber.Map{
+		"success": true,
+		"data": fiber.Map{
+			"message": "Verification email sent",
+		},
+		"error": nil,
+	})
+}
+
+// requireAccountSession rejects API keys; passwords and email addresses belong to people
+func requireAccountSession(c *fiber.Ctx) error {
+	if middleware.GetAuthTypeFromContext(c) != middleware.AuthTypeJWT {
+		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+			"success": false,
+			"data":    nil,
+			"error":   "Account management requires a user session",
+		})
+	}
+	return c.Next()
+}
+
+// accountError maps account service errors to HTTP responses
+func accountError(c *fiber.Ctx, err error) error {
+	var throttleErr *usecase.ThrottleError
+	if errors.As(err, &throttleErr) {
+		return throttled(c, throttleErr)
+	}
+
+	status := fiber.StatusInternalServerError
+	message := "Failed to process account request"
+	switch {
+	case errors.Is(err, usecase.ErrUserNotFound):
+		status, message = fiber.StatusNotFound, "User not found"
+	case errors.Is(err, usecase.ErrWeakPassword),
+		errors.Is(err, usecase.ErrPasswordUnchanged),
+		errors.Is(err, usecase.ErrInvalidResetToken),
+		errors.Is(err, usecase.ErrInvalidVerifyToken):
+		status, message = fiber.StatusBadRequest, err.Error()
+	case errors.Is(err, usecase.ErrCurrentPasswordInvalid):
+		status, message = fiber.StatusUnauthorized, err.Error()
+	case errors.Is(err, usecase.ErrLocalPasswordNotSet),
+		errors.Is(err, usecase.ErrEmailAlreadyVerified):
+		status, message = fiber.StatusConflict, err.Error()
+	}
+
+	return c.Status(status).JSON(fiber.Map{
+		"success": false,
+		"data":    nil,
+		"error":   message,
+	})
+}
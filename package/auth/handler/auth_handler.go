@@ -1,11 +1,19 @@
 package handler
 
 import (
+	"errors"
+	"math"
+	"strconv"
+
 	"github.com/gofiber/fiber/v2"
 
+	"workflow-approval/package/auth/domain"
 	"workflow-approval/package/auth/domain/dto"
 	"workflow-approval/package/auth/ports"
+	"workflow-approval/package/auth/usecase"
+	"workflow-approval/utils"
 	"workflow-approval/utils/jwthelper"
+	"workflow-approval/utils/tenancy"
 )
 
 // AuthHandler handles HTTP requests for authentication operations
@@ -28,6 +36,9 @@ func (h *AuthHandler) Routes(group fiber.Router) {
 	// POST /auth/login - Login user and get JWT token (Request Body)
 	group.Post("/login", h.Login)
 
+	// POST /auth/login/2fa - Complete a login with a TOTP or recovery code
+	group.Post("/login/2fa", h.LoginTwoFactor)
+
 	// POST /auth/refresh - Refresh JWT token
 	group.Post("/refresh", h.Refresh)
 
@@ -35,6 +46,16 @@ func (h *AuthHandler) Routes(group fiber.Router) {
 	group.Post("/logout", h.Logout)
 }
 
+// AdminRoutes defines admin-only authentication management routes
+// Mounts routes under /api/auth (admin only)
+func (h *AuthHandler) AdminRoutes(group fiber.Router) {
+	// POST /api/auth/users/:id/unlock - Unlock an account locked after failed logins
+	group.Post("/users/:id/unlock", h.UnlockUser)
+
+	// GET /api/auth/audit-log - List authentication events
+	group.Get("/audit-log", h.ListAuditLog)
+}
+
 // Login handles user login using request body
 // POST /auth/login
 // Request Body: {"email": "user@example.com", "password": "password"}
@@ -58,8 +79,19 @@ func (h *AuthHandler) Login(c *fiber.Ctx) error {
 		})
 	}
 
-	user, _, err := h.authService.Login(c.Context(), req.Email, req.Password)
+	user, _, err := h.authService.Login(c.Context(), req.Email, req.Password, clientInfo(c))
 	if err != nil {
+		var throttleErr *usecase.ThrottleError
+		if errors.As(err, &throttleErr) {
+			return throttled(c, throttleErr)
+		}
+		if errors.Is(err, usecase.ErrAccountDeactivated) {
+			return deactivated(c)
+		}
+		var challenge *usecase.TwoFactorChallenge
+		if errors.As(err, &challenge) {
+			return twoFactorChallenge(c, challenge)
+		}
 		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
 			"success": false,
 			"data":    nil,
@@ -87,6 +119,71 @@ func (h *AuthHandler) Login(c *fiber.Ctx) error {
 	})
 }
 
+// LoginTwoFactor completes a login challenged for a second factor
+// POST /auth/login/2fa
+// Request Body: {"challenge_token": "...", "code": "123456"}
+func (h *AuthHandler) LoginTwoFactor(c *fiber.Ctx) error {
+	var req dto.TwoFactorLoginRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"success": false,
+			"data":    nil,
+			"error":   "Invalid request body",
+		})
+	}
+
+	if req.ChallengeToken == "" || req.Code == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"success": false,
+			"data":    nil,
+			"error":   "Challenge token and code are required",
+		})
+	}
+
+	user, recoveryCodes, err := h.authService.VerifyTwoFactor(c.Context(), req.ChallengeToken, req.Code, clientInfo(c))
+	if err != nil {
+		var throttleErr *usecase.ThrottleError
+		if errors.As(err, &throttleErr) {
+			return throttled(c, throttleErr)
+		}
+		if errors.Is(err, usecase.ErrAccountDeactivated) {
+			return deactivated(c)
+		}
+		if errors.Is(err, usecase.ErrTwoFactorChallengeInvalid) || errors.Is(err, usecase.ErrTwoFactorInvalidCode) {
+			return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+				"success": false,
+				"data":    nil,
+				"error":   err.Error(),
+			})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"success": false,
+			"data":    nil,
+			"error":   "Failed to verify two-factor code",
+		})
+	}
+
+	// The token records the second factor, which satisfies step-up checks until it ages out
+	token, err := h.jwtHelper.GenerateMFAJWT(user, utils.TimeNowUTC())
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"success": false,
+			"data":    nil,
+			"error":   "Failed to generate token",
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"success": true,
+		"data": dto.AuthResponse{
+			User:          dto.ToUserResponse(user),
+			Token:         token,
+			RecoveryCodes: recoveryCodes,
+		},
+		"error": nil,
+	})
+}
+
 // Refresh handles token refresh
 // POST /auth/refresh
 // Authorization: Bearer <token>
@@ -112,7 +209,7 @@ func (h *AuthHandler) Refresh(c *fiber.Ctx) error {
 	}
 
 	// Validate token and get claims
-	claims, err := h.jwtHelper.ValidateTokenMapClaims(tokenString)
+	claims, err := h.jwtHelper.ValidateToken(tokenString)
 	if err != nil {
 		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
 			"success": false,
@@ -122,8 +219,7 @@ func (h *AuthHandler) Refresh(c *fiber.Ctx) error {
 	}
 
 	// Get user from claims
-	userID, ok := claims["user_id"].(string)
-	if !ok {
+	if claims.UserID == "" {
 		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
 			"success": false,
 			"data":    nil,
@@ -131,9 +227,12 @@ func (h *AuthHandler) Refresh(c *fiber.Ctx) error {
 		})
 	}
 
-	// Refresh token using auth service
-	_, newToken, err := h.authService.Refresh(c.Context(), userID)
+	// Refresh token using auth service, in the token's tenant (this route has no auth middleware)
+	_, newToken, err := h.authService.Refresh(tenancy.WithTenant(c.Context(), claims.Tenant()), claims.UserID)
 	if err != nil {
+		if errors.Is(err, usecase.ErrAccountDeactivated) {
+			return deactivated(c)
+		}
 		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
 			"success": false,
 			"data":    nil,
@@ -193,3 +292,135 @@ func (h *AuthHandler) Logout(c *fiber.Ctx) error {
 		"error": nil,
 	})
 }
+
+// UnlockUser unlocks an account locked after failed logins
+// POST /api/auth/users/:id/unlock
+func (h *AuthHandler) UnlockUser(c *fiber.Ctx) error {
+	id := c.Params("id")
+	if id == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"success": false,
+			"data":    nil,
+			"error":   "User ID is required",
+		})
+	}
+
+	adminID := c.Locals("user_id").(string)
+	if err := h.authService.UnlockUser(c.Context(), id, adminID, clientInfo(c)); err != nil {
+		if errors.Is(err, usecase.ErrUserNotFound) {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"success": false,
+				"data":    nil,
+				"error":   "User not found",
+			})
+		}
+		if errors.Is(err, usecase.ErrAccountNotLocked) {
+			return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+				"success": false,
+				"data":    nil,
+				"error":   err.Error(),
+			})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"success": false,
+			"data":    nil,
+			"error":   "Failed to unlock user",
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"success": true,
+		"data": fiber.Map{
+			"message": "Account unlocked",
+		},
+		"error": nil,
+	})
+}
+
+// ListAuditLog retrieves authentication events
+// GET /api/auth/audit-log?user_id=&email=&event=&ip=&page=&limit=
+func (h *AuthHandler) ListAuditLog(c *fiber.Ctx) error {
+	page, _ := strconv.Atoi(c.Query("page", "1"))
+	limit, _ := strconv.Atoi(c.Query("limit", "10"))
+
+	filter := domain.AuthAuditFilter{
+		UserID: c.Query("user_id"),
+		Email:  c.Query("email"),
+		Event:  domain.AuthEvent(c.Query("event")),
+		IP:     c.Query("ip"),
+	}
+
+	entries, total, err := h.authService.ListAuditLog(c.Context(), filter, page, limit)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"success": false,
+			"data":    nil,
+			"error":   "Failed to list audit log",
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"success": true,
+		"data": fiber.Map{
+			"entries": entries,
+			"total":   total,
+			"page":    page,
+			"limit":   limit,
+		},
+		"error": nil,
+	})
+}
+
+// clientInfo extracts the client IP and user agent for auditing and throttling
+func clientInfo(c *fiber.Ctx) domain.ClientInfo {
+	return domain.ClientInfo{
+		IP:        c.IP(),
+		UserAgent: c.Get(fiber.HeaderUserAgent),
+	}
+}
+
+// twoFactorChallenge responds to a login that passed its first factor but must
+// be completed with a second one at POST /auth/login/2fa
+func twoFactorChallenge(c *fiber.Ctx, challenge *usecase.TwoFactorChallenge) error {
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"success": true,
+		"data": dto.TwoFactorChallengeResponse{
+			TwoFactorRequired:  true,
+			ChallengeToken:     challenge.Token,
+			ExpiresIn:          int(challenge.ExpiresIn.Seconds()),
+			EnrollmentRequired: challenge.EnrollmentRequired,
+			Enrollment:         challenge.Enrollment,
+		},
+		"error": nil,
+	})
+}
+
+// deactivated responds 403 for a deactivated account
+func deactivated(c *fiber.Ctx) error {
+	return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+		"success": false,
+		"data":    nil,
+		"error":   usecase.ErrAccountDeactivated.Error(),
+		"code":    "ACCOUNT_DEACTIVATED",
+	})
+}
+
+// throttled responds 429 with Retry-After for a refused login
+func throttled(c *fiber.Ctx, err *usecase.ThrottleError) error {
+	code := "TOO_MANY_ATTEMPTS"
+	switch {
+	case errors.Is(err, usecase.ErrAccountLocked):
+		code = "ACCOUNT_LOCKED"
+	case errors.Is(err, usecase.ErrIPBlocked):
+		code = "IP_BLOCKED"
+	}
+
+	retryAfter := int(math.Ceil(err.RetryAfter.Seconds()))
+	c.Set(fiber.HeaderRetryAfter, strconv.Itoa(retryAfter))
+	return c.Status(fiber.StatusTooManyRequests).JSON(fiber.Map{
+		"success": false,
+		"data":    nil,
+		"error":   err.Reason.Error(),
+		"code":    code,
+	})
+}
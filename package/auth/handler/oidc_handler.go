@@ -0,0 +1,159 @@
+package handler
+
+import (
+	"errors"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+
+	"workflow-approval/package/auth/domain/dto"
+	"workflow-approval/package/auth/ports"
+	"workflow-approval/package/auth/usecase"
+	"workflow-approval/utils/jwthelper"
+)
+
+// oidcStateCookie binds a pending OIDC login to the browser that started it
+const oidcStateCookie = "oidc_state"
+
+// OIDCHandler handles HTTP requests for OpenID Connect login
+type OIDCHandler struct {
+	oidcService ports.OIDCService
+	jwtHelper   *jwthelper.JWTHelper
+}
+
+// NewOIDCHandler creates a new OIDCHandler instance
+func NewOIDCHandler(oidcService ports.OIDCService, jwtHelper *jwthelper.JWTHelper) *OIDCHandler {
+	return &OIDCHandler{
+		oidcService: oidcService,
+		jwtHelper:   jwtHelper,
+	}
+}
+
+// Routes defines all routes for OIDC login
+// Mounts routes under /auth/oidc
+func (h *OIDCHandler) Routes(group fiber.Router) {
+	// GET /auth/oidc/login - Redirect to the identity provider (authorization code + PKCE)
+	group.Get("/login", h.Login)
+
+	// GET /auth/oidc/callback - Redirect target of the identity provider, returns a JWT
+	group.Get("/callback", h.Callback)
+}
+
+// Login starts the OIDC flow and binds it to the browser with the oidc_state cookie
+// GET /auth/oidc/login
+// Redirects to the provider, or returns the URL as JSON when ?redirect=false
+func (h *OIDCHandler) Login(c *fiber.Ctx) error {
+	authURL, state, err := h.oidcService.BeginLogin(c.Context())
+	if err != nil {
+		return c.Status(fiber.StatusBadGateway).JSON(fiber.Map{
+			"success": false,
+			"data":    nil,
+			"error":   "Identity provider is unavailable",
+		})
+	}
+
+	// Lax still sends the cookie on the provider's top-level redirect back to us
+	c.Cookie(&fiber.Cookie{
+		Name:     oidcStateCookie,
+		Value:    state,
+		Path:     "/auth/oidc",
+		MaxAge:   int(usecase.OIDCStateTTL / time.Second),
+		Secure:   c.Protocol() == "https",
+		HTTPOnly: true,
+		SameSite: fiber.CookieSameSiteLaxMode,
+	})
+
+	if c.Query("redirect") == "false" {
+		return c.Status(fiber.StatusOK).JSON(fiber.Map{
+			"success": true,
+			"data": fiber.Map{
+				"authorization_url": authURL,
+			},
+			"error": nil,
+		})
+	}
+	return c.Redirect(authURL, fiber.StatusFound)
+}
+
+// Callback completes the OIDC flow and issues an application JWT. The state
+// must match the oidc_state cookie set by Login in the same browser. Users the
+// 2FA policy applies to get a challenge instead, as from POST /auth/login.
+// GET /auth/oidc/callback?code=...&state=...
+func (h *OIDCHandler) Callback(c *fiber.Ctx) error {
+	if providerError := c.Query("error"); providerError != "" {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"success": false,
+			"data":    nil,
+			"error":   "Identity provider returned an error: " + providerError,
+		})
+	}
+
+	state := c.Query("state")
+	code := c.Query("code")
+	if state == "" || code == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"success": false,
+			"data":    nil,
+			"error":   "Code and state are required",
+		})
+	}
+
+	browserState := c.Cookies(oidcStateCookie)
+	c.Cookie(&fiber.Cookie{
+		Name:     oidcStateCookie,
+		Path:     "/auth/oidc",
+		Expires:  time.Unix(0, 0),
+		Secure:   c.Protocol() == "https",
+		HTTPOnly: true,
+		SameSite: fiber.CookieSameSiteLaxMode,
+	})
+
+	user, err := h.oidcService.CompleteLogin(c.Context(), state, browserState, code, clientInfo(c))
+	if err != nil {
+		if errors.Is(err, usecase.ErrOIDCInvalidState) {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"success": false,
+				"data":    nil,
+				"error":   err.Error(),
+			})
+		}
+		if errors.Is(err, usecase.ErrAccountDeactivated) {
+			return deactivated(c)
+		}
+		// Finished with POST /auth/login/2fa, which issues the token
+		var challenge *usecase.TwoFactorChallenge
+		if errors.As(err, &challenge) {
+			return twoFactorChallenge(c, challenge)
+		}
+		if errors.Is(err, usecase.ErrOIDCEmailMissing) || errors.Is(err, usecase.ErrOIDCEmailNotVerified) || errors.Is(err, usecase.ErrOIDCActorNotFound) {
+			return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+				"success": false,
+				"data":    nil,
+				"error":   err.Error(),
+			})
+		}
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"success": false,
+			"data":    nil,
+			"error":   "OIDC login failed",
+		})
+	}
+
+	token, err := h.jwtHelper.GenerateJWT(user)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"success": false,
+			"data":    nil,
+			"error":   "Failed to generate token",
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"success": true,
+		"data": dto.AuthResponse{
+			User:  dto.ToUserResponse(user),
+			Token: token,
+		},
+		"error": nil,
+	})
+}
@@ -0,0 +1,237 @@
+package handler
+
+import (
+	"errors"
+
+	"github.com/gofiber/fiber/v2"
+
+	"workflow-approval/framework/middleware"
+	"workflow-approval/package/auth/domain/dto"
+	"workflow-approval/package/auth/ports"
+	"workflow-approval/package/auth/usecase"
+	"workflow-approval/utils"
+	"workflow-approval/utils/jwthelper"
+)
+
+// TwoFactorHandler handles HTTP requests for self-service TOTP management
+type TwoFactorHandler struct {
+	twoFactorService ports.TwoFactorService
+	jwtHelper        *jwthelper.JWTHelper
+}
+
+// NewTwoFactorHandler creates a new TwoFactorHandler instance
+func NewTwoFactorHandler(twoFactorService ports.TwoFactorService, jwtHelper *jwthelper.JWTHelper) *TwoFactorHandler {
+	return &TwoFactorHandler{
+		twoFactorService: twoFactorService,
+		jwtHelper:        jwtHelper,
+	}
+}
+
+// Routes defines all routes for two-factor management
+// Mounts routes under /api/profile/2fa (user sessions only, not API keys)
+func (h *TwoFactorHandler) Routes(group fiber.Router) {
+	group.Use(requireUserSession)
+
+	// GET /api/profile/2fa - Two-factor status of the current user
+	group.Get("/", h.Status)
+
+	// POST /api/profile/2fa/enroll - Start enrollment, returns secret and otpauth:// URI
+	group.Post("/enroll", h.Enroll)
+
+	// POST /api/profile/2fa/confirm - Confirm enrollment with a code, returns recovery codes
+	group.Post("/confirm", h.Confirm)
+
+	// POST /api/profile/2fa/disable - Disable two-factor authentication
+	group.Post("/disable", h.Disable)
+
+	// POST /api/profile/2fa/recovery-codes - Replace all recovery codes
+	group.Post("/recovery-codes", h.RegenerateRecoveryCodes)
+
+	// POST /api/profile/2fa/step-up - Re-verify a code, returns a token for high-value approvals
+	group.Post("/step-up", h.StepUp)
+}
+
+// Status returns the two-factor setup of the current user
+// GET /api/profile/2fa
+func (h *TwoFactorHandler) Status(c *fiber.Ctx) error {
+	status, err := h.twoFactorService.Status(c.Context(), c.Locals("user_id").(string))
+	if err != nil {
+		return twoFactorError(c, err)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"success": true,
+		"data":    status,
+		"error":   nil,
+	})
+}
+
+// Enroll starts TOTP enrollment
+// POST /api/profile/2fa/enroll
+func (h *TwoFactorHandler) Enroll(c *fiber.Ctx) error {
+	enrollment, err := h.twoFactorService.BeginEnrollment(c.Context(), c.Locals("user_id").(string))
+	if err != nil {
+		return twoFactorError(c, err)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"success": true,
+		"data":    enrollment,
+		"error":   nil,
+	})
+}
+
+// Confirm enables TOTP with a code from the new secret
+// POST /api/profile/2fa/confirm
+// Request Body: {"code": "123456"}
+func (h *TwoFactorHandler) Confirm(c *fiber.Ctx) error {
+	code, ok := parseCode(c)
+	if !ok {
+		return codeRequired(c)
+	}
+
+	recoveryCodes, err := h.twoFactorService.ConfirmEnrollment(c.Context(), c.Locals("user_id").(string), code, clientInfo(c))
+	if err != nil {
+		return twoFactorError(c, err)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"success": true,
+		"data":    dto.RecoveryCodesResponse{RecoveryCodes: recoveryCodes},
+		"error":   nil,
+	})
+}
+
+// Disable turns TOTP off
+// POST /api/profile/2fa/disable
+// Request Body: {"code": "123456"}
+func (h *TwoFactorHandler) Disable(c *fiber.Ctx) error {
+	code, ok := parseCode(c)
+	if !ok {
+		return codeRequired(c)
+	}
+
+	if err := h.twoFactorService.Disable(c.Context(), c.Locals("user_id").(string), code, clientInfo(c)); err != nil {
+		return twoFactorError(c, err)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"success": true,
+		"data": fiber.Map{
+			"message": "Two-factor authentication disabled",
+		},
+		"error": nil,
+	})
+}
+
+// RegenerateRecoveryCodes replaces all recovery codes
+// POST /api/profile/2fa/recovery-codes
+// Request Body: {"code": "123456"}
+func (h *TwoFactorHandler) RegenerateRecoveryCodes(c *fiber.Ctx) error {
+	code, ok := parseCode(c)
+	if !ok {
+		return codeRequired(c)
+	}
+
+	recoveryCodes, err := h.twoFactorService.RegenerateRecoveryCodes(c.Context(), c.Locals("user_id").(string), code, clientInfo(c))
+	if err != nil {
+		return twoFactorError(c, err)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"success": true,
+		"data":    dto.RecoveryCodesResponse{RecoveryCodes: recoveryCodes},
+		"error":   nil,
+	})
+}
+
+// StepUp re-verifies a TOTP code and issues a token that satisfies step-up checks
+// POST /api/profile/2fa/step-up
+// Request Body: {"code": "123456"}
+func (h *TwoFactorHandler) StepUp(c *fiber.Ctx) error {
+	code, ok := parseCode(c)
+	if !ok {
+		return codeRequired(c)
+	}
+
+	user, err := h.twoFactorService.StepUp(c.Context(), c.Locals("user_id").(string), code, clientInfo(c))
+	if err != nil {
+		return twoFactorError(c, err)
+	}
+
+	token, err := h.jwtHelper.GenerateMFAJWT(user, utils.TimeNowUTC())
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"success": false,
+			"data":    nil,
+			"error":   "Failed to generate token",
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"success": true,
+		"data": fiber.Map{
+			"token": token,
+		},
+		"error": nil,
+	})
+}
+
+// requireUserSession rejects API keys: two-factor settings belong to a person
+func requireUserSession(c *fiber.Ctx) error {
+	if middleware.GetAuthTypeFromContext(c) != middleware.AuthTypeJWT {
+		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+			"success": false,
+			"data":    nil,
+			"error":   "Two-factor management requires a user session",
+		})
+	}
+	return c.Next()
+}
+
+// parseCode reads the code from the request body
+func parseCode(c *fiber.Ctx) (string, bool) {
+	var req dto.TwoFactorCodeRequest
+	if err := c.BodyParser(&req); err != nil || req.Code == "" {
+		return "", false
+	}
+	return req.Code, true
+}
+
+// codeRequired responds 400 for a missing code
+func codeRequired(c *fiber.Ctx) error {
+	return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+		"success": false,
+		"data":    nil,
+		"error":   "Code is required",
+	})
+}
+
+// twoFactorError maps two-factor service errors to HTTP responses
+func twoFactorError(c *fiber.Ctx, err error) error {
+	var throttleErr *usecase.ThrottleError
+	if errors.As(err, &throttleErr) {
+		return throttled(c, throttleErr)
+	}
+
+	status := fiber.StatusInternalServerError
+	message := "Failed to process two-factor request"
+	switch {
+	case errors.Is(err, usecase.ErrUserNotFound):
+		status, message = fiber.StatusNotFound, "User not found"
+	case errors.Is(err, usecase.ErrTwoFactorInvalidCode):
+		status, message = fiber.StatusUnauthorized, err.Error()
+	case errors.Is(err, usecase.ErrTwoFactorRequired):
+		status, message = fiber.StatusForbidden, err.Error()
+	case errors.Is(err, usecase.ErrTwoFactorAlreadyEnabled),
+		errors.Is(err, usecase.ErrTwoFactorNotEnabled),
+		errors.Is(err, usecase.ErrTwoFactorNotEnrolled):
+		status, message = fiber.StatusConflict, err.Error()
+	}
+
+	return c.Status(status).JSON(fiber.Map{
+		"success": false,
+		"data":    nil,
+		"error":   message,
+	})
+}
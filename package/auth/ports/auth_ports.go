@@ -2,7 +2,10 @@ package ports
 
 import (
 	"context"
+	"time"
 
+	actorDomain "workflow-approval/package/actor/domain"
+	authDomain "workflow-approval/package/auth/domain"
 	"workflow-approval/package/user/domain"
 )
 
@@ -10,11 +13,109 @@ import (
 type AuthRepository interface {
 	GetByEmail(ctx context.Context, email string) (*domain.User, error)
 	GetByID(ctx context.Context, id string) (*domain.User, error)
+	GetByOIDCSubject(ctx context.Context, subject string) (*domain.User, error)
+	Create(ctx context.Context, user *domain.User) error
+	Update(ctx context.Context, user *domain.User) error
+	SaveActorMemberships(ctx context.Context, user *domain.User) error
+}
+
+// ActorRepository defines the actor lookups needed to map OIDC claims to actors
+type ActorRepository interface {
+	GetByCode(ctx context.Context, code string) (*actorDomain.Actor, error)
+}
+
+// LoginAttemptRepository defines the interface for failed-login tracking
+type LoginAttemptRepository interface {
+	Get(ctx context.Context, key string) (*authDomain.LoginAttempt, error)
+	// RecordFailure increments the failure count, restarting it when the last failure is older than window
+	RecordFailure(ctx context.Context, key string, now time.Time, window time.Duration) (*authDomain.LoginAttempt, error)
+	Lock(ctx context.Context, key string, until time.Time) error
+	Reset(ctx context.Context, key string) error
+}
+
+// AuthAuditLogRepository defines the interface for the authentication audit log
+type AuthAuditLogRepository interface {
+	Create(ctx context.Context, entry *authDomain.AuthAuditLog) error
+	List(ctx context.Context, filter authDomain.AuthAuditFilter, page, limit int) ([]*authDomain.AuthAuditLog, int64, error)
+}
+
+// UserTokenRepository defines the interface for password reset and email verification tokens
+type UserTokenRepository interface {
+	Create(ctx context.Context, token *authDomain.UserToken) error
+	GetByHash(ctx context.Context, tokenHash string) (*authDomain.UserToken, error)
+	GetLatest(ctx context.Context, userID string, purpose authDomain.TokenPurpose) (*authDomain.UserToken, error)
+	MarkUsed(ctx context.Context, id string, usedAt time.Time) error
+	InvalidateAll(ctx context.Context, userID string, purpose authDomain.TokenPurpose, usedAt time.Time) error
+}
+
+// TwoFactorChallengeRepository defines the interface for logins waiting for their second factor
+type TwoFactorChallengeRepository interface {
+	Create(ctx context.Context, challenge *authDomain.PendingTwoFactor) error
+	GetByHash(ctx context.Context, tokenHash string) (*authDomain.PendingTwoFactor, error)
+	AddFailure(ctx context.Context, id string) error
+	// Delete removes a challenge; losing a race to remove it fails with an error
+	Delete(ctx context.Context, id string) error
+	DeleteExpired(ctx context.Context, now time.Time) error
+}
+
+// OIDCStateRepository defines the interface for OIDC logins waiting for the provider's callback
+type OIDCStateRepository interface {
+	Create(ctx context.Context, state *authDomain.OIDCLoginState) error
+	// Take removes and returns a state, so each one completes a single login
+	Take(ctx context.Context, stateHash string) (*authDomain.OIDCLoginState, error)
+	DeleteExpired(ctx context.Context, now time.Time) error
+}
+
+// SecondFactorChallenger starts the second-factor challenge of logins that
+// passed a first factor outside of AuthService.Login, such as OIDC
+type SecondFactorChallenger interface {
+	ChallengeSecondFactor(ctx context.Context, user *domain.User, firstFactor string) error
 }
 
 // AuthService defines the interface for authentication business logic
 type AuthService interface {
-	Login(ctx context.Context, email, password string) (*domain.User, string, error)
+	// Login verifies the password; when a second factor is required it returns a *usecase.TwoFactorChallenge error
+	Login(ctx context.Context, email, password string, client authDomain.ClientInfo) (*domain.User, string, error)
+	// ChallengeSecondFactor returns a *usecase.TwoFactorChallenge when a user who passed another first factor must pass a second one
+	ChallengeSecondFactor(ctx context.Context, user *domain.User, firstFactor string) error
+	// VerifyTwoFactor completes a challenged login; recovery codes are returned when it also completed enrollment
+	VerifyTwoFactor(ctx context.Context, challengeToken, code string, client authDomain.ClientInfo) (*domain.User, []string, error)
 	Refresh(ctx context.Context, userID string) (*domain.User, string, error)
 	Logout(ctx context.Context, token string) error
+	UnlockUser(ctx context.Context, userID, adminID string, client authDomain.ClientInfo) error
+	ListAuditLog(ctx context.Context, filter authDomain.AuthAuditFilter, page, limit int) ([]*authDomain.AuthAuditLog, int64, error)
+}
+
+// TwoFactorService defines the interface for self-service TOTP management and step-up verification
+type TwoFactorService interface {
+	Status(ctx context.Context, userID string) (*authDomain.TwoFactorStatus, error)
+	BeginEnrollment(ctx context.Context, userID string) (*authDomain.TOTPEnrollment, error)
+	ConfirmEnrollment(ctx context.Context, userID, code string, client authDomain.ClientInfo) ([]string, error)
+	Disable(ctx context.Context, userID, code string, client authDomain.ClientInfo) error
+	RegenerateRecoveryCodes(ctx context.Context, userID, code string, client authDomain.ClientInfo) ([]string, error)
+	// StepUp re-verifies a TOTP code before a sensitive action such as a high-value approval
+	StepUp(ctx context.Context, userID, code string, client authDomain.ClientInfo) (*domain.User, error)
+}
+
+// AccountService defines the interface for password reset, password change and email verification
+type AccountService interface {
+	// RequestPasswordReset emails a reset link; unknown emails are ignored so callers cannot probe accounts
+	RequestPasswordReset(ctx context.Context, email string, client authDomain.ClientInfo) error
+	ResetPassword(ctx context.Context, token, newPassword string, client authDomain.ClientInfo) error
+	ChangePassword(ctx context.Context, userID, currentPassword, newPassword string, client authDomain.ClientInfo) (*domain.User, error)
+	// SendEmailVerification emails a verification link, e.g. right after registration
+	SendEmailVerification(ctx context.Context, user *domain.User) error
+	ResendEmailVerification(ctx context.Context, userID string) error
+	VerifyEmail(ctx context.Context, token string, client authDomain.ClientInfo) (*domain.User, error)
+}
+
+// OIDCService defines the interface for OpenID Connect login
+type OIDCService interface {
+	// BeginLogin starts an authorization code + PKCE flow and returns the provider URL
+	// and the state, which the caller must bind to the browser (e.g. in a cookie)
+	BeginLogin(ctx context.Context) (authURL, state string, err error)
+	// CompleteLogin redeems the callback code and returns the (JIT-provisioned) user,
+	// or a *usecase.TwoFactorChallenge error when the user must pass a second factor.
+	// browserState is the state bound to the browser by BeginLogin's caller and must match state.
+	CompleteLogin(ctx context.Context, state, browserState, code string, client authDomain.ClientInfo) (*domain.User, error)
 }
@@ -0,0 +1,64 @@
+package repository
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+
+	"workflow-approval/package/auth/domain"
+	"workflow-approval/package/auth/ports"
+)
+
+// AuthAuditLogRepositoryImpl implements AuthAuditLogRepository interface
+type AuthAuditLogRepositoryImpl struct {
+	db *gorm.DB
+}
+
+// NewAuthAuditLogRepository creates a new AuthAuditLogRepositoryImpl instance
+func NewAuthAuditLogRepository(db *gorm.DB) ports.AuthAuditLogRepository {
+	return &AuthAuditLogRepositoryImpl{db: db}
+}
+
+// Create records an audit log entry
+func (r *AuthAuditLogRepositoryImpl) Create(ctx context.Context, entry *domain.AuthAuditLog) error {
+	return r.db.WithContext(ctx).Create(entry).Error
+}
+
+// List retrieves a paginated, filtered list of audit log entries, newest first
+func (r *AuthAuditLogRepositoryImpl) List(ctx context.Context, filter domain.AuthAuditFilter, page, limit int) ([]*domain.AuthAuditLog, int64, error) {
+	var entries []*domain.AuthAuditLog
+	var total int64
+
+	offset := (page - 1) * limit
+
+	query := r.db.WithContext(ctx).Model(&domain.AuthAuditLog{})
+
+	if filter.UserID != "" {
+		query = query.Where("user_id = ?", filter.UserID)
+	}
+	if filter.Email != "" {
+		query = query.Where("email = ?", filter.Email)
+	}
+	if filter.Event != "" {
+		query = query.Where("event = ?", filter.Event)
+	}
+	if filter.IP != "" {
+		query = query.Where("ip_address = ?", filter.IP)
+	}
+
+	// Get total count
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	// Get paginated results
+	if err := query.
+		Order("created_at DESC").
+		Offset(offset).
+		Limit(limit).
+		Find(&entries).Error; err != nil {
+		return nil, 0, err
+	}
+
+	return entries, total, nil
+}
@@ -7,6 +7,7 @@ import (
 	"workflow-approval/package/auth/ports"
 	"workflow-approval/package/user/domain"
 	userPorts "workflow-approval/package/user/ports"
+	userRepository "workflow-approval/package/user/repository"
 )
 
 var ErrUserNotFound = errors.New("user not found")
@@ -24,10 +25,42 @@ func NewAuthRepository(userRepo userPorts.UserRepository) ports.AuthRepository {
 
 // GetByEmail retrieves a user by email
 func (r *AuthRepositoryImpl) GetByEmail(ctx context.Context, email string) (*domain.User, error) {
-	return r.userRepo.GetByEmail(ctx, email)
+	user, err := r.userRepo.GetByEmail(ctx, email)
+	if errors.Is(err, userRepository.ErrUserNotFound) {
+		return nil, ErrUserNotFound
+	}
+	return user, err
 }
 
 // GetByID retrieves a user by ID
 func (r *AuthRepositoryImpl) GetByID(ctx context.Context, id string) (*domain.User, error) {
-	return r.userRepo.GetByID(ctx, id)
+	user, err := r.userRepo.GetByID(ctx, id)
+	if errors.Is(err, userRepository.ErrUserNotFound) {
+		return nil, ErrUserNotFound
+	}
+	return user, err
+}
+
+// GetByOIDCSubject retrieves a user by the subject of its linked OIDC identity
+func (r *AuthRepositoryImpl) GetByOIDCSubject(ctx context.Context, subject string) (*domain.User, error) {
+	user, err := r.userRepo.GetByOIDCSubject(ctx, subject)
+	if errors.Is(err, userRepository.ErrUserNotFound) {
+		return nil, ErrUserNotFound
+	}
+	return user, err
+}
+
+// Create creates a new user
+func (r *AuthRepositoryImpl) Create(ctx context.Context, user *domain.User) error {
+	return r.userRepo.Create(ctx, user)
+}
+
+// Update updates an existing user
+func (r *AuthRepositoryImpl) Update(ctx context.Context, user *domain.User) error {
+	return r.userRepo.Update(ctx, user)
+}
+
+// SaveActorMemberships replaces the stored actor memberships of a user
+func (r *AuthRepositoryImpl) SaveActorMemberships(ctx context.Context, user *domain.User) error {
+	return r.userRepo.SaveActorMemberships(ctx, user)
 }
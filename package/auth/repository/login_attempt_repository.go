@@ -0,0 +1,75 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+
+	"workflow-approval/package/auth/domain"
+	"workflow-approval/package/auth/ports"
+	"workflow-approval/utils/tenancy"
+)
+
+// LoginAttemptRepositoryImpl implements LoginAttemptRepository interface
+type LoginAttemptRepositoryImpl struct {
+	db *gorm.DB
+}
+
+// NewLoginAttemptRepository creates a new LoginAttemptRepositoryImpl instance
+func NewLoginAttemptRepository(db *gorm.DB) ports.LoginAttemptRepository {
+	return &LoginAttemptRepositoryImpl{db: db}
+}
+
+// Get retrieves the tracking record of a key, or nil when there is none
+func (r *LoginAttemptRepositoryImpl) Get(ctx context.Context, key string) (*domain.LoginAttempt, error) {
+	var attempt domain.LoginAttempt
+	result := r.db.WithContext(ctx).First(&attempt, "attempt_key = ?", key)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, result.Error
+	}
+	return &attempt, nil
+}
+
+// RecordFailure atomically increments the failure count of a key (upsert), so
+// concurrent attempts from several instances are all counted. The raw upsert
+// bypasses the tenancy plugin, so the key is moved into ctx's tenant here,
+// or the default tenant when ctx spans all tenants.
+func (r *LoginAttemptRepositoryImpl) RecordFailure(ctx context.Context, key string, now time.Time, window time.Duration) (*domain.LoginAttempt, error) {
+	tenantID := tenancy.TenantID(ctx)
+	if tenantID == "" {
+		tenantID = tenancy.DefaultTenantID
+	}
+
+	windowStart := now.Add(-window)
+	err := r.db.WithContext(ctx).Exec(`
+		INSERT INTO login_attempts (attempt_key, tenant_id, failures, last_failure_at, locked_until)
+		VALUES (?, ?, 1, ?, NULL)
+		ON DUPLICATE KEY UPDATE
+			tenant_id = VALUES(tenant_id),
+			failures = IF(last_failure_at < ?, 1, failures + 1),
+			last_failure_at = VALUES(last_failure_at)`,
+		key, tenantID, now, windowStart,
+	).Error
+	if err != nil {
+		return nil, err
+	}
+	return r.Get(ctx, key)
+}
+
+// Lock locks a key until the given time
+func (r *LoginAttemptRepositoryImpl) Lock(ctx context.Context, key string, until time.Time) error {
+	return r.db.WithContext(ctx).
+		Model(&domain.LoginAttempt{}).
+		Where("attempt_key = ?", key).
+		UpdateColumn("locked_until", until).Error
+}
+
+// Reset clears failures and any lock of a key
+func (r *LoginAttemptRepositoryImpl) Reset(ctx context.Context, key string) error {
+	return r.db.WithContext(ctx).Delete(&domain.LoginAttempt{}, "attempt_key = ?", key).Error
+}
@@ -0,0 +1,56 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+
+	"workflow-approval/package/auth/domain"
+	"workflow-approval/package/auth/ports"
+)
+
+var ErrOIDCStateNotFound = errors.New("oidc login state not found")
+
+// OIDCStateRepositoryImpl implements OIDCStateRepository interface
+type OIDCStateRepositoryImpl struct {
+	db *gorm.DB
+}
+
+// NewOIDCStateRepository creates a new OIDCStateRepositoryImpl instance
+func NewOIDCStateRepository(db *gorm.DB) ports.OIDCStateRepository {
+	return &OIDCStateRepositoryImpl{db: db}
+}
+
+// Create stores a new login state
+func (r *OIDCStateRepositoryImpl) Create(ctx context.Context, state *domain.OIDCLoginState) error {
+	return r.db.WithContext(ctx).Create(state).Error
+}
+
+// Take retrieves and deletes a login state; the conditional delete makes
+// concurrent callbacks with the same state fail with ErrOIDCStateNotFound
+func (r *OIDCStateRepositoryImpl) Take(ctx context.Context, stateHash string) (*domain.OIDCLoginState, error) {
+	var state domain.OIDCLoginState
+	result := r.db.WithContext(ctx).First(&state, "state_hash = ?", stateHash)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, ErrOIDCStateNotFound
+		}
+		return nil, result.Error
+	}
+
+	deleted := r.db.WithContext(ctx).Delete(&domain.OIDCLoginState{}, "id = ?", state.ID)
+	if deleted.Error != nil {
+		return nil, deleted.Error
+	}
+	if deleted.RowsAffected == 0 {
+		return nil, ErrOIDCStateNotFound
+	}
+	return &state, nil
+}
+
+// DeleteExpired removes the login states that expired before now
+func (r *OIDCStateRepositoryImpl) DeleteExpired(ctx context.Context, now time.Time) error {
+	return r.db.WithContext(ctx).Delete(&domain.OIDCLoginState{}, "expires_at < ?", now).Error
+}
@@ -0,0 +1,67 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+
+	"workflow-approval/package/auth/domain"
+	"workflow-approval/package/auth/ports"
+)
+
+var ErrTwoFactorChallengeNotFound = errors.New("two-factor challenge not found")
+
+// TwoFactorChallengeRepositoryImpl implements TwoFactorChallengeRepository interface
+type TwoFactorChallengeRepositoryImpl struct {
+	db *gorm.DB
+}
+
+// NewTwoFactorChallengeRepository creates a new TwoFactorChallengeRepositoryImpl instance
+func NewTwoFactorChallengeRepository(db *gorm.DB) ports.TwoFactorChallengeRepository {
+	return &TwoFactorChallengeRepositoryImpl{db: db}
+}
+
+// Create stores a new challenge
+func (r *TwoFactorChallengeRepositoryImpl) Create(ctx context.Context, challenge *domain.PendingTwoFactor) error {
+	return r.db.WithContext(ctx).Create(challenge).Error
+}
+
+// GetByHash retrieves a challenge by the hash of its token
+func (r *TwoFactorChallengeRepositoryImpl) GetByHash(ctx context.Context, tokenHash string) (*domain.PendingTwoFactor, error) {
+	var challenge domain.PendingTwoFactor
+	result := r.db.WithContext(ctx).First(&challenge, "token_hash = ?", tokenHash)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, ErrTwoFactorChallengeNotFound
+		}
+		return nil, result.Error
+	}
+	return &challenge, nil
+}
+
+// AddFailure counts a wrong code for the challenge
+func (r *TwoFactorChallengeRepositoryImpl) AddFailure(ctx context.Context, id string) error {
+	return r.db.WithContext(ctx).
+		Model(&domain.PendingTwoFactor{}).
+		Where("id = ?", id).
+		UpdateColumn("failures", gorm.Expr("failures + 1")).Error
+}
+
+// Delete removes a challenge; ErrTwoFactorChallengeNotFound tells a concurrent removal won
+func (r *TwoFactorChallengeRepositoryImpl) Delete(ctx context.Context, id string) error {
+	result := r.db.WithContext(ctx).Delete(&domain.PendingTwoFactor{}, "id = ?", id)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrTwoFactorChallengeNotFound
+	}
+	return nil
+}
+
+// DeleteExpired removes the challenges that expired before now
+func (r *TwoFactorChallengeRepositoryImpl) DeleteExpired(ctx context.Context, now time.Time) error {
+	return r.db.WithContext(ctx).Delete(&domain.PendingTwoFactor{}, "expires_at < ?", now).Error
+}
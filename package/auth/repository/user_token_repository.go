@@ -0,0 +1,84 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+
+	"workflow-approval/package/auth/domain"
+	"workflow-approval/package/auth/ports"
+)
+
+var (
+	ErrUserTokenNotFound = errors.New("user token not found")
+	ErrUserTokenUsed     = errors.New("user token already used")
+)
+
+// UserTokenRepositoryImpl implements UserTokenRepository interface
+type UserTokenRepositoryImpl struct {
+	db *gorm.DB
+}
+
+// NewUserTokenRepository creates a new UserTokenRepositoryImpl instance
+func NewUserTokenRepository(db *gorm.DB) ports.UserTokenRepository {
+	return &UserTokenRepositoryImpl{db: db}
+}
+
+// Create stores a new token
+func (r *UserTokenRepositoryImpl) Create(ctx context.Context, token *domain.UserToken) error {
+	return r.db.WithContext(ctx).Create(token).Error
+}
+
+// GetByHash retrieves a token by the hash of its plaintext
+func (r *UserTokenRepositoryImpl) GetByHash(ctx context.Context, tokenHash string) (*domain.UserToken, error) {
+	var token domain.UserToken
+	result := r.db.WithContext(ctx).First(&token, "token_hash = ?", tokenHash)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, ErrUserTokenNotFound
+		}
+		return nil, result.Error
+	}
+	return &token, nil
+}
+
+// GetLatest retrieves the most recently issued token of a user for a purpose, or nil when there is none
+func (r *UserTokenRepositoryImpl) GetLatest(ctx context.Context, userID string, purpose domain.TokenPurpose) (*domain.UserToken, error) {
+	var token domain.UserToken
+	result := r.db.WithContext(ctx).
+		Where("user_id = ? AND purpose = ?", userID, purpose).
+		Order("created_at DESC").
+		First(&token)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, result.Error
+	}
+	return &token, nil
+}
+
+// MarkUsed consumes a token; the conditional update makes concurrent redemptions fail with ErrUserTokenUsed
+func (r *UserTokenRepositoryImpl) MarkUsed(ctx context.Context, id string, usedAt time.Time) error {
+	result := r.db.WithContext(ctx).
+		Model(&domain.UserToken{}).
+		Where("id = ? AND used_at IS NULL", id).
+		UpdateColumn("used_at", usedAt)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrUserTokenUsed
+	}
+	return nil
+}
+
+// InvalidateAll consumes every unused token of a user for a purpose
+func (r *UserTokenRepositoryImpl) InvalidateAll(ctx context.Context, userID string, purpose domain.TokenPurpose, usedAt time.Time) error {
+	return r.db.WithContext(ctx).
+		Model(&domain.UserToken{}).
+		Where("user_id = ? AND purpose = ? AND used_at IS NULL", userID, purpose).
+		UpdateColumn("used_at", usedAt).Error
+}
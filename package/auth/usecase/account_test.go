@@ -0,0 +1,281 @@
+package usecase
+
+import (
+	"context"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	authDomain "workflow-approval/package/auth/domain"
+	authPorts "workflow-approval/package/auth/ports"
+	authRepo "workflow-approval/package/auth/repository"
+	"workflow-approval/utils"
+	"workflow-approval/utils/mailer"
+)
+
+// MockUserTokenRepository implements UserTokenRepository for testing
+type MockUserTokenRepository struct {
+	tokens map[string]*authDomain.UserToken
+}
+
+func NewMockUserTokenRepository() *MockUserTokenRepository {
+	return &MockUserTokenRepository{
+		tokens: make(map[string]*authDomain.UserToken),
+	}
+}
+
+func (m *MockUserTokenRepository) Create(ctx context.Context, token *authDomain.UserToken) error {
+	m.tokens[token.ID] = token
+	return nil
+}
+
+func (m *MockUserTokenRepository) GetByHash(ctx context.Context, tokenHash string) (*authDomain.UserToken, error) {
+	for _, t := range m.tokens {
+		if t.TokenHash == tokenHash {
+			return t, nil
+		}
+	}
+	return nil, authRepo.ErrUserTokenNotFound
+}
+
+func (m *MockUserTokenRepository) GetLatest(ctx context.Context, userID string, purpose authDomain.TokenPurpose) (*authDomain.UserToken, error) {
+	var latest *authDomain.UserToken
+	for _, t := range m.tokens {
+		if t.UserID == userID && t.Purpose == purpose && (latest == nil || t.CreatedAt.After(latest.CreatedAt)) {
+			latest = t
+		}
+	}
+	return latest, nil
+}
+
+func (m *MockUserTokenRepository) MarkUsed(ctx context.Context, id string, usedAt time.Time) error {
+	t, ok := m.tokens[id]
+	if !ok || t.UsedAt != nil {
+		return authRepo.ErrUserTokenUsed
+	}
+	t.UsedAt = &usedAt
+	return nil
+}
+
+func (m *MockUserTokenRepository) InvalidateAll(ctx context.Context, userID string, purpose authDomain.TokenPurpose, usedAt time.Time) error {
+	for _, t := range m.tokens {
+		if t.UserID == userID && t.Purpose == purpose && t.UsedAt == nil {
+			t.UsedAt = &usedAt
+		}
+	}
+	return nil
+}
+
+// MockMailer records sent messages
+type MockMailer struct {
+	sent []mailer.Message
+}
+
+func (m *MockMailer) Send(ctx context.Context, msg mailer.Message) error {
+	m.sent = append(m.sent, msg)
+	return nil
+}
+
+// lastToken extracts the token from the link in the most recent message
+func (m *MockMailer) lastToken(t *testing.T) string {
+	t.Helper()
+	if len(m.sent) == 0 {
+		t.Fatal("Expected an email to be sent")
+	}
+	body := m.sent[len(m.sent)-1].Body
+	start := strings.Index(body, "?token=")
+	if start < 0 {
+		t.Fatalf("No link in email body: %s", body)
+	}
+	raw := strings.Fields(body[start+len("?token="):])[0]
+	token, err := url.QueryUnescape(raw)
+	if err != nil {
+		t.Fatalf("Invalid token in link: %v", err)
+	}
+	return token
+}
+
+// Interface compliance
+var (
+	_ authPorts.UserTokenRepository = (*MockUserTokenRepository)(nil)
+	_ mailer.Mailer                 = (*MockMailer)(nil)
+)
+
+// testAccountPolicy has no resend cooldown so tests can issue links back to back
+func testAccountPolicy() authDomain.AccountPolicy {
+	policy := authDomain.DefaultAccountPolicy()
+	policy.ResendCooldown = 0
+	return policy
+}
+
+func TestPasswordReset(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Reset link sets a new password once", func(t *testing.T) {
+		repo := NewMockAuthRepository()
+		attempts := NewMockLoginAttemptRepository()
+		audit := NewMockAuthAuditLogRepository()
+		mail := &MockMailer{}
+		user := createTestLocalUser(t, repo, "forgot@example.com", "old-password")
+		user.MustChangePassword = true
+		service := NewAccountService(repo, NewMockUserTokenRepository(), attempts, audit, mail, testAccountPolicy())
+
+		if err := service.RequestPasswordReset(ctx, "forgot@example.com", testClient); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		token := mail.lastToken(t)
+		if mail.sent[0].To != user.Email {
+			t.Errorf("Expected email to %s, got %s", user.Email, mail.sent[0].To)
+		}
+
+		if err := service.ResetPassword(ctx, token, "short", testClient); err != ErrWeakPassword {
+			t.Errorf("Expected ErrWeakPassword, got %v", err)
+		}
+		if err := service.ResetPassword(ctx, token, "new-password", testClient); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if err := service.ResetPassword(ctx, token, "another-password", testClient); err != ErrInvalidResetToken {
+			t.Errorf("Expected ErrInvalidResetToken on reuse, got %v", err)
+		}
+		if user.MustChangePassword || !user.IsEmailVerified() || user.PasswordChangedAt == nil {
+			t.Error("Expected forced change cleared, email verified and change time recorded")
+		}
+		if audit.count(authDomain.EventPasswordReset) != 1 {
+			t.Error("Expected PASSWORD_RESET event")
+		}
+
+		authService := NewAuthService(repo, attempts, audit, NewMockTwoFactorChallengeRepository(), testPolicy(), authDomain.DefaultTwoFactorPolicy(), nil)
+		if _, _, err := authService.Login(ctx, "forgot@example.com", "new-password", testClient); err != nil {
+			t.Errorf("Expected login with the new password, got %v", err)
+		}
+	})
+
+	t.Run("Reset lifts an account lockout", func(t *testing.T) {
+		repo := NewMockAuthRepository()
+		attempts := NewMockLoginAttemptRepository()
+		mail := &MockMailer{}
+		createTestLocalUser(t, repo, "locked-out@example.com", "old-password")
+		authService := NewAuthService(repo, attempts, NewMockAuthAuditLogRepository(), NewMockTwoFactorChallengeRepository(), testPolicy(), authDomain.DefaultTwoFactorPolicy(), nil)
+		service := NewAccountService(repo, NewMockUserTokenRepository(), attempts, NewMockAuthAuditLogRepository(), mail, testAccountPolicy())
+
+		for i := 0; i < 3; i++ {
+			authService.Login(ctx, "locked-out@example.com", "wrong", testClient)
+		}
+		service.RequestPasswordReset(ctx, "locked-out@example.com", testClient)
+		if err := service.ResetPassword(ctx, mail.lastToken(t), "new-password", testClient); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if _, _, err := authService.Login(ctx, "locked-out@example.com", "new-password", testClient); err != nil {
+			t.Errorf("Expected login after reset, got %v", err)
+		}
+	})
+
+	t.Run("Unknown and SSO accounts get no email", func(t *testing.T) {
+		repo := NewMockAuthRepository()
+		mail := &MockMailer{}
+		sso := createTestLocalUser(t, repo, "sso@example.com", "unused-password")
+		sso.Password = ""
+		service := NewAccountService(repo, NewMockUserTokenRepository(), NewMockLoginAttemptRepository(), NewMockAuthAuditLogRepository(), mail, testAccountPolicy())
+
+		for _, email := range []string{"nobody@example.com", "sso@example.com"} {
+			if err := service.RequestPasswordReset(ctx, email, testClient); err != nil {
+				t.Errorf("Expected no error for %s, got %v", email, err)
+			}
+		}
+		if len(mail.sent) != 0 {
+			t.Errorf("Expected no emails, got %d", len(mail.sent))
+		}
+	})
+
+	t.Run("Only the latest link works and links expire", func(t *testing.T) {
+		repo := NewMockAuthRepository()
+		tokens := NewMockUserTokenRepository()
+		mail := &MockMailer{}
+		createTestLocalUser(t, repo, "twice@example.com", "old-password")
+		service := NewAccountService(repo, tokens, NewMockLoginAttemptRepository(), NewMockAuthAuditLogRepository(), mail, testAccountPolicy())
+
+		service.RequestPasswordReset(ctx, "twice@example.com", testClient)
+		first := mail.lastToken(t)
+		service.RequestPasswordReset(ctx, "twice@example.com", testClient)
+		second := mail.lastToken(t)
+
+		if err := service.ResetPassword(ctx, first, "new-password", testClient); err != ErrInvalidResetToken {
+			t.Errorf("Expected superseded link to be rejected, got %v", err)
+		}
+
+		stored, _ := tokens.GetByHash(ctx, authDomain.HashUserToken(second))
+		stored.ExpiresAt = utils.TimeNowUTC().Add(-time.Second)
+		if err := service.ResetPassword(ctx, second, "new-password", testClient); err != ErrInvalidResetToken {
+			t.Errorf("Expected expired link to be rejected, got %v", err)
+		}
+	})
+
+	t.Run("Repeated requests within the cooldown send one email", func(t *testing.T) {
+		repo := NewMockAuthRepository()
+		mail := &MockMailer{}
+		createTestLocalUser(t, repo, "impatient@example.com", "old-password")
+		service := NewAccountService(repo, NewMockUserTokenRepository(), NewMockLoginAttemptRepository(), NewMockAuthAuditLogRepository(), mail, authDomain.DefaultAccountPolicy())
+
+		for i := 0; i < 3; i++ {
+			if err := service.RequestPasswordReset(ctx, "impatient@example.com", testClient); err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+		}
+		if len(mail.sent) != 1 {
+			t.Errorf("Expected 1 email, got %d", len(mail.sent))
+		}
+	})
+}
+
+func TestChangePassword(t *testing.T) {
+	ctx := context.Background()
+	repo := NewMockAuthRepository()
+	audit := NewMockAuthAuditLogRepository()
+	user := createTestLocalUser(t, repo, "new-hire@example.com", "temporary-pw")
+	user.MustChangePassword = true
+	service := NewAccountService(repo, NewMockUserTokenRepository(), NewMockLoginAttemptRepository(), audit, &MockMailer{}, testAccountPolicy())
+
+	if _, err := service.ChangePassword(ctx, user.ID, "wrong", "new-password", testClient); err != ErrCurrentPasswordInvalid {
+		t.Errorf("Expected ErrCurrentPasswordInvalid, got %v", err)
+	}
+	if _, err := service.ChangePassword(ctx, user.ID, "temporary-pw", "temporary-pw", testClient); err != ErrPasswordUnchanged {
+		t.Errorf("Expected ErrPasswordUnchanged, got %v", err)
+	}
+	if _, err := service.ChangePassword(ctx, user.ID, "temporary-pw", "new-password", testClient); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if user.MustChangePassword {
+		t.Error("Expected forced password change to be cleared")
+	}
+	if audit.count(authDomain.EventPasswordChanged) != 1 {
+		t.Error("Expected PASSWORD_CHANGED event")
+	}
+}
+
+func TestEmailVerification(t *testing.T) {
+	ctx := context.Background()
+	repo := NewMockAuthRepository()
+	mail := &MockMailer{}
+	user := createTestLocalUser(t, repo, "verify@example.com", "some-password")
+	service := NewAccountService(repo, NewMockUserTokenRepository(), NewMockLoginAttemptRepository(), NewMockAuthAuditLogRepository(), mail, testAccountPolicy())
+
+	// A reset link cannot be used to verify the email
+	service.RequestPasswordReset(ctx, "verify@example.com", testClient)
+	if _, err := service.VerifyEmail(ctx, mail.lastToken(t), testClient); err != ErrInvalidVerifyToken {
+		t.Errorf("Expected ErrInvalidVerifyToken, got %v", err)
+	}
+
+	if err := service.SendEmailVerification(ctx, user); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if _, err := service.VerifyEmail(ctx, mail.lastToken(t), testClient); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if !user.IsEmailVerified() {
+		t.Error("Expected email to be verified")
+	}
+	if err := service.ResendEmailVerification(ctx, user.ID); err != ErrEmailAlreadyVerified {
+		t.Errorf("Expected ErrEmailAlreadyVerified, got %v", err)
+	}
+}
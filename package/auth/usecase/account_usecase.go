@@ -0,0 +1,300 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"log"
+	"strings"
+	"time"
+
+	"golang.org/x/crypto/bcrypt"
+
+	authDomain "workflow-approval/package/auth/domain"
+	"workflow-approval/package/auth/ports"
+	"workflow-approval/package/auth/repository"
+	userDomain "workflow-approval/package/user/domain"
+	"workflow-approval/utils"
+	"workflow-approval/utils/mailer"
+	"workflow-approval/utils/oidc"
+	"workflow-approval/utils/tenancy"
+)
+
+var (
+	ErrWeakPassword           = errors.New("password must be at least 8 characters")
+	ErrPasswordUnchanged      = errors.New("new password must differ from the current password")
+	ErrCurrentPasswordInvalid = errors.New("current password is incorrect")
+	ErrLocalPasswordNotSet    = errors.New("account signs in through single sign-on and has no password")
+	ErrInvalidResetToken      = errors.New("password reset link is invalid or has expired")
+	ErrInvalidVerifyToken     = errors.New("email verification link is invalid or has expired")
+	ErrEmailAlreadyVerified   = errors.New("email address is already verified")
+	ErrEmailRecentlySent      = errors.New("an email was sent recently, please wait before requesting another")
+)
+
+// AccountServiceImpl implements AccountService interface
+type AccountServiceImpl struct {
+	authRepo    ports.AuthRepository
+	tokenRepo   ports.UserTokenRepository
+	attemptRepo ports.LoginAttemptRepository
+	auditRepo   ports.AuthAuditLogRepository
+	mailer      mailer.Mailer
+	policy      authDomain.AccountPolicy
+}
+
+// NewAccountService creates a new AccountServiceImpl instance
+func NewAccountService(authRepo ports.AuthRepository, tokenRepo ports.UserTokenRepository, attemptRepo ports.LoginAttemptRepository, auditRepo ports.AuthAuditLogRepository, mail mailer.Mailer, policy authDomain.AccountPolicy) ports.AccountService {
+	return &AccountServiceImpl{
+		authRepo:    authRepo,
+		tokenRepo:   tokenRepo,
+		attemptRepo: attemptRepo,
+		auditRepo:   auditRepo,
+		mailer:      mail,
+		policy:      policy,
+	}
+}
+
+// RequestPasswordReset emails a single-use reset link to a local account.
+// It reports success for unknown emails, SSO-only accounts and repeated
+// requests alike, so the response never reveals whether an account exists.
+func (s *AccountServiceImpl) RequestPasswordReset(ctx context.Context, email string, client authDomain.ClientInfo) error {
+	email = strings.TrimSpace(email)
+	if email == "" {
+		return nil
+	}
+
+	user, err := s.authRepo.GetByEmail(tenancy.WithAllTenants(ctx), email)
+	if err != nil {
+		if errors.Is(err, repository.ErrUserNotFound) {
+			s.audit(accountTenant(ctx, nil), authDomain.EventPasswordResetRequested, nil, email, client, "unknown email")
+			return nil
+		}
+		return err
+	}
+	ctx = accountTenant(ctx, user)
+	if !user.IsLocalLoginAllowed() || !user.IsActive {
+		s.audit(ctx, authDomain.EventPasswordResetRequested, &user.ID, user.Email, client, "no local password or deactivated")
+		return nil
+	}
+
+	token, err := s.issueToken(ctx, user, authDomain.TokenPurposePasswordReset)
+	if err != nil {
+		if errors.Is(err, ErrEmailRecentlySent) {
+			return nil
+		}
+		return err
+	}
+	s.audit(ctx, authDomain.EventPasswordResetRequested, &user.ID, user.Email, client, "")
+
+	// A delivery failure must not be observable by the caller either
+	if err := s.mailer.Send(ctx, s.policy.PasswordResetMessage(user, token)); err != nil {
+		log.Printf("Warning: failed to send password reset email to %s: %v", user.Email, err)
+	}
+	return nil
+}
+
+// ResetPassword sets a new password with a reset token. The token is consumed,
+// other outstanding reset links are revoked and a login lockout is lifted;
+// since the link proves control of the mailbox, the email counts as verified.
+func (s *AccountServiceImpl) ResetPassword(ctx context.Context, token, newPassword string, client authDomain.ClientInfo) error {
+	if len(newPassword) < authDomain.MinPasswordLength {
+		return ErrWeakPassword
+	}
+
+	now := utils.TimeNowUTC()
+	userToken, err := s.redeemToken(ctx, token, authDomain.TokenPurposePasswordReset, ErrInvalidResetToken, now)
+	if err != nil {
+		return err
+	}
+	ctx = tenancy.WithTenant(ctx, userToken.TenantID)
+
+	user, err := s.getUser(ctx, userToken.UserID)
+	if err != nil {
+		return err
+	}
+	if user.EmailVerifiedAt == nil {
+		user.EmailVerifiedAt = &now
+	}
+	if err := s.setPassword(ctx, user, newPassword, now); err != nil {
+		return err
+	}
+
+	if err := s.attemptRepo.Reset(ctx, authDomain.AccountAttemptKey(user.Email)); err != nil {
+		return err
+	}
+	s.audit(ctx, authDomain.EventPasswordReset, &user.ID, user.Email, client, "")
+	return nil
+}
+
+// ChangePassword replaces the password of a signed-in user after checking the
+// current one; it also completes the forced change of admin-created accounts
+func (s *AccountServiceImpl) ChangePassword(ctx context.Context, userID, currentPassword, newPassword string, client authDomain.ClientInfo) (*userDomain.User, error) {
+	user, err := s.getUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !user.IsLocalLoginAllowed() {
+		return nil, ErrLocalPasswordNotSet
+	}
+	if err := bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(currentPassword)); err != nil {
+		return nil, ErrCurrentPasswordInvalid
+	}
+	if len(newPassword) < authDomain.MinPasswordLength {
+		return nil, ErrWeakPassword
+	}
+	if newPassword == currentPassword {
+		return nil, ErrPasswordUnchanged
+	}
+
+	if err := s.setPassword(ctx, user, newPassword, utils.TimeNowUTC()); err != nil {
+		return nil, err
+	}
+	s.audit(ctx, authDomain.EventPasswordChanged, &user.ID, user.Email, client, "")
+	return user, nil
+}
+
+// SendEmailVerification emails a verification link unless the address is already verified
+func (s *AccountServiceImpl) SendEmailVerification(ctx context.Context, user *userDomain.User) error {
+	if user.IsEmailVerified() {
+		return nil
+	}
+
+	token, err := s.issueToken(ctx, user, authDomain.TokenPurposeEmailVerification)
+	if err != nil {
+		return err
+	}
+	return s.mailer.Send(ctx, s.policy.EmailVerificationMessage(user, token))
+}
+
+// ResendEmailVerification sends a new verification link to the signed-in user
+func (s *AccountServiceImpl) ResendEmailVerification(ctx context.Context, userID string) error {
+	user, err := s.getUser(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if user.IsEmailVerified() {
+		return ErrEmailAlreadyVerified
+	}
+	return s.SendEmailVerification(ctx, user)
+}
+
+// VerifyEmail marks the email address of the token's user as verified
+func (s *AccountServiceImpl) VerifyEmail(ctx context.Context, token string, client authDomain.ClientInfo) (*userDomain.User, error) {
+	now := utils.TimeNowUTC()
+	userToken, err := s.redeemToken(ctx, token, authDomain.TokenPurposeEmailVerification, ErrInvalidVerifyToken, now)
+	if err != nil {
+		return nil, err
+	}
+	ctx = tenancy.WithTenant(ctx, userToken.TenantID)
+
+	user, err := s.getUser(ctx, userToken.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if user.EmailVerifiedAt == nil {
+		user.EmailVerifiedAt = &now
+		user.UpdatedAt = now
+		if err := s.authRepo.Update(ctx, user); err != nil {
+			return nil, err
+		}
+		s.audit(ctx, authDomain.EventEmailVerified, &user.ID, user.Email, client, "")
+	}
+	return user, nil
+}
+
+// issueToken revokes the user's outstanding tokens of the purpose and stores
+// the hash of a new one; it refuses with a *ThrottleError within the resend cooldown
+func (s *AccountServiceImpl) issueToken(ctx context.Context, user *userDomain.User, purpose authDomain.TokenPurpose) (string, error) {
+	now := utils.TimeNowUTC()
+
+	latest, err := s.tokenRepo.GetLatest(ctx, user.ID, purpose)
+	if err != nil {
+		return "", err
+	}
+	if latest != nil && s.policy.ResendCooldown > 0 {
+		if wait := latest.CreatedAt.Add(s.policy.ResendCooldown).Sub(now); wait > 0 {
+			return "", &ThrottleError{Reason: ErrEmailRecentlySent, RetryAfter: wait}
+		}
+	}
+
+	if err := s.tokenRepo.InvalidateAll(ctx, user.ID, purpose, now); err != nil {
+		return "", err
+	}
+
+	token, err := oidc.RandomString(32)
+	if err != nil {
+		return "", err
+	}
+	userToken := authDomain.NewUserToken(user.ID, purpose, authDomain.HashUserToken(token), s.policy.TTL(purpose))
+	userToken.TenantID = user.TenantID
+	if err := s.tokenRepo.Create(ctx, userToken); err != nil {
+		return "", err
+	}
+	return token, nil
+}
+
+// redeemToken consumes a usable token of the purpose; unknown, expired, used or
+// mismatched tokens are all reported as invalid so they cannot be told apart.
+// Tokens arrive on public routes, so they are looked up across tenants; the
+// caller continues in the token's tenant.
+func (s *AccountServiceImpl) redeemToken(ctx context.Context, token string, purpose authDomain.TokenPurpose, invalid error, now time.Time) (*authDomain.UserToken, error) {
+	if token == "" {
+		return nil, invalid
+	}
+	ctx = tenancy.WithAllTenants(ctx)
+
+	userToken, err := s.tokenRepo.GetByHash(ctx, authDomain.HashUserToken(token))
+	if err != nil {
+		if errors.Is(err, repository.ErrUserTokenNotFound) {
+			return nil, invalid
+		}
+		return nil, err
+	}
+	if userToken.Purpose != purpose || !userToken.IsUsable(now) {
+		return nil, invalid
+	}
+
+	// A concurrent redemption of the same token loses here
+	if err := s.tokenRepo.MarkUsed(ctx, userToken.ID, now); err != nil {
+		if errors.Is(err, repository.ErrUserTokenUsed) {
+			return nil, invalid
+		}
+		return nil, err
+	}
+	return userToken, nil
+}
+
+// setPassword stores a new password hash, clears a pending forced change and revokes reset links
+func (s *AccountServiceImpl) setPassword(ctx context.Context, user *userDomain.User, password string, now time.Time) error {
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return err
+	}
+
+	user.Password = string(hash)
+	user.MustChangePassword = false
+	user.PasswordChangedAt = &now
+	user.UpdatedAt = now
+	if err := s.authRepo.Update(ctx, user); err != nil {
+		return err
+	}
+	return s.tokenRepo.InvalidateAll(ctx, user.ID, authDomain.TokenPurposePasswordReset, now)
+}
+
+// getUser loads a user, mapping not-found to ErrUserNotFound
+func (s *AccountServiceImpl) getUser(ctx context.Context, userID string) (*userDomain.User, error) {
+	user, err := s.authRepo.GetByID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, repository.ErrUserNotFound) {
+			return nil, ErrUserNotFound
+		}
+		return nil, err
+	}
+	return user, nil
+}
+
+// audit records a security event; failures are logged but never block the operation
+func (s *AccountServiceImpl) audit(ctx context.Context, event authDomain.AuthEvent, userID *string, email string, client authDomain.ClientInfo, detail string) {
+	entry := authDomain.NewAuthAuditLog(event, userID, email, client, detail)
+	if err := s.auditRepo.Create(ctx, entry); err != nil {
+		log.Printf("Warning: failed to write auth audit log (%s): %v", event, err)
+	}
+}
@@ -6,52 +6,111 @@ import (
 
 	"golang.org/x/crypto/bcrypt"
 
+	authDomain "workflow-approval/package/auth/domain"
 	"workflow-approval/package/auth/ports"
+	"workflow-approval/package/auth/repository"
 	"workflow-approval/package/user/domain"
+	"workflow-approval/utils"
 	"workflow-approval/utils/jwthelper"
+	"workflow-approval/utils/tenancy"
 )
 
 var (
 	ErrInvalidLogin = errors.New("invalid email or password")
 	ErrInvalidToken = errors.New("invalid token")
 	ErrUserNotFound = errors.New("user not found")
+
+	ErrAccountDeactivated = errors.New("account has been deactivated")
 )
 
 // AuthServiceImpl implements AuthService interface
 type AuthServiceImpl struct {
-	authRepo  ports.AuthRepository
-	jwtHelper *jwthelper.JWTHelper
+	authRepo      ports.AuthRepository
+	attemptRepo   ports.LoginAttemptRepository
+	auditRepo     ports.AuthAuditLogRepository
+	challengeRepo ports.TwoFactorChallengeRepository
+	policy        authDomain.LoginProtectionPolicy
+	twoFactor     authDomain.TwoFactorPolicy
+	jwtHelper     *jwthelper.JWTHelper
 }
 
 // NewAuthService creates a new AuthServiceImpl instance
-func NewAuthService(authRepo ports.AuthRepository, jwtHelper *jwthelper.JWTHelper) ports.AuthService {
+func NewAuthService(authRepo ports.AuthRepository, attemptRepo ports.LoginAttemptRepository, auditRepo ports.AuthAuditLogRepository, challengeRepo ports.TwoFactorChallengeRepository, policy authDomain.LoginProtectionPolicy, twoFactor authDomain.TwoFactorPolicy, jwtHelper *jwthelper.JWTHelper) ports.AuthService {
 	return &AuthServiceImpl{
-		authRepo:  authRepo,
-		jwtHelper: jwtHelper,
+		authRepo:      authRepo,
+		attemptRepo:   attemptRepo,
+		auditRepo:     auditRepo,
+		challengeRepo: challengeRepo,
+		policy:        policy,
+		twoFactor:     twoFactor,
+		jwtHelper:     jwtHelper,
 	}
 }
 
-// Login authenticates a user and returns a JWT token
-func (s *AuthServiceImpl) Login(ctx context.Context, email, password string) (*domain.User, string, error) {
+// Login authenticates a user and returns a JWT token.
+// Failed attempts are tracked per account and per client IP; callers get a
+// *ThrottleError while a progressive delay or lockout is in effect, and a
+// *TwoFactorChallenge when the account must also pass a second factor.
+func (s *AuthServiceImpl) Login(ctx context.Context, email, password string, client authDomain.ClientInfo) (*domain.User, string, error) {
 	// Validation
 	if email == "" || password == "" {
 		return nil, "", ErrInvalidLogin
 	}
 
+	now := utils.TimeNowUTC()
+	accountKey := authDomain.AccountAttemptKey(email)
+	ipKey := authDomain.IPAttemptKey(client.IP)
+
+	// The account's tenant is not known yet: counters and the email are looked up across tenants
+	anyTenant := tenancy.WithAllTenants(ctx)
+
+	// Reject early while the account or IP is locked or must wait
+	if err := s.checkThrottle(anyTenant, ipKey, ErrIPBlocked, now); err != nil {
+		s.audit(accountTenant(ctx, nil), authDomain.EventLoginBlocked, nil, email, client, err.Error())
+		return nil, "", err
+	}
+	if err := s.checkThrottle(anyTenant, accountKey, ErrAccountLocked, now); err != nil {
+		s.audit(accountTenant(ctx, nil), authDomain.EventLoginBlocked, nil, email, client, err.Error())
+		return nil, "", err
+	}
+
 	// Get user by email
-	user, err := s.authRepo.GetByEmail(ctx, email)
+	user, err := s.authRepo.GetByEmail(anyTenant, email)
 	if err != nil {
-		if errors.Is(err, ErrUserNotFound) {
-			return nil, "", ErrInvalidLogin
+		if errors.Is(err, repository.ErrUserNotFound) {
+			return nil, "", s.recordFailure(accountTenant(ctx, nil), nil, email, client, "unknown email", now)
 		}
 		return nil, "", err
 	}
+	ctx = accountTenant(ctx, user)
+
+	// Accounts provisioned through OIDC have no local password
+	if !user.IsLocalLoginAllowed() {
+		return nil, "", s.recordFailure(ctx, &user.ID, email, client, "no local password", now)
+	}
 
 	// Verify password
 	if err := bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(password)); err != nil {
-		return nil, "", ErrInvalidLogin
+		return nil, "", s.recordFailure(ctx, &user.ID, email, client, "wrong password", now)
+	}
+
+	// Only reported after the password matched, so it reveals nothing to guessers
+	if !user.IsActive {
+		s.audit(ctx, authDomain.EventLoginBlocked, &user.ID, user.Email, client, "account deactivated")
+		return nil, "", ErrAccountDeactivated
+	}
+
+	// The account's failures are kept until the second factor has been passed too
+	if s.twoFactor.RequiresTwoFactor(user) {
+		return nil, "", s.beginTwoFactorChallenge(ctx, user, methodPassword)
 	}
 
+	// Success clears the account's failures; IP failures age out on their own
+	if err := s.attemptRepo.Reset(ctx, accountKey); err != nil {
+		return nil, "", err
+	}
+	s.audit(ctx, authDomain.EventLoginSuccess, &user.ID, user.Email, client, "password")
+
 	return user, "", nil
 }
 
@@ -65,6 +124,9 @@ func (s *AuthServiceImpl) Refresh(ctx context.Context, userID string) (*domain.U
 		}
 		return nil, "", err
 	}
+	if !user.IsActive {
+		return nil, "", ErrAccountDeactivated
+	}
 
 	// Generate new JWT token
 	token, err := s.jwtHelper.GenerateJWT(user)
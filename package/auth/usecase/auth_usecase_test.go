@@ -0,0 +1,406 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"golang.org/x/crypto/bcrypt"
+
+	authDomain "workflow-approval/package/auth/domain"
+	authPorts "workflow-approval/package/auth/ports"
+	authRepo "workflow-approval/package/auth/repository"
+	userDomain "workflow-approval/package/user/domain"
+	"workflow-approval/utils"
+	"workflow-approval/utils/tenancy"
+)
+
+// MockAuthRepository implements AuthRepository for testing
+type MockAuthRepository struct {
+	users map[string]*userDomain.User
+}
+
+func NewMockAuthRepository() *MockAuthRepository {
+	return &MockAuthRepository{
+		users: make(map[string]*userDomain.User),
+	}
+}
+
+func (m *MockAuthRepository) GetByEmail(ctx context.Context, email string) (*userDomain.User, error) {
+	for _, u := range m.users {
+		if u.Email == email {
+			return u, nil
+		}
+	}
+	return nil, authRepo.ErrUserNotFound
+}
+
+func (m *MockAuthRepository) GetByID(ctx context.Context, id string) (*userDomain.User, error) {
+	if u, ok := m.users[id]; ok {
+		return u, nil
+	}
+	return nil, authRepo.ErrUserNotFound
+}
+
+func (m *MockAuthRepository) GetByOIDCSubject(ctx context.Context, subject string) (*userDomain.User, error) {
+	for _, u := range m.users {
+		if u.OIDCSubject != nil && *u.OIDCSubject == subject {
+			return u, nil
+		}
+	}
+	return nil, authRepo.ErrUserNotFound
+}
+
+func (m *MockAuthRepository) Create(ctx context.Context, user *userDomain.User) error {
+	m.users[user.ID] = user
+	return nil
+}
+
+func (m *MockAuthRepository) Update(ctx context.Context, user *userDomain.User) error {
+	m.users[user.ID] = user
+	return nil
+}
+
+func (m *MockAuthRepository) SaveActorMemberships(ctx context.Context, user *userDomain.User) error {
+	m.users[user.ID] = user
+	return nil
+}
+
+// MockLoginAttemptRepository implements LoginAttemptRepository for testing
+type MockLoginAttemptRepository struct {
+	attempts map[string]*authDomain.LoginAttempt
+}
+
+func NewMockLoginAttemptRepository() *MockLoginAttemptRepository {
+	return &MockLoginAttemptRepository{
+		attempts: make(map[string]*authDomain.LoginAttempt),
+	}
+}
+
+func (m *MockLoginAttemptRepository) Get(ctx context.Context, key string) (*authDomain.LoginAttempt, error) {
+	return m.attempts[key], nil
+}
+
+func (m *MockLoginAttemptRepository) RecordFailure(ctx context.Context, key string, now time.Time, window time.Duration) (*authDomain.LoginAttempt, error) {
+	attempt, ok := m.attempts[key]
+	if !ok || attempt.LastFailureAt.Before(now.Add(-window)) {
+		attempt = &authDomain.LoginAttempt{Key: key}
+		m.attempts[key] = attempt
+	}
+	attempt.Failures++
+	attempt.LastFailureAt = now
+	return attempt, nil
+}
+
+func (m *MockLoginAttemptRepository) Lock(ctx context.Context, key string, until time.Time) error {
+	if attempt, ok := m.attempts[key]; ok {
+		attempt.LockedUntil = &until
+	}
+	return nil
+}
+
+func (m *MockLoginAttemptRepository) Reset(ctx context.Context, key string) error {
+	delete(m.attempts, key)
+	return nil
+}
+
+// MockAuthAuditLogRepository implements AuthAuditLogRepository for testing
+type MockAuthAuditLogRepository struct {
+	entries []*authDomain.AuthAuditLog
+}
+
+func NewMockAuthAuditLogRepository() *MockAuthAuditLogRepository {
+	return &MockAuthAuditLogRepository{}
+}
+
+// Create stamps the context's tenant like the tenancy plugin does
+func (m *MockAuthAuditLogRepository) Create(ctx context.Context, entry *authDomain.AuthAuditLog) error {
+	if entry.TenantID == "" {
+		entry.TenantID = tenancy.TenantID(ctx)
+	}
+	m.entries = append(m.entries, entry)
+	return nil
+}
+
+func (m *MockAuthAuditLogRepository) List(ctx context.Context, filter authDomain.AuthAuditFilter, page, limit int) ([]*authDomain.AuthAuditLog, int64, error) {
+	return m.entries, int64(len(m.entries)), nil
+}
+
+func (m *MockAuthAuditLogRepository) count(event authDomain.AuthEvent) int {
+	n := 0
+	for _, e := range m.entries {
+		if e.Event == event {
+			n++
+		}
+	}
+	return n
+}
+
+// MockTwoFactorChallengeRepository implements TwoFactorChallengeRepository for testing
+type MockTwoFactorChallengeRepository struct {
+	challenges map[string]*authDomain.PendingTwoFactor
+}
+
+func NewMockTwoFactorChallengeRepository() *MockTwoFactorChallengeRepository {
+	return &MockTwoFactorChallengeRepository{
+		challenges: make(map[string]*authDomain.PendingTwoFactor),
+	}
+}
+
+func (m *MockTwoFactorChallengeRepository) Create(ctx context.Context, challenge *authDomain.PendingTwoFactor) error {
+	m.challenges[challenge.ID] = challenge
+	return nil
+}
+
+func (m *MockTwoFactorChallengeRepository) GetByHash(ctx context.Context, tokenHash string) (*authDomain.PendingTwoFactor, error) {
+	for _, c := range m.challenges {
+		if c.TokenHash == tokenHash {
+			return c, nil
+		}
+	}
+	return nil, authRepo.ErrTwoFactorChallengeNotFound
+}
+
+func (m *MockTwoFactorChallengeRepository) AddFailure(ctx context.Context, id string) error {
+	if c, ok := m.challenges[id]; ok {
+		c.Failures++
+	}
+	return nil
+}
+
+func (m *MockTwoFactorChallengeRepository) Delete(ctx context.Context, id string) error {
+	if _, ok := m.challenges[id]; !ok {
+		return authRepo.ErrTwoFactorChallengeNotFound
+	}
+	delete(m.challenges, id)
+	return nil
+}
+
+func (m *MockTwoFactorChallengeRepository) DeleteExpired(ctx context.Context, now time.Time) error {
+	for id, c := range m.challenges {
+		if c.ExpiresAt.Before(now) {
+			delete(m.challenges, id)
+		}
+	}
+	return nil
+}
+
+// Interface compliance
+var (
+	_ authPorts.AuthRepository               = (*MockAuthRepository)(nil)
+	_ authPorts.LoginAttemptRepository       = (*MockLoginAttemptRepository)(nil)
+	_ authPorts.AuthAuditLogRepository       = (*MockAuthAuditLogRepository)(nil)
+	_ authPorts.TwoFactorChallengeRepository = (*MockTwoFactorChallengeRepository)(nil)
+)
+
+var testClient = authDomain.ClientInfo{IP: "203.0.113.7", UserAgent: "go-test"}
+
+// testPolicy has no progressive delay so consecutive failures reach the lockout threshold
+func testPolicy() authDomain.LoginProtectionPolicy {
+	return authDomain.LoginProtectionPolicy{
+		MaxAccountFailures: 3,
+		MaxIPFailures:      5,
+		FailureWindow:      15 * time.Minute,
+		LockoutDuration:    15 * time.Minute,
+	}
+}
+
+func createTestLocalUser(t *testing.T, repo *MockAuthRepository, email, password string) *userDomain.User {
+	t.Helper()
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.MinCost)
+	if err != nil {
+		t.Fatalf("Failed to hash password: %v", err)
+	}
+	user := userDomain.NewUser(email, string(hash), "Test User", false, nil)
+	repo.Create(context.Background(), user)
+	return user
+}
+
+func TestLoginProtection(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Account is locked after repeated failures", func(t *testing.T) {
+		repo := NewMockAuthRepository()
+		attempts := NewMockLoginAttemptRepository()
+		audit := NewMockAuthAuditLogRepository()
+		createTestLocalUser(t, repo, "user@example.com", "correct-password")
+		service := NewAuthService(repo, attempts, audit, NewMockTwoFactorChallengeRepository(), testPolicy(), authDomain.DefaultTwoFactorPolicy(), nil)
+
+		for i := 0; i < 3; i++ {
+			if _, _, err := service.Login(ctx, "user@example.com", "wrong", testClient); err != ErrInvalidLogin {
+				t.Fatalf("Attempt %d: expected ErrInvalidLogin, got %v", i+1, err)
+			}
+		}
+
+		// Even the correct password is refused while locked
+		_, _, err := service.Login(ctx, "user@example.com", "correct-password", testClient)
+		var throttleErr *ThrottleError
+		if !errors.As(err, &throttleErr) || !errors.Is(err, ErrAccountLocked) {
+			t.Fatalf("Expected ErrAccountLocked, got %v", err)
+		}
+		if throttleErr.RetryAfter <= 0 {
+			t.Errorf("Expected positive retry-after, got %s", throttleErr.RetryAfter)
+		}
+
+		if audit.count(authDomain.EventLoginFailed) != 3 {
+			t.Errorf("Expected 3 LOGIN_FAILED events, got %d", audit.count(authDomain.EventLoginFailed))
+		}
+		if audit.count(authDomain.EventAccountLocked) != 1 {
+			t.Errorf("Expected 1 ACCOUNT_LOCKED event, got %d", audit.count(authDomain.EventAccountLocked))
+		}
+		if audit.count(authDomain.EventLoginBlocked) != 1 {
+			t.Errorf("Expected 1 LOGIN_BLOCKED event, got %d", audit.count(authDomain.EventLoginBlocked))
+		}
+		if audit.entries[0].IPAddress != testClient.IP || audit.entries[0].UserAgent != testClient.UserAgent {
+			t.Errorf("Expected client info in audit log, got %s / %s", audit.entries[0].IPAddress, audit.entries[0].UserAgent)
+		}
+	})
+
+	t.Run("Admin unlock restores access", func(t *testing.T) {
+		repo := NewMockAuthRepository()
+		attempts := NewMockLoginAttemptRepository()
+		audit := NewMockAuthAuditLogRepository()
+		user := createTestLocalUser(t, repo, "locked@example.com", "correct-password")
+		service := NewAuthService(repo, attempts, audit, NewMockTwoFactorChallengeRepository(), testPolicy(), authDomain.DefaultTwoFactorPolicy(), nil)
+
+		if err := service.UnlockUser(ctx, user.ID, "admin-1", testClient); err != ErrAccountNotLocked {
+			t.Errorf("Expected ErrAccountNotLocked, got %v", err)
+		}
+
+		for i := 0; i < 3; i++ {
+			service.Login(ctx, "locked@example.com", "wrong", testClient)
+		}
+		if err := service.UnlockUser(ctx, user.ID, "admin-1", testClient); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if _, _, err := service.Login(ctx, "locked@example.com", "correct-password", testClient); err != nil {
+			t.Errorf("Expected login after unlock, got %v", err)
+		}
+		if audit.count(authDomain.EventAccountUnlocked) != 1 || audit.count(authDomain.EventLoginSuccess) != 1 {
+			t.Error("Expected ACCOUNT_UNLOCKED and LOGIN_SUCCESS events")
+		}
+	})
+
+	t.Run("Unknown emails are throttled like existing accounts", func(t *testing.T) {
+		repo := NewMockAuthRepository()
+		service := NewAuthService(repo, NewMockLoginAttemptRepository(), NewMockAuthAuditLogRepository(), NewMockTwoFactorChallengeRepository(), testPolicy(), authDomain.DefaultTwoFactorPolicy(), nil)
+
+		for i := 0; i < 3; i++ {
+			service.Login(ctx, "ghost@example.com", "wrong", testClient)
+		}
+		if _, _, err := service.Login(ctx, "ghost@example.com", "wrong", testClient); !errors.Is(err, ErrAccountLocked) {
+			t.Errorf("Expected ErrAccountLocked, got %v", err)
+		}
+	})
+
+	t.Run("IP is blocked across accounts", func(t *testing.T) {
+		repo := NewMockAuthRepository()
+		createTestLocalUser(t, repo, "victim@example.com", "correct-password")
+		service := NewAuthService(repo, NewMockLoginAttemptRepository(), NewMockAuthAuditLogRepository(), NewMockTwoFactorChallengeRepository(), testPolicy(), authDomain.DefaultTwoFactorPolicy(), nil)
+
+		emails := []string{"a@example.com", "b@example.com", "c@example.com", "d@example.com", "e@example.com"}
+		for _, email := range emails {
+			service.Login(ctx, email, "wrong", testClient)
+		}
+		if _, _, err := service.Login(ctx, "victim@example.com", "correct-password", testClient); !errors.Is(err, ErrIPBlocked) {
+			t.Errorf("Expected ErrIPBlocked, got %v", err)
+		}
+
+		otherClient := authDomain.ClientInfo{IP: "198.51.100.1"}
+		if _, _, err := service.Login(ctx, "victim@example.com", "correct-password", otherClient); err != nil {
+			t.Errorf("Expected login from another IP to succeed, got %v", err)
+		}
+	})
+
+	t.Run("Progressive delay between failures", func(t *testing.T) {
+		repo := NewMockAuthRepository()
+		createTestLocalUser(t, repo, "slow@example.com", "correct-password")
+		policy := testPolicy()
+		policy.BaseDelay = time.Minute
+		policy.MaxDelay = 10 * time.Minute
+		service := NewAuthService(repo, NewMockLoginAttemptRepository(), NewMockAuthAuditLogRepository(), NewMockTwoFactorChallengeRepository(), policy, authDomain.DefaultTwoFactorPolicy(), nil)
+
+		service.Login(ctx, "slow@example.com", "wrong", testClient)
+		if _, _, err := service.Login(ctx, "slow@example.com", "correct-password", testClient); !errors.Is(err, ErrTooManyAttempts) {
+			t.Errorf("Expected ErrTooManyAttempts, got %v", err)
+		}
+	})
+
+	t.Run("Delay doubles and is capped", func(t *testing.T) {
+		policy := authDomain.LoginProtectionPolicy{BaseDelay: time.Second, MaxDelay: 5 * time.Second}
+		expected := []time.Duration{0, time.Second, 2 * time.Second, 4 * time.Second, 5 * time.Second, 5 * time.Second}
+		for failures, want := range expected {
+			if got := policy.ProgressiveDelay(failures); got != want {
+				t.Errorf("ProgressiveDelay(%d) = %s, want %s", failures, got, want)
+			}
+		}
+	})
+}
+
+func TestLocalLoginRejectsOIDCUser(t *testing.T) {
+	ctx := context.Background()
+	repo := NewMockAuthRepository()
+	user := userDomain.NewOIDCUser("sso@example.com", "SSO User", "idp-user-8", false, nil)
+	repo.Create(ctx, user)
+
+	service := NewAuthService(repo, NewMockLoginAttemptRepository(), NewMockAuthAuditLogRepository(), NewMockTwoFactorChallengeRepository(), testPolicy(), authDomain.DefaultTwoFactorPolicy(), nil)
+	if _, _, err := service.Login(ctx, "sso@example.com", "anything", testClient); err != ErrInvalidLogin {
+		t.Errorf("Expected ErrInvalidLogin, got %v", err)
+	}
+}
+
+func TestDeactivatedUser(t *testing.T) {
+	ctx := context.Background()
+	repo := NewMockAuthRepository()
+	audit := NewMockAuthAuditLogRepository()
+	user := createTestLocalUser(t, repo, "leaver@example.com", "correct-password")
+	user.Deactivate(utils.TimeNowUTC())
+
+	service := NewAuthService(repo, NewMockLoginAttemptRepository(), audit, NewMockTwoFactorChallengeRepository(), testPolicy(), authDomain.DefaultTwoFactorPolicy(), nil)
+
+	// A wrong password must not reveal that the account is deactivated
+	if _, _, err := service.Login(ctx, "leaver@example.com", "wrong", testClient); err != ErrInvalidLogin {
+		t.Errorf("Expected ErrInvalidLogin, got %v", err)
+	}
+	if _, _, err := service.Login(ctx, "leaver@example.com", "correct-password", testClient); err != ErrAccountDeactivated {
+		t.Errorf("Expected ErrAccountDeactivated, got %v", err)
+	}
+	if _, _, err := service.Refresh(ctx, user.ID); err != ErrAccountDeactivated {
+		t.Errorf("Expected ErrAccountDeactivated on refresh, got %v", err)
+	}
+	if audit.count(authDomain.EventLoginBlocked) != 1 {
+		t.Error("Expected LOGIN_BLOCKED event")
+	}
+}
+
+func TestLoginAuditsInAccountTenant(t *testing.T) {
+	repo := NewMockAuthRepository()
+	audit := NewMockAuthAuditLogRepository()
+	user := createTestLocalUser(t, repo, "tenant@example.com", "correct-password")
+	user.TenantID = "tenant-a"
+
+	service := NewAuthService(repo, NewMockLoginAttemptRepository(), audit, NewMockTwoFactorChallengeRepository(), testPolicy(), authDomain.DefaultTwoFactorPolicy(), nil)
+
+	// Public routes carry no tenant: the account's tenant is used once known
+	ctx := context.Background()
+	if _, _, err := service.Login(ctx, "tenant@example.com", "wrong", testClient); err != ErrInvalidLogin {
+		t.Fatalf("Expected ErrInvalidLogin, got %v", err)
+	}
+	if _, _, err := service.Login(ctx, "nobody@example.com", "wrong", testClient); err != ErrInvalidLogin {
+		t.Fatalf("Expected ErrInvalidLogin, got %v", err)
+	}
+	if _, _, err := service.Login(ctx, "tenant@example.com", "correct-password", testClient); err != nil {
+		t.Fatalf("Expected login to succeed, got %v", err)
+	}
+
+	want := []string{"tenant-a", tenancy.DefaultTenantID, "tenant-a"}
+	if len(audit.entries) != len(want) {
+		t.Fatalf("Expected %d audit entries, got %d", len(want), len(audit.entries))
+	}
+	for i, entry := range audit.entries {
+		if entry.TenantID != want[i] {
+			t.Errorf("Entry %d (%s, %s): expected tenant %s, got %s", i, entry.Event, entry.Email, want[i], entry.TenantID)
+		}
+	}
+}
@@ -0,0 +1,163 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	authDomain "workflow-approval/package/auth/domain"
+	"workflow-approval/package/auth/repository"
+	userDomain "workflow-approval/package/user/domain"
+	"workflow-approval/utils"
+	"workflow-approval/utils/tenancy"
+)
+
+var (
+	ErrAccountLocked    = errors.New("account is temporarily locked due to failed login attempts")
+	ErrIPBlocked        = errors.New("too many failed login attempts from this address")
+	ErrTooManyAttempts  = errors.New("too many failed login attempts, please wait before retrying")
+	ErrAccountNotLocked = errors.New("account is not locked")
+)
+
+// ThrottleError is returned while a login is refused by lockout or progressive delay
+type ThrottleError struct {
+	Reason     error
+	RetryAfter time.Duration
+}
+
+// Error implements error
+func (e *ThrottleError) Error() string {
+	return fmt.Sprintf("%s (retry after %ds)", e.Reason.Error(), int(e.RetryAfter.Seconds()+0.5))
+}
+
+// Unwrap allows errors.Is(err, ErrAccountLocked) and friends
+func (e *ThrottleError) Unwrap() error {
+	return e.Reason
+}
+
+// accountTenant scopes ctx to the tenant of user. The public authentication
+// routes find accounts across tenants, as emails are unique across them, and
+// then act in the account's tenant; without an account (nil) they act in the
+// default tenant.
+func accountTenant(ctx context.Context, user *userDomain.User) context.Context {
+	if user == nil || user.TenantID == "" {
+		return tenancy.WithTenant(ctx, tenancy.DefaultTenantID)
+	}
+	return tenancy.WithTenant(ctx, user.TenantID)
+}
+
+// ipTenants lets IP counters, which span tenants, be read and locked from any tenant
+func ipTenants(ctx context.Context) context.Context {
+	return tenancy.WithAllTenants(ctx)
+}
+
+// checkThrottle refuses an attempt while key is locked or within its progressive delay
+func (s *AuthServiceImpl) checkThrottle(ctx context.Context, key string, lockedReason error, now time.Time) error {
+	attempt, err := s.attemptRepo.Get(ctx, key)
+	if err != nil {
+		return err
+	}
+	if attempt == nil {
+		return nil
+	}
+	if attempt.IsLocked(now) {
+		return &ThrottleError{Reason: lockedReason, RetryAfter: attempt.LockedUntil.Sub(now)}
+	}
+	if now.Sub(attempt.LastFailureAt) > s.policy.FailureWindow {
+		return nil
+	}
+
+	// Lock expired but failures remain within the window: still apply the delay
+	retryAt := attempt.LastFailureAt.Add(s.policy.ProgressiveDelay(attempt.Failures))
+	if now.Before(retryAt) {
+		return &ThrottleError{Reason: ErrTooManyAttempts, RetryAfter: retryAt.Sub(now)}
+	}
+	return nil
+}
+
+// recordFailure counts a failed attempt for the account and the IP, locks either
+// when its threshold is reached and always returns ErrInvalidLogin to the caller.
+// ctx must be scoped with accountTenant.
+func (s *AuthServiceImpl) recordFailure(ctx context.Context, userID *string, email string, client authDomain.ClientInfo, detail string, now time.Time) error {
+	s.audit(ctx, authDomain.EventLoginFailed, userID, email, client, detail)
+
+	accountKey := authDomain.AccountAttemptKey(email)
+	account, err := s.attemptRepo.RecordFailure(ctx, accountKey, now, s.policy.FailureWindow)
+	if err != nil {
+		return err
+	}
+	if s.policy.MaxAccountFailures > 0 && account.Failures >= s.policy.MaxAccountFailures && !account.IsLocked(now) {
+		if err := s.attemptRepo.Lock(ctx, accountKey, now.Add(s.policy.LockoutDuration)); err != nil {
+			return err
+		}
+		s.audit(ctx, authDomain.EventAccountLocked, userID, email, client,
+			fmt.Sprintf("%d failed attempts, locked for %s", account.Failures, s.policy.LockoutDuration))
+	}
+
+	if client.IP != "" {
+		ipKey := authDomain.IPAttemptKey(client.IP)
+		ip, err := s.attemptRepo.RecordFailure(ipTenants(ctx), ipKey, now, s.policy.FailureWindow)
+		if err != nil {
+			return err
+		}
+		if s.policy.MaxIPFailures > 0 && ip.Failures >= s.policy.MaxIPFailures && !ip.IsLocked(now) {
+			if err := s.attemptRepo.Lock(ipTenants(ctx), ipKey, now.Add(s.policy.LockoutDuration)); err != nil {
+				return err
+			}
+			s.audit(ctx, authDomain.EventIPBlocked, nil, email, client,
+				fmt.Sprintf("%d failed attempts, blocked for %s", ip.Failures, s.policy.LockoutDuration))
+		}
+	}
+
+	return ErrInvalidLogin
+}
+
+// UnlockUser clears the lockout and failure count of a user's account (admin only)
+func (s *AuthServiceImpl) UnlockUser(ctx context.Context, userID, adminID string, client authDomain.ClientInfo) error {
+	user, err := s.authRepo.GetByID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, repository.ErrUserNotFound) {
+			return ErrUserNotFound
+		}
+		return err
+	}
+
+	accountKey := authDomain.AccountAttemptKey(user.Email)
+	attempt, err := s.attemptRepo.Get(ctx, accountKey)
+	if err != nil {
+		return err
+	}
+	if attempt == nil || !attempt.IsLocked(utils.TimeNowUTC()) {
+		return ErrAccountNotLocked
+	}
+
+	if err := s.attemptRepo.Reset(ctx, accountKey); err != nil {
+		return err
+	}
+	s.audit(ctx, authDomain.EventAccountUnlocked, &user.ID, user.Email, client, "unlocked by admin "+adminID)
+	return nil
+}
+
+// ListAuditLog retrieves a paginated list of authentication events
+func (s *AuthServiceImpl) ListAuditLog(ctx context.Context, filter authDomain.AuthAuditFilter, page, limit int) ([]*authDomain.AuthAuditLog, int64, error) {
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 {
+		limit = 10
+	}
+	if limit > 100 {
+		limit = 100
+	}
+	return s.auditRepo.List(ctx, filter, page, limit)
+}
+
+// audit records a security event; failures are logged but never block authentication
+func (s *AuthServiceImpl) audit(ctx context.Context, event authDomain.AuthEvent, userID *string, email string, client authDomain.ClientInfo, detail string) {
+	entry := authDomain.NewAuthAuditLog(event, userID, email, client, detail)
+	if err := s.auditRepo.Create(ctx, entry); err != nil {
+		log.Printf("Warning: failed to write auth audit log (%s): %v", event, err)
+	}
+}
@@ -0,0 +1,248 @@
+package usecase
+
+import (
+	"context"
+	"crypto/subtle"
+	"errors"
+	"log"
+	"strings"
+	"time"
+
+	"workflow-approval/package/auth/domain"
+	"workflow-approval/package/auth/ports"
+	"workflow-approval/package/auth/repository"
+	userDomain "workflow-approval/package/user/domain"
+	"workflow-approval/utils"
+	"workflow-approval/utils/oidc"
+	"workflow-approval/utils/tenancy"
+)
+
+var (
+	ErrOIDCInvalidState     = errors.New("invalid or expired oidc login state")
+	ErrOIDCEmailMissing     = errors.New("oidc identity has no email claim")
+	ErrOIDCEmailNotVerified = errors.New("oidc email is not verified by the identity provider")
+	ErrOIDCActorNotFound    = errors.New("mapped actor code not found")
+)
+
+// OIDCStateTTL bounds how long a user may take at the identity provider
+const OIDCStateTTL = 10 * time.Minute
+
+// OIDCServiceImpl implements OIDCService interface
+type OIDCServiceImpl struct {
+	client           *oidc.Client
+	authRepo         ports.AuthRepository
+	actorRepo        ports.ActorRepository
+	auditRepo        ports.AuthAuditLogRepository
+	stateRepo        ports.OIDCStateRepository
+	secondFactor     ports.SecondFactorChallenger
+	rules            []domain.ClaimMappingRule
+	defaultActorCode string
+}
+
+// NewOIDCService creates a new OIDCServiceImpl instance.
+// secondFactor applies the 2FA policy of password logins to OIDC logins.
+// defaultActorCode is assigned to newly provisioned users when no rule matches (may be empty).
+func NewOIDCService(client *oidc.Client, authRepo ports.AuthRepository, actorRepo ports.ActorRepository, auditRepo ports.AuthAuditLogRepository, stateRepo ports.OIDCStateRepository, secondFactor ports.SecondFactorChallenger, rules []domain.ClaimMappingRule, defaultActorCode string) ports.OIDCService {
+	return &OIDCServiceImpl{
+		client:           client,
+		authRepo:         authRepo,
+		actorRepo:        actorRepo,
+		auditRepo:        auditRepo,
+		stateRepo:        stateRepo,
+		secondFactor:     secondFactor,
+		rules:            rules,
+		defaultActorCode: defaultActorCode,
+	}
+}
+
+// BeginLogin creates state, nonce and PKCE verifier and returns the authorization
+// URL and the state to bind to the browser starting the login
+func (s *OIDCServiceImpl) BeginLogin(ctx context.Context) (string, string, error) {
+	state, err := oidc.RandomString(24)
+	if err != nil {
+		return "", "", err
+	}
+	nonce, err := oidc.RandomString(24)
+	if err != nil {
+		return "", "", err
+	}
+	verifier, err := oidc.GenerateCodeVerifier()
+	if err != nil {
+		return "", "", err
+	}
+
+	authURL, err := s.client.AuthCodeURL(ctx, state, nonce, oidc.CodeChallengeS256(verifier))
+	if err != nil {
+		return "", "", err
+	}
+
+	// Stored so the callback may reach any instance; expired logins are dropped on the way
+	if err := s.stateRepo.DeleteExpired(ctx, utils.TimeNowUTC()); err != nil {
+		return "", "", err
+	}
+	if err := s.stateRepo.Create(ctx, domain.NewOIDCLoginState(domain.HashUserToken(state), nonce, verifier, OIDCStateTTL)); err != nil {
+		return "", "", err
+	}
+	return authURL, state, nil
+}
+
+// CompleteLogin exchanges the code, verifies the ID token and provisions the user.
+// Like Login, it returns a *TwoFactorChallenge when a second factor is required.
+// The callback's state must be the one bound to the browser, so nobody can
+// finish their own provider login in someone else's browser (login CSRF).
+// Failures before the user is known are recorded in the default tenant.
+func (s *OIDCServiceImpl) CompleteLogin(ctx context.Context, state, browserState, code string, client domain.ClientInfo) (*userDomain.User, error) {
+	ctx = accountTenant(ctx, nil)
+	if browserState == "" || subtle.ConstantTimeCompare([]byte(state), []byte(browserState)) != 1 {
+		s.audit(ctx, domain.EventLoginFailed, nil, "", client, "oidc: state not bound to this browser")
+		return nil, ErrOIDCInvalidState
+	}
+
+	// State is single use
+	loginState, err := s.stateRepo.Take(ctx, domain.HashUserToken(state))
+	if err != nil && !errors.Is(err, repository.ErrOIDCStateNotFound) {
+		return nil, err
+	}
+	if err != nil || !utils.TimeNowUTC().Before(loginState.ExpiresAt) {
+		s.audit(ctx, domain.EventLoginFailed, nil, "", client, "oidc: "+ErrOIDCInvalidState.Error())
+		return nil, ErrOIDCInvalidState
+	}
+
+	tokens, err := s.client.Exchange(ctx, code, loginState.CodeVerifier)
+	if err != nil {
+		s.audit(ctx, domain.EventLoginFailed, nil, "", client, "oidc: "+err.Error())
+		return nil, err
+	}
+
+	claims, err := s.client.VerifyIDToken(ctx, tokens.IDToken, loginState.Nonce)
+	if err != nil {
+		s.audit(ctx, domain.EventLoginFailed, nil, "", client, "oidc: "+err.Error())
+		return nil, err
+	}
+
+	user, err := s.provisionUser(ctx, claims)
+	if err != nil {
+		s.audit(ctx, domain.EventLoginFailed, nil, claims.Email(), client, "oidc: "+err.Error())
+		return nil, err
+	}
+	ctx = accountTenant(ctx, user)
+	if !user.IsActive {
+		s.audit(ctx, domain.EventLoginBlocked, &user.ID, user.Email, client, "oidc: account deactivated")
+		return nil, ErrAccountDeactivated
+	}
+
+	// Users the 2FA policy applies to finish through VerifyTwoFactor, as password logins do
+	if err := s.secondFactor.ChallengeSecondFactor(ctx, user, methodOIDC); err != nil {
+		return nil, err
+	}
+
+	s.audit(ctx, domain.EventLoginSuccess, &user.ID, user.Email, client, methodOIDC)
+	return user, nil
+}
+
+// audit records a security event; failures are logged but never block authentication
+func (s *OIDCServiceImpl) audit(ctx context.Context, event domain.AuthEvent, userID *string, email string, client domain.ClientInfo, detail string) {
+	entry := domain.NewAuthAuditLog(event, userID, email, client, detail)
+	if err := s.auditRepo.Create(ctx, entry); err != nil {
+		log.Printf("Warning: failed to write auth audit log (%s): %v", event, err)
+	}
+}
+
+// provisionUser finds the user linked to the identity, links an existing account by
+// verified email, or creates a new account (JIT provisioning). Mapping rules are
+// re-applied on every login so role changes at the provider take effect.
+// Identities and emails are unique across tenants, so they are looked up in all
+// of them and the user is saved in its own tenant.
+func (s *OIDCServiceImpl) provisionUser(ctx context.Context, claims oidc.Claims) (*userDomain.User, error) {
+	mapping := domain.EvaluateClaimMapping(s.rules, claims)
+	anyTenant := tenancy.WithAllTenants(ctx)
+
+	// 1. Identity already linked
+	user, err := s.authRepo.GetByOIDCSubject(anyTenant, claims.Subject())
+	if err == nil {
+		if err := s.applyMapping(ctx, user, mapping); err != nil {
+			return nil, err
+		}
+		if err := s.saveUser(accountTenant(ctx, user), user); err != nil {
+			return nil, err
+		}
+		return user, nil
+	}
+	if !errors.Is(err, repository.ErrUserNotFound) {
+		return nil, err
+	}
+
+	email := strings.ToLower(claims.Email())
+	if email == "" {
+		return nil, ErrOIDCEmailMissing
+	}
+
+	// 2. Existing local account with the same email: link it, but only when the
+	// provider vouches for the address
+	user, err = s.authRepo.GetByEmail(anyTenant, email)
+	if err == nil {
+		if !claims.EmailVerified() {
+			return nil, ErrOIDCEmailNotVerified
+		}
+		subject := claims.Subject()
+		user.OIDCSubject = &subject
+		if err := s.applyMapping(ctx, user, mapping); err != nil {
+			return nil, err
+		}
+		if err := s.saveUser(accountTenant(ctx, user), user); err != nil {
+			return nil, err
+		}
+		return user, nil
+	}
+	if !errors.Is(err, repository.ErrUserNotFound) {
+		return nil, err
+	}
+
+	// 3. New account
+	name := claims.Name()
+	if name == "" {
+		name = email
+	}
+	user = userDomain.NewOIDCUser(email, name, claims.Subject(), false, nil)
+	user.TenantID = tenancy.DefaultTenantID // JIT-provisioned accounts join the default tenant
+
+	if !mapping.Matched && s.defaultActorCode != "" {
+		mapping = domain.ClaimMapping{Matched: true, ActorCode: s.defaultActorCode}
+	}
+	if err := s.applyMapping(ctx, user, mapping); err != nil {
+		return nil, err
+	}
+	if err := s.authRepo.Create(accountTenant(ctx, user), user); err != nil {
+		return nil, err
+	}
+	return user, nil
+}
+
+// saveUser stores a returning user and the actor memberships added by applyMapping
+func (s *OIDCServiceImpl) saveUser(ctx context.Context, user *userDomain.User) error {
+	if err := s.authRepo.Update(ctx, user); err != nil {
+		return err
+	}
+	return s.authRepo.SaveActorMemberships(ctx, user)
+}
+
+// applyMapping sets the admin flag and adds the mapped actor from a matched
+// mapping; memberships assigned by an admin are kept. When no rule matched the
+// user's existing actors and role are left untouched
+func (s *OIDCServiceImpl) applyMapping(ctx context.Context, user *userDomain.User, mapping domain.ClaimMapping) error {
+	if !mapping.Matched {
+		return nil
+	}
+
+	user.IsAdmin = mapping.IsAdmin
+	if mapping.ActorCode != "" {
+		// Actor codes are unique per tenant only
+		actor, err := s.actorRepo.GetByCode(tenancy.WithTenant(ctx, user.TenantID), mapping.ActorCode)
+		if err != nil {
+			return ErrOIDCActorNotFound
+		}
+		user.AddActor(actor.ID, utils.TimeNowUTC())
+	}
+	user.UpdatedAt = utils.TimeNowUTC()
+	return nil
+}
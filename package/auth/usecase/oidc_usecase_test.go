@@ -0,0 +1,463 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	actorDomain "workflow-approval/package/actor/domain"
+	authDomain "workflow-approval/package/auth/domain"
+	authPorts "workflow-approval/package/auth/ports"
+	authRepo "workflow-approval/package/auth/repository"
+	userDomain "workflow-approval/package/user/domain"
+	"workflow-approval/utils"
+	"workflow-approval/utils/oidc"
+	"workflow-approval/utils/oidc/oidctest"
+)
+
+// MockActorRepository implements ActorRepository for testing
+type MockActorRepository struct {
+	actors map[string]*actorDomain.Actor
+}
+
+func NewMockActorRepository(actors ...*actorDomain.Actor) *MockActorRepository {
+	m := &MockActorRepository{actors: make(map[string]*actorDomain.Actor)}
+	for _, a := range actors {
+		m.actors[a.Code] = a
+	}
+	return m
+}
+
+func (m *MockActorRepository) GetByCode(ctx context.Context, code string) (*actorDomain.Actor, error) {
+	if a, ok := m.actors[code]; ok {
+		return a, nil
+	}
+	return nil, authRepo.ErrUserNotFound
+}
+
+// MockOIDCStateRepository implements OIDCStateRepository for testing
+type MockOIDCStateRepository struct {
+	states map[string]*authDomain.OIDCLoginState
+}
+
+func NewMockOIDCStateRepository() *MockOIDCStateRepository {
+	return &MockOIDCStateRepository{
+		states: make(map[string]*authDomain.OIDCLoginState),
+	}
+}
+
+func (m *MockOIDCStateRepository) Create(ctx context.Context, state *authDomain.OIDCLoginState) error {
+	m.states[state.StateHash] = state
+	return nil
+}
+
+func (m *MockOIDCStateRepository) Take(ctx context.Context, stateHash string) (*authDomain.OIDCLoginState, error) {
+	state, ok := m.states[stateHash]
+	if !ok {
+		return nil, authRepo.ErrOIDCStateNotFound
+	}
+	delete(m.states, stateHash)
+	return state, nil
+}
+
+func (m *MockOIDCStateRepository) DeleteExpired(ctx context.Context, now time.Time) error {
+	for hash, state := range m.states {
+		if state.ExpiresAt.Before(now) {
+			delete(m.states, hash)
+		}
+	}
+	return nil
+}
+
+// Interface compliance
+var (
+	_ authPorts.ActorRepository     = (*MockActorRepository)(nil)
+	_ authPorts.OIDCStateRepository = (*MockOIDCStateRepository)(nil)
+)
+
+const (
+	testClientID     = "workflow-approval"
+	testClientSecret = "s3cret"
+	testRedirectURL  = "http://localhost:8080/auth/oidc/callback"
+)
+
+func newTestOIDCService(t *testing.T, provider *oidctest.Provider, repo *MockAuthRepository, rules []authDomain.ClaimMappingRule, defaultActorCode string) authPorts.OIDCService {
+	t.Helper()
+	authService := NewAuthService(repo, NewMockLoginAttemptRepository(), NewMockAuthAuditLogRepository(), NewMockTwoFactorChallengeRepository(), testPolicy(), authDomain.DefaultTwoFactorPolicy(), nil)
+	return newTestOIDCServiceWithAuth(t, provider, repo, NewMockAuthAuditLogRepository(), NewMockOIDCStateRepository(), authService, rules, defaultActorCode)
+}
+
+// newTestOIDCServiceWithAuth creates an OIDC service keeping login state in
+// states, whose second factor is challenged by authService
+func newTestOIDCServiceWithAuth(t *testing.T, provider *oidctest.Provider, repo *MockAuthRepository, audit *MockAuthAuditLogRepository, states *MockOIDCStateRepository, authService authPorts.AuthService, rules []authDomain.ClaimMappingRule, defaultActorCode string) authPorts.OIDCService {
+	t.Helper()
+	client := oidc.NewClient(oidc.Config{
+		IssuerURL:    provider.Issuer(),
+		ClientID:     testClientID,
+		ClientSecret: testClientSecret,
+		RedirectURL:  testRedirectURL,
+	}, nil)
+	actors := NewMockActorRepository(
+		&actorDomain.Actor{ID: "actor-staff", Code: "STAFF"},
+		&actorDomain.Actor{ID: "actor-manager", Code: "MANAGER"},
+	)
+	return NewOIDCService(client, repo, actors, audit, states, authService, rules, defaultActorCode)
+}
+
+// login runs the full browser round trip against the fake provider
+func login(t *testing.T, ctx context.Context, service authPorts.OIDCService, provider *oidctest.Provider) (*userDomain.User, error) {
+	t.Helper()
+	authURL, browserState, err := service.BeginLogin(ctx)
+	if err != nil {
+		t.Fatalf("BeginLogin failed: %v", err)
+	}
+	code, state, err := provider.Authorize(authURL)
+	if err != nil {
+		t.Fatalf("Authorize failed: %v", err)
+	}
+	return service.CompleteLogin(ctx, state, browserState, code, testClient)
+}
+
+func TestOIDCLogin(t *testing.T) {
+	ctx := context.Background()
+	provider, err := oidctest.NewProvider(testClientID, testClientSecret)
+	if err != nil {
+		t.Fatalf("Failed to start fake provider: %v", err)
+	}
+	defer provider.Close()
+
+	rules := []authDomain.ClaimMappingRule{
+		{Claim: "groups", Value: "finance-managers", ActorCode: "MANAGER"},
+		{Claim: "groups", Value: "workflow-admins", IsAdmin: true},
+	}
+
+	t.Run("JIT provisions a new user with mapped actor", func(t *testing.T) {
+		repo := NewMockAuthRepository()
+		service := newTestOIDCService(t, provider, repo, rules, "STAFF")
+		provider.SetUser(map[string]interface{}{
+			"sub":            "idp-user-1",
+			"email":          "Manager@Example.com",
+			"email_verified": true,
+			"name":           "Finance Manager",
+			"groups":         []string{"employees", "finance-managers"},
+		})
+
+		user, err := login(t, ctx, service, provider)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if user.Email != "manager@example.com" {
+			t.Errorf("Expected normalized email, got %s", user.Email)
+		}
+		if user.AuthProvider != userDomain.AuthProviderOIDC {
+			t.Errorf("Expected auth provider oidc, got %s", user.AuthProvider)
+		}
+		if !user.HasActor("actor-manager") {
+			t.Errorf("Expected actor-manager, got %v", user.ActorIDs())
+		}
+		if user.IsAdmin {
+			t.Error("Expected non-admin user")
+		}
+		if user.IsLocalLoginAllowed() {
+			t.Error("Expected OIDC user to have no local password")
+		}
+		if len(repo.users) != 1 {
+			t.Errorf("Expected 1 provisioned user, got %d", len(repo.users))
+		}
+	})
+
+	t.Run("Default actor when no rule matches", func(t *testing.T) {
+		repo := NewMockAuthRepository()
+		service := newTestOIDCService(t, provider, repo, rules, "STAFF")
+		provider.SetUser(map[string]interface{}{
+			"sub":   "idp-user-2",
+			"email": "staff@example.com",
+		})
+
+		user, err := login(t, ctx, service, provider)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if !user.HasActor("actor-staff") {
+			t.Errorf("Expected actor-staff, got %v", user.ActorIDs())
+		}
+	})
+
+	t.Run("Returning user is matched by subject and roles are re-synced", func(t *testing.T) {
+		repo := NewMockAuthRepository()
+		service := newTestOIDCService(t, provider, repo, rules, "")
+		provider.SetUser(map[string]interface{}{
+			"sub":    "idp-user-3",
+			"email":  "ops@example.com",
+			"groups": []string{"finance-managers"},
+		})
+		first, err := login(t, ctx, service, provider)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		// Email changed at the provider, subject is stable
+		provider.SetUser(map[string]interface{}{
+			"sub":    "idp-user-3",
+			"email":  "ops-renamed@example.com",
+			"groups": []string{"workflow-admins"},
+		})
+		second, err := login(t, ctx, service, provider)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if second.ID != first.ID {
+			t.Errorf("Expected same user, got %s and %s", first.ID, second.ID)
+		}
+		if !second.IsAdmin {
+			t.Error("Expected admin role from workflow-admins group")
+		}
+		if len(repo.users) != 1 {
+			t.Errorf("Expected 1 user, got %d", len(repo.users))
+		}
+	})
+
+	t.Run("Existing local account is linked only with verified email", func(t *testing.T) {
+		repo := NewMockAuthRepository()
+		local := userDomain.NewUser("local@example.com", "$2a$10$hash", "Local User", false, nil)
+		repo.Create(ctx, local)
+		service := newTestOIDCService(t, provider, repo, nil, "")
+
+		provider.SetUser(map[string]interface{}{
+			"sub":   "idp-user-4",
+			"email": "local@example.com",
+		})
+		if _, err := login(t, ctx, service, provider); err != ErrOIDCEmailNotVerified {
+			t.Errorf("Expected ErrOIDCEmailNotVerified, got %v", err)
+		}
+
+		provider.SetUser(map[string]interface{}{
+			"sub":            "idp-user-4",
+			"email":          "local@example.com",
+			"email_verified": true,
+		})
+		user, err := login(t, ctx, service, provider)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if user.ID != local.ID {
+			t.Errorf("Expected linked local user %s, got %s", local.ID, user.ID)
+		}
+		if user.OIDCSubject == nil || *user.OIDCSubject != "idp-user-4" {
+			t.Errorf("Expected subject to be linked, got %v", user.OIDCSubject)
+		}
+		if !user.IsLocalLoginAllowed() {
+			t.Error("Expected linked account to keep its local password")
+		}
+	})
+
+	t.Run("Unknown mapped actor is rejected", func(t *testing.T) {
+		repo := NewMockAuthRepository()
+		service := newTestOIDCService(t, provider, repo, []authDomain.ClaimMappingRule{
+			{Claim: "department", Value: "legal", ActorCode: "LEGAL"},
+		}, "")
+		provider.SetUser(map[string]interface{}{
+			"sub":        "idp-user-5",
+			"email":      "legal@example.com",
+			"department": "legal",
+		})
+
+		if _, err := login(t, ctx, service, provider); err != ErrOIDCActorNotFound {
+			t.Errorf("Expected ErrOIDCActorNotFound, got %v", err)
+		}
+		if len(repo.users) != 0 {
+			t.Errorf("Expected no user to be provisioned, got %d", len(repo.users))
+		}
+	})
+
+	t.Run("State cannot be reused or forged", func(t *testing.T) {
+		repo := NewMockAuthRepository()
+		service := newTestOIDCService(t, provider, repo, nil, "")
+		provider.SetUser(map[string]interface{}{
+			"sub":   "idp-user-6",
+			"email": "replay@example.com",
+		})
+
+		authURL, _, err := service.BeginLogin(ctx)
+		if err != nil {
+			t.Fatalf("BeginLogin failed: %v", err)
+		}
+		code, state, err := provider.Authorize(authURL)
+		if err != nil {
+			t.Fatalf("Authorize failed: %v", err)
+		}
+
+		if _, err := service.CompleteLogin(ctx, "forged-state", "forged-state", code, testClient); err != ErrOIDCInvalidState {
+			t.Errorf("Expected ErrOIDCInvalidState for forged state, got %v", err)
+		}
+		if _, err := service.CompleteLogin(ctx, state, state, code, testClient); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if _, err := service.CompleteLogin(ctx, state, state, code, testClient); err != ErrOIDCInvalidState {
+			t.Errorf("Expected ErrOIDCInvalidState for replayed state, got %v", err)
+		}
+	})
+
+	t.Run("State completes on another instance until it expires", func(t *testing.T) {
+		repo := NewMockAuthRepository()
+		states := NewMockOIDCStateRepository()
+		authService := NewAuthService(repo, NewMockLoginAttemptRepository(), NewMockAuthAuditLogRepository(), NewMockTwoFactorChallengeRepository(), testPolicy(), authDomain.DefaultTwoFactorPolicy(), nil)
+		first := newTestOIDCServiceWithAuth(t, provider, repo, NewMockAuthAuditLogRepository(), states, authService, nil, "")
+		second := newTestOIDCServiceWithAuth(t, provider, repo, NewMockAuthAuditLogRepository(), states, authService, nil, "")
+		provider.SetUser(map[string]interface{}{
+			"sub":   "idp-user-9",
+			"email": "cluster@example.com",
+		})
+
+		authURL, _, err := first.BeginLogin(ctx)
+		if err != nil {
+			t.Fatalf("BeginLogin failed: %v", err)
+		}
+		code, state, err := provider.Authorize(authURL)
+		if err != nil {
+			t.Fatalf("Authorize failed: %v", err)
+		}
+		if _, ok := states.states[state]; ok {
+			t.Error("Expected only the hash of the state stored")
+		}
+		if _, err := second.CompleteLogin(ctx, state, state, code, testClient); err != nil {
+			t.Fatalf("Expected the login completed by another instance, got %v", err)
+		}
+
+		authURL, _, _ = first.BeginLogin(ctx)
+		code, state, _ = provider.Authorize(authURL)
+		for _, pending := range states.states {
+			pending.ExpiresAt = utils.TimeNowUTC().Add(-time.Second)
+		}
+		if _, err := second.CompleteLogin(ctx, state, state, code, testClient); err != ErrOIDCInvalidState {
+			t.Errorf("Expected ErrOIDCInvalidState for an expired state, got %v", err)
+		}
+	})
+
+	t.Run("State must be bound to the browser", func(t *testing.T) {
+		repo := NewMockAuthRepository()
+		service := newTestOIDCService(t, provider, repo, nil, "")
+		provider.SetUser(map[string]interface{}{
+			"sub":   "idp-attacker",
+			"email": "attacker@example.com",
+		})
+
+		// The attacker logs in at the provider and hands the callback URL to a victim
+		authURL, attackerState, err := service.BeginLogin(ctx)
+		if err != nil {
+			t.Fatalf("BeginLogin failed: %v", err)
+		}
+		code, state, err := provider.Authorize(authURL)
+		if err != nil {
+			t.Fatalf("Authorize failed: %v", err)
+		}
+		_, victimState, err := service.BeginLogin(ctx)
+		if err != nil {
+			t.Fatalf("BeginLogin failed: %v", err)
+		}
+
+		if _, err := service.CompleteLogin(ctx, state, "", code, testClient); err != ErrOIDCInvalidState {
+			t.Errorf("Expected ErrOIDCInvalidState without a browser state, got %v", err)
+		}
+		if _, err := service.CompleteLogin(ctx, state, victimState, code, testClient); err != ErrOIDCInvalidState {
+			t.Errorf("Expected ErrOIDCInvalidState for another browser's state, got %v", err)
+		}
+		if len(repo.users) != 0 {
+			t.Errorf("Expected no user provisioned, got %d", len(repo.users))
+		}
+		if _, err := service.CompleteLogin(ctx, state, attackerState, code, testClient); err != nil {
+			t.Errorf("Expected the login to complete in the browser that began it, got %v", err)
+		}
+	})
+
+	t.Run("Token exchange fails with wrong client secret", func(t *testing.T) {
+		repo := NewMockAuthRepository()
+		client := oidc.NewClient(oidc.Config{
+			IssuerURL:    provider.Issuer(),
+			ClientID:     testClientID,
+			ClientSecret: "wrong",
+			RedirectURL:  testRedirectURL,
+		}, nil)
+		authService := NewAuthService(repo, NewMockLoginAttemptRepository(), NewMockAuthAuditLogRepository(), NewMockTwoFactorChallengeRepository(), testPolicy(), authDomain.DefaultTwoFactorPolicy(), nil)
+		service := NewOIDCService(client, repo, NewMockActorRepository(), NewMockAuthAuditLogRepository(), NewMockOIDCStateRepository(), authService, nil, "")
+		provider.SetUser(map[string]interface{}{
+			"sub":   "idp-user-7",
+			"email": "x@example.com",
+		})
+
+		if _, err := login(t, ctx, service, provider); err == nil {
+			t.Error("Expected token exchange to fail")
+		}
+	})
+}
+
+func TestOIDCLoginTwoFactor(t *testing.T) {
+	ctx := context.Background()
+	provider, err := oidctest.NewProvider(testClientID, testClientSecret)
+	if err != nil {
+		t.Fatalf("Failed to start fake provider: %v", err)
+	}
+	defer provider.Close()
+
+	t.Run("Enrolled user must pass the second factor", func(t *testing.T) {
+		repo := NewMockAuthRepository()
+		audit := NewMockAuthAuditLogRepository()
+		user := createTestLocalUser(t, repo, "approver@example.com", "correct-password")
+		enrollTestUser(t, repo, user)
+		authService := NewAuthService(repo, NewMockLoginAttemptRepository(), audit, NewMockTwoFactorChallengeRepository(), testPolicy(), authDomain.DefaultTwoFactorPolicy(), nil)
+		service := newTestOIDCServiceWithAuth(t, provider, repo, audit, NewMockOIDCStateRepository(), authService, nil, "")
+		provider.SetUser(map[string]interface{}{
+			"sub":            "idp-approver",
+			"email":          "approver@example.com",
+			"email_verified": true,
+		})
+
+		_, err := login(t, ctx, service, provider)
+		var challenge *TwoFactorChallenge
+		if !errors.As(err, &challenge) {
+			t.Fatalf("Expected TwoFactorChallenge, got %v", err)
+		}
+		if challenge.EnrollmentRequired {
+			t.Errorf("Expected no enrollment for an enrolled user")
+		}
+		if audit.count(authDomain.EventLoginSuccess) != 0 {
+			t.Errorf("Expected no LOGIN_SUCCESS before the second factor")
+		}
+
+		loggedIn, _, err := authService.VerifyTwoFactor(ctx, challenge.Token, currentCode(t, user), testClient)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if loggedIn.ID != user.ID {
+			t.Errorf("Expected user %s, got %s", user.ID, loggedIn.ID)
+		}
+		last := audit.entries[len(audit.entries)-1]
+		if last.Event != authDomain.EventLoginSuccess || last.Detail != "oidc+totp" {
+			t.Errorf("Expected LOGIN_SUCCESS oidc+totp, got %s %s", last.Event, last.Detail)
+		}
+	})
+
+	t.Run("Policy requires admins to enroll", func(t *testing.T) {
+		repo := NewMockAuthRepository()
+		policy := authDomain.DefaultTwoFactorPolicy()
+		policy.RequireForAdmins = true
+		authService := NewAuthService(repo, NewMockLoginAttemptRepository(), NewMockAuthAuditLogRepository(), NewMockTwoFactorChallengeRepository(), testPolicy(), policy, nil)
+		rules := []authDomain.ClaimMappingRule{{Claim: "groups", Value: "workflow-admins", IsAdmin: true}}
+		service := newTestOIDCServiceWithAuth(t, provider, repo, NewMockAuthAuditLogRepository(), NewMockOIDCStateRepository(), authService, rules, "")
+		provider.SetUser(map[string]interface{}{
+			"sub":    "idp-admin",
+			"email":  "admin@example.com",
+			"groups": []string{"workflow-admins"},
+		})
+
+		_, err := login(t, ctx, service, provider)
+		var challenge *TwoFactorChallenge
+		if !errors.As(err, &challenge) {
+			t.Fatalf("Expected TwoFactorChallenge, got %v", err)
+		}
+		if !challenge.EnrollmentRequired || challenge.Enrollment == nil {
+			t.Errorf("Expected an enrollment challenge, got %+v", challenge)
+		}
+	})
+}
@@ -0,0 +1,251 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	authDomain "workflow-approval/package/auth/domain"
+	"workflow-approval/package/auth/repository"
+	userDomain "workflow-approval/package/user/domain"
+	"workflow-approval/utils"
+	"workflow-approval/utils/oidc"
+	"workflow-approval/utils/tenancy"
+	"workflow-approval/utils/totp"
+)
+
+var (
+	ErrTwoFactorInvalidCode      = errors.New("invalid two-factor code")
+	ErrTwoFactorChallengeInvalid = errors.New("invalid or expired two-factor challenge")
+	ErrTwoFactorAlreadyEnabled   = errors.New("two-factor authentication is already enabled")
+	ErrTwoFactorNotEnabled       = errors.New("two-factor authentication is not enabled")
+	ErrTwoFactorNotEnrolled      = errors.New("two-factor enrollment has not been started")
+	ErrTwoFactorRequired         = errors.New("two-factor authentication is required for this account")
+)
+
+const (
+	// totpSkew accepts codes from one time step before and after the current one (clock drift)
+	totpSkew = 1
+	// maxChallengeAttempts bounds code guesses per challenge; failures also count towards lockout
+	maxChallengeAttempts = 5
+)
+
+// Authentication methods recorded in the audit log
+const (
+	methodPassword     = "password"
+	methodOIDC         = "oidc"
+	methodTOTP         = "totp"
+	methodRecoveryCode = "recovery_code"
+)
+
+// TwoFactorChallenge is returned by Login when the password was correct but a
+// second factor is still required. The client completes the login by sending
+// Token and a code to VerifyTwoFactor.
+type TwoFactorChallenge struct {
+	Token              string
+	ExpiresIn          time.Duration
+	EnrollmentRequired bool                       // The policy requires TOTP but the user has not enrolled yet
+	Enrollment         *authDomain.TOTPEnrollment // Set when EnrollmentRequired
+}
+
+// Error implements error
+func (c *TwoFactorChallenge) Error() string {
+	return "two-factor authentication required"
+}
+
+// ChallengeSecondFactor returns a *TwoFactorChallenge when the user, who passed
+// firstFactor (e.g. "oidc") outside of Login, must also pass a second factor,
+// and nil when the 2FA policy does not apply to them
+func (s *AuthServiceImpl) ChallengeSecondFactor(ctx context.Context, user *userDomain.User, firstFactor string) error {
+	if !s.twoFactor.RequiresTwoFactor(user) {
+		return nil
+	}
+	return s.beginTwoFactorChallenge(ctx, user, firstFactor)
+}
+
+// beginTwoFactorChallenge stores a pending login for the user. When the policy
+// requires enrollment, a secret is issued (or the pending one reused) so the user
+// can enroll and log in in one step.
+func (s *AuthServiceImpl) beginTwoFactorChallenge(ctx context.Context, user *userDomain.User, firstFactor string) error {
+	token, err := oidc.RandomString(32)
+	if err != nil {
+		return err
+	}
+
+	challenge := &TwoFactorChallenge{
+		Token:     token,
+		ExpiresIn: s.twoFactor.ChallengeTTL,
+	}
+	if !user.TOTPEnabled {
+		if user.TOTPSecret == "" {
+			secret, err := totp.GenerateSecret()
+			if err != nil {
+				return err
+			}
+			user.TOTPSecret = secret
+			if err := s.authRepo.Update(ctx, user); err != nil {
+				return err
+			}
+		}
+		challenge.EnrollmentRequired = true
+		challenge.Enrollment = newEnrollment(s.twoFactor.Issuer, user)
+	}
+
+	// Challenges are completed on public routes and expire across tenants
+	if err := s.challengeRepo.DeleteExpired(tenancy.WithAllTenants(ctx), utils.TimeNowUTC()); err != nil {
+		return err
+	}
+	pending := authDomain.NewPendingTwoFactor(user, authDomain.HashUserToken(token), firstFactor, challenge.EnrollmentRequired, s.twoFactor.ChallengeTTL)
+	if err := s.challengeRepo.Create(ctx, pending); err != nil {
+		return err
+	}
+	return challenge
+}
+
+// VerifyTwoFactor completes a login challenged for a second factor. Wrong codes
+// count as failed logins for lockout purposes. The challenge is looked up
+// across tenants, and the login continues in the tenant of its user.
+func (s *AuthServiceImpl) VerifyTwoFactor(ctx context.Context, challengeToken, code string, client authDomain.ClientInfo) (*userDomain.User, []string, error) {
+	if challengeToken == "" {
+		return nil, nil, ErrTwoFactorChallengeInvalid
+	}
+	pending, err := s.challengeRepo.GetByHash(tenancy.WithAllTenants(ctx), authDomain.HashUserToken(challengeToken))
+	if err != nil {
+		if errors.Is(err, repository.ErrTwoFactorChallengeNotFound) {
+			return nil, nil, ErrTwoFactorChallengeInvalid
+		}
+		return nil, nil, err
+	}
+	ctx = tenancy.WithTenant(ctx, pending.TenantID)
+	if !utils.TimeNowUTC().Before(pending.ExpiresAt) {
+		s.removeChallenge(ctx, pending)
+		return nil, nil, ErrTwoFactorChallengeInvalid
+	}
+
+	user, err := s.authRepo.GetByID(ctx, pending.UserID)
+	if err != nil {
+		if errors.Is(err, repository.ErrUserNotFound) {
+			return nil, nil, ErrTwoFactorChallengeInvalid
+		}
+		return nil, nil, err
+	}
+	if !user.IsActive {
+		s.removeChallenge(ctx, pending)
+		return nil, nil, ErrAccountDeactivated
+	}
+
+	now := utils.TimeNowUTC()
+	if err := s.checkThrottle(ipTenants(ctx), authDomain.IPAttemptKey(client.IP), ErrIPBlocked, now); err != nil {
+		s.audit(ctx, authDomain.EventLoginBlocked, &user.ID, user.Email, client, err.Error())
+		return nil, nil, err
+	}
+	if err := s.checkThrottle(ctx, authDomain.AccountAttemptKey(user.Email), ErrAccountLocked, now); err != nil {
+		s.removeChallenge(ctx, pending)
+		s.audit(ctx, authDomain.EventLoginBlocked, &user.ID, user.Email, client, err.Error())
+		return nil, nil, err
+	}
+
+	// An enrollment challenge is confirmed with a code from the new secret only
+	method, ok := "", false
+	if pending.Enrollment {
+		if verifyTOTP(user, code, now) {
+			method, ok = methodTOTP, true
+		}
+	} else {
+		method, ok = verifySecondFactor(user, code, now)
+	}
+	if !ok {
+		if pending.Failures+1 >= maxChallengeAttempts {
+			s.removeChallenge(ctx, pending)
+		} else if err := s.challengeRepo.AddFailure(ctx, pending.ID); err != nil {
+			return nil, nil, err
+		}
+		if err := s.recordFailure(ctx, &user.ID, user.Email, client, "invalid two-factor code", now); !errors.Is(err, ErrInvalidLogin) {
+			return nil, nil, err
+		}
+		return nil, nil, ErrTwoFactorInvalidCode
+	}
+	// A challenge completes one login; a concurrent verification loses here
+	if err := s.challengeRepo.Delete(ctx, pending.ID); err != nil {
+		if errors.Is(err, repository.ErrTwoFactorChallengeNotFound) {
+			return nil, nil, ErrTwoFactorChallengeInvalid
+		}
+		return nil, nil, err
+	}
+
+	var recoveryCodes []string
+	if pending.Enrollment {
+		codes, encoded, err := authDomain.GenerateRecoveryCodes()
+		if err != nil {
+			return nil, nil, err
+		}
+		user.TOTPEnabled = true
+		user.RecoveryCodes = encoded
+		recoveryCodes = codes
+	}
+	user.UpdatedAt = now
+	if err := s.authRepo.Update(ctx, user); err != nil {
+		return nil, nil, err
+	}
+
+	if err := s.attemptRepo.Reset(ctx, authDomain.AccountAttemptKey(user.Email)); err != nil {
+		return nil, nil, err
+	}
+	if pending.Enrollment {
+		s.audit(ctx, authDomain.EventTwoFactorEnabled, &user.ID, user.Email, client, "enrolled at login")
+	}
+	if method == methodRecoveryCode {
+		s.audit(ctx, authDomain.EventRecoveryCodeUsed, &user.ID, user.Email, client,
+			fmt.Sprintf("%d recovery codes remaining", authDomain.RemainingRecoveryCodes(user)))
+	}
+	s.audit(ctx, authDomain.EventLoginSuccess, &user.ID, user.Email, client, pending.FirstFactor+"+"+method)
+
+	return user, recoveryCodes, nil
+}
+
+// verifySecondFactor accepts a current TOTP code or an unused recovery code and
+// records its use on the user; the caller persists the user
+func verifySecondFactor(user *userDomain.User, code string, now time.Time) (string, bool) {
+	if !user.TOTPEnabled {
+		return "", false
+	}
+	if verifyTOTP(user, code, now) {
+		return methodTOTP, true
+	}
+	if authDomain.ConsumeRecoveryCode(user, code) {
+		return methodRecoveryCode, true
+	}
+	return "", false
+}
+
+// verifyTOTP validates code against the user's secret, refusing time steps that
+// were already used so an intercepted code cannot be replayed
+func verifyTOTP(user *userDomain.User, code string, now time.Time) bool {
+	if user.TOTPSecret == "" {
+		return false
+	}
+	counter, ok := totp.Validate(user.TOTPSecret, code, now, totpSkew)
+	if !ok || counter <= user.TOTPLastCounter {
+		return false
+	}
+	user.TOTPLastCounter = counter
+	return true
+}
+
+// newEnrollment builds the enrollment data for the user's pending secret
+func newEnrollment(issuer string, user *userDomain.User) *authDomain.TOTPEnrollment {
+	return &authDomain.TOTPEnrollment{
+		Secret:          user.TOTPSecret,
+		ProvisioningURI: totp.ProvisioningURI(issuer, user.Email, user.TOTPSecret),
+	}
+}
+
+// removeChallenge discards a challenge that can no longer complete a login;
+// failures are only logged, as the challenge expires anyway
+func (s *AuthServiceImpl) removeChallenge(ctx context.Context, pending *authDomain.PendingTwoFactor) {
+	if err := s.challengeRepo.Delete(ctx, pending.ID); err != nil && !errors.Is(err, repository.ErrTwoFactorChallengeNotFound) {
+		log.Printf("Warning: failed to remove two-factor challenge: %v", err)
+	}
+}
@@ -0,0 +1,245 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	authDomain "workflow-approval/package/auth/domain"
+	userDomain "workflow-approval/package/user/domain"
+	"workflow-approval/utils"
+	"workflow-approval/utils/totp"
+)
+
+// currentCode returns the TOTP code of the user's secret right now
+func currentCode(t *testing.T, user *userDomain.User) string {
+	t.Helper()
+	code, err := totp.Code(user.TOTPSecret, utils.TimeNowUTC())
+	if err != nil {
+		t.Fatalf("Failed to compute TOTP code: %v", err)
+	}
+	return code
+}
+
+// enrollTestUser enables TOTP for the user and returns its recovery codes
+func enrollTestUser(t *testing.T, repo *MockAuthRepository, user *userDomain.User) []string {
+	t.Helper()
+	service := NewTwoFactorService(repo, NewMockLoginAttemptRepository(), NewMockAuthAuditLogRepository(), testPolicy(), authDomain.DefaultTwoFactorPolicy())
+	if _, err := service.BeginEnrollment(context.Background(), user.ID); err != nil {
+		t.Fatalf("BeginEnrollment failed: %v", err)
+	}
+	codes, err := service.ConfirmEnrollment(context.Background(), user.ID, currentCode(t, user), testClient)
+	if err != nil {
+		t.Fatalf("ConfirmEnrollment failed: %v", err)
+	}
+	// Allow the same time step to be used again by the test that follows
+	user.TOTPLastCounter = 0
+	return codes
+}
+
+func TestTwoFactorLogin(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Enrolled user must pass the second factor", func(t *testing.T) {
+		repo := NewMockAuthRepository()
+		audit := NewMockAuthAuditLogRepository()
+		user := createTestLocalUser(t, repo, "approver@example.com", "correct-password")
+		enrollTestUser(t, repo, user)
+		service := NewAuthService(repo, NewMockLoginAttemptRepository(), audit, NewMockTwoFactorChallengeRepository(), testPolicy(), authDomain.DefaultTwoFactorPolicy(), nil)
+
+		_, _, err := service.Login(ctx, "approver@example.com", "correct-password", testClient)
+		var challenge *TwoFactorChallenge
+		if !errors.As(err, &challenge) {
+			t.Fatalf("Expected TwoFactorChallenge, got %v", err)
+		}
+		if challenge.EnrollmentRequired || challenge.Token == "" {
+			t.Errorf("Unexpected challenge %+v", challenge)
+		}
+
+		if _, _, err := service.VerifyTwoFactor(ctx, challenge.Token, "000000", testClient); err != ErrTwoFactorInvalidCode {
+			t.Errorf("Expected ErrTwoFactorInvalidCode, got %v", err)
+		}
+
+		code := currentCode(t, user)
+		loggedIn, recoveryCodes, err := service.VerifyTwoFactor(ctx, challenge.Token, code, testClient)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if loggedIn.ID != user.ID || recoveryCodes != nil {
+			t.Errorf("Expected user %s without new recovery codes", user.ID)
+		}
+		if audit.count(authDomain.EventLoginSuccess) != 1 {
+			t.Errorf("Expected 1 LOGIN_SUCCESS event, got %d", audit.count(authDomain.EventLoginSuccess))
+		}
+
+		// Challenge is single use and the code cannot be replayed
+		if _, _, err := service.VerifyTwoFactor(ctx, challenge.Token, code, testClient); err != ErrTwoFactorChallengeInvalid {
+			t.Errorf("Expected ErrTwoFactorChallengeInvalid, got %v", err)
+		}
+		_, _, err = service.Login(ctx, "approver@example.com", "correct-password", testClient)
+		errors.As(err, &challenge)
+		if _, _, err := service.VerifyTwoFactor(ctx, challenge.Token, code, testClient); err != ErrTwoFactorInvalidCode {
+			t.Errorf("Expected replayed code to be rejected, got %v", err)
+		}
+	})
+
+	t.Run("Recovery code works once", func(t *testing.T) {
+		repo := NewMockAuthRepository()
+		audit := NewMockAuthAuditLogRepository()
+		user := createTestLocalUser(t, repo, "lost-phone@example.com", "correct-password")
+		codes := enrollTestUser(t, repo, user)
+		if len(codes) != authDomain.RecoveryCodeCount {
+			t.Fatalf("Expected %d recovery codes, got %d", authDomain.RecoveryCodeCount, len(codes))
+		}
+		service := NewAuthService(repo, NewMockLoginAttemptRepository(), audit, NewMockTwoFactorChallengeRepository(), testPolicy(), authDomain.DefaultTwoFactorPolicy(), nil)
+
+		var challenge *TwoFactorChallenge
+		_, _, err := service.Login(ctx, "lost-phone@example.com", "correct-password", testClient)
+		errors.As(err, &challenge)
+		if _, _, err := service.VerifyTwoFactor(ctx, challenge.Token, codes[0], testClient); err != nil {
+			t.Fatalf("Expected recovery code to be accepted, got %v", err)
+		}
+		if authDomain.RemainingRecoveryCodes(user) != authDomain.RecoveryCodeCount-1 {
+			t.Errorf("Expected recovery code to be consumed, %d remaining", authDomain.RemainingRecoveryCodes(user))
+		}
+		if audit.count(authDomain.EventRecoveryCodeUsed) != 1 {
+			t.Error("Expected RECOVERY_CODE_USED event")
+		}
+
+		_, _, err = service.Login(ctx, "lost-phone@example.com", "correct-password", testClient)
+		errors.As(err, &challenge)
+		if _, _, err := service.VerifyTwoFactor(ctx, challenge.Token, codes[0], testClient); err != ErrTwoFactorInvalidCode {
+			t.Errorf("Expected used recovery code to be rejected, got %v", err)
+		}
+	})
+
+	t.Run("Wrong codes count towards account lockout", func(t *testing.T) {
+		repo := NewMockAuthRepository()
+		user := createTestLocalUser(t, repo, "guess@example.com", "correct-password")
+		enrollTestUser(t, repo, user)
+		service := NewAuthService(repo, NewMockLoginAttemptRepository(), NewMockAuthAuditLogRepository(), NewMockTwoFactorChallengeRepository(), testPolicy(), authDomain.DefaultTwoFactorPolicy(), nil)
+
+		var challenge *TwoFactorChallenge
+		_, _, err := service.Login(ctx, "guess@example.com", "correct-password", testClient)
+		errors.As(err, &challenge)
+		for i := 0; i < 3; i++ {
+			service.VerifyTwoFactor(ctx, challenge.Token, "000000", testClient)
+		}
+		if _, _, err := service.VerifyTwoFactor(ctx, challenge.Token, currentCode(t, user), testClient); !errors.Is(err, ErrAccountLocked) {
+			t.Errorf("Expected ErrAccountLocked, got %v", err)
+		}
+	})
+
+	t.Run("Challenge completes on another instance until it expires", func(t *testing.T) {
+		repo := NewMockAuthRepository()
+		user := createTestLocalUser(t, repo, "cluster@example.com", "correct-password")
+		enrollTestUser(t, repo, user)
+		challenges := NewMockTwoFactorChallengeRepository()
+		first := NewAuthService(repo, NewMockLoginAttemptRepository(), NewMockAuthAuditLogRepository(), challenges, testPolicy(), authDomain.DefaultTwoFactorPolicy(), nil)
+		second := NewAuthService(repo, NewMockLoginAttemptRepository(), NewMockAuthAuditLogRepository(), challenges, testPolicy(), authDomain.DefaultTwoFactorPolicy(), nil)
+
+		var challenge *TwoFactorChallenge
+		_, _, err := first.Login(ctx, "cluster@example.com", "correct-password", testClient)
+		errors.As(err, &challenge)
+		for _, pending := range challenges.challenges {
+			if pending.TokenHash == challenge.Token || pending.TenantID != user.TenantID {
+				t.Errorf("Expected only the token hash stored in the user's tenant, got %+v", pending)
+			}
+		}
+		if _, _, err := second.VerifyTwoFactor(ctx, challenge.Token, currentCode(t, user), testClient); err != nil {
+			t.Fatalf("Expected the challenge completed by another instance, got %v", err)
+		}
+
+		_, _, err = first.Login(ctx, "cluster@example.com", "correct-password", testClient)
+		errors.As(err, &challenge)
+		for _, pending := range challenges.challenges {
+			pending.ExpiresAt = utils.TimeNowUTC().Add(-time.Second)
+		}
+		if _, _, err := second.VerifyTwoFactor(ctx, challenge.Token, currentCode(t, user), testClient); err != ErrTwoFactorChallengeInvalid {
+			t.Errorf("Expected ErrTwoFactorChallengeInvalid, got %v", err)
+		}
+		if len(challenges.challenges) != 0 {
+			t.Errorf("Expected the expired challenge removed, %d left", len(challenges.challenges))
+		}
+	})
+
+	t.Run("Policy enrolls admins during login", func(t *testing.T) {
+		repo := NewMockAuthRepository()
+		admin := createTestLocalUser(t, repo, "admin@example.com", "correct-password")
+		admin.IsAdmin = true
+		policy := authDomain.DefaultTwoFactorPolicy()
+		policy.RequireForAdmins = true
+		service := NewAuthService(repo, NewMockLoginAttemptRepository(), NewMockAuthAuditLogRepository(), NewMockTwoFactorChallengeRepository(), testPolicy(), policy, nil)
+
+		_, _, err := service.Login(ctx, "admin@example.com", "correct-password", testClient)
+		var challenge *TwoFactorChallenge
+		if !errors.As(err, &challenge) || !challenge.EnrollmentRequired || challenge.Enrollment == nil {
+			t.Fatalf("Expected enrollment challenge, got %v", err)
+		}
+		if challenge.Enrollment.Secret != admin.TOTPSecret {
+			t.Error("Expected enrollment secret to be stored on the user")
+		}
+
+		_, recoveryCodes, err := service.VerifyTwoFactor(ctx, challenge.Token, currentCode(t, admin), testClient)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if !admin.TOTPEnabled || len(recoveryCodes) != authDomain.RecoveryCodeCount {
+			t.Errorf("Expected TOTP enabled with recovery codes, got enabled=%v codes=%d", admin.TOTPEnabled, len(recoveryCodes))
+		}
+
+		twoFactorService := NewTwoFactorService(repo, NewMockLoginAttemptRepository(), NewMockAuthAuditLogRepository(), testPolicy(), policy)
+		if err := twoFactorService.Disable(ctx, admin.ID, recoveryCodes[0], testClient); err != ErrTwoFactorRequired {
+			t.Errorf("Expected ErrTwoFactorRequired, got %v", err)
+		}
+	})
+}
+
+func TestTwoFactorService(t *testing.T) {
+	ctx := context.Background()
+	repo := NewMockAuthRepository()
+	audit := NewMockAuthAuditLogRepository()
+	user := createTestLocalUser(t, repo, "self@example.com", "correct-password")
+	service := NewTwoFactorService(repo, NewMockLoginAttemptRepository(), audit, testPolicy(), authDomain.DefaultTwoFactorPolicy())
+
+	if _, err := service.ConfirmEnrollment(ctx, user.ID, "123456", testClient); err != ErrTwoFactorNotEnrolled {
+		t.Errorf("Expected ErrTwoFactorNotEnrolled, got %v", err)
+	}
+	if _, err := service.StepUp(ctx, user.ID, "123456", testClient); err != ErrTwoFactorNotEnabled {
+		t.Errorf("Expected ErrTwoFactorNotEnabled, got %v", err)
+	}
+
+	enrollment, err := service.BeginEnrollment(ctx, user.ID)
+	if err != nil {
+		t.Fatalf("BeginEnrollment failed: %v", err)
+	}
+	if enrollment.ProvisioningURI == "" || user.TOTPEnabled {
+		t.Error("Expected provisioning URI and TOTP not yet enabled")
+	}
+	if _, err := service.ConfirmEnrollment(ctx, user.ID, "000000", testClient); err != ErrTwoFactorInvalidCode {
+		t.Errorf("Expected ErrTwoFactorInvalidCode, got %v", err)
+	}
+	if _, err := service.ConfirmEnrollment(ctx, user.ID, currentCode(t, user), testClient); err != nil {
+		t.Fatalf("ConfirmEnrollment failed: %v", err)
+	}
+	if _, err := service.BeginEnrollment(ctx, user.ID); err != ErrTwoFactorAlreadyEnabled {
+		t.Errorf("Expected ErrTwoFactorAlreadyEnabled, got %v", err)
+	}
+
+	user.TOTPLastCounter = 0
+	if _, err := service.StepUp(ctx, user.ID, currentCode(t, user), testClient); err != nil {
+		t.Errorf("Expected step-up to succeed, got %v", err)
+	}
+	if audit.count(authDomain.EventStepUp) != 1 {
+		t.Error("Expected STEP_UP event")
+	}
+
+	user.TOTPLastCounter = 0
+	if err := service.Disable(ctx, user.ID, currentCode(t, user), testClient); err != nil {
+		t.Fatalf("Disable failed: %v", err)
+	}
+	if user.TOTPEnabled || user.TOTPSecret != "" || user.RecoveryCodes != "" {
+		t.Error("Expected two-factor data to be cleared")
+	}
+}
@@ -0,0 +1,241 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	authDomain "workflow-approval/package/auth/domain"
+	"workflow-approval/package/auth/ports"
+	"workflow-approval/package/auth/repository"
+	userDomain "workflow-approval/package/user/domain"
+	"workflow-approval/utils"
+	"workflow-approval/utils/totp"
+)
+
+// TwoFactorServiceImpl implements TwoFactorService interface
+type TwoFactorServiceImpl struct {
+	authRepo    ports.AuthRepository
+	attemptRepo ports.LoginAttemptRepository
+	auditRepo   ports.AuthAuditLogRepository
+	loginPolicy authDomain.LoginProtectionPolicy
+	policy      authDomain.TwoFactorPolicy
+}
+
+// NewTwoFactorService creates a new TwoFactorServiceImpl instance.
+// Wrong codes are throttled with the lockout thresholds of loginPolicy.
+func NewTwoFactorService(authRepo ports.AuthRepository, attemptRepo ports.LoginAttemptRepository, auditRepo ports.AuthAuditLogRepository, loginPolicy authDomain.LoginProtectionPolicy, policy authDomain.TwoFactorPolicy) ports.TwoFactorService {
+	return &TwoFactorServiceImpl{
+		authRepo:    authRepo,
+		attemptRepo: attemptRepo,
+		auditRepo:   auditRepo,
+		loginPolicy: loginPolicy,
+		policy:      policy,
+	}
+}
+
+// Status returns the user's two-factor setup
+func (s *TwoFactorServiceImpl) Status(ctx context.Context, userID string) (*authDomain.TwoFactorStatus, error) {
+	user, err := s.getUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	return &authDomain.TwoFactorStatus{
+		Enabled:                user.TOTPEnabled,
+		Required:               s.policy.RequiresEnrollment(user),
+		RecoveryCodesRemaining: authDomain.RemainingRecoveryCodes(user),
+	}, nil
+}
+
+// BeginEnrollment issues a new secret; TOTP is enabled once a code from it is confirmed
+func (s *TwoFactorServiceImpl) BeginEnrollment(ctx context.Context, userID string) (*authDomain.TOTPEnrollment, error) {
+	user, err := s.getUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if user.TOTPEnabled {
+		return nil, ErrTwoFactorAlreadyEnabled
+	}
+
+	secret, err := totp.GenerateSecret()
+	if err != nil {
+		return nil, err
+	}
+	user.TOTPSecret = secret
+	user.TOTPLastCounter = 0
+	user.UpdatedAt = utils.TimeNowUTC()
+	if err := s.authRepo.Update(ctx, user); err != nil {
+		return nil, err
+	}
+
+	return newEnrollment(s.policy.Issuer, user), nil
+}
+
+// ConfirmEnrollment enables TOTP after a valid code and returns fresh recovery codes
+func (s *TwoFactorServiceImpl) ConfirmEnrollment(ctx context.Context, userID, code string, client authDomain.ClientInfo) ([]string, error) {
+	user, err := s.getUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if user.TOTPEnabled {
+		return nil, ErrTwoFactorAlreadyEnabled
+	}
+	if user.TOTPSecret == "" {
+		return nil, ErrTwoFactorNotEnrolled
+	}
+
+	if err := s.verify(ctx, user, client, func(now time.Time) bool { return verifyTOTP(user, code, now) }); err != nil {
+		return nil, err
+	}
+
+	codes, encoded, err := authDomain.GenerateRecoveryCodes()
+	if err != nil {
+		return nil, err
+	}
+	user.TOTPEnabled = true
+	user.RecoveryCodes = encoded
+	user.UpdatedAt = utils.TimeNowUTC()
+	if err := s.authRepo.Update(ctx, user); err != nil {
+		return nil, err
+	}
+
+	s.audit(ctx, authDomain.EventTwoFactorEnabled, user, client, "")
+	return codes, nil
+}
+
+// Disable turns TOTP off after a valid code or recovery code, unless the policy requires it
+func (s *TwoFactorServiceImpl) Disable(ctx context.Context, userID, code string, client authDomain.ClientInfo) error {
+	user, err := s.getUser(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if !user.TOTPEnabled {
+		return ErrTwoFactorNotEnabled
+	}
+	if s.policy.RequiresEnrollment(user) {
+		return ErrTwoFactorRequired
+	}
+
+	if err := s.verify(ctx, user, client, func(now time.Time) bool {
+		_, ok := verifySecondFactor(user, code, now)
+		return ok
+	}); err != nil {
+		return err
+	}
+
+	user.TOTPEnabled = false
+	user.TOTPSecret = ""
+	user.TOTPLastCounter = 0
+	user.RecoveryCodes = ""
+	user.UpdatedAt = utils.TimeNowUTC()
+	if err := s.authRepo.Update(ctx, user); err != nil {
+		return err
+	}
+
+	s.audit(ctx, authDomain.EventTwoFactorDisabled, user, client, "")
+	return nil
+}
+
+// RegenerateRecoveryCodes replaces all recovery codes after a valid TOTP code
+func (s *TwoFactorServiceImpl) RegenerateRecoveryCodes(ctx context.Context, userID, code string, client authDomain.ClientInfo) ([]string, error) {
+	user, err := s.getUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !user.TOTPEnabled {
+		return nil, ErrTwoFactorNotEnabled
+	}
+
+	if err := s.verify(ctx, user, client, func(now time.Time) bool { return verifyTOTP(user, code, now) }); err != nil {
+		return nil, err
+	}
+
+	codes, encoded, err := authDomain.GenerateRecoveryCodes()
+	if err != nil {
+		return nil, err
+	}
+	user.RecoveryCodes = encoded
+	user.UpdatedAt = utils.TimeNowUTC()
+	if err := s.authRepo.Update(ctx, user); err != nil {
+		return nil, err
+	}
+
+	s.audit(ctx, authDomain.EventRecoveryCodesRegenerated, user, client, "")
+	return codes, nil
+}
+
+// StepUp re-verifies a TOTP code; the caller issues a token recording the verification time
+func (s *TwoFactorServiceImpl) StepUp(ctx context.Context, userID, code string, client authDomain.ClientInfo) (*userDomain.User, error) {
+	user, err := s.getUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !user.TOTPEnabled {
+		return nil, ErrTwoFactorNotEnabled
+	}
+
+	if err := s.verify(ctx, user, client, func(now time.Time) bool { return verifyTOTP(user, code, now) }); err != nil {
+		return nil, err
+	}
+	if err := s.authRepo.Update(ctx, user); err != nil {
+		return nil, err
+	}
+
+	s.audit(ctx, authDomain.EventStepUp, user, client, "")
+	return user, nil
+}
+
+// verify runs check while the user's second-factor attempts are not locked,
+// counting failures and locking after the login policy's account threshold
+func (s *TwoFactorServiceImpl) verify(ctx context.Context, user *userDomain.User, client authDomain.ClientInfo, check func(now time.Time) bool) error {
+	now := utils.TimeNowUTC()
+	key := authDomain.TwoFactorAttemptKey(user.ID)
+
+	attempt, err := s.attemptRepo.Get(ctx, key)
+	if err != nil {
+		return err
+	}
+	if attempt != nil && attempt.IsLocked(now) {
+		return &ThrottleError{Reason: ErrTooManyAttempts, RetryAfter: attempt.LockedUntil.Sub(now)}
+	}
+
+	if check(now) {
+		return s.attemptRepo.Reset(ctx, key)
+	}
+
+	s.audit(ctx, authDomain.EventTwoFactorFailed, user, client, "invalid two-factor code")
+	attempt, err = s.attemptRepo.RecordFailure(ctx, key, now, s.loginPolicy.FailureWindow)
+	if err != nil {
+		return err
+	}
+	if s.loginPolicy.MaxAccountFailures > 0 && attempt.Failures >= s.loginPolicy.MaxAccountFailures {
+		if err := s.attemptRepo.Lock(ctx, key, now.Add(s.loginPolicy.LockoutDuration)); err != nil {
+			return err
+		}
+		s.audit(ctx, authDomain.EventAccountLocked, user, client,
+			fmt.Sprintf("%d invalid two-factor codes, locked for %s", attempt.Failures, s.loginPolicy.LockoutDuration))
+	}
+	return ErrTwoFactorInvalidCode
+}
+
+// getUser loads a user, mapping not-found to ErrUserNotFound
+func (s *TwoFactorServiceImpl) getUser(ctx context.Context, userID string) (*userDomain.User, error) {
+	user, err := s.authRepo.GetByID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, repository.ErrUserNotFound) {
+			return nil, ErrUserNotFound
+		}
+		return nil, err
+	}
+	return user, nil
+}
+
+// audit records a security event; failures are logged but never block the operation
+func (s *TwoFactorServiceImpl) audit(ctx context.Context, event authDomain.AuthEvent, user *userDomain.User, client authDomain.ClientInfo, detail string) {
+	entry := authDomain.NewAuthAuditLog(event, &user.ID, user.Email, client, detail)
+	if err := s.auditRepo.Create(ctx, entry); err != nil {
+		log.Printf("Warning: failed to write auth audit log (%s): %v", event, err)
+	}
+}
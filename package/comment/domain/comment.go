@@ -0,0 +1,189 @@
+package domain
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"regexp"
+	"strings"
+	"time"
+
+	historyDomain "workflow-approval/package/approval_history/domain"
+	"workflow-approval/utils"
+)
+
+// Comment is a message on a request, posted without deciding on it.
+// Replies point to the top-level comment of their thread through ParentID.
+type Comment struct {
+	ID        string     `json:"id" gorm:"primaryKey;size:36"`
+	TenantID  string     `json:"tenant_id" gorm:"size:36;not null;index"`
+	RequestID string     `json:"request_id" gorm:"size:36;not null;index"`
+	ParentID  *string    `json:"parent_id" gorm:"size:36;index"` // Nil for top-level comments
+	AuthorID  string     `json:"author_id" gorm:"size:36;not null"`
+	Body      string     `json:"body" gorm:"type:text;not null"`
+	Mentions  UserIDList `json:"mentions" gorm:"type:text"` // IDs of the users @mentioned in Body
+	EditedAt  *time.Time `json:"edited_at"`
+	DeletedAt *time.Time `json:"deleted_at"` // Set when a comment with replies is deleted; the thread stays
+	CreatedAt time.Time  `json:"created_at"`
+	UpdatedAt time.Time  `json:"updated_at"`
+}
+
+// NewComment creates a new Comment instance
+func NewComment(tenantID, requestID string, parentID *string, authorID, body string, mentions []string) *Comment {
+	now := utils.TimeNowUTC()
+	return &Comment{
+		ID:        utils.GenerateUUID(),
+		TenantID:  tenantID,
+		RequestID: requestID,
+		ParentID:  parentID,
+		AuthorID:  authorID,
+		Body:      body,
+		Mentions:  mentions,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+}
+
+// TableName returns the table name for GORM
+func (Comment) TableName() string {
+	return "comments"
+}
+
+// IsDeleted checks if the comment was deleted and only remains as a thread placeholder
+func (c *Comment) IsDeleted() bool {
+	return c.DeletedAt != nil
+}
+
+// IsReply checks if the comment is a reply in another comment's thread
+func (c *Comment) IsReply() bool {
+	return c.ParentID != nil
+}
+
+// EditableAt reports whether the comment's author may still edit it at the given time
+func (c *Comment) EditableAt(now time.Time, window time.Duration) bool {
+	return !c.IsDeleted() && now.Before(c.CreatedAt.Add(window))
+}
+
+// CommentPolicy holds the limits applied to comments
+type CommentPolicy struct {
+	EditWindow time.Duration // How long after posting the author may edit
+	MaxLength  int           // Characters per comment
+}
+
+// DefaultCommentPolicy returns the policy used when nothing is configured
+func DefaultCommentPolicy() CommentPolicy {
+	return CommentPolicy{
+		EditWindow: 15 * time.Minute,
+		MaxLength:  5000,
+	}
+}
+
+// mentionPattern matches "@" followed by an email address, e.g. "@jane.doe@example.com".
+// The "@" must not be preceded by a word character so plain addresses aren't mentions.
+var mentionPattern = regexp.MustCompile(`(?:^|[^\w.+-])@([\w.+-]+@[A-Za-z0-9-]+(?:\.[A-Za-z0-9-]+)+)`)
+
+// ParseMentions returns the distinct email addresses mentioned in a comment body, lowercased
+func ParseMentions(body string) []string {
+	var emails []string
+	seen := make(map[string]bool)
+	for _, match := range mentionPattern.FindAllStringSubmatch(body, -1) {
+		email := strings.ToLower(match[1])
+		if !seen[email] {
+			seen[email] = true
+			emails = append(emails, email)
+		}
+	}
+	return emails
+}
+
+// CommentThread is a top-level comment with its replies, oldest first
+type CommentThread struct {
+	Comment *Comment
+	Replies []*Comment
+}
+
+// BuildThreads groups comments into threads. Deleted comments are dropped
+// unless they still have replies to hold together.
+func BuildThreads(comments []*Comment) []*CommentThread {
+	var threads []*CommentThread
+	byID := make(map[string]*CommentThread)
+	for _, c := range comments {
+		if !c.IsReply() {
+			thread := &CommentThread{Comment: c}
+			byID[c.ID] = thread
+			threads = append(threads, thread)
+		}
+	}
+	for _, c := range comments {
+		if !c.IsReply() || c.IsDeleted() {
+			continue
+		}
+		if thread, ok := byID[*c.ParentID]; ok {
+			thread.Replies = append(thread.Replies, c)
+		}
+	}
+
+	visible := threads[:0]
+	for _, thread := range threads {
+		if !thread.Comment.IsDeleted() || len(thread.Replies) > 0 {
+			visible = append(visible, thread)
+		}
+	}
+	return visible
+}
+
+// TimelineEntryType identifies the kind of a timeline entry
+type TimelineEntryType string
+
+const (
+	TimelineRequestCreated   TimelineEntryType = "REQUEST_CREATED"
+	TimelineRequestSubmitted TimelineEntryType = "REQUEST_SUBMITTED" // A draft was sent for approval
+	TimelineComment          TimelineEntryType = "COMMENT"
+	TimelineApproval         TimelineEntryType = "APPROVAL" // Approve or reject decision
+)
+
+// TimelineEntry is one event in a request's activity: its creation or
+// submission, a comment or an approval decision. Exactly one of Comment and Approval is set
+// for the matching types.
+type TimelineEntry struct {
+	Type     TimelineEntryType
+	At       time.Time
+	UserID   string
+	Comment  *Comment
+	Approval *historyDomain.ApprovalHistory
+}
+
+// UserIDList is a list of user IDs stored as a JSON array in a text column
+type UserIDList []string
+
+// Value implements driver.Valuer interface for GORM
+func (l UserIDList) Value() (driver.Value, error) {
+	if l == nil {
+		return []byte(`[]`), nil
+	}
+	return json.Marshal(l)
+}
+
+// Scan implements sql.Scanner interface for GORM
+func (l *UserIDList) Scan(value interface{}) error {
+	if value == nil {
+		*l = UserIDList{}
+		return nil
+	}
+
+	var bytes []byte
+	switch v := value.(type) {
+	case []byte:
+		bytes = v
+	case string:
+		bytes = []byte(v)
+	default:
+		return errors.New("type assertion to []byte or string failed")
+	}
+
+	if len(bytes) == 0 {
+		*l = UserIDList{}
+		return nil
+	}
+	return json.Unmarshal(bytes, l)
+}
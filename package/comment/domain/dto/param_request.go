@@ -0,0 +1,12 @@
+package dto
+
+// CreateCommentRequest represents the create comment request body
+type CreateCommentRequest struct {
+	Body     string  `json:"body"`
+	ParentID *string `json:"parent_id"` // Replies to this comment's thread
+}
+
+// UpdateCommentRequest represents the update comment request body
+type UpdateCommentRequest struct {
+	Body string `json:"body"`
+}
@@ -0,0 +1,85 @@
+package dto
+
+import (
+	historyDto "workflow-approval/package/approval_history/domain/dto"
+	"workflow-approval/package/comment/domain"
+)
+
+// CommentResponse represents the comment response
+type CommentResponse struct {
+	ID        string             `json:"id"`
+	RequestID string             `json:"request_id"`
+	ParentID  *string            `json:"parent_id"`
+	AuthorID  string             `json:"author_id"`
+	Body      string             `json:"body"`
+	Mentions  []string           `json:"mentions"`
+	Edited    bool               `json:"edited"`
+	Deleted   bool               `json:"deleted"`
+	CreatedAt string             `json:"created_at"`
+	UpdatedAt string             `json:"updated_at"`
+	Replies   []*CommentResponse `json:"replies,omitempty"`
+}
+
+// ToCommentResponse converts a Comment to CommentResponse
+func ToCommentResponse(c *domain.Comment) *CommentResponse {
+	if c == nil {
+		return nil
+	}
+	mentions := []string(c.Mentions)
+	if mentions == nil {
+		mentions = []string{}
+	}
+	return &CommentResponse{
+		ID:        c.ID,
+		RequestID: c.RequestID,
+		ParentID:  c.ParentID,
+		AuthorID:  c.AuthorID,
+		Body:      c.Body,
+		Mentions:  mentions,
+		Edited:    c.EditedAt != nil,
+		Deleted:   c.IsDeleted(),
+		CreatedAt: c.CreatedAt.Format("2006-01-02T15:04:05Z"),
+		UpdatedAt: c.UpdatedAt.Format("2006-01-02T15:04:05Z"),
+	}
+}
+
+// ToThreadResponseList converts comment threads to top-level CommentResponses with their replies
+func ToThreadResponseList(threads []*domain.CommentThread) []*CommentResponse {
+	responses := make([]*CommentResponse, len(threads))
+	for i, thread := range threads {
+		response := ToCommentResponse(thread.Comment)
+		response.Replies = make([]*CommentResponse, len(thread.Replies))
+		for j, reply := range thread.Replies {
+			response.Replies[j] = ToCommentResponse(reply)
+		}
+		responses[i] = response
+	}
+	return responses
+}
+
+// TimelineEntryResponse represents one event of a request's activity timeline
+type TimelineEntryResponse struct {
+	Type      string                              `json:"type"`
+	UserID    string                              `json:"user_id"`
+	CreatedAt string                              `json:"created_at"`
+	Comment   *CommentResponse                    `json:"comment,omitempty"`
+	Approval  *historyDto.ApprovalHistoryResponse `json:"approval,omitempty"`
+}
+
+// ToTimelineResponseList converts timeline entries to TimelineEntryResponse
+func ToTimelineResponseList(entries []*domain.TimelineEntry) []*TimelineEntryResponse {
+	responses := make([]*TimelineEntryResponse, len(entries))
+	for i, e := range entries {
+		response := &TimelineEntryResponse{
+			Type:      string(e.Type),
+			UserID:    e.UserID,
+			CreatedAt: e.At.Format("2006-01-02T15:04:05Z"),
+			Comment:   ToCommentResponse(e.Comment),
+		}
+		if e.Approval != nil {
+			response.Approval = historyDto.ToApprovalHistoryResponse(e.Approval, "", "", "", "")
+		}
+		responses[i] = response
+	}
+	return responses
+}
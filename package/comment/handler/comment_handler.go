@@ -0,0 +1,205 @@
+package handler
+
+import (
+	"errors"
+
+	"github.com/gofiber/fiber/v2"
+
+	"workflow-approval/framework/middleware"
+	"workflow-approval/package/comment/domain/dto"
+	"workflow-approval/package/comment/ports"
+	"workflow-approval/package/comment/usecase"
+	reqPorts "workflow-approval/package/request/ports"
+)
+
+// CommentHandler handles HTTP requests for request comments and the activity timeline
+type CommentHandler struct {
+	commentService ports.CommentService
+	requestService reqPorts.RequestService
+}
+
+// NewCommentHandler creates a new CommentHandler instance
+func NewCommentHandler(commentService ports.CommentService, requestService reqPorts.RequestService) *CommentHandler {
+	return &CommentHandler{
+		commentService: commentService,
+		requestService: requestService,
+	}
+}
+
+// Routes defines all routes for comments
+// Mounts routes under /api/requests/:id/comments
+func (h *CommentHandler) Routes(group fiber.Router) {
+	// GET /api/requests/:id/comments - List comment threads
+	group.Get("", h.List)
+
+	// POST /api/requests/:id/comments - Post a comment or a reply (parent_id)
+	group.Post("", h.Create)
+
+	// PUT /api/requests/:id/comments/:commentId - Edit a comment (author, within the edit window)
+	group.Put("/:commentId", h.Update)
+
+	// DELETE /api/requests/:id/comments/:commentId - Delete a comment (author or admin)
+	group.Delete("/:commentId", h.Delete)
+}
+
+// TimelineRoutes defines the activity timeline route
+// Mounts routes under /api/requests
+func (h *CommentHandler) TimelineRoutes(group fiber.Router) {
+	// GET /api/requests/:id/timeline - Creation, comments and approval decisions, oldest first
+	group.Get("/:id/timeline", h.Timeline)
+}
+
+// List handles listing the comment threads of a request
+// GET /api/requests/:id/comments
+func (h *CommentHandler) List(c *fiber.Ctx) error {
+	requestID := c.Params("id")
+	if !middleware.RequestVisible(c, h.requestService, requestID) {
+		return commentError(c, usecase.ErrRequestNotFound)
+	}
+
+	threads, err := h.commentService.ListThreads(c.Context(), requestID)
+	if err != nil {
+		return commentError(c, err)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"success": true,
+		"data": fiber.Map{
+			"request_id": requestID,
+			"comments":   dto.ToThreadResponseList(threads),
+		},
+		"error": nil,
+	})
+}
+
+// Create handles posting a comment
+// POST /api/requests/:id/comments
+func (h *CommentHandler) Create(c *fiber.Ctx) error {
+	requestID := c.Params("id")
+	if !middleware.RequestVisible(c, h.requestService, requestID) {
+		return commentError(c, usecase.ErrRequestNotFound)
+	}
+
+	var req dto.CreateCommentRequest
+	if err := c.BodyParser(&req); err != nil {
+		return invalidBody(c)
+	}
+
+	userID := c.Locals("user_id").(string)
+	comment, err := h.commentService.CreateComment(c.Context(), requestID, userID, req.Body, req.ParentID)
+	if err != nil {
+		return commentError(c, err)
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
+		"success": true,
+		"data":    dto.ToCommentResponse(comment),
+		"error":   nil,
+	})
+}
+
+// Update handles editing a comment
+// PUT /api/requests/:id/comments/:commentId
+func (h *CommentHandler) Update(c *fiber.Ctx) error {
+	requestID := c.Params("id")
+	if !middleware.RequestVisible(c, h.requestService, requestID) {
+		return commentError(c, usecase.ErrRequestNotFound)
+	}
+
+	var req dto.UpdateCommentRequest
+	if err := c.BodyParser(&req); err != nil {
+		return invalidBody(c)
+	}
+
+	userID := c.Locals("user_id").(string)
+	comment, err := h.commentService.UpdateComment(c.Context(), requestID, c.Params("commentId"), userID, req.Body)
+	if err != nil {
+		return commentError(c, err)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"success": true,
+		"data":    dto.ToCommentResponse(comment),
+		"error":   nil,
+	})
+}
+
+// Delete handles deleting a comment
+// DELETE /api/requests/:id/comments/:commentId
+func (h *CommentHandler) Delete(c *fiber.Ctx) error {
+	requestID := c.Params("id")
+	if !middleware.RequestVisible(c, h.requestService, requestID) {
+		return commentError(c, usecase.ErrRequestNotFound)
+	}
+
+	userID := c.Locals("user_id").(string)
+	isAdmin, _ := c.Locals("is_admin").(bool)
+	if err := h.commentService.DeleteComment(c.Context(), requestID, c.Params("commentId"), userID, isAdmin); err != nil {
+		return commentError(c, err)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"success": true,
+		"data":    fiber.Map{"message": "Comment deleted successfully"},
+		"error":   nil,
+	})
+}
+
+// Timeline handles retrieving the activity timeline of a request
+// GET /api/requests/:id/timeline
+func (h *CommentHandler) Timeline(c *fiber.Ctx) error {
+	requestID := c.Params("id")
+	if !middleware.RequestVisible(c, h.requestService, requestID) {
+		return commentError(c, usecase.ErrRequestNotFound)
+	}
+
+	entries, err := h.commentService.GetTimeline(c.Context(), requestID)
+	if err != nil {
+		return commentError(c, err)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"success": true,
+		"data": fiber.Map{
+			"request_id": requestID,
+			"timeline":   dto.ToTimelineResponseList(entries),
+		},
+		"error": nil,
+	})
+}
+
+// invalidBody responds to a request body that could not be parsed
+func invalidBody(c *fiber.Ctx) error {
+	return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+		"success": false,
+		"data":    nil,
+		"error":   "Invalid request body",
+	})
+}
+
+// commentError maps comment service errors to HTTP responses
+func commentError(c *fiber.Ctx, err error) error {
+	status := fiber.StatusInternalServerError
+	message := "Failed to process comment"
+	switch {
+	case errors.Is(err, usecase.ErrRequestNotFound),
+		errors.Is(err, usecase.ErrCommentNotFound):
+		status, message = fiber.StatusNotFound, err.Error()
+	case errors.Is(err, usecase.ErrCommentEmpty),
+		errors.Is(err, usecase.ErrCommentTooLong),
+		errors.Is(err, usecase.ErrParentNotFound):
+		status, message = fiber.StatusBadRequest, err.Error()
+	case errors.Is(err, usecase.ErrNotCommentAuthor),
+		errors.Is(err, usecase.ErrCommentDeleteDenied):
+		status, message = fiber.StatusForbidden, err.Error()
+	case errors.Is(err, usecase.ErrEditWindowExpired),
+		errors.Is(err, usecase.ErrCommentDeleted):
+		status, message = fiber.StatusConflict, err.Error()
+	}
+
+	return c.Status(status).JSON(fiber.Map{
+		"success": false,
+		"data":    nil,
+		"error":   message,
+	})
+}
@@ -0,0 +1,53 @@
+package ports
+
+import (
+	"context"
+
+	historyDomain "workflow-approval/package/approval_history/domain"
+	"workflow-approval/package/comment/domain"
+	userDomain "workflow-approval/package/user/domain"
+)
+
+// CommentRepository defines the interface for comment data access
+type CommentRepository interface {
+	Create(ctx context.Context, comment *domain.Comment) error
+	GetByID(ctx context.Context, id string) (*domain.Comment, error)
+	// ListByRequestID returns all comments of a request, deleted ones included, oldest first
+	ListByRequestID(ctx context.Context, requestID string) ([]*domain.Comment, error)
+	Update(ctx context.Context, comment *domain.Comment) error
+	Delete(ctx context.Context, id string) error
+	CountReplies(ctx context.Context, id string) (int64, error)
+	DeleteByRequestID(ctx context.Context, requestID string) error
+}
+
+// UserRepository defines the user lookups needed to resolve mentions
+type UserRepository interface {
+	GetByID(ctx context.Context, id string) (*userDomain.User, error)
+	GetByEmail(ctx context.Context, email string) (*userDomain.User, error)
+}
+
+// ApprovalHistoryRepository defines the history lookups merged into the timeline
+type ApprovalHistoryRepository interface {
+	GetByRequestIDOrdered(ctx context.Context, requestID string) ([]*historyDomain.ApprovalHistory, error)
+}
+
+// MentionNotifier tells users they were mentioned (implemented by the notification service)
+type MentionNotifier interface {
+	NotifyMention(ctx context.Context, tenantID, userID, requestID, commentID, actorID, message string) error
+}
+
+// CommentService defines the interface for comment business logic.
+// Mentions ("@" followed by a user's email) notify the mentioned users.
+type CommentService interface {
+	// CreateComment posts a comment; with parentID it replies in that comment's thread
+	CreateComment(ctx context.Context, requestID, authorID, body string, parentID *string) (*domain.Comment, error)
+	// UpdateComment changes the body; only the author may, within the policy's edit window
+	UpdateComment(ctx context.Context, requestID, id, authorID, body string) (*domain.Comment, error)
+	// DeleteComment removes a comment; only its author or an admin may
+	DeleteComment(ctx context.Context, requestID, id, userID string, isAdmin bool) error
+	ListThreads(ctx context.Context, requestID string) ([]*domain.CommentThread, error)
+	// GetTimeline merges the request's creation, comments and approval history, oldest first
+	GetTimeline(ctx context.Context, requestID string) ([]*domain.TimelineEntry, error)
+	// PurgeRequest deletes all comments of a request that is being deleted (implements reqPorts.RequestPurgeHook)
+	PurgeRequest(ctx context.Context, requestID string) error
+}
@@ -0,0 +1,78 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"gorm.io/gorm"
+
+	"workflow-approval/package/comment/domain"
+	"workflow-approval/package/comment/ports"
+)
+
+var ErrCommentNotFound = errors.New("comment not found")
+
+// CommentRepositoryImpl implements CommentRepository interface
+type CommentRepositoryImpl struct {
+	db *gorm.DB
+}
+
+// NewCommentRepository creates a new CommentRepositoryImpl instance
+func NewCommentRepository(db *gorm.DB) ports.CommentRepository {
+	return &CommentRepositoryImpl{db: db}
+}
+
+// Create creates a new comment
+func (r *CommentRepositoryImpl) Create(ctx context.Context, comment *domain.Comment) error {
+	return r.db.WithContext(ctx).Create(comment).Error
+}
+
+// GetByID retrieves a comment by ID
+func (r *CommentRepositoryImpl) GetByID(ctx context.Context, id string) (*domain.Comment, error) {
+	var comment domain.Comment
+	result := r.db.WithContext(ctx).First(&comment, "id = ?", id)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, ErrCommentNotFound
+		}
+		return nil, result.Error
+	}
+	return &comment, nil
+}
+
+// ListByRequestID retrieves the comments of a request, oldest first
+func (r *CommentRepositoryImpl) ListByRequestID(ctx context.Context, requestID string) ([]*domain.Comment, error) {
+	var comments []*domain.Comment
+	result := r.db.WithContext(ctx).
+		Where("request_id = ?", requestID).
+		Order("created_at ASC").
+		Find(&comments)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return comments, nil
+}
+
+// Update updates a comment
+func (r *CommentRepositoryImpl) Update(ctx context.Context, comment *domain.Comment) error {
+	return r.db.WithContext(ctx).Save(comment).Error
+}
+
+// Delete deletes a comment by ID
+func (r *CommentRepositoryImpl) Delete(ctx context.Context, id string) error {
+	return r.db.WithContext(ctx).Delete(&domain.Comment{}, "id = ?", id).Error
+}
+
+// CountReplies counts the remaining replies of a comment
+func (r *CommentRepositoryImpl) CountReplies(ctx context.Context, id string) (int64, error) {
+	var count int64
+	err := r.db.WithContext(ctx).Model(&domain.Comment{}).
+		Where("parent_id = ? AND deleted_at IS NULL", id).
+		Count(&count).Error
+	return count, err
+}
+
+// DeleteByRequestID deletes all comments of a request
+func (r *CommentRepositoryImpl) DeleteByRequestID(ctx context.Context, requestID string) error {
+	return r.db.WithContext(ctx).Delete(&domain.Comment{}, "request_id = ?", requestID).Error
+}
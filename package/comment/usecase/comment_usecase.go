@@ -0,0 +1,341 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"workflow-approval/package/comment/domain"
+	"workflow-approval/package/comment/ports"
+	"workflow-approval/package/comment/repository"
+	reqDomain "workflow-approval/package/request/domain"
+	reqPorts "workflow-approval/package/request/ports"
+	reqRepo "workflow-approval/package/request/repository"
+	"workflow-approval/utils"
+)
+
+var (
+	ErrCommentNotFound     = errors.New("comment not found")
+	ErrRequestNotFound     = errors.New("request not found")
+	ErrParentNotFound      = errors.New("parent comment not found on this request")
+	ErrCommentEmpty        = errors.New("comment body is required")
+	ErrCommentTooLong      = errors.New("comment body is too long")
+	ErrNotCommentAuthor    = errors.New("only the author can change this comment")
+	ErrEditWindowExpired   = errors.New("comment can no longer be edited")
+	ErrCommentDeleted      = errors.New("comment has been deleted")
+	ErrCommentDeleteDenied = errors.New("only the author or an admin can delete this comment")
+)
+
+// CommentServiceImpl implements CommentService interface
+type CommentServiceImpl struct {
+	commentRepo ports.CommentRepository
+	requestRepo reqPorts.RequestRepository
+	historyRepo ports.ApprovalHistoryRepository
+	userRepo    ports.UserRepository
+	notifier    ports.MentionNotifier
+	policy      domain.CommentPolicy
+}
+
+// NewCommentService creates a new CommentServiceImpl instance
+func NewCommentService(
+	commentRepo ports.CommentRepository,
+	requestRepo reqPorts.RequestRepository,
+	historyRepo ports.ApprovalHistoryRepository,
+	userRepo ports.UserRepository,
+	notifier ports.MentionNotifier,
+	policy domain.CommentPolicy,
+) ports.CommentService {
+	return &CommentServiceImpl{
+		commentRepo: commentRepo,
+		requestRepo: requestRepo,
+		historyRepo: historyRepo,
+		userRepo:    userRepo,
+		notifier:    notifier,
+		policy:      policy,
+	}
+}
+
+// CreateComment posts a comment or a reply and notifies the mentioned users.
+// Replies to a reply join the thread of its top-level comment.
+func (s *CommentServiceImpl) CreateComment(ctx context.Context, requestID, authorID, body string, parentID *string) (*domain.Comment, error) {
+	body, err := s.checkBody(body)
+	if err != nil {
+		return nil, err
+	}
+
+	request, err := s.getRequest(ctx, requestID)
+	if err != nil {
+		return nil, err
+	}
+
+	if parentID != nil {
+		parent, err := s.get(ctx, requestID, *parentID)
+		if err != nil {
+			if errors.Is(err, ErrCommentNotFound) {
+				return nil, ErrParentNotFound
+			}
+			return nil, err
+		}
+		if parent.IsDeleted() {
+			return nil, ErrParentNotFound
+		}
+		if parent.IsReply() {
+			parentID = parent.ParentID
+		}
+	}
+
+	mentions := s.resolveMentions(ctx, body)
+	comment := domain.NewComment(request.TenantID, requestID, parentID, authorID, body, mentions)
+	if err := s.commentRepo.Create(ctx, comment); err != nil {
+		return nil, err
+	}
+
+	s.notifyMentions(ctx, request, comment, mentions)
+	return comment, nil
+}
+
+// UpdateComment changes a comment's body within the edit window; users
+// mentioned for the first time are notified
+func (s *CommentServiceImpl) UpdateComment(ctx context.Context, requestID, id, authorID, body string) (*domain.Comment, error) {
+	body, err := s.checkBody(body)
+	if err != nil {
+		return nil, err
+	}
+
+	request, err := s.getRequest(ctx, requestID)
+	if err != nil {
+		return nil, err
+	}
+
+	comment, err := s.get(ctx, requestID, id)
+	if err != nil {
+		return nil, err
+	}
+	if comment.IsDeleted() {
+		return nil, ErrCommentDeleted
+	}
+	if comment.AuthorID != authorID {
+		return nil, ErrNotCommentAuthor
+	}
+	now := utils.TimeNowUTC()
+	if !comment.EditableAt(now, s.policy.EditWindow) {
+		return nil, ErrEditWindowExpired
+	}
+
+	mentions := s.resolveMentions(ctx, body)
+	var added []string
+	for _, userID := range mentions {
+		if !contains(comment.Mentions, userID) {
+			added = append(added, userID)
+		}
+	}
+
+	comment.Body = body
+	comment.Mentions = mentions
+	comment.EditedAt = &now
+	comment.UpdatedAt = now
+	if err := s.commentRepo.Update(ctx, comment); err != nil {
+		return nil, err
+	}
+
+	s.notifyMentions(ctx, request, comment, added)
+	return comment, nil
+}
+
+// DeleteComment removes a comment. A comment that still has replies is
+// blanked instead so its thread stays readable.
+func (s *CommentServiceImpl) DeleteComment(ctx context.Context, requestID, id, userID string, isAdmin bool) error {
+	if _, err := s.getRequest(ctx, requestID); err != nil {
+		return err
+	}
+
+	comment, err := s.get(ctx, requestID, id)
+	if err != nil {
+		return err
+	}
+	if comment.IsDeleted() {
+		return ErrCommentNotFound
+	}
+	if comment.AuthorID != userID && !isAdmin {
+		return ErrCommentDeleteDenied
+	}
+
+	replies, err := s.commentRepo.CountReplies(ctx, comment.ID)
+	if err != nil {
+		return err
+	}
+	if replies == 0 {
+		return s.commentRepo.Delete(ctx, comment.ID)
+	}
+
+	now := utils.TimeNowUTC()
+	comment.Body = ""
+	comment.Mentions = domain.UserIDList{}
+	comment.DeletedAt = &now
+	comment.UpdatedAt = now
+	return s.commentRepo.Update(ctx, comment)
+}
+
+// ListThreads retrieves the comment threads of a request
+func (s *CommentServiceImpl) ListThreads(ctx context.Context, requestID string) ([]*domain.CommentThread, error) {
+	if _, err := s.getRequest(ctx, requestID); err != nil {
+		return nil, err
+	}
+
+	comments, err := s.commentRepo.ListByRequestID(ctx, requestID)
+	if err != nil {
+		return nil, err
+	}
+	return domain.BuildThreads(comments), nil
+}
+
+// GetTimeline retrieves the activity of a request: its creation, submission of a draft, comments and approval decisions
+func (s *CommentServiceImpl) GetTimeline(ctx context.Context, requestID string) ([]*domain.TimelineEntry, error) {
+	request, err := s.getRequest(ctx, requestID)
+	if err != nil {
+		return nil, err
+	}
+
+	comments, err := s.commentRepo.ListByRequestID(ctx, requestID)
+	if err != nil {
+		return nil, err
+	}
+	histories, err := s.historyRepo.GetByRequestIDOrdered(ctx, requestID)
+	if err != nil {
+		return nil, err
+	}
+
+	entries := make([]*domain.TimelineEntry, 0, 1+len(comments)+len(histories))
+	entries = append(entries, &domain.TimelineEntry{
+		Type:   domain.TimelineRequestCreated,
+		At:     request.CreatedAt,
+		UserID: request.RequesterID,
+	})
+	if request.SubmittedAt != nil && request.SubmittedAt.After(request.CreatedAt) {
+		entries = append(entries, &domain.TimelineEntry{
+			Type:   domain.TimelineRequestSubmitted,
+			At:     *request.SubmittedAt,
+			UserID: request.RequesterID,
+		})
+	}
+	for _, c := range comments {
+		if c.IsDeleted() {
+			continue
+		}
+		entries = append(entries, &domain.TimelineEntry{
+			Type:    domain.TimelineComment,
+			At:      c.CreatedAt,
+			UserID:  c.AuthorID,
+			Comment: c,
+		})
+	}
+	for _, h := range histories {
+		entries = append(entries, &domain.TimelineEntry{
+			Type:     domain.TimelineApproval,
+			At:       h.CreatedAt,
+			UserID:   h.UserID,
+			Approval: h,
+		})
+	}
+
+	// Stable, so events recorded in the same second keep creation, comment, decision order
+	sort.SliceStable(entries, func(i, j int) bool {
+		return entries[i].At.Before(entries[j].At)
+	})
+	return entries, nil
+}
+
+// PurgeRequest deletes the comments of a request that is being deleted
+func (s *CommentServiceImpl) PurgeRequest(ctx context.Context, requestID string) error {
+	return s.commentRepo.DeleteByRequestID(ctx, requestID)
+}
+
+// checkBody trims a comment body and checks it against the policy
+func (s *CommentServiceImpl) checkBody(body string) (string, error) {
+	body = strings.TrimSpace(body)
+	if body == "" {
+		return "", ErrCommentEmpty
+	}
+	if s.policy.MaxLength > 0 && utf8.RuneCountInString(body) > s.policy.MaxLength {
+		return "", ErrCommentTooLong
+	}
+	return body, nil
+}
+
+// getRequest loads the request a comment belongs to
+func (s *CommentServiceImpl) getRequest(ctx context.Context, requestID string) (*reqDomain.Request, error) {
+	request, err := s.requestRepo.GetByID(ctx, requestID)
+	if err != nil {
+		if errors.Is(err, reqRepo.ErrRequestNotFound) {
+			return nil, ErrRequestNotFound
+		}
+		return nil, err
+	}
+	return request, nil
+}
+
+// get loads a comment and checks it belongs to the request
+func (s *CommentServiceImpl) get(ctx context.Context, requestID, id string) (*domain.Comment, error) {
+	comment, err := s.commentRepo.GetByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, repository.ErrCommentNotFound) {
+			return nil, ErrCommentNotFound
+		}
+		return nil, err
+	}
+	if comment.RequestID != requestID {
+		return nil, ErrCommentNotFound
+	}
+	return comment, nil
+}
+
+// resolveMentions maps the emails mentioned in body to active users; unknown
+// addresses are left as plain text
+func (s *CommentServiceImpl) resolveMentions(ctx context.Context, body string) domain.UserIDList {
+	mentions := domain.UserIDList{}
+	for _, email := range domain.ParseMentions(body) {
+		user, err := s.userRepo.GetByEmail(ctx, email)
+		if err != nil || !user.IsActive {
+			continue
+		}
+		mentions = append(mentions, user.ID)
+	}
+	return mentions
+}
+
+// notifyMentions notifies mentioned users other than the author. Failures are
+// logged: the comment itself is already saved.
+func (s *CommentServiceImpl) notifyMentions(ctx context.Context, request *reqDomain.Request, comment *domain.Comment, userIDs []string) {
+	if s.notifier == nil || len(userIDs) == 0 {
+		return
+	}
+
+	author := "Someone"
+	if user, err := s.userRepo.GetByID(ctx, comment.AuthorID); err == nil {
+		author = user.Name
+	}
+	message := fmt.Sprintf("%s mentioned you on request %q", author, request.Title)
+
+	for _, userID := range userIDs {
+		if userID == comment.AuthorID {
+			continue
+		}
+		if err := s.notifier.NotifyMention(ctx, request.TenantID, userID, request.ID, comment.ID, comment.AuthorID, message); err != nil {
+			log.Printf("Warning: failed to notify user %s of mention in comment %s: %v", userID, comment.ID, err)
+		}
+	}
+}
+
+// contains reports whether ids holds id
+func contains(ids []string, id string) bool {
+	for _, v := range ids {
+		if v == id {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,330 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"testing"
+	"time"
+
+	historyDomain "workflow-approval/package/approval_history/domain"
+	"workflow-approval/package/comment/domain"
+	"workflow-approval/package/comment/repository"
+	reqDomain "workflow-approval/package/request/domain"
+	reqRepo "workflow-approval/package/request/repository"
+	userDomain "workflow-approval/package/user/domain"
+	userRepo "workflow-approval/package/user/repository"
+	"workflow-approval/utils/pagination"
+)
+
+// mockCommentRepository keeps comments in memory
+type mockCommentRepository struct {
+	comments map[string]*domain.Comment
+}
+
+func (m *mockCommentRepository) Create(ctx context.Context, comment *domain.Comment) error {
+	m.comments[comment.ID] = comment
+	return nil
+}
+
+func (m *mockCommentRepository) GetByID(ctx context.Context, id string) (*domain.Comment, error) {
+	if c, ok := m.comments[id]; ok {
+		return c, nil
+	}
+	return nil, repository.ErrCommentNotFound
+}
+
+func (m *mockCommentRepository) ListByRequestID(ctx context.Context, requestID string) ([]*domain.Comment, error) {
+	var result []*domain.Comment
+	for _, c := range m.comments {
+		if c.RequestID == requestID {
+			result = append(result, c)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].CreatedAt.Before(result[j].CreatedAt) })
+	return result, nil
+}
+
+func (m *mockCommentRepository) Update(ctx context.Context, comment *domain.Comment) error {
+	m.comments[comment.ID] = comment
+	return nil
+}
+
+func (m *mockCommentRepository) Delete(ctx context.Context, id string) error {
+	delete(m.comments, id)
+	return nil
+}
+
+func (m *mockCommentRepository) CountReplies(ctx context.Context, id string) (int64, error) {
+	var count int64
+	for _, c := range m.comments {
+		if c.ParentID != nil && *c.ParentID == id && !c.IsDeleted() {
+			count++
+		}
+	}
+	return count, nil
+}
+
+func (m *mockCommentRepository) DeleteByRequestID(ctx context.Context, requestID string) error {
+	for id, c := range m.comments {
+		if c.RequestID == requestID {
+			delete(m.comments, id)
+		}
+	}
+	return nil
+}
+
+// mockRequestRepository serves fixed requests
+type mockRequestRepository struct {
+	requests map[string]*reqDomain.Request
+}
+
+func (m *mockRequestRepository) Create(ctx context.Context, request *reqDomain.Request) error {
+	return nil
+}
+
+func (m *mockRequestRepository) GetByID(ctx context.Context, id string) (*reqDomain.Request, error) {
+	if r, ok := m.requests[id]; ok {
+		return r, nil
+	}
+	return nil, reqRepo.ErrRequestNotFound
+}
+
+func (m *mockRequestRepository) GetByIDForUpdate(ctx context.Context, id string) (*reqDomain.Request, error) {
+	return m.GetByID(ctx, id)
+}
+
+func (m *mockRequestRepository) Update(ctx context.Context, request *reqDomain.Request) error {
+	return nil
+}
+
+func (m *mockRequestRepository) Delete(ctx context.Context, id string) error {
+	return nil
+}
+
+func (m *mockRequestRepository) List(ctx context.Context, filter reqDomain.RequestFilter, page pagination.Page) ([]*reqDomain.Request, pagination.Result, error) {
+	return nil, pagination.Result{}, nil
+}
+
+func (m *mockRequestRepository) ListDraftsUpdatedBefore(ctx context.Context, before time.Time, limit int) ([]*reqDomain.Request, error) {
+	return nil, nil
+}
+
+func (m *mockRequestRepository) CountPendingByWorkflow(ctx context.Context) (map[string]int64, error) {
+	return nil, nil
+}
+
+// mockHistoryRepository serves fixed approval history
+type mockHistoryRepository struct {
+	histories []*historyDomain.ApprovalHistory
+}
+
+func (m *mockHistoryRepository) GetByRequestIDOrdered(ctx context.Context, requestID string) ([]*historyDomain.ApprovalHistory, error) {
+	return m.histories, nil
+}
+
+// mockUserRepository serves fixed users
+type mockUserRepository struct {
+	users []*userDomain.User
+}
+
+func (m *mockUserRepository) GetByID(ctx context.Context, id string) (*userDomain.User, error) {
+	for _, u := range m.users {
+		if u.ID == id {
+			return u, nil
+		}
+	}
+	return nil, userRepo.ErrUserNotFound
+}
+
+func (m *mockUserRepository) GetByEmail(ctx context.Context, email string) (*userDomain.User, error) {
+	for _, u := range m.users {
+		if u.Email == email {
+			return u, nil
+		}
+	}
+	return nil, userRepo.ErrUserNotFound
+}
+
+// mockNotifier records the mentions it is told about
+type mockNotifier struct {
+	notified []string
+	messages []string
+}
+
+func (m *mockNotifier) NotifyMention(ctx context.Context, tenantID, userID, requestID, commentID, actorID, message string) error {
+	m.notified = append(m.notified, userID)
+	m.messages = append(m.messages, message)
+	return nil
+}
+
+func newTestCommentService() (*CommentServiceImpl, *mockCommentRepository, *mockHistoryRepository, *mockNotifier) {
+	comments := &mockCommentRepository{comments: map[string]*domain.Comment{}}
+	history := &mockHistoryRepository{}
+	notifier := &mockNotifier{}
+	requests := &mockRequestRepository{requests: map[string]*reqDomain.Request{
+		"req-1": {ID: "req-1", TenantID: "tenant-1", Title: "Laptop", RequesterID: "user-alice", Status: reqDomain.StatusPending, CreatedAt: time.Now().Add(-time.Hour)},
+		"req-2": {ID: "req-2", TenantID: "tenant-1", Title: "Travel", RequesterID: "user-alice", Status: reqDomain.StatusPending},
+	}}
+	users := &mockUserRepository{users: []*userDomain.User{
+		{ID: "user-alice", Email: "alice@example.com", Name: "Alice", IsActive: true},
+		{ID: "user-bob", Email: "bob@example.com", Name: "Bob", IsActive: true},
+		{ID: "user-carol", Email: "carol@example.com", Name: "Carol", IsActive: false},
+	}}
+	service := NewCommentService(comments, requests, history, users, notifier, domain.DefaultCommentPolicy())
+	return service.(*CommentServiceImpl), comments, history, notifier
+}
+
+func TestParseMentions(t *testing.T) {
+	got := domain.ParseMentions("@Bob@Example.com can you check? cc @alice@example.com, not mail@example.com. Again @bob@example.com.")
+	want := []string{"bob@example.com", "alice@example.com"}
+	if len(got) != len(want) {
+		t.Fatalf("Expected %v, got %v", want, got)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("Expected %v, got %v", want, got)
+		}
+	}
+}
+
+func TestCreateCommentMentions(t *testing.T) {
+	ctx := context.Background()
+	service, _, _, notifier := newTestCommentService()
+
+	comment, err := service.CreateComment(ctx, "req-1", "user-alice", "  @bob@example.com @carol@example.com @alice@example.com @nobody@example.com is the quote final?  ", nil)
+	if err != nil {
+		t.Fatalf("CreateComment: %v", err)
+	}
+	if comment.Body[0] != '@' {
+		t.Errorf("Expected the body to be trimmed, got %q", comment.Body)
+	}
+	// Inactive and unknown users are not mentions; the author mentioning themselves isn't notified
+	if len(comment.Mentions) != 2 || comment.Mentions[0] != "user-bob" || comment.Mentions[1] != "user-alice" {
+		t.Errorf("Expected mentions [user-bob user-alice], got %v", comment.Mentions)
+	}
+	if len(notifier.notified) != 1 || notifier.notified[0] != "user-bob" {
+		t.Fatalf("Expected only Bob to be notified, got %v", notifier.notified)
+	}
+	if notifier.messages[0] != `Alice mentioned you on request "Laptop"` {
+		t.Errorf("Unexpected notification message %q", notifier.messages[0])
+	}
+
+	if _, err := service.CreateComment(ctx, "req-1", "user-alice", "   ", nil); !errors.Is(err, ErrCommentEmpty) {
+		t.Errorf("Expected ErrCommentEmpty, got %v", err)
+	}
+	if _, err := service.CreateComment(ctx, "req-missing", "user-alice", "hello", nil); !errors.Is(err, ErrRequestNotFound) {
+		t.Errorf("Expected ErrRequestNotFound, got %v", err)
+	}
+}
+
+func TestCommentThreads(t *testing.T) {
+	ctx := context.Background()
+	service, comments, _, _ := newTestCommentService()
+
+	root, _ := service.CreateComment(ctx, "req-1", "user-bob", "Why two laptops?", nil)
+	reply, err := service.CreateComment(ctx, "req-1", "user-alice", "One is for the new hire", &root.ID)
+	if err != nil {
+		t.Fatalf("CreateComment reply: %v", err)
+	}
+	nested, err := service.CreateComment(ctx, "req-1", "user-bob", "Thanks", &reply.ID)
+	if err != nil {
+		t.Fatalf("CreateComment nested reply: %v", err)
+	}
+	if nested.ParentID == nil || *nested.ParentID != root.ID {
+		t.Errorf("Expected a reply to a reply to join the root thread, got parent %v", nested.ParentID)
+	}
+
+	t.Run("Parent must belong to the same request", func(t *testing.T) {
+		if _, err := service.CreateComment(ctx, "req-2", "user-bob", "Hi", &root.ID); !errors.Is(err, ErrParentNotFound) {
+			t.Errorf("Expected ErrParentNotFound, got %v", err)
+		}
+	})
+
+	t.Run("Deleting a comment with replies keeps the thread", func(t *testing.T) {
+		if err := service.DeleteComment(ctx, "req-1", root.ID, "user-alice", false); !errors.Is(err, ErrCommentDeleteDenied) {
+			t.Fatalf("Expected ErrCommentDeleteDenied for another user, got %v", err)
+		}
+		if err := service.DeleteComment(ctx, "req-1", root.ID, "user-bob", false); err != nil {
+			t.Fatalf("DeleteComment: %v", err)
+		}
+		threads, _ := service.ListThreads(ctx, "req-1")
+		if len(threads) != 1 || !threads[0].Comment.IsDeleted() || threads[0].Comment.Body != "" || len(threads[0].Replies) != 2 {
+			t.Fatalf("Expected a blanked root with 2 replies, got %+v", threads)
+		}
+		if _, err := service.CreateComment(ctx, "req-1", "user-bob", "More?", &root.ID); !errors.Is(err, ErrParentNotFound) {
+			t.Errorf("Expected replies to a deleted comment to be refused, got %v", err)
+		}
+	})
+
+	t.Run("Thread disappears with its last reply", func(t *testing.T) {
+		if err := service.DeleteComment(ctx, "req-1", reply.ID, "admin", true); err != nil {
+			t.Fatalf("Admin DeleteComment: %v", err)
+		}
+		if err := service.DeleteComment(ctx, "req-1", nested.ID, "user-bob", false); err != nil {
+			t.Fatalf("DeleteComment: %v", err)
+		}
+		if _, ok := comments.comments[reply.ID]; ok {
+			t.Error("Expected a comment without replies to be removed")
+		}
+		if threads, _ := service.ListThreads(ctx, "req-1"); len(threads) != 0 {
+			t.Errorf("Expected no visible threads, got %d", len(threads))
+		}
+	})
+}
+
+func TestUpdateComment(t *testing.T) {
+	ctx := context.Background()
+	service, _, _, notifier := newTestCommentService()
+
+	comment, _ := service.CreateComment(ctx, "req-1", "user-alice", "Ping @bob@example.com", nil)
+
+	if _, err := service.UpdateComment(ctx, "req-1", comment.ID, "user-bob", "Hijacked"); !errors.Is(err, ErrNotCommentAuthor) {
+		t.Errorf("Expected ErrNotCommentAuthor, got %v", err)
+	}
+
+	updated, err := service.UpdateComment(ctx, "req-1", comment.ID, "user-alice", "Ping @bob@example.com and @alice@example.com")
+	if err != nil {
+		t.Fatalf("UpdateComment: %v", err)
+	}
+	if updated.EditedAt == nil {
+		t.Error("Expected EditedAt to be set")
+	}
+	if len(notifier.notified) != 1 {
+		t.Errorf("Expected Bob not to be notified twice, got %v", notifier.notified)
+	}
+
+	comment.CreatedAt = comment.CreatedAt.Add(-16 * time.Minute)
+	if _, err := service.UpdateComment(ctx, "req-1", comment.ID, "user-alice", "Too late"); !errors.Is(err, ErrEditWindowExpired) {
+		t.Errorf("Expected ErrEditWindowExpired, got %v", err)
+	}
+}
+
+func TestTimeline(t *testing.T) {
+	ctx := context.Background()
+	service, _, history, _ := newTestCommentService()
+
+	comment, _ := service.CreateComment(ctx, "req-1", "user-bob", "Which vendor?", nil)
+	comment.CreatedAt = time.Now().Add(-30 * time.Minute)
+	history.histories = []*historyDomain.ApprovalHistory{
+		{ID: "h-1", RequestID: "req-1", UserID: "user-bob", Action: historyDomain.ApprovalActionApprove, CreatedAt: time.Now().Add(-45 * time.Minute)},
+		{ID: "h-2", RequestID: "req-1", UserID: "user-dave", Action: historyDomain.ApprovalActionApprove, CreatedAt: time.Now().Add(-10 * time.Minute)},
+	}
+
+	entries, err := service.GetTimeline(ctx, "req-1")
+	if err != nil {
+		t.Fatalf("GetTimeline: %v", err)
+	}
+	want := []domain.TimelineEntryType{domain.TimelineRequestCreated, domain.TimelineApproval, domain.TimelineComment, domain.TimelineApproval}
+	if len(entries) != len(want) {
+		t.Fatalf("Expected %d entries, got %d", len(want), len(entries))
+	}
+	for i, e := range entries {
+		if e.Type != want[i] {
+			t.Errorf("Entry %d: expected %s, got %s", i, want[i], e.Type)
+		}
+	}
+	if entries[0].UserID != "user-alice" || entries[2].Comment != comment {
+		t.Errorf("Expected the requester and the comment in the timeline, got %+v", entries)
+	}
+}
@@ -0,0 +1,8 @@
+package dto
+
+import "workflow-approval/utils/money"
+
+// SetExchangeRateRequest represents the request body for setting a currency's rate
+type SetExchangeRateRequest struct {
+	Rate money.Rate `json:"rate"`
+}
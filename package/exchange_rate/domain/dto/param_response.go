@@ -0,0 +1,38 @@
+package dto
+
+import (
+	"workflow-approval/package/exchange_rate/domain"
+	"workflow-approval/utils/money"
+)
+
+// ExchangeRateResponse represents the exchange rate response
+type ExchangeRateResponse struct {
+	Currency     money.Currency `json:"currency"`
+	BaseCurrency money.Currency `json:"base_currency"`
+	Rate         money.Rate     `json:"rate"`
+	UpdatedBy    string         `json:"updated_by"`
+	UpdatedAt    string         `json:"updated_at"`
+}
+
+// ToExchangeRateResponse converts an ExchangeRate to ExchangeRateResponse
+func ToExchangeRateResponse(r *domain.ExchangeRate, base money.Currency) *ExchangeRateResponse {
+	if r == nil {
+		return nil
+	}
+	return &ExchangeRateResponse{
+		Currency:     r.Currency,
+		BaseCurrency: base,
+		Rate:         r.Rate,
+		UpdatedBy:    r.UpdatedBy,
+		UpdatedAt:    r.UpdatedAt.Format("2006-01-02T15:04:05Z"),
+	}
+}
+
+// ToExchangeRateResponseList converts a list of ExchangeRate to ExchangeRateResponse
+func ToExchangeRateResponseList(rates []*domain.ExchangeRate, base money.Currency) []*ExchangeRateResponse {
+	responses := make([]*ExchangeRateResponse, len(rates))
+	for i, r := range rates {
+		responses[i] = ToExchangeRateResponse(r, base)
+	}
+	return responses
+}
@@ -0,0 +1,38 @@
+package domain
+
+import (
+	"time"
+
+	"workflow-approval/utils"
+	"workflow-approval/utils/money"
+)
+
+// ExchangeRate is the admin-maintained value of one unit of a currency in the
+// base currency that workflow step thresholds are expressed in
+type ExchangeRate struct {
+	ID        string         `json:"id" gorm:"primaryKey;size:36"`
+	TenantID  string         `json:"tenant_id" gorm:"size:36;not null;uniqueIndex:idx_exchange_rates_tenant_currency"`
+	Currency  money.Currency `json:"currency" gorm:"size:3;not null;uniqueIndex:idx_exchange_rates_tenant_currency"` // Unique per tenant
+	Rate      money.Rate     `json:"rate" gorm:"type:decimal(18,8);not null"`
+	UpdatedBy string         `json:"updated_by" gorm:"size:36;not null"`
+	CreatedAt time.Time      `json:"created_at"`
+	UpdatedAt time.Time      `json:"updated_at"`
+}
+
+// NewExchangeRate creates a new ExchangeRate instance
+func NewExchangeRate(currency money.Currency, rate money.Rate, updatedBy string) *ExchangeRate {
+	now := utils.TimeNowUTC()
+	return &ExchangeRate{
+		ID:        utils.GenerateUUID(),
+		Currency:  currency,
+		Rate:      rate,
+		UpdatedBy: updatedBy,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+}
+
+// TableName returns the table name for GORM
+func (ExchangeRate) TableName() string {
+	return "exchange_rates"
+}
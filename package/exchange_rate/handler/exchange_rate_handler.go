@@ -0,0 +1,119 @@
+package handler
+
+import (
+	"errors"
+
+	"github.com/gofiber/fiber/v2"
+
+	"workflow-approval/package/exchange_rate/domain/dto"
+	"workflow-approval/package/exchange_rate/ports"
+	"workflow-approval/package/exchange_rate/usecase"
+	"workflow-approval/utils/money"
+)
+
+// ExchangeRateHandler handles HTTP requests for exchange rates
+type ExchangeRateHandler struct {
+	rateService ports.ExchangeRateService
+}
+
+// NewExchangeRateHandler creates a new ExchangeRateHandler instance
+func NewExchangeRateHandler(rateService ports.ExchangeRateService) *ExchangeRateHandler {
+	return &ExchangeRateHandler{
+		rateService: rateService,
+	}
+}
+
+// Routes defines the exchange rate routes open to every user
+// Mounts routes under /api/exchange-rates
+func (h *ExchangeRateHandler) Routes(group fiber.Router) {
+	// GET /api/exchange-rates - List the base currency and the rates into it
+	group.Get("", h.List)
+}
+
+// AdminRoutes defines the routes for maintaining exchange rates (Admin only)
+// Mounts routes under /api/exchange-rates
+func (h *ExchangeRateHandler) AdminRoutes(group fiber.Router) {
+	// PUT /api/exchange-rates/:currency - Set the rate of a currency
+	group.Put("/:currency", h.Set)
+
+	// DELETE /api/exchange-rates/:currency - Remove the rate of a currency
+	group.Delete("/:currency", h.Delete)
+}
+
+// List handles listing exchange rates
+// GET /api/exchange-rates
+func (h *ExchangeRateHandler) List(c *fiber.Ctx) error {
+	rates, err := h.rateService.ListRates(c.Context())
+	if err != nil {
+		return rateError(c, err)
+	}
+
+	base := h.rateService.BaseCurrency()
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"success": true,
+		"data": fiber.Map{
+			"base_currency": base,
+			"rates":         dto.ToExchangeRateResponseList(rates, base),
+		},
+		"error": nil,
+	})
+}
+
+// Set handles creating or replacing a currency's rate
+// PUT /api/exchange-rates/:currency
+func (h *ExchangeRateHandler) Set(c *fiber.Ctx) error {
+	var req dto.SetExchangeRateRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"success": false,
+			"data":    nil,
+			"error":   "Invalid request body",
+		})
+	}
+
+	userID := c.Locals("user_id").(string)
+	rate, err := h.rateService.SetRate(c.Context(), c.Params("currency"), req.Rate, userID)
+	if err != nil {
+		return rateError(c, err)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"success": true,
+		"data":    dto.ToExchangeRateResponse(rate, h.rateService.BaseCurrency()),
+		"error":   nil,
+	})
+}
+
+// Delete handles removing a currency's rate
+// DELETE /api/exchange-rates/:currency
+func (h *ExchangeRateHandler) Delete(c *fiber.Ctx) error {
+	if err := h.rateService.DeleteRate(c.Context(), c.Params("currency")); err != nil {
+		return rateError(c, err)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"success": true,
+		"data":    fiber.Map{"message": "Exchange rate deleted successfully"},
+		"error":   nil,
+	})
+}
+
+// rateError maps exchange rate service errors to HTTP responses
+func rateError(c *fiber.Ctx, err error) error {
+	status := fiber.StatusInternalServerError
+	message := "Failed to process exchange rate"
+	switch {
+	case errors.Is(err, usecase.ErrExchangeRateNotFound):
+		status, message = fiber.StatusNotFound, err.Error()
+	case errors.Is(err, usecase.ErrBaseCurrencyRate),
+		errors.Is(err, money.ErrUnknownCurrency),
+		errors.Is(err, money.ErrInvalidRate):
+		status, message = fiber.StatusBadRequest, err.Error()
+	}
+
+	return c.Status(status).JSON(fiber.Map{
+		"success": false,
+		"data":    nil,
+		"error":   message,
+	})
+}
@@ -0,0 +1,28 @@
+package ports
+
+import (
+	"context"
+
+	"workflow-approval/package/exchange_rate/domain"
+	"workflow-approval/utils/money"
+)
+
+// ExchangeRateRepository defines the interface for exchange rate data access
+type ExchangeRateRepository interface {
+	Create(ctx context.Context, rate *domain.ExchangeRate) error
+	GetByCurrency(ctx context.Context, currency money.Currency) (*domain.ExchangeRate, error)
+	List(ctx context.Context) ([]*domain.ExchangeRate, error)
+	Update(ctx context.Context, rate *domain.ExchangeRate) error
+	Delete(ctx context.Context, currency money.Currency) error
+}
+
+// ExchangeRateService defines the interface for exchange rate business logic
+type ExchangeRateService interface {
+	// BaseCurrency is the currency step thresholds are expressed in
+	BaseCurrency() money.Currency
+	// RateFor returns the value of one unit of currency in the base currency
+	RateFor(ctx context.Context, currency money.Currency) (money.Rate, error)
+	ListRates(ctx context.Context) ([]*domain.ExchangeRate, error)
+	SetRate(ctx context.Context, currency string, rate money.Rate, userID string) (*domain.ExchangeRate, error)
+	DeleteRate(ctx context.Context, currency string) error
+}
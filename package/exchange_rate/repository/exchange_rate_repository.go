@@ -0,0 +1,62 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"gorm.io/gorm"
+
+	"workflow-approval/package/exchange_rate/domain"
+	"workflow-approval/package/exchange_rate/ports"
+	"workflow-approval/utils/money"
+)
+
+var ErrExchangeRateNotFound = errors.New("exchange rate not found")
+
+// ExchangeRateRepositoryImpl implements ExchangeRateRepository interface
+type ExchangeRateRepositoryImpl struct {
+	db *gorm.DB
+}
+
+// NewExchangeRateRepository creates a new ExchangeRateRepositoryImpl instance
+func NewExchangeRateRepository(db *gorm.DB) ports.ExchangeRateRepository {
+	return &ExchangeRateRepositoryImpl{db: db}
+}
+
+// Create creates a new exchange rate
+func (r *ExchangeRateRepositoryImpl) Create(ctx context.Context, rate *domain.ExchangeRate) error {
+	return r.db.WithContext(ctx).Create(rate).Error
+}
+
+// GetByCurrency retrieves the rate of a currency
+func (r *ExchangeRateRepositoryImpl) GetByCurrency(ctx context.Context, currency money.Currency) (*domain.ExchangeRate, error) {
+	var rate domain.ExchangeRate
+	result := r.db.WithContext(ctx).First(&rate, "currency = ?", currency)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, ErrExchangeRateNotFound
+		}
+		return nil, result.Error
+	}
+	return &rate, nil
+}
+
+// List retrieves all exchange rates ordered by currency
+func (r *ExchangeRateRepositoryImpl) List(ctx context.Context) ([]*domain.ExchangeRate, error) {
+	var rates []*domain.ExchangeRate
+	result := r.db.WithContext(ctx).Order("currency ASC").Find(&rates)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return rates, nil
+}
+
+// Update updates an existing exchange rate
+func (r *ExchangeRateRepositoryImpl) Update(ctx context.Context, rate *domain.ExchangeRate) error {
+	return r.db.WithContext(ctx).Save(rate).Error
+}
+
+// Delete deletes the rate of a currency
+func (r *ExchangeRateRepositoryImpl) Delete(ctx context.Context, currency money.Currency) error {
+	return r.db.WithContext(ctx).Delete(&domain.ExchangeRate{}, "currency = ?", currency).Error
+}
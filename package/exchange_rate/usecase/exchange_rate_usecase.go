@@ -0,0 +1,108 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"workflow-approval/package/exchange_rate/domain"
+	"workflow-approval/package/exchange_rate/ports"
+	"workflow-approval/package/exchange_rate/repository"
+	reqDomain "workflow-approval/package/request/domain"
+	"workflow-approval/utils"
+	"workflow-approval/utils/money"
+)
+
+var (
+	ErrExchangeRateNotFound = errors.New("no exchange rate is set for this currency")
+	ErrBaseCurrencyRate     = errors.New("the base currency always converts at a rate of 1")
+)
+
+// ExchangeRateServiceImpl implements ExchangeRateService interface
+type ExchangeRateServiceImpl struct {
+	rateRepo ports.ExchangeRateRepository
+	base     money.Currency
+}
+
+// NewExchangeRateService creates a new ExchangeRateServiceImpl instance
+func NewExchangeRateService(rateRepo ports.ExchangeRateRepository, base money.Currency) ports.ExchangeRateService {
+	return &ExchangeRateServiceImpl{
+		rateRepo: rateRepo,
+		base:     base,
+	}
+}
+
+// BaseCurrency returns the currency step thresholds are expressed in
+func (s *ExchangeRateServiceImpl) BaseCurrency() money.Currency {
+	return s.base
+}
+
+// RateFor returns the current rate of a currency; the base currency converts at 1
+func (s *ExchangeRateServiceImpl) RateFor(ctx context.Context, currency money.Currency) (money.Rate, error) {
+	if currency == s.base {
+		return money.RateOne, nil
+	}
+	rate, err := s.rateRepo.GetByCurrency(ctx, currency)
+	if err != nil {
+		if errors.Is(err, repository.ErrExchangeRateNotFound) {
+			return money.Rate{}, fmt.Errorf("%w: %s", reqDomain.ErrExchangeRateMissing, currency)
+		}
+		return money.Rate{}, err
+	}
+	return rate.Rate, nil
+}
+
+// ListRates retrieves every rate of the caller's tenant
+func (s *ExchangeRateServiceImpl) ListRates(ctx context.Context) ([]*domain.ExchangeRate, error) {
+	return s.rateRepo.List(ctx)
+}
+
+// SetRate creates or replaces the rate of a currency. Requests already
+// submitted keep the rate they were converted at.
+func (s *ExchangeRateServiceImpl) SetRate(ctx context.Context, code string, rate money.Rate, userID string) (*domain.ExchangeRate, error) {
+	currency, err := money.ParseCurrency(code)
+	if err != nil {
+		return nil, err
+	}
+	if currency == s.base {
+		return nil, ErrBaseCurrencyRate
+	}
+	if rate.IsZero() {
+		return nil, money.ErrInvalidRate
+	}
+
+	existing, err := s.rateRepo.GetByCurrency(ctx, currency)
+	if err != nil && !errors.Is(err, repository.ErrExchangeRateNotFound) {
+		return nil, err
+	}
+	if existing == nil {
+		created := domain.NewExchangeRate(currency, rate, userID)
+		if err := s.rateRepo.Create(ctx, created); err != nil {
+			return nil, err
+		}
+		return created, nil
+	}
+
+	existing.Rate = rate
+	existing.UpdatedBy = userID
+	existing.UpdatedAt = utils.TimeNowUTC()
+	if err := s.rateRepo.Update(ctx, existing); err != nil {
+		return nil, err
+	}
+	return existing, nil
+}
+
+// DeleteRate removes the rate of a currency; new requests in it are refused until it is set again
+func (s *ExchangeRateServiceImpl) DeleteRate(ctx context.Context, code string) error {
+	currency, err := money.ParseCurrency(code)
+	if err != nil {
+		return err
+	}
+	if _, err := s.rateRepo.GetByCurrency(ctx, currency); err != nil {
+		if errors.Is(err, repository.ErrExchangeRateNotFound) {
+			return ErrExchangeRateNotFound
+		}
+		return err
+	}
+	return s.rateRepo.Delete(ctx, currency)
+}
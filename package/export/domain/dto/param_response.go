@@ -0,0 +1,55 @@
+package dto
+
+import (
+	"time"
+
+	"workflow-approval/package/export/domain"
+)
+
+// ExportJobResponse represents the export job response
+type ExportJobResponse struct {
+	ID          string  `json:"id"`
+	Format      string  `json:"format"`
+	Status      string  `json:"status"`
+	RowCount    int     `json:"row_count"`
+	FileSize    int64   `json:"file_size"`
+	Error       string  `json:"error,omitempty"`
+	DownloadURL *string `json:"download_url"` // Set once the job has completed
+	CreatedAt   string  `json:"created_at"`
+	StartedAt   *string `json:"started_at"`
+	CompletedAt *string `json:"completed_at"`
+	ExpiresAt   *string `json:"expires_at"`
+}
+
+// ToExportJobResponse converts an ExportJob to ExportJobResponse; jobsPath is
+// the path export jobs are served under
+func ToExportJobResponse(job *domain.ExportJob, jobsPath string) *ExportJobResponse {
+	if job == nil {
+		return nil
+	}
+	response := &ExportJobResponse{
+		ID:          job.ID,
+		Format:      string(job.Format),
+		Status:      string(job.Status),
+		RowCount:    job.RowCount,
+		FileSize:    job.FileSize,
+		Error:       job.Error,
+		CreatedAt:   job.CreatedAt.Format("2006-01-02T15:04:05Z"),
+		StartedAt:   formatTime(job.StartedAt),
+		CompletedAt: formatTime(job.CompletedAt),
+		ExpiresAt:   formatTime(job.ExpiresAt),
+	}
+	if job.Status == domain.JobStatusCompleted {
+		url := jobsPath + "/" + job.ID + "/download"
+		response.DownloadURL = &url
+	}
+	return response
+}
+
+func formatTime(t *time.Time) *string {
+	if t == nil {
+		return nil
+	}
+	s := t.Format("2006-01-02T15:04:05Z")
+	return &s
+}
@@ -0,0 +1,93 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	reqDomain "workflow-approval/package/request/domain"
+	"workflow-approval/utils"
+)
+
+// Format is the file format of an export
+type Format string
+
+const (
+	FormatCSV  Format = "csv"
+	FormatXLSX Format = "xlsx"
+)
+
+// ParseFormat parses the format query parameter; CSV when empty
+func ParseFormat(s string) (Format, error) {
+	switch Format(strings.ToLower(s)) {
+	case "", FormatCSV:
+		return FormatCSV, nil
+	case FormatXLSX:
+		return FormatXLSX, nil
+	}
+	return "", fmt.Errorf("format must be csv or xlsx")
+}
+
+// ContentType returns the MIME type of files in the format
+func (f Format) ContentType() string {
+	if f == FormatXLSX {
+		return "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+	}
+	return "text/csv; charset=utf-8"
+}
+
+// FileName returns the download name of an export created at t
+func (f Format) FileName(t time.Time) string {
+	return fmt.Sprintf("requests-%s.%s", t.UTC().Format("20060102-150405"), f)
+}
+
+// JobStatus is the state of an export job
+type JobStatus string
+
+const (
+	JobStatusPending   JobStatus = "PENDING"
+	JobStatusRunning   JobStatus = "RUNNING"
+	JobStatusCompleted JobStatus = "COMPLETED"
+	JobStatusFailed    JobStatus = "FAILED"
+)
+
+// ExportJob is an export run in the background, for exports too large to
+// stream within a request. The finished file is kept in storage until ExpiresAt.
+type ExportJob struct {
+	ID          string                  `json:"id" gorm:"primaryKey;size:36"`
+	TenantID    string                  `json:"tenant_id" gorm:"size:36;not null;index"`
+	RequestedBy string                  `json:"requested_by" gorm:"size:36;not null;index"`
+	Format      Format                  `json:"format" gorm:"size:10;not null"`
+	Filter      reqDomain.RequestFilter `json:"-" gorm:"type:text;serializer:json"` // Filters of the export, as listed by RequestedBy
+	Status      JobStatus               `json:"status" gorm:"size:20;not null;index"`
+	RowCount    int                     `json:"row_count" gorm:"not null;default:0"`
+	FileSize    int64                   `json:"file_size" gorm:"not null;default:0"`
+	StorageKey  string                  `json:"-" gorm:"size:255"`
+	Error       string                  `json:"error" gorm:"size:500"`
+	CreatedAt   time.Time               `json:"created_at"`
+	StartedAt   *time.Time              `json:"started_at"`
+	CompletedAt *time.Time              `json:"completed_at"`
+	ExpiresAt   *time.Time              `json:"expires_at"` // When the file is deleted; set on completion
+}
+
+// NewExportJob creates a pending export job
+func NewExportJob(requestedBy string, format Format, filter reqDomain.RequestFilter) *ExportJob {
+	return &ExportJob{
+		ID:          utils.GenerateUUID(),
+		RequestedBy: requestedBy,
+		Format:      format,
+		Filter:      filter,
+		Status:      JobStatusPending,
+		CreatedAt:   utils.TimeNowUTC(),
+	}
+}
+
+// IsFinished reports whether the job completed or failed
+func (j *ExportJob) IsFinished() bool {
+	return j.Status == JobStatusCompleted || j.Status == JobStatusFailed
+}
+
+// TableName returns the table name for GORM
+func (ExportJob) TableName() string {
+	return "export_jobs"
+}
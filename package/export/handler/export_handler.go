@@ -0,0 +1,197 @@
+package handler
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+
+	"workflow-approval/framework/middleware"
+	"workflow-approval/package/export/domain"
+	"workflow-approval/package/export/domain/dto"
+	"workflow-approval/package/export/ports"
+	"workflow-approval/package/export/usecase"
+	reqDomain "workflow-approval/package/request/domain"
+	reqDto "workflow-approval/package/request/domain/dto"
+	"workflow-approval/utils/tenancy"
+)
+
+// jobsPath is where export jobs are served, for their download links
+const jobsPath = "/api/requests/export/jobs"
+
+// ExportHandler handles HTTP requests for request exports
+type ExportHandler struct {
+	exportService ports.ExportService
+	maxSyncRows   int
+}
+
+// NewExportHandler creates a new ExportHandler instance; exports of more than
+// maxSyncRows requests must run as jobs (0 streams any size)
+func NewExportHandler(exportService ports.ExportService, maxSyncRows int) *ExportHandler {
+	return &ExportHandler{
+		exportService: exportService,
+		maxSyncRows:   maxSyncRows,
+	}
+}
+
+// Routes defines all routes for request exports
+// Mounts routes under /api/requests/export
+func (h *ExportHandler) Routes(group fiber.Router) {
+	// GET /api/requests/export - Download the requests matching the list filters
+	// Query params: format=csv|xlsx plus the filters and sort of GET /api/requests
+	group.Get("", h.Export)
+
+	// POST /api/requests/export/jobs - Queue an export to run in the background (same query params)
+	group.Post("/jobs", h.CreateJob)
+
+	// GET /api/requests/export/jobs/:jobId - Get the status of an export job
+	group.Get("/jobs/:jobId", h.GetJob)
+
+	// GET /api/requests/export/jobs/:jobId/download - Download the file of a completed export job
+	group.Get("/jobs/:jobId/download", h.Download)
+}
+
+// Export streams the matching requests as CSV or XLSX
+// GET /api/requests/export?format=csv|xlsx
+func (h *ExportHandler) Export(c *fiber.Ctx) error {
+	format, filter, err := h.parse(c)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"success": false,
+			"data":    nil,
+			"error":   err.Error(),
+		})
+	}
+
+	if h.maxSyncRows > 0 {
+		count, err := h.exportService.Count(c.Context(), filter)
+		if err != nil {
+			return exportError(c, err)
+		}
+		if count > int64(h.maxSyncRows) {
+			return c.Status(fiber.StatusUnprocessableEntity).JSON(fiber.Map{
+				"success": false,
+				"data": fiber.Map{
+					"rows":          count,
+					"max_sync_rows": h.maxSyncRows,
+				},
+				"error": fmt.Sprintf("export of %d requests exceeds %d; queue it with POST %s", count, h.maxSyncRows, jobsPath),
+				"code":  "EXPORT_TOO_LARGE",
+			})
+		}
+	}
+
+	// The body is written after the handler returns, so the export gets its
+	// own context carrying the caller's tenant
+	scope, _ := tenancy.FromContext(c.Context())
+	ctx := context.WithValue(context.Background(), tenancy.ScopeKey, scope)
+
+	setDownloadHeaders(c, format, format.FileName(time.Now()))
+	c.Context().SetBodyStreamWriter(func(w *bufio.Writer) {
+		if _, err := h.exportService.Export(ctx, w, format, filter); err != nil {
+			// The status has been sent; the client sees a truncated file
+			log.Printf("Warning: request export failed: %v", err)
+		}
+	})
+	return nil
+}
+
+// CreateJob queues an export of the matching requests
+// POST /api/requests/export/jobs?format=csv|xlsx
+func (h *ExportHandler) CreateJob(c *fiber.Ctx) error {
+	format, filter, err := h.parse(c)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"success": false,
+			"data":    nil,
+			"error":   err.Error(),
+		})
+	}
+
+	job, err := h.exportService.CreateJob(c.Context(), c.Locals("user_id").(string), format, filter)
+	if err != nil {
+		return exportError(c, err)
+	}
+
+	c.Location(jobsPath + "/" + job.ID)
+	return c.Status(fiber.StatusAccepted).JSON(fiber.Map{
+		"success": true,
+		"data":    dto.ToExportJobResponse(job, jobsPath),
+		"error":   nil,
+	})
+}
+
+// GetJob returns the status of one of the caller's export jobs
+// GET /api/requests/export/jobs/:jobId
+func (h *ExportHandler) GetJob(c *fiber.Ctx) error {
+	job, err := h.exportService.GetJob(c.Context(), c.Params("jobId"), c.Locals("user_id").(string))
+	if err != nil {
+		return exportError(c, err)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"success": true,
+		"data":    dto.ToExportJobResponse(job, jobsPath),
+		"error":   nil,
+	})
+}
+
+// Download streams the file of a completed export job
+// GET /api/requests/export/jobs/:jobId/download
+func (h *ExportHandler) Download(c *fiber.Ctx) error {
+	job, content, err := h.exportService.OpenJobResult(c.Context(), c.Params("jobId"), c.Locals("user_id").(string))
+	if err != nil {
+		return exportError(c, err)
+	}
+
+	setDownloadHeaders(c, job.Format, job.Format.FileName(job.CreatedAt))
+	return c.Status(fiber.StatusOK).SendStream(content, int(job.FileSize))
+}
+
+// parse reads the format and the list filters of an export. Exports see what
+// the caller's listing would: their own drafts, and an API key's workflows only.
+func (h *ExportHandler) parse(c *fiber.Ctx) (domain.Format, reqDomain.RequestFilter, error) {
+	format, err := domain.ParseFormat(c.Query("format"))
+	if err != nil {
+		return "", reqDomain.RequestFilter{}, err
+	}
+	filter, err := reqDto.ParseRequestFilter(c.Queries(), "format")
+	if err != nil {
+		return "", filter, err
+	}
+	if key := middleware.GetAPIKeyFromContext(c); key != nil {
+		filter.WorkflowIDs = key.WorkflowIDs
+	}
+	filter.ViewerID = c.Locals("user_id").(string)
+	return format, filter, nil
+}
+
+func setDownloadHeaders(c *fiber.Ctx, format domain.Format, fileName string) {
+	c.Set(fiber.HeaderContentType, format.ContentType())
+	c.Set(fiber.HeaderContentDisposition, fmt.Sprintf(`attachment; filename="%s"`, fileName))
+	c.Set(fiber.HeaderXContentTypeOptions, "nosniff")
+}
+
+// exportError maps export errors to HTTP responses
+func exportError(c *fiber.Ctx, err error) error {
+	status := fiber.StatusInternalServerError
+	message := "Failed to export requests"
+	switch {
+	case errors.Is(err, usecase.ErrExportJobNotFound):
+		status, message = fiber.StatusNotFound, err.Error()
+	case errors.Is(err, usecase.ErrExportNotReady):
+		status, message = fiber.StatusConflict, err.Error()
+	case errors.Is(err, usecase.ErrExportExpired):
+		status, message = fiber.StatusGone, err.Error()
+	}
+
+	return c.Status(status).JSON(fiber.Map{
+		"success": false,
+		"data":    nil,
+		"error":   message,
+	})
+}
@@ -0,0 +1,64 @@
+package ports
+
+import (
+	"context"
+	"io"
+	"time"
+
+	historyDomain "workflow-approval/package/approval_history/domain"
+	"workflow-approval/package/export/domain"
+	reqDomain "workflow-approval/package/request/domain"
+	userDomain "workflow-approval/package/user/domain"
+	wfDomain "workflow-approval/package/workflow/domain"
+)
+
+// ExportJobRepository defines the interface for export job data access
+type ExportJobRepository interface {
+	Create(ctx context.Context, job *domain.ExportJob) error
+	GetByID(ctx context.Context, id string) (*domain.ExportJob, error)
+	Update(ctx context.Context, job *domain.ExportJob) error
+	// ClaimNext marks the oldest pending job of any tenant as running and
+	// returns it; nil when none is pending. Concurrent workers never claim the same job.
+	ClaimNext(ctx context.Context, now time.Time) (*domain.ExportJob, error)
+	// FailStale marks the jobs of any tenant still running since before
+	// startedBefore as failed with message, e.g. after their worker crashed,
+	// and returns how many there were
+	FailStale(ctx context.Context, startedBefore, now time.Time, message string) (int64, error)
+	// ListExpired returns up to limit completed jobs whose file expired before now
+	ListExpired(ctx context.Context, now time.Time, limit int) ([]*domain.ExportJob, error)
+	Delete(ctx context.Context, id string) error
+	// MaxLevel returns the highest approval level of the given workflows (all when
+	// empty), counting both their current steps and past approval history
+	MaxLevel(ctx context.Context, workflowIDs []string) (int, error)
+}
+
+// ApprovalHistoryRepository defines the history lookups joined into exported rows
+type ApprovalHistoryRepository interface {
+	// ListByRequestIDs returns the history of the requests, oldest first
+	ListByRequestIDs(ctx context.Context, requestIDs []string) ([]*historyDomain.ApprovalHistory, error)
+}
+
+// UserRepository defines the user lookups naming requesters and approvers
+type UserRepository interface {
+	GetByID(ctx context.Context, id string) (*userDomain.User, error)
+}
+
+// WorkflowRepository defines the workflow lookups naming exported workflows
+type WorkflowRepository interface {
+	GetByID(ctx context.Context, id string) (*wfDomain.Workflow, error)
+}
+
+// ExportService defines the interface for request export business logic
+type ExportService interface {
+	// Count returns how many requests the filter exports
+	Count(ctx context.Context, filter reqDomain.RequestFilter) (int64, error)
+	// Export writes the requests matching the filter to w, one row per request
+	// with a group of columns per approval level, and returns the row count
+	Export(ctx context.Context, w io.Writer, format domain.Format, filter reqDomain.RequestFilter) (int, error)
+	// CreateJob queues an export to be run in the background
+	CreateJob(ctx context.Context, requestedBy string, format domain.Format, filter reqDomain.RequestFilter) (*domain.ExportJob, error)
+	// GetJob returns a job of the user
+	GetJob(ctx context.Context, id, userID string) (*domain.ExportJob, error)
+	// OpenJobResult opens the file of a completed job of the user; the caller must close it
+	OpenJobResult(ctx context.Context, id, userID string) (*domain.ExportJob, io.ReadCloser, error)
+}
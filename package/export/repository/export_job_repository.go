@@ -0,0 +1,124 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+
+	historyDomain "workflow-approval/package/approval_history/domain"
+	"workflow-approval/package/export/domain"
+	"workflow-approval/package/export/ports"
+	stepDomain "workflow-approval/package/workflow_step/domain"
+)
+
+var ErrExportJobNotFound = errors.New("export job not found")
+
+// ExportJobRepositoryImpl implements ExportJobRepository interface
+type ExportJobRepositoryImpl struct {
+	db *gorm.DB
+}
+
+// NewExportJobRepository creates a new ExportJobRepositoryImpl instance
+func NewExportJobRepository(db *gorm.DB) ports.ExportJobRepository {
+	return &ExportJobRepositoryImpl{db: db}
+}
+
+// Create creates a new export job
+func (r *ExportJobRepositoryImpl) Create(ctx context.Context, job *domain.ExportJob) error {
+	return r.db.WithContext(ctx).Create(job).Error
+}
+
+// GetByID retrieves an export job by ID
+func (r *ExportJobRepositoryImpl) GetByID(ctx context.Context, id string) (*domain.ExportJob, error) {
+	var job domain.ExportJob
+	result := r.db.WithContext(ctx).First(&job, "id = ?", id)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, ErrExportJobNotFound
+		}
+		return nil, result.Error
+	}
+	return &job, nil
+}
+
+// Update updates an export job
+func (r *ExportJobRepositoryImpl) Update(ctx context.Context, job *domain.ExportJob) error {
+	return r.db.WithContext(ctx).Save(job).Error
+}
+
+// ClaimNext marks the oldest pending job as running. The status check in the
+// UPDATE makes the claim atomic: a worker that loses the race to another
+// tries the next pending job.
+func (r *ExportJobRepositoryImpl) ClaimNext(ctx context.Context, now time.Time) (*domain.ExportJob, error) {
+	for {
+		var job domain.ExportJob
+		result := r.db.WithContext(ctx).
+			Where("status = ?", domain.JobStatusPending).
+			Order("created_at ASC").
+			First(&job)
+		if result.Error != nil {
+			if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+				return nil, nil
+			}
+			return nil, result.Error
+		}
+
+		claim := r.db.WithContext(ctx).Model(&domain.ExportJob{}).
+			Where("id = ? AND status = ?", job.ID, domain.JobStatusPending).
+			Updates(map[string]interface{}{"status": domain.JobStatusRunning, "started_at": now})
+		if claim.Error != nil {
+			return nil, claim.Error
+		}
+		if claim.RowsAffected == 1 {
+			job.Status = domain.JobStatusRunning
+			job.StartedAt = &now
+			return &job, nil
+		}
+	}
+}
+
+// FailStale marks jobs running since before startedBefore as failed
+func (r *ExportJobRepositoryImpl) FailStale(ctx context.Context, startedBefore, now time.Time, message string) (int64, error) {
+	result := r.db.WithContext(ctx).Model(&domain.ExportJob{}).
+		Where("status = ? AND started_at < ?", domain.JobStatusRunning, startedBefore).
+		Updates(map[string]interface{}{"status": domain.JobStatusFailed, "error": message, "completed_at": now})
+	return result.RowsAffected, result.Error
+}
+
+// ListExpired retrieves completed jobs whose file expired before now
+func (r *ExportJobRepositoryImpl) ListExpired(ctx context.Context, now time.Time, limit int) ([]*domain.ExportJob, error) {
+	var jobs []*domain.ExportJob
+	err := r.db.WithContext(ctx).
+		Where("status = ? AND expires_at < ?", domain.JobStatusCompleted, now).
+		Order("expires_at ASC").
+		Limit(limit).
+		Find(&jobs).Error
+	return jobs, err
+}
+
+// Delete deletes an export job
+func (r *ExportJobRepositoryImpl) Delete(ctx context.Context, id string) error {
+	return r.db.WithContext(ctx).Delete(&domain.ExportJob{}, "id = ?", id).Error
+}
+
+// MaxLevel returns the highest step level of the workflows, or of their
+// approval history when steps have since been removed
+func (r *ExportJobRepositoryImpl) MaxLevel(ctx context.Context, workflowIDs []string) (int, error) {
+	var stepLevel, historyLevel int
+
+	steps := r.db.WithContext(ctx).Model(&stepDomain.WorkflowStep{})
+	history := r.db.WithContext(ctx).Model(&historyDomain.ApprovalHistory{})
+	if len(workflowIDs) > 0 {
+		steps = steps.Where("workflow_id IN ?", workflowIDs)
+		history = history.Where("workflow_id IN ?", workflowIDs)
+	}
+	if err := steps.Select("COALESCE(MAX(level), 0)").Scan(&stepLevel).Error; err != nil {
+		return 0, err
+	}
+	if err := history.Select("COALESCE(MAX(step_level), 0)").Scan(&historyLevel).Error; err != nil {
+		return 0, err
+	}
+	return max(stepLevel, historyLevel), nil
+}
@@ -0,0 +1,350 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+
+	historyDomain "workflow-approval/package/approval_history/domain"
+	"workflow-approval/package/export/domain"
+	"workflow-approval/package/export/ports"
+	"workflow-approval/package/export/repository"
+	reqDomain "workflow-approval/package/request/domain"
+	reqPorts "workflow-approval/package/request/ports"
+	userDomain "workflow-approval/package/user/domain"
+	userRepo "workflow-approval/package/user/repository"
+	wfRepo "workflow-approval/package/workflow/repository"
+	"workflow-approval/utils/pagination"
+	"workflow-approval/utils/spreadsheet"
+	"workflow-approval/utils/storage"
+)
+
+// exportBatch is how many requests are read, with their history, at a time
+const exportBatch = 500
+
+var (
+	ErrExportJobNotFound = errors.New("export job not found")
+	ErrExportNotReady    = errors.New("export job has not completed")
+	ErrExportExpired     = errors.New("export file has expired")
+)
+
+// timeLayout formats exported timestamps, in UTC like API responses
+const timeLayout = "2006-01-02T15:04:05Z"
+
+// requestColumns are the leading columns of every exported row
+var requestColumns = []string{
+	"id", "title", "status", "workflow_id", "workflow_name", "requester_id", "requester_name", "requester_email",
+	"amount", "currency", "exchange_rate", "base_amount", "current_step", "fields",
+	"created_at", "submitted_at", "updated_at",
+}
+
+// levelColumns are repeated for each approval level as level_<n>_<column>
+var levelColumns = []string{"action", "approver_name", "approver_email", "decided_at", "comment"}
+
+// ExportServiceImpl implements ExportService interface
+type ExportServiceImpl struct {
+	jobRepo      ports.ExportJobRepository
+	requestRepo  reqPorts.RequestRepository
+	historyRepo  ports.ApprovalHistoryRepository
+	userRepo     ports.UserRepository
+	workflowRepo ports.WorkflowRepository
+	store        storage.Storage
+}
+
+// NewExportService creates a new ExportServiceImpl instance
+func NewExportService(
+	jobRepo ports.ExportJobRepository,
+	requestRepo reqPorts.RequestRepository,
+	historyRepo ports.ApprovalHistoryRepository,
+	userRepo ports.UserRepository,
+	workflowRepo ports.WorkflowRepository,
+	store storage.Storage,
+) ports.ExportService {
+	return &ExportServiceImpl{
+		jobRepo:      jobRepo,
+		requestRepo:  requestRepo,
+		historyRepo:  historyRepo,
+		userRepo:     userRepo,
+		workflowRepo: workflowRepo,
+		store:        store,
+	}
+}
+
+// Count returns the number of requests matching the filter
+func (s *ExportServiceImpl) Count(ctx context.Context, filter reqDomain.RequestFilter) (int64, error) {
+	_, result, err := s.requestRepo.List(ctx, filter, pagination.Page{Number: 1, Limit: 1, WithTotal: true})
+	if err != nil {
+		return 0, err
+	}
+	return result.Total, nil
+}
+
+// Export writes the matching requests in batches. Requests sorted by
+// creation time are read by cursor, so rows changing during a long export
+// are neither skipped nor repeated; other sorts are read page by page.
+func (s *ExportServiceImpl) Export(ctx context.Context, w io.Writer, format domain.Format, filter reqDomain.RequestFilter) (int, error) {
+	workflowIDs := filter.WorkflowIDs
+	if filter.WorkflowID != "" {
+		workflowIDs = []string{filter.WorkflowID}
+	}
+	levels, err := s.jobRepo.MaxLevel(ctx, workflowIDs)
+	if err != nil {
+		return 0, err
+	}
+
+	sheet, err := newSheet(w, format)
+	if err != nil {
+		return 0, err
+	}
+	if err := sheet.WriteRow(header(levels)); err != nil {
+		return 0, err
+	}
+
+	names := newNameCache(s.userRepo, s.workflowRepo)
+	_, keyset := filter.KeysetOrder()
+	page := pagination.Page{Number: 1, Limit: exportBatch}
+	rows := 0
+	for {
+		requests, result, err := s.requestRepo.List(ctx, filter, page)
+		if err != nil {
+			return rows, err
+		}
+
+		ids := make([]string, len(requests))
+		for i, request := range requests {
+			ids[i] = request.ID
+		}
+		histories, err := s.historyRepo.ListByRequestIDs(ctx, ids)
+		if err != nil {
+			return rows, err
+		}
+		byRequest := make(map[string][]*historyDomain.ApprovalHistory, len(requests))
+		for _, history := range histories {
+			byRequest[history.RequestID] = append(byRequest[history.RequestID], history)
+		}
+
+		for _, request := range requests {
+			cells, err := s.row(ctx, names, request, byRequest[request.ID], levels)
+			if err != nil {
+				return rows, err
+			}
+			if err := sheet.WriteRow(cells); err != nil {
+				return rows, err
+			}
+			rows++
+		}
+
+		if keyset {
+			if result.Next == nil {
+				break
+			}
+			page.Cursor = result.Next
+		} else {
+			if len(requests) < page.Limit {
+				break
+			}
+			page.Number++
+		}
+	}
+	return rows, sheet.Close()
+}
+
+// CreateJob queues an export job for the user
+func (s *ExportServiceImpl) CreateJob(ctx context.Context, requestedBy string, format domain.Format, filter reqDomain.RequestFilter) (*domain.ExportJob, error) {
+	job := domain.NewExportJob(requestedBy, format, filter)
+	if err := s.jobRepo.Create(ctx, job); err != nil {
+		return nil, err
+	}
+	return job, nil
+}
+
+// GetJob returns a job; jobs of other users are reported as not found
+func (s *ExportServiceImpl) GetJob(ctx context.Context, id, userID string) (*domain.ExportJob, error) {
+	job, err := s.jobRepo.GetByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, repository.ErrExportJobNotFound) {
+			return nil, ErrExportJobNotFound
+		}
+		return nil, err
+	}
+	if job.RequestedBy != userID {
+		return nil, ErrExportJobNotFound
+	}
+	return job, nil
+}
+
+// OpenJobResult opens the file of a completed job
+func (s *ExportServiceImpl) OpenJobResult(ctx context.Context, id, userID string) (*domain.ExportJob, io.ReadCloser, error) {
+	job, err := s.GetJob(ctx, id, userID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if job.Status != domain.JobStatusCompleted {
+		return nil, nil, ErrExportNotReady
+	}
+	if job.ExpiresAt != nil && job.ExpiresAt.Before(time.Now().UTC()) {
+		return nil, nil, ErrExportExpired
+	}
+
+	content, err := s.store.Get(ctx, job.StorageKey)
+	if err != nil {
+		if errors.Is(err, storage.ErrNotFound) {
+			return nil, nil, ErrExportExpired
+		}
+		return nil, nil, err
+	}
+	return job, content, nil
+}
+
+func newSheet(w io.Writer, format domain.Format) (spreadsheet.Writer, error) {
+	if format == domain.FormatXLSX {
+		return spreadsheet.NewXLSXWriter(w, "Requests")
+	}
+	return spreadsheet.NewCSVWriter(w), nil
+}
+
+func header(levels int) []spreadsheet.Cell {
+	cells := make([]spreadsheet.Cell, 0, len(requestColumns)+levels*len(levelColumns))
+	for _, column := range requestColumns {
+		cells = append(cells, spreadsheet.Text(column))
+	}
+	for level := 1; level <= levels; level++ {
+		for _, column := range levelColumns {
+			cells = append(cells, spreadsheet.Text(fmt.Sprintf("level_%d_%s", level, column)))
+		}
+	}
+	return cells
+}
+
+// row returns the cells of a request. The last decision of each level fills
+// its columns; levels not reached are left empty.
+func (s *ExportServiceImpl) row(ctx context.Context, names *nameCache, request *reqDomain.Request, histories []*historyDomain.ApprovalHistory, levels int) ([]spreadsheet.Cell, error) {
+	workflowName, err := names.workflow(ctx, request.WorkflowID)
+	if err != nil {
+		return nil, err
+	}
+	requester, err := names.user(ctx, request.RequesterID)
+	if err != nil {
+		return nil, err
+	}
+	fields, _ := json.Marshal(request.Fields)
+	if len(request.Fields) == 0 {
+		fields = nil
+	}
+	rate := ""
+	if !request.ExchangeRate.IsZero() {
+		rate = request.ExchangeRate.String()
+	}
+
+	cells := []spreadsheet.Cell{
+		spreadsheet.Text(request.ID),
+		spreadsheet.Text(request.Title),
+		spreadsheet.Text(string(request.Status)),
+		spreadsheet.Text(request.WorkflowID),
+		spreadsheet.Text(workflowName),
+		spreadsheet.Text(request.RequesterID),
+		spreadsheet.Text(requester.Name),
+		spreadsheet.Text(requester.Email),
+		spreadsheet.Number(request.Amount.String()),
+		spreadsheet.Text(string(request.Currency)),
+		numberOrEmpty(rate),
+		spreadsheet.Number(request.BaseAmount.String()),
+		spreadsheet.Number(strconv.Itoa(request.CurrentStep)),
+		spreadsheet.Text(string(fields)),
+		spreadsheet.Text(request.CreatedAt.UTC().Format(timeLayout)),
+		spreadsheet.Text(formatTime(request.SubmittedAt)),
+		spreadsheet.Text(request.UpdatedAt.UTC().Format(timeLayout)),
+	}
+
+	decisions := make(map[int]*historyDomain.ApprovalHistory, levels)
+	for _, history := range histories {
+		decisions[history.StepLevel] = history
+	}
+	for level := 1; level <= levels; level++ {
+		decision := decisions[level]
+		if decision == nil {
+			for range levelColumns {
+				cells = append(cells, spreadsheet.Text(""))
+			}
+			continue
+		}
+		approver, err := names.user(ctx, decision.UserID)
+		if err != nil {
+			return nil, err
+		}
+		cells = append(cells,
+			spreadsheet.Text(string(decision.Action)),
+			spreadsheet.Text(approver.Name),
+			spreadsheet.Text(approver.Email),
+			spreadsheet.Text(decision.CreatedAt.UTC().Format(timeLayout)),
+			spreadsheet.Text(decision.Comment),
+		)
+	}
+	return cells, nil
+}
+
+func numberOrEmpty(s string) spreadsheet.Cell {
+	if s == "" {
+		return spreadsheet.Text("")
+	}
+	return spreadsheet.Number(s)
+}
+
+func formatTime(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.UTC().Format(timeLayout)
+}
+
+// nameCache looks up each user and workflow of an export once. Deleted users
+// and workflows export with empty names.
+type nameCache struct {
+	userRepo     ports.UserRepository
+	workflowRepo ports.WorkflowRepository
+	users        map[string]*userDomain.User
+	workflows    map[string]string
+}
+
+func newNameCache(userRepo ports.UserRepository, workflowRepo ports.WorkflowRepository) *nameCache {
+	return &nameCache{
+		userRepo:     userRepo,
+		workflowRepo: workflowRepo,
+		users:        make(map[string]*userDomain.User),
+		workflows:    make(map[string]string),
+	}
+}
+
+func (c *nameCache) user(ctx context.Context, id string) (*userDomain.User, error) {
+	if user, ok := c.users[id]; ok {
+		return user, nil
+	}
+	user, err := c.userRepo.GetByID(ctx, id)
+	if err != nil {
+		if !errors.Is(err, userRepo.ErrUserNotFound) {
+			return nil, err
+		}
+		user = &userDomain.User{}
+	}
+	c.users[id] = user
+	return user, nil
+}
+
+func (c *nameCache) workflow(ctx context.Context, id string) (string, error) {
+	if name, ok := c.workflows[id]; ok {
+		return name, nil
+	}
+	name := ""
+	workflow, err := c.workflowRepo.GetByID(ctx, id)
+	if err == nil {
+		name = workflow.Name
+	} else if !errors.Is(err, wfRepo.ErrWorkflowNotFound) {
+		return "", err
+	}
+	c.workflows[id] = name
+	return name, nil
+}
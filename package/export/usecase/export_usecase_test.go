@@ -0,0 +1,350 @@
+package usecase
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	historyDomain "workflow-approval/package/approval_history/domain"
+	"workflow-approval/package/export/domain"
+	"workflow-approval/package/export/repository"
+	reqDomain "workflow-approval/package/request/domain"
+	userDomain "workflow-approval/package/user/domain"
+	userRepo "workflow-approval/package/user/repository"
+	wfDomain "workflow-approval/package/workflow/domain"
+	"workflow-approval/utils/money"
+	"workflow-approval/utils/pagination"
+	"workflow-approval/utils/storage"
+)
+
+// mockRequestRepository lists fixed requests, newest first, by cursor
+type mockRequestRepository struct {
+	requests []*reqDomain.Request
+	lists    int
+}
+
+func (m *mockRequestRepository) Create(ctx context.Context, request *reqDomain.Request) error {
+	return nil
+}
+
+func (m *mockRequestRepository) GetByID(ctx context.Context, id string) (*reqDomain.Request, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (m *mockRequestRepository) GetByIDForUpdate(ctx context.Context, id string) (*reqDomain.Request, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (m *mockRequestRepository) Update(ctx context.Context, request *reqDomain.Request) error {
+	return nil
+}
+
+func (m *mockRequestRepository) Delete(ctx context.Context, id string) error {
+	return nil
+}
+
+func (m *mockRequestRepository) List(ctx context.Context, filter reqDomain.RequestFilter, page pagination.Page) ([]*reqDomain.Request, pagination.Result, error) {
+	m.lists++
+	start := 0
+	if page.Cursor != nil {
+		for i, r := range m.requests {
+			if r.ID == page.Cursor.ID {
+				start = i + 1
+			}
+		}
+	}
+	end := min(start+page.Limit+1, len(m.requests))
+	rows, result := pagination.Finish(append([]*reqDomain.Request(nil), m.requests[start:end]...), page,
+		func(r *reqDomain.Request) (time.Time, string) { return r.CreatedAt, r.ID })
+	result.Total = int64(len(m.requests))
+	return rows, result, nil
+}
+
+func (m *mockRequestRepository) ListDraftsUpdatedBefore(ctx context.Context, before time.Time, limit int) ([]*reqDomain.Request, error) {
+	return nil, nil
+}
+
+func (m *mockRequestRepository) CountPendingByWorkflow(ctx context.Context) (map[string]int64, error) {
+	return nil, nil
+}
+
+// mockHistoryRepository returns the history of the listed requests
+type mockHistoryRepository struct {
+	histories []*historyDomain.ApprovalHistory
+}
+
+func (m *mockHistoryRepository) ListByRequestIDs(ctx context.Context, requestIDs []string) ([]*historyDomain.ApprovalHistory, error) {
+	var out []*historyDomain.ApprovalHistory
+	for _, h := range m.histories {
+		for _, id := range requestIDs {
+			if h.RequestID == id {
+				out = append(out, h)
+			}
+		}
+	}
+	return out, nil
+}
+
+type mockUserRepository struct {
+	users map[string]*userDomain.User
+}
+
+func (m *mockUserRepository) GetByID(ctx context.Context, id string) (*userDomain.User, error) {
+	if user, ok := m.users[id]; ok {
+		return user, nil
+	}
+	return nil, userRepo.ErrUserNotFound
+}
+
+type mockWorkflowRepository struct{}
+
+func (m *mockWorkflowRepository) GetByID(ctx context.Context, id string) (*wfDomain.Workflow, error) {
+	return &wfDomain.Workflow{ID: id, Name: "Purchase"}, nil
+}
+
+// mockJobRepository keeps export jobs in memory
+type mockJobRepository struct {
+	jobs     map[string]*domain.ExportJob
+	maxLevel int
+}
+
+func (m *mockJobRepository) Create(ctx context.Context, job *domain.ExportJob) error {
+	job.TenantID = "tenant-1"
+	m.jobs[job.ID] = job
+	return nil
+}
+
+func (m *mockJobRepository) GetByID(ctx context.Context, id string) (*domain.ExportJob, error) {
+	if job, ok := m.jobs[id]; ok {
+		copied := *job
+		return &copied, nil
+	}
+	return nil, repository.ErrExportJobNotFound
+}
+
+func (m *mockJobRepository) Update(ctx context.Context, job *domain.ExportJob) error {
+	m.jobs[job.ID] = job
+	return nil
+}
+
+func (m *mockJobRepository) ClaimNext(ctx context.Context, now time.Time) (*domain.ExportJob, error) {
+	for _, job := range m.jobs {
+		if job.Status == domain.JobStatusPending {
+			job.Status = domain.JobStatusRunning
+			job.StartedAt = &now
+			copied := *job
+			return &copied, nil
+		}
+	}
+	return nil, nil
+}
+
+func (m *mockJobRepository) FailStale(ctx context.Context, startedBefore, now time.Time, message string) (int64, error) {
+	var failed int64
+	for _, job := range m.jobs {
+		if job.Status == domain.JobStatusRunning && job.StartedAt.Before(startedBefore) {
+			job.Status = domain.JobStatusFailed
+			job.Error = message
+			job.CompletedAt = &now
+			failed++
+		}
+	}
+	return failed, nil
+}
+
+func (m *mockJobRepository) ListExpired(ctx context.Context, now time.Time, limit int) ([]*domain.ExportJob, error) {
+	var out []*domain.ExportJob
+	for _, job := range m.jobs {
+		if job.Status == domain.JobStatusCompleted && job.ExpiresAt.Before(now) {
+			out = append(out, job)
+		}
+	}
+	return out, nil
+}
+
+func (m *mockJobRepository) Delete(ctx context.Context, id string) error {
+	delete(m.jobs, id)
+	return nil
+}
+
+func (m *mockJobRepository) MaxLevel(ctx context.Context, workflowIDs []string) (int, error) {
+	return m.maxLevel, nil
+}
+
+// newTestExportService returns n requests created a minute apart, newest
+// first; the newest was approved at level 1 and rejected at level 2
+func newTestExportService(t *testing.T, n int) (*ExportServiceImpl, *mockRequestRepository, *mockJobRepository) {
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	requests := &mockRequestRepository{}
+	for i := n; i > 0; i-- {
+		requests.requests = append(requests.requests, &reqDomain.Request{
+			ID:          fmt.Sprintf("req-%04d", i),
+			WorkflowID:  "wf-1",
+			RequesterID: "user-1",
+			Status:      reqDomain.StatusPending,
+			Title:       fmt.Sprintf("Request %d", i),
+			Amount:      money.NewDecimal(1500),
+			Currency:    "IDR",
+			BaseAmount:  money.NewDecimal(1500),
+			CurrentStep: 1,
+			CreatedAt:   start.Add(time.Duration(i) * time.Minute),
+			UpdatedAt:   start.Add(time.Duration(i) * time.Minute),
+		})
+	}
+	newest := requests.requests[0].ID
+	history := &mockHistoryRepository{histories: []*historyDomain.ApprovalHistory{
+		{RequestID: newest, StepLevel: 1, UserID: "user-2", Action: historyDomain.ApprovalActionApprove, CreatedAt: start.Add(time.Hour)},
+		{RequestID: newest, StepLevel: 2, UserID: "user-gone", Action: historyDomain.ApprovalActionReject, Comment: "=over budget", CreatedAt: start.Add(2 * time.Hour)},
+	}}
+	users := &mockUserRepository{users: map[string]*userDomain.User{
+		"user-1": {ID: "user-1", Name: "Rina", Email: "rina@example.com"},
+		"user-2": {ID: "user-2", Name: "Budi", Email: "budi@example.com"},
+	}}
+	jobs := &mockJobRepository{jobs: make(map[string]*domain.ExportJob), maxLevel: 2}
+	store := storage.NewLocalStorage(t.TempDir())
+
+	service := NewExportService(jobs, requests, history, users, &mockWorkflowRepository{}, store).(*ExportServiceImpl)
+	return service, requests, jobs
+}
+
+func readCSV(t *testing.T, r io.Reader) []map[string]string {
+	t.Helper()
+	records, err := csv.NewReader(r).ReadAll()
+	if err != nil {
+		t.Fatalf("Invalid CSV: %v", err)
+	}
+	rows := make([]map[string]string, 0, len(records)-1)
+	for _, record := range records[1:] {
+		row := make(map[string]string)
+		for i, column := range records[0] {
+			row[column] = record[i]
+		}
+		rows = append(rows, row)
+	}
+	return rows
+}
+
+func TestExportJoinsHistoryPerLevel(t *testing.T) {
+	service, _, _ := newTestExportService(t, 2)
+
+	var buf bytes.Buffer
+	count, err := service.Export(context.Background(), &buf, domain.FormatCSV, reqDomain.RequestFilter{})
+	if err != nil || count != 2 {
+		t.Fatalf("Expected 2 rows, got %d (%v)", count, err)
+	}
+
+	rows := readCSV(t, &buf)
+	decided := rows[0]
+	if decided["workflow_name"] != "Purchase" || decided["requester_email"] != "rina@example.com" || decided["amount"] != "1500.00" {
+		t.Errorf("Unexpected request columns %v", decided)
+	}
+	if decided["level_1_action"] != "APPROVE" || decided["level_1_approver_name"] != "Budi" || decided["level_1_decided_at"] != "2026-01-01T01:00:00Z" {
+		t.Errorf("Unexpected level 1 columns %v", decided)
+	}
+	// Deleted approvers export without a name; formula-like comments are escaped
+	if decided["level_2_action"] != "REJECT" || decided["level_2_approver_name"] != "" || decided["level_2_comment"] != "'=over budget" {
+		t.Errorf("Unexpected level 2 columns %v", decided)
+	}
+	if rows[1]["level_1_action"] != "" || rows[1]["level_2_action"] != "" {
+		t.Errorf("Expected no decisions for the undecided request, got %v", rows[1])
+	}
+}
+
+func TestExportReadsEveryBatch(t *testing.T) {
+	n := exportBatch*2 + 1
+	service, requests, _ := newTestExportService(t, n)
+
+	var buf bytes.Buffer
+	count, err := service.Export(context.Background(), &buf, domain.FormatCSV, reqDomain.RequestFilter{})
+	if err != nil || count != n {
+		t.Fatalf("Expected %d rows, got %d (%v)", n, count, err)
+	}
+	if requests.lists != 3 {
+		t.Errorf("Expected 3 batches, got %d", requests.lists)
+	}
+
+	seen := make(map[string]bool)
+	for _, row := range readCSV(t, &buf) {
+		if seen[row["id"]] {
+			t.Fatalf("Request %s exported twice", row["id"])
+		}
+		seen[row["id"]] = true
+	}
+	if len(seen) != n {
+		t.Errorf("Expected %d distinct requests, got %d", n, len(seen))
+	}
+}
+
+func TestExportJobLifecycle(t *testing.T) {
+	service, _, jobs := newTestExportService(t, 3)
+	ctx := context.Background()
+
+	job, err := service.CreateJob(ctx, "user-1", domain.FormatCSV, reqDomain.RequestFilter{})
+	if err != nil {
+		t.Fatalf("CreateJob failed: %v", err)
+	}
+	if _, _, err := service.OpenJobResult(ctx, job.ID, "user-1"); !errors.Is(err, ErrExportNotReady) {
+		t.Errorf("Expected ErrExportNotReady before the job ran, got %v", err)
+	}
+
+	worker := NewExportWorker(jobs, service, service.store, time.Hour, time.Minute, time.Hour)
+	if err := worker.RunPending(ctx); err != nil {
+		t.Fatalf("RunPending failed: %v", err)
+	}
+
+	if _, err := service.GetJob(ctx, job.ID, "user-2"); !errors.Is(err, ErrExportJobNotFound) {
+		t.Errorf("Expected other users' jobs to be hidden, got %v", err)
+	}
+	done, content, err := service.OpenJobResult(ctx, job.ID, "user-1")
+	if err != nil {
+		t.Fatalf("OpenJobResult failed: %v", err)
+	}
+	defer content.Close()
+	body, _ := io.ReadAll(content)
+	if done.Status != domain.JobStatusCompleted || done.RowCount != 3 || done.FileSize != int64(len(body)) {
+		t.Errorf("Unexpected completed job %+v", done)
+	}
+	if !strings.HasPrefix(string(body), "id,title,status") || len(readCSV(t, bytes.NewReader(body))) != 3 {
+		t.Errorf("Unexpected export file %q", body)
+	}
+
+	// Expired files are deleted with their job
+	if err := worker.PurgeExpired(ctx, time.Now().Add(2*time.Hour)); err != nil {
+		t.Fatalf("PurgeExpired failed: %v", err)
+	}
+	if _, err := service.GetJob(ctx, job.ID, "user-1"); !errors.Is(err, ErrExportJobNotFound) {
+		t.Errorf("Expected the expired job to be deleted, got %v", err)
+	}
+	if _, err := service.store.Get(ctx, done.StorageKey); !errors.Is(err, storage.ErrNotFound) {
+		t.Errorf("Expected the expired file to be deleted, got %v", err)
+	}
+}
+
+func TestExportWorkerFailsInterruptedJobs(t *testing.T) {
+	service, _, jobs := newTestExportService(t, 1)
+	ctx := context.Background()
+	now := time.Now().UTC()
+
+	stale, _ := service.CreateJob(ctx, "user-1", domain.FormatCSV, reqDomain.RequestFilter{})
+	recent, _ := service.CreateJob(ctx, "user-1", domain.FormatCSV, reqDomain.RequestFilter{})
+	staleStart, recentStart := now.Add(-2*time.Hour), now.Add(-time.Minute)
+	stale.Status, stale.StartedAt = domain.JobStatusRunning, &staleStart
+	recent.Status, recent.StartedAt = domain.JobStatusRunning, &recentStart
+
+	worker := NewExportWorker(jobs, service, service.store, time.Hour, time.Minute, time.Hour)
+	if err := worker.FailStale(ctx, now); err != nil {
+		t.Fatalf("FailStale failed: %v", err)
+	}
+	if stale.Status != domain.JobStatusFailed || stale.Error == "" || stale.CompletedAt == nil {
+		t.Errorf("Expected the job running for 2h failed, got %+v", stale)
+	}
+	if recent.Status != domain.JobStatusRunning {
+		t.Errorf("Expected the recently started job left running, got %s", recent.Status)
+	}
+}
@@ -0,0 +1,205 @@
+package usecase
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"workflow-approval/package/export/domain"
+	"workflow-approval/package/export/ports"
+	"workflow-approval/utils/storage"
+	"workflow-approval/utils/tenancy"
+)
+
+// expiredExportBatch is how many expired export files are deleted at a time
+const expiredExportBatch = 100
+
+// maxJobErrorLength fits the error column of export_jobs
+const maxJobErrorLength = 500
+
+// interruptedJobError is recorded on jobs that ran past the run timeout
+const interruptedJobError = "export was interrupted before it finished; please start it again"
+
+// ExportWorker runs queued export jobs one at a time. Each export is written
+// to a temporary file first, as storage needs the size up front, then kept in
+// storage until it expires. Jobs whose worker died mid-export are failed once
+// they have been running for longer than the run timeout.
+type ExportWorker struct {
+	jobRepo    ports.ExportJobRepository
+	exporter   ports.ExportService
+	store      storage.Storage
+	retention  time.Duration
+	interval   time.Duration
+	runTimeout time.Duration // 0: running jobs are never failed
+
+	stop     chan struct{}
+	stopOnce sync.Once
+}
+
+// NewExportWorker creates a new ExportWorker instance; runTimeout must exceed
+// the longest export, as another instance may still be running the job
+func NewExportWorker(jobRepo ports.ExportJobRepository, exporter ports.ExportService, store storage.Storage, retention, interval, runTimeout time.Duration) *ExportWorker {
+	return &ExportWorker{
+		jobRepo:    jobRepo,
+		exporter:   exporter,
+		store:      store,
+		retention:  retention,
+		interval:   interval,
+		runTimeout: runTimeout,
+		stop:       make(chan struct{}),
+	}
+}
+
+// Start checks for queued jobs and expired files every interval until Stop is
+// called; ctx must reach every tenant's jobs
+func (w *ExportWorker) Start(ctx context.Context) {
+	if w.interval <= 0 {
+		return
+	}
+
+	go func() {
+		ticker := time.NewTicker(w.interval)
+		defer ticker.Stop()
+		for {
+			if err := w.FailStale(ctx, time.Now().UTC()); err != nil {
+				log.Printf("Warning: interrupted export cleanup failed: %v", err)
+			}
+			if err := w.RunPending(ctx); err != nil {
+				log.Printf("Warning: export jobs failed to run: %v", err)
+			}
+			if err := w.PurgeExpired(ctx, time.Now().UTC()); err != nil {
+				log.Printf("Warning: expired export cleanup failed: %v", err)
+			}
+
+			select {
+			case <-w.stop:
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+// Stop stops the worker after the job being run, if any
+func (w *ExportWorker) Stop() {
+	w.stopOnce.Do(func() { close(w.stop) })
+}
+
+// RunPending runs queued jobs until none is left or Stop is called. A failing
+// job is recorded as failed; only errors claiming or saving jobs are returned.
+func (w *ExportWorker) RunPending(ctx context.Context) error {
+	for {
+		select {
+		case <-w.stop:
+			return nil
+		default:
+		}
+
+		job, err := w.jobRepo.ClaimNext(ctx, time.Now().UTC())
+		if err != nil || job == nil {
+			return err
+		}
+
+		// The export sees what its requester sees in the job's tenant
+		jobCtx := tenancy.WithTenant(ctx, job.TenantID)
+		rows, size, err := w.run(jobCtx, job)
+		now := time.Now().UTC()
+		job.CompletedAt = &now
+		if err != nil {
+			log.Printf("Warning: export job %s failed: %v", job.ID, err)
+			job.Status = domain.JobStatusFailed
+			job.Error = truncate(err.Error(), maxJobErrorLength)
+		} else {
+			expiresAt := now.Add(w.retention)
+			job.Status = domain.JobStatusCompleted
+			job.RowCount = rows
+			job.FileSize = size
+			job.ExpiresAt = &expiresAt
+		}
+		if err := w.jobRepo.Update(jobCtx, job); err != nil {
+			return err
+		}
+	}
+}
+
+// FailStale fails the jobs that have been running for longer than the run
+// timeout, as the worker running them must have stopped before finishing
+func (w *ExportWorker) FailStale(ctx context.Context, now time.Time) error {
+	if w.runTimeout <= 0 {
+		return nil
+	}
+	failed, err := w.jobRepo.FailStale(ctx, now.Add(-w.runTimeout), now, interruptedJobError)
+	if err != nil {
+		return err
+	}
+	if failed > 0 {
+		log.Printf("Warning: failed %d interrupted export job(s)", failed)
+	}
+	return nil
+}
+
+// run writes the job's export and stores it under exports/<tenant>/<job>.<format>
+func (w *ExportWorker) run(ctx context.Context, job *domain.ExportJob) (int, int64, error) {
+	file, err := os.CreateTemp("", "export-*."+string(job.Format))
+	if err != nil {
+		return 0, 0, err
+	}
+	defer os.Remove(file.Name())
+	defer file.Close()
+
+	buffered := bufio.NewWriter(file)
+	rows, err := w.exporter.Export(ctx, buffered, job.Format, job.Filter)
+	if err != nil {
+		return 0, 0, err
+	}
+	if err := buffered.Flush(); err != nil {
+		return 0, 0, err
+	}
+	size, err := file.Seek(0, io.SeekCurrent)
+	if err != nil {
+		return 0, 0, err
+	}
+	if _, err := file.Seek(0, io.SeekStart); err != nil {
+		return 0, 0, err
+	}
+
+	job.StorageKey = fmt.Sprintf("exports/%s/%s.%s", job.TenantID, job.ID, job.Format)
+	if err := w.store.Put(ctx, job.StorageKey, file, size, job.Format.ContentType()); err != nil {
+		return 0, 0, err
+	}
+	return rows, size, nil
+}
+
+// PurgeExpired deletes the files and jobs of exports that expired before now
+func (w *ExportWorker) PurgeExpired(ctx context.Context, now time.Time) error {
+	for {
+		jobs, err := w.jobRepo.ListExpired(ctx, now, expiredExportBatch)
+		if err != nil {
+			return err
+		}
+		for _, job := range jobs {
+			if err := w.store.Delete(ctx, job.StorageKey); err != nil {
+				return err
+			}
+			if err := w.jobRepo.Delete(ctx, job.ID); err != nil {
+				return err
+			}
+		}
+		if len(jobs) < expiredExportBatch {
+			return nil
+		}
+	}
+}
+
+func truncate(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+	return strings.ToValidUTF8(s[:n], "")
+}
@@ -0,0 +1,68 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Kind is the kind of records a CSV file of an import holds
+type Kind string
+
+const (
+	KindActors    Kind = "actors"
+	KindUsers     Kind = "users"
+	KindWorkflows Kind = "workflows" // Workflows with their steps, one step per row
+	KindRequests  Kind = "requests"  // Historical, already decided requests
+)
+
+// Kinds lists the kinds in the order they are imported, so each file can
+// refer to records created by the files before it
+var Kinds = []Kind{KindActors, KindUsers, KindWorkflows, KindRequests}
+
+var (
+	ErrNoFiles     = errors.New("at least one CSV file is required: actors, users, workflows or requests")
+	ErrUnknownKind = errors.New("unknown import file")
+)
+
+// ParseKind parses the name of an import file
+func ParseKind(s string) (Kind, error) {
+	for _, kind := range Kinds {
+		if string(kind) == s {
+			return kind, nil
+		}
+	}
+	return "", fmt.Errorf("%w %q, use actors, users, workflows or requests", ErrUnknownKind, s)
+}
+
+// RowError reports why a row of an import file was rejected. Rows are
+// numbered as in a spreadsheet: the header is row 1.
+type RowError struct {
+	File    Kind   `json:"file"`
+	Row     int    `json:"row"`
+	Column  string `json:"column,omitempty"`
+	Message string `json:"message"`
+}
+
+// Result is the outcome of an import. An import is all-or-nothing: it is
+// committed only when no row has errors and it is not a dry run.
+type Result struct {
+	DryRun    bool           `json:"dry_run"`
+	Committed bool           `json:"committed"`
+	Created   map[string]int `json:"created"` // Records created per table (actors, users, workflows, workflow_steps, requests), or that would be by a dry run
+	Errors    []RowError     `json:"errors"`
+}
+
+// NewResult creates an empty result
+func NewResult(dryRun bool) *Result {
+	return &Result{DryRun: dryRun, Created: make(map[string]int), Errors: []RowError{}}
+}
+
+// AddError records a row error
+func (r *Result) AddError(file Kind, row int, column, message string) {
+	r.Errors = append(r.Errors, RowError{File: file, Row: row, Column: column, Message: message})
+}
+
+// Valid reports whether every row was accepted
+func (r *Result) Valid() bool {
+	return len(r.Errors) == 0
+}
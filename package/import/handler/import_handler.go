@@ -0,0 +1,115 @@
+package handler
+
+import (
+	"errors"
+	"io"
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+
+	"workflow-approval/package/import/domain"
+	"workflow-approval/package/import/ports"
+)
+
+// ImportHandler handles HTTP requests for bulk imports
+type ImportHandler struct {
+	importService ports.ImportService
+}
+
+// NewImportHandler creates a new ImportHandler instance
+func NewImportHandler(importService ports.ImportService) *ImportHandler {
+	return &ImportHandler{
+		importService: importService,
+	}
+}
+
+// Routes defines all routes for imports
+// Mounts routes under /api/imports (admin only)
+func (h *ImportHandler) Routes(group fiber.Router) {
+	// POST /api/imports - Import CSV files of actors, users, workflows and requests
+	// Multipart fields: actors, users, workflows, requests (any of them); query param: dry_run=true
+	group.Post("", h.Import)
+}
+
+// Import validates the uploaded CSV files and, unless it is a dry run,
+// creates all their records or none
+// POST /api/imports?dry_run=true
+func (h *ImportHandler) Import(c *fiber.Ctx) error {
+	dryRun := false
+	if raw := c.Query("dry_run"); raw != "" {
+		v, err := strconv.ParseBool(raw)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"success": false,
+				"data":    nil,
+				"error":   "dry_run must be true or false",
+			})
+		}
+		dryRun = v
+	}
+
+	form, err := c.MultipartForm()
+	if err != nil {
+		return importError(c, domain.ErrNoFiles)
+	}
+	files := make(map[domain.Kind]io.Reader, len(form.File))
+	for name, headers := range form.File {
+		kind, err := domain.ParseKind(name)
+		if err != nil {
+			return importError(c, err)
+		}
+		if len(headers) != 1 {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"success": false,
+				"data":    nil,
+				"error":   "Upload one " + name + " file",
+			})
+		}
+		file, err := headers[0].Open()
+		if err != nil {
+			return importError(c, err)
+		}
+		defer file.Close()
+		files[kind] = file
+	}
+
+	result, err := h.importService.Import(c.Context(), files, dryRun)
+	if err != nil {
+		return importError(c, err)
+	}
+
+	if !result.Valid() {
+		return c.Status(fiber.StatusUnprocessableEntity).JSON(fiber.Map{
+			"success": false,
+			"data":    result,
+			"error":   "Import has invalid rows; nothing was imported",
+			"code":    "IMPORT_INVALID",
+		})
+	}
+
+	status := fiber.StatusCreated
+	if dryRun {
+		status = fiber.StatusOK
+	}
+	return c.Status(status).JSON(fiber.Map{
+		"success": true,
+		"data":    result,
+		"error":   nil,
+	})
+}
+
+// importError maps import errors to HTTP responses
+func importError(c *fiber.Ctx, err error) error {
+	status := fiber.StatusInternalServerError
+	message := "Failed to import"
+	switch {
+	case errors.Is(err, domain.ErrNoFiles), errors.Is(err, domain.ErrUnknownKind):
+		status, message = fiber.StatusBadRequest, err.Error()
+	}
+
+	return c.Status(status).JSON(fiber.Map{
+		"success": false,
+		"data":    nil,
+		"error":   message,
+	})
+}
@@ -0,0 +1,51 @@
+package ports
+
+import (
+	"context"
+	"io"
+
+	actorPorts "workflow-approval/package/actor/ports"
+	historyPorts "workflow-approval/package/approval_history/ports"
+	"workflow-approval/package/import/domain"
+	reqPorts "workflow-approval/package/request/ports"
+	userDomain "workflow-approval/package/user/domain"
+	userPorts "workflow-approval/package/user/ports"
+	wfPorts "workflow-approval/package/workflow/ports"
+	stepPorts "workflow-approval/package/workflow_step/ports"
+)
+
+// Services are the services and repositories an import writes through, all
+// bound to the import's transaction. Records are created by the same services
+// as the API uses, so imported rows pass the same validations.
+type Services struct {
+	Actors    actorPorts.ActorService
+	Users     userPorts.UserService // Without an account notifier: emails are only sent once committed
+	Workflows wfPorts.WorkflowService
+	Steps     stepPorts.WorkflowStepService
+
+	ActorRepo    actorPorts.ActorRepository
+	UserRepo     userPorts.UserRepository
+	WorkflowRepo wfPorts.WorkflowRepository
+	StepRepo     stepPorts.WorkflowStepRepository
+	RequestRepo  reqPorts.RequestRepository
+	HistoryRepo  historyPorts.ApprovalHistoryRepository
+}
+
+// Transactor runs fn in a database transaction with Services bound to it. The
+// transaction is committed when fn returns nil and rolled back otherwise.
+type Transactor interface {
+	Transaction(ctx context.Context, fn func(Services) error) error
+}
+
+// AccountNotifier sends account lifecycle emails (implemented by the auth account service)
+type AccountNotifier interface {
+	SendEmailVerification(ctx context.Context, user *userDomain.User) error
+}
+
+// ImportService defines the interface for bulk import business logic
+type ImportService interface {
+	// Import reads CSV files of each kind and creates their records in one
+	// transaction. Row errors don't fail the call: they are reported in the
+	// result, and nothing is written unless every row is valid.
+	Import(ctx context.Context, files map[domain.Kind]io.Reader, dryRun bool) (*domain.Result, error)
+}
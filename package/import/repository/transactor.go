@@ -0,0 +1,28 @@
+package repository
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+
+	"workflow-approval/package/import/ports"
+)
+
+// TransactorImpl implements Transactor interface
+type TransactorImpl struct {
+	db          *gorm.DB
+	newServices func(tx *gorm.DB) ports.Services
+}
+
+// NewTransactor creates a new TransactorImpl instance; newServices builds the
+// services of an import on its transaction
+func NewTransactor(db *gorm.DB, newServices func(tx *gorm.DB) ports.Services) ports.Transactor {
+	return &TransactorImpl{db: db, newServices: newServices}
+}
+
+// Transaction runs fn in a transaction
+func (t *TransactorImpl) Transaction(ctx context.Context, fn func(ports.Services) error) error {
+	return t.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return fn(t.newServices(tx))
+	})
+}
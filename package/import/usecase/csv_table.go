@@ -0,0 +1,101 @@
+package usecase
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"workflow-approval/package/import/domain"
+)
+
+// table is a parsed CSV file: a header row naming the columns, then one
+// record per row
+type table struct {
+	kind    domain.Kind
+	columns map[string]int
+	header  []string
+	rows    [][]string
+}
+
+// readTable parses a CSV file, checking its header against the columns the
+// kind accepts. Problems with the file itself are returned as row errors.
+func readTable(kind domain.Kind, r io.Reader, allowed func(column string) bool, required []string, maxRows int) (*table, []domain.RowError) {
+	reader := csv.NewReader(r)
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, []domain.RowError{{File: kind, Row: 1, Message: "file is empty"}}
+		}
+		return nil, []domain.RowError{csvError(kind, err)}
+	}
+
+	t := &table{kind: kind, columns: make(map[string]int, len(header)), header: header}
+	var errs []domain.RowError
+	for i, column := range header {
+		// Spreadsheet applications often save CSV with a byte order mark
+		column = strings.TrimSpace(strings.TrimPrefix(column, "\ufeff"))
+		header[i] = column
+		switch {
+		case !allowed(column):
+			errs = append(errs, domain.RowError{File: kind, Row: 1, Column: column, Message: "unknown column"})
+		case t.has(column):
+			errs = append(errs, domain.RowError{File: kind, Row: 1, Column: column, Message: "column is given more than once"})
+		default:
+			t.columns[column] = i
+		}
+	}
+	for _, column := range required {
+		if !t.has(column) {
+			errs = append(errs, domain.RowError{File: kind, Row: 1, Column: column, Message: "required column is missing"})
+		}
+	}
+	if len(errs) > 0 {
+		return nil, errs
+	}
+
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, []domain.RowError{csvError(kind, err)}
+		}
+		if len(t.rows) == maxRows {
+			return nil, []domain.RowError{{File: kind, Row: len(t.rows) + 2, Message: fmt.Sprintf("file has more than %d rows", maxRows)}}
+		}
+		t.rows = append(t.rows, record)
+	}
+	return t, nil
+}
+
+func csvError(kind domain.Kind, err error) domain.RowError {
+	var parseErr *csv.ParseError
+	if errors.As(err, &parseErr) {
+		return domain.RowError{File: kind, Row: parseErr.StartLine, Message: parseErr.Err.Error()}
+	}
+	return domain.RowError{File: kind, Message: err.Error()}
+}
+
+func (t *table) has(column string) bool {
+	_, ok := t.columns[column]
+	return ok
+}
+
+// get returns the trimmed value of a column in a row, empty when the file has no such column
+func (t *table) get(row []string, column string) string {
+	i, ok := t.columns[column]
+	if !ok {
+		return ""
+	}
+	return strings.TrimSpace(row[i])
+}
+
+// rowNumber returns the spreadsheet row number of the i-th record
+func rowNumber(i int) int {
+	return i + 2
+}
@@ -0,0 +1,609 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	actorRepo "workflow-approval/package/actor/repository"
+	historyDomain "workflow-approval/package/approval_history/domain"
+	"workflow-approval/package/import/domain"
+	"workflow-approval/package/import/ports"
+	reqDomain "workflow-approval/package/request/domain"
+	reqPorts "workflow-approval/package/request/ports"
+	reqUsecase "workflow-approval/package/request/usecase"
+	userDomain "workflow-approval/package/user/domain"
+	userRepo "workflow-approval/package/user/repository"
+	wfDomain "workflow-approval/package/workflow/domain"
+	wfRepo "workflow-approval/package/workflow/repository"
+	stepDomain "workflow-approval/package/workflow_step/domain"
+	stepRepo "workflow-approval/package/workflow_step/repository"
+	"workflow-approval/utils/money"
+)
+
+// maxImportLevels is the highest level the level_<n>_* columns of a requests file may have
+const maxImportLevels = 20
+
+// fieldColumnPrefix prefixes the columns of a requests file holding custom form fields
+const fieldColumnPrefix = "fields."
+
+var (
+	actorColumns    = []string{"name", "code"}
+	userColumns     = []string{"email", "name", "password", "is_admin", "actors"}
+	workflowColumns = []string{"workflow", "workflow_id", "form", "level", "assignee_type", "actor_code",
+		"manager_level", "lookup_field", "min_amount", "max_amount", "roles", "field_conditions"}
+	requestColumns = []string{"workflow", "workflow_id", "requester_email", "title", "description", "amount",
+		"currency", "exchange_rate", "status", "created_at", "submitted_at"}
+	levelColumns = []string{"action", "approver_email", "decided_at", "comment"}
+
+	levelColumnPattern = regexp.MustCompile(`^level_([0-9]+)_(.+)$`)
+)
+
+// columnAllowed reports whether a file of each kind may have a column
+var columnAllowed = map[domain.Kind]func(string) bool{
+	domain.KindActors:    oneOf(actorColumns),
+	domain.KindUsers:     oneOf(userColumns),
+	domain.KindWorkflows: oneOf(workflowColumns),
+	domain.KindRequests: func(column string) bool {
+		if oneOf(requestColumns)(column) {
+			return true
+		}
+		if name, ok := strings.CutPrefix(column, fieldColumnPrefix); ok {
+			return wfDomain.ValidFieldName(name)
+		}
+		level, name, ok := levelColumn(column)
+		return ok && level >= 1 && level <= maxImportLevels && oneOf(levelColumns)(name)
+	},
+}
+
+// requiredColumns lists the columns each kind of file must have
+var requiredColumns = map[domain.Kind][]string{
+	domain.KindActors:    {"name", "code"},
+	domain.KindUsers:     {"email", "name", "password"},
+	domain.KindWorkflows: {},
+	domain.KindRequests:  {"requester_email", "title", "amount", "status", "created_at"},
+}
+
+func oneOf(columns []string) func(string) bool {
+	return func(column string) bool {
+		for _, c := range columns {
+			if c == column {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// levelColumn splits a level_<n>_<name> column
+func levelColumn(column string) (int, string, bool) {
+	m := levelColumnPattern.FindStringSubmatch(column)
+	if m == nil {
+		return 0, "", false
+	}
+	level, err := strconv.Atoi(m[1])
+	if err != nil {
+		return 0, "", false
+	}
+	return level, m[2], true
+}
+
+// importRun creates the records of one import inside its transaction
+type importRun struct {
+	ctx      context.Context
+	services ports.Services
+	rates    reqPorts.ExchangeRateProvider
+	result   *domain.Result
+
+	users     []*userDomain.User            // Created users, to be sent a verification email
+	workflows map[string]*wfDomain.Workflow // Workflows created by name; nil when creating one failed
+}
+
+func newImportRun(ctx context.Context, services ports.Services, rates reqPorts.ExchangeRateProvider, result *domain.Result) *importRun {
+	return &importRun{
+		ctx:       ctx,
+		services:  services,
+		rates:     rates,
+		result:    result,
+		workflows: make(map[string]*wfDomain.Workflow),
+	}
+}
+
+// rowError records an error of a row; FieldErrors of form validation are
+// reported on the column of each field
+func (r *importRun) rowError(t *table, i int, column string, err error) {
+	var fieldErrs wfDomain.FieldErrors
+	if errors.As(err, &fieldErrs) {
+		for _, fe := range fieldErrs {
+			r.result.AddError(t.kind, rowNumber(i), fieldColumnPrefix+fe.Field, fe.Message)
+		}
+		return
+	}
+	r.result.AddError(t.kind, rowNumber(i), column, err.Error())
+}
+
+// importActors creates an actor per row: name, code
+func (r *importRun) importActors(t *table) error {
+	for i, row := range t.rows {
+		if err := r.ctx.Err(); err != nil {
+			return err
+		}
+		if _, err := r.services.Actors.CreateActor(r.ctx, t.get(row, "name"), t.get(row, "code")); err != nil {
+			r.rowError(t, i, "", err)
+			continue
+		}
+		r.result.Created[string(t.kind)]++
+	}
+	return nil
+}
+
+// importUsers registers a user per row: email, name, password, is_admin and
+// actors, a ";" separated list of actor codes each optionally suffixed with
+// ":primary" or ":backup"
+func (r *importRun) importUsers(t *table) error {
+	for i, row := range t.rows {
+		if err := r.ctx.Err(); err != nil {
+			return err
+		}
+
+		isAdmin := false
+		if raw := t.get(row, "is_admin"); raw != "" {
+			v, err := strconv.ParseBool(raw)
+			if err != nil {
+				r.result.AddError(t.kind, rowNumber(i), "is_admin", "must be true or false")
+				continue
+			}
+			isAdmin = v
+		}
+		actors, err := r.actorAssignments(t.get(row, "actors"))
+		if err != nil {
+			r.rowError(t, i, "actors", err)
+			continue
+		}
+
+		user, err := r.services.Users.Register(r.ctx, t.get(row, "email"), t.get(row, "password"), t.get(row, "name"), isAdmin, actors)
+		if err != nil {
+			r.rowError(t, i, "", err)
+			continue
+		}
+		r.users = append(r.users, user)
+		r.result.Created[string(t.kind)]++
+	}
+	return nil
+}
+
+func (r *importRun) actorAssignments(raw string) ([]userDomain.ActorAssignment, error) {
+	var assignments []userDomain.ActorAssignment
+	for _, item := range strings.Split(raw, ";") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+		code, role, _ := strings.Cut(item, ":")
+		actor, err := r.services.ActorRepo.GetByCode(r.ctx, strings.TrimSpace(code))
+		if err != nil {
+			if errors.Is(err, actorRepo.ErrActorNotFound) {
+				return nil, fmt.Errorf("unknown actor code %q", code)
+			}
+			return nil, err
+		}
+
+		assignment := userDomain.ActorAssignment{ActorID: actor.ID}
+		switch strings.ToLower(strings.TrimSpace(role)) {
+		case "":
+		case "primary":
+			assignment.IsPrimary = true
+		case "backup":
+			assignment.IsBackup = true
+		default:
+			return nil, fmt.Errorf("unknown actor role %q, use primary or backup", role)
+		}
+		assignments = append(assignments, assignment)
+	}
+	return assignments, nil
+}
+
+// importWorkflows creates a workflow step per row. The workflow is named by
+// the workflow column and created with the row's form (a JSON form schema)
+// the first time the name appears, or is an existing one given by
+// workflow_id. Rows without a level only create the workflow.
+func (r *importRun) importWorkflows(t *table) error {
+	for i, row := range t.rows {
+		if err := r.ctx.Err(); err != nil {
+			return err
+		}
+
+		workflow, column, err := r.workflowOf(t, row, true)
+		if err != nil {
+			r.rowError(t, i, column, err)
+			continue
+		}
+		if workflow == nil {
+			// Creating the workflow failed on an earlier row, which reported why
+			continue
+		}
+		if t.get(row, "level") == "" {
+			continue
+		}
+
+		level, assignee, conditions, column, err := r.stepOf(t, row)
+		if err != nil {
+			r.rowError(t, i, column, err)
+			continue
+		}
+		if _, err := r.services.Steps.CreateStep(r.ctx, workflow.ID, level, assignee, conditions); err != nil {
+			r.rowError(t, i, "", err)
+			continue
+		}
+		r.result.Created["workflow_steps"]++
+	}
+	return nil
+}
+
+// workflowOf returns the workflow of a row and, on errors, the column at
+// fault. With create set a workflow named for the first time is created.
+func (r *importRun) workflowOf(t *table, row []string, create bool) (*wfDomain.Workflow, string, error) {
+	name, id := t.get(row, "workflow"), t.get(row, "workflow_id")
+	switch {
+	case name != "" && id != "":
+		return nil, "workflow", errors.New("give either workflow or workflow_id, not both")
+	case id != "":
+		workflow, err := r.services.WorkflowRepo.GetByID(r.ctx, id)
+		if err != nil {
+			if errors.Is(err, wfRepo.ErrWorkflowNotFound) {
+				return nil, "workflow_id", reqUsecase.ErrWorkflowNotFound
+			}
+			return nil, "workflow_id", err
+		}
+		return workflow, "", nil
+	case name == "":
+		return nil, "workflow", errors.New("workflow or workflow_id is required")
+	}
+
+	if workflow, seen := r.workflows[name]; seen {
+		return workflow, "", nil
+	}
+	if !create {
+		return nil, "workflow", fmt.Errorf("workflow %q is not created by this import, refer to existing workflows by workflow_id", name)
+	}
+
+	// Remember failures so later rows of the workflow don't repeat the error
+	r.workflows[name] = nil
+	var form wfDomain.FormSchema
+	if raw := t.get(row, "form"); raw != "" {
+		if err := json.Unmarshal([]byte(raw), &form); err != nil {
+			return nil, "form", fmt.Errorf("form must be a JSON form schema: %v", err)
+		}
+	}
+	workflow, err := r.services.Workflows.CreateWorkflow(r.ctx, name, form)
+	if err != nil {
+		return nil, "", err
+	}
+	r.workflows[name] = workflow
+	r.result.Created["workflows"]++
+	return workflow, "", nil
+}
+
+// stepOf parses the step columns of a workflows row
+func (r *importRun) stepOf(t *table, row []string) (int, stepDomain.StepAssignee, stepDomain.StepConditions, string, error) {
+	var assignee stepDomain.StepAssignee
+	var conditions stepDomain.StepConditions
+
+	level, err := strconv.Atoi(t.get(row, "level"))
+	if err != nil {
+		return 0, assignee, conditions, "level", errors.New("level must be a whole number")
+	}
+
+	assignee.Type = stepDomain.AssigneeType(t.get(row, "assignee_type"))
+	assignee.LookupField = t.get(row, "lookup_field")
+	if raw := t.get(row, "manager_level"); raw != "" {
+		if assignee.ManagerLevel, err = strconv.Atoi(raw); err != nil {
+			return 0, assignee, conditions, "manager_level", errors.New("manager_level must be a whole number")
+		}
+	}
+	if code := t.get(row, "actor_code"); code != "" {
+		actor, err := r.services.ActorRepo.GetByCode(r.ctx, code)
+		if err != nil {
+			if errors.Is(err, actorRepo.ErrActorNotFound) {
+				return 0, assignee, conditions, "actor_code", fmt.Errorf("unknown actor code %q", code)
+			}
+			return 0, assignee, conditions, "actor_code", err
+		}
+		assignee.ActorID = actor.ID
+	}
+
+	for _, column := range []string{"min_amount", "max_amount"} {
+		raw := t.get(row, column)
+		if raw == "" {
+			continue
+		}
+		amount, err := money.ParseDecimal(raw)
+		if err != nil {
+			return 0, assignee, conditions, column, err
+		}
+		if column == "min_amount" {
+			conditions.MinAmount = amount
+		} else {
+			conditions.MaxAmount = amount
+		}
+	}
+	for _, role := range strings.Split(t.get(row, "roles"), ";") {
+		if role = strings.TrimSpace(role); role != "" {
+			conditions.Roles = append(conditions.Roles, role)
+		}
+	}
+	if raw := t.get(row, "field_conditions"); raw != "" {
+		if err := json.Unmarshal([]byte(raw), &conditions.Fields); err != nil {
+			return 0, assignee, conditions, "field_conditions", fmt.Errorf("field_conditions must be a JSON list of conditions: %v", err)
+		}
+	}
+	return level, assignee, conditions, "", nil
+}
+
+// importRequests creates a decided request per row with the approval history
+// of its level_<n>_* columns. Requests are checked like new ones (amount,
+// currency and form fields) but skip routing: each decision is recorded
+// against the workflow step of its level as it happened.
+func (r *importRun) importRequests(t *table) error {
+	for i, row := range t.rows {
+		if err := r.ctx.Err(); err != nil {
+			return err
+		}
+		if column, err := r.importRequest(t, row); err != nil {
+			r.rowError(t, i, column, err)
+			continue
+		}
+		r.result.Created[string(t.kind)]++
+	}
+	return nil
+}
+
+// importRequest creates the request of a row, returning the column at fault on errors
+func (r *importRun) importRequest(t *table, row []string) (string, error) {
+	workflow, column, err := r.workflowOf(t, row, false)
+	if err != nil {
+		return column, err
+	}
+	if workflow == nil {
+		return "workflow", errors.New("the workflow of this request could not be created")
+	}
+	requester, err := r.userByEmail(t.get(row, "requester_email"))
+	if err != nil {
+		return "requester_email", err
+	}
+
+	status := reqDomain.RequestStatus(strings.ToUpper(t.get(row, "status")))
+	if status != reqDomain.StatusApproved && status != reqDomain.StatusRejected {
+		return "status", errors.New("status must be APPROVED or REJECTED")
+	}
+	createdAt, err := parseTime(t.get(row, "created_at"))
+	if err != nil {
+		return "created_at", err
+	}
+	submittedAt := createdAt
+	if raw := t.get(row, "submitted_at"); raw != "" {
+		if submittedAt, err = parseTime(raw); err != nil {
+			return "submitted_at", err
+		}
+		if submittedAt.Before(createdAt) {
+			return "submitted_at", errors.New("submitted_at is before created_at")
+		}
+	}
+
+	currency := r.rates.BaseCurrency()
+	if raw := t.get(row, "currency"); raw != "" {
+		if currency, err = money.ParseCurrency(raw); err != nil {
+			return "currency", reqUsecase.ErrInvalidCurrency
+		}
+	}
+	amount, err := money.ParseDecimal(t.get(row, "amount"))
+	if err != nil {
+		return "amount", err
+	}
+	switch {
+	case amount.Sign() <= 0:
+		return "amount", reqUsecase.ErrRequestAmountPositive
+	case !currency.Fits(amount):
+		return "amount", reqUsecase.ErrAmountPrecision
+	}
+
+	fields := requestFields(t, row, workflow.FormSchema)
+	if err := workflow.FormSchema.Validate(fields); err != nil {
+		return "", err
+	}
+
+	request := reqDomain.NewRequest(workflow.ID, requester.ID, amount, currency, t.get(row, "title"), t.get(row, "description"), fields)
+	rate, column, err := r.rateOf(t, row, currency)
+	if err != nil {
+		return column, err
+	}
+	if err := request.ApplyRate(rate); err != nil {
+		return "exchange_rate", err
+	}
+
+	histories, column, err := r.decisions(t, row, request, status, submittedAt)
+	if err != nil {
+		return column, err
+	}
+	last := histories[len(histories)-1]
+	request.Status = status
+	request.CurrentStep = last.StepLevel
+	request.SubmittedAt = &submittedAt
+	request.CreatedAt = createdAt
+	request.UpdatedAt = last.CreatedAt
+
+	if err := r.services.RequestRepo.Create(r.ctx, request); err != nil {
+		return "", err
+	}
+	for _, history := range histories {
+		if err := r.services.HistoryRepo.Create(r.ctx, history); err != nil {
+			return "", err
+		}
+	}
+	return "", nil
+}
+
+// rateOf returns the exchange_rate of a row, or the current rate of its currency
+func (r *importRun) rateOf(t *table, row []string, currency money.Currency) (money.Rate, string, error) {
+	if raw := t.get(row, "exchange_rate"); raw != "" {
+		rate, err := money.ParseRate(raw)
+		if err != nil {
+			return money.Rate{}, "exchange_rate", err
+		}
+		return rate, "", nil
+	}
+	if currency == r.rates.BaseCurrency() {
+		return money.RateOne, "", nil
+	}
+	rate, err := r.rates.RateFor(r.ctx, currency)
+	if err != nil {
+		return money.Rate{}, "currency", err
+	}
+	return rate, "", nil
+}
+
+// decisions builds the approval history of a request from its level columns.
+// Levels run from 1 without gaps; every level but the last was approved and
+// the last decided the request's status. Resolved approvers are recorded on
+// the request as they would have been when it reached each level.
+func (r *importRun) decisions(t *table, row []string, request *reqDomain.Request, status reqDomain.RequestStatus, submittedAt time.Time) ([]*historyDomain.ApprovalHistory, string, error) {
+	levels := levelsOf(t, row)
+	if len(levels) == 0 {
+		return nil, "level_1_action", errors.New("a decided request needs the decision of at least level 1")
+	}
+
+	histories := make([]*historyDomain.ApprovalHistory, 0, len(levels))
+	decidedBefore := submittedAt
+	for i, level := range levels {
+		column := func(name string) string { return fmt.Sprintf("level_%d_%s", level, name) }
+		if level != i+1 {
+			return nil, column("action"), fmt.Errorf("level %d has no decision", i+1)
+		}
+
+		action := historyDomain.ApprovalAction(strings.ToUpper(t.get(row, column("action"))))
+		want := historyDomain.ApprovalActionApprove
+		if i == len(levels)-1 && status == reqDomain.StatusRejected {
+			want = historyDomain.ApprovalActionReject
+		}
+		if action != want {
+			return nil, column("action"), fmt.Errorf("action must be %s for a %s request", want, status)
+		}
+
+		step, err := r.services.StepRepo.GetByWorkflowAndLevel(r.ctx, request.WorkflowID, level)
+		if err != nil {
+			if errors.Is(err, stepRepo.ErrStepNotFound) {
+				return nil, column("action"), fmt.Errorf("the workflow has no step at level %d", level)
+			}
+			return nil, column("action"), err
+		}
+		approver, err := r.userByEmail(t.get(row, column("approver_email")))
+		if err != nil {
+			return nil, column("approver_email"), err
+		}
+		decidedAt, err := parseTime(t.get(row, column("decided_at")))
+		if err != nil {
+			return nil, column("decided_at"), err
+		}
+		if decidedAt.Before(decidedBefore) {
+			return nil, column("decided_at"), errors.New("decisions must follow the submission and each other")
+		}
+		decidedBefore = decidedAt
+
+		actorID := step.ActorID
+		if actorID == "" {
+			actorID = approver.PrimaryActorID()
+		}
+		request.AssignApprover(reqDomain.StepApprover{
+			Level:        level,
+			AssigneeType: string(step.Assignee().Type),
+			ActorID:      step.ActorID,
+			UserID:       approver.ID,
+			ResolvedAt:   decidedAt,
+		})
+		history := historyDomain.NewApprovalHistory(request.ID, request.WorkflowID, level, actorID, approver.ID, action, t.get(row, column("comment")))
+		history.CreatedAt = decidedAt
+		histories = append(histories, history)
+	}
+	return histories, "", nil
+}
+
+// levelsOf returns the levels a row has any level_<n>_* value for, in order
+func levelsOf(t *table, row []string) []int {
+	seen := make(map[int]bool)
+	for column := range t.columns {
+		level, _, ok := levelColumn(column)
+		if ok && !seen[level] && t.get(row, column) != "" {
+			seen[level] = true
+		}
+	}
+	levels := make([]int, 0, len(seen))
+	for level := range seen {
+		levels = append(levels, level)
+	}
+	sort.Ints(levels)
+	return levels
+}
+
+func (r *importRun) userByEmail(email string) (*userDomain.User, error) {
+	if email == "" {
+		return nil, errors.New("email is required")
+	}
+	user, err := r.services.UserRepo.GetByEmail(r.ctx, email)
+	if err != nil {
+		if errors.Is(err, userRepo.ErrUserNotFound) {
+			return nil, fmt.Errorf("no user has email %q", email)
+		}
+		return nil, err
+	}
+	return user, nil
+}
+
+// requestFields collects the fields.<name> values of a row, converted to the
+// type of their form field so the form validates them as it would JSON
+func requestFields(t *table, row []string, form wfDomain.FormSchema) reqDomain.RequestFields {
+	fields := make(reqDomain.RequestFields)
+	for column := range t.columns {
+		name, ok := strings.CutPrefix(column, fieldColumnPrefix)
+		value := t.get(row, column)
+		if !ok || value == "" {
+			continue
+		}
+		fields[name] = value
+
+		field := form.Field(name)
+		if field == nil {
+			continue
+		}
+		switch field.Type {
+		case wfDomain.FieldNumber, wfDomain.FieldInteger:
+			if n, err := strconv.ParseFloat(value, 64); err == nil {
+				fields[name] = n
+			}
+		case wfDomain.FieldBoolean:
+			if b, err := strconv.ParseBool(value); err == nil {
+				fields[name] = b
+			}
+		}
+	}
+	return fields
+}
+
+// parseTime accepts RFC 3339 timestamps and plain dates, taken as midnight UTC
+func parseTime(s string) (time.Time, error) {
+	if s == "" {
+		return time.Time{}, errors.New("is required")
+	}
+	if t, err := time.Parse(time.RFC3339, s); err == nil {
+		return t.UTC(), nil
+	}
+	if t, err := time.Parse("2006-01-02", s); err == nil {
+		return t, nil
+	}
+	return time.Time{}, errors.New("must be an RFC 3339 timestamp or a YYYY-MM-DD date")
+}
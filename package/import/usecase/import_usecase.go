@@ -0,0 +1,107 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log"
+
+	"workflow-approval/package/import/domain"
+	"workflow-approval/package/import/ports"
+	reqPorts "workflow-approval/package/request/ports"
+	userDomain "workflow-approval/package/user/domain"
+)
+
+// maxImportRows caps the rows of each file; passwords are hashed row by row,
+// so a user file this size already takes about a minute
+const maxImportRows = 1000
+
+// errRollback ends the import transaction of a dry run or an import with row errors
+var errRollback = errors.New("import rolled back")
+
+// ImportServiceImpl implements ImportService interface
+type ImportServiceImpl struct {
+	transactor ports.Transactor
+	rates      reqPorts.ExchangeRateProvider
+	notifier   ports.AccountNotifier
+}
+
+// NewImportService creates a new ImportServiceImpl instance. Imported users are
+// sent their verification email through notifier once the import is committed.
+func NewImportService(transactor ports.Transactor, rates reqPorts.ExchangeRateProvider, notifier ports.AccountNotifier) ports.ImportService {
+	return &ImportServiceImpl{
+		transactor: transactor,
+		rates:      rates,
+		notifier:   notifier,
+	}
+}
+
+// Import reads the files, then creates their records in one transaction in
+// the order of domain.Kinds. Every row is attempted even after errors so the
+// result lists all of them; the transaction is only committed when there are
+// none and dryRun is not set.
+func (s *ImportServiceImpl) Import(ctx context.Context, files map[domain.Kind]io.Reader, dryRun bool) (*domain.Result, error) {
+	if len(files) == 0 {
+		return nil, domain.ErrNoFiles
+	}
+
+	result := domain.NewResult(dryRun)
+	tables := make(map[domain.Kind]*table, len(files))
+	for _, kind := range domain.Kinds {
+		r, ok := files[kind]
+		if !ok {
+			continue
+		}
+		t, errs := readTable(kind, r, columnAllowed[kind], requiredColumns[kind], maxImportRows)
+		result.Errors = append(result.Errors, errs...)
+		tables[kind] = t
+	}
+	if !result.Valid() {
+		return result, nil
+	}
+
+	var users []*userDomain.User
+	err := s.transactor.Transaction(ctx, func(services ports.Services) error {
+		run := newImportRun(ctx, services, s.rates, result)
+		for _, kind := range domain.Kinds {
+			t := tables[kind]
+			if t == nil {
+				continue
+			}
+			var err error
+			switch kind {
+			case domain.KindActors:
+				err = run.importActors(t)
+			case domain.KindUsers:
+				err = run.importUsers(t)
+			case domain.KindWorkflows:
+				err = run.importWorkflows(t)
+			case domain.KindRequests:
+				err = run.importRequests(t)
+			}
+			if err != nil {
+				return err
+			}
+		}
+		users = run.users
+
+		if dryRun || !result.Valid() {
+			return errRollback
+		}
+		return nil
+	})
+	if err != nil && !errors.Is(err, errRollback) {
+		return nil, err
+	}
+	result.Committed = err == nil
+
+	if result.Committed && s.notifier != nil {
+		for _, user := range users {
+			if err := s.notifier.SendEmailVerification(ctx, user); err != nil {
+				log.Printf("Warning: failed to send verification email to %s: %v", user.Email, err)
+			}
+		}
+	}
+
+	return result, nil
+}
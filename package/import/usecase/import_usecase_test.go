@@ -0,0 +1,396 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	actorDomain "workflow-approval/package/actor/domain"
+	actorPorts "workflow-approval/package/actor/ports"
+	actorRepo "workflow-approval/package/actor/repository"
+	historyDomain "workflow-approval/package/approval_history/domain"
+	historyPorts "workflow-approval/package/approval_history/ports"
+	"workflow-approval/package/import/domain"
+	"workflow-approval/package/import/ports"
+	reqDomain "workflow-approval/package/request/domain"
+	reqPorts "workflow-approval/package/request/ports"
+	userDomain "workflow-approval/package/user/domain"
+	userPorts "workflow-approval/package/user/ports"
+	userRepo "workflow-approval/package/user/repository"
+	wfDomain "workflow-approval/package/workflow/domain"
+	wfPorts "workflow-approval/package/workflow/ports"
+	wfRepo "workflow-approval/package/workflow/repository"
+	stepDomain "workflow-approval/package/workflow_step/domain"
+	stepPorts "workflow-approval/package/workflow_step/ports"
+	stepRepo "workflow-approval/package/workflow_step/repository"
+	"workflow-approval/utils/money"
+)
+
+// store holds the records created by an import. The fakes below embed the
+// port they implement and only define the methods an import calls.
+type store struct {
+	actors    map[string]*actorDomain.Actor // By code
+	users     map[string]*userDomain.User   // By email
+	workflows map[string]*wfDomain.Workflow // By ID
+	steps     []*stepDomain.WorkflowStep
+	requests  []*reqDomain.Request
+	histories []*historyDomain.ApprovalHistory
+}
+
+func newStore() *store {
+	return &store{
+		actors:    make(map[string]*actorDomain.Actor),
+		users:     make(map[string]*userDomain.User),
+		workflows: make(map[string]*wfDomain.Workflow),
+	}
+}
+
+type fakeActors struct {
+	actorPorts.ActorService
+	actorPorts.ActorRepository
+	s *store
+}
+
+func (f *fakeActors) CreateActor(ctx context.Context, name, code string) (*actorDomain.Actor, error) {
+	if name == "" || code == "" {
+		return nil, errors.New("name and code are required")
+	}
+	if _, ok := f.s.actors[code]; ok {
+		return nil, actorRepo.ErrActorAlreadyExist
+	}
+	actor := &actorDomain.Actor{ID: "actor-" + code, Name: name, Code: code}
+	f.s.actors[code] = actor
+	return actor, nil
+}
+
+func (f *fakeActors) GetByCode(ctx context.Context, code string) (*actorDomain.Actor, error) {
+	if actor, ok := f.s.actors[code]; ok {
+		return actor, nil
+	}
+	return nil, actorRepo.ErrActorNotFound
+}
+
+type fakeUsers struct {
+	userPorts.UserService
+	userPorts.UserRepository
+	s *store
+}
+
+func (f *fakeUsers) Register(ctx context.Context, email, password, name string, isAdmin bool, actors []userDomain.ActorAssignment) (*userDomain.User, error) {
+	if !isAdmin && len(actors) == 0 {
+		return nil, errors.New("actor_id is required for non-admin users")
+	}
+	if _, ok := f.s.users[email]; ok {
+		return nil, errors.New("user already exists")
+	}
+	user := userDomain.NewUser(email, password, name, isAdmin, actors)
+	f.s.users[email] = user
+	return user, nil
+}
+
+func (f *fakeUsers) GetByEmail(ctx context.Context, email string) (*userDomain.User, error) {
+	if user, ok := f.s.users[email]; ok {
+		return user, nil
+	}
+	return nil, userRepo.ErrUserNotFound
+}
+
+type fakeWorkflows struct {
+	wfPorts.WorkflowService
+	wfPorts.WorkflowRepository
+	s *store
+}
+
+func (f *fakeWorkflows) CreateWorkflow(ctx context.Context, name string, form wfDomain.FormSchema) (*wfDomain.Workflow, error) {
+	workflow := &wfDomain.Workflow{ID: fmt.Sprintf("wf-%d", len(f.s.workflows)+1), Name: name, FormSchema: form}
+	f.s.workflows[workflow.ID] = workflow
+	return workflow, nil
+}
+
+func (f *fakeWorkflows) GetByID(ctx context.Context, id string) (*wfDomain.Workflow, error) {
+	if workflow, ok := f.s.workflows[id]; ok {
+		return workflow, nil
+	}
+	return nil, wfRepo.ErrWorkflowNotFound
+}
+
+type fakeSteps struct {
+	stepPorts.WorkflowStepService
+	stepPorts.WorkflowStepRepository
+	s *store
+}
+
+func (f *fakeSteps) CreateStep(ctx context.Context, workflowID string, level int, assignee stepDomain.StepAssignee, conditions stepDomain.StepConditions) (*stepDomain.WorkflowStep, error) {
+	if assignee.Normalize().Type == stepDomain.AssigneeActor && assignee.ActorID == "" {
+		return nil, errors.New("actor_id is required")
+	}
+	step := &stepDomain.WorkflowStep{WorkflowID: workflowID, Level: level, Conditions: conditions}
+	step.SetAssignee(assignee)
+	f.s.steps = append(f.s.steps, step)
+	return step, nil
+}
+
+func (f *fakeSteps) GetByWorkflowAndLevel(ctx context.Context, workflowID string, level int) (*stepDomain.WorkflowStep, error) {
+	for _, step := range f.s.steps {
+		if step.WorkflowID == workflowID && step.Level == level {
+			return step, nil
+		}
+	}
+	return nil, stepRepo.ErrStepNotFound
+}
+
+type fakeRequests struct {
+	reqPorts.RequestRepository
+	s *store
+}
+
+func (f *fakeRequests) Create(ctx context.Context, request *reqDomain.Request) error {
+	f.s.requests = append(f.s.requests, request)
+	return nil
+}
+
+type fakeHistories struct {
+	historyPorts.ApprovalHistoryRepository
+	s *store
+}
+
+func (f *fakeHistories) Create(ctx context.Context, history *historyDomain.ApprovalHistory) error {
+	f.s.histories = append(f.s.histories, history)
+	return nil
+}
+
+// fakeTransactor runs imports against a store that is only kept when the
+// transaction commits
+type fakeTransactor struct {
+	committed *store
+}
+
+func (f *fakeTransactor) Transaction(ctx context.Context, fn func(ports.Services) error) error {
+	s := newStore()
+	for code, actor := range f.committed.actors {
+		s.actors[code] = actor
+	}
+	for email, user := range f.committed.users {
+		s.users[email] = user
+	}
+	for id, workflow := range f.committed.workflows {
+		s.workflows[id] = workflow
+	}
+	s.steps = append(s.steps, f.committed.steps...)
+
+	actors, users, workflows, steps := &fakeActors{s: s}, &fakeUsers{s: s}, &fakeWorkflows{s: s}, &fakeSteps{s: s}
+	err := fn(ports.Services{
+		Actors:       actors,
+		Users:        users,
+		Workflows:    workflows,
+		Steps:        steps,
+		ActorRepo:    actors,
+		UserRepo:     users,
+		WorkflowRepo: workflows,
+		StepRepo:     steps,
+		RequestRepo:  &fakeRequests{s: s},
+		HistoryRepo:  &fakeHistories{s: s},
+	})
+	if err == nil {
+		f.committed = s
+	}
+	return err
+}
+
+type fakeRates struct{}
+
+func (fakeRates) BaseCurrency() money.Currency {
+	return "IDR"
+}
+
+func (fakeRates) RateFor(ctx context.Context, currency money.Currency) (money.Rate, error) {
+	return money.Rate{}, fmt.Errorf("%w: %s", reqDomain.ErrExchangeRateMissing, currency)
+}
+
+type fakeNotifier struct {
+	sent []string
+}
+
+func (f *fakeNotifier) SendEmailVerification(ctx context.Context, user *userDomain.User) error {
+	f.sent = append(f.sent, user.Email)
+	return nil
+}
+
+const (
+	actorsCSV = "name,code\nFinance,FIN\nManager,MGR\n"
+	usersCSV  = "email,name,password,is_admin,actors\n" +
+		"rina@example.com,Rina,password1,,MGR:primary\n" +
+		"budi@example.com,Budi,password1,false,FIN;MGR:backup\n"
+	workflowsCSV = "workflow,form,level,assignee_type,actor_code,min_amount\n" +
+		`Purchase,"{""fields"":[{""name"":""cost_center"",""type"":""string"",""required"":true},{""name"":""urgent"",""type"":""boolean""}]}",1,actor,MGR,` + "\n" +
+		"Purchase,,2,actor,FIN,1000000\n"
+	requestsCSV = "workflow,requester_email,title,amount,status,created_at,fields.cost_center,fields.urgent," +
+		"level_1_action,level_1_approver_email,level_1_decided_at,level_2_action,level_2_approver_email,level_2_decided_at,level_2_comment\n" +
+		"Purchase,rina@example.com,Laptop,15000000,APPROVED,2025-03-01,CC-1,true,approve,rina@example.com,2025-03-02T09:00:00Z,APPROVE,budi@example.com,2025-03-03T09:00:00Z,\n" +
+		"Purchase,budi@example.com,Chairs,2000000,REJECTED,2025-04-01T08:00:00Z,CC-2,,APPROVE,rina@example.com,2025-04-01T10:00:00Z,REJECT,budi@example.com,2025-04-02,Over budget\n"
+)
+
+func importFiles(csvs map[domain.Kind]string) map[domain.Kind]io.Reader {
+	files := make(map[domain.Kind]io.Reader, len(csvs))
+	for kind, content := range csvs {
+		files[kind] = strings.NewReader(content)
+	}
+	return files
+}
+
+func allFiles() map[domain.Kind]io.Reader {
+	return importFiles(map[domain.Kind]string{
+		domain.KindActors:    actorsCSV,
+		domain.KindUsers:     usersCSV,
+		domain.KindWorkflows: workflowsCSV,
+		domain.KindRequests:  requestsCSV,
+	})
+}
+
+func newTestImportService() (*ImportServiceImpl, *fakeTransactor, *fakeNotifier) {
+	transactor := &fakeTransactor{committed: newStore()}
+	notifier := &fakeNotifier{}
+	return NewImportService(transactor, fakeRates{}, notifier).(*ImportServiceImpl), transactor, notifier
+}
+
+func TestImportDryRunWritesNothing(t *testing.T) {
+	service, transactor, notifier := newTestImportService()
+
+	result, err := service.Import(context.Background(), allFiles(), true)
+	if err != nil {
+		t.Fatalf("Import failed: %v", err)
+	}
+	if !result.Valid() {
+		t.Fatalf("Expected no row errors, got %+v", result.Errors)
+	}
+	want := map[string]int{"actors": 2, "users": 2, "workflows": 1, "workflow_steps": 2, "requests": 2}
+	for table, n := range want {
+		if result.Created[table] != n {
+			t.Errorf("Expected %d %s, got %d", n, table, result.Created[table])
+		}
+	}
+	if result.Committed || len(transactor.committed.actors) != 0 || len(notifier.sent) != 0 {
+		t.Errorf("Expected a dry run to roll back without emails, got committed=%v", result.Committed)
+	}
+}
+
+func TestImportCommitsRequestsWithHistory(t *testing.T) {
+	service, transactor, notifier := newTestImportService()
+
+	result, err := service.Import(context.Background(), allFiles(), false)
+	if err != nil || !result.Committed {
+		t.Fatalf("Expected the import to commit, got %+v (%v)", result, err)
+	}
+	if len(notifier.sent) != 2 {
+		t.Errorf("Expected verification emails after commit, got %v", notifier.sent)
+	}
+
+	db := transactor.committed
+	if len(db.requests) != 2 || len(db.histories) != 4 {
+		t.Fatalf("Expected 2 requests with 4 decisions, got %d and %d", len(db.requests), len(db.histories))
+	}
+	approved, rejected := db.requests[0], db.requests[1]
+	if approved.Status != reqDomain.StatusApproved || approved.CurrentStep != 2 || approved.Fields["urgent"] != true {
+		t.Errorf("Unexpected approved request %+v", approved)
+	}
+	if approved.BaseAmount.Cmp(approved.Amount) != 0 || approved.CreatedAt.Format("2006-01-02") != "2025-03-01" {
+		t.Errorf("Expected base amounts and creation dates to be kept, got %+v", approved)
+	}
+	if rejected.Status != reqDomain.StatusRejected || len(rejected.Approvers) != 2 || rejected.Approvers[1].UserID != db.users["budi@example.com"].ID {
+		t.Errorf("Unexpected rejected request %+v", rejected)
+	}
+	last := db.histories[3]
+	if last.Action != historyDomain.ApprovalActionReject || last.ActorID != "actor-FIN" || last.Comment != "Over budget" || last.CreatedAt.Format(time.RFC3339) != "2025-04-02T00:00:00Z" {
+		t.Errorf("Unexpected rejection history %+v", last)
+	}
+}
+
+func TestImportReportsRowErrors(t *testing.T) {
+	service, transactor, notifier := newTestImportService()
+
+	files := importFiles(map[domain.Kind]string{
+		domain.KindActors:    actorsCSV + "Finance again,FIN\n",
+		domain.KindUsers:     usersCSV + "sari@example.com,Sari,password1,,OPS\n",
+		domain.KindWorkflows: workflowsCSV,
+		domain.KindRequests: requestsCSV +
+			"Purchase,rina@example.com,Desk,0,APPROVED,2025-05-01,CC-3,,APPROVE,rina@example.com,2025-05-02,,,,\n",
+	})
+	result, err := service.Import(context.Background(), files, false)
+	if err != nil {
+		t.Fatalf("Import failed: %v", err)
+	}
+	if result.Committed || len(transactor.committed.actors) != 0 || len(notifier.sent) != 0 {
+		t.Fatalf("Expected an import with row errors to roll back")
+	}
+
+	got := make(map[string]bool)
+	for _, e := range result.Errors {
+		got[fmt.Sprintf("%s:%d:%s", e.File, e.Row, e.Column)] = true
+	}
+	for _, want := range []string{
+		"actors:4:",         // Duplicate code
+		"users:4:actors",    // Unknown actor code
+		"requests:4:amount", // IDR has no decimal places
+	} {
+		if !got[want] {
+			t.Errorf("Expected error %s, got %+v", want, result.Errors)
+		}
+	}
+}
+
+func TestImportChecksFieldsAndLevels(t *testing.T) {
+	service, _, _ := newTestImportService()
+
+	files := allFiles()
+	files[domain.KindRequests] = strings.NewReader(requestsCSV +
+		"Purchase,rina@example.com,Desk,100,APPROVED,2025-05-01,,yes,,,,APPROVE,budi@example.com,2025-05-02,\n")
+	result, err := service.Import(context.Background(), files, true)
+	if err != nil {
+		t.Fatalf("Import failed: %v", err)
+	}
+
+	got := make(map[string]bool)
+	for _, e := range result.Errors {
+		got[e.Column] = true
+	}
+	// The form rejects the values before the missing level 1 decision is checked
+	if !got["fields.cost_center"] || !got["fields.urgent"] || len(result.Errors) != 2 {
+		t.Errorf("Expected errors on the form fields, got %+v", result.Errors)
+	}
+
+	files = allFiles()
+	files[domain.KindRequests] = strings.NewReader(requestsCSV +
+		"Purchase,rina@example.com,Desk,100,APPROVED,2025-05-01,CC-3,,,,,APPROVE,budi@example.com,2025-05-02,\n")
+	result, _ = service.Import(context.Background(), files, true)
+	if len(result.Errors) != 1 || result.Errors[0].Column != "level_2_action" || result.Errors[0].Row != 4 {
+		t.Errorf("Expected the gap before level 2 to be reported, got %+v", result.Errors)
+	}
+}
+
+func TestImportRejectsUnknownColumns(t *testing.T) {
+	service, _, _ := newTestImportService()
+
+	files := importFiles(map[domain.Kind]string{
+		domain.KindActors: "\ufeffname,code,colour\nFinance,FIN,red\n",
+		domain.KindUsers:  "email,name\n",
+	})
+	result, err := service.Import(context.Background(), files, false)
+	if err != nil {
+		t.Fatalf("Import failed: %v", err)
+	}
+	want := []domain.RowError{
+		{File: domain.KindActors, Row: 1, Column: "colour", Message: "unknown column"},
+		{File: domain.KindUsers, Row: 1, Column: "password", Message: "required column is missing"},
+	}
+	if fmt.Sprint(result.Errors) != fmt.Sprint(want) {
+		t.Errorf("Expected %+v, got %+v", want, result.Errors)
+	}
+
+	if _, err := service.Import(context.Background(), nil, false); !errors.Is(err, domain.ErrNoFiles) {
+		t.Errorf("Expected ErrNoFiles, got %v", err)
+	}
+}
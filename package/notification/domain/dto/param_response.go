@@ -0,0 +1,47 @@
+package dto
+
+import "workflow-approval/package/notification/domain"
+
+// NotificationResponse represents the notification response
+type NotificationResponse struct {
+	ID        string  `json:"id"`
+	Type      string  `json:"type"`
+	RequestID string  `json:"request_id"`
+	CommentID *string `json:"comment_id,omitempty"`
+	ActorID   string  `json:"actor_id"`
+	Message   string  `json:"message"`
+	Read      bool    `json:"read"`
+	ReadAt    *string `json:"read_at"`
+	CreatedAt string  `json:"created_at"`
+}
+
+// ToNotificationResponse converts a Notification to NotificationResponse
+func ToNotificationResponse(n *domain.Notification) *NotificationResponse {
+	if n == nil {
+		return nil
+	}
+	response := &NotificationResponse{
+		ID:        n.ID,
+		Type:      string(n.Type),
+		RequestID: n.RequestID,
+		CommentID: n.CommentID,
+		ActorID:   n.ActorID,
+		Message:   n.Message,
+		Read:      n.IsRead(),
+		CreatedAt: n.CreatedAt.Format("2006-01-02T15:04:05Z"),
+	}
+	if n.ReadAt != nil {
+		readAt := n.ReadAt.Format("2006-01-02T15:04:05Z")
+		response.ReadAt = &readAt
+	}
+	return response
+}
+
+// ToNotificationResponseList converts a list of Notification to NotificationResponse
+func ToNotificationResponseList(notifications []*domain.Notification) []*NotificationResponse {
+	responses := make([]*NotificationResponse, len(notifications))
+	for i, n := range notifications {
+		responses[i] = ToNotificationResponse(n)
+	}
+	return responses
+}
@@ -0,0 +1,53 @@
+package domain
+
+import (
+	"time"
+
+	"workflow-approval/utils"
+)
+
+// NotificationType identifies what a notification is about
+type NotificationType string
+
+const (
+	NotificationTypeMention NotificationType = "MENTION" // The user was @mentioned in a request comment
+)
+
+// Notification is an in-app message for a user, e.g. a mention in a comment
+type Notification struct {
+	ID        string           `json:"id" gorm:"primaryKey;size:36"`
+	TenantID  string           `json:"tenant_id" gorm:"size:36;not null;index"`
+	UserID    string           `json:"user_id" gorm:"size:36;not null;index"` // Recipient
+	Type      NotificationType `json:"type" gorm:"size:30;not null"`
+	RequestID string           `json:"request_id" gorm:"size:36;not null"`
+	CommentID *string          `json:"comment_id" gorm:"size:36"`
+	ActorID   string           `json:"actor_id" gorm:"size:36;not null"` // User (or API key) that caused the notification
+	Message   string           `json:"message" gorm:"size:500;not null"`
+	ReadAt    *time.Time       `json:"read_at"`
+	CreatedAt time.Time        `json:"created_at"`
+}
+
+// NewMentionNotification creates the notification for a user mentioned in a comment
+func NewMentionNotification(tenantID, userID, requestID, commentID, actorID, message string) *Notification {
+	return &Notification{
+		ID:        utils.GenerateUUID(),
+		TenantID:  tenantID,
+		UserID:    userID,
+		Type:      NotificationTypeMention,
+		RequestID: requestID,
+		CommentID: &commentID,
+		ActorID:   actorID,
+		Message:   message,
+		CreatedAt: utils.TimeNowUTC(),
+	}
+}
+
+// IsRead reports whether the recipient has read the notification
+func (n *Notification) IsRead() bool {
+	return n.ReadAt != nil
+}
+
+// TableName returns the table name for GORM
+func (Notification) TableName() string {
+	return "notifications"
+}
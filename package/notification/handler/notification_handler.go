@@ -0,0 +1,120 @@
+package handler
+
+import (
+	"errors"
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+
+	"workflow-approval/package/notification/domain/dto"
+	"workflow-approval/package/notification/ports"
+	"workflow-approval/package/notification/usecase"
+)
+
+// NotificationHandler handles HTTP requests for the caller's notifications
+type NotificationHandler struct {
+	notificationService ports.NotificationService
+}
+
+// NewNotificationHandler creates a new NotificationHandler instance
+func NewNotificationHandler(notificationService ports.NotificationService) *NotificationHandler {
+	return &NotificationHandler{
+		notificationService: notificationService,
+	}
+}
+
+// Routes defines all routes for notifications
+// Mounts routes under /api/notifications
+func (h *NotificationHandler) Routes(group fiber.Router) {
+	// GET /api/notifications - List the caller's notifications
+	// Query params: page, limit, unread=true
+	group.Get("", h.List)
+
+	// POST /api/notifications/read-all - Mark all notifications as read
+	group.Post("/read-all", h.MarkAllRead)
+
+	// POST /api/notifications/:id/read - Mark a notification as read
+	group.Post("/:id/read", h.MarkRead)
+}
+
+// List handles listing the caller's notifications
+// GET /api/notifications
+func (h *NotificationHandler) List(c *fiber.Ctx) error {
+	page, _ := strconv.Atoi(c.Query("page", "1"))
+	limit, _ := strconv.Atoi(c.Query("limit", "20"))
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 || limit > 100 {
+		limit = 20
+	}
+
+	userID := c.Locals("user_id").(string)
+	notifications, total, unread, err := h.notificationService.ListNotifications(c.Context(), userID, c.QueryBool("unread"), page, limit)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"success": false,
+			"data":    nil,
+			"error":   "Failed to list notifications",
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"success": true,
+		"data": fiber.Map{
+			"notifications": dto.ToNotificationResponseList(notifications),
+			"unread":        unread,
+			"total":         total,
+			"page":          page,
+			"limit":         limit,
+		},
+		"error": nil,
+	})
+}
+
+// MarkRead handles marking a notification as read
+// POST /api/notifications/:id/read
+func (h *NotificationHandler) MarkRead(c *fiber.Ctx) error {
+	userID := c.Locals("user_id").(string)
+	notification, err := h.notificationService.MarkRead(c.Context(), userID, c.Params("id"))
+	if err != nil {
+		if errors.Is(err, usecase.ErrNotificationNotFound) {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"success": false,
+				"data":    nil,
+				"error":   err.Error(),
+			})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"success": false,
+			"data":    nil,
+			"error":   "Failed to update notification",
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"success": true,
+		"data":    dto.ToNotificationResponse(notification),
+		"error":   nil,
+	})
+}
+
+// MarkAllRead handles marking all of the caller's notifications as read
+// POST /api/notifications/read-all
+func (h *NotificationHandler) MarkAllRead(c *fiber.Ctx) error {
+	userID := c.Locals("user_id").(string)
+	updated, err := h.notificationService.MarkAllRead(c.Context(), userID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"success": false,
+			"data":    nil,
+			"error":   "Failed to update notifications",
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"success": true,
+		"data":    fiber.Map{"updated": updated},
+		"error":   nil,
+	})
+}
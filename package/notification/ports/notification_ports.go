@@ -0,0 +1,29 @@
+package ports
+
+import (
+	"context"
+
+	"workflow-approval/package/notification/domain"
+)
+
+// NotificationRepository defines the interface for notification data access
+type NotificationRepository interface {
+	Create(ctx context.Context, notification *domain.Notification) error
+	GetByID(ctx context.Context, id string) (*domain.Notification, error)
+	// ListByUserID returns a user's notifications, newest first
+	ListByUserID(ctx context.Context, userID string, unreadOnly bool, page, limit int) ([]*domain.Notification, int64, error)
+	CountUnread(ctx context.Context, userID string) (int64, error)
+	Update(ctx context.Context, notification *domain.Notification) error
+	// MarkAllRead marks every unread notification of a user as read and returns how many changed
+	MarkAllRead(ctx context.Context, userID string) (int64, error)
+}
+
+// NotificationService defines the interface for notification business logic
+type NotificationService interface {
+	// NotifyMention tells a user they were mentioned in a request comment
+	NotifyMention(ctx context.Context, tenantID, userID, requestID, commentID, actorID, message string) error
+	// ListNotifications returns the caller's notifications and their unread count
+	ListNotifications(ctx context.Context, userID string, unreadOnly bool, page, limit int) ([]*domain.Notification, int64, int64, error)
+	MarkRead(ctx context.Context, userID, id string) (*domain.Notification, error)
+	MarkAllRead(ctx context.Context, userID string) (int64, error)
+}
@@ -0,0 +1,87 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"gorm.io/gorm"
+
+	"workflow-approval/package/notification/domain"
+	"workflow-approval/package/notification/ports"
+	"workflow-approval/utils"
+)
+
+var ErrNotificationNotFound = errors.New("notification not found")
+
+// NotificationRepositoryImpl implements NotificationRepository interface
+type NotificationRepositoryImpl struct {
+	db *gorm.DB
+}
+
+// NewNotificationRepository creates a new NotificationRepositoryImpl instance
+func NewNotificationRepository(db *gorm.DB) ports.NotificationRepository {
+	return &NotificationRepositoryImpl{db: db}
+}
+
+// Create creates a new notification
+func (r *NotificationRepositoryImpl) Create(ctx context.Context, notification *domain.Notification) error {
+	return r.db.WithContext(ctx).Create(notification).Error
+}
+
+// GetByID retrieves a notification by ID
+func (r *NotificationRepositoryImpl) GetByID(ctx context.Context, id string) (*domain.Notification, error) {
+	var notification domain.Notification
+	result := r.db.WithContext(ctx).First(&notification, "id = ?", id)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, ErrNotificationNotFound
+		}
+		return nil, result.Error
+	}
+	return &notification, nil
+}
+
+// ListByUserID retrieves a user's notifications with pagination, newest first
+func (r *NotificationRepositoryImpl) ListByUserID(ctx context.Context, userID string, unreadOnly bool, page, limit int) ([]*domain.Notification, int64, error) {
+	var notifications []*domain.Notification
+	var total int64
+
+	query := r.db.WithContext(ctx).Model(&domain.Notification{}).Where("user_id = ?", userID)
+	if unreadOnly {
+		query = query.Where("read_at IS NULL")
+	}
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	offset := (page - 1) * limit
+	result := query.Order("created_at DESC").Offset(offset).Limit(limit).Find(&notifications)
+	if result.Error != nil {
+		return nil, 0, result.Error
+	}
+
+	return notifications, total, nil
+}
+
+// CountUnread counts a user's unread notifications
+func (r *NotificationRepositoryImpl) CountUnread(ctx context.Context, userID string) (int64, error) {
+	var count int64
+	err := r.db.WithContext(ctx).Model(&domain.Notification{}).
+		Where("user_id = ? AND read_at IS NULL", userID).
+		Count(&count).Error
+	return count, err
+}
+
+// Update updates a notification
+func (r *NotificationRepositoryImpl) Update(ctx context.Context, notification *domain.Notification) error {
+	return r.db.WithContext(ctx).Save(notification).Error
+}
+
+// MarkAllRead marks all unread notifications of a user as read
+func (r *NotificationRepositoryImpl) MarkAllRead(ctx context.Context, userID string) (int64, error) {
+	result := r.db.WithContext(ctx).Model(&domain.Notification{}).
+		Where("user_id = ? AND read_at IS NULL", userID).
+		Update("read_at", utils.TimeNowUTC())
+	return result.RowsAffected, result.Error
+}
@@ -0,0 +1,73 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+
+	"workflow-approval/package/notification/domain"
+	"workflow-approval/package/notification/ports"
+	"workflow-approval/package/notification/repository"
+	"workflow-approval/utils"
+)
+
+var ErrNotificationNotFound = errors.New("notification not found")
+
+// NotificationServiceImpl implements NotificationService interface
+type NotificationServiceImpl struct {
+	notificationRepo ports.NotificationRepository
+}
+
+// NewNotificationService creates a new NotificationServiceImpl instance
+func NewNotificationService(notificationRepo ports.NotificationRepository) ports.NotificationService {
+	return &NotificationServiceImpl{
+		notificationRepo: notificationRepo,
+	}
+}
+
+// NotifyMention records a mention notification for a user
+func (s *NotificationServiceImpl) NotifyMention(ctx context.Context, tenantID, userID, requestID, commentID, actorID, message string) error {
+	return s.notificationRepo.Create(ctx, domain.NewMentionNotification(tenantID, userID, requestID, commentID, actorID, message))
+}
+
+// ListNotifications retrieves the user's notifications with pagination and the unread count
+func (s *NotificationServiceImpl) ListNotifications(ctx context.Context, userID string, unreadOnly bool, page, limit int) ([]*domain.Notification, int64, int64, error) {
+	notifications, total, err := s.notificationRepo.ListByUserID(ctx, userID, unreadOnly, page, limit)
+	if err != nil {
+		return nil, 0, 0, err
+	}
+	unread, err := s.notificationRepo.CountUnread(ctx, userID)
+	if err != nil {
+		return nil, 0, 0, err
+	}
+	return notifications, total, unread, nil
+}
+
+// MarkRead marks one of the user's notifications as read; other users'
+// notifications are reported as not found
+func (s *NotificationServiceImpl) MarkRead(ctx context.Context, userID, id string) (*domain.Notification, error) {
+	notification, err := s.notificationRepo.GetByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotificationNotFound) {
+			return nil, ErrNotificationNotFound
+		}
+		return nil, err
+	}
+	if notification.UserID != userID {
+		return nil, ErrNotificationNotFound
+	}
+	if notification.IsRead() {
+		return notification, nil
+	}
+
+	now := utils.TimeNowUTC()
+	notification.ReadAt = &now
+	if err := s.notificationRepo.Update(ctx, notification); err != nil {
+		return nil, err
+	}
+	return notification, nil
+}
+
+// MarkAllRead marks all of the user's notifications as read
+func (s *NotificationServiceImpl) MarkAllRead(ctx context.Context, userID string) (int64, error) {
+	return s.notificationRepo.MarkAllRead(ctx, userID)
+}
@@ -0,0 +1,36 @@
+package domain
+
+import (
+	"time"
+
+	"workflow-approval/utils"
+)
+
+// ApproverLookup maps a request field value to its approver, e.g. the owner
+// of cost center "CC-100". Used by field_lookup steps.
+type ApproverLookup struct {
+	ID        string    `json:"id" gorm:"primaryKey;size:36"`
+	TenantID  string    `json:"tenant_id" gorm:"size:36;not null;uniqueIndex:idx_approver_lookups_tenant_field_value"`
+	Field     string    `json:"field" gorm:"size:100;not null;uniqueIndex:idx_approver_lookups_tenant_field_value"`
+	Value     string    `json:"value" gorm:"size:255;not null;uniqueIndex:idx_approver_lookups_tenant_field_value"`
+	UserID    string    `json:"user_id" gorm:"size:36;not null;index"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// NewApproverLookup creates a new ApproverLookup instance
+func NewApproverLookup(field, value, userID string) *ApproverLookup {
+	return &ApproverLookup{
+		ID:        utils.GenerateUUID(),
+		Field:     field,
+		Value:     value,
+		UserID:    userID,
+		CreatedAt: utils.TimeNowUTC(),
+		UpdatedAt: utils.TimeNowUTC(),
+	}
+}
+
+// TableName returns the table name for GORM
+func (ApproverLookup) TableName() string {
+	return "approver_lookups"
+}
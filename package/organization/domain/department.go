@@ -0,0 +1,38 @@
+package domain
+
+import (
+	"time"
+
+	"workflow-approval/utils"
+)
+
+// Department is a node of the organization tree; its head approves
+// department_head steps for requesters in the department
+type Department struct {
+	ID         string    `json:"id" gorm:"primaryKey;size:36"`
+	TenantID   string    `json:"tenant_id" gorm:"size:36;not null;uniqueIndex:idx_departments_tenant_code"`
+	Code       string    `json:"code" gorm:"size:50;uniqueIndex:idx_departments_tenant_code;not null"`
+	Name       string    `json:"name" gorm:"size:255;not null"`
+	ParentID   *string   `json:"parent_id" gorm:"size:36;index"`    // Escalation target when the head can't approve
+	HeadUserID *string   `json:"head_user_id" gorm:"size:36;index"` // Nil while the position is vacant
+	CreatedAt  time.Time `json:"created_at"`
+	UpdatedAt  time.Time `json:"updated_at"`
+}
+
+// NewDepartment creates a new Department instance
+func NewDepartment(code, name string, parentID, headUserID *string) *Department {
+	return &Department{
+		ID:         utils.GenerateUUID(),
+		Code:       code,
+		Name:       name,
+		ParentID:   parentID,
+		HeadUserID: headUserID,
+		CreatedAt:  utils.TimeNowUTC(),
+		UpdatedAt:  utils.TimeNowUTC(),
+	}
+}
+
+// TableName returns the table name for GORM
+func (Department) TableName() string {
+	return "departments"
+}
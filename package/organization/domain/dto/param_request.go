@@ -0,0 +1,22 @@
+package dto
+
+// DepartmentRequest represents the create/update department request body; code is ignored on update
+type DepartmentRequest struct {
+	Code       string  `json:"code"`
+	Name       string  `json:"name"`
+	ParentID   *string `json:"parent_id"`
+	HeadUserID *string `json:"head_user_id"`
+}
+
+// UserOrganizationRequest represents the body setting a user's place in the org chart; null clears a value
+type UserOrganizationRequest struct {
+	ManagerID    *string `json:"manager_id"`
+	DepartmentID *string `json:"department_id"`
+}
+
+// CreateLookupRequest represents the create approver lookup request body
+type CreateLookupRequest struct {
+	Field  string `json:"field"`
+	Value  string `json:"value"`
+	UserID string `json:"user_id"`
+}
@@ -0,0 +1,71 @@
+package dto
+
+import "workflow-approval/package/organization/domain"
+
+// DepartmentResponse represents the department response
+type DepartmentResponse struct {
+	ID         string  `json:"id"`
+	Code       string  `json:"code"`
+	Name       string  `json:"name"`
+	ParentID   *string `json:"parent_id"`
+	HeadUserID *string `json:"head_user_id"`
+	CreatedAt  string  `json:"created_at"`
+	UpdatedAt  string  `json:"updated_at"`
+}
+
+// ToDepartmentResponse converts a Department to DepartmentResponse
+func ToDepartmentResponse(d *domain.Department) *DepartmentResponse {
+	if d == nil {
+		return nil
+	}
+	return &DepartmentResponse{
+		ID:         d.ID,
+		Code:       d.Code,
+		Name:       d.Name,
+		ParentID:   d.ParentID,
+		HeadUserID: d.HeadUserID,
+		CreatedAt:  d.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
+		UpdatedAt:  d.UpdatedAt.Format("2006-01-02T15:04:05Z07:00"),
+	}
+}
+
+// ToDepartmentResponseList converts a list of Departments to DepartmentResponse list
+func ToDepartmentResponseList(departments []*domain.Department) []*DepartmentResponse {
+	result := make([]*DepartmentResponse, len(departments))
+	for i, d := range departments {
+		result[i] = ToDepartmentResponse(d)
+	}
+	return result
+}
+
+// LookupResponse represents the approver lookup response
+type LookupResponse struct {
+	ID        string `json:"id"`
+	Field     string `json:"field"`
+	Value     string `json:"value"`
+	UserID    string `json:"user_id"`
+	CreatedAt string `json:"created_at"`
+}
+
+// ToLookupResponse converts an ApproverLookup to LookupResponse
+func ToLookupResponse(l *domain.ApproverLookup) *LookupResponse {
+	if l == nil {
+		return nil
+	}
+	return &LookupResponse{
+		ID:        l.ID,
+		Field:     l.Field,
+		Value:     l.Value,
+		UserID:    l.UserID,
+		CreatedAt: l.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
+	}
+}
+
+// ToLookupResponseList converts a list of ApproverLookups to LookupResponse list
+func ToLookupResponseList(lookups []*domain.ApproverLookup) []*LookupResponse {
+	result := make([]*LookupResponse, len(lookups))
+	for i, l := range lookups {
+		result[i] = ToLookupResponse(l)
+	}
+	return result
+}
@@ -0,0 +1,250 @@
+package handler
+
+import (
+	"errors"
+
+	"github.com/gofiber/fiber/v2"
+
+	"workflow-approval/package/organization/domain/dto"
+	"workflow-approval/package/organization/ports"
+	"workflow-approval/package/organization/repository"
+	"workflow-approval/package/organization/usecase"
+	userDto "workflow-approval/package/user/domain/dto"
+)
+
+// OrganizationHandler handles HTTP requests for the org chart used by dynamic approvers
+type OrganizationHandler struct {
+	orgService ports.OrganizationService
+}
+
+// NewOrganizationHandler creates a new OrganizationHandler instance
+func NewOrganizationHandler(orgService ports.OrganizationService) *OrganizationHandler {
+	return &OrganizationHandler{
+		orgService: orgService,
+	}
+}
+
+// AdminRoutes defines the org chart management routes (Admin only)
+// Mounts routes under /api/org
+func (h *OrganizationHandler) AdminRoutes(group fiber.Router) {
+	// GET /api/org/departments - List departments
+	group.Get("/departments", h.ListDepartments)
+
+	// POST /api/org/departments - Create a department
+	group.Post("/departments", h.CreateDepartment)
+
+	// GET /api/org/departments/:id - Get a department
+	group.Get("/departments/:id", h.GetDepartment)
+
+	// PUT /api/org/departments/:id - Update a department's name, parent and head
+	group.Put("/departments/:id", h.UpdateDepartment)
+
+	// DELETE /api/org/departments/:id - Delete an empty department
+	group.Delete("/departments/:id", h.DeleteDepartment)
+
+	// PUT /api/org/users/:id - Set a user's manager and department
+	group.Put("/users/:id", h.SetUserOrganization)
+
+	// GET /api/org/lookups - List approver lookups (?field= to filter)
+	group.Get("/lookups", h.ListLookups)
+
+	// POST /api/org/lookups - Map a request field value to an approver
+	group.Post("/lookups", h.CreateLookup)
+
+	// DELETE /api/org/lookups/:id - Delete an approver lookup
+	group.Delete("/lookups/:id", h.DeleteLookup)
+}
+
+// ListDepartments handles listing departments
+// GET /api/org/departments
+func (h *OrganizationHandler) ListDepartments(c *fiber.Ctx) error {
+	departments, err := h.orgService.ListDepartments(c.Context())
+	if err != nil {
+		return orgError(c, err)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"success": true,
+		"data":    dto.ToDepartmentResponseList(departments),
+		"error":   nil,
+	})
+}
+
+// CreateDepartment handles creating a department
+// POST /api/org/departments
+func (h *OrganizationHandler) CreateDepartment(c *fiber.Ctx) error {
+	var req dto.DepartmentRequest
+	if err := c.BodyParser(&req); err != nil {
+		return invalidBody(c)
+	}
+
+	department, err := h.orgService.CreateDepartment(c.Context(), req.Code, req.Name, req.ParentID, req.HeadUserID)
+	if err != nil {
+		return orgError(c, err)
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
+		"success": true,
+		"data":    dto.ToDepartmentResponse(department),
+		"error":   nil,
+	})
+}
+
+// GetDepartment handles getting a department by ID
+// GET /api/org/departments/:id
+func (h *OrganizationHandler) GetDepartment(c *fiber.Ctx) error {
+	department, err := h.orgService.GetDepartment(c.Context(), c.Params("id"))
+	if err != nil {
+		return orgError(c, err)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"success": true,
+		"data":    dto.ToDepartmentResponse(department),
+		"error":   nil,
+	})
+}
+
+// UpdateDepartment handles updating a department
+// PUT /api/org/departments/:id
+func (h *OrganizationHandler) UpdateDepartment(c *fiber.Ctx) error {
+	var req dto.DepartmentRequest
+	if err := c.BodyParser(&req); err != nil {
+		return invalidBody(c)
+	}
+
+	department, err := h.orgService.UpdateDepartment(c.Context(), c.Params("id"), req.Name, req.ParentID, req.HeadUserID)
+	if err != nil {
+		return orgError(c, err)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"success": true,
+		"data":    dto.ToDepartmentResponse(department),
+		"error":   nil,
+	})
+}
+
+// DeleteDepartment handles deleting a department
+// DELETE /api/org/departments/:id
+func (h *OrganizationHandler) DeleteDepartment(c *fiber.Ctx) error {
+	if err := h.orgService.DeleteDepartment(c.Context(), c.Params("id")); err != nil {
+		return orgError(c, err)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"success": true,
+		"data":    fiber.Map{"message": "Department deleted successfully"},
+		"error":   nil,
+	})
+}
+
+// SetUserOrganization handles setting a user's manager and department
+// PUT /api/org/users/:id
+func (h *OrganizationHandler) SetUserOrganization(c *fiber.Ctx) error {
+	var req dto.UserOrganizationRequest
+	if err := c.BodyParser(&req); err != nil {
+		return invalidBody(c)
+	}
+
+	user, err := h.orgService.SetUserOrganization(c.Context(), c.Params("id"), req.ManagerID, req.DepartmentID)
+	if err != nil {
+		return orgError(c, err)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"success": true,
+		"data":    userDto.ToUserResponse(user),
+		"error":   nil,
+	})
+}
+
+// ListLookups handles listing approver lookups
+// GET /api/org/lookups
+func (h *OrganizationHandler) ListLookups(c *fiber.Ctx) error {
+	lookups, err := h.orgService.ListLookups(c.Context(), c.Query("field"))
+	if err != nil {
+		return orgError(c, err)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"success": true,
+		"data":    dto.ToLookupResponseList(lookups),
+		"error":   nil,
+	})
+}
+
+// CreateLookup handles creating an approver lookup
+// POST /api/org/lookups
+func (h *OrganizationHandler) CreateLookup(c *fiber.Ctx) error {
+	var req dto.CreateLookupRequest
+	if err := c.BodyParser(&req); err != nil {
+		return invalidBody(c)
+	}
+
+	lookup, err := h.orgService.CreateLookup(c.Context(), req.Field, req.Value, req.UserID)
+	if err != nil {
+		return orgError(c, err)
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
+		"success": true,
+		"data":    dto.ToLookupResponse(lookup),
+		"error":   nil,
+	})
+}
+
+// DeleteLookup handles deleting an approver lookup
+// DELETE /api/org/lookups/:id
+func (h *OrganizationHandler) DeleteLookup(c *fiber.Ctx) error {
+	if err := h.orgService.DeleteLookup(c.Context(), c.Params("id")); err != nil {
+		return orgError(c, err)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"success": true,
+		"data":    fiber.Map{"message": "Approver lookup deleted successfully"},
+		"error":   nil,
+	})
+}
+
+// invalidBody responds to a request body that can't be parsed
+func invalidBody(c *fiber.Ctx) error {
+	return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+		"success": false,
+		"data":    nil,
+		"error":   "Invalid request body",
+	})
+}
+
+// orgError maps organization service errors to HTTP responses
+func orgError(c *fiber.Ctx, err error) error {
+	status := fiber.StatusInternalServerError
+	message := "Failed to process organization request"
+	switch {
+	case errors.Is(err, usecase.ErrDepartmentNotFound),
+		errors.Is(err, usecase.ErrUserNotFound),
+		errors.Is(err, repository.ErrLookupNotFound):
+		status, message = fiber.StatusNotFound, err.Error()
+	case errors.Is(err, repository.ErrDepartmentAlreadyExist),
+		errors.Is(err, repository.ErrLookupAlreadyExist),
+		errors.Is(err, usecase.ErrDepartmentInUse):
+		status, message = fiber.StatusConflict, err.Error()
+	case errors.Is(err, usecase.ErrDepartmentCodeRequired),
+		errors.Is(err, usecase.ErrDepartmentNameRequired),
+		errors.Is(err, usecase.ErrParentNotFound),
+		errors.Is(err, usecase.ErrDepartmentCycle),
+		errors.Is(err, usecase.ErrHeadNotFound),
+		errors.Is(err, usecase.ErrManagerNotFound),
+		errors.Is(err, usecase.ErrManagerCycle),
+		errors.Is(err, usecase.ErrLookupFieldRequired),
+		errors.Is(err, usecase.ErrLookupUserNotFound):
+		status, message = fiber.StatusBadRequest, err.Error()
+	}
+
+	return c.Status(status).JSON(fiber.Map{
+		"success": false,
+		"data":    nil,
+		"error":   message,
+	})
+}
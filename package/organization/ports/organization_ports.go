@@ -0,0 +1,51 @@
+package ports
+
+import (
+	"context"
+
+	orgDomain "workflow-approval/package/organization/domain"
+	userDomain "workflow-approval/package/user/domain"
+)
+
+// DepartmentRepository defines the interface for department data access
+type DepartmentRepository interface {
+	Create(ctx context.Context, department *orgDomain.Department) error
+	GetByID(ctx context.Context, id string) (*orgDomain.Department, error)
+	GetAll(ctx context.Context) ([]*orgDomain.Department, error)
+	Update(ctx context.Context, department *orgDomain.Department) error
+	Delete(ctx context.Context, id string) error
+	CountChildren(ctx context.Context, id string) (int64, error)
+	CountUsers(ctx context.Context, id string) (int64, error)
+}
+
+// ApproverLookupRepository defines the interface for approver lookup data access
+type ApproverLookupRepository interface {
+	Create(ctx context.Context, lookup *orgDomain.ApproverLookup) error
+	GetByID(ctx context.Context, id string) (*orgDomain.ApproverLookup, error)
+	GetByFieldValue(ctx context.Context, field, value string) (*orgDomain.ApproverLookup, error)
+	List(ctx context.Context, field string) ([]*orgDomain.ApproverLookup, error)
+	Delete(ctx context.Context, id string) error
+}
+
+// UserRepository defines the user access needed to maintain and walk the org chart
+type UserRepository interface {
+	GetByID(ctx context.Context, id string) (*userDomain.User, error)
+	Update(ctx context.Context, user *userDomain.User) error
+}
+
+// OrganizationService defines the interface for org chart business logic
+type OrganizationService interface {
+	CreateDepartment(ctx context.Context, code, name string, parentID, headUserID *string) (*orgDomain.Department, error)
+	GetDepartment(ctx context.Context, id string) (*orgDomain.Department, error)
+	ListDepartments(ctx context.Context) ([]*orgDomain.Department, error)
+	UpdateDepartment(ctx context.Context, id, name string, parentID, headUserID *string) (*orgDomain.Department, error)
+	// DeleteDepartment refuses departments that still have users or sub-departments
+	DeleteDepartment(ctx context.Context, id string) error
+
+	// SetUserOrganization sets a user's manager and department; nil clears them
+	SetUserOrganization(ctx context.Context, userID string, managerID, departmentID *string) (*userDomain.User, error)
+
+	CreateLookup(ctx context.Context, field, value, userID string) (*orgDomain.ApproverLookup, error)
+	ListLookups(ctx context.Context, field string) ([]*orgDomain.ApproverLookup, error)
+	DeleteLookup(ctx context.Context, id string) error
+}
@@ -0,0 +1,86 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"gorm.io/gorm"
+
+	"workflow-approval/package/organization/domain"
+	"workflow-approval/package/organization/ports"
+)
+
+var (
+	ErrLookupNotFound     = errors.New("approver lookup not found")
+	ErrLookupAlreadyExist = errors.New("approver lookup already exists for this field value")
+)
+
+// ApproverLookupRepositoryImpl implements ApproverLookupRepository interface
+type ApproverLookupRepositoryImpl struct {
+	db *gorm.DB
+}
+
+// NewApproverLookupRepository creates a new ApproverLookupRepositoryImpl instance
+func NewApproverLookupRepository(db *gorm.DB) ports.ApproverLookupRepository {
+	return &ApproverLookupRepositoryImpl{db: db}
+}
+
+// Create creates a new approver lookup
+func (r *ApproverLookupRepositoryImpl) Create(ctx context.Context, lookup *domain.ApproverLookup) error {
+	result := r.db.WithContext(ctx).Create(lookup)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrDuplicatedKey) {
+			return ErrLookupAlreadyExist
+		}
+		return result.Error
+	}
+	return nil
+}
+
+// GetByID retrieves an approver lookup by ID
+func (r *ApproverLookupRepositoryImpl) GetByID(ctx context.Context, id string) (*domain.ApproverLookup, error) {
+	var lookup domain.ApproverLookup
+	result := r.db.WithContext(ctx).First(&lookup, "id = ?", id)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, ErrLookupNotFound
+		}
+		return nil, result.Error
+	}
+	return &lookup, nil
+}
+
+// GetByFieldValue retrieves the approver lookup of a field value
+func (r *ApproverLookupRepositoryImpl) GetByFieldValue(ctx context.Context, field, value string) (*domain.ApproverLookup, error) {
+	var lookup domain.ApproverLookup
+	result := r.db.WithContext(ctx).First(&lookup, "field = ? AND value = ?", field, value)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, ErrLookupNotFound
+		}
+		return nil, result.Error
+	}
+	return &lookup, nil
+}
+
+// List retrieves approver lookups, optionally only those of one field
+func (r *ApproverLookupRepositoryImpl) List(ctx context.Context, field string) ([]*domain.ApproverLookup, error) {
+	var lookups []*domain.ApproverLookup
+	query := r.db.WithContext(ctx).Order("field ASC, value ASC")
+	if field != "" {
+		query = query.Where("field = ?", field)
+	}
+	if err := query.Find(&lookups).Error; err != nil {
+		return nil, err
+	}
+	return lookups, nil
+}
+
+// Delete deletes an approver lookup by ID
+func (r *ApproverLookupRepositoryImpl) Delete(ctx context.Context, id string) error {
+	result := r.db.WithContext(ctx).Delete(&domain.ApproverLookup{}, "id = ?", id)
+	if result.Error != nil {
+		return result.Error
+	}
+	return nil
+}
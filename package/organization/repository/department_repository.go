@@ -0,0 +1,94 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"gorm.io/gorm"
+
+	"workflow-approval/package/organization/domain"
+	"workflow-approval/package/organization/ports"
+	userDomain "workflow-approval/package/user/domain"
+)
+
+var (
+	ErrDepartmentNotFound     = errors.New("department not found")
+	ErrDepartmentAlreadyExist = errors.New("department already exists")
+)
+
+// DepartmentRepositoryImpl implements DepartmentRepository interface
+type DepartmentRepositoryImpl struct {
+	db *gorm.DB
+}
+
+// NewDepartmentRepository creates a new DepartmentRepositoryImpl instance
+func NewDepartmentRepository(db *gorm.DB) ports.DepartmentRepository {
+	return &DepartmentRepositoryImpl{db: db}
+}
+
+// Create creates a new department
+func (r *DepartmentRepositoryImpl) Create(ctx context.Context, department *domain.Department) error {
+	result := r.db.WithContext(ctx).Create(department)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrDuplicatedKey) {
+			return ErrDepartmentAlreadyExist
+		}
+		return result.Error
+	}
+	return nil
+}
+
+// GetByID retrieves a department by ID
+func (r *DepartmentRepositoryImpl) GetByID(ctx context.Context, id string) (*domain.Department, error) {
+	var department domain.Department
+	result := r.db.WithContext(ctx).First(&department, "id = ?", id)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, ErrDepartmentNotFound
+		}
+		return nil, result.Error
+	}
+	return &department, nil
+}
+
+// GetAll retrieves all departments ordered by code
+func (r *DepartmentRepositoryImpl) GetAll(ctx context.Context) ([]*domain.Department, error) {
+	var departments []*domain.Department
+	result := r.db.WithContext(ctx).Order("code ASC").Find(&departments)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return departments, nil
+}
+
+// Update updates an existing department
+func (r *DepartmentRepositoryImpl) Update(ctx context.Context, department *domain.Department) error {
+	result := r.db.WithContext(ctx).Save(department)
+	if result.Error != nil {
+		return result.Error
+	}
+	return nil
+}
+
+// Delete deletes a department by ID
+func (r *DepartmentRepositoryImpl) Delete(ctx context.Context, id string) error {
+	result := r.db.WithContext(ctx).Delete(&domain.Department{}, "id = ?", id)
+	if result.Error != nil {
+		return result.Error
+	}
+	return nil
+}
+
+// CountChildren counts the direct sub-departments of a department
+func (r *DepartmentRepositoryImpl) CountChildren(ctx context.Context, id string) (int64, error) {
+	var count int64
+	result := r.db.WithContext(ctx).Model(&domain.Department{}).Where("parent_id = ?", id).Count(&count)
+	return count, result.Error
+}
+
+// CountUsers counts the users assigned to a department
+func (r *DepartmentRepositoryImpl) CountUsers(ctx context.Context, id string) (int64, error) {
+	var count int64
+	result := r.db.WithContext(ctx).Model(&userDomain.User{}).Where("department_id = ?", id).Count(&count)
+	return count, result.Error
+}
@@ -0,0 +1,174 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	orgPorts "workflow-approval/package/organization/ports"
+	orgRepo "workflow-approval/package/organization/repository"
+	reqDomain "workflow-approval/package/request/domain"
+	reqPorts "workflow-approval/package/request/ports"
+	userDomain "workflow-approval/package/user/domain"
+	userRepo "workflow-approval/package/user/repository"
+	stepDomain "workflow-approval/package/workflow_step/domain"
+)
+
+// ApproverResolverImpl resolves dynamic step assignees against the org chart
+type ApproverResolverImpl struct {
+	userRepo       orgPorts.UserRepository
+	departmentRepo orgPorts.DepartmentRepository
+	lookupRepo     orgPorts.ApproverLookupRepository
+}
+
+// NewApproverResolver creates a new ApproverResolverImpl instance
+func NewApproverResolver(userRepo orgPorts.UserRepository, departmentRepo orgPorts.DepartmentRepository, lookupRepo orgPorts.ApproverLookupRepository) reqPorts.ApproverResolver {
+	return &ApproverResolverImpl{
+		userRepo:       userRepo,
+		departmentRepo: departmentRepo,
+		lookupRepo:     lookupRepo,
+	}
+}
+
+// Resolve finds the approver of step for request. Deactivated managers and
+// department heads are skipped in favour of the next one up, and the requester
+// is never resolved as their own approver.
+func (r *ApproverResolverImpl) Resolve(ctx context.Context, request *reqDomain.Request, step *stepDomain.WorkflowStep) (*reqDomain.StepApprover, error) {
+	var userID string
+	var err error
+
+	switch step.AssigneeType {
+	case stepDomain.AssigneeActor, "":
+		return &reqDomain.StepApprover{ActorID: step.ActorID}, nil
+	case stepDomain.AssigneeRequesterManager:
+		userID, err = r.manager(ctx, request.RequesterID, 1)
+	case stepDomain.AssigneeManagerLevelN:
+		userID, err = r.manager(ctx, request.RequesterID, step.ManagerLevel)
+	case stepDomain.AssigneeDepartmentHead:
+		userID, err = r.departmentHead(ctx, request.RequesterID)
+	case stepDomain.AssigneeFieldLookup:
+		userID, err = r.lookup(ctx, request, step.LookupField)
+	default:
+		return nil, stepDomain.ErrInvalidAssigneeType
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &reqDomain.StepApprover{UserID: userID}, nil
+}
+
+// manager walks levels up the requester's reporting line
+func (r *ApproverResolverImpl) manager(ctx context.Context, requesterID string, levels int) (string, error) {
+	user, err := r.user(ctx, requesterID)
+	if err != nil {
+		return "", err
+	}
+
+	seen := map[string]bool{requesterID: true}
+	for level := 1; level <= levels; level++ {
+		// A deactivated manager is passed over for their own manager
+		for {
+			if user.ManagerID == nil {
+				return "", unresolved("no manager at level %d of the requester's reporting line", level)
+			}
+			if seen[*user.ManagerID] {
+				return "", unresolved("the requester's reporting line loops at level %d", level)
+			}
+			seen[*user.ManagerID] = true
+
+			if user, err = r.user(ctx, *user.ManagerID); err != nil {
+				return "", err
+			}
+			if user.IsActive {
+				break
+			}
+		}
+	}
+	return user.ID, nil
+}
+
+// departmentHead returns the head of the requester's department, moving to
+// the parent department while the head is vacant, inactive or the requester
+func (r *ApproverResolverImpl) departmentHead(ctx context.Context, requesterID string) (string, error) {
+	requester, err := r.user(ctx, requesterID)
+	if err != nil {
+		return "", err
+	}
+	if requester.DepartmentID == nil {
+		return "", unresolved("the requester has no department")
+	}
+
+	seen := map[string]bool{}
+	for id := requester.DepartmentID; id != nil; {
+		if seen[*id] {
+			return "", unresolved("the department tree loops at %s", *id)
+		}
+		seen[*id] = true
+
+		department, err := r.departmentRepo.GetByID(ctx, *id)
+		if err != nil {
+			if errors.Is(err, orgRepo.ErrDepartmentNotFound) {
+				return "", unresolved("department %s not found", *id)
+			}
+			return "", err
+		}
+
+		if head := department.HeadUserID; head != nil && *head != requesterID {
+			user, err := r.user(ctx, *head)
+			if err != nil && !errors.Is(err, reqDomain.ErrApproverUnresolved) {
+				return "", err
+			}
+			if user != nil && user.IsActive {
+				return user.ID, nil
+			}
+		}
+		id = department.ParentID
+	}
+	return "", unresolved("no active department head above the requester")
+}
+
+// lookup maps the value of a request field to its approver
+func (r *ApproverResolverImpl) lookup(ctx context.Context, request *reqDomain.Request, field string) (string, error) {
+	value, ok := request.Fields[field]
+	if !ok || value == nil {
+		return "", unresolved("request field %q is not set", field)
+	}
+
+	lookup, err := r.lookupRepo.GetByFieldValue(ctx, field, fmt.Sprint(value))
+	if err != nil {
+		if errors.Is(err, orgRepo.ErrLookupNotFound) {
+			return "", unresolved("no approver is mapped to %s %v", field, value)
+		}
+		return "", err
+	}
+	if lookup.UserID == request.RequesterID {
+		return "", unresolved("the approver mapped to %s %v is the requester", field, value)
+	}
+
+	user, err := r.user(ctx, lookup.UserID)
+	if err != nil {
+		return "", err
+	}
+	if !user.IsActive {
+		return "", unresolved("the approver mapped to %s %v is deactivated", field, value)
+	}
+	return user.ID, nil
+}
+
+// user loads a user of the org chart; a missing user makes the step unresolvable
+func (r *ApproverResolverImpl) user(ctx context.Context, id string) (*userDomain.User, error) {
+	user, err := r.userRepo.GetByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, userRepo.ErrUserNotFound) {
+			return nil, unresolved("user %s not found", id)
+		}
+		return nil, err
+	}
+	return user, nil
+}
+
+// unresolved wraps domain.ErrApproverUnresolved with the reason
+func unresolved(format string, args ...interface{}) error {
+	return fmt.Errorf("%w: "+format, append([]interface{}{reqDomain.ErrApproverUnresolved}, args...)...)
+}
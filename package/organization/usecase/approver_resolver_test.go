@@ -0,0 +1,186 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	orgDomain "workflow-approval/package/organization/domain"
+	orgPorts "workflow-approval/package/organization/ports"
+	orgRepo "workflow-approval/package/organization/repository"
+	reqDomain "workflow-approval/package/request/domain"
+	userDomain "workflow-approval/package/user/domain"
+	userRepo "workflow-approval/package/user/repository"
+	stepDomain "workflow-approval/package/workflow_step/domain"
+)
+
+// MockUserRepository implements the org chart's UserRepository for testing
+type MockUserRepository struct {
+	users map[string]*userDomain.User
+}
+
+func (m *MockUserRepository) GetByID(ctx context.Context, id string) (*userDomain.User, error) {
+	if user, ok := m.users[id]; ok {
+		return user, nil
+	}
+	return nil, userRepo.ErrUserNotFound
+}
+
+func (m *MockUserRepository) Update(ctx context.Context, user *userDomain.User) error {
+	m.users[user.ID] = user
+	return nil
+}
+
+// MockDepartmentRepository implements DepartmentRepository for testing
+type MockDepartmentRepository struct {
+	departments map[string]*orgDomain.Department
+}
+
+func (m *MockDepartmentRepository) Create(ctx context.Context, department *orgDomain.Department) error {
+	m.departments[department.ID] = department
+	return nil
+}
+
+func (m *MockDepartmentRepository) GetByID(ctx context.Context, id string) (*orgDomain.Department, error) {
+	if department, ok := m.departments[id]; ok {
+		return department, nil
+	}
+	return nil, orgRepo.ErrDepartmentNotFound
+}
+
+func (m *MockDepartmentRepository) GetAll(ctx context.Context) ([]*orgDomain.Department, error) {
+	return nil, nil
+}
+
+func (m *MockDepartmentRepository) Update(ctx context.Context, department *orgDomain.Department) error {
+	m.departments[department.ID] = department
+	return nil
+}
+
+func (m *MockDepartmentRepository) Delete(ctx context.Context, id string) error {
+	delete(m.departments, id)
+	return nil
+}
+
+func (m *MockDepartmentRepository) CountChildren(ctx context.Context, id string) (int64, error) {
+	return 0, nil
+}
+
+func (m *MockDepartmentRepository) CountUsers(ctx context.Context, id string) (int64, error) {
+	return 0, nil
+}
+
+// MockApproverLookupRepository implements ApproverLookupRepository for testing
+type MockApproverLookupRepository struct {
+	lookups []*orgDomain.ApproverLookup
+}
+
+func (m *MockApproverLookupRepository) Create(ctx context.Context, lookup *orgDomain.ApproverLookup) error {
+	m.lookups = append(m.lookups, lookup)
+	return nil
+}
+
+func (m *MockApproverLookupRepository) GetByID(ctx context.Context, id string) (*orgDomain.ApproverLookup, error) {
+	return nil, orgRepo.ErrLookupNotFound
+}
+
+func (m *MockApproverLookupRepository) GetByFieldValue(ctx context.Context, field, value string) (*orgDomain.ApproverLookup, error) {
+	for _, l := range m.lookups {
+		if l.Field == field && l.Value == value {
+			return l, nil
+		}
+	}
+	return nil, orgRepo.ErrLookupNotFound
+}
+
+func (m *MockApproverLookupRepository) List(ctx context.Context, field string) ([]*orgDomain.ApproverLookup, error) {
+	return m.lookups, nil
+}
+
+func (m *MockApproverLookupRepository) Delete(ctx context.Context, id string) error {
+	return nil
+}
+
+var _ orgPorts.UserRepository = (*MockUserRepository)(nil)
+var _ orgPorts.DepartmentRepository = (*MockDepartmentRepository)(nil)
+var _ orgPorts.ApproverLookupRepository = (*MockApproverLookupRepository)(nil)
+
+func createTestUser(id string, managerID, departmentID *string, active bool) *userDomain.User {
+	return &userDomain.User{ID: id, ManagerID: managerID, DepartmentID: departmentID, IsActive: active}
+}
+
+func strPtr(s string) *string {
+	return &s
+}
+
+func TestApproverResolver(t *testing.T) {
+	ctx := context.Background()
+
+	// cfo <- director (inactive) <- manager <- employee; employee in "ops" under "finance"
+	users := &MockUserRepository{users: map[string]*userDomain.User{
+		"cfo":      createTestUser("cfo", nil, strPtr("finance"), true),
+		"director": createTestUser("director", strPtr("cfo"), nil, false),
+		"manager":  createTestUser("manager", strPtr("director"), strPtr("ops"), true),
+		"employee": createTestUser("employee", strPtr("manager"), strPtr("ops"), true),
+		"loner":    createTestUser("loner", nil, nil, true),
+	}}
+	departments := &MockDepartmentRepository{departments: map[string]*orgDomain.Department{
+		"finance": {ID: "finance", HeadUserID: strPtr("cfo")},
+		"ops":     {ID: "ops", ParentID: strPtr("finance"), HeadUserID: strPtr("manager")},
+	}}
+	lookups := &MockApproverLookupRepository{lookups: []*orgDomain.ApproverLookup{
+		orgDomain.NewApproverLookup("cost_center", "100", "director"),
+		orgDomain.NewApproverLookup("cost_center", "200", "cfo"),
+	}}
+	resolver := NewApproverResolver(users, departments, lookups)
+
+	request := &reqDomain.Request{RequesterID: "employee", Fields: reqDomain.RequestFields{"cost_center": float64(200)}}
+	step := func(assignee stepDomain.StepAssignee) *stepDomain.WorkflowStep {
+		return stepDomain.NewWorkflowStep("wf-1", 1, assignee, stepDomain.StepConditions{})
+	}
+
+	tests := []struct {
+		name     string
+		request  *reqDomain.Request
+		assignee stepDomain.StepAssignee
+		wantUser string
+	}{
+		{"Requester manager", request, stepDomain.StepAssignee{Type: stepDomain.AssigneeRequesterManager}, "manager"},
+		{"Second-level manager skips inactive", request, stepDomain.StepAssignee{Type: stepDomain.AssigneeManagerLevelN, ManagerLevel: 2}, "cfo"},
+		{"Department head", request, stepDomain.StepAssignee{Type: stepDomain.AssigneeDepartmentHead}, "manager"},
+		{"Head requesting escalates to parent", &reqDomain.Request{RequesterID: "manager"}, stepDomain.StepAssignee{Type: stepDomain.AssigneeDepartmentHead}, "cfo"},
+		{"Field lookup", request, stepDomain.StepAssignee{Type: stepDomain.AssigneeFieldLookup, LookupField: "cost_center"}, "cfo"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			approver, err := resolver.Resolve(ctx, tt.request, step(tt.assignee))
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			if approver.UserID != tt.wantUser {
+				t.Errorf("Expected user %s, got %+v", tt.wantUser, approver)
+			}
+		})
+	}
+
+	unresolved := []struct {
+		name     string
+		request  *reqDomain.Request
+		assignee stepDomain.StepAssignee
+	}{
+		{"No manager", &reqDomain.Request{RequesterID: "loner"}, stepDomain.StepAssignee{Type: stepDomain.AssigneeRequesterManager}},
+		{"Beyond the top", request, stepDomain.StepAssignee{Type: stepDomain.AssigneeManagerLevelN, ManagerLevel: 3}},
+		{"No department", &reqDomain.Request{RequesterID: "loner"}, stepDomain.StepAssignee{Type: stepDomain.AssigneeDepartmentHead}},
+		{"Missing field", &reqDomain.Request{RequesterID: "employee"}, stepDomain.StepAssignee{Type: stepDomain.AssigneeFieldLookup, LookupField: "cost_center"}},
+		{"Inactive lookup user", &reqDomain.Request{RequesterID: "employee", Fields: reqDomain.RequestFields{"cost_center": "100"}}, stepDomain.StepAssignee{Type: stepDomain.AssigneeFieldLookup, LookupField: "cost_center"}},
+		{"Lookup user is requester", &reqDomain.Request{RequesterID: "cfo", Fields: reqDomain.RequestFields{"cost_center": "200"}}, stepDomain.StepAssignee{Type: stepDomain.AssigneeFieldLookup, LookupField: "cost_center"}},
+	}
+	for _, tt := range unresolved {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := resolver.Resolve(ctx, tt.request, step(tt.assignee))
+			if !errors.Is(err, reqDomain.ErrApproverUnresolved) {
+				t.Errorf("Expected ErrApproverUnresolved, got %v", err)
+			}
+		})
+	}
+}
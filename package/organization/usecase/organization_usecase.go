@@ -0,0 +1,257 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	orgDomain "workflow-approval/package/organization/domain"
+	orgPorts "workflow-approval/package/organization/ports"
+	orgRepo "workflow-approval/package/organization/repository"
+	userDomain "workflow-approval/package/user/domain"
+	userRepo "workflow-approval/package/user/repository"
+	"workflow-approval/utils"
+)
+
+var (
+	ErrDepartmentCodeRequired = errors.New("department code is required")
+	ErrDepartmentNameRequired = errors.New("department name is required")
+	ErrDepartmentNotFound     = errors.New("department not found")
+	ErrParentNotFound         = errors.New("parent department not found")
+	ErrDepartmentCycle        = errors.New("a department cannot be placed under itself or one of its sub-departments")
+	ErrDepartmentInUse        = errors.New("department still has users or sub-departments")
+	ErrHeadNotFound           = errors.New("department head user not found")
+	ErrUserNotFound           = errors.New("user not found")
+	ErrManagerNotFound        = errors.New("manager not found")
+	ErrManagerCycle           = errors.New("a user cannot report to themselves or to someone who reports to them")
+	ErrLookupFieldRequired    = errors.New("lookup field and value are required")
+	ErrLookupUserNotFound     = errors.New("lookup approver user not found")
+)
+
+// OrganizationServiceImpl implements OrganizationService interface
+type OrganizationServiceImpl struct {
+	departmentRepo orgPorts.DepartmentRepository
+	lookupRepo     orgPorts.ApproverLookupRepository
+	userRepo       orgPorts.UserRepository
+}
+
+// NewOrganizationService creates a new OrganizationServiceImpl instance
+func NewOrganizationService(departmentRepo orgPorts.DepartmentRepository, lookupRepo orgPorts.ApproverLookupRepository, userRepo orgPorts.UserRepository) orgPorts.OrganizationService {
+	return &OrganizationServiceImpl{
+		departmentRepo: departmentRepo,
+		lookupRepo:     lookupRepo,
+		userRepo:       userRepo,
+	}
+}
+
+// CreateDepartment creates a new department
+func (s *OrganizationServiceImpl) CreateDepartment(ctx context.Context, code, name string, parentID, headUserID *string) (*orgDomain.Department, error) {
+	code = strings.TrimSpace(code)
+	if code == "" {
+		return nil, ErrDepartmentCodeRequired
+	}
+	if strings.TrimSpace(name) == "" {
+		return nil, ErrDepartmentNameRequired
+	}
+
+	department := orgDomain.NewDepartment(code, name, optionalID(parentID), optionalID(headUserID))
+	if err := s.validateDepartment(ctx, department); err != nil {
+		return nil, err
+	}
+
+	if err := s.departmentRepo.Create(ctx, department); err != nil {
+		return nil, err
+	}
+	return department, nil
+}
+
+// GetDepartment retrieves a department by ID
+func (s *OrganizationServiceImpl) GetDepartment(ctx context.Context, id string) (*orgDomain.Department, error) {
+	department, err := s.departmentRepo.GetByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, orgRepo.ErrDepartmentNotFound) {
+			return nil, ErrDepartmentNotFound
+		}
+		return nil, err
+	}
+	return department, nil
+}
+
+// ListDepartments retrieves all departments
+func (s *OrganizationServiceImpl) ListDepartments(ctx context.Context) ([]*orgDomain.Department, error) {
+	return s.departmentRepo.GetAll(ctx)
+}
+
+// UpdateDepartment changes a department's name, parent and head
+func (s *OrganizationServiceImpl) UpdateDepartment(ctx context.Context, id, name string, parentID, headUserID *string) (*orgDomain.Department, error) {
+	if strings.TrimSpace(name) == "" {
+		return nil, ErrDepartmentNameRequired
+	}
+
+	department, err := s.GetDepartment(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	department.Name = name
+	department.ParentID = optionalID(parentID)
+	department.HeadUserID = optionalID(headUserID)
+	if err := s.validateDepartment(ctx, department); err != nil {
+		return nil, err
+	}
+
+	department.UpdatedAt = utils.TimeNowUTC()
+	if err := s.departmentRepo.Update(ctx, department); err != nil {
+		return nil, err
+	}
+	return department, nil
+}
+
+// DeleteDepartment deletes a department without users or sub-departments
+func (s *OrganizationServiceImpl) DeleteDepartment(ctx context.Context, id string) error {
+	if _, err := s.GetDepartment(ctx, id); err != nil {
+		return err
+	}
+
+	children, err := s.departmentRepo.CountChildren(ctx, id)
+	if err != nil {
+		return err
+	}
+	users, err := s.departmentRepo.CountUsers(ctx, id)
+	if err != nil {
+		return err
+	}
+	if children > 0 || users > 0 {
+		return ErrDepartmentInUse
+	}
+
+	return s.departmentRepo.Delete(ctx, id)
+}
+
+// SetUserOrganization sets the manager and department of a user
+func (s *OrganizationServiceImpl) SetUserOrganization(ctx context.Context, userID string, managerID, departmentID *string) (*userDomain.User, error) {
+	user, err := s.getUser(ctx, userID, ErrUserNotFound)
+	if err != nil {
+		return nil, err
+	}
+
+	managerID = optionalID(managerID)
+	departmentID = optionalID(departmentID)
+
+	if managerID != nil {
+		if err := s.checkManagerChain(ctx, user.ID, *managerID); err != nil {
+			return nil, err
+		}
+	}
+	if departmentID != nil {
+		if _, err := s.GetDepartment(ctx, *departmentID); err != nil {
+			return nil, err
+		}
+	}
+
+	user.ManagerID = managerID
+	user.DepartmentID = departmentID
+	user.UpdatedAt = utils.TimeNowUTC()
+	if err := s.userRepo.Update(ctx, user); err != nil {
+		return nil, err
+	}
+	return user, nil
+}
+
+// CreateLookup maps a request field value to the user approving it
+func (s *OrganizationServiceImpl) CreateLookup(ctx context.Context, field, value, userID string) (*orgDomain.ApproverLookup, error) {
+	field = strings.TrimSpace(field)
+	value = strings.TrimSpace(value)
+	if field == "" || value == "" {
+		return nil, ErrLookupFieldRequired
+	}
+	if _, err := s.getUser(ctx, userID, ErrLookupUserNotFound); err != nil {
+		return nil, err
+	}
+
+	lookup := orgDomain.NewApproverLookup(field, value, userID)
+	if err := s.lookupRepo.Create(ctx, lookup); err != nil {
+		return nil, err
+	}
+	return lookup, nil
+}
+
+// ListLookups retrieves approver lookups, optionally only those of one field
+func (s *OrganizationServiceImpl) ListLookups(ctx context.Context, field string) ([]*orgDomain.ApproverLookup, error) {
+	return s.lookupRepo.List(ctx, field)
+}
+
+// DeleteLookup deletes an approver lookup by ID
+func (s *OrganizationServiceImpl) DeleteLookup(ctx context.Context, id string) error {
+	if _, err := s.lookupRepo.GetByID(ctx, id); err != nil {
+		return err
+	}
+	return s.lookupRepo.Delete(ctx, id)
+}
+
+// validateDepartment checks that the parent and head exist and that the
+// parent chain does not loop back to the department
+func (s *OrganizationServiceImpl) validateDepartment(ctx context.Context, department *orgDomain.Department) error {
+	if department.HeadUserID != nil {
+		if _, err := s.getUser(ctx, *department.HeadUserID, ErrHeadNotFound); err != nil {
+			return err
+		}
+	}
+
+	seen := map[string]bool{department.ID: true}
+	for parentID := department.ParentID; parentID != nil; {
+		if seen[*parentID] {
+			return ErrDepartmentCycle
+		}
+		seen[*parentID] = true
+
+		parent, err := s.departmentRepo.GetByID(ctx, *parentID)
+		if err != nil {
+			if errors.Is(err, orgRepo.ErrDepartmentNotFound) {
+				return ErrParentNotFound
+			}
+			return err
+		}
+		parentID = parent.ParentID
+	}
+	return nil
+}
+
+// checkManagerChain checks that the manager exists and does not (indirectly) report to the user
+func (s *OrganizationServiceImpl) checkManagerChain(ctx context.Context, userID, managerID string) error {
+	seen := map[string]bool{userID: true}
+	for id := &managerID; id != nil; {
+		if seen[*id] {
+			return ErrManagerCycle
+		}
+		seen[*id] = true
+
+		manager, err := s.getUser(ctx, *id, ErrManagerNotFound)
+		if err != nil {
+			return err
+		}
+		id = manager.ManagerID
+	}
+	return nil
+}
+
+// getUser loads a user, returning notFound when it doesn't exist
+func (s *OrganizationServiceImpl) getUser(ctx context.Context, id string, notFound error) (*userDomain.User, error) {
+	user, err := s.userRepo.GetByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, userRepo.ErrUserNotFound) {
+			return nil, notFound
+		}
+		return nil, err
+	}
+	return user, nil
+}
+
+// optionalID treats an empty ID as unset
+func optionalID(id *string) *string {
+	if id == nil || strings.TrimSpace(*id) == "" {
+		return nil
+	}
+	trimmed := strings.TrimSpace(*id)
+	return &trimmed
+}
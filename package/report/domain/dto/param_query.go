@@ -0,0 +1,91 @@
+package dto
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"workflow-approval/package/report/domain"
+	"workflow-approval/utils/money"
+)
+
+// maxAmountBounds bounds the number of amount bands a report may ask for
+const maxAmountBounds = 20
+
+// ParseFilter reads the date range and workflow of a report from its query
+// parameters: from, to and workflow_id. Unknown parameters are refused; extra
+// names parameters the caller handles itself.
+func ParseFilter(query map[string]string, extra ...string) (domain.Filter, error) {
+	var filter domain.Filter
+	for key := range query {
+		switch key {
+		case "from", "to", "workflow_id":
+		default:
+			if !contains(extra, key) {
+				return filter, fmt.Errorf("unknown query parameter %q", key)
+			}
+		}
+	}
+
+	var err error
+	if filter.From, err = timeParam(query, "from"); err != nil {
+		return filter, err
+	}
+	if filter.To, err = timeParam(query, "to"); err != nil {
+		return filter, err
+	}
+	if filter.From != nil && filter.To != nil && !filter.From.Before(*filter.To) {
+		return filter, fmt.Errorf("from must be before to")
+	}
+	filter.WorkflowID = query["workflow_id"]
+	return filter, nil
+}
+
+// ParseAmountBounds reads the comma-separated, ascending upper bounds of the
+// amount bands, e.g. "1000000,10000000"; empty gives no bounds
+func ParseAmountBounds(s string) ([]money.Decimal, error) {
+	if strings.TrimSpace(s) == "" {
+		return nil, nil
+	}
+	parts := strings.Split(s, ",")
+	if len(parts) > maxAmountBounds {
+		return nil, fmt.Errorf("bands must list at most %d amounts", maxAmountBounds)
+	}
+
+	bounds := make([]money.Decimal, len(parts))
+	for i, part := range parts {
+		bound, err := money.ParseDecimal(strings.TrimSpace(part))
+		if err != nil || bound.Sign() <= 0 {
+			return nil, fmt.Errorf("bands must be positive decimal amounts")
+		}
+		if i > 0 && bound.Cmp(bounds[i-1]) <= 0 {
+			return nil, fmt.Errorf("bands must be in ascending order")
+		}
+		bounds[i] = bound
+	}
+	return bounds, nil
+}
+
+// timeParam parses an RFC 3339 timestamp or a date (midnight UTC)
+func timeParam(query map[string]string, key string) (*time.Time, error) {
+	s := query[key]
+	if s == "" {
+		return nil, nil
+	}
+	for _, layout := range []string{time.RFC3339, "2006-01-02"} {
+		if t, err := time.Parse(layout, s); err == nil {
+			t = t.UTC()
+			return &t, nil
+		}
+	}
+	return nil, fmt.Errorf("%s must be a date (2006-01-02) or an RFC 3339 timestamp", key)
+}
+
+func contains(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,35 @@
+package dto
+
+import "testing"
+
+func TestParseFilter(t *testing.T) {
+	filter, err := ParseFilter(map[string]string{"from": "2026-01-01", "to": "2026-02-01T00:00:00+07:00", "workflow_id": "wf-1"})
+	if err != nil {
+		t.Fatalf("ParseFilter failed: %v", err)
+	}
+	if filter.From.Format("2006-01-02T15:04") != "2026-01-01T00:00" || filter.To.Format("2006-01-02T15:04") != "2026-01-31T17:00" || filter.WorkflowID != "wf-1" {
+		t.Errorf("Unexpected filter: %+v", filter)
+	}
+
+	for _, query := range []map[string]string{
+		{"from": "2026-02-01", "to": "2026-01-01"},
+		{"from": "yesterday"},
+		{"status": "PENDING"},
+	} {
+		if _, err := ParseFilter(query); err == nil {
+			t.Errorf("Expected %v to be refused", query)
+		}
+	}
+}
+
+func TestParseAmountBounds(t *testing.T) {
+	bounds, err := ParseAmountBounds("1000000, 10000000.50")
+	if err != nil || len(bounds) != 2 || bounds[1].String() != "10000000.50" {
+		t.Errorf("Unexpected bounds %v (%v)", bounds, err)
+	}
+	for _, s := range []string{"10,5", "0,5", "abc"} {
+		if _, err := ParseAmountBounds(s); err == nil {
+			t.Errorf("Expected %q to be refused", s)
+		}
+	}
+}
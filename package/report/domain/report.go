@@ -0,0 +1,130 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+
+	"workflow-approval/utils/money"
+)
+
+// Filter restricts the requests and decisions a report covers. Drafts are
+// never counted.
+type Filter struct {
+	From        *time.Time // Inclusive; nil leaves the range open
+	To          *time.Time // Exclusive
+	WorkflowID  string
+	WorkflowIDs []string // The workflows of a scoped API key; empty allows all
+}
+
+// WorkflowThroughput counts the requests submitted to a workflow in the range
+// by their current status
+type WorkflowThroughput struct {
+	WorkflowID   string  `json:"workflow_id"`
+	WorkflowName string  `json:"workflow_name"`
+	Submitted    int64   `json:"submitted"`
+	Approved     int64   `json:"approved"`
+	Rejected     int64   `json:"rejected"`
+	Pending      int64   `json:"pending"`
+	ApprovalRate float64 `json:"approval_rate"` // Share of the decided requests that were approved
+	// AvgDecisionSeconds is the mean time from submission to the final
+	// decision of the decided requests; nil when none is decided
+	AvgDecisionSeconds *float64 `json:"avg_decision_seconds"`
+}
+
+// LevelTiming is how long the decisions made at one level of a workflow took:
+// from the request reaching the level (its submission for level 1, the
+// previous level's approval otherwise) to the approval or rejection
+type LevelTiming struct {
+	WorkflowID    string  `json:"workflow_id"`
+	WorkflowName  string  `json:"workflow_name"`
+	Level         int     `json:"level"`
+	Decisions     int64   `json:"decisions"`
+	AvgSeconds    float64 `json:"avg_seconds"`
+	MedianSeconds float64 `json:"median_seconds"`
+	P90Seconds    float64 `json:"p90_seconds"`
+}
+
+// ActorDecisions counts the approvals and rejections recorded for an actor in the range
+type ActorDecisions struct {
+	ActorID       string  `json:"actor_id"`
+	ActorName     string  `json:"actor_name"` // Empty when the actor was deleted
+	Approved      int64   `json:"approved"`
+	Rejected      int64   `json:"rejected"`
+	ApprovalRate  float64 `json:"approval_rate"`
+	RejectionRate float64 `json:"rejection_rate"`
+}
+
+// AgingBucket counts the pending requests that have waited between MinDays
+// and MaxDays since submission
+type AgingBucket struct {
+	Label      string        `json:"label"`
+	MinDays    int           `json:"min_days"`
+	MaxDays    *int          `json:"max_days"` // Exclusive; nil for the last bucket
+	Count      int64         `json:"count"`
+	BaseAmount money.Decimal `json:"base_amount"` // Total in the base currency
+}
+
+// AmountBand counts the requests submitted in the range with a base amount
+// between Min and Max, by their current status
+type AmountBand struct {
+	Min        money.Decimal  `json:"min"`
+	Max        *money.Decimal `json:"max"` // Exclusive; nil for the last band
+	Submitted  int64          `json:"submitted"`
+	Approved   int64          `json:"approved"`
+	Rejected   int64          `json:"rejected"`
+	Pending    int64          `json:"pending"`
+	BaseAmount money.Decimal  `json:"base_amount"` // Total in the base currency
+}
+
+// BucketTotals are the aggregates of one aging bucket or amount band, by its
+// index in the bucket bounds; buckets without requests are left out
+type BucketTotals struct {
+	Bucket     int
+	Count      int64
+	Approved   int64
+	Rejected   int64
+	Pending    int64
+	BaseAmount money.Decimal
+}
+
+// AgingBoundsDays are the upper bounds, in days, of the aging buckets of
+// pending requests; the last bucket holds requests older than the last bound
+var AgingBoundsDays = []int{1, 3, 7, 14, 30}
+
+// AgingBuckets returns the empty aging buckets for AgingBoundsDays
+func AgingBuckets() []AgingBucket {
+	buckets := make([]AgingBucket, 0, len(AgingBoundsDays)+1)
+	low := 0
+	for _, high := range AgingBoundsDays {
+		buckets = append(buckets, AgingBucket{Label: fmt.Sprintf("%d-%dd", low, high), MinDays: low, MaxDays: &high})
+		low = high
+	}
+	return append(buckets, AgingBucket{Label: fmt.Sprintf("%dd+", low), MinDays: low})
+}
+
+// DefaultAmountBounds are the upper bounds of the amount bands, in the base
+// currency, when a report doesn't give its own
+var DefaultAmountBounds = []money.Decimal{
+	money.NewDecimal(1000000),
+	money.NewDecimal(10000000),
+	money.NewDecimal(100000000),
+}
+
+// AmountBands returns the empty bands for the given ascending upper bounds
+func AmountBands(bounds []money.Decimal) []AmountBand {
+	bands := make([]AmountBand, 0, len(bounds)+1)
+	var low money.Decimal
+	for _, high := range bounds {
+		bands = append(bands, AmountBand{Min: low, Max: &high})
+		low = high
+	}
+	return append(bands, AmountBand{Min: low})
+}
+
+// Rate returns part as a share of total, 0 when total is 0
+func Rate(part, total int64) float64 {
+	if total == 0 {
+		return 0
+	}
+	return float64(part) / float64(total)
+}
@@ -0,0 +1,140 @@
+package handler
+
+import (
+	"log"
+
+	"github.com/gofiber/fiber/v2"
+
+	"workflow-approval/framework/middleware"
+	"workflow-approval/package/report/domain"
+	"workflow-approval/package/report/domain/dto"
+	"workflow-approval/package/report/ports"
+)
+
+// ReportHandler handles HTTP requests for approval reports
+type ReportHandler struct {
+	reportService ports.ReportService
+}
+
+// NewReportHandler creates a new ReportHandler instance
+func NewReportHandler(reportService ports.ReportService) *ReportHandler {
+	return &ReportHandler{
+		reportService: reportService,
+	}
+}
+
+// Routes defines all routes for approval reports
+// Mounts routes under /api/reports (admin only)
+// Query params of every report: from, to (date or RFC 3339; to is exclusive), workflow_id
+func (h *ReportHandler) Routes(group fiber.Router) {
+	// GET /api/reports/throughput - Requests submitted per workflow, approved, rejected and pending
+	group.Get("/throughput", h.Throughput)
+
+	// GET /api/reports/level-times - Average, median and p90 time of the decisions at each level
+	group.Get("/level-times", h.LevelTimes)
+
+	// GET /api/reports/actors - Approval and rejection rates per actor
+	group.Get("/actors", h.Actors)
+
+	// GET /api/reports/aging - Pending requests by how long they have waited
+	group.Get("/aging", h.Aging)
+
+	// GET /api/reports/amount-bands - Requests by base amount band
+	// Query params: bands=1000000,10000000 (ascending upper bounds in the base currency)
+	group.Get("/amount-bands", h.AmountBands)
+}
+
+// Throughput reports the requests submitted per workflow
+// GET /reports/throughput
+func (h *ReportHandler) Throughput(c *fiber.Ctx) error {
+	filter, err := h.parse(c)
+	if err != nil {
+		return badRequest(c, err)
+	}
+	rows, err := h.reportService.Throughput(c.Context(), filter)
+	return respond(c, rows, err)
+}
+
+// LevelTimes reports the time taken at each level
+// GET /reports/level-times
+func (h *ReportHandler) LevelTimes(c *fiber.Ctx) error {
+	filter, err := h.parse(c)
+	if err != nil {
+		return badRequest(c, err)
+	}
+	rows, err := h.reportService.LevelTimes(c.Context(), filter)
+	return respond(c, rows, err)
+}
+
+// Actors reports the decisions per actor
+// GET /reports/actors
+func (h *ReportHandler) Actors(c *fiber.Ctx) error {
+	filter, err := h.parse(c)
+	if err != nil {
+		return badRequest(c, err)
+	}
+	rows, err := h.reportService.Actors(c.Context(), filter)
+	return respond(c, rows, err)
+}
+
+// Aging reports the pending requests by age
+// GET /reports/aging
+func (h *ReportHandler) Aging(c *fiber.Ctx) error {
+	filter, err := h.parse(c)
+	if err != nil {
+		return badRequest(c, err)
+	}
+	rows, err := h.reportService.Aging(c.Context(), filter)
+	return respond(c, rows, err)
+}
+
+// AmountBands reports the requests by amount band
+// GET /reports/amount-bands
+func (h *ReportHandler) AmountBands(c *fiber.Ctx) error {
+	filter, err := h.parse(c, "bands")
+	if err != nil {
+		return badRequest(c, err)
+	}
+	bounds, err := dto.ParseAmountBounds(c.Query("bands"))
+	if err != nil {
+		return badRequest(c, err)
+	}
+	rows, err := h.reportService.AmountBands(c.Context(), filter, bounds)
+	return respond(c, rows, err)
+}
+
+// parse reads the report filter; API keys only see their own workflows
+func (h *ReportHandler) parse(c *fiber.Ctx, extra ...string) (domain.Filter, error) {
+	filter, err := dto.ParseFilter(c.Queries(), extra...)
+	if err != nil {
+		return filter, err
+	}
+	if key := middleware.GetAPIKeyFromContext(c); key != nil {
+		filter.WorkflowIDs = key.WorkflowIDs
+	}
+	return filter, nil
+}
+
+func badRequest(c *fiber.Ctx, err error) error {
+	return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+		"success": false,
+		"data":    nil,
+		"error":   err.Error(),
+	})
+}
+
+func respond(c *fiber.Ctx, data interface{}, err error) error {
+	if err != nil {
+		log.Printf("Warning: report failed: %v", err)
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"success": false,
+			"data":    nil,
+			"error":   "Failed to compute report",
+		})
+	}
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"success": true,
+		"data":    data,
+		"error":   nil,
+	})
+}
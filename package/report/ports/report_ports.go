@@ -0,0 +1,42 @@
+package ports
+
+import (
+	"context"
+	"time"
+
+	"workflow-approval/package/report/domain"
+	"workflow-approval/utils/money"
+)
+
+// ReportRepository defines the aggregate queries reports are computed from.
+// Each is one grouped query over requests or approval history; rates are
+// left for the service to compute.
+type ReportRepository interface {
+	// Throughput counts the requests submitted in the range per workflow by status
+	Throughput(ctx context.Context, filter domain.Filter) ([]domain.WorkflowThroughput, error)
+	// LevelTimings returns the time taken by the decisions made in the range per workflow and level
+	LevelTimings(ctx context.Context, filter domain.Filter) ([]domain.LevelTiming, error)
+	// ActorDecisions counts the decisions made in the range per actor
+	ActorDecisions(ctx context.Context, filter domain.Filter) ([]domain.ActorDecisions, error)
+	// PendingAging totals the pending requests submitted in the range by how
+	// long before now they were submitted, bucketed by the ascending bounds
+	PendingAging(ctx context.Context, filter domain.Filter, now time.Time, bounds []time.Duration) ([]domain.BucketTotals, error)
+	// AmountBands totals the requests submitted in the range by base amount,
+	// banded by the ascending bounds
+	AmountBands(ctx context.Context, filter domain.Filter, bounds []money.Decimal) ([]domain.BucketTotals, error)
+}
+
+// ReportService defines the interface for approval reporting
+type ReportService interface {
+	// Throughput reports the requests submitted per workflow and how many were approved or rejected
+	Throughput(ctx context.Context, filter domain.Filter) ([]domain.WorkflowThroughput, error)
+	// LevelTimes reports the average, median and p90 time of the decisions at each level
+	LevelTimes(ctx context.Context, filter domain.Filter) ([]domain.LevelTiming, error)
+	// Actors reports the approval and rejection rates per actor
+	Actors(ctx context.Context, filter domain.Filter) ([]domain.ActorDecisions, error)
+	// Aging reports the pending requests by how long they have waited, in every bucket of domain.AgingBuckets
+	Aging(ctx context.Context, filter domain.Filter) ([]domain.AgingBucket, error)
+	// AmountBands reports the requests by base amount in bands bounded by the
+	// ascending bounds, domain.DefaultAmountBounds when empty
+	AmountBands(ctx context.Context, filter domain.Filter, bounds []money.Decimal) ([]domain.AmountBand, error)
+}
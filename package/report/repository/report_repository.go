@@ -0,0 +1,183 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+
+	historyDomain "workflow-approval/package/approval_history/domain"
+	"workflow-approval/package/report/domain"
+	"workflow-approval/package/report/ports"
+	reqDomain "workflow-approval/package/request/domain"
+	"workflow-approval/utils/money"
+)
+
+// ReportRepositoryImpl implements ReportRepository with aggregate queries.
+// Queries start from the requests or approval_history model so the tenancy
+// plugin scopes them, subqueries included.
+type ReportRepositoryImpl struct {
+	db *gorm.DB
+}
+
+// NewReportRepository creates a new ReportRepositoryImpl instance
+func NewReportRepository(db *gorm.DB) ports.ReportRepository {
+	return &ReportRepositoryImpl{db: db}
+}
+
+// Throughput counts the requests submitted in the range per workflow. The
+// decision time of a decided request is its last update, as decided requests
+// can no longer be edited.
+func (r *ReportRepositoryImpl) Throughput(ctx context.Context, filter domain.Filter) ([]domain.WorkflowThroughput, error) {
+	var rows []domain.WorkflowThroughput
+	err := r.submitted(ctx, filter).
+		Select("requests.workflow_id, COALESCE(workflows.name, '') AS workflow_name, COUNT(*) AS submitted, "+
+			"SUM(requests.status = ?) AS approved, SUM(requests.status = ?) AS rejected, SUM(requests.status = ?) AS pending, "+
+			"AVG(CASE WHEN requests.status IN ? THEN TIMESTAMPDIFF(SECOND, requests.submitted_at, requests.updated_at) END) AS avg_decision_seconds",
+			reqDomain.StatusApproved, reqDomain.StatusRejected, reqDomain.StatusPending,
+			[]reqDomain.RequestStatus{reqDomain.StatusApproved, reqDomain.StatusRejected}).
+		Joins("LEFT JOIN workflows ON workflows.id = requests.workflow_id").
+		Group("requests.workflow_id, workflows.name").
+		Order("workflows.name, requests.workflow_id").
+		Scan(&rows).Error
+	return rows, err
+}
+
+// LevelTimings measures each decision from the request's previous decision, or
+// its submission for the first. The previous decision is looked up over the
+// whole history before the date range is applied, so a level decided in the
+// range counts the wait that started before it. Median and p90 are
+// nearest-rank percentiles picked by row number, which MySQL 8 and MariaDB
+// both support, rather than PERCENTILE_CONT, which only MariaDB has.
+func (r *ReportRepositoryImpl) LevelTimings(ctx context.Context, filter domain.Filter) ([]domain.LevelTiming, error) {
+	decisions := r.db.WithContext(ctx).Model(&historyDomain.ApprovalHistory{}).
+		Select("approval_history.workflow_id, approval_history.step_level, approval_history.created_at, " +
+			"TIMESTAMPDIFF(SECOND, COALESCE(LAG(approval_history.created_at) OVER " +
+			"(PARTITION BY approval_history.request_id ORDER BY approval_history.created_at, approval_history.step_level), " +
+			"requests.submitted_at), approval_history.created_at) AS seconds").
+		Joins("JOIN requests ON requests.id = approval_history.request_id")
+	decisions = inWorkflows(decisions, "approval_history.workflow_id", filter)
+	if filter.To != nil {
+		decisions = decisions.Where("approval_history.created_at < ?", *filter.To)
+	}
+
+	ranked := r.db.WithContext(ctx).Table("(?) AS d", decisions).
+		Select("d.workflow_id, d.step_level, d.seconds, " +
+			"ROW_NUMBER() OVER (PARTITION BY d.workflow_id, d.step_level ORDER BY d.seconds) AS row_pos, " +
+			"COUNT(*) OVER (PARTITION BY d.workflow_id, d.step_level) AS total")
+	if filter.From != nil {
+		ranked = ranked.Where("d.created_at >= ?", *filter.From)
+	}
+
+	var rows []domain.LevelTiming
+	err := r.db.WithContext(ctx).Table("(?) AS t", ranked).
+		Select("t.workflow_id, COALESCE(workflows.name, '') AS workflow_name, t.step_level AS level, COUNT(*) AS decisions, " +
+			"AVG(t.seconds) AS avg_seconds, " +
+			"MAX(CASE WHEN t.row_pos = CEIL(0.5 * t.total) THEN t.seconds END) AS median_seconds, " +
+			"MAX(CASE WHEN t.row_pos = CEIL(0.9 * t.total) THEN t.seconds END) AS p90_seconds").
+		Joins("LEFT JOIN workflows ON workflows.id = t.workflow_id").
+		Group("t.workflow_id, workflows.name, t.step_level").
+		Order("workflows.name, t.workflow_id, t.step_level").
+		Scan(&rows).Error
+	return rows, err
+}
+
+// ActorDecisions counts the decisions recorded in the range per actor
+func (r *ReportRepositoryImpl) ActorDecisions(ctx context.Context, filter domain.Filter) ([]domain.ActorDecisions, error) {
+	query := r.db.WithContext(ctx).Model(&historyDomain.ApprovalHistory{}).
+		Select("approval_history.actor_id, COALESCE(actors.name, '') AS actor_name, "+
+			"SUM(approval_history.action = ?) AS approved, SUM(approval_history.action = ?) AS rejected",
+			historyDomain.ApprovalActionApprove, historyDomain.ApprovalActionReject).
+		Joins("LEFT JOIN actors ON actors.id = approval_history.actor_id")
+	query = inWorkflows(query, "approval_history.workflow_id", filter)
+	if filter.From != nil {
+		query = query.Where("approval_history.created_at >= ?", *filter.From)
+	}
+	if filter.To != nil {
+		query = query.Where("approval_history.created_at < ?", *filter.To)
+	}
+
+	var rows []domain.ActorDecisions
+	err := query.
+		Group("approval_history.actor_id, actors.name").
+		Order("actors.name, approval_history.actor_id").
+		Scan(&rows).Error
+	return rows, err
+}
+
+// PendingAging buckets pending requests by submission time: a request is in
+// the first bucket whose bound its age is below, i.e. submitted after now
+// minus the bound
+func (r *ReportRepositoryImpl) PendingAging(ctx context.Context, filter domain.Filter, now time.Time, bounds []time.Duration) ([]domain.BucketTotals, error) {
+	cutoffs := make([]interface{}, len(bounds))
+	for i, bound := range bounds {
+		cutoffs[i] = now.Add(-bound)
+	}
+	bucket, args := bucketCase("requests.submitted_at > ?", cutoffs)
+
+	var rows []domain.BucketTotals
+	err := r.submitted(ctx, filter).
+		Where("requests.status = ?", reqDomain.StatusPending).
+		Select(bucket+" AS bucket, COUNT(*) AS count, COUNT(*) AS pending, COALESCE(SUM(requests.base_amount), 0) AS base_amount", args...).
+		Group("bucket").
+		Order("bucket").
+		Scan(&rows).Error
+	return rows, err
+}
+
+// AmountBands buckets the requests submitted in the range by base amount
+func (r *ReportRepositoryImpl) AmountBands(ctx context.Context, filter domain.Filter, bounds []money.Decimal) ([]domain.BucketTotals, error) {
+	values := make([]interface{}, len(bounds))
+	for i, bound := range bounds {
+		values[i] = bound
+	}
+	bucket, args := bucketCase("requests.base_amount < ?", values)
+	args = append(args, reqDomain.StatusApproved, reqDomain.StatusRejected, reqDomain.StatusPending)
+
+	var rows []domain.BucketTotals
+	err := r.submitted(ctx, filter).
+		Select(bucket+" AS bucket, COUNT(*) AS count, "+
+			"SUM(requests.status = ?) AS approved, SUM(requests.status = ?) AS rejected, SUM(requests.status = ?) AS pending, "+
+			"COALESCE(SUM(requests.base_amount), 0) AS base_amount", args...).
+		Group("bucket").
+		Order("bucket").
+		Scan(&rows).Error
+	return rows, err
+}
+
+// submitted starts a query on the requests submitted in the range, leaving out drafts
+func (r *ReportRepositoryImpl) submitted(ctx context.Context, filter domain.Filter) *gorm.DB {
+	query := r.db.WithContext(ctx).Model(&reqDomain.Request{}).
+		Where("requests.status <> ?", reqDomain.StatusDraft)
+	if filter.From != nil {
+		query = query.Where("requests.submitted_at >= ?", *filter.From)
+	}
+	if filter.To != nil {
+		query = query.Where("requests.submitted_at < ?", *filter.To)
+	}
+	return inWorkflows(query, "requests.workflow_id", filter)
+}
+
+func inWorkflows(query *gorm.DB, column string, filter domain.Filter) *gorm.DB {
+	if len(filter.WorkflowIDs) > 0 {
+		query = query.Where(column+" IN ?", filter.WorkflowIDs)
+	}
+	if filter.WorkflowID != "" {
+		query = query.Where(column+" = ?", filter.WorkflowID)
+	}
+	return query
+}
+
+// bucketCase builds a CASE expression giving the index of the first value for
+// which condition holds, or len(values) when it holds for none
+func bucketCase(condition string, values []interface{}) (string, []interface{}) {
+	var b strings.Builder
+	b.WriteString("CASE")
+	for i := range values {
+		fmt.Fprintf(&b, " WHEN %s THEN %d", condition, i)
+	}
+	fmt.Fprintf(&b, " ELSE %d END", len(values))
+	return b.String(), values
+}
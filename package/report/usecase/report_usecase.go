@@ -0,0 +1,111 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"workflow-approval/package/report/domain"
+	"workflow-approval/package/report/ports"
+	"workflow-approval/utils"
+	"workflow-approval/utils/money"
+)
+
+// ReportServiceImpl implements ReportService interface
+type ReportServiceImpl struct {
+	reportRepo ports.ReportRepository
+	now        func() time.Time
+}
+
+// NewReportService creates a new ReportServiceImpl instance
+func NewReportService(reportRepo ports.ReportRepository) ports.ReportService {
+	return &ReportServiceImpl{
+		reportRepo: reportRepo,
+		now:        utils.TimeNowUTC,
+	}
+}
+
+// Throughput reports the requests submitted per workflow
+func (s *ReportServiceImpl) Throughput(ctx context.Context, filter domain.Filter) ([]domain.WorkflowThroughput, error) {
+	rows, err := s.reportRepo.Throughput(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	for i := range rows {
+		rows[i].ApprovalRate = domain.Rate(rows[i].Approved, rows[i].Approved+rows[i].Rejected)
+	}
+	return nonNil(rows), nil
+}
+
+// LevelTimes reports the time taken at each level
+func (s *ReportServiceImpl) LevelTimes(ctx context.Context, filter domain.Filter) ([]domain.LevelTiming, error) {
+	rows, err := s.reportRepo.LevelTimings(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	return nonNil(rows), nil
+}
+
+// Actors reports the approval and rejection rates per actor
+func (s *ReportServiceImpl) Actors(ctx context.Context, filter domain.Filter) ([]domain.ActorDecisions, error) {
+	rows, err := s.reportRepo.ActorDecisions(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	for i := range rows {
+		decided := rows[i].Approved + rows[i].Rejected
+		rows[i].ApprovalRate = domain.Rate(rows[i].Approved, decided)
+		rows[i].RejectionRate = domain.Rate(rows[i].Rejected, decided)
+	}
+	return nonNil(rows), nil
+}
+
+// Aging reports the pending requests by how long they have waited, listing
+// empty buckets too so charts keep their axis
+func (s *ReportServiceImpl) Aging(ctx context.Context, filter domain.Filter) ([]domain.AgingBucket, error) {
+	bounds := make([]time.Duration, len(domain.AgingBoundsDays))
+	for i, days := range domain.AgingBoundsDays {
+		bounds[i] = time.Duration(days) * 24 * time.Hour
+	}
+	totals, err := s.reportRepo.PendingAging(ctx, filter, s.now(), bounds)
+	if err != nil {
+		return nil, err
+	}
+
+	buckets := domain.AgingBuckets()
+	for _, t := range totals {
+		if t.Bucket >= 0 && t.Bucket < len(buckets) {
+			buckets[t.Bucket].Count = t.Count
+			buckets[t.Bucket].BaseAmount = t.BaseAmount
+		}
+	}
+	return buckets, nil
+}
+
+// AmountBands reports the requests by base amount, listing empty bands too
+func (s *ReportServiceImpl) AmountBands(ctx context.Context, filter domain.Filter, bounds []money.Decimal) ([]domain.AmountBand, error) {
+	if len(bounds) == 0 {
+		bounds = domain.DefaultAmountBounds
+	}
+	totals, err := s.reportRepo.AmountBands(ctx, filter, bounds)
+	if err != nil {
+		return nil, err
+	}
+
+	bands := domain.AmountBands(bounds)
+	for _, t := range totals {
+		if t.Bucket >= 0 && t.Bucket < len(bands) {
+			band := &bands[t.Bucket]
+			band.Submitted, band.Approved, band.Rejected, band.Pending = t.Count, t.Approved, t.Rejected, t.Pending
+			band.BaseAmount = t.BaseAmount
+		}
+	}
+	return bands, nil
+}
+
+// nonNil keeps empty reports encoding as [] rather than null
+func nonNil[T any](rows []T) []T {
+	if rows == nil {
+		return []T{}
+	}
+	return rows
+}
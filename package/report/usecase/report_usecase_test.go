@@ -0,0 +1,110 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"workflow-approval/package/report/domain"
+	"workflow-approval/utils/money"
+)
+
+// fakeReportRepo returns canned aggregates and records what it was asked for
+type fakeReportRepo struct {
+	throughput []domain.WorkflowThroughput
+	actors     []domain.ActorDecisions
+	totals     []domain.BucketTotals
+	agingNow   time.Time
+	bounds     []time.Duration
+	amounts    []money.Decimal
+}
+
+func (f *fakeReportRepo) Throughput(ctx context.Context, filter domain.Filter) ([]domain.WorkflowThroughput, error) {
+	return f.throughput, nil
+}
+
+func (f *fakeReportRepo) LevelTimings(ctx context.Context, filter domain.Filter) ([]domain.LevelTiming, error) {
+	return nil, nil
+}
+
+func (f *fakeReportRepo) ActorDecisions(ctx context.Context, filter domain.Filter) ([]domain.ActorDecisions, error) {
+	return f.actors, nil
+}
+
+func (f *fakeReportRepo) PendingAging(ctx context.Context, filter domain.Filter, now time.Time, bounds []time.Duration) ([]domain.BucketTotals, error) {
+	f.agingNow, f.bounds = now, bounds
+	return f.totals, nil
+}
+
+func (f *fakeReportRepo) AmountBands(ctx context.Context, filter domain.Filter, bounds []money.Decimal) ([]domain.BucketTotals, error) {
+	f.amounts = bounds
+	return f.totals, nil
+}
+
+func TestReportRates(t *testing.T) {
+	repo := &fakeReportRepo{
+		throughput: []domain.WorkflowThroughput{{WorkflowID: "wf-1", Submitted: 10, Approved: 6, Rejected: 2, Pending: 2}},
+		actors:     []domain.ActorDecisions{{ActorID: "mgr", Approved: 3, Rejected: 1}, {ActorID: "fin"}},
+	}
+	service := NewReportService(repo)
+	ctx := context.Background()
+
+	throughput, err := service.Throughput(ctx, domain.Filter{})
+	if err != nil {
+		t.Fatalf("Throughput failed: %v", err)
+	}
+	if throughput[0].ApprovalRate != 0.75 {
+		t.Errorf("Expected the approval rate of decided requests, got %v", throughput[0].ApprovalRate)
+	}
+
+	actors, err := service.Actors(ctx, domain.Filter{})
+	if err != nil {
+		t.Fatalf("Actors failed: %v", err)
+	}
+	if actors[0].ApprovalRate != 0.75 || actors[0].RejectionRate != 0.25 || actors[1].ApprovalRate != 0 {
+		t.Errorf("Unexpected actor rates: %+v", actors)
+	}
+
+	// Empty reports are lists, not null
+	if timings, _ := service.LevelTimes(ctx, domain.Filter{}); timings == nil {
+		t.Errorf("Expected an empty list of level times")
+	}
+}
+
+func TestReportBuckets(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	repo := &fakeReportRepo{totals: []domain.BucketTotals{
+		{Bucket: 1, Count: 4, Approved: 1, Pending: 3, BaseAmount: money.NewDecimal(400)},
+		{Bucket: 3, Count: 2, Pending: 2, BaseAmount: money.NewDecimal(9000)},
+	}}
+	service := &ReportServiceImpl{reportRepo: repo, now: func() time.Time { return now }}
+	ctx := context.Background()
+
+	aging, err := service.Aging(ctx, domain.Filter{})
+	if err != nil {
+		t.Fatalf("Aging failed: %v", err)
+	}
+	if len(aging) != len(domain.AgingBoundsDays)+1 || !repo.agingNow.Equal(now) || repo.bounds[0] != 24*time.Hour {
+		t.Fatalf("Expected every aging bucket as of now, got %+v", aging)
+	}
+	if aging[0].Count != 0 || aging[1].Label != "1-3d" || aging[1].Count != 4 || aging[3].BaseAmount.String() != "9000.00" {
+		t.Errorf("Unexpected aging buckets: %+v", aging)
+	}
+	if last := aging[len(aging)-1]; last.Label != "30d+" || last.MaxDays != nil {
+		t.Errorf("Expected an open last bucket, got %+v", last)
+	}
+
+	bands, err := service.AmountBands(ctx, domain.Filter{}, nil)
+	if err != nil {
+		t.Fatalf("AmountBands failed: %v", err)
+	}
+	if len(repo.amounts) != len(domain.DefaultAmountBounds) || len(bands) != len(domain.DefaultAmountBounds)+1 {
+		t.Fatalf("Expected the default bands, got %+v", bands)
+	}
+	if b := bands[1]; b.Min.Cmp(money.NewDecimal(1000000)) != 0 || b.Submitted != 4 || b.Approved != 1 || b.Pending != 3 {
+		t.Errorf("Unexpected band: %+v", b)
+	}
+	if bands[3].Max != nil || bands[3].Submitted != 2 {
+		t.Errorf("Expected the open last band to hold bucket 3, got %+v", bands[3])
+	}
+}
@@ -0,0 +1,90 @@
+package domain
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"time"
+)
+
+// ErrApproverUnresolved is returned when nobody can be found to approve a step,
+// e.g. the requester has no manager; wrapped errors carry the reason
+var ErrApproverUnresolved = errors.New("no approver could be resolved for this step")
+
+// StepApprover records who was assigned to approve a level of a request. It is
+// resolved when the request reaches the level, so later changes to the org
+// chart or the workflow do not rewrite who was responsible.
+type StepApprover struct {
+	Level        int       `json:"level"`
+	AssigneeType string    `json:"assignee_type"`
+	ActorID      string    `json:"actor_id,omitempty"` // Any member of the actor may act
+	UserID       string    `json:"user_id,omitempty"`  // Only this user may act
+	Fallback     bool      `json:"fallback,omitempty"` // Resolution failed and the step's actor was used
+	ResolvedAt   time.Time `json:"resolved_at"`
+}
+
+// StepApprovers is the list of resolved approvers stored on a request
+type StepApprovers []StepApprover
+
+// ForLevel returns the approver resolved for a level, nil if the request never reached it
+func (a StepApprovers) ForLevel(level int) *StepApprover {
+	for i := range a {
+		if a[i].Level == level {
+			return &a[i]
+		}
+	}
+	return nil
+}
+
+// Value implements driver.Valuer interface for GORM
+func (a StepApprovers) Value() (driver.Value, error) {
+	if len(a) == 0 {
+		return []byte(`[]`), nil
+	}
+	return json.Marshal(a)
+}
+
+// Scan implements sql.Scanner interface for GORM
+func (a *StepApprovers) Scan(value interface{}) error {
+	bytes, err := scanBytes(value)
+	if err != nil || bytes == nil {
+		*a = nil
+		return err
+	}
+	return json.Unmarshal(bytes, a)
+}
+
+// RequestFields holds custom values submitted with a request, e.g. a cost center
+type RequestFields map[string]interface{}
+
+// Value implements driver.Valuer interface for GORM
+func (f RequestFields) Value() (driver.Value, error) {
+	if len(f) == 0 {
+		return []byte(`{}`), nil
+	}
+	return json.Marshal(f)
+}
+
+// Scan implements sql.Scanner interface for GORM
+func (f *RequestFields) Scan(value interface{}) error {
+	bytes, err := scanBytes(value)
+	if err != nil || bytes == nil {
+		*f = nil
+		return err
+	}
+	return json.Unmarshal(bytes, f)
+}
+
+// scanBytes converts a TEXT column value to bytes; nil for NULL
+func scanBytes(value interface{}) ([]byte, error) {
+	switch v := value.(type) {
+	case nil:
+		return nil, nil
+	case []byte:
+		return v, nil
+	case string:
+		return []byte(v), nil
+	default:
+		return nil, errors.New("type assertion to []byte or string failed")
+	}
+}
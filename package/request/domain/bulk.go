@@ -0,0 +1,23 @@
+package domain
+
+// BulkStatus is the result of one request in a bulk approval or rejection
+type BulkStatus string
+
+const (
+	BulkOK             BulkStatus = "ok"
+	BulkNotFound       BulkStatus = "not_found"
+	BulkNotPending     BulkStatus = "not_pending"
+	BulkUnauthorized   BulkStatus = "unauthorized"     // The caller is not the approver of the request's current step
+	BulkStepUpRequired BulkStatus = "step_up_required" // High-value approvals need a recent second factor
+	BulkConflict       BulkStatus = "conflict"         // The request was changed concurrently
+	BulkFailed         BulkStatus = "failed"           // Any other refusal, e.g. unmet step conditions; see Err
+)
+
+// BulkResult is the outcome of one request of a bulk action; Request is the
+// updated request when Status is BulkOK
+type BulkResult struct {
+	RequestID string
+	Status    BulkStatus
+	Err       error
+	Request   *Request
+}
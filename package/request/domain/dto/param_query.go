@@ -0,0 +1,150 @@
+package dto
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"workflow-approval/package/request/domain"
+	wfDomain "workflow-approval/package/workflow/domain"
+	"workflow-approval/utils/money"
+)
+
+// maxQueryLength bounds the full-text search term
+const maxQueryLength = 200
+
+// listParams are the query parameters of request listings besides custom
+// field filters, which are given as fields.<name>=value
+var listParams = map[string]bool{
+	"page": true, "limit": true, "status": true, "workflow_id": true, "requester_id": true,
+	"actor_id": true, "current_step": true, "amount_min": true, "amount_max": true,
+	"created_from": true, "created_to": true, "updated_from": true, "updated_to": true,
+	"q": true, "sort": true, "cursor": true, "total": true,
+}
+
+// ParseRequestFilter reads the filters of GET /api/requests from its query
+// parameters. Unknown parameters are refused so a misspelt filter doesn't
+// silently list everything; extra names parameters the caller handles itself.
+func ParseRequestFilter(query map[string]string, extra ...string) (domain.RequestFilter, error) {
+	var filter domain.RequestFilter
+	for key, value := range query {
+		if name, ok := strings.CutPrefix(key, "fields."); ok {
+			if !wfDomain.ValidFieldName(name) {
+				return filter, fmt.Errorf("invalid field filter %q", name)
+			}
+			if filter.Fields == nil {
+				filter.Fields = make(map[string]string)
+			}
+			filter.Fields[name] = value
+			continue
+		}
+		if !listParams[key] && !contains(extra, key) {
+			return filter, fmt.Errorf("unknown query parameter %q", key)
+		}
+	}
+
+	if s := query["status"]; s != "" {
+		status := domain.RequestStatus(strings.ToUpper(s))
+		switch status {
+		case domain.StatusDraft, domain.StatusPending, domain.StatusApproved, domain.StatusRejected:
+		default:
+			return filter, fmt.Errorf("status must be one of DRAFT, PENDING, APPROVED, REJECTED")
+		}
+		filter.Status = &status
+	}
+	filter.WorkflowID = query["workflow_id"]
+	filter.RequesterID = query["requester_id"]
+	filter.ActorID = query["actor_id"]
+
+	if s := query["current_step"]; s != "" {
+		step, err := strconv.Atoi(s)
+		if err != nil || step < 1 {
+			return filter, fmt.Errorf("current_step must be a positive integer")
+		}
+		filter.CurrentStep = step
+	}
+
+	var err error
+	if filter.MinAmount, err = amountParam(query, "amount_min"); err != nil {
+		return filter, err
+	}
+	if filter.MaxAmount, err = amountParam(query, "amount_max"); err != nil {
+		return filter, err
+	}
+	if filter.MinAmount != nil && filter.MaxAmount != nil && filter.MinAmount.Cmp(*filter.MaxAmount) > 0 {
+		return filter, fmt.Errorf("amount_min must not be greater than amount_max")
+	}
+
+	if filter.CreatedFrom, filter.CreatedTo, err = dateRange(query, "created"); err != nil {
+		return filter, err
+	}
+	if filter.UpdatedFrom, filter.UpdatedTo, err = dateRange(query, "updated"); err != nil {
+		return filter, err
+	}
+
+	filter.Query = strings.TrimSpace(query["q"])
+	if len([]rune(filter.Query)) > maxQueryLength {
+		return filter, fmt.Errorf("q must be at most %d characters", maxQueryLength)
+	}
+
+	if s := query["sort"]; s != "" {
+		if filter.Sort, err = domain.ParseSort(s); err != nil {
+			return filter, err
+		}
+	}
+	return filter, nil
+}
+
+// amountParam parses an amount in the base currency
+func amountParam(query map[string]string, key string) (*money.Decimal, error) {
+	s := query[key]
+	if s == "" {
+		return nil, nil
+	}
+	amount, err := money.ParseDecimal(s)
+	if err != nil {
+		return nil, fmt.Errorf("%s must be a decimal amount", key)
+	}
+	return &amount, nil
+}
+
+// dateRange parses <prefix>_from and <prefix>_to, each an RFC 3339 timestamp
+// or a date (midnight UTC)
+func dateRange(query map[string]string, prefix string) (*time.Time, *time.Time, error) {
+	from, err := timeParam(query, prefix+"_from")
+	if err != nil {
+		return nil, nil, err
+	}
+	to, err := timeParam(query, prefix+"_to")
+	if err != nil {
+		return nil, nil, err
+	}
+	if from != nil && to != nil && !from.Before(*to) {
+		return nil, nil, fmt.Errorf("%s_from must be before %s_to", prefix, prefix)
+	}
+	return from, to, nil
+}
+
+func timeParam(query map[string]string, key string) (*time.Time, error) {
+	s := query[key]
+	if s == "" {
+		return nil, nil
+	}
+	for _, layout := range []string{time.RFC3339, "2006-01-02"} {
+		if t, err := time.Parse(layout, s); err == nil {
+			t = t.UTC()
+			return &t, nil
+		}
+	}
+	return nil, fmt.Errorf("%s must be a date (2006-01-02) or an RFC 3339 timestamp", key)
+}
+
+func contains(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
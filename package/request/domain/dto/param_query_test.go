@@ -0,0 +1,79 @@
+package dto_test
+
+import (
+	"strings"
+	"testing"
+
+	"workflow-approval/package/request/domain"
+	"workflow-approval/package/request/domain/dto"
+)
+
+func TestParseRequestFilter(t *testing.T) {
+	filter, err := dto.ParseRequestFilter(map[string]string{
+		"page":               "2",
+		"status":             "pending",
+		"actor_id":           "actor-1",
+		"current_step":       "2",
+		"amount_min":         "1000000",
+		"amount_max":         "5000000.50",
+		"created_from":       "2026-01-01",
+		"created_to":         "2026-02-01T00:00:00+07:00",
+		"q":                  "  laptop  ",
+		"sort":               "-amount,created_at",
+		"fields.cost_center": "CC-100",
+	})
+	if err != nil {
+		t.Fatalf("ParseRequestFilter: %v", err)
+	}
+	if filter.Status == nil || *filter.Status != domain.StatusPending {
+		t.Errorf("Expected status PENDING, got %v", filter.Status)
+	}
+	if filter.ActorID != "actor-1" || filter.CurrentStep != 2 || filter.Query != "laptop" {
+		t.Errorf("Unexpected filter %+v", filter)
+	}
+	if filter.MinAmount.String() != "1000000.00" || filter.MaxAmount.String() != "5000000.50" {
+		t.Errorf("Unexpected amount range %s - %s", filter.MinAmount, filter.MaxAmount)
+	}
+	if got := filter.CreatedTo.Format("2006-01-02T15:04:05Z"); got != "2026-01-31T17:00:00Z" {
+		t.Errorf("Expected created_to in UTC, got %s", got)
+	}
+	if len(filter.Sort) != 2 || filter.Sort[0] != (domain.SortField{Field: "amount", Desc: true}) || filter.Sort[1].Desc {
+		t.Errorf("Unexpected sort %+v", filter.Sort)
+	}
+	if filter.Fields["cost_center"] != "CC-100" {
+		t.Errorf("Expected the cost_center field filter, got %v", filter.Fields)
+	}
+}
+
+func TestParseRequestFilterErrors(t *testing.T) {
+	tests := []struct {
+		name  string
+		query map[string]string
+		want  string
+	}{
+		{"unknown parameter", map[string]string{"requestor_id": "u1"}, `unknown query parameter "requestor_id"`},
+		{"unknown status", map[string]string{"status": "DONE"}, "status must be one of"},
+		{"bad step", map[string]string{"current_step": "0"}, "current_step must be a positive integer"},
+		{"bad amount", map[string]string{"amount_min": "ten"}, "amount_min must be a decimal amount"},
+		{"inverted amounts", map[string]string{"amount_min": "10", "amount_max": "5"}, "amount_min must not be greater than amount_max"},
+		{"bad date", map[string]string{"updated_from": "01/02/2026"}, "updated_from must be a date"},
+		{"inverted dates", map[string]string{"created_from": "2026-02-01", "created_to": "2026-01-01"}, "created_from must be before created_to"},
+		{"unknown sort field", map[string]string{"sort": "-requester_id"}, `cannot sort by "requester_id"`},
+		{"repeated sort field", map[string]string{"sort": "title,-title"}, `sort field "title" is given more than once`},
+		{"invalid field filter", map[string]string{"fields.a-b": "x"}, `invalid field filter "a-b"`},
+		{"long query", map[string]string{"q": strings.Repeat("x", 201)}, "q must be at most 200 characters"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := dto.ParseRequestFilter(tt.query)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("Expected error containing %q, got %v", tt.want, err)
+			}
+		})
+	}
+
+	if _, err := dto.ParseRequestFilter(map[string]string{"tenant_id": "t1"}, "tenant_id"); err != nil {
+		t.Errorf("Expected the extra parameter to be allowed, got %v", err)
+	}
+}
@@ -1,21 +1,49 @@
 package dto
 
+import (
+	"workflow-approval/package/request/domain"
+	"workflow-approval/utils/money"
+)
+
 // CreateRequestRequest represents the create request request body
 type CreateRequestRequest struct {
-	WorkflowID  string  `json:"workflow_id"`
-	Amount      float64 `json:"amount"`
-	Title       string  `json:"title"`
-	Description string  `json:"description"`
+	WorkflowID  string        `json:"workflow_id"`
+	Amount      money.Decimal `json:"amount"`   // JSON number or numeric string
+	Currency    string        `json:"currency"` // ISO-4217 code; omitted: the base currency
+	Title       string        `json:"title"`
+	Description string        `json:"description"`
+
+	// Fields holds custom values, e.g. the cost center used by field_lookup steps
+	Fields domain.RequestFields `json:"fields"`
+
+	// Draft saves the request without submitting it (POST /api/requests/:id/submit later)
+	Draft bool `json:"draft"`
 }
 
 // UpdateRequestRequest represents the update request request body
 type UpdateRequestRequest struct {
-	Amount      float64 `json:"amount"`
-	Title       string  `json:"title"`
-	Description string  `json:"description"`
+	Amount      money.Decimal        `json:"amount"`
+	Currency    string               `json:"currency"` // Omitted: keep the current currency
+	Title       string               `json:"title"`
+	Description string               `json:"description"`
+	Fields      domain.RequestFields `json:"fields"` // Omitted: keep the current fields
+}
+
+// SimulateRequestRequest represents the hypothetical request of a workflow simulation
+type SimulateRequestRequest struct {
+	RequesterID string               `json:"requester_id"` // Omitted: the caller; dynamic approvers are resolved from the requester
+	Amount      money.Decimal        `json:"amount"`
+	Currency    string               `json:"currency"` // ISO-4217 code; omitted: the base currency
+	Fields      domain.RequestFields `json:"fields"`
 }
 
 // RejectRequest represents the reject request body
 type RejectRequest struct {
 	Reason string `json:"reason"`
 }
+
+// BulkActionRequest represents the body of a bulk approval or rejection
+type BulkActionRequest struct {
+	IDs     []string `json:"ids"`     // At most 100; repeated IDs are acted on once
+	Comment string   `json:"comment"` // Recorded on every request; the rejection reason for bulk-reject
+}
@@ -2,20 +2,29 @@ package dto
 
 import (
 	"workflow-approval/package/request/domain"
+	"workflow-approval/utils/money"
 )
 
 // RequestResponse represents the request response
 type RequestResponse struct {
 	ID          string               `json:"id"`
+	TenantID    string               `json:"tenant_id"`
 	WorkflowID  string               `json:"workflow_id"`
 	CurrentStep int                  `json:"current_step"`
 	Status      domain.RequestStatus `json:"status"`
-	Amount      float64              `json:"amount"`
-	Title       string               `json:"title"`
-	Description string               `json:"description"`
-	RequesterID string               `json:"requester_id"`
-	CreatedAt   string               `json:"created_at"`
-	UpdatedAt   string               `json:"updated_at"`
+	Amount      money.Decimal        `json:"amount"`
+	Currency    money.Currency       `json:"currency"`
+	// ExchangeRate and BaseAmount are fixed at submission; null while the request is a draft
+	ExchangeRate *money.Rate          `json:"exchange_rate"`
+	BaseAmount   *money.Decimal       `json:"base_amount"`
+	Title        string               `json:"title"`
+	Description  string               `json:"description"`
+	RequesterID  string               `json:"requester_id"`
+	Fields       domain.RequestFields `json:"fields"`
+	Approvers    domain.StepApprovers `json:"approvers"`
+	SubmittedAt  *string              `json:"submitted_at"` // Null while the request is a draft
+	CreatedAt    string               `json:"created_at"`
+	UpdatedAt    string               `json:"updated_at"`
 }
 
 // ToRequestResponse converts a Request to RequestResponse
@@ -23,18 +32,32 @@ func ToRequestResponse(r *domain.Request) *RequestResponse {
 	if r == nil {
 		return nil
 	}
-	return &RequestResponse{
+	response := &RequestResponse{
 		ID:          r.ID,
+		TenantID:    r.TenantID,
 		WorkflowID:  r.WorkflowID,
 		CurrentStep: r.CurrentStep,
 		Status:      r.Status,
 		Amount:      r.Amount,
+		Currency:    r.Currency,
 		Title:       r.Title,
 		Description: r.Description,
 		RequesterID: r.RequesterID,
+		Fields:      r.Fields,
+		Approvers:   r.Approvers,
 		CreatedAt:   r.CreatedAt.Format("2006-01-02T15:04:05Z"),
 		UpdatedAt:   r.UpdatedAt.Format("2006-01-02T15:04:05Z"),
 	}
+	if !r.ExchangeRate.IsZero() {
+		rate, baseAmount := r.ExchangeRate, r.BaseAmount
+		response.ExchangeRate = &rate
+		response.BaseAmount = &baseAmount
+	}
+	if r.SubmittedAt != nil {
+		submittedAt := r.SubmittedAt.Format("2006-01-02T15:04:05Z")
+		response.SubmittedAt = &submittedAt
+	}
+	return response
 }
 
 // ToRequestResponseList converts a list of Request to RequestResponse
@@ -45,3 +68,35 @@ func ToRequestResponseList(requests []*domain.Request) []*RequestResponse {
 	}
 	return responses
 }
+
+// BulkResultResponse represents the outcome of one request of a bulk action
+type BulkResultResponse struct {
+	ID      string            `json:"id"`
+	Result  domain.BulkStatus `json:"result"`
+	Error   *string           `json:"error"`   // Why the request was refused; null when the result is ok
+	Request *RequestResponse  `json:"request"` // The updated request; null unless the result is ok
+}
+
+// BulkResponse represents the results of a bulk action, in the order the IDs were given
+type BulkResponse struct {
+	Results []*BulkResultResponse     `json:"results"`
+	Summary map[domain.BulkStatus]int `json:"summary"` // Number of requests per result
+}
+
+// ToBulkResponse converts the results of a bulk action to BulkResponse
+func ToBulkResponse(results []domain.BulkResult) *BulkResponse {
+	response := &BulkResponse{
+		Results: make([]*BulkResultResponse, len(results)),
+		Summary: make(map[domain.BulkStatus]int),
+	}
+	for i, r := range results {
+		item := &BulkResultResponse{ID: r.RequestID, Result: r.Status, Request: ToRequestResponse(r.Request)}
+		if r.Err != nil {
+			msg := r.Err.Error()
+			item.Error = &msg
+		}
+		response.Results[i] = item
+		response.Summary[r.Status]++
+	}
+	return response
+}
@@ -0,0 +1,84 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"workflow-approval/utils/money"
+)
+
+// RequestFilter narrows down request listings; zero fields don't filter
+type RequestFilter struct {
+	Status      *RequestStatus
+	WorkflowIDs []string // Scope of an API key: only these workflows when non-empty
+	WorkflowID  string
+	RequesterID string
+	ActorID     string // Pending requests whose current step awaits this actor
+	CurrentStep int
+	MinAmount   *money.Decimal // Amounts are compared in the base currency
+	MaxAmount   *money.Decimal
+
+	// Date ranges include From and exclude To
+	CreatedFrom *time.Time
+	CreatedTo   *time.Time
+	UpdatedFrom *time.Time
+	UpdatedTo   *time.Time
+
+	Fields map[string]string // Exact custom field values, field name to value
+	Query  string            // Full-text search over title and description
+	Sort   []SortField       // Newest first when empty
+
+	ViewerID string // Drafts are only listed for their requester
+}
+
+// SortField orders request listings by one field
+type SortField struct {
+	Field string
+	Desc  bool
+}
+
+// SortableFields lists the fields request listings can be sorted by
+var SortableFields = []string{"created_at", "updated_at", "submitted_at", "amount", "title", "status", "current_step"}
+
+// ParseSort parses a comma-separated sort such as "-amount,created_at", where
+// a leading "-" sorts the field in descending order
+func ParseSort(s string) ([]SortField, error) {
+	var sort []SortField
+	seen := make(map[string]bool)
+	for _, part := range strings.Split(s, ",") {
+		part = strings.TrimSpace(part)
+		desc := strings.HasPrefix(part, "-")
+		field := strings.TrimPrefix(part, "-")
+		if !isSortable(field) {
+			return nil, fmt.Errorf("cannot sort by %q, use one of %s", field, strings.Join(SortableFields, ", "))
+		}
+		if seen[field] {
+			return nil, fmt.Errorf("sort field %q is given more than once", field)
+		}
+		seen[field] = true
+		sort = append(sort, SortField{Field: field, Desc: desc})
+	}
+	return sort, nil
+}
+
+// KeysetOrder reports whether the listing is sorted by creation time only, so
+// it can be paged by cursor, and whether newest first (the default)
+func (f RequestFilter) KeysetOrder() (desc, ok bool) {
+	switch {
+	case len(f.Sort) == 0:
+		return true, true
+	case len(f.Sort) == 1 && f.Sort[0].Field == "created_at":
+		return f.Sort[0].Desc, true
+	}
+	return false, false
+}
+
+func isSortable(field string) bool {
+	for _, f := range SortableFields {
+		if f == field {
+			return true
+		}
+	}
+	return false
+}
@@ -1,15 +1,22 @@
 package domain
 
 import (
+	"errors"
 	"time"
 
 	"workflow-approval/utils"
+	"workflow-approval/utils/money"
 )
 
+// ErrExchangeRateMissing is returned when a request's currency has no rate
+// into the base currency that step thresholds are expressed in
+var ErrExchangeRateMissing = errors.New("no exchange rate is set for the request currency")
+
 // RequestStatus represents the status of a workflow request
 type RequestStatus string
 
 const (
+	StatusDraft    RequestStatus = "DRAFT" // Saved by the requester, not yet routed to approvers
 	StatusPending  RequestStatus = "PENDING"
 	StatusApproved RequestStatus = "APPROVED"
 	StatusRejected RequestStatus = "REJECTED"
@@ -17,21 +24,30 @@ const (
 
 // Request represents a workflow approval request
 type Request struct {
-	ID          string        `json:"id" gorm:"primaryKey;size:36"`
-	WorkflowID  string        `json:"workflow_id" gorm:"size:36;not null;index"`
-	CurrentStep int           `json:"current_step" gorm:"not null;default:1"`
-	Status      RequestStatus `json:"status" gorm:"size:20;not null;default:'PENDING'"`
-	Amount      float64       `json:"amount" gorm:"type:decimal(15,2);not null"`
-	Title       string        `json:"title" gorm:"size:255"`
-	Description string        `json:"description" gorm:"type:text"`
-	RequesterID string        `json:"requester_id" gorm:"size:36;not null;index"`
-	Version     int           `json:"version" gorm:"not null;default:1"` // For optimistic locking
-	CreatedAt   time.Time     `json:"created_at"`
-	UpdatedAt   time.Time     `json:"updated_at"`
+	ID          string         `json:"id" gorm:"primaryKey;size:36"`
+	TenantID    string         `json:"tenant_id" gorm:"size:36;not null;index"`
+	WorkflowID  string         `json:"workflow_id" gorm:"size:36;not null;index"`
+	CurrentStep int            `json:"current_step" gorm:"not null;default:1"`
+	Status      RequestStatus  `json:"status" gorm:"size:20;not null;default:'PENDING'"`
+	Amount      money.Decimal  `json:"amount" gorm:"type:decimal(18,4);not null"`
+	Currency    money.Currency `json:"currency" gorm:"size:3;not null"`
+	// ExchangeRate converts Amount into the base currency; it is fixed when the
+	// request is submitted and zero while it is a draft
+	ExchangeRate money.Rate    `json:"exchange_rate" gorm:"type:decimal(18,8);not null;default:0"`
+	BaseAmount   money.Decimal `json:"base_amount" gorm:"type:decimal(18,4);not null;default:0"` // Compared against step thresholds
+	Title        string        `json:"title" gorm:"size:255"`
+	Description  string        `json:"description" gorm:"type:text"`
+	RequesterID  string        `json:"requester_id" gorm:"size:36;not null;index"`
+	Fields       RequestFields `json:"fields" gorm:"type:text"`
+	Approvers    StepApprovers `json:"approvers" gorm:"type:text"`        // Resolved approver of each level reached
+	Version      int           `json:"version" gorm:"not null;default:1"` // For optimistic locking
+	SubmittedAt  *time.Time    `json:"submitted_at"`                      // When the request entered PENDING
+	CreatedAt    time.Time     `json:"created_at"`
+	UpdatedAt    time.Time     `json:"updated_at"`
 }
 
 // NewRequest creates a new Request instance
-func NewRequest(workflowID, requesterID string, amount float64, title, description string) *Request {
+func NewRequest(workflowID, requesterID string, amount money.Decimal, currency money.Currency, title, description string, fields RequestFields) *Request {
 	now := utils.TimeNowUTC()
 	return &Request{
 		ID:          utils.GenerateUUID(),
@@ -39,20 +55,54 @@ func NewRequest(workflowID, requesterID string, amount float64, title, descripti
 		CurrentStep: 1,
 		Status:      StatusPending,
 		Amount:      amount,
+		Currency:    currency,
 		Title:       title,
 		Description: description,
 		RequesterID: requesterID,
+		Fields:      fields,
 		Version:     1,
+		SubmittedAt: &now,
 		CreatedAt:   now,
 		UpdatedAt:   now,
 	}
 }
 
+// NewDraftRequest creates a new Request instance saved as a draft; approvers
+// are only resolved once it is submitted
+func NewDraftRequest(workflowID, requesterID string, amount money.Decimal, currency money.Currency, title, description string, fields RequestFields) *Request {
+	request := NewRequest(workflowID, requesterID, amount, currency, title, description, fields)
+	request.Status = StatusDraft
+	request.SubmittedAt = nil
+	return request
+}
+
 // TableName returns the table name for GORM
 func (Request) TableName() string {
 	return "requests"
 }
 
+// IsDraft checks if the request is a draft that has not been submitted
+func (r *Request) IsDraft() bool {
+	return r.Status == StatusDraft
+}
+
+// ApplyRate records the exchange rate of the request's currency and the
+// amount converted with it
+func (r *Request) ApplyRate(rate money.Rate) error {
+	baseAmount, err := rate.Convert(r.Amount)
+	if err != nil {
+		return err
+	}
+	r.ExchangeRate = rate
+	r.BaseAmount = baseAmount
+	return nil
+}
+
+// IsEditable checks if the requester may still change the request and its attachments
+func (r *Request) IsEditable() bool {
+	return r.IsDraft() || r.IsPending()
+}
+
 // IsPending checks if the request is still pending
 func (r *Request) IsPending() bool {
 	return r.Status == StatusPending
@@ -62,3 +112,19 @@ func (r *Request) IsPending() bool {
 func (r *Request) IsTerminal() bool {
 	return r.Status == StatusApproved || r.Status == StatusRejected
 }
+
+// AssignApprover records the approver of a level, replacing an earlier resolution of the same level
+func (r *Request) AssignApprover(approver StepApprover) {
+	for i := range r.Approvers {
+		if r.Approvers[i].Level == approver.Level {
+			r.Approvers[i] = approver
+			return
+		}
+	}
+	r.Approvers = append(r.Approvers, approver)
+}
+
+// CurrentApprover returns the approver resolved for the current step, nil if none was recorded
+func (r *Request) CurrentApprover() *StepApprover {
+	return r.Approvers.ForLevel(r.CurrentStep)
+}
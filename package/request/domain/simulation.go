@@ -0,0 +1,70 @@
+package domain
+
+import (
+	valDomain "workflow-approval/package/workflow_validation/domain"
+	"workflow-approval/utils/money"
+)
+
+// SimulationOutcome is how a simulated request would end
+type SimulationOutcome string
+
+const (
+	OutcomeApproved   SimulationOutcome = "approved"   // Every level can be approved
+	OutcomeBlocked    SimulationOutcome = "blocked"    // A level's conditions fail, so its approval would be refused
+	OutcomeUnroutable SimulationOutcome = "unroutable" // Nobody could be resolved to approve a level
+	OutcomeNoSteps    SimulationOutcome = "no_steps"   // The workflow has no level 1, so nobody can approve the request
+	OutcomeInvalid    SimulationOutcome = "invalid"    // The workflow fails validation, so the request could not be created
+)
+
+// LevelStatus is what happens to a request at a level of its workflow. There
+// is no skipped status: requests move from each level to the next and can't
+// pass a level whose conditions fail, which is reported as blocked instead.
+type LevelStatus string
+
+const (
+	LevelHit        LevelStatus = "hit"         // Reached; its approver may approve
+	LevelBlocked    LevelStatus = "blocked"     // Reached, but its conditions fail
+	LevelUnresolved LevelStatus = "unresolved"  // Reached, but no approver could be resolved
+	LevelNotReached LevelStatus = "not_reached" // An earlier level stops the request, levels before it are missing or the workflow is invalid
+)
+
+// ConditionStatus is the result of one step condition for a request
+type ConditionStatus string
+
+const (
+	ConditionMatched     ConditionStatus = "matched"
+	ConditionNotMatched  ConditionStatus = "not_matched"
+	ConditionNotEnforced ConditionStatus = "not_enforced" // Stored on the step but not checked when approving
+)
+
+// ConditionResult explains one condition of a step, e.g. "min_amount" or "fields.nights gt 3"
+type ConditionResult struct {
+	Condition string          `json:"condition"`
+	Status    ConditionStatus `json:"status"`
+	Reason    string          `json:"reason"`
+}
+
+// SimulatedLevel is the route of a simulated request through one level
+type SimulatedLevel struct {
+	Level        int               `json:"level"`
+	Status       LevelStatus       `json:"status"`
+	AssigneeType string            `json:"assignee_type"`
+	Approver     *StepApprover     `json:"approver,omitempty"` // Who would be asked to approve; nil unless reached
+	Conditions   []ConditionResult `json:"conditions"`
+	Reason       string            `json:"reason,omitempty"` // Why the level is blocked, unresolved or not reached
+}
+
+// Simulation is the route a hypothetical request would take through a
+// workflow, found by the same routing as real requests without saving anything
+type Simulation struct {
+	WorkflowID     string            `json:"workflow_id"`
+	RequesterID    string            `json:"requester_id"`
+	Amount         money.Decimal     `json:"amount"`
+	Currency       money.Currency    `json:"currency"`
+	ExchangeRate   money.Rate        `json:"exchange_rate"`
+	BaseAmount     money.Decimal     `json:"base_amount"`
+	StepUpRequired bool              `json:"step_up_required"` // Approvers need a recent second factor
+	Outcome        SimulationOutcome `json:"outcome"`
+	Validation     *valDomain.Report `json:"validation,omitempty"` // The workflow's validation, as checked when creating requests
+	Levels         []SimulatedLevel  `json:"levels"`
+}
@@ -0,0 +1,24 @@
+package domain
+
+import (
+	"time"
+
+	"workflow-approval/utils/money"
+)
+
+// StepUpPolicy requires approvers of high-value requests to have recently
+// re-verified a second factor (TOTP)
+type StepUpPolicy struct {
+	Amount money.Decimal // Requests worth this much in the base currency or more need step-up; 0 disables
+	MaxAge time.Duration // How long a second-factor verification counts as recent
+}
+
+// Requires reports whether approving a request of the given base amount needs step-up
+func (p StepUpPolicy) Requires(baseAmount money.Decimal) bool {
+	return p.Amount.Sign() > 0 && baseAmount.Cmp(p.Amount) >= 0
+}
+
+// IsSatisfied reports whether a second factor verified at verifiedAt is recent enough
+func (p StepUpPolicy) IsSatisfied(verifiedAt *time.Time, now time.Time) bool {
+	return verifiedAt != nil && !verifiedAt.After(now.Add(time.Minute)) && now.Sub(*verifiedAt) <= p.MaxAge
+}
@@ -1,14 +1,19 @@
 package handler
 
 import (
-	"strconv"
+	"errors"
 
 	"github.com/gofiber/fiber/v2"
 
+	"workflow-approval/framework/middleware"
 	approvalHistoryPorts "workflow-approval/package/approval_history/ports"
 	"workflow-approval/package/request/domain"
 	"workflow-approval/package/request/domain/dto"
 	reqPorts "workflow-approval/package/request/ports"
+	reqUsecase "workflow-approval/package/request/usecase"
+	wfDomain "workflow-approval/package/workflow/domain"
+	valDomain "workflow-approval/package/workflow_validation/domain"
+	"workflow-approval/utils/pagination"
 )
 
 // RequestHandler handles HTTP requests for request operations
@@ -28,18 +33,30 @@ func NewRequestHandler(requestService reqPorts.RequestService, historyService ap
 // Routes defines all routes for request module
 // Mounts routes under /api/requests
 func (h *RequestHandler) Routes(group fiber.Router) {
-	// POST /api/requests - Create a new request
+	// POST /api/requests/bulk-approve - Approve up to 100 requests, with a result per request
+	// Request body: { "ids": ["...", "..."], "comment": "..." }
+	group.Post("/bulk-approve", h.BulkApprove)
+
+	// POST /api/requests/bulk-reject - Reject up to 100 requests with a shared reason, with a result per request
+	// Request body: { "ids": ["...", "..."], "comment": "..." }
+	group.Post("/bulk-reject", h.BulkReject)
+
+	// POST /api/requests - Create a new request ("draft": true saves it without submitting)
 	group.Post("", h.Create)
 
-	// GET /api/requests - List all requests with pagination
+	// GET /api/requests - List requests with filters, sorting, full-text search and pagination (drafts only for their requester)
+	// Query params: page, limit, status, fields.<name>=<value> (custom field equals value)
 	group.Get("", h.List)
 
 	// GET /api/requests/:id - Get a specific request
 	group.Get("/:id", h.Get)
 
-	// PUT /api/requests/:id - Update a request (only if PENDING)
+	// PUT /api/requests/:id - Update a request (only if DRAFT or PENDING)
 	group.Put("/:id", h.Update)
 
+	// POST /api/requests/:id/submit - Submit a draft for approval (requester only)
+	group.Post("/:id/submit", h.Submit)
+
 	// POST /api/requests/:id/approve - Approve a request
 	group.Post("/:id/approve", h.Approve)
 
@@ -53,6 +70,14 @@ func (h *RequestHandler) Routes(group fiber.Router) {
 	group.Delete("/:id", h.Delete)
 }
 
+// SimulationRoutes defines the workflow simulation route
+// Mounts routes under /api/workflows/:id/simulate (admin only)
+func (h *RequestHandler) SimulationRoutes(group fiber.Router) {
+	// POST /api/workflows/:id/simulate - Route a hypothetical request through the workflow without saving it
+	// Request body: { "requester_id": "...", "amount": 1500000, "currency": "IDR", "fields": {...} }
+	group.Post("", h.Simulate)
+}
+
 // Create creates a new request
 // POST /requests
 func (h *RequestHandler) Create(c *fiber.Ctx) error {
@@ -67,8 +92,35 @@ func (h *RequestHandler) Create(c *fiber.Ctx) error {
 		})
 	}
 
-	request, err := h.requestService.CreateRequest(c.Context(), req.WorkflowID, requesterID, req.Amount, req.Title, req.Description)
+	// API keys may be restricted to a set of workflows
+	if key := middleware.GetAPIKeyFromContext(c); key != nil && !key.AllowsWorkflow(req.WorkflowID) {
+		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+			"success": false,
+			"data":    nil,
+			"error":   "API key is not permitted to use this workflow",
+		})
+	}
+
+	create := h.requestService.CreateRequest
+	if req.Draft {
+		create = h.requestService.CreateDraft
+	}
+	request, err := create(c.Context(), req.WorkflowID, requesterID, req.Amount, req.Currency, req.Title, req.Description, req.Fields)
 	if err != nil {
+		if errors.Is(err, domain.ErrApproverUnresolved) {
+			return approverUnresolved(c, err)
+		}
+		if errors.Is(err, domain.ErrExchangeRateMissing) {
+			return exchangeRateMissing(c, err)
+		}
+		var invalid *valDomain.InvalidError
+		if errors.As(err, &invalid) {
+			return workflowInvalid(c, invalid)
+		}
+		var fieldErrs wfDomain.FieldErrors
+		if errors.As(err, &fieldErrs) {
+			return invalidFields(c, fieldErrs)
+		}
 		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
 			"success": false,
 			"data":    nil,
@@ -96,7 +148,7 @@ func (h *RequestHandler) Get(c *fiber.Ctx) error {
 	}
 
 	request, err := h.requestService.GetRequest(c.Context(), id)
-	if err != nil {
+	if err != nil || !middleware.CanSeeRequest(c, request) {
 		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
 			"success": false,
 			"data":    nil,
@@ -111,21 +163,42 @@ func (h *RequestHandler) Get(c *fiber.Ctx) error {
 	})
 }
 
-// List retrieves a list of requests
-// GET /requests
+// List retrieves a list of requests matching the filters
+// GET /requests?page=&limit=&cursor=&total=&status=&workflow_id=&requester_id=&actor_id=&current_step=&amount_min=&amount_max=&created_from=&created_to=&updated_from=&updated_to=&q=&sort=&fields.<name>=
 func (h *RequestHandler) List(c *fiber.Ctx) error {
-	page, _ := strconv.Atoi(c.Query("page", "1"))
-	limit, _ := strconv.Atoi(c.Query("limit", "10"))
+	page, err := pagination.Parse(c.Query("page"), c.Query("limit"), c.Query("cursor"), c.Query("total"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"success": false,
+			"data":    nil,
+			"error":   err.Error(),
+		})
+	}
 
-	statusStr := c.Query("status")
-	var status *domain.RequestStatus
-	if statusStr != "" {
-		s := domain.RequestStatus(statusStr)
-		status = &s
+	filter, err := dto.ParseRequestFilter(c.Queries())
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"success": false,
+			"data":    nil,
+			"error":   err.Error(),
+		})
 	}
 
-	requests, total, err := h.requestService.ListRequests(c.Context(), page, limit, status)
+	// API keys scoped to workflows only see requests of those workflows
+	if key := middleware.GetAPIKeyFromContext(c); key != nil {
+		filter.WorkflowIDs = key.WorkflowIDs
+	}
+
+	filter.ViewerID = c.Locals("user_id").(string)
+	requests, result, err := h.requestService.ListRequests(c.Context(), filter, page)
 	if err != nil {
+		if errors.Is(err, reqUsecase.ErrCursorSort) {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"success": false,
+				"data":    nil,
+				"error":   err.Error(),
+			})
+		}
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
 			"success": false,
 			"data":    nil,
@@ -133,15 +206,12 @@ func (h *RequestHandler) List(c *fiber.Ctx) error {
 		})
 	}
 
+	data := fiber.Map(result.Meta(c.Path(), string(c.Request().URI().QueryString())))
+	data["requests"] = dto.ToRequestResponseList(requests)
 	return c.Status(fiber.StatusOK).JSON(fiber.Map{
 		"success": true,
-		"data": fiber.Map{
-			"requests": dto.ToRequestResponseList(requests),
-			"total":    total,
-			"page":     page,
-			"limit":    limit,
-		},
-		"error": nil,
+		"data":    data,
+		"error":   nil,
 	})
 }
 
@@ -157,6 +227,14 @@ func (h *RequestHandler) Update(c *fiber.Ctx) error {
 		})
 	}
 
+	if !middleware.RequestVisible(c, h.requestService, id) {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"success": false,
+			"data":    nil,
+			"error":   "Request not found",
+		})
+	}
+
 	var req dto.UpdateRequestRequest
 	if err := c.BodyParser(&req); err != nil {
 		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
@@ -166,8 +244,15 @@ func (h *RequestHandler) Update(c *fiber.Ctx) error {
 		})
 	}
 
-	request, err := h.requestService.UpdateRequest(c.Context(), id, req.Amount, req.Title, req.Description)
+	request, err := h.requestService.UpdateRequest(c.Context(), id, req.Amount, req.Currency, req.Title, req.Description, req.Fields)
 	if err != nil {
+		if errors.Is(err, domain.ErrExchangeRateMissing) {
+			return exchangeRateMissing(c, err)
+		}
+		var fieldErrs wfDomain.FieldErrors
+		if errors.As(err, &fieldErrs) {
+			return invalidFields(c, fieldErrs)
+		}
 		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
 			"success": false,
 			"data":    nil,
@@ -182,6 +267,53 @@ func (h *RequestHandler) Update(c *fiber.Ctx) error {
 	})
 }
 
+// Submit submits a draft for approval
+// POST /requests/:id/submit
+func (h *RequestHandler) Submit(c *fiber.Ctx) error {
+	id := c.Params("id")
+	if !h.requestInWorkflowScope(c, id) {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"success": false,
+			"data":    nil,
+			"error":   "Request not found",
+		})
+	}
+
+	userID := c.Locals("user_id").(string)
+	request, err := h.requestService.SubmitRequest(c.Context(), id, userID)
+	if err != nil {
+		var fieldErrs wfDomain.FieldErrors
+		var invalid *valDomain.InvalidError
+		status := fiber.StatusBadRequest
+		switch {
+		case errors.As(err, &fieldErrs):
+			return invalidFields(c, fieldErrs)
+		case errors.Is(err, domain.ErrApproverUnresolved):
+			return approverUnresolved(c, err)
+		case errors.Is(err, domain.ErrExchangeRateMissing):
+			return exchangeRateMissing(c, err)
+		case errors.As(err, &invalid):
+			return workflowInvalid(c, invalid)
+		case errors.Is(err, reqUsecase.ErrRequestNotFound),
+			errors.Is(err, reqUsecase.ErrNotRequester): // Drafts are private to their requester
+			status, err = fiber.StatusNotFound, reqUsecase.ErrRequestNotFound
+		case errors.Is(err, reqUsecase.ErrRequestNotDraft):
+			status = fiber.StatusConflict
+		}
+		return c.Status(status).JSON(fiber.Map{
+			"success": false,
+			"data":    nil,
+			"error":   err.Error(),
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"success": true,
+		"data":    dto.ToRequestResponse(request),
+		"error":   nil,
+	})
+}
+
 // Approve approves a request
 // POST /requests/:id/approve
 func (h *RequestHandler) Approve(c *fiber.Ctx) error {
@@ -194,12 +326,39 @@ func (h *RequestHandler) Approve(c *fiber.Ctx) error {
 		})
 	}
 
+	if !h.requestInWorkflowScope(c, id) {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"success": false,
+			"data":    nil,
+			"error":   "Request not found",
+		})
+	}
+
 	userID := c.Locals("user_id").(string)
-	actorID := c.Locals("actor_id").(string)
+	actorIDs := middleware.GetActorIDsFromContext(c)
 	isAdmin := c.Locals("is_admin").(bool)
 
-	request, err := h.requestService.Approve(c.Context(), id, userID, actorID, isAdmin)
+	request, err := h.requestService.Approve(c.Context(), id, userID, actorIDs, isAdmin, middleware.GetMFAAtFromContext(c))
 	if err != nil {
+		// High-value approvals need a recent TOTP verification (POST /api/profile/2fa/step-up)
+		if errors.Is(err, reqUsecase.ErrStepUpRequired) {
+			return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+				"success": false,
+				"data":    nil,
+				"error":   err.Error(),
+				"code":    "STEP_UP_REQUIRED",
+			})
+		}
+		if errors.Is(err, domain.ErrApproverUnresolved) {
+			return approverUnresolved(c, err)
+		}
+		if errors.Is(err, reqUsecase.ErrRequestConflict) {
+			return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+				"success": false,
+				"data":    nil,
+				"error":   err.Error(),
+			})
+		}
 		// Return 403 for unauthorized actor errors
 		if err.Error() == "unauthorized actor: you are not the assigned approver for this step" {
 			return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
@@ -234,6 +393,14 @@ func (h *RequestHandler) Reject(c *fiber.Ctx) error {
 		})
 	}
 
+	if !h.requestInWorkflowScope(c, id) {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"success": false,
+			"data":    nil,
+			"error":   "Request not found",
+		})
+	}
+
 	var req dto.RejectRequest
 	if err := c.BodyParser(&req); err != nil {
 		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
@@ -244,11 +411,18 @@ func (h *RequestHandler) Reject(c *fiber.Ctx) error {
 	}
 
 	userID := c.Locals("user_id").(string)
-	actorID := c.Locals("actor_id").(string)
+	actorIDs := middleware.GetActorIDsFromContext(c)
 	isAdmin := c.Locals("is_admin").(bool)
 
-	request, err := h.requestService.Reject(c.Context(), id, userID, actorID, isAdmin, req.Reason)
+	request, err := h.requestService.Reject(c.Context(), id, userID, actorIDs, isAdmin, req.Reason)
 	if err != nil {
+		if errors.Is(err, reqUsecase.ErrRequestConflict) {
+			return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+				"success": false,
+				"data":    nil,
+				"error":   err.Error(),
+			})
+		}
 		// Return 403 for unauthorized actor errors
 		if err.Error() == "unauthorized actor: you are not the assigned approver for this step" {
 			return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
@@ -271,6 +445,93 @@ func (h *RequestHandler) Reject(c *fiber.Ctx) error {
 	})
 }
 
+// BulkApprove approves several requests, each as Approve would
+// POST /requests/bulk-approve
+func (h *RequestHandler) BulkApprove(c *fiber.Ctx) error {
+	userID := c.Locals("user_id").(string)
+	actorIDs := middleware.GetActorIDsFromContext(c)
+	isAdmin := c.Locals("is_admin").(bool)
+	mfaAt := middleware.GetMFAAtFromContext(c)
+
+	return h.bulk(c, func(ids []string, comment string) ([]domain.BulkResult, error) {
+		return h.requestService.BulkApprove(c.Context(), ids, userID, actorIDs, isAdmin, mfaAt, comment)
+	})
+}
+
+// BulkReject rejects several requests, each as Reject would
+// POST /requests/bulk-reject
+func (h *RequestHandler) BulkReject(c *fiber.Ctx) error {
+	userID := c.Locals("user_id").(string)
+	actorIDs := middleware.GetActorIDsFromContext(c)
+	isAdmin := c.Locals("is_admin").(bool)
+
+	return h.bulk(c, func(ids []string, comment string) ([]domain.BulkResult, error) {
+		return h.requestService.BulkReject(c.Context(), ids, userID, actorIDs, isAdmin, comment)
+	})
+}
+
+// bulk runs a bulk action on the requests in the caller's workflow scope.
+// Requests outside it are reported as not found, as a single approval would.
+// The response is 200 whatever the per-request results are.
+func (h *RequestHandler) bulk(c *fiber.Ctx, act func(ids []string, comment string) ([]domain.BulkResult, error)) error {
+	var req dto.BulkActionRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"success": false,
+			"data":    nil,
+			"error":   "Invalid request body",
+		})
+	}
+	if len(req.IDs) == 0 || len(req.IDs) > reqUsecase.MaxBulkRequests {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"success": false,
+			"data":    nil,
+			"error":   reqUsecase.ErrBulkRequestCount.Error(),
+		})
+	}
+
+	var inScope []string
+	for _, id := range req.IDs {
+		if h.requestInWorkflowScope(c, id) {
+			inScope = append(inScope, id)
+		}
+	}
+	byID := make(map[string]domain.BulkResult, len(req.IDs))
+	if len(inScope) > 0 {
+		results, err := act(inScope, req.Comment)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"success": false,
+				"data":    nil,
+				"error":   err.Error(),
+			})
+		}
+		for _, result := range results {
+			byID[result.RequestID] = result
+		}
+	}
+
+	results := make([]domain.BulkResult, 0, len(req.IDs))
+	seen := make(map[string]bool, len(req.IDs))
+	for _, id := range req.IDs {
+		if seen[id] {
+			continue
+		}
+		seen[id] = true
+		result, ok := byID[id]
+		if !ok {
+			result = domain.BulkResult{RequestID: id, Status: domain.BulkNotFound, Err: reqUsecase.ErrRequestNotFound}
+		}
+		results = append(results, result)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"success": true,
+		"data":    dto.ToBulkResponse(results),
+		"error":   nil,
+	})
+}
+
 // Delete deletes a request by ID
 // DELETE /requests/:id
 func (h *RequestHandler) Delete(c *fiber.Ctx) error {
@@ -283,6 +544,14 @@ func (h *RequestHandler) Delete(c *fiber.Ctx) error {
 		})
 	}
 
+	if !middleware.RequestVisible(c, h.requestService, id) {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"success": false,
+			"data":    nil,
+			"error":   "Request not found",
+		})
+	}
+
 	err := h.requestService.DeleteRequest(c.Context(), id)
 	if err != nil {
 		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
@@ -299,8 +568,9 @@ func (h *RequestHandler) Delete(c *fiber.Ctx) error {
 	})
 }
 
-// GetHistory retrieves the approval history for a specific request
-// GET /requests/:id/history
+// GetHistory retrieves the approval history for a specific request, all of it
+// or a page when any of page, limit or cursor is given
+// GET /requests/:id/history?page=&limit=&cursor=&total=
 func (h *RequestHandler) GetHistory(c *fiber.Ctx) error {
 	id := c.Params("id")
 	if id == "" {
@@ -311,6 +581,18 @@ func (h *RequestHandler) GetHistory(c *fiber.Ctx) error {
 		})
 	}
 
+	if !middleware.RequestVisible(c, h.requestService, id) {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"success": false,
+			"data":    nil,
+			"error":   "Request not found",
+		})
+	}
+
+	if c.Query("page") != "" || c.Query("limit") != "" || c.Query("cursor") != "" {
+		return h.listHistory(c, id)
+	}
+
 	history, err := h.historyService.GetHistoryByRequestID(c.Context(), id)
 	if err != nil {
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
@@ -329,3 +611,149 @@ func (h *RequestHandler) GetHistory(c *fiber.Ctx) error {
 		"error": nil,
 	})
 }
+
+// listHistory responds with a page of a request's approval history, by offset or by cursor
+func (h *RequestHandler) listHistory(c *fiber.Ctx, id string) error {
+	page, err := pagination.Parse(c.Query("page"), c.Query("limit"), c.Query("cursor"), c.Query("total"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"success": false,
+			"data":    nil,
+			"error":   err.Error(),
+		})
+	}
+
+	history, result, err := h.historyService.ListHistoryByRequestID(c.Context(), id, page)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"success": false,
+			"data":    nil,
+			"error":   "Failed to get approval history",
+		})
+	}
+
+	data := fiber.Map(result.Meta(c.Path(), string(c.Request().URI().QueryString())))
+	data["request_id"] = id
+	data["history"] = history
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"success": true,
+		"data":    data,
+		"error":   nil,
+	})
+}
+
+// Simulate shows the levels a hypothetical request would go through
+// POST /workflows/:id/simulate
+func (h *RequestHandler) Simulate(c *fiber.Ctx) error {
+	workflowID := c.Params("id")
+	if !h.inWorkflowScope(c, workflowID) {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"success": false,
+			"data":    nil,
+			"error":   reqUsecase.ErrWorkflowNotFound.Error(),
+		})
+	}
+
+	var req dto.SimulateRequestRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"success": false,
+			"data":    nil,
+			"error":   "Invalid request body",
+		})
+	}
+	if req.RequesterID == "" {
+		req.RequesterID = c.Locals("user_id").(string)
+	}
+
+	simulation, err := h.requestService.Simulate(c.Context(), workflowID, req.RequesterID, req.Amount, req.Currency, req.Fields)
+	if err != nil {
+		var fieldErrs wfDomain.FieldErrors
+		status := fiber.StatusBadRequest
+		switch {
+		case errors.As(err, &fieldErrs):
+			return invalidFields(c, fieldErrs)
+		case errors.Is(err, domain.ErrExchangeRateMissing):
+			return exchangeRateMissing(c, err)
+		case errors.Is(err, reqUsecase.ErrWorkflowNotFound):
+			status = fiber.StatusNotFound
+		}
+		return c.Status(status).JSON(fiber.Map{
+			"success": false,
+			"data":    nil,
+			"error":   err.Error(),
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"success": true,
+		"data":    simulation,
+		"error":   nil,
+	})
+}
+
+// approverUnresolved responds when the next step has nobody to approve it,
+// e.g. the requester has no manager and the step has no fallback actor
+func approverUnresolved(c *fiber.Ctx, err error) error {
+	return c.Status(fiber.StatusUnprocessableEntity).JSON(fiber.Map{
+		"success": false,
+		"data":    nil,
+		"error":   err.Error(),
+		"code":    "APPROVER_UNRESOLVED",
+	})
+}
+
+// exchangeRateMissing responds when the request's currency can't be converted
+// into the base currency because no admin has set its rate
+func exchangeRateMissing(c *fiber.Ctx, err error) error {
+	return c.Status(fiber.StatusUnprocessableEntity).JSON(fiber.Map{
+		"success": false,
+		"data":    nil,
+		"error":   err.Error(),
+		"code":    "EXCHANGE_RATE_MISSING",
+	})
+}
+
+// workflowInvalid responds when the request's workflow fails validation, e.g.
+// it has no steps or a gap in its levels, with the issues to fix
+func workflowInvalid(c *fiber.Ctx, err *valDomain.InvalidError) error {
+	return c.Status(fiber.StatusUnprocessableEntity).JSON(fiber.Map{
+		"success": false,
+		"data":    nil,
+		"error":   err.Error(),
+		"code":    "WORKFLOW_INVALID",
+		"issues":  err.Issues,
+	})
+}
+
+// invalidFields responds with one entry per custom field that failed the workflow's form schema
+func invalidFields(c *fiber.Ctx, errs wfDomain.FieldErrors) error {
+	return c.Status(fiber.StatusUnprocessableEntity).JSON(fiber.Map{
+		"success":      false,
+		"data":         nil,
+		"error":        "Invalid request fields",
+		"code":         "INVALID_FIELDS",
+		"field_errors": errs,
+	})
+}
+
+// inWorkflowScope checks that an API key caller may access the given workflow.
+// JWT callers and unscoped keys are always in scope.
+func (h *RequestHandler) inWorkflowScope(c *fiber.Ctx, workflowID string) bool {
+	key := middleware.GetAPIKeyFromContext(c)
+	return key == nil || key.AllowsWorkflow(workflowID)
+}
+
+// requestInWorkflowScope loads a request to check it against the caller's workflow scope.
+// Missing requests are reported as in scope so the service returns its usual error.
+func (h *RequestHandler) requestInWorkflowScope(c *fiber.Ctx, requestID string) bool {
+	key := middleware.GetAPIKeyFromContext(c)
+	if key == nil || len(key.WorkflowIDs) == 0 {
+		return true
+	}
+	request, err := h.requestService.GetRequest(c.Context(), requestID)
+	if err != nil {
+		return true
+	}
+	return key.AllowsWorkflow(request.WorkflowID)
+}
@@ -0,0 +1,141 @@
+package handler
+
+import (
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/mock"
+
+	apiKeyDomain "workflow-approval/package/api_key/domain"
+	historyMocks "workflow-approval/package/approval_history/ports/mocks"
+	"workflow-approval/package/request/domain"
+	reqMocks "workflow-approval/package/request/ports/mocks"
+	"workflow-approval/utils/money"
+)
+
+// newRequestTestApp mounts the request routes for a JWT caller with userID
+func newRequestTestApp(h *RequestHandler, userID string) *fiber.App {
+	app := fiber.New()
+	app.Use(func(c *fiber.Ctx) error {
+		c.Locals("user_id", userID)
+		c.Locals("is_admin", false)
+		return c.Next()
+	})
+	h.Routes(app.Group("/api/requests"))
+	return app
+}
+
+func TestDraftsArePrivateToTheirRequester(t *testing.T) {
+	requests := reqMocks.NewRequestService(t)
+	history := historyMocks.NewApprovalHistoryService(t)
+	h := NewRequestHandler(requests, history)
+
+	draft := domain.NewRequest("wf-1", "owner", money.NewDecimal(1000), "IDR", "Laptop", "", nil)
+	draft.ID = "draft-1"
+	draft.Status = domain.StatusDraft
+	requests.EXPECT().GetRequest(mock.Anything, "draft-1").Return(draft, nil)
+
+	app := newRequestTestApp(h, "someone-else")
+	tests := []struct {
+		name   string
+		method string
+		path   string
+		body   string
+	}{
+		{"Update", fiber.MethodPut, "/api/requests/draft-1", `{"title":"Mine now"}`},
+		{"Delete", fiber.MethodDelete, "/api/requests/draft-1", ""},
+		{"History", fiber.MethodGet, "/api/requests/draft-1/history", ""},
+		{"Get", fiber.MethodGet, "/api/requests/draft-1", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, tt.path, strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			resp, err := app.Test(req)
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			if resp.StatusCode != fiber.StatusNotFound {
+				t.Errorf("Expected 404 for another user's draft, got %d", resp.StatusCode)
+			}
+		})
+	}
+
+	requests.AssertNotCalled(t, "UpdateRequest", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	requests.AssertNotCalled(t, "DeleteRequest", mock.Anything, mock.Anything)
+	history.AssertNotCalled(t, "GetHistoryByRequestID", mock.Anything, mock.Anything)
+}
+
+func TestDraftRequesterCanManageTheirDraft(t *testing.T) {
+	requests := reqMocks.NewRequestService(t)
+	history := historyMocks.NewApprovalHistoryService(t)
+	h := NewRequestHandler(requests, history)
+
+	draft := domain.NewRequest("wf-1", "owner", money.NewDecimal(1000), "IDR", "Laptop", "", nil)
+	draft.ID = "draft-1"
+	draft.Status = domain.StatusDraft
+	requests.EXPECT().GetRequest(mock.Anything, "draft-1").Return(draft, nil)
+	requests.EXPECT().DeleteRequest(mock.Anything, "draft-1").Return(nil)
+	history.EXPECT().GetHistoryByRequestID(mock.Anything, "draft-1").Return(nil, nil)
+
+	app := newRequestTestApp(h, "owner")
+	for _, tt := range []struct{ method, path string }{
+		{fiber.MethodGet, "/api/requests/draft-1/history"},
+		{fiber.MethodDelete, "/api/requests/draft-1"},
+	} {
+		resp, err := app.Test(httptest.NewRequest(tt.method, tt.path, nil))
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if resp.StatusCode != fiber.StatusOK {
+			t.Errorf("%s %s: expected 200 for the requester, got %d", tt.method, tt.path, resp.StatusCode)
+		}
+	}
+}
+
+func TestAPIKeyWorkflowScope(t *testing.T) {
+	requests := reqMocks.NewRequestService(t)
+	history := historyMocks.NewApprovalHistoryService(t)
+	h := NewRequestHandler(requests, history)
+
+	inScope := domain.NewRequest("wf-1", "owner", money.NewDecimal(1000), "IDR", "Laptop", "", nil)
+	inScope.ID = "req-1"
+	inScope.Status = domain.StatusPending
+	outOfScope := domain.NewRequest("wf-2", "owner", money.NewDecimal(1000), "IDR", "Monitor", "", nil)
+	outOfScope.ID = "req-2"
+	outOfScope.Status = domain.StatusPending
+	requests.EXPECT().GetRequest(mock.Anything, "req-1").Return(inScope, nil)
+	requests.EXPECT().GetRequest(mock.Anything, "req-2").Return(outOfScope, nil)
+	history.EXPECT().GetHistoryByRequestID(mock.Anything, "req-1").Return(nil, nil)
+
+	app := fiber.New()
+	app.Use(func(c *fiber.Ctx) error {
+		c.Locals("user_id", "key-1")
+		c.Locals("is_admin", false)
+		c.Locals("api_key", &apiKeyDomain.APIKey{ID: "key-1", WorkflowIDs: apiKeyDomain.StringList{"wf-1"}})
+		return c.Next()
+	})
+	h.Routes(app.Group("/api/requests"))
+
+	tests := []struct {
+		path string
+		want int
+	}{
+		{"/api/requests/req-1", fiber.StatusOK},
+		{"/api/requests/req-1/history", fiber.StatusOK},
+		{"/api/requests/req-2", fiber.StatusNotFound},
+		{"/api/requests/req-2/history", fiber.StatusNotFound},
+	}
+	for _, tt := range tests {
+		resp, err := app.Test(httptest.NewRequest(fiber.MethodGet, tt.path, nil))
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if resp.StatusCode != tt.want {
+			t.Errorf("%s: expected %d for a key scoped to wf-1, got %d", tt.path, tt.want, resp.StatusCode)
+		}
+	}
+	history.AssertNumberOfCalls(t, "GetHistoryByRequestID", 1)
+}
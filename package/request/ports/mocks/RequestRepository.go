@@ -4,7 +4,9 @@ package mocks
 
 import (
 	context "context"
+	time "time"
 	domain "workflow-approval/package/request/domain"
+	pagination "workflow-approval/utils/pagination"
 
 	mock "github.com/stretchr/testify/mock"
 )
@@ -22,6 +24,52 @@ func (_m *RequestRepository) EXPECT() *RequestRepository_Expecter {
 	return &RequestRepository_Expecter{mock: &_m.Mock}
 }
 
+// CountPendingByWorkflow provides a mock function with given fields: ctx
+func (_m *RequestRepository) CountPendingByWorkflow(ctx context.Context) (map[string]int64, error) {
+	ret := _m.Called(ctx)
+
+	var r0 map[string]int64
+	if rf, ok := ret.Get(0).(func(context.Context) map[string]int64); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[string]int64)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RequestRepository_CountPendingByWorkflow_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CountPendingByWorkflow'
+type RequestRepository_CountPendingByWorkflow_Call struct {
+	*mock.Call
+}
+
+// CountPendingByWorkflow is a helper method to define mock.On call
+//  - ctx context.Context
+func (_e *RequestRepository_Expecter) CountPendingByWorkflow(ctx interface{}) *RequestRepository_CountPendingByWorkflow_Call {
+	return &RequestRepository_CountPendingByWorkflow_Call{Call: _e.mock.On("CountPendingByWorkflow", ctx)}
+}
+
+func (_c *RequestRepository_CountPendingByWorkflow_Call) Run(run func(ctx context.Context)) *RequestRepository_CountPendingByWorkflow_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *RequestRepository_CountPendingByWorkflow_Call) Return(_a0 map[string]int64, _a1 error) *RequestRepository_CountPendingByWorkflow_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
 // Create provides a mock function with given fields: ctx, request
 func (_m *RequestRepository) Create(ctx context.Context, request *domain.Request) error {
 	ret := _m.Called(ctx, request)
@@ -192,29 +240,29 @@ func (_c *RequestRepository_GetByIDForUpdate_Call) Return(_a0 *domain.Request, _
 	return _c
 }
 
-// List provides a mock function with given fields: ctx, page, limit, status
-func (_m *RequestRepository) List(ctx context.Context, page int, limit int, status *domain.RequestStatus) ([]*domain.Request, int64, error) {
-	ret := _m.Called(ctx, page, limit, status)
+// List provides a mock function with given fields: ctx, filter, page
+func (_m *RequestRepository) List(ctx context.Context, filter domain.RequestFilter, page pagination.Page) ([]*domain.Request, pagination.Result, error) {
+	ret := _m.Called(ctx, filter, page)
 
 	var r0 []*domain.Request
-	if rf, ok := ret.Get(0).(func(context.Context, int, int, *domain.RequestStatus) []*domain.Request); ok {
-		r0 = rf(ctx, page, limit, status)
+	if rf, ok := ret.Get(0).(func(context.Context, domain.RequestFilter, pagination.Page) []*domain.Request); ok {
+		r0 = rf(ctx, filter, page)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]*domain.Request)
 		}
 	}
 
-	var r1 int64
-	if rf, ok := ret.Get(1).(func(context.Context, int, int, *domain.RequestStatus) int64); ok {
-		r1 = rf(ctx, page, limit, status)
+	var r1 pagination.Result
+	if rf, ok := ret.Get(1).(func(context.Context, domain.RequestFilter, pagination.Page) pagination.Result); ok {
+		r1 = rf(ctx, filter, page)
 	} else {
-		r1 = ret.Get(1).(int64)
+		r1 = ret.Get(1).(pagination.Result)
 	}
 
 	var r2 error
-	if rf, ok := ret.Get(2).(func(context.Context, int, int, *domain.RequestStatus) error); ok {
-		r2 = rf(ctx, page, limit, status)
+	if rf, ok := ret.Get(2).(func(context.Context, domain.RequestFilter, pagination.Page) error); ok {
+		r2 = rf(ctx, filter, page)
 	} else {
 		r2 = ret.Error(2)
 	}
@@ -229,25 +277,72 @@ type RequestRepository_List_Call struct {
 
 // List is a helper method to define mock.On call
 //  - ctx context.Context
-//  - page int
-//  - limit int
-//  - status *domain.RequestStatus
-func (_e *RequestRepository_Expecter) List(ctx interface{}, page interface{}, limit interface{}, status interface{}) *RequestRepository_List_Call {
-	return &RequestRepository_List_Call{Call: _e.mock.On("List", ctx, page, limit, status)}
+//  - filter domain.RequestFilter
+//  - page pagination.Page
+func (_e *RequestRepository_Expecter) List(ctx interface{}, filter interface{}, page interface{}) *RequestRepository_List_Call {
+	return &RequestRepository_List_Call{Call: _e.mock.On("List", ctx, filter, page)}
 }
 
-func (_c *RequestRepository_List_Call) Run(run func(ctx context.Context, page int, limit int, status *domain.RequestStatus)) *RequestRepository_List_Call {
+func (_c *RequestRepository_List_Call) Run(run func(ctx context.Context, filter domain.RequestFilter, page pagination.Page)) *RequestRepository_List_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(int), args[2].(int), args[3].(*domain.RequestStatus))
+		run(args[0].(context.Context), args[1].(domain.RequestFilter), args[2].(pagination.Page))
 	})
 	return _c
 }
 
-func (_c *RequestRepository_List_Call) Return(_a0 []*domain.Request, _a1 int64, _a2 error) *RequestRepository_List_Call {
+func (_c *RequestRepository_List_Call) Return(_a0 []*domain.Request, _a1 pagination.Result, _a2 error) *RequestRepository_List_Call {
 	_c.Call.Return(_a0, _a1, _a2)
 	return _c
 }
 
+// ListDraftsUpdatedBefore provides a mock function with given fields: ctx, before, limit
+func (_m *RequestRepository) ListDraftsUpdatedBefore(ctx context.Context, before time.Time, limit int) ([]*domain.Request, error) {
+	ret := _m.Called(ctx, before, limit)
+
+	var r0 []*domain.Request
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time, int) []*domain.Request); ok {
+		r0 = rf(ctx, before, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*domain.Request)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time, int) error); ok {
+		r1 = rf(ctx, before, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RequestRepository_ListDraftsUpdatedBefore_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListDraftsUpdatedBefore'
+type RequestRepository_ListDraftsUpdatedBefore_Call struct {
+	*mock.Call
+}
+
+// ListDraftsUpdatedBefore is a helper method to define mock.On call
+//  - ctx context.Context
+//  - before time.Time
+//  - limit int
+func (_e *RequestRepository_Expecter) ListDraftsUpdatedBefore(ctx interface{}, before interface{}, limit interface{}) *RequestRepository_ListDraftsUpdatedBefore_Call {
+	return &RequestRepository_ListDraftsUpdatedBefore_Call{Call: _e.mock.On("ListDraftsUpdatedBefore", ctx, before, limit)}
+}
+
+func (_c *RequestRepository_ListDraftsUpdatedBefore_Call) Run(run func(ctx context.Context, before time.Time, limit int)) *RequestRepository_ListDraftsUpdatedBefore_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(time.Time), args[2].(int))
+	})
+	return _c
+}
+
+func (_c *RequestRepository_ListDraftsUpdatedBefore_Call) Return(_a0 []*domain.Request, _a1 error) *RequestRepository_ListDraftsUpdatedBefore_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
 // Update provides a mock function with given fields: ctx, request
 func (_m *RequestRepository) Update(ctx context.Context, request *domain.Request) error {
 	ret := _m.Called(ctx, request)
@@ -4,7 +4,10 @@ package mocks
 
 import (
 	context "context"
+	time "time"
 	domain "workflow-approval/package/request/domain"
+	money "workflow-approval/utils/money"
+	pagination "workflow-approval/utils/pagination"
 
 	mock "github.com/stretchr/testify/mock"
 )
@@ -22,13 +25,13 @@ func (_m *RequestService) EXPECT() *RequestService_Expecter {
 	return &RequestService_Expecter{mock: &_m.Mock}
 }
 
-// Approve provides a mock function with given fields: ctx, requestID, userID, actorID, isAdmin
-func (_m *RequestService) Approve(ctx context.Context, requestID string, userID string, actorID string, isAdmin bool) (*domain.Request, error) {
-	ret := _m.Called(ctx, requestID, userID, actorID, isAdmin)
+// Approve provides a mock function with given fields: ctx, requestID, userID, actorIDs, isAdmin, mfaAt
+func (_m *RequestService) Approve(ctx context.Context, requestID string, userID string, actorIDs []string, isAdmin bool, mfaAt *time.Time) (*domain.Request, error) {
+	ret := _m.Called(ctx, requestID, userID, actorIDs, isAdmin, mfaAt)
 
 	var r0 *domain.Request
-	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, bool) *domain.Request); ok {
-		r0 = rf(ctx, requestID, userID, actorID, isAdmin)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, []string, bool, *time.Time) *domain.Request); ok {
+		r0 = rf(ctx, requestID, userID, actorIDs, isAdmin, mfaAt)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*domain.Request)
@@ -36,8 +39,8 @@ func (_m *RequestService) Approve(ctx context.Context, requestID string, userID
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(context.Context, string, string, string, bool) error); ok {
-		r1 = rf(ctx, requestID, userID, actorID, isAdmin)
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, []string, bool, *time.Time) error); ok {
+		r1 = rf(ctx, requestID, userID, actorIDs, isAdmin, mfaAt)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -54,15 +57,16 @@ type RequestService_Approve_Call struct {
 //  - ctx context.Context
 //  - requestID string
 //  - userID string
-//  - actorID string
+//  - actorIDs []string
 //  - isAdmin bool
-func (_e *RequestService_Expecter) Approve(ctx interface{}, requestID interface{}, userID interface{}, actorID interface{}, isAdmin interface{}) *RequestService_Approve_Call {
-	return &RequestService_Approve_Call{Call: _e.mock.On("Approve", ctx, requestID, userID, actorID, isAdmin)}
+//  - mfaAt *time.Time
+func (_e *RequestService_Expecter) Approve(ctx interface{}, requestID interface{}, userID interface{}, actorIDs interface{}, isAdmin interface{}, mfaAt interface{}) *RequestService_Approve_Call {
+	return &RequestService_Approve_Call{Call: _e.mock.On("Approve", ctx, requestID, userID, actorIDs, isAdmin, mfaAt)}
 }
 
-func (_c *RequestService_Approve_Call) Run(run func(ctx context.Context, requestID string, userID string, actorID string, isAdmin bool)) *RequestService_Approve_Call {
+func (_c *RequestService_Approve_Call) Run(run func(ctx context.Context, requestID string, userID string, actorIDs []string, isAdmin bool, mfaAt *time.Time)) *RequestService_Approve_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(string), args[4].(bool))
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].([]string), args[4].(bool), args[5].(*time.Time))
 	})
 	return _c
 }
@@ -72,13 +76,169 @@ func (_c *RequestService_Approve_Call) Return(_a0 *domain.Request, _a1 error) *R
 	return _c
 }
 
-// CreateRequest provides a mock function with given fields: ctx, workflowID, requesterID, amount, title, description
-func (_m *RequestService) CreateRequest(ctx context.Context, workflowID string, requesterID string, amount float64, title string, description string) (*domain.Request, error) {
-	ret := _m.Called(ctx, workflowID, requesterID, amount, title, description)
+// BulkApprove provides a mock function with given fields: ctx, requestIDs, userID, actorIDs, isAdmin, mfaAt, comment
+func (_m *RequestService) BulkApprove(ctx context.Context, requestIDs []string, userID string, actorIDs []string, isAdmin bool, mfaAt *time.Time, comment string) ([]domain.BulkResult, error) {
+	ret := _m.Called(ctx, requestIDs, userID, actorIDs, isAdmin, mfaAt, comment)
+
+	var r0 []domain.BulkResult
+	if rf, ok := ret.Get(0).(func(context.Context, []string, string, []string, bool, *time.Time, string) []domain.BulkResult); ok {
+		r0 = rf(ctx, requestIDs, userID, actorIDs, isAdmin, mfaAt, comment)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.BulkResult)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, []string, string, []string, bool, *time.Time, string) error); ok {
+		r1 = rf(ctx, requestIDs, userID, actorIDs, isAdmin, mfaAt, comment)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RequestService_BulkApprove_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'BulkApprove'
+type RequestService_BulkApprove_Call struct {
+	*mock.Call
+}
+
+// BulkApprove is a helper method to define mock.On call
+//  - ctx context.Context
+//  - requestIDs []string
+//  - userID string
+//  - actorIDs []string
+//  - isAdmin bool
+//  - mfaAt *time.Time
+//  - comment string
+func (_e *RequestService_Expecter) BulkApprove(ctx interface{}, requestIDs interface{}, userID interface{}, actorIDs interface{}, isAdmin interface{}, mfaAt interface{}, comment interface{}) *RequestService_BulkApprove_Call {
+	return &RequestService_BulkApprove_Call{Call: _e.mock.On("BulkApprove", ctx, requestIDs, userID, actorIDs, isAdmin, mfaAt, comment)}
+}
+
+func (_c *RequestService_BulkApprove_Call) Run(run func(ctx context.Context, requestIDs []string, userID string, actorIDs []string, isAdmin bool, mfaAt *time.Time, comment string)) *RequestService_BulkApprove_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]string), args[2].(string), args[3].([]string), args[4].(bool), args[5].(*time.Time), args[6].(string))
+	})
+	return _c
+}
+
+func (_c *RequestService_BulkApprove_Call) Return(_a0 []domain.BulkResult, _a1 error) *RequestService_BulkApprove_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+// BulkReject provides a mock function with given fields: ctx, requestIDs, userID, actorIDs, isAdmin, reason
+func (_m *RequestService) BulkReject(ctx context.Context, requestIDs []string, userID string, actorIDs []string, isAdmin bool, reason string) ([]domain.BulkResult, error) {
+	ret := _m.Called(ctx, requestIDs, userID, actorIDs, isAdmin, reason)
+
+	var r0 []domain.BulkResult
+	if rf, ok := ret.Get(0).(func(context.Context, []string, string, []string, bool, string) []domain.BulkResult); ok {
+		r0 = rf(ctx, requestIDs, userID, actorIDs, isAdmin, reason)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.BulkResult)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, []string, string, []string, bool, string) error); ok {
+		r1 = rf(ctx, requestIDs, userID, actorIDs, isAdmin, reason)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RequestService_BulkReject_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'BulkReject'
+type RequestService_BulkReject_Call struct {
+	*mock.Call
+}
+
+// BulkReject is a helper method to define mock.On call
+//  - ctx context.Context
+//  - requestIDs []string
+//  - userID string
+//  - actorIDs []string
+//  - isAdmin bool
+//  - reason string
+func (_e *RequestService_Expecter) BulkReject(ctx interface{}, requestIDs interface{}, userID interface{}, actorIDs interface{}, isAdmin interface{}, reason interface{}) *RequestService_BulkReject_Call {
+	return &RequestService_BulkReject_Call{Call: _e.mock.On("BulkReject", ctx, requestIDs, userID, actorIDs, isAdmin, reason)}
+}
+
+func (_c *RequestService_BulkReject_Call) Run(run func(ctx context.Context, requestIDs []string, userID string, actorIDs []string, isAdmin bool, reason string)) *RequestService_BulkReject_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]string), args[2].(string), args[3].([]string), args[4].(bool), args[5].(string))
+	})
+	return _c
+}
+
+func (_c *RequestService_BulkReject_Call) Return(_a0 []domain.BulkResult, _a1 error) *RequestService_BulkReject_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+// CreateDraft provides a mock function with given fields: ctx, workflowID, requesterID, amount, currency, title, description, fields
+func (_m *RequestService) CreateDraft(ctx context.Context, workflowID string, requesterID string, amount money.Decimal, currency string, title string, description string, fields domain.RequestFields) (*domain.Request, error) {
+	ret := _m.Called(ctx, workflowID, requesterID, amount, currency, title, description, fields)
+
+	var r0 *domain.Request
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, money.Decimal, string, string, string, domain.RequestFields) *domain.Request); ok {
+		r0 = rf(ctx, workflowID, requesterID, amount, currency, title, description, fields)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Request)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, money.Decimal, string, string, string, domain.RequestFields) error); ok {
+		r1 = rf(ctx, workflowID, requesterID, amount, currency, title, description, fields)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RequestService_CreateDraft_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateDraft'
+type RequestService_CreateDraft_Call struct {
+	*mock.Call
+}
+
+// CreateDraft is a helper method to define mock.On call
+//  - ctx context.Context
+//  - workflowID string
+//  - requesterID string
+//  - amount money.Decimal
+//  - currency string
+//  - title string
+//  - description string
+//  - fields domain.RequestFields
+func (_e *RequestService_Expecter) CreateDraft(ctx interface{}, workflowID interface{}, requesterID interface{}, amount interface{}, currency interface{}, title interface{}, description interface{}, fields interface{}) *RequestService_CreateDraft_Call {
+	return &RequestService_CreateDraft_Call{Call: _e.mock.On("CreateDraft", ctx, workflowID, requesterID, amount, currency, title, description, fields)}
+}
+
+func (_c *RequestService_CreateDraft_Call) Run(run func(ctx context.Context, workflowID string, requesterID string, amount money.Decimal, currency string, title string, description string, fields domain.RequestFields)) *RequestService_CreateDraft_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(money.Decimal), args[4].(string), args[5].(string), args[6].(string), args[7].(domain.RequestFields))
+	})
+	return _c
+}
+
+func (_c *RequestService_CreateDraft_Call) Return(_a0 *domain.Request, _a1 error) *RequestService_CreateDraft_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+// CreateRequest provides a mock function with given fields: ctx, workflowID, requesterID, amount, currency, title, description, fields
+func (_m *RequestService) CreateRequest(ctx context.Context, workflowID string, requesterID string, amount money.Decimal, currency string, title string, description string, fields domain.RequestFields) (*domain.Request, error) {
+	ret := _m.Called(ctx, workflowID, requesterID, amount, currency, title, description, fields)
 
 	var r0 *domain.Request
-	if rf, ok := ret.Get(0).(func(context.Context, string, string, float64, string, string) *domain.Request); ok {
-		r0 = rf(ctx, workflowID, requesterID, amount, title, description)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, money.Decimal, string, string, string, domain.RequestFields) *domain.Request); ok {
+		r0 = rf(ctx, workflowID, requesterID, amount, currency, title, description, fields)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*domain.Request)
@@ -86,8 +246,8 @@ func (_m *RequestService) CreateRequest(ctx context.Context, workflowID string,
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(context.Context, string, string, float64, string, string) error); ok {
-		r1 = rf(ctx, workflowID, requesterID, amount, title, description)
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, money.Decimal, string, string, string, domain.RequestFields) error); ok {
+		r1 = rf(ctx, workflowID, requesterID, amount, currency, title, description, fields)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -104,16 +264,18 @@ type RequestService_CreateRequest_Call struct {
 //  - ctx context.Context
 //  - workflowID string
 //  - requesterID string
-//  - amount float64
+//  - amount money.Decimal
+//  - currency string
 //  - title string
 //  - description string
-func (_e *RequestService_Expecter) CreateRequest(ctx interface{}, workflowID interface{}, requesterID interface{}, amount interface{}, title interface{}, description interface{}) *RequestService_CreateRequest_Call {
-	return &RequestService_CreateRequest_Call{Call: _e.mock.On("CreateRequest", ctx, workflowID, requesterID, amount, title, description)}
+//  - fields domain.RequestFields
+func (_e *RequestService_Expecter) CreateRequest(ctx interface{}, workflowID interface{}, requesterID interface{}, amount interface{}, currency interface{}, title interface{}, description interface{}, fields interface{}) *RequestService_CreateRequest_Call {
+	return &RequestService_CreateRequest_Call{Call: _e.mock.On("CreateRequest", ctx, workflowID, requesterID, amount, currency, title, description, fields)}
 }
 
-func (_c *RequestService_CreateRequest_Call) Run(run func(ctx context.Context, workflowID string, requesterID string, amount float64, title string, description string)) *RequestService_CreateRequest_Call {
+func (_c *RequestService_CreateRequest_Call) Run(run func(ctx context.Context, workflowID string, requesterID string, amount money.Decimal, currency string, title string, description string, fields domain.RequestFields)) *RequestService_CreateRequest_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(float64), args[4].(string), args[5].(string))
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(money.Decimal), args[4].(string), args[5].(string), args[6].(string), args[7].(domain.RequestFields))
 	})
 	return _c
 }
@@ -208,29 +370,29 @@ func (_c *RequestService_GetRequest_Call) Return(_a0 *domain.Request, _a1 error)
 	return _c
 }
 
-// ListRequests provides a mock function with given fields: ctx, page, limit, status
-func (_m *RequestService) ListRequests(ctx context.Context, page int, limit int, status *domain.RequestStatus) ([]*domain.Request, int64, error) {
-	ret := _m.Called(ctx, page, limit, status)
+// ListRequests provides a mock function with given fields: ctx, filter, page
+func (_m *RequestService) ListRequests(ctx context.Context, filter domain.RequestFilter, page pagination.Page) ([]*domain.Request, pagination.Result, error) {
+	ret := _m.Called(ctx, filter, page)
 
 	var r0 []*domain.Request
-	if rf, ok := ret.Get(0).(func(context.Context, int, int, *domain.RequestStatus) []*domain.Request); ok {
-		r0 = rf(ctx, page, limit, status)
+	if rf, ok := ret.Get(0).(func(context.Context, domain.RequestFilter, pagination.Page) []*domain.Request); ok {
+		r0 = rf(ctx, filter, page)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]*domain.Request)
 		}
 	}
 
-	var r1 int64
-	if rf, ok := ret.Get(1).(func(context.Context, int, int, *domain.RequestStatus) int64); ok {
-		r1 = rf(ctx, page, limit, status)
+	var r1 pagination.Result
+	if rf, ok := ret.Get(1).(func(context.Context, domain.RequestFilter, pagination.Page) pagination.Result); ok {
+		r1 = rf(ctx, filter, page)
 	} else {
-		r1 = ret.Get(1).(int64)
+		r1 = ret.Get(1).(pagination.Result)
 	}
 
 	var r2 error
-	if rf, ok := ret.Get(2).(func(context.Context, int, int, *domain.RequestStatus) error); ok {
-		r2 = rf(ctx, page, limit, status)
+	if rf, ok := ret.Get(2).(func(context.Context, domain.RequestFilter, pagination.Page) error); ok {
+		r2 = rf(ctx, filter, page)
 	} else {
 		r2 = ret.Error(2)
 	}
@@ -245,21 +407,20 @@ type RequestService_ListRequests_Call struct {
 
 // ListRequests is a helper method to define mock.On call
 //  - ctx context.Context
-//  - page int
-//  - limit int
-//  - status *domain.RequestStatus
-func (_e *RequestService_Expecter) ListRequests(ctx interface{}, page interface{}, limit interface{}, status interface{}) *RequestService_ListRequests_Call {
-	return &RequestService_ListRequests_Call{Call: _e.mock.On("ListRequests", ctx, page, limit, status)}
+//  - filter domain.RequestFilter
+//  - page pagination.Page
+func (_e *RequestService_Expecter) ListRequests(ctx interface{}, filter interface{}, page interface{}) *RequestService_ListRequests_Call {
+	return &RequestService_ListRequests_Call{Call: _e.mock.On("ListRequests", ctx, filter, page)}
 }
 
-func (_c *RequestService_ListRequests_Call) Run(run func(ctx context.Context, page int, limit int, status *domain.RequestStatus)) *RequestService_ListRequests_Call {
+func (_c *RequestService_ListRequests_Call) Run(run func(ctx context.Context, filter domain.RequestFilter, page pagination.Page)) *RequestService_ListRequests_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(int), args[2].(int), args[3].(*domain.RequestStatus))
+		run(args[0].(context.Context), args[1].(domain.RequestFilter), args[2].(pagination.Page))
 	})
 	return _c
 }
 
-func (_c *RequestService_ListRequests_Call) Return(_a0 []*domain.Request, _a1 int64, _a2 error) *RequestService_ListRequests_Call {
+func (_c *RequestService_ListRequests_Call) Return(_a0 []*domain.Request, _a1 pagination.Result, _a2 error) *RequestService_ListRequests_Call {
 	_c.Call.Return(_a0, _a1, _a2)
 	return _c
 }
@@ -303,13 +464,13 @@ func (_c *RequestService_LockRequest_Call) Return(_a0 func()) *RequestService_Lo
 	return _c
 }
 
-// Reject provides a mock function with given fields: ctx, requestID, userID, actorID, isAdmin, reason
-func (_m *RequestService) Reject(ctx context.Context, requestID string, userID string, actorID string, isAdmin bool, reason string) (*domain.Request, error) {
-	ret := _m.Called(ctx, requestID, userID, actorID, isAdmin, reason)
+// Reject provides a mock function with given fields: ctx, requestID, userID, actorIDs, isAdmin, reason
+func (_m *RequestService) Reject(ctx context.Context, requestID string, userID string, actorIDs []string, isAdmin bool, reason string) (*domain.Request, error) {
+	ret := _m.Called(ctx, requestID, userID, actorIDs, isAdmin, reason)
 
 	var r0 *domain.Request
-	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, bool, string) *domain.Request); ok {
-		r0 = rf(ctx, requestID, userID, actorID, isAdmin, reason)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, []string, bool, string) *domain.Request); ok {
+		r0 = rf(ctx, requestID, userID, actorIDs, isAdmin, reason)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*domain.Request)
@@ -317,8 +478,8 @@ func (_m *RequestService) Reject(ctx context.Context, requestID string, userID s
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(context.Context, string, string, string, bool, string) error); ok {
-		r1 = rf(ctx, requestID, userID, actorID, isAdmin, reason)
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, []string, bool, string) error); ok {
+		r1 = rf(ctx, requestID, userID, actorIDs, isAdmin, reason)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -335,16 +496,16 @@ type RequestService_Reject_Call struct {
 //  - ctx context.Context
 //  - requestID string
 //  - userID string
-//  - actorID string
+//  - actorIDs []string
 //  - isAdmin bool
 //  - reason string
-func (_e *RequestService_Expecter) Reject(ctx interface{}, requestID interface{}, userID interface{}, actorID interface{}, isAdmin interface{}, reason interface{}) *RequestService_Reject_Call {
-	return &RequestService_Reject_Call{Call: _e.mock.On("Reject", ctx, requestID, userID, actorID, isAdmin, reason)}
+func (_e *RequestService_Expecter) Reject(ctx interface{}, requestID interface{}, userID interface{}, actorIDs interface{}, isAdmin interface{}, reason interface{}) *RequestService_Reject_Call {
+	return &RequestService_Reject_Call{Call: _e.mock.On("Reject", ctx, requestID, userID, actorIDs, isAdmin, reason)}
 }
 
-func (_c *RequestService_Reject_Call) Run(run func(ctx context.Context, requestID string, userID string, actorID string, isAdmin bool, reason string)) *RequestService_Reject_Call {
+func (_c *RequestService_Reject_Call) Run(run func(ctx context.Context, requestID string, userID string, actorIDs []string, isAdmin bool, reason string)) *RequestService_Reject_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(string), args[4].(bool), args[5].(string))
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].([]string), args[4].(bool), args[5].(string))
 	})
 	return _c
 }
@@ -354,13 +515,112 @@ func (_c *RequestService_Reject_Call) Return(_a0 *domain.Request, _a1 error) *Re
 	return _c
 }
 
-// UpdateRequest provides a mock function with given fields: ctx, id, amount, title, description
-func (_m *RequestService) UpdateRequest(ctx context.Context, id string, amount float64, title string, description string) (*domain.Request, error) {
-	ret := _m.Called(ctx, id, amount, title, description)
+// Simulate provides a mock function with given fields: ctx, workflowID, requesterID, amount, currency, fields
+func (_m *RequestService) Simulate(ctx context.Context, workflowID string, requesterID string, amount money.Decimal, currency string, fields domain.RequestFields) (*domain.Simulation, error) {
+	ret := _m.Called(ctx, workflowID, requesterID, amount, currency, fields)
+
+	var r0 *domain.Simulation
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, money.Decimal, string, domain.RequestFields) *domain.Simulation); ok {
+		r0 = rf(ctx, workflowID, requesterID, amount, currency, fields)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Simulation)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, money.Decimal, string, domain.RequestFields) error); ok {
+		r1 = rf(ctx, workflowID, requesterID, amount, currency, fields)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RequestService_Simulate_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Simulate'
+type RequestService_Simulate_Call struct {
+	*mock.Call
+}
+
+// Simulate is a helper method to define mock.On call
+//  - ctx context.Context
+//  - workflowID string
+//  - requesterID string
+//  - amount money.Decimal
+//  - currency string
+//  - fields domain.RequestFields
+func (_e *RequestService_Expecter) Simulate(ctx interface{}, workflowID interface{}, requesterID interface{}, amount interface{}, currency interface{}, fields interface{}) *RequestService_Simulate_Call {
+	return &RequestService_Simulate_Call{Call: _e.mock.On("Simulate", ctx, workflowID, requesterID, amount, currency, fields)}
+}
+
+func (_c *RequestService_Simulate_Call) Run(run func(ctx context.Context, workflowID string, requesterID string, amount money.Decimal, currency string, fields domain.RequestFields)) *RequestService_Simulate_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(money.Decimal), args[4].(string), args[5].(domain.RequestFields))
+	})
+	return _c
+}
+
+func (_c *RequestService_Simulate_Call) Return(_a0 *domain.Simulation, _a1 error) *RequestService_Simulate_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+// SubmitRequest provides a mock function with given fields: ctx, id, userID
+func (_m *RequestService) SubmitRequest(ctx context.Context, id string, userID string) (*domain.Request, error) {
+	ret := _m.Called(ctx, id, userID)
+
+	var r0 *domain.Request
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) *domain.Request); ok {
+		r0 = rf(ctx, id, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Request)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, id, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RequestService_SubmitRequest_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SubmitRequest'
+type RequestService_SubmitRequest_Call struct {
+	*mock.Call
+}
+
+// SubmitRequest is a helper method to define mock.On call
+//  - ctx context.Context
+//  - id string
+//  - userID string
+func (_e *RequestService_Expecter) SubmitRequest(ctx interface{}, id interface{}, userID interface{}) *RequestService_SubmitRequest_Call {
+	return &RequestService_SubmitRequest_Call{Call: _e.mock.On("SubmitRequest", ctx, id, userID)}
+}
+
+func (_c *RequestService_SubmitRequest_Call) Run(run func(ctx context.Context, id string, userID string)) *RequestService_SubmitRequest_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *RequestService_SubmitRequest_Call) Return(_a0 *domain.Request, _a1 error) *RequestService_SubmitRequest_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+// UpdateRequest provides a mock function with given fields: ctx, id, amount, currency, title, description, fields
+func (_m *RequestService) UpdateRequest(ctx context.Context, id string, amount money.Decimal, currency string, title string, description string, fields domain.RequestFields) (*domain.Request, error) {
+	ret := _m.Called(ctx, id, amount, currency, title, description, fields)
 
 	var r0 *domain.Request
-	if rf, ok := ret.Get(0).(func(context.Context, string, float64, string, string) *domain.Request); ok {
-		r0 = rf(ctx, id, amount, title, description)
+	if rf, ok := ret.Get(0).(func(context.Context, string, money.Decimal, string, string, string, domain.RequestFields) *domain.Request); ok {
+		r0 = rf(ctx, id, amount, currency, title, description, fields)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*domain.Request)
@@ -368,8 +628,8 @@ func (_m *RequestService) UpdateRequest(ctx context.Context, id string, amount f
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(context.Context, string, float64, string, string) error); ok {
-		r1 = rf(ctx, id, amount, title, description)
+	if rf, ok := ret.Get(1).(func(context.Context, string, money.Decimal, string, string, string, domain.RequestFields) error); ok {
+		r1 = rf(ctx, id, amount, currency, title, description, fields)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -385,16 +645,18 @@ type RequestService_UpdateRequest_Call struct {
 // UpdateRequest is a helper method to define mock.On call
 //  - ctx context.Context
 //  - id string
-//  - amount float64
+//  - amount money.Decimal
+//  - currency string
 //  - title string
 //  - description string
-func (_e *RequestService_Expecter) UpdateRequest(ctx interface{}, id interface{}, amount interface{}, title interface{}, description interface{}) *RequestService_UpdateRequest_Call {
-	return &RequestService_UpdateRequest_Call{Call: _e.mock.On("UpdateRequest", ctx, id, amount, title, description)}
+//  - fields domain.RequestFields
+func (_e *RequestService_Expecter) UpdateRequest(ctx interface{}, id interface{}, amount interface{}, currency interface{}, title interface{}, description interface{}, fields interface{}) *RequestService_UpdateRequest_Call {
+	return &RequestService_UpdateRequest_Call{Call: _e.mock.On("UpdateRequest", ctx, id, amount, currency, title, description, fields)}
 }
 
-func (_c *RequestService_UpdateRequest_Call) Run(run func(ctx context.Context, id string, amount float64, title string, description string)) *RequestService_UpdateRequest_Call {
+func (_c *RequestService_UpdateRequest_Call) Run(run func(ctx context.Context, id string, amount money.Decimal, currency string, title string, description string, fields domain.RequestFields)) *RequestService_UpdateRequest_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(string), args[2].(float64), args[3].(string), args[4].(string))
+		run(args[0].(context.Context), args[1].(string), args[2].(money.Decimal), args[3].(string), args[4].(string), args[5].(string), args[6].(domain.RequestFields))
 	})
 	return _c
 }
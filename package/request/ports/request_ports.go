@@ -2,8 +2,13 @@ package ports
 
 import (
 	"context"
+	"time"
 
 	"workflow-approval/package/request/domain"
+	stepDomain "workflow-approval/package/workflow_step/domain"
+	valDomain "workflow-approval/package/workflow_validation/domain"
+	"workflow-approval/utils/money"
+	"workflow-approval/utils/pagination"
 )
 
 // RequestRepository defines the interface for request data access
@@ -14,21 +19,74 @@ type RequestRepository interface {
 	GetByID(ctx context.Context, id string) (*domain.Request, error)
 	Update(ctx context.Context, request *domain.Request) error
 	Delete(ctx context.Context, id string) error
-	List(ctx context.Context, page, limit int, status *domain.RequestStatus) ([]*domain.Request, int64, error)
+	// List returns a page of requests matching the filter; drafts are only listed for their requester, filter.ViewerID
+	List(ctx context.Context, filter domain.RequestFilter, page pagination.Page) ([]*domain.Request, pagination.Result, error)
+	// ListDraftsUpdatedBefore returns up to limit drafts last changed before the given time, oldest first
+	ListDraftsUpdatedBefore(ctx context.Context, before time.Time, limit int) ([]*domain.Request, error)
+	// CountPendingByWorkflow counts pending requests per workflow ID
+	CountPendingByWorkflow(ctx context.Context) (map[string]int64, error)
 	GetByIDForUpdate(ctx context.Context, id string) (*domain.Request, error) // For transaction locking
 }
 
+// ApproverResolver finds the approver of a step for a request (implemented by the organization package)
+type ApproverResolver interface {
+	// Resolve returns the assignee type with either an actor or a user; resolution
+	// failures wrap domain.ErrApproverUnresolved
+	Resolve(ctx context.Context, request *domain.Request, step *stepDomain.WorkflowStep) (*domain.StepApprover, error)
+}
+
+// WorkflowValidator checks that a workflow can route requests before one is
+// created against it (implemented by the workflow_validation package)
+type WorkflowValidator interface {
+	Validate(ctx context.Context, workflowID string) (*valDomain.Report, error)
+}
+
+// ExchangeRateProvider converts request amounts into the base currency that
+// step thresholds are expressed in (implemented by the exchange_rate package)
+type ExchangeRateProvider interface {
+	BaseCurrency() money.Currency
+	// RateFor returns the current rate of currency into the base currency; a
+	// currency without one wraps domain.ErrExchangeRateMissing
+	RateFor(ctx context.Context, currency money.Currency) (money.Rate, error)
+}
+
+// RequestPurgeHook removes data kept alongside a request, such as its attachments, before the request is purged
+type RequestPurgeHook interface {
+	PurgeRequest(ctx context.Context, requestID string) error
+}
+
 // RequestService defines the interface for request business logic
 //
 //go:generate mockery --with-expecter --name=RequestService --output=mocks --filename=RequestService.go
 type RequestService interface {
-	CreateRequest(ctx context.Context, workflowID, requesterID string, amount float64, title, description string) (*domain.Request, error)
+	// CreateRequest validates fields against the workflow's form schema; invalid values return wfDomain.FieldErrors.
+	// Workflows failing validation are refused with a *valDomain.InvalidError.
+	// currency is an ISO-4217 code, empty for the base currency; the amount is converted at the current rate.
+	CreateRequest(ctx context.Context, workflowID, requesterID string, amount money.Decimal, currency, title, description string, fields domain.RequestFields) (*domain.Request, error)
+	// CreateDraft saves a DRAFT request; required fields may be missing and no approver is resolved until submission
+	CreateDraft(ctx context.Context, workflowID, requesterID string, amount money.Decimal, currency, title, description string, fields domain.RequestFields) (*domain.Request, error)
+	// SubmitRequest moves the requester's draft to PENDING after validating and converting it like CreateRequest,
+	// and refuses workflows failing validation the same way
+	SubmitRequest(ctx context.Context, id, userID string) (*domain.Request, error)
 	GetRequest(ctx context.Context, id string) (*domain.Request, error)
-	ListRequests(ctx context.Context, page, limit int, status *domain.RequestStatus) ([]*domain.Request, int64, error)
-	Approve(ctx context.Context, requestID, userID, actorID string, isAdmin bool) (*domain.Request, error)
-	Reject(ctx context.Context, requestID, userID, actorID string, isAdmin bool, reason string) (*domain.Request, error)
-	UpdateRequest(ctx context.Context, id string, amount float64, title, description string) (*domain.Request, error)
+	// ListRequests lists requests matching the filter; drafts only to their requester, filter.ViewerID.
+	// Paging by cursor requires the default sort or created_at alone.
+	ListRequests(ctx context.Context, filter domain.RequestFilter, page pagination.Page) ([]*domain.Request, pagination.Result, error)
+	// Approve approves the current step when the step's actor is one of actorIDs (the caller's actors);
+	// mfaAt is when the approver last passed a second factor (nil if never)
+	Approve(ctx context.Context, requestID, userID string, actorIDs []string, isAdmin bool, mfaAt *time.Time) (*domain.Request, error)
+	Reject(ctx context.Context, requestID, userID string, actorIDs []string, isAdmin bool, reason string) (*domain.Request, error)
+	// BulkApprove approves up to 100 requests one by one as Approve does, recording comment in each history.
+	// Refusals are reported per request; only an empty or oversized list is an error.
+	BulkApprove(ctx context.Context, requestIDs []string, userID string, actorIDs []string, isAdmin bool, mfaAt *time.Time, comment string) ([]domain.BulkResult, error)
+	// BulkReject rejects up to 100 requests one by one as Reject does, with a shared reason
+	BulkReject(ctx context.Context, requestIDs []string, userID string, actorIDs []string, isAdmin bool, reason string) ([]domain.BulkResult, error)
+	// UpdateRequest changes a draft or pending request; nil fields and an empty currency keep the current ones
+	UpdateRequest(ctx context.Context, id string, amount money.Decimal, currency, title, description string, fields domain.RequestFields) (*domain.Request, error)
 	DeleteRequest(ctx context.Context, id string) error
+	// Simulate routes a hypothetical request through a workflow as CreateRequest and Approve would, without saving anything.
+	// Invalid input fails as it does for CreateRequest; levels without an approver or with failing conditions are reported, not returned as errors.
+	Simulate(ctx context.Context, workflowID, requesterID string, amount money.Decimal, currency string, fields domain.RequestFields) (*domain.Simulation, error)
 
 	// LockRequest acquires a mutex lock for the given request ID to prevent concurrent approval operations.
 	// Returns a function that must be called to release the lock (defer it).
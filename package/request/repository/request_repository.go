@@ -3,6 +3,8 @@ package repository
 import (
 	"context"
 	"errors"
+	"strings"
+	"time"
 
 	"gorm.io/gorm"
 	"gorm.io/gorm/clause"
@@ -10,9 +12,17 @@ import (
 	"workflow-approval/package/request/domain"
 	"workflow-approval/package/request/ports"
 	"workflow-approval/utils"
+	"workflow-approval/utils/metrics"
+	"workflow-approval/utils/pagination"
 )
 
-var ErrRequestNotFound = errors.New("request not found")
+var (
+	ErrRequestNotFound = errors.New("request not found")
+	ErrVersionConflict = errors.New("optimistic lock error: request was modified by another transaction")
+)
+
+var versionConflicts = metrics.Default.NewCounterVec("approval_request_version_conflicts_total",
+	"Request updates refused because the request was modified concurrently.")
 
 // RequestRepositoryImpl implements RequestRepository interface with optimistic locking
 type RequestRepositoryImpl struct {
@@ -58,21 +68,27 @@ func (r *RequestRepositoryImpl) GetByIDForUpdate(ctx context.Context, id string)
 	return &request, nil
 }
 
-// Update updates a request with optimistic locking
-// Uses version field to prevent concurrent updates
+// Update updates a request with optimistic locking. Callers increment
+// Version before calling, so the row is only written while it still holds the
+// version they read; otherwise another writer got there first.
 func (r *RequestRepositoryImpl) Update(ctx context.Context, request *domain.Request) error {
 	request.UpdatedAt = utils.TimeNowUTC()
 
+	// Save would fall back to an upsert when no row matches, overwriting the
+	// other writer's changes, so update the matching row only
 	result := r.db.WithContext(ctx).
-		Where("id = ? AND version = ?", request.ID, request.Version).
-		Save(request)
+		Model(&domain.Request{}).
+		Where("id = ? AND version = ?", request.ID, request.Version-1).
+		Select("*").
+		Updates(request)
 
 	if result.Error != nil {
 		return result.Error
 	}
 
 	if result.RowsAffected == 0 {
-		return errors.New("optimistic lock error: request was modified by another transaction")
+		versionConflicts.With().Inc()
+		return ErrVersionConflict
 	}
 
 	return nil
@@ -87,32 +103,189 @@ func (r *RequestRepositoryImpl) Delete(ctx context.Context, id string) error {
 	return nil
 }
 
-// List retrieves a paginated list of requests
-func (r *RequestRepositoryImpl) List(ctx context.Context, page, limit int, status *domain.RequestStatus) ([]*domain.Request, int64, error) {
+// List retrieves a page of requests matching the filter. Listings sorted by
+// creation time only are paged by cursor; other sorts only by offset.
+// Drafts are private to their requester and only listed for the viewer's own.
+func (r *RequestRepositoryImpl) List(ctx context.Context, filter domain.RequestFilter, page pagination.Page) ([]*domain.Request, pagination.Result, error) {
 	var requests []*domain.Request
 	var total int64
 
-	offset := (page - 1) * limit
-
 	query := r.db.WithContext(ctx).Model(&domain.Request{})
 
-	if status != nil {
-		query = query.Where("status = ?", *status)
+	if filter.Status != nil {
+		query = query.Where("status = ?", *filter.Status)
+	}
+
+	query = query.Where("(status <> ? OR requester_id = ?)", domain.StatusDraft, filter.ViewerID)
+
+	if len(filter.WorkflowIDs) > 0 {
+		query = query.Where("workflow_id IN ?", filter.WorkflowIDs)
+	}
+	if filter.WorkflowID != "" {
+		query = query.Where("workflow_id = ?", filter.WorkflowID)
+	}
+	if filter.RequesterID != "" {
+		query = query.Where("requester_id = ?", filter.RequesterID)
+	}
+	if filter.CurrentStep > 0 {
+		query = query.Where("current_step = ?", filter.CurrentStep)
+	}
+	if filter.ActorID != "" {
+		// The approver resolved for the current step decides; requests without
+		// one fall back to the step's actor, as approvals do
+		query = query.Where("status = ?", domain.StatusPending).Where(
+			"(JSON_CONTAINS(approvers, JSON_OBJECT('level', current_step, 'actor_id', ?)) OR "+
+				"(NOT JSON_CONTAINS(COALESCE(NULLIF(approvers, ''), '[]'), JSON_OBJECT('level', current_step)) AND "+
+				"EXISTS (SELECT 1 FROM workflow_steps ws WHERE ws.workflow_id = requests.workflow_id AND ws.level = requests.current_step AND ws.actor_id = ?)))",
+			filter.ActorID, filter.ActorID)
+	}
+	if filter.MinAmount != nil {
+		query = query.Where("base_amount >= ?", *filter.MinAmount)
+	}
+	if filter.MaxAmount != nil {
+		query = query.Where("base_amount <= ?", *filter.MaxAmount)
+	}
+	if filter.CreatedFrom != nil {
+		query = query.Where("created_at >= ?", *filter.CreatedFrom)
+	}
+	if filter.CreatedTo != nil {
+		query = query.Where("created_at < ?", *filter.CreatedTo)
+	}
+	if filter.UpdatedFrom != nil {
+		query = query.Where("updated_at >= ?", *filter.UpdatedFrom)
+	}
+	if filter.UpdatedTo != nil {
+		query = query.Where("updated_at < ?", *filter.UpdatedTo)
+	}
+
+	for name, value := range filter.Fields {
+		query = query.Where("JSON_UNQUOTE(JSON_EXTRACT(fields, ?)) = ?", "$."+name, value)
+	}
+
+	if filter.Query != "" {
+		condition, args := r.searchCondition(filter.Query)
+		query = query.Where(condition, args...)
+	}
+
+	if page.WithTotal {
+		if err := query.Count(&total).Error; err != nil {
+			return nil, pagination.Result{}, err
+		}
+	}
+
+	desc, keyset := filter.KeysetOrder()
+	if keyset {
+		query = pagination.Apply(query, page, desc)
+	} else {
+		query = query.Order(orderBy(filter.Sort)).Offset(page.Offset()).Limit(page.Limit + 1)
+	}
+	if err := query.Find(&requests).Error; err != nil {
+		return nil, pagination.Result{}, err
+	}
+
+	var result pagination.Result
+	if keyset {
+		requests, result = pagination.Finish(requests, page, requestKey)
+	} else {
+		requests, result = pagination.Trimmed(requests, page)
+	}
+	result.Total = total
+	return requests, result, nil
+}
+
+func requestKey(r *domain.Request) (time.Time, string) {
+	return r.CreatedAt, r.ID
+}
+
+// searchCondition matches the terms of q against title and description. MySQL
+// and MariaDB use the FULLTEXT index, PostgreSQL its text search; other
+// databases require every term to appear in either column.
+func (r *RequestRepositoryImpl) searchCondition(q string) (string, []interface{}) {
+	switch r.db.Dialector.Name() {
+	case "mysql":
+		return "MATCH(title, description) AGAINST (? IN NATURAL LANGUAGE MODE)", []interface{}{q}
+	case "postgres":
+		return "to_tsvector('simple', COALESCE(title, '') || ' ' || COALESCE(description, '')) @@ plainto_tsquery('simple', ?)", []interface{}{q}
+	}
+
+	var conditions []string
+	var args []interface{}
+	for _, term := range strings.Fields(strings.ToLower(q)) {
+		pattern := "%" + escapeLike(term) + "%"
+		conditions = append(conditions, `(LOWER(title) LIKE ? ESCAPE '\' OR LOWER(description) LIKE ? ESCAPE '\')`)
+		args = append(args, pattern, pattern)
 	}
+	return strings.Join(conditions, " AND "), args
+}
+
+// sortColumns maps sortable fields to their columns; amounts sort in the base currency
+var sortColumns = map[string]string{
+	"created_at":   "created_at",
+	"updated_at":   "updated_at",
+	"submitted_at": "submitted_at",
+	"amount":       "base_amount",
+	"title":        "title",
+	"status":       "status",
+	"current_step": "current_step",
+}
 
-	// Get total count
-	if err := query.Count(&total).Error; err != nil {
-		return nil, 0, err
+// orderBy builds the ORDER BY clause of a listing sorted by other fields than
+// the creation time. The ID breaks ties in the direction of the last field so
+// pages don't overlap when sort values repeat.
+func orderBy(sort []domain.SortField) string {
+	clauses := make([]string, 0, len(sort)+1)
+	tieBreak := "id"
+	for _, f := range sort {
+		column, ok := sortColumns[f.Field]
+		if !ok {
+			continue
+		}
+		tieBreak = "id"
+		if f.Desc {
+			column, tieBreak = column+" DESC", "id DESC"
+		}
+		clauses = append(clauses, column)
 	}
+	return strings.Join(append(clauses, tieBreak), ", ")
+}
 
-	// Get paginated results
-	if err := query.
-		Order("created_at DESC").
-		Offset(offset).
+// escapeLike escapes the wildcards of a LIKE pattern
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(s)
+}
+
+// ListDraftsUpdatedBefore retrieves drafts not updated since before, oldest first
+func (r *RequestRepositoryImpl) ListDraftsUpdatedBefore(ctx context.Context, before time.Time, limit int) ([]*domain.Request, error) {
+	var requests []*domain.Request
+	result := r.db.WithContext(ctx).
+		Where("status = ? AND updated_at < ?", domain.StatusDraft, before).
+		Order("updated_at ASC").
 		Limit(limit).
-		Find(&requests).Error; err != nil {
-		return nil, 0, err
+		Find(&requests)
+	if result.Error != nil {
+		return nil, result.Error
 	}
+	return requests, nil
+}
 
-	return requests, total, nil
+// CountPendingByWorkflow counts pending requests per workflow ID
+func (r *RequestRepositoryImpl) CountPendingByWorkflow(ctx context.Context) (map[string]int64, error) {
+	var rows []struct {
+		WorkflowID string
+		Count      int64
+	}
+	result := r.db.WithContext(ctx).Model(&domain.Request{}).
+		Select("workflow_id, COUNT(*) AS count").
+		Where("status = ?", domain.StatusPending).
+		Group("workflow_id").
+		Scan(&rows)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	counts := make(map[string]int64, len(rows))
+	for _, row := range rows {
+		counts[row.WorkflowID] = row.Count
+	}
+	return counts, nil
 }
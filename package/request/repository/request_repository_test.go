@@ -0,0 +1,178 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"strings"
+	"sync"
+	"testing"
+
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+
+	"workflow-approval/package/request/domain"
+	"workflow-approval/utils/money"
+	"workflow-approval/utils/tenancy"
+)
+
+// execRecorder backs a database/sql driver that records the statements it runs
+// and reports rowsAffected for each of them; queries return no rows
+type execRecorder struct {
+	mu           sync.Mutex
+	rowsAffected int64
+	statements   []recordedExec
+}
+
+type recordedExec struct {
+	query string
+	args  []driver.NamedValue
+}
+
+func (d *execRecorder) Open(name string) (driver.Conn, error) {
+	return &recorderConn{d: d}, nil
+}
+
+type recorderConn struct{ d *execRecorder }
+
+func (c *recorderConn) Prepare(query string) (driver.Stmt, error) {
+	return nil, errors.New("prepare not supported")
+}
+
+func (c *recorderConn) Close() error { return nil }
+
+func (c *recorderConn) Begin() (driver.Tx, error) { return c, nil }
+
+func (c *recorderConn) Commit() error { return nil }
+
+func (c *recorderConn) Rollback() error { return nil }
+
+func (c *recorderConn) ExecContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	c.d.mu.Lock()
+	defer c.d.mu.Unlock()
+	c.d.statements = append(c.d.statements, recordedExec{query: query, args: args})
+	return driver.RowsAffected(c.d.rowsAffected), nil
+}
+
+func (c *recorderConn) QueryContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	return emptyRows{}, nil
+}
+
+type emptyRows struct{}
+
+func (emptyRows) Columns() []string              { return nil }
+func (emptyRows) Close() error                   { return nil }
+func (emptyRows) Next(dest []driver.Value) error { return io.EOF }
+
+var registerRecorder sync.Once
+
+// newRecorderDB returns a GORM DB whose statements go to a fresh execRecorder
+func newRecorderDB(t *testing.T) (*gorm.DB, *execRecorder) {
+	t.Helper()
+	recorder := &execRecorder{}
+	registerRecorder.Do(func() {
+		sql.Register("request-repository-recorder", &recorderConnector{})
+	})
+	recorders.Store(t.Name(), recorder)
+
+	sqlDB, err := sql.Open("request-repository-recorder", t.Name())
+	if err != nil {
+		t.Fatalf("failed to open recorder: %v", err)
+	}
+	db, err := gorm.Open(mysql.New(mysql.Config{Conn: sqlDB, SkipInitializeWithVersion: true}), &gorm.Config{
+		DisableAutomaticPing: true,
+		Logger:               logger.Default.LogMode(logger.Silent),
+	})
+	if err != nil {
+		t.Fatalf("failed to open DB: %v", err)
+	}
+	if err := db.Use(tenancy.Plugin{}); err != nil {
+		t.Fatalf("failed to register tenancy plugin: %v", err)
+	}
+	return db, recorder
+}
+
+// recorders maps a DSN (the test name) to the recorder of that test
+var recorders sync.Map
+
+type recorderConnector struct{}
+
+func (recorderConnector) Open(name string) (driver.Conn, error) {
+	recorder, ok := recorders.Load(name)
+	if !ok {
+		return nil, errors.New("no recorder for " + name)
+	}
+	return recorder.(*execRecorder).Open(name)
+}
+
+func TestUpdateUsesReadVersion(t *testing.T) {
+	db, recorder := newRecorderDB(t)
+	repo := NewRequestRepository(db)
+	ctx := tenancy.WithTenant(context.Background(), "tenant-a")
+
+	request := domain.NewRequest("wf-1", "user-1", money.NewDecimal(1000), "IDR", "Laptop", "", nil)
+	request.ID = "req-1"
+	request.Version = 4 // Read at version 3 and incremented by the caller
+	recorder.rowsAffected = 1
+
+	if err := repo.Update(ctx, request); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(recorder.statements) != 1 {
+		t.Fatalf("Expected a single statement, got %d", len(recorder.statements))
+	}
+	stmt := recorder.statements[0]
+	if !strings.HasPrefix(stmt.query, "UPDATE `requests` SET") {
+		t.Fatalf("Expected an UPDATE, got %q", stmt.query)
+	}
+	if !strings.Contains(stmt.query, "WHERE (id = ? AND version = ?)") {
+		t.Errorf("Expected the version guard, got %q", stmt.query)
+	}
+	if !hasArgs(stmt.args, "req-1", int64(3), "tenant-a") {
+		t.Errorf("Expected id req-1, read version 3 and tenant-a bound, got %v", stmt.args)
+	}
+}
+
+func TestUpdateReportsVersionConflict(t *testing.T) {
+	db, recorder := newRecorderDB(t)
+	repo := NewRequestRepository(db)
+	ctx := tenancy.WithTenant(context.Background(), "tenant-a")
+
+	request := domain.NewRequest("wf-1", "user-1", money.NewDecimal(1000), "IDR", "Laptop", "", nil)
+	request.ID = "req-1"
+	request.Version = 2
+	recorder.rowsAffected = 0 // Another writer already moved the row past version 1
+
+	before := versionConflicts.With().Value()
+	if err := repo.Update(ctx, request); !errors.Is(err, ErrVersionConflict) {
+		t.Fatalf("Expected ErrVersionConflict, got %v", err)
+	}
+	if got := versionConflicts.With().Value() - before; got != 1 {
+		t.Errorf("Expected the conflict counted once, got %v", got)
+	}
+	for _, stmt := range recorder.statements {
+		if strings.HasPrefix(stmt.query, "INSERT") {
+			t.Errorf("Expected no upsert fallback, got %q", stmt.query)
+		}
+	}
+}
+
+// hasArgs checks that every want value is among the bound arguments
+func hasArgs(args []driver.NamedValue, want ...interface{}) bool {
+	for _, w := range want {
+		found := false
+		for _, arg := range args {
+			if arg.Value == w {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
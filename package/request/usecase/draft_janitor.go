@@ -0,0 +1,108 @@
+package usecase
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+
+	reqPorts "workflow-approval/package/request/ports"
+)
+
+// draftPurgeBatch is how many stale drafts are loaded at a time
+const draftPurgeBatch = 100
+
+// DraftJanitor deletes drafts that have not been changed for longer than the
+// configured period, together with their attachments and comments
+type DraftJanitor struct {
+	requestRepo reqPorts.RequestRepository
+	hooks       []reqPorts.RequestPurgeHook
+	maxAge      time.Duration
+	interval    time.Duration
+
+	stop     chan struct{}
+	stopOnce sync.Once
+}
+
+// NewDraftJanitor creates a new DraftJanitor instance; hooks run for each draft before it is deleted
+func NewDraftJanitor(requestRepo reqPorts.RequestRepository, maxAge, interval time.Duration, hooks ...reqPorts.RequestPurgeHook) *DraftJanitor {
+	return &DraftJanitor{
+		requestRepo: requestRepo,
+		hooks:       hooks,
+		maxAge:      maxAge,
+		interval:    interval,
+		stop:        make(chan struct{}),
+	}
+}
+
+// Start purges stale drafts every interval until Stop is called; ctx must
+// reach every tenant's drafts. A zero maxAge keeps drafts forever.
+func (j *DraftJanitor) Start(ctx context.Context) {
+	if j.maxAge <= 0 || j.interval <= 0 {
+		return
+	}
+
+	go func() {
+		ticker := time.NewTicker(j.interval)
+		defer ticker.Stop()
+		for {
+			if purged, err := j.PurgeStale(ctx, time.Now().UTC()); err != nil {
+				log.Printf("Warning: stale draft cleanup failed: %v", err)
+			} else if purged > 0 {
+				log.Printf("Deleted %d stale draft request(s)", purged)
+			}
+
+			select {
+			case <-j.stop:
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+// Stop stops scheduled cleanup
+func (j *DraftJanitor) Stop() {
+	j.stopOnce.Do(func() { close(j.stop) })
+}
+
+// PurgeStale deletes the drafts last changed more than maxAge before now and
+// returns how many were deleted. A draft whose hooks fail is kept for the next run.
+func (j *DraftJanitor) PurgeStale(ctx context.Context, now time.Time) (int, error) {
+	cutoff := now.Add(-j.maxAge)
+	purged := 0
+	skipped := make(map[string]bool)
+
+	for {
+		// Skipped drafts are still stale, so leave room for them in the batch
+		limit := draftPurgeBatch + len(skipped)
+		drafts, err := j.requestRepo.ListDraftsUpdatedBefore(ctx, cutoff, limit)
+		if err != nil {
+			return purged, err
+		}
+
+		progress := false
+	drafts:
+		for _, draft := range drafts {
+			if skipped[draft.ID] {
+				continue
+			}
+			for _, hook := range j.hooks {
+				if err := hook.PurgeRequest(ctx, draft.ID); err != nil {
+					log.Printf("Warning: failed to clean up draft %s: %v", draft.ID, err)
+					skipped[draft.ID] = true
+					continue drafts
+				}
+			}
+			if err := j.requestRepo.Delete(ctx, draft.ID); err != nil {
+				return purged, err
+			}
+			purged++
+			progress = true
+		}
+
+		if !progress || len(drafts) < limit {
+			return purged, nil
+		}
+	}
+}
@@ -0,0 +1,70 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	reqDomain "workflow-approval/package/request/domain"
+)
+
+// MaxBulkRequests is the most requests one bulk approval or rejection may list
+const MaxBulkRequests = 100
+
+var ErrBulkRequestCount = errors.New("bulk actions need between 1 and 100 request IDs")
+
+// BulkApprove approves each request in turn through Approve's locking and
+// checks, recording comment in each approval history. A refused request only
+// fails its own result. Repeated IDs are acted on once, so a batch can't
+// approve two levels of the same request.
+func (s *RequestServiceImpl) BulkApprove(ctx context.Context, requestIDs []string, userID string, actorIDs []string, isAdmin bool, mfaAt *time.Time, comment string) ([]reqDomain.BulkResult, error) {
+	return bulk(requestIDs, func(id string) (*reqDomain.Request, error) {
+		return s.approve(ctx, id, userID, actorIDs, isAdmin, mfaAt, comment)
+	})
+}
+
+// BulkReject rejects each request in turn as Reject does, with reason as the
+// shared rejection reason
+func (s *RequestServiceImpl) BulkReject(ctx context.Context, requestIDs []string, userID string, actorIDs []string, isAdmin bool, reason string) ([]reqDomain.BulkResult, error) {
+	return bulk(requestIDs, func(id string) (*reqDomain.Request, error) {
+		return s.Reject(ctx, id, userID, actorIDs, isAdmin, reason)
+	})
+}
+
+func bulk(requestIDs []string, act func(id string) (*reqDomain.Request, error)) ([]reqDomain.BulkResult, error) {
+	if len(requestIDs) == 0 || len(requestIDs) > MaxBulkRequests {
+		return nil, ErrBulkRequestCount
+	}
+
+	seen := make(map[string]bool, len(requestIDs))
+	results := make([]reqDomain.BulkResult, 0, len(requestIDs))
+	for _, id := range requestIDs {
+		if seen[id] {
+			continue
+		}
+		seen[id] = true
+
+		request, err := act(id)
+		results = append(results, reqDomain.BulkResult{RequestID: id, Status: bulkStatus(err), Err: err, Request: request})
+	}
+	return results, nil
+}
+
+func bulkStatus(err error) reqDomain.BulkStatus {
+	switch {
+	case err == nil:
+		return reqDomain.BulkOK
+	case errors.Is(err, ErrRequestNotFound):
+		return reqDomain.BulkNotFound
+	case errors.Is(err, ErrRequestNotPending):
+		return reqDomain.BulkNotPending
+	case errors.Is(err, ErrUnauthorizedActor):
+		return reqDomain.BulkUnauthorized
+	case errors.Is(err, ErrStepUpRequired):
+		return reqDomain.BulkStepUpRequired
+	case errors.Is(err, ErrRequestConflict):
+		return reqDomain.BulkConflict
+	default:
+		return reqDomain.BulkFailed
+	}
+}
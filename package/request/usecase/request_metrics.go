@@ -0,0 +1,65 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	reqDomain "workflow-approval/package/request/domain"
+	reqPorts "workflow-approval/package/request/ports"
+	"workflow-approval/utils"
+	"workflow-approval/utils/metrics"
+	"workflow-approval/utils/tenancy"
+)
+
+// decisionBuckets span minutes to weeks, in seconds: approvals wait on people
+var decisionBuckets = []float64{60, 300, 900, 3600, 4 * 3600, 24 * 3600, 3 * 24 * 3600, 7 * 24 * 3600, 30 * 24 * 3600}
+
+var (
+	requestsCreated = metrics.Default.NewCounterVec("approval_requests_created_total",
+		"Requests submitted for approval, created directly or from a draft.", "workflow_id")
+	requestsApproved = metrics.Default.NewCounterVec("approval_requests_approved_total",
+		"Requests approved at their last step.", "workflow_id")
+	requestsRejected = metrics.Default.NewCounterVec("approval_requests_rejected_total",
+		"Requests rejected.", "workflow_id")
+	decisionLatency = metrics.Default.NewHistogramVec("approval_request_decision_seconds",
+		"Time from submission to the final approval or rejection.", decisionBuckets, "workflow_id", "outcome")
+	lockWait = metrics.Default.NewHistogramVec("approval_request_lock_wait_seconds",
+		"Time spent waiting for the in-memory lock of a request in LockRequest.", metrics.DurationBuckets)
+	requestsPending = metrics.Default.NewGaugeVec("approval_requests_pending",
+		"Requests waiting for a decision.", "workflow_id")
+)
+
+// recordDecision counts a request approved at its last step or rejected and
+// observes how long it waited since submission
+func recordDecision(request *reqDomain.Request) {
+	counter, outcome := requestsApproved, "approved"
+	if request.Status == reqDomain.StatusRejected {
+		counter, outcome = requestsRejected, "rejected"
+	}
+	counter.With(request.WorkflowID).Inc()
+	if request.SubmittedAt != nil {
+		decisionLatency.With(request.WorkflowID, outcome).
+			Observe(utils.TimeNowUTC().Sub(*request.SubmittedAt).Seconds())
+	}
+}
+
+// PendingRequestsHook returns a scrape hook setting the pending requests gauge
+// from the database, across all tenants
+func PendingRequestsHook(requestRepo reqPorts.RequestRepository) func(ctx context.Context) error {
+	return func(ctx context.Context) error {
+		counts, err := requestRepo.CountPendingByWorkflow(tenancy.WithAllTenants(ctx))
+		if err != nil {
+			return err
+		}
+		requestsPending.Reset()
+		for workflowID, count := range counts {
+			requestsPending.With(workflowID).Set(float64(count))
+		}
+		return nil
+	}
+}
+
+// observeLockWait records how long acquiring a request lock took
+func observeLockWait(start time.Time) {
+	lockWait.With().Observe(time.Since(start).Seconds())
+}
@@ -0,0 +1,187 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+
+	reqDomain "workflow-approval/package/request/domain"
+	wfRepo "workflow-approval/package/workflow/repository"
+	stepDomain "workflow-approval/package/workflow_step/domain"
+	"workflow-approval/utils/money"
+)
+
+// Simulate routes a hypothetical request through a workflow with the checks
+// CreateRequest and Approve make: the request is validated and converted like
+// a new one, a workflow failing validation takes it nowhere, each level's
+// approver is resolved as the request would reach it, and a level whose
+// conditions fail stops the route as Approve would refuse it. Nothing is saved.
+func (s *RequestServiceImpl) Simulate(ctx context.Context, workflowID, requesterID string, amount money.Decimal, currencyCode string, fields reqDomain.RequestFields) (*reqDomain.Simulation, error) {
+	currency, err := s.currencyOf(currencyCode)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkAmount(amount, currency, false); err != nil {
+		return nil, err
+	}
+
+	workflow, err := s.workflowRepo.GetByID(ctx, workflowID)
+	if err != nil {
+		if errors.Is(err, wfRepo.ErrWorkflowNotFound) {
+			return nil, ErrWorkflowNotFound
+		}
+		return nil, err
+	}
+	if err := workflow.FormSchema.Validate(fields); err != nil {
+		return nil, err
+	}
+
+	request := reqDomain.NewRequest(workflowID, requesterID, amount, currency, "", "", fields)
+	if err := s.applyRate(ctx, request); err != nil {
+		return nil, err
+	}
+
+	steps, err := s.workflowStepRepo.GetByWorkflowID(ctx, workflowID)
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(steps, func(i, j int) bool { return steps[i].Level < steps[j].Level })
+
+	simulation := &reqDomain.Simulation{
+		WorkflowID:     workflowID,
+		RequesterID:    requesterID,
+		Amount:         request.Amount,
+		Currency:       request.Currency,
+		ExchangeRate:   request.ExchangeRate,
+		BaseAmount:     request.BaseAmount,
+		StepUpRequired: s.stepUp.Requires(request.BaseAmount),
+		Outcome:        reqDomain.OutcomeApproved,
+		Levels:         make([]reqDomain.SimulatedLevel, 0, len(steps)),
+	}
+	if len(steps) == 0 || steps[0].Level != 1 {
+		simulation.Outcome = reqDomain.OutcomeNoSteps
+	}
+
+	// Requests only move to the next level, so once a level stops the route
+	// or is missing, the levels after it are never reached
+	stopped := ""
+	if simulation.Outcome == reqDomain.OutcomeNoSteps {
+		stopped = "the workflow has no level 1"
+	}
+
+	// CreateRequest refuses requests against a workflow failing validation
+	if s.validator != nil {
+		report, err := s.validator.Validate(ctx, workflowID)
+		if err != nil {
+			return nil, err
+		}
+		simulation.Validation = report
+		if !report.Valid {
+			simulation.Outcome = reqDomain.OutcomeInvalid
+			stopped = "the workflow is not valid, so the request could not be created"
+		}
+	}
+	for _, step := range steps {
+		level := reqDomain.SimulatedLevel{
+			Level:        step.Level,
+			AssigneeType: string(step.Assignee().Type),
+			Conditions:   s.explainConditions(step.Conditions, request),
+		}
+
+		switch {
+		case stopped != "":
+			level.Status, level.Reason = reqDomain.LevelNotReached, stopped
+		case step.Level != request.CurrentStep:
+			level.Status = reqDomain.LevelNotReached
+			level.Reason = fmt.Sprintf("the workflow has no level %d, so the request is approved after level %d", request.CurrentStep, request.CurrentStep-1)
+			stopped = level.Reason
+		default:
+			if err := s.assignApprover(ctx, request, step); err != nil {
+				if !errors.Is(err, reqDomain.ErrApproverUnresolved) {
+					return nil, err
+				}
+				level.Status, level.Reason = reqDomain.LevelUnresolved, err.Error()
+				simulation.Outcome = reqDomain.OutcomeUnroutable
+				stopped = fmt.Sprintf("nobody can approve level %d", step.Level)
+				break
+			}
+			level.Approver = request.Approvers.ForLevel(step.Level)
+
+			if !s.checkCondition(step.Conditions, request.BaseAmount) || !step.Conditions.MatchesFields(request.Fields) {
+				level.Status, level.Reason = reqDomain.LevelBlocked, "the step's conditions do not hold, so approving it would be refused"
+				simulation.Outcome = reqDomain.OutcomeBlocked
+				stopped = fmt.Sprintf("the request is blocked at level %d", step.Level)
+				break
+			}
+			level.Status = reqDomain.LevelHit
+			request.CurrentStep++
+		}
+		simulation.Levels = append(simulation.Levels, level)
+	}
+
+	return simulation, nil
+}
+
+// explainConditions evaluates each condition of a step as Approve does and
+// says why it holds or not. Conditions Approve doesn't check are listed as not
+// enforced so the stored workflow isn't mistaken for the route.
+func (s *RequestServiceImpl) explainConditions(conditions stepDomain.StepConditions, request *reqDomain.Request) []reqDomain.ConditionResult {
+	results := []reqDomain.ConditionResult{}
+
+	base := s.rates.BaseCurrency()
+	if !conditions.MinAmount.IsZero() {
+		result := reqDomain.ConditionResult{
+			Condition: "min_amount",
+			Status:    reqDomain.ConditionMatched,
+			Reason:    fmt.Sprintf("base amount %s %s is at least %s", request.BaseAmount, base, conditions.MinAmount),
+		}
+		if request.BaseAmount.Cmp(conditions.MinAmount) < 0 {
+			result.Status = reqDomain.ConditionNotMatched
+			result.Reason = fmt.Sprintf("base amount %s %s is below %s", request.BaseAmount, base, conditions.MinAmount)
+		}
+		results = append(results, result)
+	}
+	if !conditions.MaxAmount.IsZero() {
+		results = append(results, reqDomain.ConditionResult{
+			Condition: "max_amount",
+			Status:    reqDomain.ConditionNotEnforced,
+			Reason:    "max_amount only bounds the step's amount range for workflow validation",
+		})
+	}
+
+	for _, fc := range conditions.Fields {
+		result := reqDomain.ConditionResult{
+			Condition: fmt.Sprintf("fields.%s %s %s", fc.Field, fc.Operator, conditionValue(fc.Value)),
+			Status:    reqDomain.ConditionMatched,
+		}
+		if !fc.Matches(request.Fields) {
+			result.Status = reqDomain.ConditionNotMatched
+		}
+		if value, ok := request.Fields[fc.Field]; ok {
+			result.Reason = fmt.Sprintf("%s is %s", fc.Field, conditionValue(value))
+		} else {
+			result.Reason = fc.Field + " is not set"
+		}
+		results = append(results, result)
+	}
+
+	if len(conditions.Roles) > 0 {
+		results = append(results, reqDomain.ConditionResult{
+			Condition: "roles",
+			Status:    reqDomain.ConditionNotEnforced,
+			Reason:    "roles are not checked when approving",
+		})
+	}
+	return results
+}
+
+// conditionValue writes a field or condition value as JSON, so strings are quoted
+func conditionValue(v interface{}) string {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprint(v)
+	}
+	return string(b)
+}
@@ -3,7 +3,9 @@ package usecase
 import (
 	"context"
 	"errors"
+	"fmt"
 	"sync"
+	"time"
 
 	approvalHistoryDomain "workflow-approval/package/approval_history/domain"
 	approvalHistoryPorts "workflow-approval/package/approval_history/ports"
@@ -15,18 +17,30 @@ import (
 	stepDomain "workflow-approval/package/workflow_step/domain"
 	stepPorts "workflow-approval/package/workflow_step/ports"
 	stepRepo "workflow-approval/package/workflow_step/repository"
+	"workflow-approval/utils"
+	"workflow-approval/utils/money"
+	"workflow-approval/utils/pagination"
 )
 
 var (
 	ErrRequestAmountRequired = errors.New("request amount is required")
 	ErrRequestAmountPositive = errors.New("request amount must be greater than 0")
+	ErrRequestAmountNegative = errors.New("request amount cannot be negative")
+	ErrAmountPrecision       = errors.New("request amount has more decimal places than its currency allows")
+	ErrInvalidCurrency       = errors.New("currency must be an ISO-4217 code")
 	ErrRequestNotPending     = errors.New("request is not in pending status")
+	ErrRequestNotDraft       = errors.New("request is not a draft")
+	ErrNotRequester          = errors.New("only the requester can submit this request")
 	ErrRequestNotFound       = errors.New("request not found")
 	ErrWorkflowNotFound      = errors.New("workflow not found")
 	ErrNoNextStep            = errors.New("no more steps in workflow")
 	ErrInvalidWorkflow       = errors.New("invalid workflow")
 	ErrRejectReasonRequired  = errors.New("reject reason is required")
 	ErrUnauthorizedActor     = errors.New("unauthorized actor: you are not the assigned approver for this step")
+	ErrStepUpRequired        = errors.New("two-factor verification is required to approve this request")
+	ErrFieldConditionsNotMet = errors.New("request fields do not meet the conditions for this step")
+	ErrCursorSort            = errors.New("cursor pagination requires sorting by created_at")
+	ErrRequestConflict       = errors.New("request was changed by another approval; reload it and try again")
 )
 
 // RequestServiceImpl implements RequestService interface with approval workflow logic
@@ -36,24 +50,40 @@ type RequestServiceImpl struct {
 	workflowRepo        wfPorts.WorkflowRepository
 	workflowStepRepo    stepPorts.WorkflowStepRepository
 	approvalHistoryRepo approvalHistoryPorts.ApprovalHistoryRepository
+	stepUp              reqDomain.StepUpPolicy
+	resolver            reqPorts.ApproverResolver // nil: only actor steps can be resolved
+	rates               reqPorts.ExchangeRateProvider
+	validator           reqPorts.WorkflowValidator // nil: workflows are not validated
+	purgeHooks          []reqPorts.RequestPurgeHook
 
 	// mutexMap stores per-request mutexes for in-memory locking
 	// This is Layer 1 of our double-layer concurrency control
 	mutexMap sync.Map
 }
 
-// NewRequestService creates a new RequestServiceImpl instance
+// NewRequestService creates a new RequestServiceImpl instance; purgeHooks run
+// for each request before DeleteRequest deletes it
 func NewRequestService(
 	requestRepo reqPorts.RequestRepository,
 	workflowRepo wfPorts.WorkflowRepository,
 	workflowStepRepo stepPorts.WorkflowStepRepository,
 	approvalHistoryRepo approvalHistoryPorts.ApprovalHistoryRepository,
+	stepUp reqDomain.StepUpPolicy,
+	resolver reqPorts.ApproverResolver,
+	rates reqPorts.ExchangeRateProvider,
+	validator reqPorts.WorkflowValidator,
+	purgeHooks ...reqPorts.RequestPurgeHook,
 ) reqPorts.RequestService {
 	return &RequestServiceImpl{
 		requestRepo:         requestRepo,
 		workflowRepo:        workflowRepo,
 		workflowStepRepo:    workflowStepRepo,
 		approvalHistoryRepo: approvalHistoryRepo,
+		stepUp:              stepUp,
+		resolver:            resolver,
+		rates:               rates,
+		validator:           validator,
+		purgeHooks:          purgeHooks,
 	}
 }
 
@@ -80,7 +110,9 @@ func (s *RequestServiceImpl) LockRequest(requestID string) func() {
 	mu := mutex.(*sync.Mutex)
 
 	// Lock - blocks until lock is acquired
+	start := time.Now()
 	mu.Lock()
+	observeLockWait(start)
 
 	// Return unlock function
 	return func() {
@@ -88,55 +120,172 @@ func (s *RequestServiceImpl) LockRequest(requestID string) func() {
 	}
 }
 
-// CreateRequest creates a new approval request
-func (s *RequestServiceImpl) CreateRequest(ctx context.Context, workflowID, requesterID string, amount float64, title, description string) (*reqDomain.Request, error) {
-	if amount <= 0 {
-		return nil, ErrRequestAmountPositive
+// CreateRequest creates a new approval request, converts its amount into the
+// base currency and resolves the approver of its first step
+func (s *RequestServiceImpl) CreateRequest(ctx context.Context, workflowID, requesterID string, amount money.Decimal, currencyCode, title, description string, fields reqDomain.RequestFields) (*reqDomain.Request, error) {
+	currency, err := s.currencyOf(currencyCode)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkAmount(amount, currency, false); err != nil {
+		return nil, err
 	}
 
 	// Verify workflow exists
-	_, err := s.workflowRepo.GetByID(ctx, workflowID)
+	workflow, err := s.workflowRepo.GetByID(ctx, workflowID)
 	if err != nil {
 		if errors.Is(err, wfRepo.ErrWorkflowNotFound) {
 			return nil, ErrWorkflowNotFound
 		}
 		return nil, err
 	}
+	if err := workflow.FormSchema.Validate(fields); err != nil {
+		return nil, err
+	}
+	if err := s.checkWorkflow(ctx, workflowID); err != nil {
+		return nil, err
+	}
+
+	request := reqDomain.NewRequest(workflowID, requesterID, amount, currency, title, description, fields)
+	if err := s.applyRate(ctx, request); err != nil {
+		return nil, err
+	}
+
+	// Workflows without steps can't be approved yet; the first approver is resolved once steps exist
+	firstStep, err := s.workflowStepRepo.GetByWorkflowAndLevel(ctx, workflowID, request.CurrentStep)
+	if err != nil && !errors.Is(err, stepRepo.ErrStepNotFound) {
+		return nil, err
+	}
+	if firstStep != nil {
+		if err := s.assignApprover(ctx, request, firstStep); err != nil {
+			return nil, err
+		}
+	}
 
-	request := reqDomain.NewRequest(workflowID, requesterID, amount, title, description)
 	if err := s.requestRepo.Create(ctx, request); err != nil {
 		return nil, err
 	}
+	requestsCreated.With(workflowID).Inc()
 
 	return request, nil
 }
 
-// GetRequest retrieves a request by ID
-func (s *RequestServiceImpl) GetRequest(ctx context.Context, id string) (*reqDomain.Request, error) {
-	return s.requestRepo.GetByID(ctx, id)
+// CreateDraft saves a request as a draft. Values are checked against the
+// workflow's form, but required fields may still be missing and no approver is
+// resolved; both happen on submission.
+func (s *RequestServiceImpl) CreateDraft(ctx context.Context, workflowID, requesterID string, amount money.Decimal, currencyCode, title, description string, fields reqDomain.RequestFields) (*reqDomain.Request, error) {
+	currency, err := s.currencyOf(currencyCode)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkAmount(amount, currency, true); err != nil {
+		return nil, err
+	}
+
+	workflow, err := s.workflowRepo.GetByID(ctx, workflowID)
+	if err != nil {
+		if errors.Is(err, wfRepo.ErrWorkflowNotFound) {
+			return nil, ErrWorkflowNotFound
+		}
+		return nil, err
+	}
+	if err := workflow.FormSchema.ValidateDraft(fields); err != nil {
+		return nil, err
+	}
+
+	request := reqDomain.NewDraftRequest(workflowID, requesterID, amount, currency, title, description, fields)
+	if err := s.requestRepo.Create(ctx, request); err != nil {
+		return nil, err
+	}
+
+	return request, nil
 }
 
-// ListRequests retrieves a paginated list of requests
-func (s *RequestServiceImpl) ListRequests(ctx context.Context, page, limit int, status *reqDomain.RequestStatus) ([]*reqDomain.Request, int64, error) {
-	if page < 1 {
-		page = 1
+// SubmitRequest sends a draft for approval. The draft is validated against the
+// workflow as it is now and its first approver is resolved at this point, so
+// routing reflects the current workflow and org chart rather than the ones at
+// the time the draft was saved. The exchange rate is fixed at this point too.
+func (s *RequestServiceImpl) SubmitRequest(ctx context.Context, id, userID string) (*reqDomain.Request, error) {
+	defer s.LockRequest(id)()
+
+	request, err := s.requestRepo.GetByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, reqRepo.ErrRequestNotFound) {
+			return nil, ErrRequestNotFound
+		}
+		return nil, err
+	}
+	if request.RequesterID != userID {
+		return nil, ErrNotRequester
+	}
+	if !request.IsDraft() {
+		return nil, ErrRequestNotDraft
+	}
+	if err := checkAmount(request.Amount, request.Currency, false); err != nil {
+		return nil, err
+	}
+
+	workflow, err := s.workflowRepo.GetByID(ctx, request.WorkflowID)
+	if err != nil {
+		if errors.Is(err, wfRepo.ErrWorkflowNotFound) {
+			return nil, ErrWorkflowNotFound
+		}
+		return nil, err
 	}
-	if limit < 1 {
-		limit = 10
+	if err := workflow.FormSchema.Validate(request.Fields); err != nil {
+		return nil, err
 	}
-	if limit > 100 {
-		limit = 100
+	if err := s.checkWorkflow(ctx, request.WorkflowID); err != nil {
+		return nil, err
+	}
+
+	if err := s.applyRate(ctx, request); err != nil {
+		return nil, err
+	}
+
+	request.CurrentStep = 1
+	request.Approvers = nil
+	firstStep, err := s.workflowStepRepo.GetByWorkflowAndLevel(ctx, request.WorkflowID, request.CurrentStep)
+	if err != nil && !errors.Is(err, stepRepo.ErrStepNotFound) {
+		return nil, err
+	}
+	if firstStep != nil {
+		if err := s.assignApprover(ctx, request, firstStep); err != nil {
+			return nil, err
+		}
+	}
+
+	now := utils.TimeNowUTC()
+	request.Status = reqDomain.StatusPending
+	request.SubmittedAt = &now
+	request.Version++ // Increment version for optimistic locking
+	if err := s.requestRepo.Update(ctx, request); err != nil {
+		return nil, err
 	}
-	return s.requestRepo.List(ctx, page, limit, status)
+	requestsCreated.With(request.WorkflowID).Inc()
+
+	return request, nil
 }
 
-// UpdateRequest updates an existing request
-// Only allows updates if the request is still in PENDING status
-func (s *RequestServiceImpl) UpdateRequest(ctx context.Context, id string, amount float64, title, description string) (*reqDomain.Request, error) {
-	if amount <= 0 {
-		return nil, ErrRequestAmountPositive
+// GetRequest retrieves a request by ID
+func (s *RequestServiceImpl) GetRequest(ctx context.Context, id string) (*reqDomain.Request, error) {
+	return s.requestRepo.GetByID(ctx, id)
+}
+
+// ListRequests retrieves a page of requests; other users' drafts are left out
+func (s *RequestServiceImpl) ListRequests(ctx context.Context, filter reqDomain.RequestFilter, page pagination.Page) ([]*reqDomain.Request, pagination.Result, error) {
+	page = page.Normalized()
+	if _, keyset := filter.KeysetOrder(); page.Cursor != nil && !keyset {
+		return nil, pagination.Result{}, ErrCursorSort
 	}
+	return s.requestRepo.List(ctx, filter, page)
+}
 
+// UpdateRequest updates an existing request
+// Only allows updates if the request is still a DRAFT or in PENDING status;
+// drafts are held to the same rules as CreateDraft. A pending request keeps the
+// exchange rate it was submitted at unless its currency changes.
+func (s *RequestServiceImpl) UpdateRequest(ctx context.Context, id string, amount money.Decimal, currencyCode, title, description string, fields reqDomain.RequestFields) (*reqDomain.Request, error) {
 	request, err := s.requestRepo.GetByID(ctx, id)
 	if err != nil {
 		if errors.Is(err, reqRepo.ErrRequestNotFound) {
@@ -145,15 +294,61 @@ func (s *RequestServiceImpl) UpdateRequest(ctx context.Context, id string, amoun
 		return nil, err
 	}
 
-	// Only allow updates for PENDING requests
-	if !request.IsPending() {
+	// Only allow updates for DRAFT and PENDING requests
+	if !request.IsEditable() {
 		return nil, ErrRequestNotPending
 	}
 
+	currency := request.Currency
+	if currencyCode != "" {
+		if currency, err = s.currencyOf(currencyCode); err != nil {
+			return nil, err
+		}
+	}
+	if err := checkAmount(amount, currency, request.IsDraft()); err != nil {
+		return nil, err
+	}
+
+	// New field values must match the workflow's current form
+	if fields != nil {
+		workflow, err := s.workflowRepo.GetByID(ctx, request.WorkflowID)
+		if err != nil {
+			if errors.Is(err, wfRepo.ErrWorkflowNotFound) {
+				return nil, ErrWorkflowNotFound
+			}
+			return nil, err
+		}
+		validate := workflow.FormSchema.Validate
+		if request.IsDraft() {
+			validate = workflow.FormSchema.ValidateDraft
+		}
+		if err := validate(fields); err != nil {
+			return nil, err
+		}
+	}
+
 	// Update fields
+	rate := request.ExchangeRate
+	if currency != request.Currency {
+		rate = money.Rate{}
+	}
 	request.Amount = amount
+	request.Currency = currency
+	if !request.IsDraft() {
+		if rate.IsZero() {
+			err = s.applyRate(ctx, request)
+		} else {
+			err = request.ApplyRate(rate)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
 	request.Title = title
 	request.Description = description
+	if fields != nil {
+		request.Fields = fields
+	}
 	request.Version++ // Increment version for optimistic locking
 
 	if err := s.requestRepo.Update(ctx, request); err != nil {
@@ -167,7 +362,15 @@ func (s *RequestServiceImpl) UpdateRequest(ctx context.Context, id string, amoun
 // This method uses double-layer concurrency control:
 // 1. Layer 1: Mutex lock (in-memory) - prevents concurrent goroutine calls within same instance
 // 2. Layer 2: SELECT FOR UPDATE (database) - prevents race conditions across instances
-func (s *RequestServiceImpl) Approve(ctx context.Context, requestID, userID, actorID string, isAdmin bool) (*reqDomain.Request, error) {
+//
+// mfaAt is when the approver last passed a second factor (nil if never); high-value
+// requests are refused with ErrStepUpRequired unless it is recent enough.
+func (s *RequestServiceImpl) Approve(ctx context.Context, requestID, userID string, actorIDs []string, isAdmin bool, mfaAt *time.Time) (*reqDomain.Request, error) {
+	return s.approve(ctx, requestID, userID, actorIDs, isAdmin, mfaAt, "")
+}
+
+// approve is Approve with a comment recorded in the approval history
+func (s *RequestServiceImpl) approve(ctx context.Context, requestID, userID string, actorIDs []string, isAdmin bool, mfaAt *time.Time, comment string) (*reqDomain.Request, error) {
 	// Layer 1: Acquire in-memory mutex lock
 	// This prevents race conditions when multiple goroutines in the same instance
 	// try to approve the same request simultaneously
@@ -197,18 +400,39 @@ func (s *RequestServiceImpl) Approve(ctx context.Context, requestID, userID, act
 		return nil, err
 	}
 
-	// Validate actor authorization
-	// If not admin, check if actorID matches the step's actor_id
-	if !isAdmin && actorID != "" && currentStep.ActorID != actorID {
-		return nil, ErrUnauthorizedActor
+	// Validate approver authorization
+	actorID, err := authorizeApprover(request, currentStep, userID, actorIDs, isAdmin)
+	if err != nil {
+		return nil, err
 	}
 
-	// Check if amount meets the condition for current step
-	if !s.checkCondition(currentStep.Conditions, request.Amount) {
+	// Check if the amount, in the base currency, meets the condition for current step
+	if !s.checkCondition(currentStep.Conditions, request.BaseAmount) {
 		return nil, errors.New("request amount does not meet the minimum requirement for this step")
 	}
+	if !currentStep.Conditions.MatchesFields(request.Fields) {
+		return nil, ErrFieldConditionsNotMet
+	}
 
-	// Record approval history
+	// High-value approvals need a recent second factor, admins included
+	if s.stepUp.Requires(request.BaseAmount) && !s.stepUp.IsSatisfied(mfaAt, utils.TimeNowUTC()) {
+		return nil, ErrStepUpRequired
+	}
+
+	// Resolve the next step's approver before recording anything, so a step
+	// nobody can approve leaves the request untouched at the current level
+	nextStep, err := s.workflowStepRepo.GetByWorkflowAndLevel(ctx, request.WorkflowID, request.CurrentStep+1)
+	if err != nil && !errors.Is(err, stepRepo.ErrStepNotFound) {
+		return nil, err
+	}
+	if nextStep != nil {
+		if err := s.assignApprover(ctx, request, nextStep); err != nil {
+			return nil, err
+		}
+	}
+
+	// History is recorded once the version-guarded update succeeded, so an
+	// approval that lost a race to another instance leaves no trace
 	history := approvalHistoryDomain.NewApprovalHistory(
 		requestID,
 		request.WorkflowID,
@@ -216,41 +440,39 @@ func (s *RequestServiceImpl) Approve(ctx context.Context, requestID, userID, act
 		actorID,
 		userID,
 		approvalHistoryDomain.ApprovalActionApprove,
-		"",
+		comment,
 	)
-	if err := s.approvalHistoryRepo.Create(ctx, history); err != nil {
-		return nil, errors.New("failed to record approval history")
-	}
 
-	// Try to find the next step
-	nextStep, err := s.workflowStepRepo.GetByWorkflowAndLevel(ctx, request.WorkflowID, request.CurrentStep+1)
-	if err != nil {
-		if errors.Is(err, stepRepo.ErrStepNotFound) {
-			// No more steps, mark as approved
-			request.Status = reqDomain.StatusApproved
-			request.CurrentStep = request.CurrentStep + 1
-			request.Version++ // Increment version for optimistic locking
-			if err := s.requestRepo.Update(ctx, request); err != nil {
-				return nil, errors.New("failed to update request status to approved")
-			}
-			return request, nil
+	if nextStep == nil {
+		// No more steps, mark as approved
+		request.Status = reqDomain.StatusApproved
+		request.CurrentStep = request.CurrentStep + 1
+		request.Version++ // Increment version for optimistic locking
+		if err := s.requestRepo.Update(ctx, request); err != nil {
+			return nil, updateError(err, "failed to update request status to approved")
+		}
+	} else {
+		// Move to next step
+		request.CurrentStep = nextStep.Level
+		request.Version++ // Increment version for optimistic locking
+		if err := s.requestRepo.Update(ctx, request); err != nil {
+			return nil, updateError(err, "failed to update request to next step")
 		}
-		return nil, err
 	}
 
-	// Move to next step
-	request.CurrentStep = nextStep.Level
-	request.Version++ // Increment version for optimistic locking
-	if err := s.requestRepo.Update(ctx, request); err != nil {
-		return nil, errors.New("failed to update request to next step")
+	// Record approval history
+	if err := s.approvalHistoryRepo.Create(ctx, history); err != nil {
+		return nil, errors.New("failed to record approval history")
+	}
+	if request.Status == reqDomain.StatusApproved {
+		recordDecision(request)
 	}
-
 	return request, nil
 }
 
 // Reject rejects the request
 // Also uses mutex lock for consistency
-func (s *RequestServiceImpl) Reject(ctx context.Context, requestID, userID, actorID string, isAdmin bool, reason string) (*reqDomain.Request, error) {
+func (s *RequestServiceImpl) Reject(ctx context.Context, requestID, userID string, actorIDs []string, isAdmin bool, reason string) (*reqDomain.Request, error) {
 	// Layer 1: Acquire in-memory mutex lock
 	defer s.LockRequest(requestID)()
 
@@ -276,13 +498,20 @@ func (s *RequestServiceImpl) Reject(ctx context.Context, requestID, userID, acto
 		return nil, err
 	}
 
-	// Validate actor authorization
-	// If not admin, check if actorID matches the step's actor_id
-	if !isAdmin && actorID != "" && currentStep.ActorID != actorID {
-		return nil, ErrUnauthorizedActor
+	// Validate approver authorization
+	actorID, err := authorizeApprover(request, currentStep, userID, actorIDs, isAdmin)
+	if err != nil {
+		return nil, err
+	}
+
+	// Mark as rejected
+	request.Status = reqDomain.StatusRejected
+	request.Version++ // Increment version for optimistic locking
+	if err := s.requestRepo.Update(ctx, request); err != nil {
+		return nil, updateError(err, "failed to update request status to rejected")
 	}
 
-	// Record rejection history
+	// Record rejection history once the rejection is stored, as in Approve
 	history := approvalHistoryDomain.NewApprovalHistory(
 		requestID,
 		request.WorkflowID,
@@ -295,18 +524,80 @@ func (s *RequestServiceImpl) Reject(ctx context.Context, requestID, userID, acto
 	if err := s.approvalHistoryRepo.Create(ctx, history); err != nil {
 		return nil, errors.New("failed to record rejection history")
 	}
+	recordDecision(request)
 
-	// Mark as rejected
-	request.Status = reqDomain.StatusRejected
-	request.Version++ // Increment version for optimistic locking
-	if err := s.requestRepo.Update(ctx, request); err != nil {
-		return nil, errors.New("failed to update request status to rejected")
+	return request, nil
+}
+
+// updateError reports a request changed concurrently, e.g. by another
+// instance, as ErrRequestConflict and hides other storage errors behind message
+func updateError(err error, message string) error {
+	if errors.Is(err, reqRepo.ErrVersionConflict) {
+		return ErrRequestConflict
 	}
+	return errors.New(message)
+}
 
-	return request, nil
+// authorizeApprover checks that the caller may act on the current step and
+// returns the actor to record in the approval history. A step resolved to a
+// user (manager, department head, lookup) can only be handled by that user;
+// otherwise non-admins must be a member of the step's actor. Admins may act on
+// any step, without an actor unless they hold it too.
+func authorizeApprover(request *reqDomain.Request, step *stepDomain.WorkflowStep, userID string, actorIDs []string, isAdmin bool) (string, error) {
+	stepActorID := step.ActorID
+	if approver := request.CurrentApprover(); approver != nil {
+		if approver.UserID != "" {
+			if approver.UserID == userID || isAdmin {
+				return "", nil
+			}
+			return "", ErrUnauthorizedActor
+		}
+		stepActorID = approver.ActorID
+	}
+
+	for _, id := range actorIDs {
+		if id == stepActorID {
+			return stepActorID, nil
+		}
+	}
+	if !isAdmin {
+		return "", ErrUnauthorizedActor
+	}
+	return "", nil
 }
 
-// DeleteRequest deletes a request by ID
+// assignApprover resolves who approves step for the request and records it.
+// When a dynamic assignee can't be resolved the step's actor, if any, is used.
+func (s *RequestServiceImpl) assignApprover(ctx context.Context, request *reqDomain.Request, step *stepDomain.WorkflowStep) error {
+	assignee := step.Assignee()
+
+	var approver *reqDomain.StepApprover
+	var err error
+	switch {
+	case !assignee.Type.IsDynamic():
+		approver = &reqDomain.StepApprover{ActorID: assignee.ActorID}
+	case s.resolver == nil:
+		err = fmt.Errorf("%w: %s assignees are not supported", reqDomain.ErrApproverUnresolved, assignee.Type)
+	default:
+		approver, err = s.resolver.Resolve(ctx, request, step)
+	}
+
+	if err != nil {
+		if !errors.Is(err, reqDomain.ErrApproverUnresolved) || assignee.ActorID == "" {
+			return err
+		}
+		approver = &reqDomain.StepApprover{ActorID: assignee.ActorID, Fallback: true}
+	}
+
+	approver.Level = step.Level
+	approver.AssigneeType = string(assignee.Type)
+	approver.ResolvedAt = utils.TimeNowUTC()
+	request.AssignApprover(*approver)
+	return nil
+}
+
+// DeleteRequest deletes a request by ID together with its attachments and
+// comments; if a purge hook fails the request is kept so it can be retried
 func (s *RequestServiceImpl) DeleteRequest(ctx context.Context, id string) error {
 	_, err := s.requestRepo.GetByID(ctx, id)
 	if err != nil {
@@ -315,14 +606,70 @@ func (s *RequestServiceImpl) DeleteRequest(ctx context.Context, id string) error
 		}
 		return err
 	}
+	for _, hook := range s.purgeHooks {
+		if err := hook.PurgeRequest(ctx, id); err != nil {
+			return err
+		}
+	}
 	return s.requestRepo.Delete(ctx, id)
 }
 
-// checkCondition checks if the amount meets the step conditions
-func (s *RequestServiceImpl) checkCondition(conditions stepDomain.StepConditions, amount float64) bool {
+// checkCondition checks if the base amount meets the step conditions. Only
+// min_amount is enforced: max_amount bounds the step's amount range for
+// workflow validation, as requests can't skip a level they are above.
+func (s *RequestServiceImpl) checkCondition(conditions stepDomain.StepConditions, baseAmount money.Decimal) bool {
 	// If no min_amount condition, always pass
-	if conditions.MinAmount == 0 {
+	if conditions.MinAmount.IsZero() {
 		return true
 	}
-	return amount >= conditions.MinAmount
+	return baseAmount.Cmp(conditions.MinAmount) >= 0
+}
+
+// checkWorkflow refuses requests against a workflow that fails validation,
+// e.g. one with a gap in its levels that would approve requests early
+func (s *RequestServiceImpl) checkWorkflow(ctx context.Context, workflowID string) error {
+	if s.validator == nil {
+		return nil
+	}
+	report, err := s.validator.Validate(ctx, workflowID)
+	if err != nil {
+		return err
+	}
+	return report.Err()
+}
+
+// currencyOf parses a request's currency code; empty means the base currency
+func (s *RequestServiceImpl) currencyOf(code string) (money.Currency, error) {
+	if code == "" {
+		return s.rates.BaseCurrency(), nil
+	}
+	currency, err := money.ParseCurrency(code)
+	if err != nil {
+		return "", ErrInvalidCurrency
+	}
+	return currency, nil
+}
+
+// checkAmount requires a positive amount once submitted, a non-negative one
+// for drafts, and no more decimal places than the currency has
+func checkAmount(amount money.Decimal, currency money.Currency, draft bool) error {
+	switch {
+	case draft && amount.Sign() < 0:
+		return ErrRequestAmountNegative
+	case !draft && amount.Sign() <= 0:
+		return ErrRequestAmountPositive
+	case !currency.Fits(amount):
+		return ErrAmountPrecision
+	}
+	return nil
+}
+
+// applyRate converts the request's amount into the base currency at the
+// current rate of its currency
+func (s *RequestServiceImpl) applyRate(ctx context.Context, request *reqDomain.Request) error {
+	rate, err := s.rates.RateFor(ctx, request.Currency)
+	if err != nil {
+		return err
+	}
+	return request.ApplyRate(rate)
 }
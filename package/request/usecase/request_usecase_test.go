@@ -2,7 +2,10 @@ package usecase
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"testing"
+	"time"
 
 	approvalHistoryDomain "workflow-approval/package/approval_history/domain"
 	approvalHistoryPorts "workflow-approval/package/approval_history/ports"
@@ -15,6 +18,9 @@ import (
 	stepDomain "workflow-approval/package/workflow_step/domain"
 	stepPorts "workflow-approval/package/workflow_step/ports"
 	stepRepo "workflow-approval/package/workflow_step/repository"
+	valDomain "workflow-approval/package/workflow_validation/domain"
+	"workflow-approval/utils/money"
+	"workflow-approval/utils/pagination"
 )
 
 // MockRequestRepository implements RequestRepository for testing
@@ -57,8 +63,28 @@ func (m *MockRequestRepository) Delete(ctx context.Context, id string) error {
 	return nil
 }
 
-func (m *MockRequestRepository) List(ctx context.Context, page, limit int, status *reqDomain.RequestStatus) ([]*reqDomain.Request, int64, error) {
-	return nil, 0, nil
+func (m *MockRequestRepository) List(ctx context.Context, filter reqDomain.RequestFilter, page pagination.Page) ([]*reqDomain.Request, pagination.Result, error) {
+	return nil, pagination.Result{}, nil
+}
+
+func (m *MockRequestRepository) ListDraftsUpdatedBefore(ctx context.Context, before time.Time, limit int) ([]*reqDomain.Request, error) {
+	var drafts []*reqDomain.Request
+	for _, r := range m.requests {
+		if r.IsDraft() && r.UpdatedAt.Before(before) && len(drafts) < limit {
+			drafts = append(drafts, r)
+		}
+	}
+	return drafts, nil
+}
+
+func (m *MockRequestRepository) CountPendingByWorkflow(ctx context.Context) (map[string]int64, error) {
+	counts := make(map[string]int64)
+	for _, r := range m.requests {
+		if r.IsPending() {
+			counts[r.WorkflowID]++
+		}
+	}
+	return counts, nil
 }
 
 // MockWorkflowRepository implements WorkflowRepository for testing
@@ -84,6 +110,16 @@ func (m *MockWorkflowRepository) GetByID(ctx context.Context, id string) (*wfDom
 	return nil, wfRepo.ErrWorkflowNotFound
 }
 
+func (m *MockWorkflowRepository) ListByName(ctx context.Context, name string) ([]*wfDomain.Workflow, error) {
+	var out []*wfDomain.Workflow
+	for _, w := range m.workflows {
+		if w.Name == name {
+			out = append(out, w)
+		}
+	}
+	return out, nil
+}
+
 func (m *MockWorkflowRepository) Update(ctx context.Context, workflow *wfDomain.Workflow) error {
 	return nil
 }
@@ -92,8 +128,8 @@ func (m *MockWorkflowRepository) Delete(ctx context.Context, id string) error {
 	return nil
 }
 
-func (m *MockWorkflowRepository) List(ctx context.Context, page, limit int) ([]*wfDomain.Workflow, int64, error) {
-	return nil, 0, nil
+func (m *MockWorkflowRepository) List(ctx context.Context, page pagination.Page) ([]*wfDomain.Workflow, pagination.Result, error) {
+	return nil, pagination.Result{}, nil
 }
 
 // MockWorkflowStepRepository implements WorkflowStepRepository for testing
@@ -151,6 +187,18 @@ func (m *MockWorkflowStepRepository) GetByWorkflowID(ctx context.Context, workfl
 	return nil, nil
 }
 
+func (m *MockWorkflowStepRepository) GetByActorID(ctx context.Context, actorID string) ([]*stepDomain.WorkflowStep, error) {
+	var result []*stepDomain.WorkflowStep
+	for _, steps := range m.steps {
+		for _, step := range steps {
+			if step.ActorID == actorID {
+				result = append(result, step)
+			}
+		}
+	}
+	return result, nil
+}
+
 // MockApprovalHistoryRepository implements ApprovalHistoryRepository for testing
 type MockApprovalHistoryRepository struct {
 	histories map[string][]*approvalHistoryDomain.ApprovalHistory
@@ -186,6 +234,14 @@ func (m *MockApprovalHistoryRepository) GetByRequestIDOrdered(ctx context.Contex
 	return m.GetByRequestID(ctx, requestID)
 }
 
+func (m *MockApprovalHistoryRepository) ListByRequestID(ctx context.Context, requestID string, page pagination.Page) ([]*approvalHistoryDomain.ApprovalHistory, pagination.Result, error) {
+	return nil, pagination.Result{}, nil
+}
+
+func (m *MockApprovalHistoryRepository) ListByRequestIDs(ctx context.Context, requestIDs []string) ([]*approvalHistoryDomain.ApprovalHistory, error) {
+	return nil, nil
+}
+
 // Test helper functions
 func createTestWorkflow(id string) *wfDomain.Workflow {
 	return &wfDomain.Workflow{
@@ -194,26 +250,47 @@ func createTestWorkflow(id string) *wfDomain.Workflow {
 	}
 }
 
-func createTestStep(workflowID string, level int, minAmount float64, actorID string) *stepDomain.WorkflowStep {
+func createTestStep(workflowID string, level int, minAmount int64, actorID string) *stepDomain.WorkflowStep {
 	return &stepDomain.WorkflowStep{
 		WorkflowID: workflowID,
 		Level:      level,
 		ActorID:    actorID,
 		Conditions: stepDomain.StepConditions{
-			MinAmount: minAmount,
+			MinAmount: money.NewDecimal(minAmount),
 		},
 	}
 }
 
-func createTestRequest(id, workflowID string, amount float64, step int, status reqDomain.RequestStatus) *reqDomain.Request {
+func createTestRequest(id, workflowID string, amount int64, step int, status reqDomain.RequestStatus) *reqDomain.Request {
 	return &reqDomain.Request{
-		ID:          id,
-		WorkflowID:  workflowID,
-		Amount:      amount,
-		CurrentStep: step,
-		Status:      status,
-		Title:       "Test Request",
+		ID:           id,
+		WorkflowID:   workflowID,
+		Amount:       money.NewDecimal(amount),
+		Currency:     "IDR",
+		ExchangeRate: money.RateOne,
+		BaseAmount:   money.NewDecimal(amount),
+		CurrentStep:  step,
+		Status:       status,
+		Title:        "Test Request",
+	}
+}
+
+// testRates converts into IDR at the rates of the map
+type testRates map[money.Currency]string
+
+func (r testRates) BaseCurrency() money.Currency {
+	return "IDR"
+}
+
+func (r testRates) RateFor(ctx context.Context, currency money.Currency) (money.Rate, error) {
+	if currency == "IDR" {
+		return money.RateOne, nil
+	}
+	rate, ok := r[currency]
+	if !ok {
+		return money.Rate{}, fmt.Errorf("%w: %s", reqDomain.ErrExchangeRateMissing, currency)
 	}
+	return money.ParseRate(rate)
 }
 
 // Test cases
@@ -228,10 +305,10 @@ func TestRequestCreation(t *testing.T) {
 	workflow := createTestWorkflow("wf-1")
 	mockWorkflowRepo.Create(ctx, workflow)
 
-	service := NewRequestService(mockRequestRepo, mockWorkflowRepo, mockStepRepo, mockApprovalHistoryRepo)
+	service := NewRequestService(mockRequestRepo, mockWorkflowRepo, mockStepRepo, mockApprovalHistoryRepo, reqDomain.StepUpPolicy{}, nil, testRates{}, nil)
 
 	t.Run("Create valid request", func(t *testing.T) {
-		req, err := service.CreateRequest(ctx, "wf-1", "user-1", 1500000, "Test Request", "Description")
+		req, err := service.CreateRequest(ctx, "wf-1", "user-1", money.NewDecimal(1500000), "", "Test Request", "Description", nil)
 		if err != nil {
 			t.Errorf("Expected no error, got %v", err)
 		}
@@ -247,21 +324,21 @@ func TestRequestCreation(t *testing.T) {
 	})
 
 	t.Run("Create request with invalid amount", func(t *testing.T) {
-		_, err := service.CreateRequest(ctx, "wf-1", "user-1", 0, "Test Request", "Description")
+		_, err := service.CreateRequest(ctx, "wf-1", "user-1", money.NewDecimal(0), "", "Test Request", "Description", nil)
 		if err != ErrRequestAmountPositive {
 			t.Errorf("Expected ErrRequestAmountPositive, got %v", err)
 		}
 	})
 
 	t.Run("Create request with negative amount", func(t *testing.T) {
-		_, err := service.CreateRequest(ctx, "wf-1", "user-1", -100, "Test Request", "Description")
+		_, err := service.CreateRequest(ctx, "wf-1", "user-1", money.NewDecimal(-100), "", "Test Request", "Description", nil)
 		if err != ErrRequestAmountPositive {
 			t.Errorf("Expected ErrRequestAmountPositive, got %v", err)
 		}
 	})
 
 	t.Run("Create request with non-existent workflow", func(t *testing.T) {
-		_, err := service.CreateRequest(ctx, "non-existent", "user-1", 1500000, "Test Request", "Description")
+		_, err := service.CreateRequest(ctx, "non-existent", "user-1", money.NewDecimal(1500000), "", "Test Request", "Description", nil)
 		if err != ErrWorkflowNotFound {
 			t.Errorf("Expected ErrWorkflowNotFound, got %v", err)
 		}
@@ -283,13 +360,13 @@ func TestApprovalLogic(t *testing.T) {
 		step1 := createTestStep("wf-1", 1, 1000000, "approver-1")
 		mockStepRepo.Create(ctx, step1)
 
-		service := NewRequestService(mockRequestRepo, mockWorkflowRepo, mockStepRepo, mockApprovalHistoryRepo)
+		service := NewRequestService(mockRequestRepo, mockWorkflowRepo, mockStepRepo, mockApprovalHistoryRepo, reqDomain.StepUpPolicy{}, nil, testRates{}, nil)
 
 		// Create request with amount that exceeds step 1 min_amount
 		req := createTestRequest("req-1", "wf-1", 2000000, 1, reqDomain.StatusPending)
 		mockRequestRepo.Create(ctx, req)
 
-		approved, err := service.Approve(ctx, "req-1", "user-1", "approver-1", false)
+		approved, err := service.Approve(ctx, "req-1", "user-1", []string{"approver-1"}, false, nil)
 		if err != nil {
 			t.Errorf("Expected no error, got %v", err)
 		}
@@ -315,13 +392,13 @@ func TestApprovalLogic(t *testing.T) {
 		step2 := createTestStep("wf-1", 2, 5000000, "approver-2")
 		mockStepRepo.Create(ctx, step2)
 
-		service := NewRequestService(mockRequestRepo, mockWorkflowRepo, mockStepRepo, mockApprovalHistoryRepo)
+		service := NewRequestService(mockRequestRepo, mockWorkflowRepo, mockStepRepo, mockApprovalHistoryRepo, reqDomain.StepUpPolicy{}, nil, testRates{}, nil)
 
 		// Create request with amount that exceeds step 1 but not step 2
 		req := createTestRequest("req-2", "wf-1", 2000000, 1, reqDomain.StatusPending)
 		mockRequestRepo.Create(ctx, req)
 
-		approved, err := service.Approve(ctx, "req-2", "user-1", "approver-1", false)
+		approved, err := service.Approve(ctx, "req-2", "user-1", []string{"approver-1"}, false, nil)
 		if err != nil {
 			t.Errorf("Expected no error, got %v", err)
 		}
@@ -346,13 +423,13 @@ func TestApprovalLogic(t *testing.T) {
 		step1 := createTestStep("wf-1", 1, 1000000, "approver-1")
 		mockStepRepo.Create(ctx, step1)
 
-		service := NewRequestService(mockRequestRepo, mockWorkflowRepo, mockStepRepo, mockApprovalHistoryRepo)
+		service := NewRequestService(mockRequestRepo, mockWorkflowRepo, mockStepRepo, mockApprovalHistoryRepo, reqDomain.StepUpPolicy{}, nil, testRates{}, nil)
 
 		// Create request with amount below step 1 min_amount
 		req := createTestRequest("req-3", "wf-1", 500000, 1, reqDomain.StatusPending)
 		mockRequestRepo.Create(ctx, req)
 
-		_, err := service.Approve(ctx, "req-3", "user-1", "approver-1", false)
+		_, err := service.Approve(ctx, "req-3", "user-1", []string{"approver-1"}, false, nil)
 		if err == nil {
 			t.Error("Expected error for amount below min_amount")
 		}
@@ -368,12 +445,12 @@ func TestApprovalLogic(t *testing.T) {
 		workflow := createTestWorkflow("wf-1")
 		mockWorkflowRepo.Create(ctx, workflow)
 
-		service := NewRequestService(mockRequestRepo, mockWorkflowRepo, mockStepRepo, mockApprovalHistoryRepo)
+		service := NewRequestService(mockRequestRepo, mockWorkflowRepo, mockStepRepo, mockApprovalHistoryRepo, reqDomain.StepUpPolicy{}, nil, testRates{}, nil)
 
 		req := createTestRequest("req-4", "wf-1", 2000000, 2, reqDomain.StatusApproved)
 		mockRequestRepo.Create(ctx, req)
 
-		_, err := service.Approve(ctx, "req-4", "user-1", "approver-1", false)
+		_, err := service.Approve(ctx, "req-4", "user-1", []string{"approver-1"}, false, nil)
 		if err != ErrRequestNotPending {
 			t.Errorf("Expected ErrRequestNotPending, got %v", err)
 		}
@@ -389,12 +466,12 @@ func TestApprovalLogic(t *testing.T) {
 		workflow := createTestWorkflow("wf-1")
 		mockWorkflowRepo.Create(ctx, workflow)
 
-		service := NewRequestService(mockRequestRepo, mockWorkflowRepo, mockStepRepo, mockApprovalHistoryRepo)
+		service := NewRequestService(mockRequestRepo, mockWorkflowRepo, mockStepRepo, mockApprovalHistoryRepo, reqDomain.StepUpPolicy{}, nil, testRates{}, nil)
 
 		req := createTestRequest("req-5", "wf-1", 2000000, 1, reqDomain.StatusRejected)
 		mockRequestRepo.Create(ctx, req)
 
-		_, err := service.Approve(ctx, "req-5", "user-1", "approver-1", false)
+		_, err := service.Approve(ctx, "req-5", "user-1", []string{"approver-1"}, false, nil)
 		if err != ErrRequestNotPending {
 			t.Errorf("Expected ErrRequestNotPending, got %v", err)
 		}
@@ -410,9 +487,9 @@ func TestApprovalLogic(t *testing.T) {
 		workflow := createTestWorkflow("wf-1")
 		mockWorkflowRepo.Create(ctx, workflow)
 
-		service := NewRequestService(mockRequestRepo, mockWorkflowRepo, mockStepRepo, mockApprovalHistoryRepo)
+		service := NewRequestService(mockRequestRepo, mockWorkflowRepo, mockStepRepo, mockApprovalHistoryRepo, reqDomain.StepUpPolicy{}, nil, testRates{}, nil)
 
-		_, err := service.Approve(ctx, "non-existent", "user-1", "approver-1", false)
+		_, err := service.Approve(ctx, "non-existent", "user-1", []string{"approver-1"}, false, nil)
 		if err != ErrRequestNotFound {
 			t.Errorf("Expected ErrRequestNotFound, got %v", err)
 		}
@@ -433,13 +510,13 @@ func TestRejectLogic(t *testing.T) {
 	step1 := createTestStep("wf-1", 1, 1000000, "approver-1")
 	mockStepRepo.Create(ctx, step1)
 
-	service := NewRequestService(mockRequestRepo, mockWorkflowRepo, mockStepRepo, mockApprovalHistoryRepo)
+	service := NewRequestService(mockRequestRepo, mockWorkflowRepo, mockStepRepo, mockApprovalHistoryRepo, reqDomain.StepUpPolicy{}, nil, testRates{}, nil)
 
 	t.Run("Reject pending request", func(t *testing.T) {
 		req := createTestRequest("req-1", "wf-1", 1500000, 1, reqDomain.StatusPending)
 		mockRequestRepo.Create(ctx, req)
 
-		rejected, err := service.Reject(ctx, "req-1", "user-1", "approver-1", false, "Insufficient documentation")
+		rejected, err := service.Reject(ctx, "req-1", "user-1", []string{"approver-1"}, false, "Insufficient documentation")
 		if err != nil {
 			t.Errorf("Expected no error, got %v", err)
 		}
@@ -452,15 +529,878 @@ func TestRejectLogic(t *testing.T) {
 		req := createTestRequest("req-2", "wf-1", 1500000, 1, reqDomain.StatusRejected)
 		mockRequestRepo.Create(ctx, req)
 
-		_, err := service.Reject(ctx, "req-2", "user-1", "approver-1", false, "Another reason")
+		_, err := service.Reject(ctx, "req-2", "user-1", []string{"approver-1"}, false, "Another reason")
 		if err != ErrRequestNotPending {
 			t.Errorf("Expected ErrRequestNotPending, got %v", err)
 		}
 	})
 }
 
+func TestApproveStepUp(t *testing.T) {
+	ctx := context.Background()
+	mockRequestRepo := NewMockRequestRepository()
+	mockWorkflowRepo := NewMockWorkflowRepository()
+	mockStepRepo := NewMockWorkflowStepRepository()
+	mockApprovalHistoryRepo := NewMockApprovalHistoryRepository()
+
+	workflow := createTestWorkflow("wf-1")
+	mockWorkflowRepo.Create(ctx, workflow)
+	step1 := createTestStep("wf-1", 1, 0, "approver-1")
+	mockStepRepo.Create(ctx, step1)
+
+	stepUp := reqDomain.StepUpPolicy{Amount: money.NewDecimal(10000000), MaxAge: 5 * time.Minute}
+	service := NewRequestService(mockRequestRepo, mockWorkflowRepo, mockStepRepo, mockApprovalHistoryRepo, stepUp, nil, testRates{}, nil)
+
+	t.Run("Below threshold needs no second factor", func(t *testing.T) {
+		req := createTestRequest("req-low", "wf-1", 9999999, 1, reqDomain.StatusPending)
+		mockRequestRepo.Create(ctx, req)
+
+		if _, err := service.Approve(ctx, "req-low", "user-1", []string{"approver-1"}, false, nil); err != nil {
+			t.Errorf("Expected no error, got %v", err)
+		}
+	})
+
+	t.Run("High-value approval requires step-up", func(t *testing.T) {
+		req := createTestRequest("req-high", "wf-1", 10000000, 1, reqDomain.StatusPending)
+		mockRequestRepo.Create(ctx, req)
+
+		if _, err := service.Approve(ctx, "req-high", "user-1", []string{"approver-1"}, false, nil); err != ErrStepUpRequired {
+			t.Errorf("Expected ErrStepUpRequired without second factor, got %v", err)
+		}
+		stale := time.Now().UTC().Add(-10 * time.Minute)
+		if _, err := service.Approve(ctx, "req-high", "admin-1", nil, true, &stale); err != ErrStepUpRequired {
+			t.Errorf("Expected ErrStepUpRequired for stale verification, got %v", err)
+		}
+
+		recent := time.Now().UTC().Add(-time.Minute)
+		approved, err := service.Approve(ctx, "req-high", "user-1", []string{"approver-1"}, false, &recent)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if approved.Status != reqDomain.StatusApproved {
+			t.Errorf("Expected status APPROVED, got %s", approved.Status)
+		}
+	})
+}
+
 // Interface compliance tests - ensure mocks implement the interfaces
 var _ reqPorts.RequestRepository = (*MockRequestRepository)(nil)
 var _ wfPorts.WorkflowRepository = (*MockWorkflowRepository)(nil)
 var _ stepPorts.WorkflowStepRepository = (*MockWorkflowStepRepository)(nil)
 var _ approvalHistoryPorts.ApprovalHistoryRepository = (*MockApprovalHistoryRepository)(nil)
+
+func TestApproveWithMultipleActors(t *testing.T) {
+	ctx := context.Background()
+	mockRequestRepo := NewMockRequestRepository()
+	mockWorkflowRepo := NewMockWorkflowRepository()
+	mockStepRepo := NewMockWorkflowStepRepository()
+	mockApprovalHistoryRepo := NewMockApprovalHistoryRepository()
+
+	mockWorkflowRepo.Create(ctx, createTestWorkflow("wf-1"))
+	mockStepRepo.Create(ctx, createTestStep("wf-1", 1, 0, "finance"))
+	mockStepRepo.Create(ctx, createTestStep("wf-1", 2, 0, "director"))
+
+	service := NewRequestService(mockRequestRepo, mockWorkflowRepo, mockStepRepo, mockApprovalHistoryRepo, reqDomain.StepUpPolicy{}, nil, testRates{}, nil)
+	mockRequestRepo.Create(ctx, createTestRequest("req-1", "wf-1", 1000, 1, reqDomain.StatusPending))
+
+	if _, err := service.Approve(ctx, "req-1", "user-1", []string{"sales", "hr"}, false, nil); err != ErrUnauthorizedActor {
+		t.Errorf("Expected ErrUnauthorizedActor, got %v", err)
+	}
+
+	// The approver holds the step's actor as a secondary membership
+	approved, err := service.Approve(ctx, "req-1", "user-1", []string{"sales", "finance"}, false, nil)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if approved.CurrentStep != 2 {
+		t.Errorf("Expected current step 2, got %d", approved.CurrentStep)
+	}
+	history := mockApprovalHistoryRepo.histories["req-1"]
+	if len(history) != 1 || history[0].ActorID != "finance" {
+		t.Errorf("Expected history recorded for actor finance, got %+v", history)
+	}
+
+	rejected, err := service.Reject(ctx, "req-1", "user-2", []string{"director", "finance"}, false, "Over budget")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if rejected.Status != reqDomain.StatusRejected {
+		t.Errorf("Expected status REJECTED, got %s", rejected.Status)
+	}
+}
+
+func TestApproveWithoutActors(t *testing.T) {
+	ctx := context.Background()
+	mockRequestRepo := NewMockRequestRepository()
+	mockWorkflowRepo := NewMockWorkflowRepository()
+	mockStepRepo := NewMockWorkflowStepRepository()
+	mockApprovalHistoryRepo := NewMockApprovalHistoryRepository()
+
+	mockWorkflowRepo.Create(ctx, createTestWorkflow("wf-1"))
+	mockStepRepo.Create(ctx, createTestStep("wf-1", 1, 0, "finance"))
+
+	service := NewRequestService(mockRequestRepo, mockWorkflowRepo, mockStepRepo, mockApprovalHistoryRepo, reqDomain.StepUpPolicy{}, nil, testRates{}, nil)
+	mockRequestRepo.Create(ctx, createTestRequest("req-1", "wf-1", 1000, 1, reqDomain.StatusPending))
+
+	// A JIT-provisioned user or an API key principal holds no actors
+	if _, err := service.Approve(ctx, "req-1", "user-1", nil, false, nil); err != ErrUnauthorizedActor {
+		t.Errorf("Expected ErrUnauthorizedActor on approve, got %v", err)
+	}
+	if _, err := service.Reject(ctx, "req-1", "user-1", nil, false, "No"); err != ErrUnauthorizedActor {
+		t.Errorf("Expected ErrUnauthorizedActor on reject, got %v", err)
+	}
+	if req, _ := mockRequestRepo.GetByID(ctx, "req-1"); !req.IsPending() || req.CurrentStep != 1 {
+		t.Errorf("Expected request untouched, got status %s at step %d", req.Status, req.CurrentStep)
+	}
+
+	// Admins may still act on any step
+	if _, err := service.Approve(ctx, "req-1", "admin-1", nil, true, nil); err != nil {
+		t.Errorf("Expected admin approval without actors, got %v", err)
+	}
+}
+
+// fakeApproverResolver resolves dynamic steps to fixed users by assignee type
+type fakeApproverResolver struct {
+	users map[stepDomain.AssigneeType]string
+}
+
+func (r *fakeApproverResolver) Resolve(ctx context.Context, request *reqDomain.Request, step *stepDomain.WorkflowStep) (*reqDomain.StepApprover, error) {
+	if userID, ok := r.users[step.AssigneeType]; ok {
+		return &reqDomain.StepApprover{UserID: userID}, nil
+	}
+	return nil, reqDomain.ErrApproverUnresolved
+}
+
+var _ reqPorts.ApproverResolver = (*fakeApproverResolver)(nil)
+
+func TestDynamicApproverResolution(t *testing.T) {
+	ctx := context.Background()
+	mockRequestRepo := NewMockRequestRepository()
+	mockWorkflowRepo := NewMockWorkflowRepository()
+	mockStepRepo := NewMockWorkflowStepRepository()
+	mockApprovalHistoryRepo := NewMockApprovalHistoryRepository()
+	resolver := &fakeApproverResolver{users: map[stepDomain.AssigneeType]string{
+		stepDomain.AssigneeRequesterManager: "manager-1",
+	}}
+
+	mockWorkflowRepo.Create(ctx, createTestWorkflow("wf-1"))
+	managerStep := createTestStep("wf-1", 1, 0, "")
+	managerStep.AssigneeType = stepDomain.AssigneeRequesterManager
+	mockStepRepo.Create(ctx, managerStep)
+	headStep := createTestStep("wf-1", 2, 0, "finance")
+	headStep.AssigneeType = stepDomain.AssigneeDepartmentHead
+	mockStepRepo.Create(ctx, headStep)
+
+	service := NewRequestService(mockRequestRepo, mockWorkflowRepo, mockStepRepo, mockApprovalHistoryRepo, reqDomain.StepUpPolicy{}, resolver, testRates{}, nil)
+
+	req, err := service.CreateRequest(ctx, "wf-1", "user-1", money.NewDecimal(1000), "", "Laptop", "", reqDomain.RequestFields{"cost_center": "CC-1"})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if approver := req.CurrentApprover(); approver == nil || approver.UserID != "manager-1" {
+		t.Fatalf("Expected level 1 resolved to manager-1, got %+v", approver)
+	}
+
+	t.Run("Only the resolved user may act", func(t *testing.T) {
+		if _, err := service.Approve(ctx, req.ID, "someone-else", []string{"finance"}, false, nil); err != ErrUnauthorizedActor {
+			t.Errorf("Expected ErrUnauthorizedActor, got %v", err)
+		}
+	})
+
+	t.Run("Unresolved step falls back to its actor", func(t *testing.T) {
+		approved, err := service.Approve(ctx, req.ID, "manager-1", nil, false, nil)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		approver := approved.CurrentApprover()
+		if approver == nil || approver.Level != 2 || approver.ActorID != "finance" || !approver.Fallback {
+			t.Errorf("Expected level 2 fallback to actor finance, got %+v", approver)
+		}
+		history := mockApprovalHistoryRepo.histories[req.ID]
+		if len(history) != 1 || history[0].UserID != "manager-1" || history[0].ActorID != "" {
+			t.Errorf("Expected history recorded for manager-1 without actor, got %+v", history)
+		}
+	})
+
+	t.Run("Unresolved step without fallback blocks the approval", func(t *testing.T) {
+		lookupStep := createTestStep("wf-2", 2, 0, "")
+		lookupStep.AssigneeType = stepDomain.AssigneeFieldLookup
+		lookupStep.LookupField = "cost_center"
+		mockWorkflowRepo.Create(ctx, createTestWorkflow("wf-2"))
+		mockStepRepo.Create(ctx, createTestStep("wf-2", 1, 0, "sales"))
+		mockStepRepo.Create(ctx, lookupStep)
+		mockRequestRepo.Create(ctx, createTestRequest("req-2", "wf-2", 1000, 1, reqDomain.StatusPending))
+
+		_, err := service.Approve(ctx, "req-2", "user-2", []string{"sales"}, false, nil)
+		if !errors.Is(err, reqDomain.ErrApproverUnresolved) {
+			t.Fatalf("Expected ErrApproverUnresolved, got %v", err)
+		}
+		stored, _ := mockRequestRepo.GetByID(ctx, "req-2")
+		if stored.CurrentStep != 1 || len(mockApprovalHistoryRepo.histories["req-2"]) != 0 {
+			t.Errorf("Expected request left at step 1 without history, got step %d", stored.CurrentStep)
+		}
+	})
+}
+
+func TestRequestFormFields(t *testing.T) {
+	ctx := context.Background()
+	mockRequestRepo := NewMockRequestRepository()
+	mockWorkflowRepo := NewMockWorkflowRepository()
+	mockStepRepo := NewMockWorkflowStepRepository()
+	mockApprovalHistoryRepo := NewMockApprovalHistoryRepository()
+
+	maxNights := 30.0
+	workflow := createTestWorkflow("wf-travel")
+	workflow.FormSchema = wfDomain.FormSchema{Fields: []wfDomain.FormField{
+		{Name: "destination", Type: wfDomain.FieldString, Required: true, Enum: []interface{}{"domestic", "abroad"}},
+		{Name: "nights", Type: wfDomain.FieldInteger, Required: true, Max: &maxNights},
+		{Name: "project_code", Type: wfDomain.FieldString, Pattern: `^PRJ-[0-9]{3}$`},
+	}}
+	mockWorkflowRepo.Create(ctx, workflow)
+
+	// Only trips abroad can be approved at step 1
+	step := createTestStep("wf-travel", 1, 0, "hr")
+	step.Conditions.Fields = []stepDomain.FieldCondition{
+		{Field: "destination", Operator: stepDomain.OpEquals, Value: "abroad"},
+	}
+	mockStepRepo.Create(ctx, step)
+
+	service := NewRequestService(mockRequestRepo, mockWorkflowRepo, mockStepRepo, mockApprovalHistoryRepo, reqDomain.StepUpPolicy{}, nil, testRates{}, nil)
+
+	t.Run("Invalid values are reported per field", func(t *testing.T) {
+		_, err := service.CreateRequest(ctx, "wf-travel", "user-1", money.NewDecimal(500), "", "Trip", "", reqDomain.RequestFields{
+			"destination":  "moon",
+			"nights":       2.5,
+			"project_code": "X-1",
+			"unknown":      true,
+		})
+		var fieldErrs wfDomain.FieldErrors
+		if !errors.As(err, &fieldErrs) {
+			t.Fatalf("Expected FieldErrors, got %v", err)
+		}
+		got := map[string]bool{}
+		for _, fe := range fieldErrs {
+			got[fe.Field] = true
+		}
+		for _, field := range []string{"destination", "nights", "project_code", "unknown"} {
+			if !got[field] {
+				t.Errorf("Expected an error for %s, got %+v", field, fieldErrs)
+			}
+		}
+	})
+
+	t.Run("Missing required fields are rejected", func(t *testing.T) {
+		_, err := service.CreateRequest(ctx, "wf-travel", "user-1", money.NewDecimal(500), "", "Trip", "", nil)
+		var fieldErrs wfDomain.FieldErrors
+		if !errors.As(err, &fieldErrs) || len(fieldErrs) != 2 {
+			t.Errorf("Expected 2 required field errors, got %v", err)
+		}
+	})
+
+	t.Run("Field conditions gate the approval", func(t *testing.T) {
+		req, err := service.CreateRequest(ctx, "wf-travel", "user-1", money.NewDecimal(500), "", "Trip", "", reqDomain.RequestFields{
+			"destination": "domestic",
+			"nights":      float64(3),
+		})
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if _, err := service.Approve(ctx, req.ID, "user-2", []string{"hr"}, false, nil); err != ErrFieldConditionsNotMet {
+			t.Fatalf("Expected ErrFieldConditionsNotMet, got %v", err)
+		}
+
+		if _, err := service.UpdateRequest(ctx, req.ID, money.NewDecimal(500), "", "Trip", "", reqDomain.RequestFields{"destination": "abroad", "nights": float64(31)}); err == nil {
+			t.Fatal("Expected nights above max to be rejected on update")
+		}
+		if _, err := service.UpdateRequest(ctx, req.ID, money.NewDecimal(500), "", "Trip", "", reqDomain.RequestFields{"destination": "abroad", "nights": float64(3)}); err != nil {
+			t.Fatalf("Expected update to succeed, got %v", err)
+		}
+		approved, err := service.Approve(ctx, req.ID, "user-2", []string{"hr"}, false, nil)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if approved.Status != reqDomain.StatusApproved {
+			t.Errorf("Expected status APPROVED, got %s", approved.Status)
+		}
+	})
+}
+
+func TestDraftSubmission(t *testing.T) {
+	ctx := context.Background()
+	mockRequestRepo := NewMockRequestRepository()
+	mockWorkflowRepo := NewMockWorkflowRepository()
+	mockStepRepo := NewMockWorkflowStepRepository()
+	mockApprovalHistoryRepo := NewMockApprovalHistoryRepository()
+
+	workflow := createTestWorkflow("wf-travel")
+	workflow.FormSchema = wfDomain.FormSchema{Fields: []wfDomain.FormField{
+		{Name: "destination", Type: wfDomain.FieldString, Required: true, Enum: []interface{}{"domestic", "abroad"}},
+	}}
+	mockWorkflowRepo.Create(ctx, workflow)
+
+	service := NewRequestService(mockRequestRepo, mockWorkflowRepo, mockStepRepo, mockApprovalHistoryRepo, reqDomain.StepUpPolicy{}, nil, testRates{}, nil)
+
+	draft, err := service.CreateDraft(ctx, "wf-travel", "user-1", money.NewDecimal(0), "", "Trip", "", nil)
+	if err != nil {
+		t.Fatalf("Expected an incomplete draft to be saved, got %v", err)
+	}
+	if draft.Status != reqDomain.StatusDraft || draft.SubmittedAt != nil || len(draft.Approvers) != 0 {
+		t.Fatalf("Expected an unrouted DRAFT, got %+v", draft)
+	}
+
+	t.Run("Draft values are still type checked", func(t *testing.T) {
+		_, err := service.CreateDraft(ctx, "wf-travel", "user-1", money.NewDecimal(0), "", "Trip", "", reqDomain.RequestFields{"destination": "moon"})
+		var fieldErrs wfDomain.FieldErrors
+		if !errors.As(err, &fieldErrs) {
+			t.Errorf("Expected FieldErrors, got %v", err)
+		}
+		if _, err := service.CreateDraft(ctx, "wf-travel", "user-1", money.NewDecimal(-1), "", "Trip", "", nil); err != ErrRequestAmountNegative {
+			t.Errorf("Expected ErrRequestAmountNegative, got %v", err)
+		}
+	})
+
+	t.Run("Drafts cannot be approved", func(t *testing.T) {
+		if _, err := service.Approve(ctx, draft.ID, "admin", nil, true, nil); err != ErrRequestNotPending {
+			t.Errorf("Expected ErrRequestNotPending, got %v", err)
+		}
+	})
+
+	t.Run("Only the requester submits", func(t *testing.T) {
+		if _, err := service.SubmitRequest(ctx, draft.ID, "user-2"); err != ErrNotRequester {
+			t.Errorf("Expected ErrNotRequester, got %v", err)
+		}
+	})
+
+	t.Run("Submission re-validates the draft", func(t *testing.T) {
+		if _, err := service.SubmitRequest(ctx, draft.ID, "user-1"); err != ErrRequestAmountPositive {
+			t.Fatalf("Expected ErrRequestAmountPositive, got %v", err)
+		}
+		if _, err := service.UpdateRequest(ctx, draft.ID, money.NewDecimal(500), "", "Trip", "", nil); err != nil {
+			t.Fatalf("UpdateRequest: %v", err)
+		}
+		_, err := service.SubmitRequest(ctx, draft.ID, "user-1")
+		var fieldErrs wfDomain.FieldErrors
+		if !errors.As(err, &fieldErrs) || fieldErrs[0].Field != "destination" {
+			t.Fatalf("Expected the missing destination to be reported, got %v", err)
+		}
+	})
+
+	t.Run("Routing is evaluated at submission", func(t *testing.T) {
+		// The step is added after the draft was saved
+		mockStepRepo.Create(ctx, createTestStep("wf-travel", 1, 0, "hr"))
+		if _, err := service.UpdateRequest(ctx, draft.ID, money.NewDecimal(500), "", "Trip", "", reqDomain.RequestFields{"destination": "abroad"}); err != nil {
+			t.Fatalf("UpdateRequest: %v", err)
+		}
+
+		submitted, err := service.SubmitRequest(ctx, draft.ID, "user-1")
+		if err != nil {
+			t.Fatalf("SubmitRequest: %v", err)
+		}
+		if submitted.Status != reqDomain.StatusPending || submitted.SubmittedAt == nil {
+			t.Errorf("Expected a submitted PENDING request, got %+v", submitted)
+		}
+		if approver := submitted.CurrentApprover(); approver == nil || approver.ActorID != "hr" {
+			t.Errorf("Expected step 1 to be routed to hr, got %+v", submitted.Approvers)
+		}
+		if _, err := service.SubmitRequest(ctx, draft.ID, "user-1"); err != ErrRequestNotDraft {
+			t.Errorf("Expected ErrRequestNotDraft on a second submission, got %v", err)
+		}
+	})
+}
+
+// failingPurgeHook refuses to clean up one request
+type failingPurgeHook struct {
+	failFor string
+	purged  []string
+}
+
+func (h *failingPurgeHook) PurgeRequest(ctx context.Context, requestID string) error {
+	if requestID == h.failFor {
+		return errors.New("storage unavailable")
+	}
+	h.purged = append(h.purged, requestID)
+	return nil
+}
+
+func TestDraftJanitor(t *testing.T) {
+	ctx := context.Background()
+	mockRequestRepo := NewMockRequestRepository()
+	now := time.Now().UTC()
+
+	stale := createTestRequest("stale", "wf-1", 100, 1, reqDomain.StatusDraft)
+	stale.UpdatedAt = now.Add(-31 * 24 * time.Hour)
+	stuck := createTestRequest("stuck", "wf-1", 100, 1, reqDomain.StatusDraft)
+	stuck.UpdatedAt = now.Add(-40 * 24 * time.Hour)
+	fresh := createTestRequest("fresh", "wf-1", 100, 1, reqDomain.StatusDraft)
+	fresh.UpdatedAt = now.Add(-time.Hour)
+	oldPending := createTestRequest("old-pending", "wf-1", 100, 1, reqDomain.StatusPending)
+	oldPending.UpdatedAt = now.Add(-90 * 24 * time.Hour)
+	for _, r := range []*reqDomain.Request{stale, stuck, fresh, oldPending} {
+		mockRequestRepo.Create(ctx, r)
+	}
+
+	hook := &failingPurgeHook{failFor: "stuck"}
+	janitor := NewDraftJanitor(mockRequestRepo, 30*24*time.Hour, time.Hour, hook)
+
+	purged, err := janitor.PurgeStale(ctx, now)
+	if err != nil {
+		t.Fatalf("PurgeStale: %v", err)
+	}
+	if purged != 1 || len(hook.purged) != 1 || hook.purged[0] != "stale" {
+		t.Errorf("Expected only the stale draft to be purged, got %d %v", purged, hook.purged)
+	}
+	for _, id := range []string{"stuck", "fresh", "old-pending"} {
+		if _, ok := mockRequestRepo.requests[id]; !ok {
+			t.Errorf("Expected %s to be kept", id)
+		}
+	}
+}
+
+func TestRequestCurrency(t *testing.T) {
+	ctx := context.Background()
+	mockRequestRepo := NewMockRequestRepository()
+	mockWorkflowRepo := NewMockWorkflowRepository()
+	mockStepRepo := NewMockWorkflowStepRepository()
+	mockApprovalHistoryRepo := NewMockApprovalHistoryRepository()
+
+	mockWorkflowRepo.Create(ctx, createTestWorkflow("wf-1"))
+	mockStepRepo.Create(ctx, createTestStep("wf-1", 1, 1000000, "finance"))
+
+	rates := testRates{"USD": "15750", "JPY": "105.5"}
+	service := NewRequestService(mockRequestRepo, mockWorkflowRepo, mockStepRepo, mockApprovalHistoryRepo, reqDomain.StepUpPolicy{}, nil, rates, nil)
+
+	amount := func(s string) money.Decimal {
+		d, err := money.ParseDecimal(s)
+		if err != nil {
+			t.Fatalf("ParseDecimal(%q): %v", s, err)
+		}
+		return d
+	}
+
+	t.Run("Threshold is compared in the base currency", func(t *testing.T) {
+		below, err := service.CreateRequest(ctx, "wf-1", "user-1", amount("63.49"), "usd", "Licence", "", nil)
+		if err != nil {
+			t.Fatalf("CreateRequest: %v", err)
+		}
+		if below.Currency != "USD" || below.BaseAmount.String() != "999967.50" {
+			t.Errorf("Expected 999967.50 IDR, got %s %s", below.Currency, below.BaseAmount)
+		}
+		if _, err := service.Approve(ctx, below.ID, "user-2", []string{"finance"}, false, nil); err == nil {
+			t.Error("Expected the amount to fall short of the step minimum")
+		}
+
+		above, _ := service.CreateRequest(ctx, "wf-1", "user-1", amount("63.50"), "USD", "Licence", "", nil)
+		if _, err := service.Approve(ctx, above.ID, "user-2", []string{"finance"}, false, nil); err != nil {
+			t.Errorf("Expected 1000125 IDR to meet the minimum, got %v", err)
+		}
+	})
+
+	t.Run("Exactly at the limit", func(t *testing.T) {
+		req, _ := service.CreateRequest(ctx, "wf-1", "user-1", amount("1000000.00"), "", "Laptop", "", nil)
+		if req.Currency != "IDR" || req.ExchangeRate != money.RateOne {
+			t.Errorf("Expected the base currency at rate 1, got %s at %s", req.Currency, req.ExchangeRate)
+		}
+		if _, err := service.Approve(ctx, req.ID, "user-2", []string{"finance"}, false, nil); err != nil {
+			t.Errorf("Expected an amount equal to the minimum to pass, got %v", err)
+		}
+	})
+
+	t.Run("Invalid currencies and amounts", func(t *testing.T) {
+		if _, err := service.CreateRequest(ctx, "wf-1", "user-1", amount("10"), "XYZ", "", "", nil); err != ErrInvalidCurrency {
+			t.Errorf("Expected ErrInvalidCurrency, got %v", err)
+		}
+		if _, err := service.CreateRequest(ctx, "wf-1", "user-1", amount("10.5"), "JPY", "", "", nil); err != ErrAmountPrecision {
+			t.Errorf("Expected ErrAmountPrecision for yen with decimals, got %v", err)
+		}
+		if _, err := service.CreateRequest(ctx, "wf-1", "user-1", amount("10"), "EUR", "", "", nil); !errors.Is(err, reqDomain.ErrExchangeRateMissing) {
+			t.Errorf("Expected ErrExchangeRateMissing, got %v", err)
+		}
+	})
+
+	t.Run("Rate is fixed at submission", func(t *testing.T) {
+		draft, _ := service.CreateDraft(ctx, "wf-1", "user-1", amount("100"), "USD", "Trip", "", nil)
+		if !draft.ExchangeRate.IsZero() {
+			t.Errorf("Expected drafts to have no rate yet, got %s", draft.ExchangeRate)
+		}
+
+		rates["USD"] = "16000"
+		submitted, err := service.SubmitRequest(ctx, draft.ID, "user-1")
+		if err != nil {
+			t.Fatalf("SubmitRequest: %v", err)
+		}
+		if submitted.ExchangeRate.String() != "16000" || submitted.BaseAmount.String() != "1600000.00" {
+			t.Errorf("Expected the rate at submission, got %s (%s)", submitted.ExchangeRate, submitted.BaseAmount)
+		}
+
+		rates["USD"] = "17000"
+		updated, _ := service.UpdateRequest(ctx, submitted.ID, amount("200"), "", "Trip", "", nil)
+		if updated.BaseAmount.String() != "3200000.00" {
+			t.Errorf("Expected the submitted rate to be kept, got %s", updated.BaseAmount)
+		}
+		updated, _ = service.UpdateRequest(ctx, submitted.ID, amount("200"), "JPY", "Trip", "", nil)
+		if updated.ExchangeRate.String() != "105.5" || updated.BaseAmount.String() != "21100.00" {
+			t.Errorf("Expected a currency change to take the current rate, got %s (%s)", updated.ExchangeRate, updated.BaseAmount)
+		}
+	})
+}
+
+func TestSimulate(t *testing.T) {
+	ctx := context.Background()
+	mockRequestRepo := NewMockRequestRepository()
+	mockWorkflowRepo := NewMockWorkflowRepository()
+	mockStepRepo := NewMockWorkflowStepRepository()
+	mockApprovalHistoryRepo := NewMockApprovalHistoryRepository()
+	resolver := &fakeApproverResolver{users: map[stepDomain.AssigneeType]string{
+		stepDomain.AssigneeRequesterManager: "manager-1",
+	}}
+
+	mockWorkflowRepo.Create(ctx, createTestWorkflow("wf-1"))
+	managerStep := createTestStep("wf-1", 1, 0, "")
+	managerStep.AssigneeType = stepDomain.AssigneeRequesterManager
+	mockStepRepo.Create(ctx, managerStep)
+	abroadStep := createTestStep("wf-1", 2, 1000000, "finance")
+	abroadStep.Conditions.Fields = []stepDomain.FieldCondition{{Field: "destination", Operator: stepDomain.OpEquals, Value: "abroad"}}
+	abroadStep.Conditions.Roles = []string{"travel"}
+	mockStepRepo.Create(ctx, abroadStep)
+	headStep := createTestStep("wf-1", 3, 0, "")
+	headStep.AssigneeType = stepDomain.AssigneeDepartmentHead
+	mockStepRepo.Create(ctx, headStep)
+	mockStepRepo.Create(ctx, createTestStep("wf-1", 5, 0, "board"))
+
+	service := NewRequestService(mockRequestRepo, mockWorkflowRepo, mockStepRepo, mockApprovalHistoryRepo, reqDomain.StepUpPolicy{Amount: money.NewDecimal(1000000)}, resolver, testRates{"USD": "15000"}, nil)
+
+	statuses := func(sim *reqDomain.Simulation) []reqDomain.LevelStatus {
+		var out []reqDomain.LevelStatus
+		for _, level := range sim.Levels {
+			out = append(out, level.Status)
+		}
+		return out
+	}
+
+	t.Run("Failing conditions block the route", func(t *testing.T) {
+		sim, err := service.Simulate(ctx, "wf-1", "user-1", money.NewDecimal(100), "USD", reqDomain.RequestFields{"destination": "local"})
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		want := []reqDomain.LevelStatus{reqDomain.LevelHit, reqDomain.LevelBlocked, reqDomain.LevelNotReached, reqDomain.LevelNotReached}
+		if fmt.Sprint(statuses(sim)) != fmt.Sprint(want) || sim.Outcome != reqDomain.OutcomeBlocked {
+			t.Fatalf("Expected %v and a blocked outcome, got %v %s", want, statuses(sim), sim.Outcome)
+		}
+		if sim.BaseAmount.Cmp(money.NewDecimal(1500000)) != 0 || !sim.StepUpRequired {
+			t.Errorf("Expected the amount converted to 1500000 IDR with step-up, got %s", sim.BaseAmount)
+		}
+		if approver := sim.Levels[0].Approver; approver == nil || approver.UserID != "manager-1" {
+			t.Errorf("Expected level 1 resolved to manager-1, got %+v", approver)
+		}
+
+		conditions := sim.Levels[1].Conditions
+		if len(conditions) != 3 ||
+			conditions[0].Condition != "min_amount" || conditions[0].Status != reqDomain.ConditionMatched ||
+			conditions[1].Condition != `fields.destination eq "abroad"` || conditions[1].Status != reqDomain.ConditionNotMatched || conditions[1].Reason != `destination is "local"` ||
+			conditions[2].Condition != "roles" || conditions[2].Status != reqDomain.ConditionNotEnforced {
+			t.Errorf("Unexpected conditions: %+v", conditions)
+		}
+	})
+
+	t.Run("Unresolved approvers and missing levels end the route", func(t *testing.T) {
+		sim, err := service.Simulate(ctx, "wf-1", "user-1", money.NewDecimal(100), "USD", reqDomain.RequestFields{"destination": "abroad"})
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		want := []reqDomain.LevelStatus{reqDomain.LevelHit, reqDomain.LevelHit, reqDomain.LevelUnresolved, reqDomain.LevelNotReached}
+		if fmt.Sprint(statuses(sim)) != fmt.Sprint(want) || sim.Outcome != reqDomain.OutcomeUnroutable {
+			t.Fatalf("Expected %v and an unroutable outcome, got %v %s", want, statuses(sim), sim.Outcome)
+		}
+
+		// With level 3 resolvable the route ends after it, as level 4 is missing
+		resolver.users[stepDomain.AssigneeDepartmentHead] = "head-1"
+		defer delete(resolver.users, stepDomain.AssigneeDepartmentHead)
+		sim, _ = service.Simulate(ctx, "wf-1", "user-1", money.NewDecimal(100), "USD", reqDomain.RequestFields{"destination": "abroad"})
+		want = []reqDomain.LevelStatus{reqDomain.LevelHit, reqDomain.LevelHit, reqDomain.LevelHit, reqDomain.LevelNotReached}
+		if fmt.Sprint(statuses(sim)) != fmt.Sprint(want) || sim.Outcome != reqDomain.OutcomeApproved {
+			t.Errorf("Expected %v and an approved outcome, got %v %s", want, statuses(sim), sim.Outcome)
+		}
+	})
+
+	t.Run("Workflows failing validation route nothing", func(t *testing.T) {
+		validated := NewRequestService(mockRequestRepo, mockWorkflowRepo, mockStepRepo, mockApprovalHistoryRepo, reqDomain.StepUpPolicy{}, resolver, testRates{"USD": "15000"}, &fakeValidator{steps: mockStepRepo})
+		sim, err := validated.Simulate(ctx, "wf-1", "user-1", money.NewDecimal(100), "USD", reqDomain.RequestFields{"destination": "abroad"})
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		want := []reqDomain.LevelStatus{reqDomain.LevelNotReached, reqDomain.LevelNotReached, reqDomain.LevelNotReached, reqDomain.LevelNotReached}
+		if fmt.Sprint(statuses(sim)) != fmt.Sprint(want) || sim.Outcome != reqDomain.OutcomeInvalid {
+			t.Fatalf("Expected %v and an invalid outcome, got %v %s", want, statuses(sim), sim.Outcome)
+		}
+		if sim.Validation == nil || sim.Validation.Valid || sim.Validation.Issues[0].Code != valDomain.IssueLevelGap {
+			t.Errorf("Expected the level gap reported, got %+v", sim.Validation)
+		}
+	})
+
+	t.Run("Invalid requests fail as they would on creation", func(t *testing.T) {
+		if _, err := service.Simulate(ctx, "wf-1", "user-1", money.NewDecimal(0), "", nil); err != ErrRequestAmountPositive {
+			t.Errorf("Expected ErrRequestAmountPositive, got %v", err)
+		}
+		if _, err := service.Simulate(ctx, "wf-1", "user-1", money.NewDecimal(100), "EUR", nil); !errors.Is(err, reqDomain.ErrExchangeRateMissing) {
+			t.Errorf("Expected ErrExchangeRateMissing, got %v", err)
+		}
+		if _, err := service.Simulate(ctx, "missing", "user-1", money.NewDecimal(100), "", nil); err != ErrWorkflowNotFound {
+			t.Errorf("Expected ErrWorkflowNotFound, got %v", err)
+		}
+		if len(mockRequestRepo.requests) != 0 {
+			t.Errorf("Expected nothing saved, got %d requests", len(mockRequestRepo.requests))
+		}
+	})
+}
+
+// fakeValidator validates workflows from the steps in the mock step repository, with every actor staffed
+type fakeValidator struct {
+	steps *MockWorkflowStepRepository
+}
+
+func (v *fakeValidator) Validate(ctx context.Context, workflowID string) (*valDomain.Report, error) {
+	steps, _ := v.steps.GetByWorkflowID(ctx, workflowID)
+	actors := make(map[string]valDomain.ActorStatus)
+	for _, step := range steps {
+		actors[step.ActorID] = valDomain.ActorStatus{Exists: true, ActiveMembers: 1}
+	}
+	return valDomain.Validate(workflowID, steps, actors), nil
+}
+
+func TestWorkflowValidationGate(t *testing.T) {
+	ctx := context.Background()
+	mockRequestRepo := NewMockRequestRepository()
+	mockWorkflowRepo := NewMockWorkflowRepository()
+	mockStepRepo := NewMockWorkflowStepRepository()
+	mockApprovalHistoryRepo := NewMockApprovalHistoryRepository()
+
+	mockWorkflowRepo.Create(ctx, createTestWorkflow("wf-1"))
+	mockStepRepo.Create(ctx, createTestStep("wf-1", 1, 0, "manager"))
+	mockStepRepo.Create(ctx, createTestStep("wf-1", 3, 0, "director"))
+
+	service := NewRequestService(mockRequestRepo, mockWorkflowRepo, mockStepRepo, mockApprovalHistoryRepo, reqDomain.StepUpPolicy{}, nil, testRates{}, &fakeValidator{steps: mockStepRepo})
+
+	t.Run("Workflows with a gap refuse new requests", func(t *testing.T) {
+		_, err := service.CreateRequest(ctx, "wf-1", "user-1", money.NewDecimal(1000), "", "Laptop", "", nil)
+		var invalid *valDomain.InvalidError
+		if !errors.As(err, &invalid) || len(invalid.Issues) != 2 || invalid.Issues[0].Code != valDomain.IssueLevelGap {
+			t.Fatalf("Expected the level gap to be reported, got %v", err)
+		}
+		if len(mockRequestRepo.requests) != 0 {
+			t.Errorf("Expected no request saved")
+		}
+	})
+
+	t.Run("Drafts are saved but cannot be submitted", func(t *testing.T) {
+		draft, err := service.CreateDraft(ctx, "wf-1", "user-1", money.NewDecimal(1000), "", "Laptop", "", nil)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if _, err := service.SubmitRequest(ctx, draft.ID, "user-1"); !errors.Is(err, valDomain.ErrWorkflowInvalid) {
+			t.Errorf("Expected ErrWorkflowInvalid, got %v", err)
+		}
+	})
+
+	t.Run("Fixed workflows take requests again", func(t *testing.T) {
+		mockStepRepo.Create(ctx, createTestStep("wf-1", 2, 0, "finance"))
+		if _, err := service.CreateRequest(ctx, "wf-1", "user-1", money.NewDecimal(1000), "", "Laptop", "", nil); err != nil {
+			t.Errorf("Expected no error, got %v", err)
+		}
+	})
+}
+
+func TestApproveIgnoresMaxAmount(t *testing.T) {
+	ctx := context.Background()
+	mockRequestRepo := NewMockRequestRepository()
+	mockWorkflowRepo := NewMockWorkflowRepository()
+	mockStepRepo := NewMockWorkflowStepRepository()
+	mockApprovalHistoryRepo := NewMockApprovalHistoryRepository()
+
+	mockWorkflowRepo.Create(ctx, createTestWorkflow("wf-1"))
+	step := createTestStep("wf-1", 1, 0, "manager")
+	step.Conditions.MaxAmount = money.NewDecimal(5000)
+	mockStepRepo.Create(ctx, step)
+	mockRequestRepo.Create(ctx, createTestRequest("req-1", "wf-1", 5000, 1, reqDomain.StatusPending))
+	mockRequestRepo.Create(ctx, createTestRequest("req-2", "wf-1", 5001, 1, reqDomain.StatusPending))
+
+	service := NewRequestService(mockRequestRepo, mockWorkflowRepo, mockStepRepo, mockApprovalHistoryRepo, reqDomain.StepUpPolicy{}, nil, testRates{}, nil)
+
+	// Requests can't skip a level, so one above max_amount must still get past it
+	for _, id := range []string{"req-1", "req-2"} {
+		if _, err := service.Approve(ctx, id, "user-1", []string{"manager"}, false, nil); err != nil {
+			t.Errorf("Expected %s to be approved, got %v", id, err)
+		}
+	}
+}
+
+// versionedRequestRepo checks versions on update as the repository does and
+// lets another instance change one request right after it has been read
+type versionedRequestRepo struct {
+	*MockRequestRepository
+	racedID string
+}
+
+func (r *versionedRequestRepo) GetByID(ctx context.Context, id string) (*reqDomain.Request, error) {
+	stored, err := r.MockRequestRepository.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	read := *stored
+	if id == r.racedID {
+		stored.Version++ // Another instance approved it meanwhile
+	}
+	return &read, nil
+}
+
+func (r *versionedRequestRepo) Update(ctx context.Context, request *reqDomain.Request) error {
+	stored, err := r.MockRequestRepository.GetByID(ctx, request.ID)
+	if err != nil {
+		return err
+	}
+	if stored.Version != request.Version-1 {
+		return reqRepo.ErrVersionConflict
+	}
+	saved := *request
+	return r.MockRequestRepository.Update(ctx, &saved)
+}
+
+func TestLostRaceRecordsNoHistory(t *testing.T) {
+	ctx := context.Background()
+	mockRequestRepo := &versionedRequestRepo{MockRequestRepository: NewMockRequestRepository()}
+	mockWorkflowRepo := NewMockWorkflowRepository()
+	mockStepRepo := NewMockWorkflowStepRepository()
+	mockApprovalHistoryRepo := NewMockApprovalHistoryRepository()
+
+	mockWorkflowRepo.Create(ctx, createTestWorkflow("wf-1"))
+	mockStepRepo.Create(ctx, createTestStep("wf-1", 1, 0, "manager"))
+	mockStepRepo.Create(ctx, createTestStep("wf-1", 2, 0, "finance"))
+	mockRequestRepo.Create(ctx, createTestRequest("req-1", "wf-1", 100, 1, reqDomain.StatusPending))
+	mockRequestRepo.Create(ctx, createTestRequest("req-2", "wf-1", 100, 2, reqDomain.StatusPending))
+
+	service := NewRequestService(mockRequestRepo, mockWorkflowRepo, mockStepRepo, mockApprovalHistoryRepo, reqDomain.StepUpPolicy{}, nil, testRates{}, nil)
+
+	// Moving to the next step, approving at the last one and rejecting
+	decisions := []struct {
+		id     string
+		decide func(id string) error
+	}{
+		{"req-1", func(id string) error {
+			_, err := service.Approve(ctx, id, "user-1", []string{"manager"}, false, nil)
+			return err
+		}},
+		{"req-2", func(id string) error {
+			_, err := service.Approve(ctx, id, "user-1", []string{"finance"}, false, nil)
+			return err
+		}},
+		{"req-2", func(id string) error {
+			_, err := service.Reject(ctx, id, "user-1", []string{"finance"}, false, "no budget")
+			return err
+		}},
+	}
+	for _, d := range decisions {
+		mockRequestRepo.racedID = d.id
+		if err := d.decide(d.id); !errors.Is(err, ErrRequestConflict) {
+			t.Errorf("Expected ErrRequestConflict for %s, got %v", d.id, err)
+		}
+		if h := mockApprovalHistoryRepo.histories[d.id]; len(h) != 0 {
+			t.Errorf("Expected no history for the lost decision on %s, got %d entries", d.id, len(h))
+		}
+	}
+}
+
+func TestBulkApproveAndReject(t *testing.T) {
+	ctx := context.Background()
+	mockRequestRepo := &versionedRequestRepo{MockRequestRepository: NewMockRequestRepository(), racedID: "req-conflict"}
+	mockWorkflowRepo := NewMockWorkflowRepository()
+	mockStepRepo := NewMockWorkflowStepRepository()
+	mockApprovalHistoryRepo := NewMockApprovalHistoryRepository()
+
+	mockWorkflowRepo.Create(ctx, createTestWorkflow("wf-1"))
+	mockWorkflowRepo.Create(ctx, createTestWorkflow("wf-2"))
+	mockStepRepo.Create(ctx, createTestStep("wf-1", 1, 0, "manager"))
+	mockStepRepo.Create(ctx, createTestStep("wf-2", 1, 0, "finance"))
+	mockRequestRepo.Create(ctx, createTestRequest("req-ok", "wf-1", 100, 1, reqDomain.StatusPending))
+	mockRequestRepo.Create(ctx, createTestRequest("req-done", "wf-1", 100, 1, reqDomain.StatusApproved))
+	mockRequestRepo.Create(ctx, createTestRequest("req-other", "wf-2", 100, 1, reqDomain.StatusPending))
+	mockRequestRepo.Create(ctx, createTestRequest("req-conflict", "wf-1", 100, 1, reqDomain.StatusPending))
+
+	service := NewRequestService(mockRequestRepo, mockWorkflowRepo, mockStepRepo, mockApprovalHistoryRepo, reqDomain.StepUpPolicy{}, nil, testRates{}, nil)
+
+	ids := []string{"req-ok", "req-done", "req-other", "req-conflict", "req-missing", "req-ok"}
+	results, err := service.BulkApprove(ctx, ids, "user-1", []string{"manager"}, false, nil, "monthly claims")
+	if err != nil {
+		t.Fatalf("BulkApprove failed: %v", err)
+	}
+	want := []reqDomain.BulkStatus{reqDomain.BulkOK, reqDomain.BulkNotPending, reqDomain.BulkUnauthorized, reqDomain.BulkConflict, reqDomain.BulkNotFound}
+	if len(results) != len(want) {
+		t.Fatalf("Expected %d results (repeated IDs once), got %d", len(want), len(results))
+	}
+	for i, result := range results {
+		if result.RequestID != ids[i] || result.Status != want[i] {
+			t.Errorf("Result %d: expected %s %s, got %s %s (%v)", i, ids[i], want[i], result.RequestID, result.Status, result.Err)
+		}
+	}
+	if results[0].Request == nil || results[0].Request.Status != reqDomain.StatusApproved {
+		t.Errorf("Expected the approved request in the ok result, got %+v", results[0].Request)
+	}
+	if h := mockApprovalHistoryRepo.histories["req-ok"]; len(h) != 1 || h[0].Comment != "monthly claims" {
+		t.Errorf("Expected one approval recorded with the shared comment, got %+v", h)
+	}
+	if h := mockApprovalHistoryRepo.histories["req-conflict"]; len(h) != 0 {
+		t.Errorf("Expected no history for the conflicting request, got %d entries", len(h))
+	}
+
+	results, err = service.BulkReject(ctx, []string{"req-other", "req-ok"}, "user-2", []string{"finance"}, false, "duplicate")
+	if err != nil {
+		t.Fatalf("BulkReject failed: %v", err)
+	}
+	if results[0].Status != reqDomain.BulkOK || results[0].Request.Status != reqDomain.StatusRejected || results[1].Status != reqDomain.BulkNotPending {
+		t.Errorf("Unexpected reject results: %+v", results)
+	}
+
+	if _, err := service.BulkApprove(ctx, nil, "user-1", nil, false, nil, ""); !errors.Is(err, ErrBulkRequestCount) {
+		t.Errorf("Expected ErrBulkRequestCount for no IDs, got %v", err)
+	}
+}
+
+func TestRequestMetrics(t *testing.T) {
+	ctx := context.Background()
+	mockRequestRepo := NewMockRequestRepository()
+	mockWorkflowRepo := NewMockWorkflowRepository()
+	mockStepRepo := NewMockWorkflowStepRepository()
+
+	mockWorkflowRepo.Create(ctx, createTestWorkflow("wf-metrics"))
+	mockStepRepo.Create(ctx, createTestStep("wf-metrics", 1, 1000000, "approver-1"))
+	service := NewRequestService(mockRequestRepo, mockWorkflowRepo, mockStepRepo, NewMockApprovalHistoryRepository(), reqDomain.StepUpPolicy{}, nil, testRates{}, nil)
+
+	submittedAt := time.Now().UTC().Add(-time.Hour)
+	for _, id := range []string{"req-m1", "req-m2", "req-m3"} {
+		req := createTestRequest(id, "wf-metrics", 1500000, 1, reqDomain.StatusPending)
+		req.SubmittedAt = &submittedAt
+		mockRequestRepo.Create(ctx, req)
+	}
+
+	if _, err := service.Approve(ctx, "req-m1", "user-1", []string{"approver-1"}, false, nil); err != nil {
+		t.Fatalf("Approve failed: %v", err)
+	}
+	if _, err := service.Reject(ctx, "req-m2", "user-1", []string{"approver-1"}, false, "No budget"); err != nil {
+		t.Fatalf("Reject failed: %v", err)
+	}
+
+	if got := requestsApproved.With("wf-metrics").Value(); got != 1 {
+		t.Errorf("Expected 1 approval, got %v", got)
+	}
+	if got := requestsRejected.With("wf-metrics").Value(); got != 1 {
+		t.Errorf("Expected 1 rejection, got %v", got)
+	}
+	if got := decisionLatency.With("wf-metrics", "approved").Count(); got != 1 {
+		t.Errorf("Expected 1 approval latency, got %d", got)
+	}
+
+	if err := PendingRequestsHook(mockRequestRepo)(ctx); err != nil {
+		t.Fatalf("Pending hook failed: %v", err)
+	}
+	if got := requestsPending.With("wf-metrics").Value(); got != 1 {
+		t.Errorf("Expected 1 pending request, got %v", got)
+	}
+}
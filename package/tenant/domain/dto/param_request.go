@@ -0,0 +1,12 @@
+package dto
+
+// CreateTenantRequest represents the create tenant request body
+type CreateTenantRequest struct {
+	Code string `json:"code"`
+	Name string `json:"name"`
+}
+
+// UpdateTenantRequest represents the update tenant request body
+type UpdateTenantRequest struct {
+	Name string `json:"name"`
+}
@@ -0,0 +1,35 @@
+package dto
+
+import "workflow-approval/package/tenant/domain"
+
+// TenantResponse represents the tenant response
+type TenantResponse struct {
+	ID        string `json:"id"`
+	Code      string `json:"code"`
+	Name      string `json:"name"`
+	CreatedAt string `json:"created_at"`
+	UpdatedAt string `json:"updated_at"`
+}
+
+// ToTenantResponse converts a Tenant to TenantResponse
+func ToTenantResponse(t *domain.Tenant) *TenantResponse {
+	if t == nil {
+		return nil
+	}
+	return &TenantResponse{
+		ID:        t.ID,
+		Code:      t.Code,
+		Name:      t.Name,
+		CreatedAt: t.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
+		UpdatedAt: t.UpdatedAt.Format("2006-01-02T15:04:05Z07:00"),
+	}
+}
+
+// ToTenantResponseList converts a list of Tenants to TenantResponse list
+func ToTenantResponseList(tenants []*domain.Tenant) []*TenantResponse {
+	result := make([]*TenantResponse, len(tenants))
+	for i, t := range tenants {
+		result[i] = ToTenantResponse(t)
+	}
+	return result
+}
@@ -0,0 +1,33 @@
+package domain
+
+import (
+	"time"
+
+	"workflow-approval/utils"
+)
+
+// Tenant is an organization (e.g. a subsidiary) whose users, actors, workflows
+// and requests are isolated from every other tenant
+type Tenant struct {
+	ID        string    `json:"id" gorm:"primaryKey;size:36"`
+	Code      string    `json:"code" gorm:"size:50;uniqueIndex;not null"`
+	Name      string    `json:"name" gorm:"size:255;not null"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// NewTenant creates a new Tenant instance
+func NewTenant(code, name string) *Tenant {
+	return &Tenant{
+		ID:        utils.GenerateUUID(),
+		Code:      code,
+		Name:      name,
+		CreatedAt: utils.TimeNowUTC(),
+		UpdatedAt: utils.TimeNowUTC(),
+	}
+}
+
+// TableName returns the table name for GORM
+func (Tenant) TableName() string {
+	return "tenants"
+}
@@ -0,0 +1,257 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+
+	reqDto "workflow-approval/package/request/domain/dto"
+	reqPorts "workflow-approval/package/request/ports"
+	reqUsecase "workflow-approval/package/request/usecase"
+	"workflow-approval/package/tenant/domain/dto"
+	"workflow-approval/package/tenant/ports"
+	"workflow-approval/package/tenant/usecase"
+	userDomain "workflow-approval/package/user/domain"
+	userDto "workflow-approval/package/user/domain/dto"
+	userPorts "workflow-approval/package/user/ports"
+	"workflow-approval/utils/pagination"
+	"workflow-approval/utils/tenancy"
+)
+
+// TenantHandler handles HTTP requests for tenant management and cross-tenant views
+type TenantHandler struct {
+	tenantService  ports.TenantService
+	userService    userPorts.UserService
+	requestService reqPorts.RequestService
+}
+
+// NewTenantHandler creates a new TenantHandler instance
+func NewTenantHandler(tenantService ports.TenantService, userService userPorts.UserService, requestService reqPorts.RequestService) *TenantHandler {
+	return &TenantHandler{
+		tenantService:  tenantService,
+		userService:    userService,
+		requestService: requestService,
+	}
+}
+
+// Routes defines the super admin routes
+// Mounts routes under /api/tenants
+func (h *TenantHandler) Routes(group fiber.Router) {
+	// GET /api/tenants - List tenants
+	group.Get("/", h.List)
+
+	// POST /api/tenants - Create a tenant
+	group.Post("/", h.Create)
+
+	// GET /api/tenants/users - List users across tenants (?tenant_id= to narrow down)
+	group.Get("/users", h.ListUsers)
+
+	// GET /api/tenants/requests - List requests across tenants (?tenant_id=, ?status=)
+	group.Get("/requests", h.ListRequests)
+
+	// GET /api/tenants/:id - Get a tenant
+	group.Get("/:id", h.Get)
+
+	// PUT /api/tenants/:id - Rename a tenant
+	group.Put("/:id", h.Update)
+}
+
+// List handles listing tenants
+// GET /api/tenants
+func (h *TenantHandler) List(c *fiber.Ctx) error {
+	tenants, err := h.tenantService.ListTenants(c.Context())
+	if err != nil {
+		return tenantError(c, err)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"success": true,
+		"data":    dto.ToTenantResponseList(tenants),
+		"error":   nil,
+	})
+}
+
+// Create handles creating a tenant
+// POST /api/tenants
+func (h *TenantHandler) Create(c *fiber.Ctx) error {
+	var req dto.CreateTenantRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"success": false,
+			"data":    nil,
+			"error":   "Invalid request body",
+		})
+	}
+
+	tenant, err := h.tenantService.CreateTenant(c.Context(), req.Code, req.Name)
+	if err != nil {
+		return tenantError(c, err)
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
+		"success": true,
+		"data":    dto.ToTenantResponse(tenant),
+		"error":   nil,
+	})
+}
+
+// Get handles getting a tenant by ID
+// GET /api/tenants/:id
+func (h *TenantHandler) Get(c *fiber.Ctx) error {
+	tenant, err := h.tenantService.GetTenant(c.Context(), c.Params("id"))
+	if err != nil {
+		return tenantError(c, err)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"success": true,
+		"data":    dto.ToTenantResponse(tenant),
+		"error":   nil,
+	})
+}
+
+// Update handles renaming a tenant
+// PUT /api/tenants/:id
+func (h *TenantHandler) Update(c *fiber.Ctx) error {
+	var req dto.UpdateTenantRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"success": false,
+			"data":    nil,
+			"error":   "Invalid request body",
+		})
+	}
+
+	tenant, err := h.tenantService.UpdateTenant(c.Context(), c.Params("id"), req.Name)
+	if err != nil {
+		return tenantError(c, err)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"success": true,
+		"data":    dto.ToTenantResponse(tenant),
+		"error":   nil,
+	})
+}
+
+// ListUsers handles listing users of every tenant, or of one with ?tenant_id=
+// GET /api/tenants/users
+func (h *TenantHandler) ListUsers(c *fiber.Ctx) error {
+	page, _ := strconv.Atoi(c.Query("page", "1"))
+	limit, _ := strconv.Atoi(c.Query("limit", "10"))
+
+	ctx, err := h.crossTenantContext(c)
+	if err != nil {
+		return tenantError(c, err)
+	}
+
+	filter := userDomain.UserFilter{Email: c.Query("email")}
+	users, total, err := h.userService.ListUsers(ctx, filter, page, limit)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"success": false,
+			"data":    nil,
+			"error":   "Failed to list users",
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"success": true,
+		"data": fiber.Map{
+			"users": userDto.ToUserResponseList(users),
+			"total": total,
+			"page":  page,
+			"limit": limit,
+		},
+		"error": nil,
+	})
+}
+
+// ListRequests handles listing requests of every tenant, or of one with ?tenant_id=,
+// taking the filters of GET /api/requests
+// GET /api/tenants/requests
+func (h *TenantHandler) ListRequests(c *fiber.Ctx) error {
+	ctx, err := h.crossTenantContext(c)
+	if err != nil {
+		return tenantError(c, err)
+	}
+
+	page, err := pagination.Parse(c.Query("page"), c.Query("limit"), c.Query("cursor"), c.Query("total"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"success": false,
+			"data":    nil,
+			"error":   err.Error(),
+		})
+	}
+
+	filter, err := reqDto.ParseRequestFilter(c.Queries(), "tenant_id")
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"success": false,
+			"data":    nil,
+			"error":   err.Error(),
+		})
+	}
+
+	filter.ViewerID = c.Locals("user_id").(string)
+	requests, result, err := h.requestService.ListRequests(ctx, filter, page)
+	if err != nil {
+		if errors.Is(err, reqUsecase.ErrCursorSort) {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"success": false,
+				"data":    nil,
+				"error":   err.Error(),
+			})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"success": false,
+			"data":    nil,
+			"error":   "Failed to list requests",
+		})
+	}
+
+	data := fiber.Map(result.Meta(c.Path(), string(c.Request().URI().QueryString())))
+	data["requests"] = reqDto.ToRequestResponseList(requests)
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"success": true,
+		"data":    data,
+		"error":   nil,
+	})
+}
+
+// crossTenantContext lifts the caller's tenant scope, or moves it to ?tenant_id=
+func (h *TenantHandler) crossTenantContext(c *fiber.Ctx) (context.Context, error) {
+	tenantID := c.Query("tenant_id")
+	if tenantID == "" {
+		return tenancy.WithAllTenants(c.Context()), nil
+	}
+	if _, err := h.tenantService.GetTenant(c.Context(), tenantID); err != nil {
+		return nil, err
+	}
+	return tenancy.WithTenant(c.Context(), tenantID), nil
+}
+
+// tenantError maps tenant service errors to HTTP responses
+func tenantError(c *fiber.Ctx, err error) error {
+	status := fiber.StatusInternalServerError
+	message := "Failed to process tenant request"
+	switch {
+	case errors.Is(err, usecase.ErrTenantNotFound):
+		status, message = fiber.StatusNotFound, err.Error()
+	case errors.Is(err, usecase.ErrTenantAlreadyExist):
+		status, message = fiber.StatusConflict, err.Error()
+	case errors.Is(err, usecase.ErrTenantCodeRequired),
+		errors.Is(err, usecase.ErrTenantCodeInvalid),
+		errors.Is(err, usecase.ErrTenantNameRequired):
+		status, message = fiber.StatusBadRequest, err.Error()
+	}
+
+	return c.Status(status).JSON(fiber.Map{
+		"success": false,
+		"data":    nil,
+		"error":   message,
+	})
+}
@@ -0,0 +1,24 @@
+package ports
+
+import (
+	"context"
+
+	"workflow-approval/package/tenant/domain"
+)
+
+// TenantRepository defines the interface for tenant data access
+type TenantRepository interface {
+	Create(ctx context.Context, tenant *domain.Tenant) error
+	GetByID(ctx context.Context, id string) (*domain.Tenant, error)
+	GetByCode(ctx context.Context, code string) (*domain.Tenant, error)
+	GetAll(ctx context.Context) ([]*domain.Tenant, error)
+	Update(ctx context.Context, tenant *domain.Tenant) error
+}
+
+// TenantService defines the interface for tenant business logic (super admin only)
+type TenantService interface {
+	CreateTenant(ctx context.Context, code, name string) (*domain.Tenant, error)
+	GetTenant(ctx context.Context, id string) (*domain.Tenant, error)
+	ListTenants(ctx context.Context) ([]*domain.Tenant, error)
+	UpdateTenant(ctx context.Context, id, name string) (*domain.Tenant, error)
+}
@@ -0,0 +1,83 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"gorm.io/gorm"
+
+	"workflow-approval/package/tenant/domain"
+	"workflow-approval/package/tenant/ports"
+)
+
+var (
+	ErrTenantNotFound     = errors.New("tenant not found")
+	ErrTenantAlreadyExist = errors.New("tenant already exists")
+)
+
+// TenantRepositoryImpl implements TenantRepository interface
+type TenantRepositoryImpl struct {
+	db *gorm.DB
+}
+
+// NewTenantRepository creates a new TenantRepositoryImpl instance
+func NewTenantRepository(db *gorm.DB) ports.TenantRepository {
+	return &TenantRepositoryImpl{db: db}
+}
+
+// Create creates a new tenant
+func (r *TenantRepositoryImpl) Create(ctx context.Context, tenant *domain.Tenant) error {
+	result := r.db.WithContext(ctx).Create(tenant)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrDuplicatedKey) {
+			return ErrTenantAlreadyExist
+		}
+		return result.Error
+	}
+	return nil
+}
+
+// GetByID retrieves a tenant by ID
+func (r *TenantRepositoryImpl) GetByID(ctx context.Context, id string) (*domain.Tenant, error) {
+	var tenant domain.Tenant
+	result := r.db.WithContext(ctx).First(&tenant, "id = ?", id)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, ErrTenantNotFound
+		}
+		return nil, result.Error
+	}
+	return &tenant, nil
+}
+
+// GetByCode retrieves a tenant by code
+func (r *TenantRepositoryImpl) GetByCode(ctx context.Context, code string) (*domain.Tenant, error) {
+	var tenant domain.Tenant
+	result := r.db.WithContext(ctx).First(&tenant, "code = ?", code)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, ErrTenantNotFound
+		}
+		return nil, result.Error
+	}
+	return &tenant, nil
+}
+
+// GetAll retrieves all tenants ordered by code
+func (r *TenantRepositoryImpl) GetAll(ctx context.Context) ([]*domain.Tenant, error) {
+	var tenants []*domain.Tenant
+	result := r.db.WithContext(ctx).Order("code ASC").Find(&tenants)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return tenants, nil
+}
+
+// Update updates an existing tenant
+func (r *TenantRepositoryImpl) Update(ctx context.Context, tenant *domain.Tenant) error {
+	result := r.db.WithContext(ctx).Save(tenant)
+	if result.Error != nil {
+		return result.Error
+	}
+	return nil
+}
@@ -0,0 +1,100 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"strings"
+
+	"workflow-approval/package/tenant/domain"
+	"workflow-approval/package/tenant/ports"
+	"workflow-approval/package/tenant/repository"
+	"workflow-approval/utils"
+)
+
+var (
+	ErrTenantCodeRequired = errors.New("tenant code is required")
+	ErrTenantCodeInvalid  = errors.New("tenant code must be alphanumeric with underscores or dashes")
+	ErrTenantNameRequired = errors.New("tenant name is required")
+	ErrTenantNotFound     = errors.New("tenant not found")
+	ErrTenantAlreadyExist = errors.New("tenant already exists")
+)
+
+var tenantCodePattern = regexp.MustCompile(`^[a-zA-Z0-9_-]+$`)
+
+// TenantServiceImpl implements TenantService interface
+type TenantServiceImpl struct {
+	tenantRepo ports.TenantRepository
+}
+
+// NewTenantService creates a new TenantServiceImpl instance
+func NewTenantService(tenantRepo ports.TenantRepository) ports.TenantService {
+	return &TenantServiceImpl{tenantRepo: tenantRepo}
+}
+
+// CreateTenant creates a new tenant
+func (s *TenantServiceImpl) CreateTenant(ctx context.Context, code, name string) (*domain.Tenant, error) {
+	code = strings.TrimSpace(code)
+	if code == "" {
+		return nil, ErrTenantCodeRequired
+	}
+	if !tenantCodePattern.MatchString(code) {
+		return nil, ErrTenantCodeInvalid
+	}
+	if strings.TrimSpace(name) == "" {
+		return nil, ErrTenantNameRequired
+	}
+
+	_, err := s.tenantRepo.GetByCode(ctx, code)
+	if err == nil {
+		return nil, ErrTenantAlreadyExist
+	}
+	if !errors.Is(err, repository.ErrTenantNotFound) {
+		return nil, err
+	}
+
+	tenant := domain.NewTenant(code, name)
+	if err := s.tenantRepo.Create(ctx, tenant); err != nil {
+		if errors.Is(err, repository.ErrTenantAlreadyExist) {
+			return nil, ErrTenantAlreadyExist
+		}
+		return nil, err
+	}
+	return tenant, nil
+}
+
+// GetTenant retrieves a tenant by ID
+func (s *TenantServiceImpl) GetTenant(ctx context.Context, id string) (*domain.Tenant, error) {
+	tenant, err := s.tenantRepo.GetByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, repository.ErrTenantNotFound) {
+			return nil, ErrTenantNotFound
+		}
+		return nil, err
+	}
+	return tenant, nil
+}
+
+// ListTenants retrieves all tenants
+func (s *TenantServiceImpl) ListTenants(ctx context.Context) ([]*domain.Tenant, error) {
+	return s.tenantRepo.GetAll(ctx)
+}
+
+// UpdateTenant renames a tenant; its code is permanent
+func (s *TenantServiceImpl) UpdateTenant(ctx context.Context, id, name string) (*domain.Tenant, error) {
+	if strings.TrimSpace(name) == "" {
+		return nil, ErrTenantNameRequired
+	}
+
+	tenant, err := s.GetTenant(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	tenant.Name = name
+	tenant.UpdatedAt = utils.TimeNowUTC()
+	if err := s.tenantRepo.Update(ctx, tenant); err != nil {
+		return nil, err
+	}
+	return tenant, nil
+}
@@ -0,0 +1,109 @@
+package domain
+
+import (
+	"time"
+
+	"workflow-approval/utils"
+)
+
+// ActorMembership binds a user to an actor (approval role). A user can hold
+// several actors and an actor can have several members; IsPrimary marks the
+// member who normally handles the actor's steps and IsBackup a stand-in.
+type ActorMembership struct {
+	ID        string    `json:"-" gorm:"primaryKey;size:36"`
+	TenantID  string    `json:"-" gorm:"size:36;not null;index"`
+	UserID    string    `json:"-" gorm:"size:36;not null;uniqueIndex:idx_actor_members_user_actor"`
+	ActorID   string    `json:"actor_id" gorm:"size:36;not null;uniqueIndex:idx_actor_members_user_actor;index"`
+	IsPrimary bool      `json:"is_primary" gorm:"default:false"`
+	IsBackup  bool      `json:"is_backup" gorm:"default:false"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// ActorAssignment is the requested membership of a user in an actor
+type ActorAssignment struct {
+	ActorID   string `json:"actor_id"`
+	IsPrimary bool   `json:"is_primary"`
+	IsBackup  bool   `json:"is_backup"`
+}
+
+// NewActorMembership creates a membership of the user in the assigned actor
+func NewActorMembership(userID string, assignment ActorAssignment, at time.Time) ActorMembership {
+	return ActorMembership{
+		ID:        utils.GenerateUUID(),
+		UserID:    userID,
+		ActorID:   assignment.ActorID,
+		IsPrimary: assignment.IsPrimary,
+		IsBackup:  assignment.IsBackup,
+		CreatedAt: at,
+	}
+}
+
+// TableName returns the table name for GORM
+func (ActorMembership) TableName() string {
+	return "actor_members"
+}
+
+// ActorIDs returns the IDs of all actors the user belongs to, primary memberships first
+func (u *User) ActorIDs() []string {
+	ids := make([]string, 0, len(u.Memberships))
+	for _, m := range u.Memberships {
+		if m.IsPrimary {
+			ids = append(ids, m.ActorID)
+		}
+	}
+	for _, m := range u.Memberships {
+		if !m.IsPrimary {
+			ids = append(ids, m.ActorID)
+		}
+	}
+	return ids
+}
+
+// PrimaryActorID returns the first of the user's actors, empty when the user has none
+func (u *User) PrimaryActorID() string {
+	if ids := u.ActorIDs(); len(ids) > 0 {
+		return ids[0]
+	}
+	return ""
+}
+
+// HasActor reports whether the user is a member of the actor
+func (u *User) HasActor(actorID string) bool {
+	for _, m := range u.Memberships {
+		if m.ActorID == actorID {
+			return true
+		}
+	}
+	return false
+}
+
+// SetActors replaces the user's memberships; memberships of actors the user
+// already held keep their ID and creation time
+func (u *User) SetActors(assignments []ActorAssignment, at time.Time) {
+	existing := make(map[string]ActorMembership, len(u.Memberships))
+	for _, m := range u.Memberships {
+		existing[m.ActorID] = m
+	}
+
+	memberships := make([]ActorMembership, 0, len(assignments))
+	for _, a := range assignments {
+		m, ok := existing[a.ActorID]
+		if !ok {
+			m = NewActorMembership(u.ID, a, at)
+		}
+		m.IsPrimary = a.IsPrimary
+		m.IsBackup = a.IsBackup
+		memberships = append(memberships, m)
+	}
+	u.Memberships = memberships
+	u.UpdatedAt = at
+}
+
+// AddActor makes the user a member of the actor unless it already is one
+func (u *User) AddActor(actorID string, at time.Time) {
+	if u.HasActor(actorID) {
+		return
+	}
+	u.Memberships = append(u.Memberships, NewActorMembership(u.ID, ActorAssignment{ActorID: actorID}, at))
+	u.UpdatedAt = at
+}
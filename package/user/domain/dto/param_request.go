@@ -1,15 +1,55 @@
 package dto
 
+import "workflow-approval/package/user/domain"
+
 // CreateUserRequest represents the create user request body
 type CreateUserRequest struct {
-	Email    string  `json:"email"`
-	Password string  `json:"password"`
-	Name     string  `json:"name"`
-	IsAdmin  bool    `json:"is_admin"`
-	ActorID  *string `json:"actor_id"`
+	Email    string                   `json:"email"`
+	Password string                   `json:"password"`
+	Name     string                   `json:"name"`
+	IsAdmin  bool                     `json:"is_admin"`
+	ActorID  *string                  `json:"actor_id"` // Shorthand for a single primary membership
+	Actors   []domain.ActorAssignment `json:"actors"`
+}
+
+// ActorAssignments returns the requested memberships, preferring actors over actor_id
+func (r CreateUserRequest) ActorAssignments() []domain.ActorAssignment {
+	return actorAssignments(r.Actors, r.ActorID)
 }
 
 // UpdateProfileRequest represents the update profile request body
 type UpdateProfileRequest struct {
 	Name string `json:"name"`
 }
+
+// AdminUpdateUserRequest represents an admin's update of another user
+type AdminUpdateUserRequest struct {
+	Name    string                   `json:"name"`
+	IsAdmin bool                     `json:"is_admin"`
+	ActorID *string                  `json:"actor_id"` // Shorthand for a single primary membership
+	Actors  []domain.ActorAssignment `json:"actors"`   // Replaces all memberships; omit both to keep them
+}
+
+// ActorAssignments returns the requested memberships, nil when neither actors nor actor_id is given
+func (r AdminUpdateUserRequest) ActorAssignments() []domain.ActorAssignment {
+	return actorAssignments(r.Actors, r.ActorID)
+}
+
+// DeactivateUserRequest represents the deactivate user request body
+type DeactivateUserRequest struct {
+	Force bool `json:"force"` // Proceed although workflow steps lose their last active approver
+}
+
+// actorAssignments merges the actors list and the single actor_id shorthand
+func actorAssignments(actors []domain.ActorAssignment, actorID *string) []domain.ActorAssignment {
+	if actors != nil {
+		return actors
+	}
+	if actorID != nil {
+		if *actorID == "" {
+			return []domain.ActorAssignment{}
+		}
+		return []domain.ActorAssignment{{ActorID: *actorID, IsPrimary: true}}
+	}
+	return nil
+}
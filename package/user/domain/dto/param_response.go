@@ -1,13 +1,26 @@
 package dto
 
-import "workflow-approval/package/user/domain"
+import (
+	"time"
+
+	"workflow-approval/package/user/domain"
+)
 
 // UserResponse represents the user response
 type UserResponse struct {
-	ID      string `json:"id"`
-	Email   string `json:"email"`
-	Name    string `json:"name"`
-	IsAdmin bool   `json:"is_admin"`
+	ID                 string                   `json:"id"`
+	TenantID           string                   `json:"tenant_id"`
+	Email              string                   `json:"email"`
+	Name               string                   `json:"name"`
+	IsAdmin            bool                     `json:"is_admin"`
+	ActorID            *string                  `json:"actor_id"` // First of the user's actors, kept for older clients
+	Actors             []domain.ActorMembership `json:"actors"`
+	ManagerID          *string                  `json:"manager_id"`
+	DepartmentID       *string                  `json:"department_id"`
+	IsActive           bool                     `json:"is_active"`
+	DeactivatedAt      *time.Time               `json:"deactivated_at,omitempty"`
+	EmailVerified      bool                     `json:"email_verified"`
+	MustChangePassword bool                     `json:"must_change_password"`
 }
 
 // ToUserResponse converts a User to UserResponse
@@ -16,9 +29,41 @@ func ToUserResponse(u *domain.User) *UserResponse {
 		return nil
 	}
 	return &UserResponse{
-		ID:      u.ID,
-		Email:   u.Email,
-		Name:    u.Name,
-		IsAdmin: u.IsAdmin,
+		ID:                 u.ID,
+		TenantID:           u.TenantID,
+		Email:              u.Email,
+		Name:               u.Name,
+		IsAdmin:            u.IsAdmin,
+		ActorID:            primaryActorID(u),
+		Actors:             u.Memberships,
+		ManagerID:          u.ManagerID,
+		DepartmentID:       u.DepartmentID,
+		IsActive:           u.IsActive,
+		DeactivatedAt:      u.DeactivatedAt,
+		EmailVerified:      u.IsEmailVerified(),
+		MustChangePassword: u.MustChangePassword,
 	}
 }
+
+// primaryActorID returns the user's first actor, nil when the user has none
+func primaryActorID(u *domain.User) *string {
+	if id := u.PrimaryActorID(); id != "" {
+		return &id
+	}
+	return nil
+}
+
+// ToUserResponseList converts a slice of Users to UserResponses
+func ToUserResponseList(users []*domain.User) []*UserResponse {
+	responses := make([]*UserResponse, 0, len(users))
+	for _, u := range users {
+		responses = append(responses, ToUserResponse(u))
+	}
+	return responses
+}
+
+// DeactivateUserResponse reports a deactivated user and the workflow steps left without an approver
+type DeactivateUserResponse struct {
+	User     *UserResponse             `json:"user"`
+	Warnings []domain.ActorCoverageGap `json:"warnings,omitempty"`
+}
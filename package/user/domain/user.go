@@ -6,30 +6,116 @@ import (
 	"workflow-approval/utils"
 )
 
+// Authentication providers a user account can originate from
+const (
+	AuthProviderLocal = "local" // email + bcrypt password
+	AuthProviderOIDC  = "oidc"  // provisioned just-in-time from an OIDC login
+)
+
 // User represents a user in the system
 type User struct {
-	ID        string    `json:"id" gorm:"primaryKey;size:36"`
-	Email     string    `json:"email" gorm:"uniqueIndex;size:255;not null"`
-	Password  string    `json:"-" gorm:"size:255;not null"`
-	Name      string    `json:"name" gorm:"size:255;not null"`
-	IsAdmin   bool      `json:"is_admin" gorm:"default:false"`
-	ActorID   *string   `json:"actor_id" gorm:"size:36"` // Optional, only for non-admin users
-	CreatedAt time.Time `json:"created_at"`
-	UpdatedAt time.Time `json:"updated_at"`
-}
-
-// NewUser creates a new User instance
-func NewUser(email, password, name string, isAdmin bool, actorID *string) *User {
-	return &User{
-		ID:        utils.GenerateUUID(),
-		Email:     email,
-		Password:  password,
-		Name:      name,
-		IsAdmin:   isAdmin,
-		ActorID:   actorID,
-		CreatedAt: utils.TimeNowUTC(),
-		UpdatedAt: utils.TimeNowUTC(),
+	ID           string    `json:"id" gorm:"primaryKey;size:36"`
+	TenantID     string    `json:"tenant_id" gorm:"size:36;not null;index"`
+	Email        string    `json:"email" gorm:"uniqueIndex;size:255;not null"` // Unique across tenants: login resolves the tenant from the account
+	Password     string    `json:"-" gorm:"size:255;not null"`                 // Empty for OIDC-only accounts
+	Name         string    `json:"name" gorm:"size:255;not null"`
+	IsAdmin      bool      `json:"is_admin" gorm:"default:false"`
+	IsSuperAdmin bool      `json:"is_super_admin" gorm:"default:false"` // Manages tenants and may act in any tenant
+	AuthProvider string    `json:"auth_provider" gorm:"size:20;default:local"`
+	OIDCSubject  *string   `json:"-" gorm:"column:oidc_subject;size:255;uniqueIndex"` // "sub" claim of the linked OIDC identity
+	CreatedAt    time.Time `json:"created_at"`
+	UpdatedAt    time.Time `json:"updated_at"`
+
+	// Two-factor authentication (TOTP)
+	TOTPSecret      string `json:"-" gorm:"column:totp_secret;size:64"` // Set on enrollment, active once TOTPEnabled
+	TOTPEnabled     bool   `json:"totp_enabled" gorm:"column:totp_enabled;default:false"`
+	TOTPLastCounter int64  `json:"-" gorm:"column:totp_last_counter;default:0"` // Last accepted time step, prevents code replay
+	RecoveryCodes   string `json:"-" gorm:"column:recovery_codes;type:text"`    // JSON array of SHA-256 hashes of unused codes
+
+	// Account lifecycle
+	EmailVerifiedAt    *time.Time `json:"email_verified_at"`
+	MustChangePassword bool       `json:"must_change_password" gorm:"default:false"` // Set for accounts created by an admin
+	PasswordChangedAt  *time.Time `json:"password_changed_at"`
+	IsActive           bool       `json:"is_active" gorm:"default:true"` // Deactivated users cannot log in or refresh tokens
+	DeactivatedAt      *time.Time `json:"deactivated_at"`
+
+	// Organization, used to resolve dynamic approvers (manager, department head)
+	ManagerID    *string `json:"manager_id" gorm:"size:36;index"`
+	DepartmentID *string `json:"department_id" gorm:"size:36;index"`
+
+	// Memberships are the actors (approval roles) the user holds; admins have none
+	Memberships []ActorMembership `json:"actors" gorm:"foreignKey:UserID"`
+}
+
+// UserFilter narrows down user listings
+type UserFilter struct {
+	IsAdmin  *bool
+	ActorID  string
+	IsActive *bool
+	Email    string // Partial, case-insensitive match
+}
+
+// ActorCoverageGap describes workflow steps whose actor would have no active user left
+type ActorCoverageGap struct {
+	ActorID string        `json:"actor_id"`
+	Steps   []StepSummary `json:"steps"`
+}
+
+// StepSummary identifies a workflow step in a coverage gap
+type StepSummary struct {
+	StepID     string `json:"step_id"`
+	WorkflowID string `json:"workflow_id"`
+	Level      int    `json:"level"`
+}
+
+// NewUser creates a new User instance that is a member of the assigned actors
+func NewUser(email, password, name string, isAdmin bool, actors []ActorAssignment) *User {
+	now := utils.TimeNowUTC()
+	user := &User{
+		ID:           utils.GenerateUUID(),
+		Email:        email,
+		Password:     password,
+		Name:         name,
+		IsAdmin:      isAdmin,
+		AuthProvider: AuthProviderLocal,
+		IsActive:     true,
+		CreatedAt:    now,
+		UpdatedAt:    now,
 	}
+	user.SetActors(actors, now)
+	return user
+}
+
+// NewOIDCUser creates a User provisioned from an OIDC identity; it has no local password
+func NewOIDCUser(email, name, subject string, isAdmin bool, actors []ActorAssignment) *User {
+	user := NewUser(email, "", name, isAdmin, actors)
+	user.AuthProvider = AuthProviderOIDC
+	user.OIDCSubject = &subject
+	return user
+}
+
+// IsLocalLoginAllowed reports whether the user can log in with email and password
+func (u *User) IsLocalLoginAllowed() bool {
+	return u.Password != ""
+}
+
+// IsEmailVerified reports whether the user confirmed ownership of the email address
+func (u *User) IsEmailVerified() bool {
+	return u.EmailVerifiedAt != nil
+}
+
+// Deactivate blocks the user from logging in; history stays intact
+func (u *User) Deactivate(at time.Time) {
+	u.IsActive = false
+	u.DeactivatedAt = &at
+	u.UpdatedAt = at
+}
+
+// Activate restores a deactivated user
+func (u *User) Activate(at time.Time) {
+	u.IsActive = true
+	u.DeactivatedAt = nil
+	u.UpdatedAt = at
 }
 
 // TableName returns the table name for GORM
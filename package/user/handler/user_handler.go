@@ -1,10 +1,15 @@
 package handler
 
 import (
+	"errors"
+	"strconv"
+
 	"github.com/gofiber/fiber/v2"
 
+	"workflow-approval/package/user/domain"
 	"workflow-approval/package/user/domain/dto"
 	"workflow-approval/package/user/ports"
+	"workflow-approval/package/user/usecase"
 )
 
 // UserHandler handles HTTP requests for user operations
@@ -20,7 +25,7 @@ func NewUserHandler(userService ports.UserService) *UserHandler {
 }
 
 // Create creates a new user
-// POST /api/users
+// POST /api/users (Admin only)
 func (h *UserHandler) Create(c *fiber.Ctx) error {
 	var req dto.CreateUserRequest
 	if err := c.BodyParser(&req); err != nil {
@@ -31,7 +36,7 @@ func (h *UserHandler) Create(c *fiber.Ctx) error {
 		})
 	}
 
-	user, err := h.userService.Register(c.Context(), req.Email, req.Password, req.Name, req.IsAdmin, req.ActorID)
+	user, err := h.userService.Register(c.Context(), req.Email, req.Password, req.Name, req.IsAdmin, req.ActorAssignments())
 	if err != nil {
 		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
 			"success": false,
@@ -47,12 +52,9 @@ func (h *UserHandler) Create(c *fiber.Ctx) error {
 	})
 }
 
-// Routes defines all routes for user module
+// Routes defines the signed-in user's profile routes
 // Mounts routes under /api
 func (h *UserHandler) Routes(group fiber.Router) {
-	// POST /api/users - Create a new user (Admin only in production)
-	group.Post("/users", h.Create)
-
 	// GET /api/profile - Get current user's profile
 	group.Get("/profile", h.GetProfile)
 
@@ -117,3 +119,217 @@ func (h *UserHandler) UpdateProfile(c *fiber.Ctx) error {
 		"error":   nil,
 	})
 }
+
+// AdminRoutes defines admin-only user management routes
+// Mounts routes under /api/users
+func (h *UserHandler) AdminRoutes(group fiber.Router) {
+	// POST /api/users - Create a new user
+	group.Post("/", h.Create)
+
+	// GET /api/users - List users (filters: is_admin, actor_id, active, email)
+	group.Get("/", h.List)
+
+	// GET /api/users/:id - Get a user by ID
+	group.Get("/:id", h.GetByID)
+
+	// PUT /api/users/:id - Update a user's name, admin flag and actor
+	group.Put("/:id", h.Update)
+
+	// POST /api/users/:id/deactivate - Block a user from logging in
+	group.Post("/:id/deactivate", h.Deactivate)
+
+	// POST /api/users/:id/activate - Restore a deactivated user
+	group.Post("/:id/activate", h.Activate)
+}
+
+// List retrieves users matching the filters with pagination
+// GET /api/users?is_admin=&actor_id=&active=&email=&page=&limit=
+func (h *UserHandler) List(c *fiber.Ctx) error {
+	page, _ := strconv.Atoi(c.Query("page", "1"))
+	limit, _ := strconv.Atoi(c.Query("limit", "10"))
+
+	filter := domain.UserFilter{
+		ActorID: c.Query("actor_id"),
+		Email:   c.Query("email"),
+	}
+	var err error
+	if filter.IsAdmin, err = boolQuery(c, "is_admin"); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"success": false,
+			"data":    nil,
+			"error":   "is_admin must be true or false",
+		})
+	}
+	if filter.IsActive, err = boolQuery(c, "active"); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"success": false,
+			"data":    nil,
+			"error":   "active must be true or false",
+		})
+	}
+
+	users, total, err := h.userService.ListUsers(c.Context(), filter, page, limit)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"success": false,
+			"data":    nil,
+			"error":   "Failed to list users",
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"success": true,
+		"data": fiber.Map{
+			"users": dto.ToUserResponseList(users),
+			"total": total,
+			"page":  page,
+			"limit": limit,
+		},
+		"error": nil,
+	})
+}
+
+// GetByID retrieves a user by ID
+// GET /api/users/:id
+func (h *UserHandler) GetByID(c *fiber.Ctx) error {
+	user, err := h.userService.GetUserByID(c.Context(), c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"success": false,
+			"data":    nil,
+			"error":   "User not found",
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"success": true,
+		"data":    dto.ToUserResponse(user),
+		"error":   nil,
+	})
+}
+
+// Update changes another user's name, admin flag and actor memberships
+// PUT /api/users/:id
+// Request Body: {"name": "...", "is_admin": false, "actors": [{"actor_id": "...", "is_primary": true}]}
+func (h *UserHandler) Update(c *fiber.Ctx) error {
+	var req dto.AdminUpdateUserRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"success": false,
+			"data":    nil,
+			"error":   "Invalid request body",
+		})
+	}
+
+	user, err := h.userService.AdminUpdateUser(c.Context(), c.Params("id"), req.Name, req.IsAdmin, req.ActorAssignments())
+	if err != nil {
+		return userError(c, err)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"success": true,
+		"data":    dto.ToUserResponse(user),
+		"error":   nil,
+	})
+}
+
+// Deactivate blocks a user from logging in and refreshing tokens; requests
+// and approval history are kept. Deactivating the last active member of an
+// actor used by workflow steps is refused with 409 LAST_ACTOR_MEMBER unless
+// force is set.
+// POST /api/users/:id/deactivate?force=true
+// Request Body (optional): {"force": true}
+func (h *UserHandler) Deactivate(c *fiber.Ctx) error {
+	var req dto.DeactivateUserRequest
+	if len(c.Body()) > 0 {
+		if err := c.BodyParser(&req); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"success": false,
+				"data":    nil,
+				"error":   "Invalid request body",
+			})
+		}
+	}
+	force := req.Force || c.QueryBool("force")
+
+	adminID, _ := c.Locals("user_id").(string)
+	user, gaps, err := h.userService.DeactivateUser(c.Context(), c.Params("id"), adminID, force)
+	if err != nil {
+		var warning *usecase.ActorCoverageWarning
+		if errors.As(err, &warning) {
+			return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+				"success": false,
+				"data": fiber.Map{
+					"gaps": warning.Gaps,
+				},
+				"error": warning.Error() + "; repeat with force=true to deactivate anyway",
+				"code":  "LAST_ACTOR_MEMBER",
+			})
+		}
+		return userError(c, err)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"success": true,
+		"data": dto.DeactivateUserResponse{
+			User:     dto.ToUserResponse(user),
+			Warnings: gaps,
+		},
+		"error": nil,
+	})
+}
+
+// Activate restores a deactivated user
+// POST /api/users/:id/activate
+func (h *UserHandler) Activate(c *fiber.Ctx) error {
+	user, err := h.userService.ActivateUser(c.Context(), c.Params("id"))
+	if err != nil {
+		return userError(c, err)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"success": true,
+		"data":    dto.ToUserResponse(user),
+		"error":   nil,
+	})
+}
+
+// boolQuery parses an optional boolean query parameter
+func boolQuery(c *fiber.Ctx, key string) (*bool, error) {
+	raw := c.Query(key)
+	if raw == "" {
+		return nil, nil
+	}
+	value, err := strconv.ParseBool(raw)
+	if err != nil {
+		return nil, err
+	}
+	return &value, nil
+}
+
+// userError maps user management errors to HTTP responses
+func userError(c *fiber.Ctx, err error) error {
+	status := fiber.StatusInternalServerError
+	message := "Failed to process user request"
+	switch {
+	case errors.Is(err, usecase.ErrUserNotFound):
+		status, message = fiber.StatusNotFound, "User not found"
+	case errors.Is(err, usecase.ErrNameRequired),
+		errors.Is(err, usecase.ErrActorIDRequired),
+		errors.Is(err, usecase.ErrActorIDNotRequired),
+		errors.Is(err, usecase.ErrActorIDNotFound),
+		errors.Is(err, usecase.ErrDuplicateActor),
+		errors.Is(err, usecase.ErrActorRoleConflict),
+		errors.Is(err, usecase.ErrCannotDeactivateSelf):
+		status, message = fiber.StatusBadRequest, err.Error()
+	case errors.Is(err, usecase.ErrUserAlreadyInactive),
+		errors.Is(err, usecase.ErrUserAlreadyActive):
+		status, message = fiber.StatusConflict, err.Error()
+	}
+
+	return c.Status(status).JSON(fiber.Map{
+		"success": false,
+		"data":    nil,
+		"error":   message,
+	})
+}
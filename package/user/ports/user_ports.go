@@ -5,6 +5,7 @@ import (
 
 	actorDomain "workflow-approval/package/actor/domain"
 	userDomain "workflow-approval/package/user/domain"
+	stepDomain "workflow-approval/package/workflow_step/domain"
 )
 
 // UserRepository defines the interface for user data access
@@ -12,8 +13,12 @@ type UserRepository interface {
 	Create(ctx context.Context, user *userDomain.User) error
 	GetByID(ctx context.Context, id string) (*userDomain.User, error)
 	GetByEmail(ctx context.Context, email string) (*userDomain.User, error)
+	GetByOIDCSubject(ctx context.Context, subject string) (*userDomain.User, error)
 	Update(ctx context.Context, user *userDomain.User) error
 	Delete(ctx context.Context, id string) error
+	List(ctx context.Context, filter userDomain.UserFilter, page, limit int) ([]*userDomain.User, int64, error)
+	CountActiveByActorID(ctx context.Context, actorID string) (int64, error)
+	SaveActorMemberships(ctx context.Context, user *userDomain.User) error
 }
 
 // ActorRepository defines the interface for actor data access
@@ -21,11 +26,29 @@ type ActorRepository interface {
 	GetByID(ctx context.Context, id string) (*actorDomain.Actor, error)
 }
 
+// WorkflowStepRepository defines the workflow step lookups needed before deactivating a user
+type WorkflowStepRepository interface {
+	GetByActorID(ctx context.Context, actorID string) ([]*stepDomain.WorkflowStep, error)
+}
+
+// AccountNotifier sends account lifecycle emails (implemented by the auth account service)
+type AccountNotifier interface {
+	SendEmailVerification(ctx context.Context, user *userDomain.User) error
+}
+
 // UserService defines the interface for user business logic
 type UserService interface {
-	Register(ctx context.Context, email, password, name string, isAdmin bool, actorID *string) (*userDomain.User, error)
+	Register(ctx context.Context, email, password, name string, isAdmin bool, actors []userDomain.ActorAssignment) (*userDomain.User, error)
 	Login(ctx context.Context, email, password string) (*userDomain.User, string, error)
 	GetUserByID(ctx context.Context, id string) (*userDomain.User, error)
 	GetUserByEmail(ctx context.Context, email string) (*userDomain.User, error)
 	UpdateUser(ctx context.Context, id string, name string) (*userDomain.User, error)
+	ListUsers(ctx context.Context, filter userDomain.UserFilter, page, limit int) ([]*userDomain.User, int64, error)
+	// AdminUpdateUser changes another user's name, admin flag and actors with the validations of Register;
+	// nil actors keeps the current memberships
+	AdminUpdateUser(ctx context.Context, id, name string, isAdmin bool, actors []userDomain.ActorAssignment) (*userDomain.User, error)
+	// DeactivateUser returns a *usecase.ActorCoverageWarning error unless force is set when the user is
+	// the last active member of an actor assigned to workflow steps
+	DeactivateUser(ctx context.Context, id, adminID string, force bool) (*userDomain.User, []userDomain.ActorCoverageGap, error)
+	ActivateUser(ctx context.Context, id string) (*userDomain.User, error)
 }
@@ -3,11 +3,14 @@ package repository
 import (
 	"context"
 	"errors"
+	"strings"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 
 	"workflow-approval/package/user/domain"
 	"workflow-approval/package/user/ports"
+	"workflow-approval/utils/tenancy"
 )
 
 var (
@@ -25,14 +28,22 @@ func NewUserRepository(db *gorm.DB) ports.UserRepository {
 	return &UserRepositoryImpl{db: db}
 }
 
-// Create creates a new user
+// Create creates a new user together with its actor memberships
 func (r *UserRepositoryImpl) Create(ctx context.Context, user *domain.User) error {
-	result := r.db.WithContext(ctx).Create(user)
-	if result.Error != nil {
-		if errors.Is(result.Error, gorm.ErrDuplicatedKey) {
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit(clause.Associations).Create(user).Error; err != nil {
+			return err
+		}
+		if len(user.Memberships) > 0 {
+			return tx.Create(&user.Memberships).Error
+		}
+		return nil
+	})
+	if err != nil {
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
 			return ErrUserAlreadyExist
 		}
-		return result.Error
+		return err
 	}
 	return nil
 }
@@ -40,7 +51,7 @@ func (r *UserRepositoryImpl) Create(ctx context.Context, user *domain.User) erro
 // GetByID retrieves a user by ID
 func (r *UserRepositoryImpl) GetByID(ctx context.Context, id string) (*domain.User, error) {
 	var user domain.User
-	result := r.db.WithContext(ctx).First(&user, "id = ?", id)
+	result := r.withMemberships(ctx).First(&user, "id = ?", id)
 	if result.Error != nil {
 		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
 			return nil, ErrUserNotFound
@@ -53,7 +64,7 @@ func (r *UserRepositoryImpl) GetByID(ctx context.Context, id string) (*domain.Us
 // GetByEmail retrieves a user by email
 func (r *UserRepositoryImpl) GetByEmail(ctx context.Context, email string) (*domain.User, error) {
 	var user domain.User
-	result := r.db.WithContext(ctx).First(&user, "email = ?", email)
+	result := r.withMemberships(ctx).First(&user, "email = ?", email)
 	if result.Error != nil {
 		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
 			return nil, ErrUserNotFound
@@ -63,15 +74,96 @@ func (r *UserRepositoryImpl) GetByEmail(ctx context.Context, email string) (*dom
 	return &user, nil
 }
 
-// Update updates an existing user
+// GetByOIDCSubject retrieves a user by the "sub" claim of its linked OIDC identity
+func (r *UserRepositoryImpl) GetByOIDCSubject(ctx context.Context, subject string) (*domain.User, error) {
+	var user domain.User
+	result := r.withMemberships(ctx).First(&user, "oidc_subject = ?", subject)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, ErrUserNotFound
+		}
+		return nil, result.Error
+	}
+	return &user, nil
+}
+
+// Update updates an existing user; actor memberships are saved with SaveActorMemberships
 func (r *UserRepositoryImpl) Update(ctx context.Context, user *domain.User) error {
-	result := r.db.WithContext(ctx).Save(user)
+	result := r.db.WithContext(ctx).Omit(clause.Associations).Save(user)
 	if result.Error != nil {
 		return result.Error
 	}
 	return nil
 }
 
+// List retrieves users matching the filter with pagination, ordered by email
+func (r *UserRepositoryImpl) List(ctx context.Context, filter domain.UserFilter, page, limit int) ([]*domain.User, int64, error) {
+	var users []*domain.User
+	var total int64
+
+	offset := (page - 1) * limit
+
+	query := r.db.WithContext(ctx).Model(&domain.User{})
+
+	if filter.IsAdmin != nil {
+		query = query.Where("is_admin = ?", *filter.IsAdmin)
+	}
+	if filter.ActorID != "" {
+		query = query.Where("id IN (?)", r.db.WithContext(ctx).Model(&domain.ActorMembership{}).Select("user_id").Where("actor_id = ?", filter.ActorID))
+	}
+	if filter.IsActive != nil {
+		query = query.Where("is_active = ?", *filter.IsActive)
+	}
+	if filter.Email != "" {
+		query = query.Where("LOWER(email) LIKE ?", "%"+escapeLike(strings.ToLower(filter.Email))+"%")
+	}
+
+	// Get total count
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	// Get paginated results
+	if err := query.
+		Preload("Memberships", orderMemberships).
+		Order("email ASC").
+		Offset(offset).
+		Limit(limit).
+		Find(&users).Error; err != nil {
+		return nil, 0, err
+	}
+
+	return users, total, nil
+}
+
+// CountActiveByActorID counts active users that are members of an actor
+func (r *UserRepositoryImpl) CountActiveByActorID(ctx context.Context, actorID string) (int64, error) {
+	var count int64
+	err := r.db.WithContext(ctx).
+		Model(&domain.User{}).
+		Joins("JOIN actor_members ON actor_members.user_id = users.id").
+		Where("actor_members.actor_id = ? AND users.is_active = ?", actorID, true).
+		Count(&count).Error
+	return count, err
+}
+
+// SaveActorMemberships replaces the stored actor memberships of a user with user.Memberships
+func (r *UserRepositoryImpl) SaveActorMemberships(ctx context.Context, user *domain.User) error {
+	// Memberships belong to the user's tenant, also when saved outside one (OIDC login)
+	if user.TenantID != "" {
+		ctx = tenancy.WithTenant(ctx, user.TenantID)
+	}
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("user_id = ?", user.ID).Delete(&domain.ActorMembership{}).Error; err != nil {
+			return err
+		}
+		if len(user.Memberships) == 0 {
+			return nil
+		}
+		return tx.Create(&user.Memberships).Error
+	})
+}
+
 // Delete deletes a user by ID
 func (r *UserRepositoryImpl) Delete(ctx context.Context, id string) error {
 	result := r.db.WithContext(ctx).Delete(&domain.User{}, "id = ?", id)
@@ -80,3 +172,18 @@ func (r *UserRepositoryImpl) Delete(ctx context.Context, id string) error {
 	}
 	return nil
 }
+
+// withMemberships returns a query that loads the user's actor memberships
+func (r *UserRepositoryImpl) withMemberships(ctx context.Context) *gorm.DB {
+	return r.db.WithContext(ctx).Preload("Memberships", orderMemberships)
+}
+
+// orderMemberships lists primary memberships first, then by creation
+func orderMemberships(db *gorm.DB) *gorm.DB {
+	return db.Order("is_primary DESC, created_at ASC")
+}
+
+// escapeLike escapes LIKE wildcards so user input matches literally
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(s)
+}
@@ -3,6 +3,8 @@ package usecase
 import (
 	"context"
 	"errors"
+	"fmt"
+	"log"
 	"regexp"
 
 	"golang.org/x/crypto/bcrypt"
@@ -10,6 +12,8 @@ import (
 	"workflow-approval/package/user/domain"
 	"workflow-approval/package/user/ports"
 	"workflow-approval/package/user/repository"
+	"workflow-approval/utils"
+	"workflow-approval/utils/tenancy"
 )
 
 var (
@@ -19,27 +23,55 @@ var (
 	ErrEmailRequired      = errors.New("email is required")
 	ErrPasswordRequired   = errors.New("password is required")
 	ErrNameRequired       = errors.New("name is required")
-	ErrActorIDRequired    = errors.New("actor_id is required for non-admin users")
-	ErrActorIDNotRequired = errors.New("actor_id should be empty for admin users")
+	ErrActorIDRequired    = errors.New("non-admin users must belong to at least one actor")
+	ErrActorIDNotRequired = errors.New("admin users cannot be actor members")
 	ErrActorIDNotFound    = errors.New("actor_id not found")
+	ErrDuplicateActor     = errors.New("an actor can only be assigned once per user")
+	ErrActorRoleConflict  = errors.New("a membership cannot be both primary and backup")
+
+	ErrUserNotFound         = errors.New("user not found")
+	ErrCannotDeactivateSelf = errors.New("you cannot deactivate your own account")
+	ErrUserAlreadyInactive  = errors.New("user is already deactivated")
+	ErrUserAlreadyActive    = errors.New("user is already active")
 )
 
+// ActorCoverageWarning is returned when deactivating a user would leave
+// workflow steps without an active approver; repeat with force to proceed
+type ActorCoverageWarning struct {
+	Gaps []domain.ActorCoverageGap
+}
+
+// Error implements error
+func (w *ActorCoverageWarning) Error() string {
+	steps := 0
+	for _, gap := range w.Gaps {
+		steps += len(gap.Steps)
+	}
+	return fmt.Sprintf("user is the last active member of an actor used by %d workflow step(s)", steps)
+}
+
 // UserServiceImpl implements UserService interface
 type UserServiceImpl struct {
 	userRepo  ports.UserRepository
 	actorRepo ports.ActorRepository
+	stepRepo  ports.WorkflowStepRepository
+	notifier  ports.AccountNotifier
 }
 
 // NewUserService creates a new UserServiceImpl instance
-func NewUserService(userRepo ports.UserRepository, actorRepo ports.ActorRepository) ports.UserService {
+func NewUserService(userRepo ports.UserRepository, actorRepo ports.ActorRepository, stepRepo ports.WorkflowStepRepository, notifier ports.AccountNotifier) ports.UserService {
 	return &UserServiceImpl{
 		userRepo:  userRepo,
 		actorRepo: actorRepo,
+		stepRepo:  stepRepo,
+		notifier:  notifier,
 	}
 }
 
-// Register creates a new user account
-func (s *UserServiceImpl) Register(ctx context.Context, email, password, name string, isAdmin bool, actorID *string) (*domain.User, error) {
+// Register creates a new user account on behalf of an admin. The initial
+// password is chosen by the admin, so the user must replace it at first login,
+// and a verification link is emailed to confirm the address.
+func (s *UserServiceImpl) Register(ctx context.Context, email, password, name string, isAdmin bool, actors []domain.ActorAssignment) (*domain.User, error) {
 	// Validation
 	if email == "" {
 		return nil, ErrEmailRequired
@@ -51,24 +83,9 @@ func (s *UserServiceImpl) Register(ctx context.Context, email, password, name st
 		return nil, ErrNameRequired
 	}
 
-	// Validate actor_id based on is_admin
-	if isAdmin {
-		// Admin users should not have actor_id
-		if actorID != nil && *actorID != "" {
-			return nil, ErrActorIDNotRequired
-		}
-		actorID = nil
-	} else {
-		// Non-admin users must have actor_id
-		if actorID == nil || *actorID == "" {
-			return nil, ErrActorIDRequired
-		}
-
-		// Verify actor_id exists in database
-		_, err := s.actorRepo.GetByID(ctx, *actorID)
-		if err != nil {
-			return nil, ErrActorIDNotFound
-		}
+	// Validate actors based on is_admin
+	if err := s.validateActors(ctx, isAdmin, actors); err != nil {
+		return nil, err
 	}
 
 	// Validate email format
@@ -81,8 +98,8 @@ func (s *UserServiceImpl) Register(ctx context.Context, email, password, name st
 		return nil, ErrWeakPassword
 	}
 
-	// Check if user already exists
-	_, err := s.userRepo.GetByEmail(ctx, email)
+	// Check if user already exists; emails identify logins and are unique across tenants
+	_, err := s.userRepo.GetByEmail(tenancy.WithAllTenants(ctx), email)
 	if err == nil {
 		return nil, ErrUserAlreadyExist
 	}
@@ -97,11 +114,19 @@ func (s *UserServiceImpl) Register(ctx context.Context, email, password, name st
 	}
 
 	// Create user
-	user := domain.NewUser(email, string(hashedPassword), name, isAdmin, actorID)
+	user := domain.NewUser(email, string(hashedPassword), name, isAdmin, actors)
+	user.MustChangePassword = true
 	if err := s.userRepo.Create(ctx, user); err != nil {
 		return nil, err
 	}
 
+	// The account is usable without a verified email; the user can ask for a new link later
+	if s.notifier != nil {
+		if err := s.notifier.SendEmailVerification(ctx, user); err != nil {
+			log.Printf("Warning: failed to send verification email to %s: %v", user.Email, err)
+		}
+	}
+
 	return user, nil
 }
 
@@ -159,6 +184,191 @@ func (s *UserServiceImpl) UpdateUser(ctx context.Context, id string, name string
 	return user, nil
 }
 
+// ListUsers retrieves users matching the filter with pagination
+func (s *UserServiceImpl) ListUsers(ctx context.Context, filter domain.UserFilter, page, limit int) ([]*domain.User, int64, error) {
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 {
+		limit = 10
+	}
+	if limit > 100 {
+		limit = 100
+	}
+	return s.userRepo.List(ctx, filter, page, limit)
+}
+
+// AdminUpdateUser changes another user's name, admin flag and actors,
+// applying the same rules as Register. Nil actors keeps the user's current
+// memberships, which must still satisfy the rules for the new admin flag.
+func (s *UserServiceImpl) AdminUpdateUser(ctx context.Context, id, name string, isAdmin bool, actors []domain.ActorAssignment) (*domain.User, error) {
+	if name == "" {
+		return nil, ErrNameRequired
+	}
+
+	user, err := s.getUser(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if actors == nil {
+		for _, m := range user.Memberships {
+			actors = append(actors, domain.ActorAssignment{ActorID: m.ActorID, IsPrimary: m.IsPrimary, IsBackup: m.IsBackup})
+		}
+	}
+	if err := s.validateActors(ctx, isAdmin, actors); err != nil {
+		return nil, err
+	}
+
+	now := utils.TimeNowUTC()
+	user.Name = name
+	user.IsAdmin = isAdmin
+	user.SetActors(actors, now)
+	if err := s.userRepo.Update(ctx, user); err != nil {
+		return nil, err
+	}
+	if err := s.userRepo.SaveActorMemberships(ctx, user); err != nil {
+		return nil, err
+	}
+
+	return user, nil
+}
+
+// DeactivateUser blocks a user from logging in and refreshing tokens without
+// deleting the user's requests or approval history. When the user is the last
+// active member of an actor that workflow steps are assigned to, an
+// *ActorCoverageWarning is returned unless force is set; with force the gaps
+// are returned alongside the deactivated user.
+func (s *UserServiceImpl) DeactivateUser(ctx context.Context, id, adminID string, force bool) (*domain.User, []domain.ActorCoverageGap, error) {
+	if id == adminID {
+		return nil, nil, ErrCannotDeactivateSelf
+	}
+
+	user, err := s.getUser(ctx, id)
+	if err != nil {
+		return nil, nil, err
+	}
+	if !user.IsActive {
+		return nil, nil, ErrUserAlreadyInactive
+	}
+
+	gaps, err := s.coverageGaps(ctx, user)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(gaps) > 0 && !force {
+		return nil, nil, &ActorCoverageWarning{Gaps: gaps}
+	}
+
+	user.Deactivate(utils.TimeNowUTC())
+	if err := s.userRepo.Update(ctx, user); err != nil {
+		return nil, nil, err
+	}
+
+	return user, gaps, nil
+}
+
+// ActivateUser restores a deactivated user
+func (s *UserServiceImpl) ActivateUser(ctx context.Context, id string) (*domain.User, error) {
+	user, err := s.getUser(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if user.IsActive {
+		return nil, ErrUserAlreadyActive
+	}
+
+	user.Activate(utils.TimeNowUTC())
+	if err := s.userRepo.Update(ctx, user); err != nil {
+		return nil, err
+	}
+
+	return user, nil
+}
+
+// coverageGaps lists, per actor of the user, the workflow steps that would
+// lose their last active approver without the user
+func (s *UserServiceImpl) coverageGaps(ctx context.Context, user *domain.User) ([]domain.ActorCoverageGap, error) {
+	var gaps []domain.ActorCoverageGap
+	for _, actorID := range user.ActorIDs() {
+		steps, err := s.stepRepo.GetByActorID(ctx, actorID)
+		if err != nil {
+			return nil, err
+		}
+		if len(steps) == 0 {
+			continue
+		}
+
+		active, err := s.userRepo.CountActiveByActorID(ctx, actorID)
+		if err != nil {
+			return nil, err
+		}
+		if active > 1 {
+			continue
+		}
+
+		gap := domain.ActorCoverageGap{ActorID: actorID}
+		for _, step := range steps {
+			gap.Steps = append(gap.Steps, domain.StepSummary{
+				StepID:     step.ID,
+				WorkflowID: step.WorkflowID,
+				Level:      step.Level,
+			})
+		}
+		gaps = append(gaps, gap)
+	}
+	return gaps, nil
+}
+
+// validateActors enforces that admins have no actors and other users have at
+// least one; every actor must exist and appear once
+func (s *UserServiceImpl) validateActors(ctx context.Context, isAdmin bool, actors []domain.ActorAssignment) error {
+	if isAdmin {
+		// Admin users should not be actor members
+		if len(actors) > 0 {
+			return ErrActorIDNotRequired
+		}
+		return nil
+	}
+
+	// Non-admin users must belong to at least one actor
+	if len(actors) == 0 {
+		return ErrActorIDRequired
+	}
+
+	seen := make(map[string]bool, len(actors))
+	for _, a := range actors {
+		if a.ActorID == "" {
+			return ErrActorIDRequired
+		}
+		if seen[a.ActorID] {
+			return ErrDuplicateActor
+		}
+		seen[a.ActorID] = true
+		if a.IsPrimary && a.IsBackup {
+			return ErrActorRoleConflict
+		}
+
+		// Verify actor exists in database
+		if _, err := s.actorRepo.GetByID(ctx, a.ActorID); err != nil {
+			return ErrActorIDNotFound
+		}
+	}
+	return nil
+}
+
+// getUser loads a user, mapping not-found to ErrUserNotFound
+func (s *UserServiceImpl) getUser(ctx context.Context, id string) (*domain.User, error) {
+	user, err := s.userRepo.GetByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, repository.ErrUserNotFound) {
+			return nil, ErrUserNotFound
+		}
+		return nil, err
+	}
+	return user, nil
+}
+
 // isValidEmail validates email format using regex
 func isValidEmail(email string) bool {
 	pattern := `^[a-zA-Z0-9._%+-]+@[a-zA-Z0-9.-]+\.[a-zA-Z]{2,}$`
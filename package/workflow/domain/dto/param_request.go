@@ -1,11 +1,15 @@
 package dto
 
+import "workflow-approval/package/workflow/domain"
+
 // CreateWorkflowRequest represents the create workflow request body
 type CreateWorkflowRequest struct {
-	Name string `json:"name"`
+	Name       string            `json:"name"`
+	FormSchema domain.FormSchema `json:"form_schema"`
 }
 
 // UpdateWorkflowRequest represents the update workflow request body
 type UpdateWorkflowRequest struct {
-	Name string `json:"name"`
+	Name       string             `json:"name"`
+	FormSchema *domain.FormSchema `json:"form_schema"` // Omitted: keep the current form
 }
@@ -4,9 +4,10 @@ import "workflow-approval/package/workflow/domain"
 
 // WorkflowResponse represents the workflow response
 type WorkflowResponse struct {
-	ID        string `json:"id"`
-	Name      string `json:"name"`
-	CreatedAt string `json:"created_at"`
+	ID         string            `json:"id"`
+	Name       string            `json:"name"`
+	FormSchema domain.FormSchema `json:"form_schema"`
+	CreatedAt  string            `json:"created_at"`
 }
 
 // ToWorkflowResponse converts a Workflow to WorkflowResponse
@@ -15,9 +16,10 @@ func ToWorkflowResponse(w *domain.Workflow) *WorkflowResponse {
 		return nil
 	}
 	return &WorkflowResponse{
-		ID:        w.ID,
-		Name:      w.Name,
-		CreatedAt: w.CreatedAt.Format("2006-01-02T15:04:05Z"),
+		ID:         w.ID,
+		Name:       w.Name,
+		FormSchema: w.FormSchema,
+		CreatedAt:  w.CreatedAt.Format("2006-01-02T15:04:05Z"),
 	}
 }
 
@@ -0,0 +1,278 @@
+package domain
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// FieldType is the type of a form field value
+type FieldType string
+
+const (
+	FieldString  FieldType = "string"
+	FieldNumber  FieldType = "number"
+	FieldInteger FieldType = "integer"
+	FieldBoolean FieldType = "boolean"
+	FieldDate    FieldType = "date" // "2006-01-02"
+)
+
+// fieldNamePattern restricts field names so they can be used in JSON paths and query parameters
+var fieldNamePattern = regexp.MustCompile(`^[a-zA-Z][a-zA-Z0-9_]{0,63}$`)
+
+var (
+	ErrInvalidFormSchema = errors.New("invalid form schema")
+	ErrInvalidFieldName  = errors.New("field names must start with a letter and contain only letters, digits and underscores")
+)
+
+// ValidFieldName reports whether name may be used as a form field name
+func ValidFieldName(name string) bool {
+	return fieldNamePattern.MatchString(name)
+}
+
+// FormField describes one custom field of a workflow's request form
+type FormField struct {
+	Name     string        `json:"name"`
+	Label    string        `json:"label,omitempty"`
+	Type     FieldType     `json:"type"`
+	Required bool          `json:"required,omitempty"`
+	Enum     []interface{} `json:"enum,omitempty"`    // Allowed values
+	Min      *float64      `json:"min,omitempty"`     // Minimum value of numbers, minimum length of strings
+	Max      *float64      `json:"max,omitempty"`     // Maximum value of numbers, maximum length of strings
+	Pattern  string        `json:"pattern,omitempty"` // Regular expression string values must match
+}
+
+// FormSchema defines the custom fields requests of a workflow carry, e.g. the
+// destination of a travel request. An empty schema accepts any fields.
+type FormSchema struct {
+	Fields []FormField `json:"fields"`
+}
+
+// FieldError describes why one field value was rejected
+type FieldError struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+// FieldErrors is returned when request fields don't match the workflow's form schema
+type FieldErrors []FieldError
+
+func (e FieldErrors) Error() string {
+	msgs := make([]string, len(e))
+	for i, fe := range e {
+		msgs[i] = fe.Field + ": " + fe.Message
+	}
+	return "invalid request fields: " + strings.Join(msgs, "; ")
+}
+
+// IsEmpty reports whether the schema defines no fields
+func (s FormSchema) IsEmpty() bool {
+	return len(s.Fields) == 0
+}
+
+// Field returns the field with the given name, nil if the schema has none
+func (s FormSchema) Field(name string) *FormField {
+	for i := range s.Fields {
+		if s.Fields[i].Name == name {
+			return &s.Fields[i]
+		}
+	}
+	return nil
+}
+
+// Check verifies the schema definition itself: names, types, bounds and patterns
+func (s FormSchema) Check() error {
+	seen := make(map[string]bool, len(s.Fields))
+	for _, f := range s.Fields {
+		if !ValidFieldName(f.Name) {
+			return fmt.Errorf("%w: field %q: %v", ErrInvalidFormSchema, f.Name, ErrInvalidFieldName)
+		}
+		if seen[f.Name] {
+			return fmt.Errorf("%w: field %q is defined twice", ErrInvalidFormSchema, f.Name)
+		}
+		seen[f.Name] = true
+
+		switch f.Type {
+		case FieldString, FieldNumber, FieldInteger, FieldBoolean, FieldDate:
+		default:
+			return fmt.Errorf("%w: field %q has unknown type %q", ErrInvalidFormSchema, f.Name, f.Type)
+		}
+		if f.Min != nil && f.Max != nil && *f.Min > *f.Max {
+			return fmt.Errorf("%w: field %q has min greater than max", ErrInvalidFormSchema, f.Name)
+		}
+		if f.Pattern != "" {
+			if f.Type != FieldString {
+				return fmt.Errorf("%w: field %q: pattern only applies to string fields", ErrInvalidFormSchema, f.Name)
+			}
+			if _, err := regexp.Compile(f.Pattern); err != nil {
+				return fmt.Errorf("%w: field %q has an invalid pattern: %v", ErrInvalidFormSchema, f.Name, err)
+			}
+		}
+		for _, v := range f.Enum {
+			if msg := f.checkType(v); msg != "" {
+				return fmt.Errorf("%w: field %q has an enum value that is not a %s", ErrInvalidFormSchema, f.Name, f.Type)
+			}
+		}
+	}
+	return nil
+}
+
+// Validate checks request field values against the schema and returns every
+// problem found as FieldErrors. Fields the schema does not define are rejected
+// unless the schema is empty.
+func (s FormSchema) Validate(values map[string]interface{}) error {
+	return s.validate(values, true)
+}
+
+// ValidateDraft checks the values of a draft request: like Validate, except
+// that required fields may still be missing
+func (s FormSchema) ValidateDraft(values map[string]interface{}) error {
+	return s.validate(values, false)
+}
+
+// validate implements Validate and ValidateDraft
+func (s FormSchema) validate(values map[string]interface{}, requireAll bool) error {
+	if s.IsEmpty() {
+		return nil
+	}
+
+	var errs FieldErrors
+	for _, f := range s.Fields {
+		v, ok := values[f.Name]
+		if !ok || v == nil || v == "" {
+			if f.Required && requireAll {
+				errs = append(errs, FieldError{Field: f.Name, Message: "is required"})
+			}
+			continue
+		}
+		if msg := f.check(v); msg != "" {
+			errs = append(errs, FieldError{Field: f.Name, Message: msg})
+		}
+	}
+	for name := range values {
+		if s.Field(name) == nil {
+			errs = append(errs, FieldError{Field: name, Message: "is not defined by the workflow form"})
+		}
+	}
+
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
+
+// check validates a present value and returns a message describing the problem, "" if valid
+func (f FormField) check(v interface{}) string {
+	if msg := f.checkType(v); msg != "" {
+		return msg
+	}
+
+	if len(f.Enum) > 0 && !containsValue(f.Enum, v) {
+		return fmt.Sprintf("must be one of %s", formatEnum(f.Enum))
+	}
+
+	switch f.Type {
+	case FieldNumber, FieldInteger:
+		n := v.(float64)
+		if f.Min != nil && n < *f.Min {
+			return fmt.Sprintf("must be at least %g", *f.Min)
+		}
+		if f.Max != nil && n > *f.Max {
+			return fmt.Sprintf("must be at most %g", *f.Max)
+		}
+	case FieldString:
+		str := v.(string)
+		length := float64(len([]rune(str)))
+		if f.Min != nil && length < *f.Min {
+			return fmt.Sprintf("must be at least %g characters", *f.Min)
+		}
+		if f.Max != nil && length > *f.Max {
+			return fmt.Sprintf("must be at most %g characters", *f.Max)
+		}
+		if f.Pattern != "" {
+			if re, err := regexp.Compile(f.Pattern); err != nil || !re.MatchString(str) {
+				return fmt.Sprintf("must match pattern %s", f.Pattern)
+			}
+		}
+	}
+	return ""
+}
+
+// checkType reports a message when v is not of the field's type; JSON numbers decode to float64
+func (f FormField) checkType(v interface{}) string {
+	switch f.Type {
+	case FieldString:
+		if _, ok := v.(string); !ok {
+			return "must be a string"
+		}
+	case FieldNumber:
+		if _, ok := v.(float64); !ok {
+			return "must be a number"
+		}
+	case FieldInteger:
+		if n, ok := v.(float64); !ok || n != math.Trunc(n) {
+			return "must be an integer"
+		}
+	case FieldBoolean:
+		if _, ok := v.(bool); !ok {
+			return "must be true or false"
+		}
+	case FieldDate:
+		str, ok := v.(string)
+		if !ok {
+			return "must be a date (YYYY-MM-DD)"
+		}
+		if _, err := time.Parse("2006-01-02", str); err != nil {
+			return "must be a date (YYYY-MM-DD)"
+		}
+	}
+	return ""
+}
+
+func containsValue(values []interface{}, v interface{}) bool {
+	for _, allowed := range values {
+		if allowed == v {
+			return true
+		}
+	}
+	return false
+}
+
+func formatEnum(values []interface{}) string {
+	parts := make([]string, len(values))
+	for i, v := range values {
+		parts[i] = fmt.Sprint(v)
+	}
+	return "[" + strings.Join(parts, ", ") + "]"
+}
+
+// Value implements driver.Valuer interface for GORM
+func (s FormSchema) Value() (driver.Value, error) {
+	if s.IsEmpty() {
+		return []byte(`{}`), nil
+	}
+	return json.Marshal(s)
+}
+
+// Scan implements sql.Scanner interface for GORM
+func (s *FormSchema) Scan(value interface{}) error {
+	var bytes []byte
+	switch v := value.(type) {
+	case nil:
+		*s = FormSchema{}
+		return nil
+	case []byte:
+		bytes = v
+	case string:
+		bytes = []byte(v)
+	default:
+		return errors.New("type assertion to []byte or string failed")
+	}
+	*s = FormSchema{}
+	return json.Unmarshal(bytes, s)
+}
@@ -8,20 +8,23 @@ import (
 
 // Workflow represents an approval workflow
 type Workflow struct {
-	ID        string    `json:"id" gorm:"primaryKey;size:36"`
-	Name      string    `json:"name" gorm:"size:255;not null"`
-	CreatedAt time.Time `json:"created_at"`
-	UpdatedAt time.Time `json:"updated_at"`
+	ID         string     `json:"id" gorm:"primaryKey;size:36"`
+	TenantID   string     `json:"tenant_id" gorm:"size:36;not null;index"`
+	Name       string     `json:"name" gorm:"size:255;not null"`
+	FormSchema FormSchema `json:"form_schema" gorm:"type:text"` // Custom fields of the workflow's requests
+	CreatedAt  time.Time  `json:"created_at"`
+	UpdatedAt  time.Time  `json:"updated_at"`
 }
 
 // NewWorkflow creates a new Workflow instance
-func NewWorkflow(name string) *Workflow {
+func NewWorkflow(name string, form FormSchema) *Workflow {
 	now := utils.TimeNowUTC()
 	return &Workflow{
-		ID:        utils.GenerateUUID(),
-		Name:      name,
-		CreatedAt: now,
-		UpdatedAt: now,
+		ID:         utils.GenerateUUID(),
+		Name:       name,
+		FormSchema: form,
+		CreatedAt:  now,
+		UpdatedAt:  now,
 	}
 }
 
@@ -1,12 +1,11 @@
 package handler
 
 import (
-	"strconv"
-
 	"github.com/gofiber/fiber/v2"
 
 	"workflow-approval/package/workflow/domain/dto"
 	"workflow-approval/package/workflow/ports"
+	"workflow-approval/utils/pagination"
 )
 
 // WorkflowHandler handles HTTP requests for workflow operations
@@ -25,7 +24,7 @@ func NewWorkflowHandler(workflowService ports.WorkflowService) *WorkflowHandler
 // Mounts routes under /api/workflows
 func (h *WorkflowHandler) Routes(group fiber.Router) {
 	// POST /api/workflows - Create a new workflow
-	// Request body: { "name": "Workflow Name" }
+	// Request body: { "name": "Workflow Name", "form_schema": { "fields": [...] } }
 	group.Post("", h.Create)
 
 	// GET /api/workflows - List all workflows with pagination
@@ -54,7 +53,7 @@ func (h *WorkflowHandler) Create(c *fiber.Ctx) error {
 		})
 	}
 
-	workflow, err := h.workflowService.CreateWorkflow(c.Context(), req.Name)
+	workflow, err := h.workflowService.CreateWorkflow(c.Context(), req.Name, req.FormSchema)
 	if err != nil {
 		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
 			"success": false,
@@ -98,13 +97,19 @@ func (h *WorkflowHandler) Get(c *fiber.Ctx) error {
 	})
 }
 
-// List retrieves a list of workflows
-// GET /workflows
+// List retrieves a page of workflows, by offset or by cursor
+// GET /workflows?page=&limit=&cursor=&total=
 func (h *WorkflowHandler) List(c *fiber.Ctx) error {
-	page, _ := strconv.Atoi(c.Query("page", "1"))
-	limit, _ := strconv.Atoi(c.Query("limit", "10"))
+	page, err := pagination.Parse(c.Query("page"), c.Query("limit"), c.Query("cursor"), c.Query("total"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"success": false,
+			"data":    nil,
+			"error":   err.Error(),
+		})
+	}
 
-	workflows, total, err := h.workflowService.ListWorkflows(c.Context(), page, limit)
+	workflows, result, err := h.workflowService.ListWorkflows(c.Context(), page)
 	if err != nil {
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
 			"success": false,
@@ -113,15 +118,12 @@ func (h *WorkflowHandler) List(c *fiber.Ctx) error {
 		})
 	}
 
+	data := fiber.Map(result.Meta(c.Path(), string(c.Request().URI().QueryString())))
+	data["workflows"] = dto.ToWorkflowResponseList(workflows)
 	return c.Status(fiber.StatusOK).JSON(fiber.Map{
 		"success": true,
-		"data": fiber.Map{
-			"workflows": dto.ToWorkflowResponseList(workflows),
-			"total":     total,
-			"page":      page,
-			"limit":     limit,
-		},
-		"error": nil,
+		"data":    data,
+		"error":   nil,
 	})
 }
 
@@ -174,7 +176,7 @@ func (h *WorkflowHandler) Update(c *fiber.Ctx) error {
 		})
 	}
 
-	workflow, err := h.workflowService.UpdateWorkflow(c.Context(), id, req.Name)
+	workflow, err := h.workflowService.UpdateWorkflow(c.Context(), id, req.Name, req.FormSchema)
 	if err != nil {
 		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
 			"success": false,
@@ -5,6 +5,7 @@ package mocks
 import (
 	context "context"
 	domain "workflow-approval/package/workflow/domain"
+	pagination "workflow-approval/utils/pagination"
 
 	mock "github.com/stretchr/testify/mock"
 )
@@ -145,29 +146,29 @@ func (_c *WorkflowRepository_GetByID_Call) Return(_a0 *domain.Workflow, _a1 erro
 	return _c
 }
 
-// List provides a mock function with given fields: ctx, page, limit
-func (_m *WorkflowRepository) List(ctx context.Context, page int, limit int) ([]*domain.Workflow, int64, error) {
-	ret := _m.Called(ctx, page, limit)
+// List provides a mock function with given fields: ctx, page
+func (_m *WorkflowRepository) List(ctx context.Context, page pagination.Page) ([]*domain.Workflow, pagination.Result, error) {
+	ret := _m.Called(ctx, page)
 
 	var r0 []*domain.Workflow
-	if rf, ok := ret.Get(0).(func(context.Context, int, int) []*domain.Workflow); ok {
-		r0 = rf(ctx, page, limit)
+	if rf, ok := ret.Get(0).(func(context.Context, pagination.Page) []*domain.Workflow); ok {
+		r0 = rf(ctx, page)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]*domain.Workflow)
 		}
 	}
 
-	var r1 int64
-	if rf, ok := ret.Get(1).(func(context.Context, int, int) int64); ok {
-		r1 = rf(ctx, page, limit)
+	var r1 pagination.Result
+	if rf, ok := ret.Get(1).(func(context.Context, pagination.Page) pagination.Result); ok {
+		r1 = rf(ctx, page)
 	} else {
-		r1 = ret.Get(1).(int64)
+		r1 = ret.Get(1).(pagination.Result)
 	}
 
 	var r2 error
-	if rf, ok := ret.Get(2).(func(context.Context, int, int) error); ok {
-		r2 = rf(ctx, page, limit)
+	if rf, ok := ret.Get(2).(func(context.Context, pagination.Page) error); ok {
+		r2 = rf(ctx, page)
 	} else {
 		r2 = ret.Error(2)
 	}
@@ -182,24 +183,70 @@ type WorkflowRepository_List_Call struct {
 
 // List is a helper method to define mock.On call
 //  - ctx context.Context
-//  - page int
-//  - limit int
-func (_e *WorkflowRepository_Expecter) List(ctx interface{}, page interface{}, limit interface{}) *WorkflowRepository_List_Call {
-	return &WorkflowRepository_List_Call{Call: _e.mock.On("List", ctx, page, limit)}
+//  - page pagination.Page
+func (_e *WorkflowRepository_Expecter) List(ctx interface{}, page interface{}) *WorkflowRepository_List_Call {
+	return &WorkflowRepository_List_Call{Call: _e.mock.On("List", ctx, page)}
 }
 
-func (_c *WorkflowRepository_List_Call) Run(run func(ctx context.Context, page int, limit int)) *WorkflowRepository_List_Call {
+func (_c *WorkflowRepository_List_Call) Run(run func(ctx context.Context, page pagination.Page)) *WorkflowRepository_List_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(int), args[2].(int))
+		run(args[0].(context.Context), args[1].(pagination.Page))
 	})
 	return _c
 }
 
-func (_c *WorkflowRepository_List_Call) Return(_a0 []*domain.Workflow, _a1 int64, _a2 error) *WorkflowRepository_List_Call {
+func (_c *WorkflowRepository_List_Call) Return(_a0 []*domain.Workflow, _a1 pagination.Result, _a2 error) *WorkflowRepository_List_Call {
 	_c.Call.Return(_a0, _a1, _a2)
 	return _c
 }
 
+// ListByName provides a mock function with given fields: ctx, name
+func (_m *WorkflowRepository) ListByName(ctx context.Context, name string) ([]*domain.Workflow, error) {
+	ret := _m.Called(ctx, name)
+
+	var r0 []*domain.Workflow
+	if rf, ok := ret.Get(0).(func(context.Context, string) []*domain.Workflow); ok {
+		r0 = rf(ctx, name)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*domain.Workflow)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, name)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// WorkflowRepository_ListByName_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListByName'
+type WorkflowRepository_ListByName_Call struct {
+	*mock.Call
+}
+
+// ListByName is a helper method to define mock.On call
+//  - ctx context.Context
+//  - name string
+func (_e *WorkflowRepository_Expecter) ListByName(ctx interface{}, name interface{}) *WorkflowRepository_ListByName_Call {
+	return &WorkflowRepository_ListByName_Call{Call: _e.mock.On("ListByName", ctx, name)}
+}
+
+func (_c *WorkflowRepository_ListByName_Call) Run(run func(ctx context.Context, name string)) *WorkflowRepository_ListByName_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *WorkflowRepository_ListByName_Call) Return(_a0 []*domain.Workflow, _a1 error) *WorkflowRepository_ListByName_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
 // Update provides a mock function with given fields: ctx, workflow
 func (_m *WorkflowRepository) Update(ctx context.Context, workflow *domain.Workflow) error {
 	ret := _m.Called(ctx, workflow)
@@ -5,6 +5,7 @@ package mocks
 import (
 	context "context"
 	domain "workflow-approval/package/workflow/domain"
+	pagination "workflow-approval/utils/pagination"
 
 	mock "github.com/stretchr/testify/mock"
 )
@@ -22,13 +23,13 @@ func (_m *WorkflowService) EXPECT() *WorkflowService_Expecter {
 	return &WorkflowService_Expecter{mock: &_m.Mock}
 }
 
-// CreateWorkflow provides a mock function with given fields: ctx, name
-func (_m *WorkflowService) CreateWorkflow(ctx context.Context, name string) (*domain.Workflow, error) {
-	ret := _m.Called(ctx, name)
+// CreateWorkflow provides a mock function with given fields: ctx, name, form
+func (_m *WorkflowService) CreateWorkflow(ctx context.Context, name string, form domain.FormSchema) (*domain.Workflow, error) {
+	ret := _m.Called(ctx, name, form)
 
 	var r0 *domain.Workflow
-	if rf, ok := ret.Get(0).(func(context.Context, string) *domain.Workflow); ok {
-		r0 = rf(ctx, name)
+	if rf, ok := ret.Get(0).(func(context.Context, string, domain.FormSchema) *domain.Workflow); ok {
+		r0 = rf(ctx, name, form)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*domain.Workflow)
@@ -36,8 +37,8 @@ func (_m *WorkflowService) CreateWorkflow(ctx context.Context, name string) (*do
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
-		r1 = rf(ctx, name)
+	if rf, ok := ret.Get(1).(func(context.Context, string, domain.FormSchema) error); ok {
+		r1 = rf(ctx, name, form)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -53,13 +54,14 @@ type WorkflowService_CreateWorkflow_Call struct {
 // CreateWorkflow is a helper method to define mock.On call
 //  - ctx context.Context
 //  - name string
-func (_e *WorkflowService_Expecter) CreateWorkflow(ctx interface{}, name interface{}) *WorkflowService_CreateWorkflow_Call {
-	return &WorkflowService_CreateWorkflow_Call{Call: _e.mock.On("CreateWorkflow", ctx, name)}
+//  - form domain.FormSchema
+func (_e *WorkflowService_Expecter) CreateWorkflow(ctx interface{}, name interface{}, form interface{}) *WorkflowService_CreateWorkflow_Call {
+	return &WorkflowService_CreateWorkflow_Call{Call: _e.mock.On("CreateWorkflow", ctx, name, form)}
 }
 
-func (_c *WorkflowService_CreateWorkflow_Call) Run(run func(ctx context.Context, name string)) *WorkflowService_CreateWorkflow_Call {
+func (_c *WorkflowService_CreateWorkflow_Call) Run(run func(ctx context.Context, name string, form domain.FormSchema)) *WorkflowService_CreateWorkflow_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(string))
+		run(args[0].(context.Context), args[1].(string), args[2].(domain.FormSchema))
 	})
 	return _c
 }
@@ -154,29 +156,29 @@ func (_c *WorkflowService_GetWorkflow_Call) Return(_a0 *domain.Workflow, _a1 err
 	return _c
 }
 
-// ListWorkflows provides a mock function with given fields: ctx, page, limit
-func (_m *WorkflowService) ListWorkflows(ctx context.Context, page int, limit int) ([]*domain.Workflow, int64, error) {
-	ret := _m.Called(ctx, page, limit)
+// ListWorkflows provides a mock function with given fields: ctx, page
+func (_m *WorkflowService) ListWorkflows(ctx context.Context, page pagination.Page) ([]*domain.Workflow, pagination.Result, error) {
+	ret := _m.Called(ctx, page)
 
 	var r0 []*domain.Workflow
-	if rf, ok := ret.Get(0).(func(context.Context, int, int) []*domain.Workflow); ok {
-		r0 = rf(ctx, page, limit)
+	if rf, ok := ret.Get(0).(func(context.Context, pagination.Page) []*domain.Workflow); ok {
+		r0 = rf(ctx, page)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]*domain.Workflow)
 		}
 	}
 
-	var r1 int64
-	if rf, ok := ret.Get(1).(func(context.Context, int, int) int64); ok {
-		r1 = rf(ctx, page, limit)
+	var r1 pagination.Result
+	if rf, ok := ret.Get(1).(func(context.Context, pagination.Page) pagination.Result); ok {
+		r1 = rf(ctx, page)
 	} else {
-		r1 = ret.Get(1).(int64)
+		r1 = ret.Get(1).(pagination.Result)
 	}
 
 	var r2 error
-	if rf, ok := ret.Get(2).(func(context.Context, int, int) error); ok {
-		r2 = rf(ctx, page, limit)
+	if rf, ok := ret.Get(2).(func(context.Context, pagination.Page) error); ok {
+		r2 = rf(ctx, page)
 	} else {
 		r2 = ret.Error(2)
 	}
@@ -191,31 +193,30 @@ type WorkflowService_ListWorkflows_Call struct {
 
 // ListWorkflows is a helper method to define mock.On call
 //  - ctx context.Context
-//  - page int
-//  - limit int
-func (_e *WorkflowService_Expecter) ListWorkflows(ctx interface{}, page interface{}, limit interface{}) *WorkflowService_ListWorkflows_Call {
-	return &WorkflowService_ListWorkflows_Call{Call: _e.mock.On("ListWorkflows", ctx, page, limit)}
+//  - page pagination.Page
+func (_e *WorkflowService_Expecter) ListWorkflows(ctx interface{}, page interface{}) *WorkflowService_ListWorkflows_Call {
+	return &WorkflowService_ListWorkflows_Call{Call: _e.mock.On("ListWorkflows", ctx, page)}
 }
 
-func (_c *WorkflowService_ListWorkflows_Call) Run(run func(ctx context.Context, page int, limit int)) *WorkflowService_ListWorkflows_Call {
+func (_c *WorkflowService_ListWorkflows_Call) Run(run func(ctx context.Context, page pagination.Page)) *WorkflowService_ListWorkflows_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(int), args[2].(int))
+		run(args[0].(context.Context), args[1].(pagination.Page))
 	})
 	return _c
 }
 
-func (_c *WorkflowService_ListWorkflows_Call) Return(_a0 []*domain.Workflow, _a1 int64, _a2 error) *WorkflowService_ListWorkflows_Call {
+func (_c *WorkflowService_ListWorkflows_Call) Return(_a0 []*domain.Workflow, _a1 pagination.Result, _a2 error) *WorkflowService_ListWorkflows_Call {
 	_c.Call.Return(_a0, _a1, _a2)
 	return _c
 }
 
-// UpdateWorkflow provides a mock function with given fields: ctx, id, name
-func (_m *WorkflowService) UpdateWorkflow(ctx context.Context, id string, name string) (*domain.Workflow, error) {
-	ret := _m.Called(ctx, id, name)
+// UpdateWorkflow provides a mock function with given fields: ctx, id, name, form
+func (_m *WorkflowService) UpdateWorkflow(ctx context.Context, id string, name string, form *domain.FormSchema) (*domain.Workflow, error) {
+	ret := _m.Called(ctx, id, name, form)
 
 	var r0 *domain.Workflow
-	if rf, ok := ret.Get(0).(func(context.Context, string, string) *domain.Workflow); ok {
-		r0 = rf(ctx, id, name)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, *domain.FormSchema) *domain.Workflow); ok {
+		r0 = rf(ctx, id, name, form)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*domain.Workflow)
@@ -223,8 +224,8 @@ func (_m *WorkflowService) UpdateWorkflow(ctx context.Context, id string, name s
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
-		r1 = rf(ctx, id, name)
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, *domain.FormSchema) error); ok {
+		r1 = rf(ctx, id, name, form)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -241,13 +242,14 @@ type WorkflowService_UpdateWorkflow_Call struct {
 //  - ctx context.Context
 //  - id string
 //  - name string
-func (_e *WorkflowService_Expecter) UpdateWorkflow(ctx interface{}, id interface{}, name interface{}) *WorkflowService_UpdateWorkflow_Call {
-	return &WorkflowService_UpdateWorkflow_Call{Call: _e.mock.On("UpdateWorkflow", ctx, id, name)}
+//  - form *domain.FormSchema
+func (_e *WorkflowService_Expecter) UpdateWorkflow(ctx interface{}, id interface{}, name interface{}, form interface{}) *WorkflowService_UpdateWorkflow_Call {
+	return &WorkflowService_UpdateWorkflow_Call{Call: _e.mock.On("UpdateWorkflow", ctx, id, name, form)}
 }
 
-func (_c *WorkflowService_UpdateWorkflow_Call) Run(run func(ctx context.Context, id string, name string)) *WorkflowService_UpdateWorkflow_Call {
+func (_c *WorkflowService_UpdateWorkflow_Call) Run(run func(ctx context.Context, id string, name string, form *domain.FormSchema)) *WorkflowService_UpdateWorkflow_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(string), args[2].(string))
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(*domain.FormSchema))
 	})
 	return _c
 }
@@ -4,6 +4,7 @@ import (
 	"context"
 
 	"workflow-approval/package/workflow/domain"
+	"workflow-approval/utils/pagination"
 )
 
 // WorkflowRepository defines the interface for workflow data access
@@ -12,18 +13,21 @@ import (
 type WorkflowRepository interface {
 	Create(ctx context.Context, workflow *domain.Workflow) error
 	GetByID(ctx context.Context, id string) (*domain.Workflow, error)
+	// ListByName retrieves the workflows with a name; names are not unique
+	ListByName(ctx context.Context, name string) ([]*domain.Workflow, error)
 	Update(ctx context.Context, workflow *domain.Workflow) error
 	Delete(ctx context.Context, id string) error
-	List(ctx context.Context, page, limit int) ([]*domain.Workflow, int64, error)
+	List(ctx context.Context, page pagination.Page) ([]*domain.Workflow, pagination.Result, error)
 }
 
 // WorkflowService defines the interface for workflow business logic
 //
 //go:generate mockery --with-expecter --name=WorkflowService --output=mocks --filename=WorkflowService.go
 type WorkflowService interface {
-	CreateWorkflow(ctx context.Context, name string) (*domain.Workflow, error)
+	CreateWorkflow(ctx context.Context, name string, form domain.FormSchema) (*domain.Workflow, error)
 	GetWorkflow(ctx context.Context, id string) (*domain.Workflow, error)
-	UpdateWorkflow(ctx context.Context, id string, name string) (*domain.Workflow, error)
-	ListWorkflows(ctx context.Context, page, limit int) ([]*domain.Workflow, int64, error)
+	// UpdateWorkflow renames a workflow; a nil form keeps the current form schema
+	UpdateWorkflow(ctx context.Context, id string, name string, form *domain.FormSchema) (*domain.Workflow, error)
+	ListWorkflows(ctx context.Context, page pagination.Page) ([]*domain.Workflow, pagination.Result, error)
 	DeleteWorkflow(ctx context.Context, id string) error
 }
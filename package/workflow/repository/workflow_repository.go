@@ -3,12 +3,14 @@ package repository
 import (
 	"context"
 	"errors"
+	"time"
 
 	"gorm.io/gorm"
 
 	"workflow-approval/package/workflow/domain"
 	"workflow-approval/package/workflow/ports"
 	"workflow-approval/utils"
+	"workflow-approval/utils/pagination"
 )
 
 var ErrWorkflowNotFound = errors.New("workflow not found")
@@ -41,6 +43,15 @@ func (r *WorkflowRepositoryImpl) GetByID(ctx context.Context, id string) (*domai
 	return &workflow, nil
 }
 
+// ListByName retrieves the workflows with a name, oldest first
+func (r *WorkflowRepositoryImpl) ListByName(ctx context.Context, name string) ([]*domain.Workflow, error) {
+	var workflows []*domain.Workflow
+	if err := r.db.WithContext(ctx).Where("name = ?", name).Order("created_at ASC, id ASC").Find(&workflows).Error; err != nil {
+		return nil, err
+	}
+	return workflows, nil
+}
+
 // Update updates an existing workflow
 func (r *WorkflowRepositoryImpl) Update(ctx context.Context, workflow *domain.Workflow) error {
 	workflow.UpdatedAt = utils.TimeNowUTC()
@@ -56,26 +67,24 @@ func (r *WorkflowRepositoryImpl) Delete(ctx context.Context, id string) error {
 	return nil
 }
 
-// List retrieves a paginated list of workflows
-func (r *WorkflowRepositoryImpl) List(ctx context.Context, page, limit int) ([]*domain.Workflow, int64, error) {
+// List retrieves a page of workflows, newest first
+func (r *WorkflowRepositoryImpl) List(ctx context.Context, page pagination.Page) ([]*domain.Workflow, pagination.Result, error) {
 	var workflows []*domain.Workflow
 	var total int64
 
-	offset := (page - 1) * limit
-
-	// Get total count
-	if err := r.db.WithContext(ctx).Model(&domain.Workflow{}).Count(&total).Error; err != nil {
-		return nil, 0, err
+	if page.WithTotal {
+		if err := r.db.WithContext(ctx).Model(&domain.Workflow{}).Count(&total).Error; err != nil {
+			return nil, pagination.Result{}, err
+		}
 	}
 
-	// Get paginated results
-	if err := r.db.WithContext(ctx).
-		Order("created_at DESC").
-		Offset(offset).
-		Limit(limit).
-		Find(&workflows).Error; err != nil {
-		return nil, 0, err
+	if err := pagination.Apply(r.db.WithContext(ctx), page, true).Find(&workflows).Error; err != nil {
+		return nil, pagination.Result{}, err
 	}
 
-	return workflows, total, nil
+	workflows, result := pagination.Finish(workflows, page, func(w *domain.Workflow) (time.Time, string) {
+		return w.CreatedAt, w.ID
+	})
+	result.Total = total
+	return workflows, result, nil
 }
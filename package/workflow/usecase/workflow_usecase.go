@@ -7,6 +7,7 @@ import (
 	"workflow-approval/package/workflow/domain"
 	"workflow-approval/package/workflow/ports"
 	"workflow-approval/package/workflow/repository"
+	"workflow-approval/utils/pagination"
 )
 
 var (
@@ -24,12 +25,15 @@ func NewWorkflowService(workflowRepo ports.WorkflowRepository) ports.WorkflowSer
 }
 
 // CreateWorkflow creates a new workflow
-func (s *WorkflowServiceImpl) CreateWorkflow(ctx context.Context, name string) (*domain.Workflow, error) {
+func (s *WorkflowServiceImpl) CreateWorkflow(ctx context.Context, name string, form domain.FormSchema) (*domain.Workflow, error) {
 	if name == "" {
 		return nil, ErrWorkflowNameRequired
 	}
+	if err := form.Check(); err != nil {
+		return nil, err
+	}
 
-	workflow := domain.NewWorkflow(name)
+	workflow := domain.NewWorkflow(name, form)
 	if err := s.workflowRepo.Create(ctx, workflow); err != nil {
 		return nil, err
 	}
@@ -42,11 +46,18 @@ func (s *WorkflowServiceImpl) GetWorkflow(ctx context.Context, id string) (*doma
 	return s.workflowRepo.GetByID(ctx, id)
 }
 
-// UpdateWorkflow updates a workflow's name
-func (s *WorkflowServiceImpl) UpdateWorkflow(ctx context.Context, id string, name string) (*domain.Workflow, error) {
+// UpdateWorkflow updates a workflow's name and, when given, its form schema.
+// Requests created earlier keep their values; they are validated against the
+// new schema the next time their fields change.
+func (s *WorkflowServiceImpl) UpdateWorkflow(ctx context.Context, id string, name string, form *domain.FormSchema) (*domain.Workflow, error) {
 	if name == "" {
 		return nil, ErrWorkflowNameRequired
 	}
+	if form != nil {
+		if err := form.Check(); err != nil {
+			return nil, err
+		}
+	}
 
 	workflow, err := s.workflowRepo.GetByID(ctx, id)
 	if err != nil {
@@ -54,6 +65,9 @@ func (s *WorkflowServiceImpl) UpdateWorkflow(ctx context.Context, id string, nam
 	}
 
 	workflow.Name = name
+	if form != nil {
+		workflow.FormSchema = *form
+	}
 	if err := s.workflowRepo.Update(ctx, workflow); err != nil {
 		return nil, err
 	}
@@ -61,18 +75,9 @@ func (s *WorkflowServiceImpl) UpdateWorkflow(ctx context.Context, id string, nam
 	return workflow, nil
 }
 
-// ListWorkflows retrieves a paginated list of workflows
-func (s *WorkflowServiceImpl) ListWorkflows(ctx context.Context, page, limit int) ([]*domain.Workflow, int64, error) {
-	if page < 1 {
-		page = 1
-	}
-	if limit < 1 {
-		limit = 10
-	}
-	if limit > 100 {
-		limit = 100
-	}
-	return s.workflowRepo.List(ctx, page, limit)
+// ListWorkflows retrieves a page of workflows, newest first
+func (s *WorkflowServiceImpl) ListWorkflows(ctx context.Context, page pagination.Page) ([]*domain.Workflow, pagination.Result, error) {
+	return s.workflowRepo.List(ctx, page.Normalized())
 }
 
 // DeleteWorkflow deletes a workflow by ID
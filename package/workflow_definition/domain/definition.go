@@ -0,0 +1,206 @@
+package domain
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+
+	wfDomain "workflow-approval/package/workflow/domain"
+	stepDomain "workflow-approval/package/workflow_step/domain"
+	"workflow-approval/utils/money"
+)
+
+// Format is the document format of a workflow definition
+type Format string
+
+const (
+	FormatYAML Format = "yaml"
+	FormatJSON Format = "json"
+)
+
+var (
+	ErrInvalidFormat     = errors.New("format must be yaml or json")
+	ErrInvalidDefinition = errors.New("invalid workflow definition")
+)
+
+// ParseFormat parses the format parameter of a definition; empty means YAML
+func ParseFormat(s string) (Format, error) {
+	switch Format(s) {
+	case "", FormatYAML, "yml":
+		return FormatYAML, nil
+	case FormatJSON:
+		return FormatJSON, nil
+	}
+	return "", ErrInvalidFormat
+}
+
+// ContentType returns the MIME type of the format
+func (f Format) ContentType() string {
+	if f == FormatJSON {
+		return "application/json"
+	}
+	return "application/yaml"
+}
+
+// Definition is a workflow with its steps as kept in version control. It
+// refers to actors by code and is matched to a workflow by name, so the same
+// file can be applied to any environment.
+type Definition struct {
+	Name  string               `json:"name"`
+	Form  *wfDomain.FormSchema `json:"form,omitempty"`
+	Steps []StepDefinition     `json:"steps"`
+}
+
+// StepDefinition is a step of a workflow definition, identified by its level
+type StepDefinition struct {
+	Level        int                     `json:"level"`
+	AssigneeType stepDomain.AssigneeType `json:"assignee_type,omitempty"` // Defaults to actor
+	Actor        string                  `json:"actor,omitempty"`         // Actor code
+	ManagerLevel int                     `json:"manager_level,omitempty"`
+	LookupField  string                  `json:"lookup_field,omitempty"`
+	Conditions   *ConditionsDefinition   `json:"conditions,omitempty"`
+}
+
+// ConditionsDefinition are the conditions of a step; unset amounts are zero
+type ConditionsDefinition struct {
+	MinAmount *money.Decimal              `json:"min_amount,omitempty"`
+	MaxAmount *money.Decimal              `json:"max_amount,omitempty"`
+	Roles     []string                    `json:"roles,omitempty"`
+	Fields    []stepDomain.FieldCondition `json:"fields,omitempty"`
+}
+
+// Parse reads a definition document. Unknown keys are rejected so typos don't
+// silently drop settings.
+func Parse(data []byte, format Format) (*Definition, error) {
+	if format == FormatYAML {
+		converted, err := yamlToJSON(data)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidDefinition, err)
+		}
+		data = converted
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	var def Definition
+	if err := decoder.Decode(&def); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidDefinition, err)
+	}
+	if err := def.Validate(); err != nil {
+		return nil, err
+	}
+	return &def, nil
+}
+
+// Encode writes a definition document
+func Encode(def *Definition, format Format) ([]byte, error) {
+	data, err := json.MarshalIndent(def, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	if format == FormatYAML {
+		return jsonToYAML(data)
+	}
+	return append(data, '\n'), nil
+}
+
+// Validate checks the definition's own structure; steps are validated like
+// API input when the definition is applied
+func (d *Definition) Validate() error {
+	if d.Name == "" {
+		return fmt.Errorf("%w: name is required", ErrInvalidDefinition)
+	}
+	seen := make(map[int]bool, len(d.Steps))
+	for _, step := range d.Steps {
+		if step.Level < 1 {
+			return fmt.Errorf("%w: step levels start at 1", ErrInvalidDefinition)
+		}
+		if seen[step.Level] {
+			return fmt.Errorf("%w: level %d is defined twice", ErrInvalidDefinition, step.Level)
+		}
+		seen[step.Level] = true
+	}
+	return nil
+}
+
+// FormSchema returns the form of the definition; no form is an empty schema
+func (d *Definition) FormSchema() wfDomain.FormSchema {
+	if d.Form == nil {
+		return wfDomain.FormSchema{Fields: []wfDomain.FormField{}}
+	}
+	return *d.Form
+}
+
+// SortSteps orders the steps by level
+func (d *Definition) SortSteps() {
+	sort.Slice(d.Steps, func(i, j int) bool { return d.Steps[i].Level < d.Steps[j].Level })
+}
+
+// NewStepDefinition describes a step; actorCode is the code of its actor
+func NewStepDefinition(step *stepDomain.WorkflowStep, actorCode string) StepDefinition {
+	assignee := step.Assignee()
+	return StepDefinition{
+		Level:        step.Level,
+		AssigneeType: assignee.Type,
+		Actor:        actorCode,
+		ManagerLevel: assignee.ManagerLevel,
+		LookupField:  assignee.LookupField,
+		Conditions:   newConditionsDefinition(step.Conditions),
+	}
+}
+
+func newConditionsDefinition(sc stepDomain.StepConditions) *ConditionsDefinition {
+	c := &ConditionsDefinition{Roles: sc.Roles, Fields: sc.Fields}
+	if !sc.MinAmount.IsZero() {
+		c.MinAmount = &sc.MinAmount
+	}
+	if !sc.MaxAmount.IsZero() {
+		c.MaxAmount = &sc.MaxAmount
+	}
+	if c.MinAmount == nil && c.MaxAmount == nil && len(c.Roles) == 0 && len(c.Fields) == 0 {
+		return nil
+	}
+	return c
+}
+
+// Assignee returns who approves the step given the ID of its actor
+func (s StepDefinition) Assignee(actorID string) stepDomain.StepAssignee {
+	return stepDomain.StepAssignee{
+		Type:         s.AssigneeType,
+		ActorID:      actorID,
+		ManagerLevel: s.ManagerLevel,
+		LookupField:  s.LookupField,
+	}.Normalize()
+}
+
+// StepConditions returns the conditions of the step
+func (s StepDefinition) StepConditions() stepDomain.StepConditions {
+	var sc stepDomain.StepConditions
+	if s.Conditions == nil {
+		return sc
+	}
+	if s.Conditions.MinAmount != nil {
+		sc.MinAmount = *s.Conditions.MinAmount
+	}
+	if s.Conditions.MaxAmount != nil {
+		sc.MaxAmount = *s.Conditions.MaxAmount
+	}
+	sc.Roles = s.Conditions.Roles
+	sc.Fields = s.Conditions.Fields
+	return sc
+}
+
+// Normalized returns the step as it is stored: the assignee type defaults to
+// actor and settings the type does not use are dropped
+func (s StepDefinition) Normalized() StepDefinition {
+	assignee := s.Assignee("")
+	s.AssigneeType = assignee.Type
+	s.ManagerLevel = assignee.ManagerLevel
+	s.LookupField = assignee.LookupField
+	if s.Conditions != nil {
+		s.Conditions = newConditionsDefinition(s.StepConditions())
+	}
+	return s
+}
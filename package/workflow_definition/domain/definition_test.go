@@ -0,0 +1,76 @@
+package domain
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+const purchaseYAML = `name: Purchase
+form:
+  fields:
+    - name: cost_center
+      type: string
+      required: true
+    - name: nights
+      type: integer
+steps:
+  - level: 1
+    actor: MGR
+  - level: 2
+    assignee_type: manager_level_n
+    manager_level: 2
+    actor: FIN
+    conditions:
+      min_amount: 1000000.50
+      roles:
+        - "true"
+      fields:
+        - field: nights
+          operator: gt
+          value: 3
+`
+
+func TestParseYAMLKeepsNumbersExact(t *testing.T) {
+	def, err := Parse([]byte(purchaseYAML), FormatYAML)
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	step := def.Steps[1]
+	if step.Conditions.MinAmount.String() != "1000000.50" {
+		t.Errorf("Expected an exact amount, got %s", step.Conditions.MinAmount)
+	}
+	// Condition values decode as JSON numbers, as they do from the API
+	if v, ok := step.Conditions.Fields[0].Value.(float64); !ok || v != 3 {
+		t.Errorf("Expected a float64 condition value, got %#v", step.Conditions.Fields[0].Value)
+	}
+
+	out, err := Encode(def, FormatYAML)
+	if err != nil {
+		t.Fatalf("Encode failed: %v", err)
+	}
+	// Block style, and strings that would read as another type stay quoted
+	if !strings.Contains(string(out), "min_amount: 1000000.50") || !strings.Contains(string(out), `- "true"`) || strings.Contains(string(out), "{") {
+		t.Errorf("Unexpected YAML:\n%s", out)
+	}
+	again, err := Parse(out, FormatYAML)
+	if err != nil {
+		t.Fatalf("Parse of encoded definition failed: %v", err)
+	}
+	if again.Steps[1].Conditions.Roles[0] != "true" || again.Steps[1].ManagerLevel != 2 {
+		t.Errorf("Definition changed in a round trip: %+v", again.Steps[1])
+	}
+}
+
+func TestParseRejectsUnknownKeysAndDuplicateLevels(t *testing.T) {
+	for _, doc := range []string{
+		"name: Purchase\nsteps:\n  - level: 1\n    actr: MGR\n",
+		"name: Purchase\nsteps:\n  - level: 1\n  - level: 1\n",
+		"steps: []\n",
+		"",
+	} {
+		if _, err := Parse([]byte(doc), FormatYAML); !errors.Is(err, ErrInvalidDefinition) {
+			t.Errorf("Expected ErrInvalidDefinition for %q, got %v", doc, err)
+		}
+	}
+}
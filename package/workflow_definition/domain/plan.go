@@ -0,0 +1,155 @@
+package domain
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	wfDomain "workflow-approval/package/workflow/domain"
+)
+
+// ChangeAction is what applying a definition does to a workflow or step
+type ChangeAction string
+
+const (
+	ActionCreate ChangeAction = "create"
+	ActionUpdate ChangeAction = "update"
+	ActionDelete ChangeAction = "delete"
+)
+
+// FieldChange is a setting that changes; values are rendered as text, empty when unset
+type FieldChange struct {
+	Field string `json:"field"`
+	From  string `json:"from"`
+	To    string `json:"to"`
+}
+
+// Change is one change of a plan: to the workflow itself, or to the step at Level
+type Change struct {
+	Action ChangeAction  `json:"action"`
+	Target string        `json:"target"` // "workflow" or "step"
+	Level  int           `json:"level,omitempty"`
+	Fields []FieldChange `json:"fields,omitempty"`
+}
+
+// Plan lists the changes applying a definition makes. Unchanged steps are left out.
+type Plan struct {
+	Workflow   string   `json:"workflow"`
+	WorkflowID string   `json:"workflow_id,omitempty"` // Empty while the workflow is still to be created
+	Changes    []Change `json:"changes"`
+	Applied    bool     `json:"applied"` // Whether the changes were written
+}
+
+// NewPlan creates an empty plan for the named workflow
+func NewPlan(workflow string) *Plan {
+	return &Plan{Workflow: workflow, Changes: []Change{}}
+}
+
+// HasChanges reports whether applying the definition changes anything
+func (p *Plan) HasChanges() bool {
+	return len(p.Changes) > 0
+}
+
+// String renders the plan for terminals, one change per line with its settings indented below
+func (p *Plan) String() string {
+	var b strings.Builder
+	if !p.HasChanges() {
+		fmt.Fprintf(&b, "Workflow %q is up to date.\n", p.Workflow)
+		return b.String()
+	}
+
+	signs := map[ChangeAction]string{ActionCreate: "+", ActionUpdate: "~", ActionDelete: "-"}
+	for _, c := range p.Changes {
+		target := fmt.Sprintf("workflow %q", p.Workflow)
+		if c.Target == "step" {
+			target = fmt.Sprintf("step %d", c.Level)
+		}
+		fmt.Fprintf(&b, "%s %s %s\n", signs[c.Action], c.Action, target)
+		for _, f := range c.Fields {
+			switch c.Action {
+			case ActionCreate:
+				fmt.Fprintf(&b, "    %s: %s\n", f.Field, f.To)
+			case ActionDelete:
+				fmt.Fprintf(&b, "    %s: %s\n", f.Field, f.From)
+			default:
+				fmt.Fprintf(&b, "    %s: %s -> %s\n", f.Field, orNone(f.From), orNone(f.To))
+			}
+		}
+	}
+	return b.String()
+}
+
+func orNone(s string) string {
+	if s == "" {
+		return "(none)"
+	}
+	return s
+}
+
+// DiffForm compares two form schemas
+func DiffForm(from, to wfDomain.FormSchema) []FieldChange {
+	return diff([]FieldChange{{Field: "form", From: renderForm(from), To: renderForm(to)}})
+}
+
+// DiffSteps compares two normalized steps; either may be a zero step to
+// describe a step being created or deleted
+func DiffSteps(from, to StepDefinition) []FieldChange {
+	fa, ta := from.render(), to.render()
+	changes := make([]FieldChange, len(fa))
+	for i := range fa {
+		changes[i] = FieldChange{Field: fa[i][0], From: fa[i][1], To: ta[i][1]}
+	}
+	return diff(changes)
+}
+
+// diff keeps the fields whose value changes
+func diff(changes []FieldChange) []FieldChange {
+	out := changes[:0]
+	for _, c := range changes {
+		if c.From != c.To {
+			out = append(out, c)
+		}
+	}
+	return out
+}
+
+// render lists the settings of a step as name/value pairs
+func (s StepDefinition) render() [][2]string {
+	var minAmount, maxAmount, roles, fields string
+	if c := s.Conditions; c != nil {
+		if c.MinAmount != nil {
+			minAmount = c.MinAmount.String()
+		}
+		if c.MaxAmount != nil {
+			maxAmount = c.MaxAmount.String()
+		}
+		roles = strings.Join(c.Roles, ", ")
+		if len(c.Fields) > 0 {
+			out, _ := json.Marshal(c.Fields)
+			fields = string(out)
+		}
+	}
+	var managerLevel string
+	if s.ManagerLevel != 0 {
+		managerLevel = strconv.Itoa(s.ManagerLevel)
+	}
+	return [][2]string{
+		{"assignee_type", string(s.AssigneeType)},
+		{"actor", s.Actor},
+		{"manager_level", managerLevel},
+		{"lookup_field", s.LookupField},
+		{"min_amount", minAmount},
+		{"max_amount", maxAmount},
+		{"roles", roles},
+		{"field_conditions", fields},
+	}
+}
+
+func renderForm(form wfDomain.FormSchema) string {
+	if form.IsEmpty() {
+		return ""
+	}
+	out, _ := json.Marshal(form)
+	return string(out)
+}
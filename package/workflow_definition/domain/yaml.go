@@ -0,0 +1,137 @@
+package domain
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Definitions are decoded and encoded through their JSON form so that the
+// json tags, money.Decimal's exact number handling and the JSON number types
+// of field conditions apply to both formats.
+
+// yamlToJSON converts a YAML document to JSON, keeping number literals as written
+func yamlToJSON(data []byte) ([]byte, error) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, err
+	}
+	if doc.Kind == 0 || len(doc.Content) == 0 {
+		return nil, errors.New("document is empty")
+	}
+	var buf bytes.Buffer
+	if err := writeJSON(&buf, doc.Content[0]); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func writeJSON(buf *bytes.Buffer, n *yaml.Node) error {
+	switch n.Kind {
+	case yaml.AliasNode:
+		return writeJSON(buf, n.Alias)
+	case yaml.MappingNode:
+		buf.WriteByte('{')
+		for i := 0; i+1 < len(n.Content); i += 2 {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			key := n.Content[i]
+			if key.Kind != yaml.ScalarNode {
+				return fmt.Errorf("line %d: keys must be plain strings", key.Line)
+			}
+			writeString(buf, key.Value)
+			buf.WriteByte(':')
+			if err := writeJSON(buf, n.Content[i+1]); err != nil {
+				return err
+			}
+		}
+		buf.WriteByte('}')
+	case yaml.SequenceNode:
+		buf.WriteByte('[')
+		for i, item := range n.Content {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			if err := writeJSON(buf, item); err != nil {
+				return err
+			}
+		}
+		buf.WriteByte(']')
+	case yaml.ScalarNode:
+		return writeScalar(buf, n)
+	default:
+		return fmt.Errorf("line %d: unsupported YAML node", n.Line)
+	}
+	return nil
+}
+
+func writeScalar(buf *bytes.Buffer, n *yaml.Node) error {
+	switch n.ShortTag() {
+	case "!!null":
+		buf.WriteString("null")
+	case "!!bool":
+		var b bool
+		if err := n.Decode(&b); err != nil {
+			return err
+		}
+		buf.WriteString(strconv.FormatBool(b))
+	case "!!int", "!!float":
+		// Plain decimal literals are kept exact; other notations such as 0x1F go through float64
+		var literal json.Number
+		if json.Unmarshal([]byte(n.Value), &literal) == nil {
+			buf.WriteString(n.Value)
+			return nil
+		}
+		var f float64
+		if err := n.Decode(&f); err != nil {
+			return err
+		}
+		out, err := json.Marshal(f)
+		if err != nil {
+			return fmt.Errorf("line %d: %v", n.Line, err)
+		}
+		buf.Write(out)
+	default:
+		writeString(buf, n.Value)
+	}
+	return nil
+}
+
+func writeString(buf *bytes.Buffer, s string) {
+	out, _ := json.Marshal(s)
+	buf.Write(out)
+}
+
+// jsonToYAML converts a JSON document to block-style YAML, keeping key order
+func jsonToYAML(data []byte) ([]byte, error) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, err
+	}
+	clearStyle(&doc)
+
+	var buf bytes.Buffer
+	encoder := yaml.NewEncoder(&buf)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(&doc); err != nil {
+		return nil, err
+	}
+	if err := encoder.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// clearStyle drops the flow style and quoting JSON syntax implies; strings
+// that would read as another type stay quoted
+func clearStyle(n *yaml.Node) {
+	n.Style = 0
+	for _, child := range n.Content {
+		clearStyle(child)
+	}
+}
@@ -0,0 +1,137 @@
+package handler
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+
+	"workflow-approval/package/workflow_definition/domain"
+	"workflow-approval/package/workflow_definition/ports"
+	"workflow-approval/package/workflow_definition/usecase"
+)
+
+// DefinitionHandler handles HTTP requests for workflow definitions
+type DefinitionHandler struct {
+	definitionService ports.DefinitionService
+}
+
+// NewDefinitionHandler creates a new DefinitionHandler instance
+func NewDefinitionHandler(definitionService ports.DefinitionService) *DefinitionHandler {
+	return &DefinitionHandler{
+		definitionService: definitionService,
+	}
+}
+
+// Routes defines all routes for workflow definitions
+// Mounts routes under /api/workflow-definitions (admin only)
+func (h *DefinitionHandler) Routes(group fiber.Router) {
+	// POST /api/workflow-definitions/plan - List the changes applying a definition would make
+	// Body: YAML or JSON definition (format=yaml|json or the Content-Type header)
+	group.Post("/plan", h.Plan)
+
+	// POST /api/workflow-definitions/apply - Create or update the workflow of a definition
+	group.Post("/apply", h.Apply)
+
+	// GET /api/workflow-definitions/:id - Export a workflow and its steps as a definition
+	// Query params: format=yaml|json
+	group.Get("/:id", h.Export)
+}
+
+// Export writes a workflow and its steps as a YAML or JSON definition
+// GET /api/workflow-definitions/:id?format=yaml|json
+func (h *DefinitionHandler) Export(c *fiber.Ctx) error {
+	format, err := domain.ParseFormat(c.Query("format"))
+	if err != nil {
+		return definitionError(c, err)
+	}
+
+	def, err := h.definitionService.Export(c.Context(), c.Params("id"))
+	if err != nil {
+		return definitionError(c, err)
+	}
+	body, err := domain.Encode(def, format)
+	if err != nil {
+		return definitionError(c, err)
+	}
+
+	c.Set(fiber.HeaderContentType, format.ContentType())
+	return c.Status(fiber.StatusOK).Send(body)
+}
+
+// Plan lists the changes applying the definition in the body would make
+// POST /api/workflow-definitions/plan
+func (h *DefinitionHandler) Plan(c *fiber.Ctx) error {
+	def, err := parseBody(c)
+	if err != nil {
+		return definitionError(c, err)
+	}
+
+	plan, err := h.definitionService.Plan(c.Context(), def)
+	if err != nil {
+		return definitionError(c, err)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"success": true,
+		"data":    plan,
+		"error":   nil,
+	})
+}
+
+// Apply creates or updates the workflow of the definition in the body
+// POST /api/workflow-definitions/apply
+func (h *DefinitionHandler) Apply(c *fiber.Ctx) error {
+	def, err := parseBody(c)
+	if err != nil {
+		return definitionError(c, err)
+	}
+
+	plan, err := h.definitionService.Apply(c.Context(), def)
+	if err != nil {
+		return definitionError(c, err)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"success": true,
+		"data":    plan,
+		"error":   nil,
+	})
+}
+
+// parseBody reads the definition in the request body. The format parameter
+// wins over the Content-Type header; bodies are YAML unless either says JSON.
+func parseBody(c *fiber.Ctx) (*domain.Definition, error) {
+	raw := c.Query("format")
+	if raw == "" && strings.HasPrefix(c.Get(fiber.HeaderContentType), fiber.MIMEApplicationJSON) {
+		raw = string(domain.FormatJSON)
+	}
+	format, err := domain.ParseFormat(raw)
+	if err != nil {
+		return nil, err
+	}
+	return domain.Parse(c.Body(), format)
+}
+
+// definitionError maps workflow definition errors to HTTP responses
+func definitionError(c *fiber.Ctx, err error) error {
+	status := fiber.StatusInternalServerError
+	message := "Failed to process workflow definition"
+	var rejected *usecase.RejectedError
+	switch {
+	case errors.Is(err, domain.ErrInvalidFormat), errors.Is(err, domain.ErrInvalidDefinition):
+		status, message = fiber.StatusBadRequest, err.Error()
+	case errors.Is(err, usecase.ErrWorkflowNotFound):
+		status, message = fiber.StatusNotFound, err.Error()
+	case errors.Is(err, usecase.ErrAmbiguousWorkflow), errors.Is(err, usecase.ErrActorDeleted):
+		status, message = fiber.StatusConflict, err.Error()
+	case errors.Is(err, usecase.ErrUnknownActor), errors.As(err, &rejected):
+		status, message = fiber.StatusUnprocessableEntity, err.Error()
+	}
+
+	return c.Status(status).JSON(fiber.Map{
+		"success": false,
+		"data":    nil,
+		"error":   message,
+	})
+}
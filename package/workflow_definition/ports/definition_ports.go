@@ -0,0 +1,59 @@
+package ports
+
+import (
+	"context"
+
+	actorDomain "workflow-approval/package/actor/domain"
+	wfDomain "workflow-approval/package/workflow/domain"
+	wfPorts "workflow-approval/package/workflow/ports"
+	"workflow-approval/package/workflow_definition/domain"
+	stepDomain "workflow-approval/package/workflow_step/domain"
+	stepPorts "workflow-approval/package/workflow_step/ports"
+)
+
+// ActorRepository defines the actor lookups needed to translate between actor codes and IDs
+type ActorRepository interface {
+	GetByID(ctx context.Context, id string) (*actorDomain.Actor, error)
+	GetByCode(ctx context.Context, code string) (*actorDomain.Actor, error)
+}
+
+// WorkflowRepository defines the workflow lookups needed to match a definition
+type WorkflowRepository interface {
+	GetByID(ctx context.Context, id string) (*wfDomain.Workflow, error)
+	ListByName(ctx context.Context, name string) ([]*wfDomain.Workflow, error)
+}
+
+// WorkflowStepRepository defines the step lookups needed to compare a definition
+type WorkflowStepRepository interface {
+	GetByWorkflowID(ctx context.Context, workflowID string) ([]*stepDomain.WorkflowStep, error)
+}
+
+// Services are the services and repositories a definition is applied
+// through, all bound to one transaction. Changes go through the workflow and
+// step services, so they are validated like API input.
+type Services struct {
+	Workflows    wfPorts.WorkflowService
+	Steps        stepPorts.WorkflowStepService
+	ActorRepo    ActorRepository
+	WorkflowRepo WorkflowRepository
+	StepRepo     WorkflowStepRepository
+}
+
+// Transactor runs fn in a database transaction with Services bound to it. The
+// transaction is committed when fn returns nil and rolled back otherwise.
+type Transactor interface {
+	Transaction(ctx context.Context, fn func(Services) error) error
+}
+
+// DefinitionService defines the interface for workflow definition business logic
+type DefinitionService interface {
+	// Export describes a workflow and its steps as a definition
+	Export(ctx context.Context, workflowID string) (*domain.Definition, error)
+	// Plan lists the changes Apply would make without writing them; the
+	// changes are validated exactly as Apply does
+	Plan(ctx context.Context, def *domain.Definition) (*domain.Plan, error)
+	// Apply creates or updates the workflow named by the definition so that it
+	// matches, deleting steps the definition no longer has. Applying the same
+	// definition again changes nothing.
+	Apply(ctx context.Context, def *domain.Definition) (*domain.Plan, error)
+}
@@ -0,0 +1,277 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	actorRepo "workflow-approval/package/actor/repository"
+	wfDomain "workflow-approval/package/workflow/domain"
+	wfRepo "workflow-approval/package/workflow/repository"
+	"workflow-approval/package/workflow_definition/domain"
+	"workflow-approval/package/workflow_definition/ports"
+	stepDomain "workflow-approval/package/workflow_step/domain"
+)
+
+var (
+	ErrWorkflowNotFound  = errors.New("workflow not found")
+	ErrAmbiguousWorkflow = errors.New("several workflows have the definition's name; rename all but one to apply it")
+	ErrUnknownActor      = errors.New("unknown actor code")
+	ErrActorDeleted      = errors.New("the actor of a step no longer exists; assign the step another actor before exporting")
+)
+
+// errRollback ends the transaction of a plan, or of an apply without changes
+var errRollback = errors.New("definition not applied")
+
+// RejectedError is returned when the workflow or step services refuse a change
+// of the definition, e.g. a step condition on a field the form doesn't have
+type RejectedError struct {
+	Target string // "workflow" or "step"
+	Level  int
+	Err    error
+}
+
+func (e *RejectedError) Error() string {
+	if e.Target == "step" {
+		return fmt.Sprintf("step %d: %v", e.Level, e.Err)
+	}
+	return fmt.Sprintf("workflow: %v", e.Err)
+}
+
+func (e *RejectedError) Unwrap() error {
+	return e.Err
+}
+
+// DefinitionServiceImpl implements DefinitionService interface
+type DefinitionServiceImpl struct {
+	transactor ports.Transactor
+}
+
+// NewDefinitionService creates a new DefinitionServiceImpl instance
+func NewDefinitionService(transactor ports.Transactor) ports.DefinitionService {
+	return &DefinitionServiceImpl{transactor: transactor}
+}
+
+// Export describes a workflow and its steps as a definition
+func (s *DefinitionServiceImpl) Export(ctx context.Context, workflowID string) (*domain.Definition, error) {
+	var def *domain.Definition
+	err := s.transactor.Transaction(ctx, func(services ports.Services) error {
+		workflow, err := services.WorkflowRepo.GetByID(ctx, workflowID)
+		if err != nil {
+			if errors.Is(err, wfRepo.ErrWorkflowNotFound) {
+				return ErrWorkflowNotFound
+			}
+			return err
+		}
+		def, _, err = describe(ctx, services, workflow)
+		if err != nil {
+			return err
+		}
+		for _, step := range def.Steps {
+			if step.Actor == "" && step.AssigneeType == stepDomain.AssigneeActor {
+				return fmt.Errorf("step %d: %w", step.Level, ErrActorDeleted)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return def, nil
+}
+
+// Plan lists the changes Apply would make by applying the definition in a
+// transaction that is rolled back
+func (s *DefinitionServiceImpl) Plan(ctx context.Context, def *domain.Definition) (*domain.Plan, error) {
+	return s.apply(ctx, def, true)
+}
+
+// Apply makes the workflow match the definition in one transaction
+func (s *DefinitionServiceImpl) Apply(ctx context.Context, def *domain.Definition) (*domain.Plan, error) {
+	return s.apply(ctx, def, false)
+}
+
+func (s *DefinitionServiceImpl) apply(ctx context.Context, def *domain.Definition, dryRun bool) (*domain.Plan, error) {
+	if err := def.Validate(); err != nil {
+		return nil, err
+	}
+
+	var plan *domain.Plan
+	var workflowID string
+	err := s.transactor.Transaction(ctx, func(services ports.Services) error {
+		var err error
+		plan, workflowID, err = applyDefinition(ctx, services, def)
+		if err != nil {
+			return err
+		}
+		if dryRun || !plan.HasChanges() {
+			return errRollback
+		}
+		return nil
+	})
+	if err != nil && !errors.Is(err, errRollback) {
+		return nil, err
+	}
+	plan.Applied = err == nil
+	if plan.Applied {
+		plan.WorkflowID = workflowID
+	}
+	return plan, nil
+}
+
+// applyDefinition changes the workflow to match the definition, recording
+// each change in the returned plan. The form is changed first so steps are
+// checked against the new form, then steps are deleted, updated and created.
+// The ID of the workflow is returned too, as the plan only has it for
+// workflows that already existed.
+func applyDefinition(ctx context.Context, services ports.Services, def *domain.Definition) (*domain.Plan, string, error) {
+	plan := domain.NewPlan(def.Name)
+	desiredForm := def.FormSchema()
+
+	actorIDs, err := resolveActors(ctx, services, def)
+	if err != nil {
+		return nil, "", err
+	}
+
+	workflows, err := services.WorkflowRepo.ListByName(ctx, def.Name)
+	if err != nil {
+		return nil, "", err
+	}
+	if len(workflows) > 1 {
+		return nil, "", ErrAmbiguousWorkflow
+	}
+
+	current := &domain.Definition{Name: def.Name}
+	steps := make(map[int]*stepDomain.WorkflowStep)
+	var workflow *wfDomain.Workflow
+	if len(workflows) == 0 {
+		plan.Changes = append(plan.Changes, domain.Change{
+			Action: domain.ActionCreate,
+			Target: "workflow",
+			Fields: domain.DiffForm(wfDomain.FormSchema{}, desiredForm),
+		})
+		if workflow, err = services.Workflows.CreateWorkflow(ctx, def.Name, desiredForm); err != nil {
+			return nil, "", &RejectedError{Target: "workflow", Err: err}
+		}
+	} else {
+		workflow = workflows[0]
+		plan.WorkflowID = workflow.ID
+		if current, steps, err = describe(ctx, services, workflow); err != nil {
+			return nil, "", err
+		}
+		if fields := domain.DiffForm(workflow.FormSchema, desiredForm); len(fields) > 0 {
+			plan.Changes = append(plan.Changes, domain.Change{Action: domain.ActionUpdate, Target: "workflow", Fields: fields})
+			if _, err := services.Workflows.UpdateWorkflow(ctx, workflow.ID, workflow.Name, &desiredForm); err != nil {
+				return nil, "", &RejectedError{Target: "workflow", Err: err}
+			}
+		}
+	}
+
+	def.SortSteps()
+	desired := make(map[int]domain.StepDefinition, len(def.Steps))
+	for _, step := range def.Steps {
+		desired[step.Level] = step.Normalized()
+	}
+
+	for _, step := range current.Steps {
+		if _, keep := desired[step.Level]; keep {
+			continue
+		}
+		plan.Changes = append(plan.Changes, domain.Change{
+			Action: domain.ActionDelete,
+			Target: "step",
+			Level:  step.Level,
+			Fields: domain.DiffSteps(step, domain.StepDefinition{}),
+		})
+		if err := services.Steps.DeleteStep(ctx, steps[step.Level].ID); err != nil {
+			return nil, "", &RejectedError{Target: "step", Level: step.Level, Err: err}
+		}
+	}
+
+	currentByLevel := make(map[int]domain.StepDefinition, len(current.Steps))
+	for _, step := range current.Steps {
+		currentByLevel[step.Level] = step
+	}
+	for _, step := range def.Steps {
+		want := desired[step.Level]
+		assignee, conditions := want.Assignee(actorIDs[want.Actor]), want.StepConditions()
+
+		have, exists := currentByLevel[step.Level]
+		if !exists {
+			plan.Changes = append(plan.Changes, domain.Change{
+				Action: domain.ActionCreate,
+				Target: "step",
+				Level:  step.Level,
+				Fields: domain.DiffSteps(domain.StepDefinition{}, want),
+			})
+			if _, err := services.Steps.CreateStep(ctx, workflow.ID, step.Level, assignee, conditions); err != nil {
+				return nil, "", &RejectedError{Target: "step", Level: step.Level, Err: err}
+			}
+			continue
+		}
+
+		fields := domain.DiffSteps(have, want)
+		if len(fields) == 0 {
+			continue
+		}
+		plan.Changes = append(plan.Changes, domain.Change{Action: domain.ActionUpdate, Target: "step", Level: step.Level, Fields: fields})
+		if _, err := services.Steps.UpdateStep(ctx, steps[step.Level].ID, step.Level, assignee, conditions); err != nil {
+			return nil, "", &RejectedError{Target: "step", Level: step.Level, Err: err}
+		}
+	}
+
+	return plan, workflow.ID, nil
+}
+
+// describe returns the definition of a workflow with its steps by level. Steps
+// whose actor no longer exists are described without an actor.
+func describe(ctx context.Context, services ports.Services, workflow *wfDomain.Workflow) (*domain.Definition, map[int]*stepDomain.WorkflowStep, error) {
+	stored, err := services.StepRepo.GetByWorkflowID(ctx, workflow.ID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	def := &domain.Definition{Name: workflow.Name, Steps: make([]domain.StepDefinition, 0, len(stored))}
+	if !workflow.FormSchema.IsEmpty() {
+		form := workflow.FormSchema
+		def.Form = &form
+	}
+	steps := make(map[int]*stepDomain.WorkflowStep, len(stored))
+	codes := make(map[string]string)
+	for _, step := range stored {
+		code, ok := codes[step.ActorID]
+		if !ok && step.ActorID != "" {
+			actor, err := services.ActorRepo.GetByID(ctx, step.ActorID)
+			if err != nil && !errors.Is(err, actorRepo.ErrActorNotFound) {
+				return nil, nil, err
+			}
+			if actor != nil {
+				code = actor.Code
+			}
+			codes[step.ActorID] = code
+		}
+		def.Steps = append(def.Steps, domain.NewStepDefinition(step, code))
+		steps[step.Level] = step
+	}
+	def.SortSteps()
+	return def, steps, nil
+}
+
+// resolveActors maps the actor codes of a definition to actor IDs
+func resolveActors(ctx context.Context, services ports.Services, def *domain.Definition) (map[string]string, error) {
+	ids := map[string]string{"": ""}
+	for _, step := range def.Steps {
+		if _, ok := ids[step.Actor]; ok {
+			continue
+		}
+		actor, err := services.ActorRepo.GetByCode(ctx, step.Actor)
+		if err != nil {
+			if errors.Is(err, actorRepo.ErrActorNotFound) {
+				return nil, fmt.Errorf("%w %q (step %d)", ErrUnknownActor, step.Actor, step.Level)
+			}
+			return nil, err
+		}
+		ids[step.Actor] = actor.ID
+	}
+	return ids, nil
+}
@@ -0,0 +1,297 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	actorDomain "workflow-approval/package/actor/domain"
+	actorRepo "workflow-approval/package/actor/repository"
+	wfDomain "workflow-approval/package/workflow/domain"
+	wfPorts "workflow-approval/package/workflow/ports"
+	wfRepo "workflow-approval/package/workflow/repository"
+	"workflow-approval/package/workflow_definition/domain"
+	"workflow-approval/package/workflow_definition/ports"
+	stepDomain "workflow-approval/package/workflow_step/domain"
+	stepPorts "workflow-approval/package/workflow_step/ports"
+)
+
+// store holds workflows and steps; the fakes embed the ports they implement
+// and only define the methods applying a definition calls
+type store struct {
+	actors    []*actorDomain.Actor
+	workflows map[string]*wfDomain.Workflow
+	steps     map[string]*stepDomain.WorkflowStep
+	nextID    int
+}
+
+func (s *store) clone() *store {
+	c := &store{actors: s.actors, nextID: s.nextID,
+		workflows: make(map[string]*wfDomain.Workflow), steps: make(map[string]*stepDomain.WorkflowStep)}
+	for id, w := range s.workflows {
+		copied := *w
+		c.workflows[id] = &copied
+	}
+	for id, step := range s.steps {
+		copied := *step
+		c.steps[id] = &copied
+	}
+	return c
+}
+
+func (s *store) id(prefix string) string {
+	s.nextID++
+	return fmt.Sprintf("%s-%d", prefix, s.nextID)
+}
+
+type fakeActors struct{ s *store }
+
+func (f *fakeActors) GetByID(ctx context.Context, id string) (*actorDomain.Actor, error) {
+	for _, a := range f.s.actors {
+		if a.ID == id {
+			return a, nil
+		}
+	}
+	return nil, actorRepo.ErrActorNotFound
+}
+
+func (f *fakeActors) GetByCode(ctx context.Context, code string) (*actorDomain.Actor, error) {
+	for _, a := range f.s.actors {
+		if a.Code == code {
+			return a, nil
+		}
+	}
+	return nil, actorRepo.ErrActorNotFound
+}
+
+type fakeWorkflows struct {
+	wfPorts.WorkflowService
+	s *store
+}
+
+func (f *fakeWorkflows) CreateWorkflow(ctx context.Context, name string, form wfDomain.FormSchema) (*wfDomain.Workflow, error) {
+	w := &wfDomain.Workflow{ID: f.s.id("wf"), Name: name, FormSchema: form}
+	f.s.workflows[w.ID] = w
+	return w, nil
+}
+
+func (f *fakeWorkflows) UpdateWorkflow(ctx context.Context, id, name string, form *wfDomain.FormSchema) (*wfDomain.Workflow, error) {
+	w := f.s.workflows[id]
+	w.Name, w.FormSchema = name, *form
+	return w, nil
+}
+
+func (f *fakeWorkflows) GetByID(ctx context.Context, id string) (*wfDomain.Workflow, error) {
+	if w, ok := f.s.workflows[id]; ok {
+		return w, nil
+	}
+	return nil, wfRepo.ErrWorkflowNotFound
+}
+
+func (f *fakeWorkflows) ListByName(ctx context.Context, name string) ([]*wfDomain.Workflow, error) {
+	var out []*wfDomain.Workflow
+	for _, w := range f.s.workflows {
+		if w.Name == name {
+			out = append(out, w)
+		}
+	}
+	return out, nil
+}
+
+type fakeSteps struct {
+	stepPorts.WorkflowStepService
+	s *store
+}
+
+func (f *fakeSteps) CreateStep(ctx context.Context, workflowID string, level int, assignee stepDomain.StepAssignee, conditions stepDomain.StepConditions) (*stepDomain.WorkflowStep, error) {
+	if assignee.Type == stepDomain.AssigneeActor && assignee.ActorID == "" {
+		return nil, errors.New("step actor is required")
+	}
+	step := &stepDomain.WorkflowStep{ID: f.s.id("step"), WorkflowID: workflowID, Level: level, Conditions: conditions}
+	step.SetAssignee(assignee)
+	f.s.steps[step.ID] = step
+	return step, nil
+}
+
+func (f *fakeSteps) UpdateStep(ctx context.Context, id string, level int, assignee stepDomain.StepAssignee, conditions stepDomain.StepConditions) (*stepDomain.WorkflowStep, error) {
+	step := f.s.steps[id]
+	step.Level, step.Conditions = level, conditions
+	step.SetAssignee(assignee)
+	return step, nil
+}
+
+func (f *fakeSteps) DeleteStep(ctx context.Context, id string) error {
+	delete(f.s.steps, id)
+	return nil
+}
+
+func (f *fakeSteps) GetByWorkflowID(ctx context.Context, workflowID string) ([]*stepDomain.WorkflowStep, error) {
+	var out []*stepDomain.WorkflowStep
+	for _, step := range f.s.steps {
+		if step.WorkflowID == workflowID {
+			out = append(out, step)
+		}
+	}
+	return out, nil
+}
+
+// fakeTransactor applies changes to a copy of the store, kept only on commit
+type fakeTransactor struct {
+	committed *store
+	commits   int
+}
+
+func (f *fakeTransactor) Transaction(ctx context.Context, fn func(ports.Services) error) error {
+	s := f.committed.clone()
+	workflows, steps := &fakeWorkflows{s: s}, &fakeSteps{s: s}
+	err := fn(ports.Services{
+		Workflows:    workflows,
+		Steps:        steps,
+		ActorRepo:    &fakeActors{s: s},
+		WorkflowRepo: workflows,
+		StepRepo:     steps,
+	})
+	if err == nil {
+		f.committed = s
+		f.commits++
+	}
+	return err
+}
+
+func newTestDefinitionService() (ports.DefinitionService, *fakeTransactor) {
+	transactor := &fakeTransactor{committed: &store{
+		actors: []*actorDomain.Actor{
+			{ID: "actor-mgr", Code: "MGR"},
+			{ID: "actor-fin", Code: "FIN"},
+			{ID: "actor-ops", Code: "OPS"},
+		},
+		workflows: make(map[string]*wfDomain.Workflow),
+		steps:     make(map[string]*stepDomain.WorkflowStep),
+	}}
+	return NewDefinitionService(transactor), transactor
+}
+
+func mustParse(t *testing.T, doc string) *domain.Definition {
+	t.Helper()
+	def, err := domain.Parse([]byte(doc), domain.FormatYAML)
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	return def
+}
+
+const purchase = `name: Purchase
+steps:
+  - level: 1
+    actor: MGR
+  - level: 2
+    actor: FIN
+    conditions:
+      min_amount: 1000000
+  - level: 3
+    assignee_type: department_head
+`
+
+func TestPlanThenApplyIsIdempotent(t *testing.T) {
+	service, transactor := newTestDefinitionService()
+	ctx := context.Background()
+
+	plan, err := service.Plan(ctx, mustParse(t, purchase))
+	if err != nil {
+		t.Fatalf("Plan failed: %v", err)
+	}
+	if plan.Applied || len(plan.Changes) != 4 || plan.WorkflowID != "" || transactor.commits != 0 {
+		t.Fatalf("Expected 4 planned changes and nothing written, got %+v", plan)
+	}
+
+	applied, err := service.Apply(ctx, mustParse(t, purchase))
+	if err != nil {
+		t.Fatalf("Apply failed: %v", err)
+	}
+	if !applied.Applied || applied.WorkflowID == "" || fmt.Sprint(applied.Changes) != fmt.Sprint(plan.Changes) {
+		t.Fatalf("Expected the planned changes to be applied, got %+v", applied)
+	}
+
+	again, err := service.Apply(ctx, mustParse(t, purchase))
+	if err != nil {
+		t.Fatalf("Second apply failed: %v", err)
+	}
+	if again.HasChanges() || again.Applied || transactor.commits != 1 {
+		t.Errorf("Expected applying again to change nothing, got %+v", again)
+	}
+
+	// Exporting the workflow gives back an equivalent definition
+	exported, err := service.Export(ctx, applied.WorkflowID)
+	if err != nil {
+		t.Fatalf("Export failed: %v", err)
+	}
+	if noop, _ := service.Plan(ctx, exported); noop.HasChanges() {
+		t.Errorf("Expected the exported definition to plan no changes, got %+v", noop.Changes)
+	}
+}
+
+func TestApplyUpdatesAndDeletesSteps(t *testing.T) {
+	service, transactor := newTestDefinitionService()
+	ctx := context.Background()
+	if _, err := service.Apply(ctx, mustParse(t, purchase)); err != nil {
+		t.Fatalf("Apply failed: %v", err)
+	}
+
+	changed := `name: Purchase
+form:
+  fields:
+    - name: cost_center
+      type: string
+steps:
+  - level: 1
+    actor: MGR
+  - level: 2
+    actor: OPS
+    conditions:
+      min_amount: 1000000
+`
+	plan, err := service.Apply(ctx, mustParse(t, changed))
+	if err != nil {
+		t.Fatalf("Apply failed: %v", err)
+	}
+	text := plan.String()
+	for _, want := range []string{"~ update workflow \"Purchase\"", "~ update step 2\n    actor: FIN -> OPS\n", "- delete step 3\n    assignee_type: department_head\n"} {
+		if !strings.Contains(text, want) {
+			t.Errorf("Expected plan to contain %q, got:\n%s", want, text)
+		}
+	}
+	if strings.Contains(text, "step 1") {
+		t.Errorf("Expected the unchanged step to be left out, got:\n%s", text)
+	}
+	if len(transactor.committed.steps) != 2 {
+		t.Errorf("Expected 2 steps after apply, got %d", len(transactor.committed.steps))
+	}
+}
+
+func TestApplyRollsBackRejectedDefinitions(t *testing.T) {
+	service, transactor := newTestDefinitionService()
+	ctx := context.Background()
+
+	_, err := service.Apply(ctx, mustParse(t, "name: Purchase\nsteps:\n  - level: 1\n    actor: NOPE\n"))
+	if !errors.Is(err, ErrUnknownActor) {
+		t.Errorf("Expected ErrUnknownActor, got %v", err)
+	}
+
+	// The second step is refused after the workflow and first step were created
+	_, err = service.Apply(ctx, mustParse(t, "name: Purchase\nsteps:\n  - level: 1\n    actor: MGR\n  - level: 2\n"))
+	var rejected *RejectedError
+	if !errors.As(err, &rejected) || rejected.Level != 2 {
+		t.Errorf("Expected step 2 to be rejected, got %v", err)
+	}
+	if len(transactor.committed.workflows) != 0 || len(transactor.committed.steps) != 0 {
+		t.Errorf("Expected nothing to be written")
+	}
+
+	transactor.committed.workflows["wf-a"] = &wfDomain.Workflow{ID: "wf-a", Name: "Purchase"}
+	transactor.committed.workflows["wf-b"] = &wfDomain.Workflow{ID: "wf-b", Name: "Purchase"}
+	if _, err := service.Plan(ctx, mustParse(t, purchase)); !errors.Is(err, ErrAmbiguousWorkflow) {
+		t.Errorf("Expected ErrAmbiguousWorkflow, got %v", err)
+	}
+}
@@ -4,16 +4,42 @@ import "workflow-approval/package/workflow_step/domain"
 
 // CreateStepRequest represents the create step request body
 type CreateStepRequest struct {
-	Level       int                   `json:"level"`
-	ActorID     string                `json:"actor_id"`
-	Conditions  domain.StepConditions `json:"conditions"`
-	Description string                `json:"description"`
+	Level        int                   `json:"level"`
+	AssigneeType domain.AssigneeType   `json:"assignee_type"` // Defaults to actor
+	ActorID      string                `json:"actor_id"`      // Required for actor steps, fallback for the others
+	ManagerLevel int                   `json:"manager_level"` // manager_level_n only
+	LookupField  string                `json:"lookup_field"`  // field_lookup only
+	Conditions   domain.StepConditions `json:"conditions"`
+	Description  string                `json:"description"`
+}
+
+// Assignee returns who approves the step
+func (r CreateStepRequest) Assignee() domain.StepAssignee {
+	return domain.StepAssignee{
+		Type:         r.AssigneeType,
+		ActorID:      r.ActorID,
+		ManagerLevel: r.ManagerLevel,
+		LookupField:  r.LookupField,
+	}
 }
 
 // UpdateStepRequest represents the update step request body
 type UpdateStepRequest struct {
-	Level       int                   `json:"level"`
-	ActorID     string                `json:"actor_id"`
-	Conditions  domain.StepConditions `json:"conditions"`
-	Description string                `json:"description"`
+	Level        int                   `json:"level"`
+	AssigneeType domain.AssigneeType   `json:"assignee_type"` // Defaults to actor
+	ActorID      string                `json:"actor_id"`      // Required for actor steps, fallback for the others
+	ManagerLevel int                   `json:"manager_level"` // manager_level_n only
+	LookupField  string                `json:"lookup_field"`  // field_lookup only
+	Conditions   domain.StepConditions `json:"conditions"`
+	Description  string                `json:"description"`
+}
+
+// Assignee returns who approves the step
+func (r UpdateStepRequest) Assignee() domain.StepAssignee {
+	return domain.StepAssignee{
+		Type:         r.AssigneeType,
+		ActorID:      r.ActorID,
+		ManagerLevel: r.ManagerLevel,
+		LookupField:  r.LookupField,
+	}
 }
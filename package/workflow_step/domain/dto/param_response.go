@@ -6,13 +6,16 @@ import (
 
 // StepResponse represents the step response
 type StepResponse struct {
-	ID          string                `json:"id"`
-	WorkflowID  string                `json:"workflow_id"`
-	Level       int                   `json:"level"`
-	ActorID     string                `json:"actor_id"`
-	Conditions  domain.StepConditions `json:"conditions"`
-	Description string                `json:"description"`
-	CreatedAt   string                `json:"created_at"`
+	ID           string                `json:"id"`
+	WorkflowID   string                `json:"workflow_id"`
+	Level        int                   `json:"level"`
+	AssigneeType domain.AssigneeType   `json:"assignee_type"`
+	ActorID      string                `json:"actor_id"`
+	ManagerLevel int                   `json:"manager_level,omitempty"`
+	LookupField  string                `json:"lookup_field,omitempty"`
+	Conditions   domain.StepConditions `json:"conditions"`
+	Description  string                `json:"description"`
+	CreatedAt    string                `json:"created_at"`
 }
 
 // ToStepResponse converts a WorkflowStep to StepResponse
@@ -20,13 +23,17 @@ func ToStepResponse(s *domain.WorkflowStep) *StepResponse {
 	if s == nil {
 		return nil
 	}
+	assignee := s.Assignee()
 	return &StepResponse{
-		ID:         s.ID,
-		WorkflowID: s.WorkflowID,
-		Level:      s.Level,
-		ActorID:    s.ActorID,
-		Conditions: s.Conditions,
-		CreatedAt:  s.CreatedAt.Format("2006-01-02T15:04:05Z"),
+		ID:           s.ID,
+		WorkflowID:   s.WorkflowID,
+		Level:        s.Level,
+		AssigneeType: assignee.Type,
+		ActorID:      s.ActorID,
+		ManagerLevel: assignee.ManagerLevel,
+		LookupField:  assignee.LookupField,
+		Conditions:   s.Conditions,
+		CreatedAt:    s.CreatedAt.Format("2006-01-02T15:04:05Z"),
 	}
 }
 
@@ -5,35 +5,234 @@ import (
 	"database/sql/driver"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"time"
 
 	"workflow-approval/utils"
+	"workflow-approval/utils/money"
+)
+
+// AssigneeType determines how the approver of a step is found
+type AssigneeType string
+
+const (
+	AssigneeActor            AssigneeType = "actor"             // Any member of ActorID
+	AssigneeRequesterManager AssigneeType = "requester_manager" // The requester's line manager
+	AssigneeManagerLevelN    AssigneeType = "manager_level_n"   // The requester's manager ManagerLevel levels up
+	AssigneeDepartmentHead   AssigneeType = "department_head"   // Head of the requester's department
+	AssigneeFieldLookup      AssigneeType = "field_lookup"      // Owner of the request's LookupField value, e.g. a cost center
+)
+
+var (
+	ErrInvalidAssigneeType      = errors.New("invalid assignee_type")
+	ErrInvalidConditionOperator = errors.New("invalid condition operator")
+	ErrConditionFieldRequired   = errors.New("condition field is required")
+	ErrStepManagerLevelRequired = errors.New("manager_level must be at least 1 for manager_level_n steps")
+	ErrStepLookupFieldRequired  = errors.New("lookup_field is required for field_lookup steps")
 )
 
 // WorkflowStep represents a step in an approval workflow
 type WorkflowStep struct {
-	ID          string         `json:"id" gorm:"primaryKey;size:36"`
-	WorkflowID  string         `json:"workflow_id" gorm:"size:36;not null;index"`
-	Level       int            `json:"level" gorm:"not null"`
-	ActorID     string         `json:"actor_id" gorm:"size:36;not null"`
-	Conditions  StepConditions `json:"conditions" gorm:"type:text"`
-	Description string         `json:"description" gorm:"size:500"`
-	CreatedAt   time.Time      `json:"created_at"`
-	UpdatedAt   time.Time      `json:"updated_at"`
+	ID         string `json:"id" gorm:"primaryKey;size:36"`
+	TenantID   string `json:"tenant_id" gorm:"size:36;not null;index"`
+	WorkflowID string `json:"workflow_id" gorm:"size:36;not null;index"`
+	Level      int    `json:"level" gorm:"not null"`
+	// ActorID is the approving actor of actor steps; for the other assignee
+	// types it is an optional fallback when no approver can be resolved
+	ActorID      string         `json:"actor_id" gorm:"size:36;not null"`
+	AssigneeType AssigneeType   `json:"assignee_type" gorm:"size:30;not null;default:actor"`
+	ManagerLevel int            `json:"manager_level" gorm:"not null;default:0"`
+	LookupField  string         `json:"lookup_field" gorm:"size:100;not null;default:''"`
+	Conditions   StepConditions `json:"conditions" gorm:"type:text"`
+	Description  string         `json:"description" gorm:"size:500"`
+	CreatedAt    time.Time      `json:"created_at"`
+	UpdatedAt    time.Time      `json:"updated_at"`
+}
+
+// StepAssignee describes who approves a step
+type StepAssignee struct {
+	Type         AssigneeType `json:"assignee_type"`
+	ActorID      string       `json:"actor_id"`
+	ManagerLevel int          `json:"manager_level"`
+	LookupField  string       `json:"lookup_field"`
+}
+
+// Normalize defaults the type to actor and clears settings the type does not use
+func (a StepAssignee) Normalize() StepAssignee {
+	if a.Type == "" {
+		a.Type = AssigneeActor
+	}
+	if a.Type != AssigneeManagerLevelN {
+		a.ManagerLevel = 0
+	}
+	if a.Type != AssigneeFieldLookup {
+		a.LookupField = ""
+	}
+	return a
+}
+
+// Validate checks the settings each assignee type needs; a normalized assignee is expected
+func (a StepAssignee) Validate() error {
+	switch a.Type {
+	case AssigneeActor, AssigneeRequesterManager, AssigneeDepartmentHead:
+		return nil
+	case AssigneeManagerLevelN:
+		if a.ManagerLevel < 1 {
+			return ErrStepManagerLevelRequired
+		}
+		return nil
+	case AssigneeFieldLookup:
+		if a.LookupField == "" {
+			return ErrStepLookupFieldRequired
+		}
+		return nil
+	default:
+		return ErrInvalidAssigneeType
+	}
+}
+
+// IsDynamic reports whether the approver is resolved per request rather than a fixed actor
+func (a AssigneeType) IsDynamic() bool {
+	return a != "" && a != AssigneeActor
+}
+
+// Assignee returns who approves the step
+func (s *WorkflowStep) Assignee() StepAssignee {
+	return StepAssignee{
+		Type:         s.AssigneeType,
+		ActorID:      s.ActorID,
+		ManagerLevel: s.ManagerLevel,
+		LookupField:  s.LookupField,
+	}.Normalize()
+}
+
+// SetAssignee changes who approves the step
+func (s *WorkflowStep) SetAssignee(a StepAssignee) {
+	a = a.Normalize()
+	s.AssigneeType = a.Type
+	s.ActorID = a.ActorID
+	s.ManagerLevel = a.ManagerLevel
+	s.LookupField = a.LookupField
 }
 
 // StepConditions represents the conditions for a workflow step
 type StepConditions struct {
-	MinAmount float64  `json:"min_amount,omitempty"`
-	MaxAmount float64  `json:"max_amount,omitempty"`
-	Roles     []string `json:"roles,omitempty"`
+	MinAmount money.Decimal    `json:"min_amount"` // In the base currency; 0 applies to any amount
+	MaxAmount money.Decimal    `json:"max_amount"` // In the base currency
+	Roles     []string         `json:"roles,omitempty"`
+	Fields    []FieldCondition `json:"fields,omitempty"` // All must hold for the request's custom fields
+}
+
+// ConditionOperator compares a request field with a condition value
+type ConditionOperator string
+
+const (
+	OpEquals       ConditionOperator = "eq"
+	OpNotEquals    ConditionOperator = "ne"
+	OpGreater      ConditionOperator = "gt"
+	OpGreaterEqual ConditionOperator = "gte"
+	OpLess         ConditionOperator = "lt"
+	OpLessEqual    ConditionOperator = "lte"
+	OpIn           ConditionOperator = "in" // Value is a list
+)
+
+// FieldCondition is a rule on one of the request's custom fields, e.g. destination eq "abroad"
+type FieldCondition struct {
+	Field    string            `json:"field"`
+	Operator ConditionOperator `json:"operator"`
+	Value    interface{}       `json:"value"`
+}
+
+// Validate checks the field conditions' operators
+func (sc StepConditions) Validate() error {
+	for _, fc := range sc.Fields {
+		if fc.Field == "" {
+			return ErrConditionFieldRequired
+		}
+		switch fc.Operator {
+		case OpEquals, OpNotEquals, OpGreater, OpGreaterEqual, OpLess, OpLessEqual:
+		case OpIn:
+			if _, ok := fc.Value.([]interface{}); !ok {
+				return fmt.Errorf("%w: %q needs a list value", ErrInvalidConditionOperator, fc.Operator)
+			}
+		default:
+			return fmt.Errorf("%w: %q", ErrInvalidConditionOperator, fc.Operator)
+		}
+	}
+	return nil
+}
+
+// MatchesFields reports whether every field condition holds for the request's
+// fields; a missing field fails every operator except ne
+func (sc StepConditions) MatchesFields(fields map[string]interface{}) bool {
+	for _, fc := range sc.Fields {
+		if !fc.Matches(fields) {
+			return false
+		}
+	}
+	return true
+}
+
+// Matches reports whether the condition holds for the request's fields
+func (fc FieldCondition) Matches(fields map[string]interface{}) bool {
+	return fc.matches(fields[fc.Field])
+}
+
+func (fc FieldCondition) matches(v interface{}) bool {
+	switch fc.Operator {
+	case OpEquals:
+		return v != nil && equalValues(v, fc.Value)
+	case OpNotEquals:
+		return v == nil || !equalValues(v, fc.Value)
+	case OpIn:
+		list, _ := fc.Value.([]interface{})
+		for _, item := range list {
+			if v != nil && equalValues(v, item) {
+				return true
+			}
+		}
+		return false
+	}
+
+	// Ordering works on numbers and on strings such as dates
+	switch a := v.(type) {
+	case float64:
+		b, ok := fc.Value.(float64)
+		return ok && compareOrdered(fc.Operator, a, b)
+	case string:
+		b, ok := fc.Value.(string)
+		return ok && compareOrdered(fc.Operator, a, b)
+	}
+	return false
+}
+
+func equalValues(a, b interface{}) bool {
+	switch a.(type) {
+	case string, float64, bool:
+		return a == b
+	}
+	return false
+}
+
+func compareOrdered[T float64 | string](op ConditionOperator, a, b T) bool {
+	switch op {
+	case OpGreater:
+		return a > b
+	case OpGreaterEqual:
+		return a >= b
+	case OpLess:
+		return a < b
+	case OpLessEqual:
+		return a <= b
+	}
+	return false
 }
 
 // Value implements driver.Valuer interface for GORM
 func (sc StepConditions) Value() (driver.Value, error) {
 	// Check if empty by checking if all fields are zero values
 	// Use JSON comparison to detect empty struct
-	if sc.MinAmount == 0 && sc.MaxAmount == 0 && len(sc.Roles) == 0 {
+	if sc.MinAmount.IsZero() && sc.MaxAmount.IsZero() && len(sc.Roles) == 0 && len(sc.Fields) == 0 {
 		// Return empty object instead of nil to preserve field
 		return []byte(`{}`), nil
 	}
@@ -90,17 +289,18 @@ var _ sql.Scanner = (*StepConditions)(nil)
 var _ driver.Valuer = StepConditions{}
 
 // NewWorkflowStep creates a new WorkflowStep instance
-func NewWorkflowStep(workflowID string, level int, actorID string, conditions StepConditions) *WorkflowStep {
+func NewWorkflowStep(workflowID string, level int, assignee StepAssignee, conditions StepConditions) *WorkflowStep {
 	now := utils.TimeNowUTC()
-	return &WorkflowStep{
+	step := &WorkflowStep{
 		ID:         utils.GenerateUUID(),
 		WorkflowID: workflowID,
 		Level:      level,
-		ActorID:    actorID,
 		Conditions: conditions,
 		CreatedAt:  now,
 		UpdatedAt:  now,
 	}
+	step.SetAssignee(assignee)
+	return step
 }
 
 // TableName returns the table name for GORM
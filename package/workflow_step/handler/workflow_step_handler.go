@@ -59,7 +59,7 @@ func (h *WorkflowStepHandler) Create(c *fiber.Ctx) error {
 		})
 	}
 
-	step, err := h.stepService.CreateStep(c.Context(), workflowID, req.Level, req.ActorID, req.Conditions)
+	step, err := h.stepService.CreateStep(c.Context(), workflowID, req.Level, req.Assignee(), req.Conditions)
 	if err != nil {
 		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
 			"success": false,
@@ -180,7 +180,7 @@ func (h *WorkflowStepHandler) Update(c *fiber.Ctx) error {
 		})
 	}
 
-	step, err := h.stepService.UpdateStep(c.Context(), stepID, req.Level, req.ActorID, req.Conditions)
+	step, err := h.stepService.UpdateStep(c.Context(), stepID, req.Level, req.Assignee(), req.Conditions)
 	if err != nil {
 		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
 			"success": false,
@@ -98,6 +98,53 @@ func (_c *WorkflowStepRepository_Delete_Call) Return(_a0 error) *WorkflowStepRep
 	return _c
 }
 
+// GetByActorID provides a mock function with given fields: ctx, actorID
+func (_m *WorkflowStepRepository) GetByActorID(ctx context.Context, actorID string) ([]*domain.WorkflowStep, error) {
+	ret := _m.Called(ctx, actorID)
+
+	var r0 []*domain.WorkflowStep
+	if rf, ok := ret.Get(0).(func(context.Context, string) []*domain.WorkflowStep); ok {
+		r0 = rf(ctx, actorID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*domain.WorkflowStep)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, actorID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// WorkflowStepRepository_GetByActorID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetByActorID'
+type WorkflowStepRepository_GetByActorID_Call struct {
+	*mock.Call
+}
+
+// GetByActorID is a helper method to define mock.On call
+//  - ctx context.Context
+//  - actorID string
+func (_e *WorkflowStepRepository_Expecter) GetByActorID(ctx interface{}, actorID interface{}) *WorkflowStepRepository_GetByActorID_Call {
+	return &WorkflowStepRepository_GetByActorID_Call{Call: _e.mock.On("GetByActorID", ctx, actorID)}
+}
+
+func (_c *WorkflowStepRepository_GetByActorID_Call) Run(run func(ctx context.Context, actorID string)) *WorkflowStepRepository_GetByActorID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *WorkflowStepRepository_GetByActorID_Call) Return(_a0 []*domain.WorkflowStep, _a1 error) *WorkflowStepRepository_GetByActorID_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
 // GetByID provides a mock function with given fields: ctx, id
 func (_m *WorkflowStepRepository) GetByID(ctx context.Context, id string) (*domain.WorkflowStep, error) {
 	ret := _m.Called(ctx, id)
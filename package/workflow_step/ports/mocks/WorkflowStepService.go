@@ -22,13 +22,13 @@ func (_m *WorkflowStepService) EXPECT() *WorkflowStepService_Expecter {
 	return &WorkflowStepService_Expecter{mock: &_m.Mock}
 }
 
-// CreateStep provides a mock function with given fields: ctx, workflowID, level, actorID, conditions
-func (_m *WorkflowStepService) CreateStep(ctx context.Context, workflowID string, level int, actorID string, conditions domain.StepConditions) (*domain.WorkflowStep, error) {
-	ret := _m.Called(ctx, workflowID, level, actorID, conditions)
+// CreateStep provides a mock function with given fields: ctx, workflowID, level, assignee, conditions
+func (_m *WorkflowStepService) CreateStep(ctx context.Context, workflowID string, level int, assignee domain.StepAssignee, conditions domain.StepConditions) (*domain.WorkflowStep, error) {
+	ret := _m.Called(ctx, workflowID, level, assignee, conditions)
 
 	var r0 *domain.WorkflowStep
-	if rf, ok := ret.Get(0).(func(context.Context, string, int, string, domain.StepConditions) *domain.WorkflowStep); ok {
-		r0 = rf(ctx, workflowID, level, actorID, conditions)
+	if rf, ok := ret.Get(0).(func(context.Context, string, int, domain.StepAssignee, domain.StepConditions) *domain.WorkflowStep); ok {
+		r0 = rf(ctx, workflowID, level, assignee, conditions)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*domain.WorkflowStep)
@@ -36,8 +36,8 @@ func (_m *WorkflowStepService) CreateStep(ctx context.Context, workflowID string
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(context.Context, string, int, string, domain.StepConditions) error); ok {
-		r1 = rf(ctx, workflowID, level, actorID, conditions)
+	if rf, ok := ret.Get(1).(func(context.Context, string, int, domain.StepAssignee, domain.StepConditions) error); ok {
+		r1 = rf(ctx, workflowID, level, assignee, conditions)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -54,15 +54,15 @@ type WorkflowStepService_CreateStep_Call struct {
 //  - ctx context.Context
 //  - workflowID string
 //  - level int
-//  - actorID string
+//  - assignee domain.StepAssignee
 //  - conditions domain.StepConditions
-func (_e *WorkflowStepService_Expecter) CreateStep(ctx interface{}, workflowID interface{}, level interface{}, actorID interface{}, conditions interface{}) *WorkflowStepService_CreateStep_Call {
-	return &WorkflowStepService_CreateStep_Call{Call: _e.mock.On("CreateStep", ctx, workflowID, level, actorID, conditions)}
+func (_e *WorkflowStepService_Expecter) CreateStep(ctx interface{}, workflowID interface{}, level interface{}, assignee interface{}, conditions interface{}) *WorkflowStepService_CreateStep_Call {
+	return &WorkflowStepService_CreateStep_Call{Call: _e.mock.On("CreateStep", ctx, workflowID, level, assignee, conditions)}
 }
 
-func (_c *WorkflowStepService_CreateStep_Call) Run(run func(ctx context.Context, workflowID string, level int, actorID string, conditions domain.StepConditions)) *WorkflowStepService_CreateStep_Call {
+func (_c *WorkflowStepService_CreateStep_Call) Run(run func(ctx context.Context, workflowID string, level int, assignee domain.StepAssignee, conditions domain.StepConditions)) *WorkflowStepService_CreateStep_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(string), args[2].(int), args[3].(string), args[4].(domain.StepConditions))
+		run(args[0].(context.Context), args[1].(string), args[2].(int), args[3].(domain.StepAssignee), args[4].(domain.StepConditions))
 	})
 	return _c
 }
@@ -204,13 +204,13 @@ func (_c *WorkflowStepService_GetSteps_Call) Return(_a0 []*domain.WorkflowStep,
 	return _c
 }
 
-// UpdateStep provides a mock function with given fields: ctx, id, level, actorID, conditions
-func (_m *WorkflowStepService) UpdateStep(ctx context.Context, id string, level int, actorID string, conditions domain.StepConditions) (*domain.WorkflowStep, error) {
-	ret := _m.Called(ctx, id, level, actorID, conditions)
+// UpdateStep provides a mock function with given fields: ctx, id, level, assignee, conditions
+func (_m *WorkflowStepService) UpdateStep(ctx context.Context, id string, level int, assignee domain.StepAssignee, conditions domain.StepConditions) (*domain.WorkflowStep, error) {
+	ret := _m.Called(ctx, id, level, assignee, conditions)
 
 	var r0 *domain.WorkflowStep
-	if rf, ok := ret.Get(0).(func(context.Context, string, int, string, domain.StepConditions) *domain.WorkflowStep); ok {
-		r0 = rf(ctx, id, level, actorID, conditions)
+	if rf, ok := ret.Get(0).(func(context.Context, string, int, domain.StepAssignee, domain.StepConditions) *domain.WorkflowStep); ok {
+		r0 = rf(ctx, id, level, assignee, conditions)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*domain.WorkflowStep)
@@ -218,8 +218,8 @@ func (_m *WorkflowStepService) UpdateStep(ctx context.Context, id string, level
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(context.Context, string, int, string, domain.StepConditions) error); ok {
-		r1 = rf(ctx, id, level, actorID, conditions)
+	if rf, ok := ret.Get(1).(func(context.Context, string, int, domain.StepAssignee, domain.StepConditions) error); ok {
+		r1 = rf(ctx, id, level, assignee, conditions)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -236,15 +236,15 @@ type WorkflowStepService_UpdateStep_Call struct {
 //  - ctx context.Context
 //  - id string
 //  - level int
-//  - actorID string
+//  - assignee domain.StepAssignee
 //  - conditions domain.StepConditions
-func (_e *WorkflowStepService_Expecter) UpdateStep(ctx interface{}, id interface{}, level interface{}, actorID interface{}, conditions interface{}) *WorkflowStepService_UpdateStep_Call {
-	return &WorkflowStepService_UpdateStep_Call{Call: _e.mock.On("UpdateStep", ctx, id, level, actorID, conditions)}
+func (_e *WorkflowStepService_Expecter) UpdateStep(ctx interface{}, id interface{}, level interface{}, assignee interface{}, conditions interface{}) *WorkflowStepService_UpdateStep_Call {
+	return &WorkflowStepService_UpdateStep_Call{Call: _e.mock.On("UpdateStep", ctx, id, level, assignee, conditions)}
 }
 
-func (_c *WorkflowStepService_UpdateStep_Call) Run(run func(ctx context.Context, id string, level int, actorID string, conditions domain.StepConditions)) *WorkflowStepService_UpdateStep_Call {
+func (_c *WorkflowStepService_UpdateStep_Call) Run(run func(ctx context.Context, id string, level int, assignee domain.StepAssignee, conditions domain.StepConditions)) *WorkflowStepService_UpdateStep_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(string), args[2].(int), args[3].(string), args[4].(domain.StepConditions))
+		run(args[0].(context.Context), args[1].(string), args[2].(int), args[3].(domain.StepAssignee), args[4].(domain.StepConditions))
 	})
 	return _c
 }
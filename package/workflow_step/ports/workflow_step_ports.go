@@ -16,15 +16,16 @@ type WorkflowStepRepository interface {
 	Delete(ctx context.Context, id string) error
 	GetByWorkflowID(ctx context.Context, workflowID string) ([]*stepDomain.WorkflowStep, error)
 	GetByWorkflowAndLevel(ctx context.Context, workflowID string, level int) (*stepDomain.WorkflowStep, error)
+	GetByActorID(ctx context.Context, actorID string) ([]*stepDomain.WorkflowStep, error)
 }
 
 // WorkflowStepService defines the interface for workflow step business logic
 //
 //go:generate mockery --with-expecter --name=WorkflowStepService --output=mocks --filename=WorkflowStepService.go
 type WorkflowStepService interface {
-	CreateStep(ctx context.Context, workflowID string, level int, actorID string, conditions stepDomain.StepConditions) (*stepDomain.WorkflowStep, error)
+	CreateStep(ctx context.Context, workflowID string, level int, assignee stepDomain.StepAssignee, conditions stepDomain.StepConditions) (*stepDomain.WorkflowStep, error)
 	GetSteps(ctx context.Context, workflowID string) ([]*stepDomain.WorkflowStep, error)
 	GetStepByID(ctx context.Context, id string) (*stepDomain.WorkflowStep, error)
-	UpdateStep(ctx context.Context, id string, level int, actorID string, conditions stepDomain.StepConditions) (*stepDomain.WorkflowStep, error)
+	UpdateStep(ctx context.Context, id string, level int, assignee stepDomain.StepAssignee, conditions stepDomain.StepConditions) (*stepDomain.WorkflowStep, error)
 	DeleteStep(ctx context.Context, id string) error
 }
@@ -66,6 +66,16 @@ func (r *WorkflowStepRepositoryImpl) GetByWorkflowID(ctx context.Context, workfl
 	return steps, err
 }
 
+// GetByActorID retrieves all steps assigned to an actor
+func (r *WorkflowStepRepositoryImpl) GetByActorID(ctx context.Context, actorID string) ([]*domain.WorkflowStep, error) {
+	var steps []*domain.WorkflowStep
+	err := r.db.WithContext(ctx).
+		Where("actor_id = ?", actorID).
+		Order("workflow_id ASC, level ASC").
+		Find(&steps).Error
+	return steps, err
+}
+
 // GetByWorkflowAndLevel retrieves a step by workflow ID and level
 func (r *WorkflowStepRepositoryImpl) GetByWorkflowAndLevel(ctx context.Context, workflowID string, level int) (*domain.WorkflowStep, error) {
 	var step domain.WorkflowStep
@@ -3,9 +3,11 @@ package usecase
 import (
 	"context"
 	"errors"
+	"fmt"
 
 	actorPorts "workflow-approval/package/actor/ports"
 	actorRepo "workflow-approval/package/actor/repository"
+	workflowDomain "workflow-approval/package/workflow/domain"
 	workflowPorts "workflow-approval/package/workflow/ports"
 	workflowRepo "workflow-approval/package/workflow/repository"
 	stepDomain "workflow-approval/package/workflow_step/domain"
@@ -19,6 +21,7 @@ var (
 	ErrStepLevelExists   = errors.New("step level already exists for this workflow")
 	ErrActorNotFound     = errors.New("actor not found")
 	ErrWorkflowNotFound  = errors.New("workflow not found")
+	ErrUnknownFormField  = errors.New("field is not defined by the workflow form")
 )
 
 // WorkflowStepServiceImpl implements WorkflowStepService interface
@@ -38,32 +41,38 @@ func NewWorkflowStepService(StepRepo stepPorts.WorkflowStepRepository, ActorRepo
 }
 
 // CreateStep creates a new workflow step
-func (s *WorkflowStepServiceImpl) CreateStep(ctx context.Context, workflowID string, level int, actorID string, conditions stepDomain.StepConditions) (*stepDomain.WorkflowStep, error) {
+func (s *WorkflowStepServiceImpl) CreateStep(ctx context.Context, workflowID string, level int, assignee stepDomain.StepAssignee, conditions stepDomain.StepConditions) (*stepDomain.WorkflowStep, error) {
 	if workflowID == "" {
 		return nil, ErrWorkflowNotFound
 	}
 	if level < 1 {
 		return nil, ErrStepLevelRequired
 	}
-	if actorID == "" {
+	assignee = assignee.Normalize()
+	if err := assignee.Validate(); err != nil {
+		return nil, err
+	}
+	if assignee.Type == stepDomain.AssigneeActor && assignee.ActorID == "" {
 		return nil, ErrStepActorRequired
 	}
+	if err := conditions.Validate(); err != nil {
+		return nil, err
+	}
 
 	// Validate workflow exists in database
-	_, err := s.workflowRepo.GetByID(ctx, workflowID)
+	workflow, err := s.workflowRepo.GetByID(ctx, workflowID)
 	if err != nil {
 		if errors.Is(err, workflowRepo.ErrWorkflowNotFound) {
 			return nil, ErrWorkflowNotFound
 		}
 		return nil, err
 	}
+	if err := checkFormFields(workflow, assignee, conditions); err != nil {
+		return nil, err
+	}
 
 	// Validate actor exists in database
-	_, err = s.actorRepo.GetByID(ctx, actorID)
-	if err != nil {
-		if errors.Is(err, actorRepo.ErrActorNotFound) {
-			return nil, ErrActorNotFound
-		}
+	if err := s.validateActor(ctx, assignee.ActorID); err != nil {
 		return nil, err
 	}
 
@@ -76,7 +85,7 @@ func (s *WorkflowStepServiceImpl) CreateStep(ctx context.Context, workflowID str
 		return nil, err
 	}
 
-	step := stepDomain.NewWorkflowStep(workflowID, level, actorID, conditions)
+	step := stepDomain.NewWorkflowStep(workflowID, level, assignee, conditions)
 	if err := s.stepRepo.Create(ctx, step); err != nil {
 		return nil, err
 	}
@@ -108,20 +117,23 @@ func (s *WorkflowStepServiceImpl) GetStepByID(ctx context.Context, id string) (*
 }
 
 // UpdateStep updates a workflow step
-func (s *WorkflowStepServiceImpl) UpdateStep(ctx context.Context, id string, level int, actorID string, conditions stepDomain.StepConditions) (*stepDomain.WorkflowStep, error) {
+func (s *WorkflowStepServiceImpl) UpdateStep(ctx context.Context, id string, level int, assignee stepDomain.StepAssignee, conditions stepDomain.StepConditions) (*stepDomain.WorkflowStep, error) {
 	if level < 1 {
 		return nil, ErrStepLevelRequired
 	}
-	if actorID == "" {
+	assignee = assignee.Normalize()
+	if err := assignee.Validate(); err != nil {
+		return nil, err
+	}
+	if assignee.Type == stepDomain.AssigneeActor && assignee.ActorID == "" {
 		return nil, ErrStepActorRequired
 	}
+	if err := conditions.Validate(); err != nil {
+		return nil, err
+	}
 
 	// Validate actor exists in database
-	_, err := s.actorRepo.GetByID(ctx, actorID)
-	if err != nil {
-		if errors.Is(err, actorRepo.ErrActorNotFound) {
-			return nil, ErrActorNotFound
-		}
+	if err := s.validateActor(ctx, assignee.ActorID); err != nil {
 		return nil, err
 	}
 
@@ -131,16 +143,19 @@ func (s *WorkflowStepServiceImpl) UpdateStep(ctx context.Context, id string, lev
 	}
 
 	// Validate workflow exists in database
-	_, err = s.workflowRepo.GetByID(ctx, step.WorkflowID)
+	workflow, err := s.workflowRepo.GetByID(ctx, step.WorkflowID)
 	if err != nil {
 		if errors.Is(err, workflowRepo.ErrWorkflowNotFound) {
 			return nil, ErrWorkflowNotFound
 		}
 		return nil, err
 	}
+	if err := checkFormFields(workflow, assignee, conditions); err != nil {
+		return nil, err
+	}
 
 	step.Level = level
-	step.ActorID = actorID
+	step.SetAssignee(assignee)
 	step.Conditions = conditions
 
 	if err := s.stepRepo.Update(ctx, step); err != nil {
@@ -171,3 +186,34 @@ func (s *WorkflowStepServiceImpl) DeleteStep(ctx context.Context, id string) err
 
 	return s.stepRepo.Delete(ctx, id)
 }
+
+// checkFormFields makes sure the lookup field and field conditions of a step
+// refer to fields of the workflow's form; workflows without a form accept any field
+func checkFormFields(workflow *workflowDomain.Workflow, assignee stepDomain.StepAssignee, conditions stepDomain.StepConditions) error {
+	if workflow.FormSchema.IsEmpty() {
+		return nil
+	}
+	if assignee.LookupField != "" && workflow.FormSchema.Field(assignee.LookupField) == nil {
+		return fmt.Errorf("%w: %s", ErrUnknownFormField, assignee.LookupField)
+	}
+	for _, fc := range conditions.Fields {
+		if workflow.FormSchema.Field(fc.Field) == nil {
+			return fmt.Errorf("%w: %s", ErrUnknownFormField, fc.Field)
+		}
+	}
+	return nil
+}
+
+// validateActor checks that a step's actor exists; dynamic steps may have none
+func (s *WorkflowStepServiceImpl) validateActor(ctx context.Context, actorID string) error {
+	if actorID == "" {
+		return nil
+	}
+	if _, err := s.actorRepo.GetByID(ctx, actorID); err != nil {
+		if errors.Is(err, actorRepo.ErrActorNotFound) {
+			return ErrActorNotFound
+		}
+		return err
+	}
+	return nil
+}
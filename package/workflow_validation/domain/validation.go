@@ -0,0 +1,210 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	stepDomain "workflow-approval/package/workflow_step/domain"
+	"workflow-approval/utils/money"
+)
+
+// ErrWorkflowInvalid is returned when a request is created against a workflow
+// that fails validation; the error is an *InvalidError listing the issues
+var ErrWorkflowInvalid = errors.New("workflow is not valid")
+
+// Severity tells whether an issue blocks new requests
+type Severity string
+
+const (
+	SeverityError   Severity = "error"   // New requests are refused until it is fixed
+	SeverityWarning Severity = "warning" // Some requests may stall, but the workflow can be used
+)
+
+// IssueCode identifies a kind of validation issue
+type IssueCode string
+
+const (
+	IssueNoSteps                 IssueCode = "no_steps"
+	IssueLevelGap                IssueCode = "level_gap"               // Levels must run 1, 2, 3, ...
+	IssueUnreachableStep         IssueCode = "unreachable_step"        // No request can ever reach the step
+	IssueActorNotFound           IssueCode = "actor_not_found"         // The step's actor was deleted
+	IssueActorNoActiveMembers    IssueCode = "actor_no_active_members" // Nobody but an admin could approve the step
+	IssueFallbackActorNotFound   IssueCode = "fallback_actor_not_found"
+	IssueEmptyAmountRange        IssueCode = "empty_amount_range"        // max_amount is not above min_amount
+	IssueOverlappingAmountRanges IssueCode = "overlapping_amount_ranges" // Two steps' amount ranges share amounts
+	IssueIncompleteAmountRange   IssueCode = "incomplete_amount_range"   // Some amounts are in no step's range
+	IssueMinAmountStall          IssueCode = "min_amount_stall"          // Requests below a level's min_amount stall there
+)
+
+// Issue is one problem found in a workflow
+type Issue struct {
+	Code     IssueCode `json:"code"`
+	Severity Severity  `json:"severity"`
+	Level    int       `json:"level,omitempty"` // The step the issue is about, 0 for the whole workflow
+	Message  string    `json:"message"`
+}
+
+// Report is the result of validating a workflow
+type Report struct {
+	WorkflowID string  `json:"workflow_id"`
+	Valid      bool    `json:"valid"` // No issue is an error
+	Issues     []Issue `json:"issues"`
+}
+
+// Err returns an *InvalidError with the report's errors, nil for a valid workflow
+func (r *Report) Err() error {
+	if r.Valid {
+		return nil
+	}
+	var issues []Issue
+	for _, issue := range r.Issues {
+		if issue.Severity == SeverityError {
+			issues = append(issues, issue)
+		}
+	}
+	return &InvalidError{Issues: issues}
+}
+
+// InvalidError lists the errors that keep a workflow from taking requests
+type InvalidError struct {
+	Issues []Issue
+}
+
+func (e *InvalidError) Error() string {
+	if len(e.Issues) == 0 {
+		return ErrWorkflowInvalid.Error()
+	}
+	msg := fmt.Sprintf("%v: %s", ErrWorkflowInvalid, e.Issues[0].Message)
+	if len(e.Issues) > 1 {
+		msg += fmt.Sprintf(" (and %d more)", len(e.Issues)-1)
+	}
+	return msg
+}
+
+func (e *InvalidError) Is(target error) bool {
+	return target == ErrWorkflowInvalid
+}
+
+// ActorStatus is what validation needs to know about the actor of a step
+type ActorStatus struct {
+	Exists        bool
+	ActiveMembers int64
+}
+
+// Validate checks that requests can be routed through the steps of a
+// workflow. Levels are linked only by their order, as Approve moves a request
+// from a level to the next one, so:
+//   - levels must start at 1 without gaps, as requests are approved after the
+//     last level before a gap and never reach the steps after it;
+//   - actor steps need an existing actor with active members;
+//   - the amount ranges of the steps that set one, from min_amount up to but
+//     excluding max_amount (0 for no upper limit), must not overlap and must
+//     together cover every amount, e.g. 0-1000 and 1000 and up.
+//
+// Approving a level only checks its min_amount, so requests below it stall
+// there; this is reported as a warning.
+func Validate(workflowID string, steps []*stepDomain.WorkflowStep, actors map[string]ActorStatus) *Report {
+	report := &Report{WorkflowID: workflowID, Issues: []Issue{}}
+	add := func(code IssueCode, severity Severity, level int, format string, args ...interface{}) {
+		report.Issues = append(report.Issues, Issue{Code: code, Severity: severity, Level: level, Message: fmt.Sprintf(format, args...)})
+	}
+
+	if len(steps) == 0 {
+		add(IssueNoSteps, SeverityError, 0, "the workflow has no steps")
+		report.Valid = false
+		return report
+	}
+	sorted := append([]*stepDomain.WorkflowStep(nil), steps...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Level < sorted[j].Level })
+
+	// Levels after a gap are never reached
+	reachable := len(sorted)
+	for i, step := range sorted {
+		if step.Level == i+1 {
+			continue
+		}
+		reachable = i
+		var after []string
+		for _, s := range sorted[i:] {
+			after = append(after, fmt.Sprint(s.Level))
+		}
+		if i == 0 {
+			add(IssueLevelGap, SeverityError, 0, "the workflow has no level 1, so nobody can approve its requests")
+		} else {
+			add(IssueLevelGap, SeverityError, 0, "the workflow has no level %d, so requests are approved after level %d and never reach level %s", i+1, i, strings.Join(after, ", "))
+		}
+		for _, s := range sorted[i:] {
+			add(IssueUnreachableStep, SeverityError, s.Level, "level %d comes after a missing level", s.Level)
+		}
+		break
+	}
+
+	var ranged []*stepDomain.WorkflowStep
+	for _, step := range sorted {
+		assignee := step.Assignee()
+		if assignee.ActorID != "" {
+			status := actors[assignee.ActorID]
+			switch {
+			case assignee.Type.IsDynamic() && !status.Exists:
+				add(IssueFallbackActorNotFound, SeverityWarning, step.Level, "the fallback actor of level %d no longer exists", step.Level)
+			case !assignee.Type.IsDynamic() && !status.Exists:
+				add(IssueActorNotFound, SeverityError, step.Level, "the actor of level %d no longer exists", step.Level)
+			case !assignee.Type.IsDynamic() && status.ActiveMembers == 0:
+				add(IssueActorNoActiveMembers, SeverityError, step.Level, "the actor of level %d has no active members", step.Level)
+			}
+		}
+
+		c := step.Conditions
+		switch {
+		case c.MinAmount.IsZero() && c.MaxAmount.IsZero():
+			// Applies to every amount
+		case !c.MaxAmount.IsZero() && c.MaxAmount.Cmp(c.MinAmount) <= 0:
+			add(IssueEmptyAmountRange, SeverityError, step.Level, "max_amount %s of level %d is not above its min_amount %s", c.MaxAmount, step.Level, c.MinAmount)
+		default:
+			ranged = append(ranged, step)
+		}
+	}
+
+	// Walk the ranges from the lowest amount up; covered is where the ranges
+	// seen so far end, unbounded once one has no max_amount
+	sort.SliceStable(ranged, func(i, j int) bool { return ranged[i].Conditions.MinAmount.Cmp(ranged[j].Conditions.MinAmount) < 0 })
+	var covered money.Decimal
+	var coveredBy *stepDomain.WorkflowStep
+	unbounded := false
+	for _, step := range ranged {
+		c := step.Conditions
+		switch {
+		case coveredBy != nil && (unbounded || c.MinAmount.Cmp(covered) < 0):
+			add(IssueOverlappingAmountRanges, SeverityError, step.Level, "the amount range of level %d overlaps that of level %d from %s", step.Level, coveredBy.Level, c.MinAmount)
+		case c.MinAmount.Cmp(covered) > 0:
+			add(IssueIncompleteAmountRange, SeverityError, step.Level, "amounts from %s up to %s are in no step's range", covered, c.MinAmount)
+		}
+		if unbounded {
+			continue
+		}
+		if c.MaxAmount.IsZero() {
+			unbounded, coveredBy = true, step
+		} else if c.MaxAmount.Cmp(covered) > 0 {
+			covered, coveredBy = c.MaxAmount, step
+		}
+	}
+	if coveredBy != nil && !unbounded {
+		add(IssueIncompleteAmountRange, SeverityError, coveredBy.Level, "amounts from %s up are in no step's range", covered)
+	}
+
+	for _, step := range sorted[:reachable] {
+		if min := step.Conditions.MinAmount; !min.IsZero() {
+			add(IssueMinAmountStall, SeverityWarning, step.Level, "requests below %s can be created but stall at level %d", min, step.Level)
+		}
+	}
+
+	report.Valid = true
+	for _, issue := range report.Issues {
+		if issue.Severity == SeverityError {
+			report.Valid = false
+		}
+	}
+	return report
+}
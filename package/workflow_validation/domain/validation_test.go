@@ -0,0 +1,119 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	stepDomain "workflow-approval/package/workflow_step/domain"
+	"workflow-approval/utils/money"
+)
+
+func step(level int, actorID string, min, max int64) *stepDomain.WorkflowStep {
+	return &stepDomain.WorkflowStep{
+		Level:      level,
+		ActorID:    actorID,
+		Conditions: stepDomain.StepConditions{MinAmount: money.NewDecimal(min), MaxAmount: money.NewDecimal(max)},
+	}
+}
+
+func codes(report *Report) []string {
+	var out []string
+	for _, issue := range report.Issues {
+		out = append(out, fmt.Sprintf("%s@%d", issue.Code, issue.Level))
+	}
+	return out
+}
+
+func TestValidate(t *testing.T) {
+	active := map[string]ActorStatus{"mgr": {Exists: true, ActiveMembers: 2}, "fin": {Exists: true, ActiveMembers: 1}}
+
+	tests := []struct {
+		name   string
+		steps  []*stepDomain.WorkflowStep
+		actors map[string]ActorStatus
+		valid  bool
+		issues string
+	}{
+		{
+			name:   "contiguous levels with active actors",
+			steps:  []*stepDomain.WorkflowStep{step(2, "fin", 0, 0), step(1, "mgr", 0, 0)},
+			actors: active,
+			valid:  true,
+			issues: "[]",
+		},
+		{
+			name:   "no steps",
+			valid:  false,
+			issues: "[no_steps@0]",
+		},
+		{
+			name:   "gaps leave later levels unreachable",
+			steps:  []*stepDomain.WorkflowStep{step(1, "mgr", 0, 0), step(3, "fin", 0, 0), step(7, "fin", 0, 0)},
+			actors: active,
+			valid:  false,
+			issues: "[level_gap@0 unreachable_step@3 unreachable_step@7]",
+		},
+		{
+			name:   "deleted and unstaffed actors",
+			steps:  []*stepDomain.WorkflowStep{step(1, "gone", 0, 0), step(2, "empty", 0, 0)},
+			actors: map[string]ActorStatus{"empty": {Exists: true}},
+			valid:  false,
+			issues: "[actor_not_found@1 actor_no_active_members@2]",
+		},
+		{
+			name:   "amount bands covering every amount",
+			steps:  []*stepDomain.WorkflowStep{step(1, "mgr", 0, 1000), step(2, "fin", 1000, 0), step(3, "fin", 0, 0)},
+			actors: active,
+			valid:  true,
+			issues: "[min_amount_stall@2]",
+		},
+		{
+			name:   "overlapping amount ranges",
+			steps:  []*stepDomain.WorkflowStep{step(1, "mgr", 0, 5000), step(2, "fin", 1000, 0)},
+			actors: active,
+			valid:  false,
+			issues: "[overlapping_amount_ranges@2 min_amount_stall@2]",
+		},
+		{
+			name:   "open-ended range overlapping a later one",
+			steps:  []*stepDomain.WorkflowStep{step(1, "mgr", 1000, 0), step(2, "fin", 0, 1000), step(3, "fin", 5000, 0)},
+			actors: active,
+			valid:  false,
+			issues: "[overlapping_amount_ranges@3 min_amount_stall@1 min_amount_stall@3]",
+		},
+		{
+			name:   "amounts between and above the ranges",
+			steps:  []*stepDomain.WorkflowStep{step(1, "mgr", 0, 1000), step(2, "fin", 5000, 9000)},
+			actors: active,
+			valid:  false,
+			issues: "[incomplete_amount_range@2 incomplete_amount_range@2 min_amount_stall@2]",
+		},
+		{
+			name:   "amounts below the lowest range",
+			steps:  []*stepDomain.WorkflowStep{step(1, "mgr", 0, 0), step(2, "fin", 5000, 0)},
+			actors: active,
+			valid:  false,
+			issues: "[incomplete_amount_range@2 min_amount_stall@2]",
+		},
+		{
+			name:   "empty range on one step",
+			steps:  []*stepDomain.WorkflowStep{step(1, "mgr", 5000, 1000)},
+			actors: active,
+			valid:  false,
+			issues: "[empty_amount_range@1 min_amount_stall@1]",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			report := Validate("wf-1", tt.steps, tt.actors)
+			if report.Valid != tt.valid || fmt.Sprint(codes(report)) != tt.issues {
+				t.Errorf("Expected valid=%v %s, got valid=%v %v", tt.valid, tt.issues, report.Valid, codes(report))
+			}
+			if err := report.Err(); (err == nil) != tt.valid || (err != nil && !errors.Is(err, ErrWorkflowInvalid)) {
+				t.Errorf("Expected Err to match validity, got %v", err)
+			}
+		})
+	}
+}
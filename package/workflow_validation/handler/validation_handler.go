@@ -0,0 +1,53 @@
+package handler
+
+import (
+	"errors"
+
+	"github.com/gofiber/fiber/v2"
+
+	"workflow-approval/package/workflow_validation/ports"
+	"workflow-approval/package/workflow_validation/usecase"
+)
+
+// ValidationHandler handles HTTP requests for workflow validation
+type ValidationHandler struct {
+	validatorService ports.ValidatorService
+}
+
+// NewValidationHandler creates a new ValidationHandler instance
+func NewValidationHandler(validatorService ports.ValidatorService) *ValidationHandler {
+	return &ValidationHandler{
+		validatorService: validatorService,
+	}
+}
+
+// Routes defines all routes for workflow validation
+// Mounts routes under /api/workflows/:id/validation (admin only)
+func (h *ValidationHandler) Routes(group fiber.Router) {
+	// GET /api/workflows/:id/validation - List the issues keeping the workflow from taking requests
+	group.Get("", h.Validate)
+}
+
+// Validate reports whether a workflow can take requests and why not
+// GET /api/workflows/:id/validation
+func (h *ValidationHandler) Validate(c *fiber.Ctx) error {
+	report, err := h.validatorService.Validate(c.Context(), c.Params("id"))
+	if err != nil {
+		status := fiber.StatusInternalServerError
+		message := "Failed to validate workflow"
+		if errors.Is(err, usecase.ErrWorkflowNotFound) {
+			status, message = fiber.StatusNotFound, err.Error()
+		}
+		return c.Status(status).JSON(fiber.Map{
+			"success": false,
+			"data":    nil,
+			"error":   message,
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"success": true,
+		"data":    report,
+		"error":   nil,
+	})
+}
@@ -0,0 +1,36 @@
+package ports
+
+import (
+	"context"
+
+	actorDomain "workflow-approval/package/actor/domain"
+	wfDomain "workflow-approval/package/workflow/domain"
+	stepDomain "workflow-approval/package/workflow_step/domain"
+	"workflow-approval/package/workflow_validation/domain"
+)
+
+// WorkflowRepository defines the workflow lookup needed to validate a workflow
+type WorkflowRepository interface {
+	GetByID(ctx context.Context, id string) (*wfDomain.Workflow, error)
+}
+
+// WorkflowStepRepository defines the step lookup needed to validate a workflow
+type WorkflowStepRepository interface {
+	GetByWorkflowID(ctx context.Context, workflowID string) ([]*stepDomain.WorkflowStep, error)
+}
+
+// ActorRepository defines the actor lookup needed to check the actors of steps
+type ActorRepository interface {
+	GetByID(ctx context.Context, id string) (*actorDomain.Actor, error)
+}
+
+// UserRepository defines the member count needed to check that actors can approve
+type UserRepository interface {
+	CountActiveByActorID(ctx context.Context, actorID string) (int64, error)
+}
+
+// ValidatorService defines the interface for workflow validation
+type ValidatorService interface {
+	// Validate checks a workflow and its steps; a workflow that fails has a report with Valid false, not an error
+	Validate(ctx context.Context, workflowID string) (*domain.Report, error)
+}
@@ -0,0 +1,68 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+
+	actorRepo "workflow-approval/package/actor/repository"
+	wfRepo "workflow-approval/package/workflow/repository"
+	"workflow-approval/package/workflow_validation/domain"
+	"workflow-approval/package/workflow_validation/ports"
+)
+
+var ErrWorkflowNotFound = errors.New("workflow not found")
+
+// ValidatorServiceImpl implements ValidatorService interface
+type ValidatorServiceImpl struct {
+	workflowRepo ports.WorkflowRepository
+	stepRepo     ports.WorkflowStepRepository
+	actorRepo    ports.ActorRepository
+	userRepo     ports.UserRepository
+}
+
+// NewValidatorService creates a new ValidatorServiceImpl instance
+func NewValidatorService(workflowRepo ports.WorkflowRepository, stepRepo ports.WorkflowStepRepository, actorRepo ports.ActorRepository, userRepo ports.UserRepository) ports.ValidatorService {
+	return &ValidatorServiceImpl{
+		workflowRepo: workflowRepo,
+		stepRepo:     stepRepo,
+		actorRepo:    actorRepo,
+		userRepo:     userRepo,
+	}
+}
+
+// Validate loads a workflow's steps and the state of their actors and checks them
+func (s *ValidatorServiceImpl) Validate(ctx context.Context, workflowID string) (*domain.Report, error) {
+	if _, err := s.workflowRepo.GetByID(ctx, workflowID); err != nil {
+		if errors.Is(err, wfRepo.ErrWorkflowNotFound) {
+			return nil, ErrWorkflowNotFound
+		}
+		return nil, err
+	}
+
+	steps, err := s.stepRepo.GetByWorkflowID(ctx, workflowID)
+	if err != nil {
+		return nil, err
+	}
+
+	actors := make(map[string]domain.ActorStatus)
+	for _, step := range steps {
+		if _, seen := actors[step.ActorID]; seen || step.ActorID == "" {
+			continue
+		}
+		status := domain.ActorStatus{Exists: true}
+		if _, err := s.actorRepo.GetByID(ctx, step.ActorID); err != nil {
+			if !errors.Is(err, actorRepo.ErrActorNotFound) {
+				return nil, err
+			}
+			status.Exists = false
+		}
+		if status.Exists {
+			if status.ActiveMembers, err = s.userRepo.CountActiveByActorID(ctx, step.ActorID); err != nil {
+				return nil, err
+			}
+		}
+		actors[step.ActorID] = status
+	}
+
+	return domain.Validate(workflowID, steps, actors), nil
+}
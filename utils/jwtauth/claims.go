@@ -0,0 +1,50 @@
+// Package jwtauth issues and validates the application's access tokens.
+// It is the single place that defines the token claims and the validation
+// rules (signature, kid, expiry, issuer and audience); both the HTTP
+// middleware and jwthelper.JWTHelper delegate to it.
+package jwtauth
+
+import (
+	"github.com/golang-jwt/jwt/v4"
+
+	"workflow-approval/utils/tenancy"
+)
+
+// Claims represents the JWT claims structure
+type Claims struct {
+	UserID  string `json:"user_id"`
+	Email   string `json:"email"`
+	IsAdmin bool   `json:"is_admin"`
+	// TenantID is the tenant every API call of the token is scoped to; tokens
+	// issued before multi-tenancy have none and belong to the default tenant
+	TenantID   string `json:"tenant_id,omitempty"`
+	SuperAdmin bool   `json:"super_admin,omitempty"`
+	ActorID    string `json:"actor_id"` // First of ActorIDs, kept for tokens read by older clients
+	// ActorIDs lists every actor the user is a member of, primary memberships first
+	ActorIDs []string `json:"actor_ids,omitempty"`
+	// MFAAt is when the user last passed a second factor (TOTP); absent for password-only sessions
+	MFAAt *jwt.NumericDate `json:"mfa_at,omitempty"`
+	// PasswordChangeRequired restricts the token to changing the password (admin-created accounts)
+	PasswordChangeRequired bool `json:"pwd_change,omitempty"`
+	jwt.RegisteredClaims
+}
+
+// Actors returns the actors of the token's user; tokens issued before actor
+// memberships only carry actor_id
+func (c *Claims) Actors() []string {
+	if len(c.ActorIDs) > 0 {
+		return c.ActorIDs
+	}
+	if c.ActorID != "" {
+		return []string{c.ActorID}
+	}
+	return nil
+}
+
+// Tenant returns the tenant of the token's user
+func (c *Claims) Tenant() string {
+	if c.TenantID != "" {
+		return c.TenantID
+	}
+	return tenancy.DefaultTenantID
+}
@@ -0,0 +1,222 @@
+package jwtauth
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+)
+
+// Supported signing algorithms
+const (
+	AlgHS256 = "HS256"
+	AlgRS256 = "RS256"
+	AlgEdDSA = "EdDSA"
+)
+
+// hmacKeyID identifies the shared secret when HS256 is used
+const hmacKeyID = "hs256"
+
+// rsaKeyBits is the modulus size of generated RSA keys
+const rsaKeyBits = 2048
+
+var ErrUnsupportedAlgorithm = errors.New("unsupported jwt signing algorithm")
+
+// signingKey is a key that can sign and/or verify tokens
+type signingKey struct {
+	id        string
+	algorithm string
+	method    jwt.SigningMethod
+	private   interface{} // []byte for HS256, crypto.Signer otherwise
+	public    interface{} // []byte for HS256, crypto.PublicKey otherwise
+	createdAt time.Time
+}
+
+// newHMACKey wraps the configured shared secret
+func newHMACKey(secret string) *signingKey {
+	return &signingKey{
+		id:        hmacKeyID,
+		algorithm: AlgHS256,
+		method:    jwt.SigningMethodHS256,
+		private:   []byte(secret),
+		public:    []byte(secret),
+	}
+}
+
+// generateKey creates a new asymmetric key for the algorithm
+func generateKey(algorithm string, now time.Time) (*signingKey, error) {
+	var private crypto.Signer
+	switch algorithm {
+	case AlgRS256:
+		key, err := rsa.GenerateKey(rand.Reader, rsaKeyBits)
+		if err != nil {
+			return nil, err
+		}
+		private = key
+	case AlgEdDSA:
+		_, key, err := ed25519.GenerateKey(rand.Reader)
+		if err != nil {
+			return nil, err
+		}
+		private = key
+	default:
+		return nil, ErrUnsupportedAlgorithm
+	}
+
+	suffix := make([]byte, 4)
+	if _, err := rand.Read(suffix); err != nil {
+		return nil, err
+	}
+	kid := now.UTC().Format("20060102T150405Z") + "-" + hex.EncodeToString(suffix)
+
+	return newAsymmetricKey(kid, private, now)
+}
+
+// newAsymmetricKey derives algorithm and public key from a private key
+func newAsymmetricKey(kid string, private crypto.Signer, createdAt time.Time) (*signingKey, error) {
+	key := &signingKey{
+		id:        kid,
+		private:   private,
+		public:    private.Public(),
+		createdAt: createdAt,
+	}
+	switch private.(type) {
+	case *rsa.PrivateKey:
+		key.algorithm = AlgRS256
+		key.method = jwt.SigningMethodRS256
+	case ed25519.PrivateKey:
+		key.algorithm = AlgEdDSA
+		key.method = jwt.SigningMethodEdDSA
+	default:
+		return nil, ErrUnsupportedAlgorithm
+	}
+	return key, nil
+}
+
+// keyFilePath returns where a key is persisted inside dir
+func keyFilePath(dir, kid string) string {
+	return filepath.Join(dir, kid+".pem")
+}
+
+// saveKey writes a private key as PKCS#8 PEM readable only by the owner
+func saveKey(dir string, key *signingKey) error {
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return err
+	}
+	der, err := x509.MarshalPKCS8PrivateKey(key.private)
+	if err != nil {
+		return err
+	}
+	data := pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der})
+
+	// Write to a temp file first so other instances never read a partial key
+	path := keyFilePath(dir, key.id)
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o600); err != nil {
+		return err
+	}
+	if err := os.Chtimes(tmp, key.createdAt, key.createdAt); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
+
+// loadKeys reads all persisted keys from dir; the file modification time is the key's creation time
+func loadKeys(dir string) ([]*signingKey, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	var keys []*signingKey
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".pem") {
+			continue
+		}
+		path := filepath.Join(dir, entry.Name())
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		block, _ := pem.Decode(data)
+		if block == nil {
+			return nil, fmt.Errorf("invalid PEM in %s", path)
+		}
+		parsed, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("invalid private key in %s: %w", path, err)
+		}
+		signer, ok := parsed.(crypto.Signer)
+		if !ok {
+			return nil, fmt.Errorf("unsupported key type in %s", path)
+		}
+		info, err := entry.Info()
+		if err != nil {
+			return nil, err
+		}
+		key, err := newAsymmetricKey(strings.TrimSuffix(entry.Name(), ".pem"), signer, info.ModTime().UTC())
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+		keys = append(keys, key)
+	}
+	return keys, nil
+}
+
+// JSONWebKey is a public key in JWK format
+type JSONWebKey struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	Alg string `json:"alg"`
+	N   string `json:"n,omitempty"`
+	E   string `json:"e,omitempty"`
+	Crv string `json:"crv,omitempty"`
+	X   string `json:"x,omitempty"`
+}
+
+// JWKS is a JSON Web Key Set
+type JWKS struct {
+	Keys []JSONWebKey `json:"keys"`
+}
+
+// jwk returns the public JWK of an asymmetric key; HMAC keys are never published
+func (k *signingKey) jwk() (JSONWebKey, bool) {
+	switch public := k.public.(type) {
+	case *rsa.PublicKey:
+		return JSONWebKey{
+			Kty: "RSA",
+			Kid: k.id,
+			Use: "sig",
+			Alg: k.algorithm,
+			N:   base64.RawURLEncoding.EncodeToString(public.N.Bytes()),
+			E:   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(public.E)).Bytes()),
+		}, true
+	case ed25519.PublicKey:
+		return JSONWebKey{
+			Kty: "OKP",
+			Kid: k.id,
+			Use: "sig",
+			Alg: k.algorithm,
+			Crv: "Ed25519",
+			X:   base64.RawURLEncoding.EncodeToString(public),
+		}, true
+	}
+	return JSONWebKey{}, false
+}
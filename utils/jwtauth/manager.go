@@ -0,0 +1,349 @@
+package jwtauth
+
+import (
+	"errors"
+	"log"
+	"os"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+)
+
+var (
+	ErrSecretRequired    = errors.New("jwt secret is required for HS256")
+	ErrUnknownSigningKey = errors.New("token signed with unknown key")
+	ErrInvalidIssuer     = errors.New("token has an invalid issuer")
+	ErrInvalidAudience   = errors.New("token has an invalid audience")
+	ErrInvalidClaims     = errors.New("invalid token claims")
+)
+
+// reloadCooldown limits how often an unknown kid triggers a reload of KeysDir
+const reloadCooldown = 30 * time.Second
+
+// Config holds token issuing and validation settings
+type Config struct {
+	Algorithm        string        // HS256, RS256 or EdDSA
+	Secret           string        // Shared secret, HS256 only
+	Issuer           string        // "iss" of issued tokens; validated on every token
+	Audience         string        // "aud" of issued tokens; defaults to Issuer
+	Expiration       time.Duration // Token lifetime
+	KeysDir          string        // Where asymmetric keys are persisted; empty keeps them in memory
+	RotationInterval time.Duration // How often a new signing key is generated; 0 disables rotation
+}
+
+// Manager signs and validates tokens with a set of keys identified by kid.
+// New tokens are signed with the newest key; older keys keep verifying tokens
+// until every token they signed has expired, then they are pruned.
+type Manager struct {
+	cfg Config
+
+	mu         sync.RWMutex
+	keys       map[string]*signingKey
+	current    *signingKey
+	lastReload time.Time
+
+	stopOnce sync.Once
+	stop     chan struct{}
+}
+
+// NewManager creates a Manager, loading persisted keys or generating the first one
+func NewManager(cfg Config) (*Manager, error) {
+	if cfg.Algorithm == "" {
+		cfg.Algorithm = AlgHS256
+	}
+	if cfg.Audience == "" {
+		cfg.Audience = cfg.Issuer
+	}
+
+	m := &Manager{
+		cfg:  cfg,
+		keys: make(map[string]*signingKey),
+		stop: make(chan struct{}),
+	}
+
+	switch cfg.Algorithm {
+	case AlgHS256:
+		if cfg.Secret == "" {
+			return nil, ErrSecretRequired
+		}
+		key := newHMACKey(cfg.Secret)
+		m.keys[key.id] = key
+		m.current = key
+		return m, nil
+	case AlgRS256, AlgEdDSA:
+		if err := m.reload(); err != nil {
+			return nil, err
+		}
+		if m.current == nil {
+			if err := m.Rotate(); err != nil {
+				return nil, err
+			}
+		}
+		return m, nil
+	}
+	return nil, ErrUnsupportedAlgorithm
+}
+
+// Algorithm returns the algorithm used for new tokens
+func (m *Manager) Algorithm() string {
+	return m.cfg.Algorithm
+}
+
+// Expiration returns the lifetime of issued tokens
+func (m *Manager) Expiration() time.Duration {
+	return m.cfg.Expiration
+}
+
+// Sign issues a token for the claims, filling in iss, aud, iat and exp
+func (m *Manager) Sign(claims *Claims) (string, error) {
+	m.mu.RLock()
+	key := m.current
+	m.mu.RUnlock()
+
+	now := time.Now()
+	claims.Issuer = m.cfg.Issuer
+	claims.Audience = jwt.ClaimStrings{m.cfg.Audience}
+	claims.IssuedAt = jwt.NewNumericDate(now)
+	claims.ExpiresAt = jwt.NewNumericDate(now.Add(m.cfg.Expiration))
+
+	token := jwt.NewWithClaims(key.method, claims)
+	token.Header["kid"] = key.id
+	return token.SignedString(key.private)
+}
+
+// Validate parses a token and checks signature, kid, expiry, issuer and audience.
+// Failures are returned as *jwt.ValidationError so callers can inspect the cause.
+func (m *Manager) Validate(tokenString string) (*Claims, error) {
+	claims := &Claims{}
+	token, err := jwt.ParseWithClaims(tokenString, claims, m.keyFunc)
+	if err != nil {
+		return nil, err
+	}
+	if !token.Valid {
+		return nil, jwt.NewValidationError(ErrInvalidClaims.Error(), jwt.ValidationErrorClaimsInvalid)
+	}
+
+	if !claims.VerifyIssuer(m.cfg.Issuer, true) {
+		return nil, jwt.NewValidationError(ErrInvalidIssuer.Error(), jwt.ValidationErrorIssuer)
+	}
+	if !claims.VerifyAudience(m.cfg.Audience, true) {
+		return nil, jwt.NewValidationError(ErrInvalidAudience.Error(), jwt.ValidationErrorAudience)
+	}
+	if claims.ExpiresAt == nil {
+		return nil, jwt.NewValidationError("token has no expiry", jwt.ValidationErrorClaimsInvalid)
+	}
+	return claims, nil
+}
+
+// keyFunc resolves the verification key from the token's kid and checks
+// that the token's algorithm matches the key (prevents algorithm confusion)
+func (m *Manager) keyFunc(token *jwt.Token) (interface{}, error) {
+	kid, _ := token.Header["kid"].(string)
+
+	key := m.lookup(kid)
+	if key == nil && m.cfg.KeysDir != "" {
+		// Another instance may have rotated; pick up new keys from disk
+		if m.reloadIfStale() {
+			key = m.lookup(kid)
+		}
+	}
+	if key == nil {
+		return nil, ErrUnknownSigningKey
+	}
+	if token.Method.Alg() != key.method.Alg() {
+		return nil, jwt.ErrSignatureInvalid
+	}
+	return key.public, nil
+}
+
+// lookup finds a key by kid; tokens without kid are only accepted for HS256
+func (m *Manager) lookup(kid string) *signingKey {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	if kid == "" {
+		if m.current != nil && m.current.algorithm == AlgHS256 {
+			return m.current
+		}
+		return nil
+	}
+	return m.keys[kid]
+}
+
+// JWKS returns the public keys currently accepted for verification
+func (m *Manager) JWKS() JWKS {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	set := JWKS{Keys: []JSONWebKey{}}
+	for _, key := range m.sortedKeys() {
+		if jwk, ok := key.jwk(); ok {
+			set.Keys = append(set.Keys, jwk)
+		}
+	}
+	return set
+}
+
+// Rotate generates a new signing key, persists it and prunes expired keys
+func (m *Manager) Rotate() error {
+	if m.cfg.Algorithm == AlgHS256 {
+		return nil
+	}
+
+	key, err := generateKey(m.cfg.Algorithm, time.Now().UTC())
+	if err != nil {
+		return err
+	}
+	if m.cfg.KeysDir != "" {
+		if err := saveKey(m.cfg.KeysDir, key); err != nil {
+			return err
+		}
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.keys[key.id] = key
+	m.current = key
+	m.prune()
+	return nil
+}
+
+// StartRotation rotates the signing key every RotationInterval until Stop is called
+func (m *Manager) StartRotation() {
+	if m.cfg.Algorithm == AlgHS256 || m.cfg.RotationInterval <= 0 {
+		return
+	}
+
+	go func() {
+		// Check more often than the interval so a restart doesn't postpone rotation
+		ticker := time.NewTicker(checkInterval(m.cfg.RotationInterval))
+		defer ticker.Stop()
+		for {
+			select {
+			case <-m.stop:
+				return
+			case <-ticker.C:
+				if err := m.rotateIfDue(); err != nil {
+					log.Printf("Warning: jwt key rotation failed: %v", err)
+				}
+			}
+		}
+	}()
+}
+
+// Stop stops scheduled rotation
+func (m *Manager) Stop() {
+	m.stopOnce.Do(func() { close(m.stop) })
+}
+
+// rotateIfDue rotates when the newest key is older than RotationInterval
+func (m *Manager) rotateIfDue() error {
+	if m.cfg.KeysDir != "" {
+		if err := m.reload(); err != nil {
+			return err
+		}
+	}
+
+	m.mu.RLock()
+	due := m.current == nil || time.Since(m.current.createdAt) >= m.cfg.RotationInterval
+	m.mu.RUnlock()
+
+	if !due {
+		return nil
+	}
+	return m.Rotate()
+}
+
+// reloadIfStale reloads keys from KeysDir unless it was done recently
+func (m *Manager) reloadIfStale() bool {
+	m.mu.RLock()
+	stale := time.Since(m.lastReload) >= reloadCooldown
+	m.mu.RUnlock()
+	if !stale {
+		return false
+	}
+	if err := m.reload(); err != nil {
+		log.Printf("Warning: failed to reload jwt keys: %v", err)
+		return false
+	}
+	return true
+}
+
+// reload replaces the in-memory key set with the keys persisted in KeysDir
+func (m *Manager) reload() error {
+	if m.cfg.KeysDir == "" {
+		return nil
+	}
+	keys, err := loadKeys(m.cfg.KeysDir)
+	if err != nil {
+		return err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.lastReload = time.Now()
+	if len(keys) == 0 {
+		return nil
+	}
+
+	m.keys = make(map[string]*signingKey, len(keys))
+	for _, key := range keys {
+		m.keys[key.id] = key
+	}
+	// Sign with the newest key of the configured algorithm
+	m.current = nil
+	for _, key := range m.sortedKeys() {
+		if key.algorithm == m.cfg.Algorithm {
+			m.current = key
+		}
+	}
+	m.prune()
+	return nil
+}
+
+// prune drops keys whose successor has existed for longer than the token lifetime.
+// Callers must hold the write lock.
+func (m *Manager) prune() {
+	sorted := m.sortedKeys()
+	for i := 0; i < len(sorted)-1; i++ {
+		key, successor := sorted[i], sorted[i+1]
+		if key == m.current || time.Since(successor.createdAt) <= m.cfg.Expiration {
+			continue
+		}
+		delete(m.keys, key.id)
+		if m.cfg.KeysDir != "" {
+			if err := os.Remove(keyFilePath(m.cfg.KeysDir, key.id)); err != nil && !errors.Is(err, os.ErrNotExist) {
+				log.Printf("Warning: failed to remove retired jwt key %s: %v", key.id, err)
+			}
+		}
+	}
+}
+
+// sortedKeys returns keys ordered from oldest to newest. Callers must hold the lock.
+func (m *Manager) sortedKeys() []*signingKey {
+	keys := make([]*signingKey, 0, len(m.keys))
+	for _, key := range m.keys {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].createdAt.Equal(keys[j].createdAt) {
+			return keys[i].id < keys[j].id
+		}
+		return keys[i].createdAt.Before(keys[j].createdAt)
+	})
+	return keys
+}
+
+// checkInterval returns how often StartRotation checks whether rotation is due
+func checkInterval(rotation time.Duration) time.Duration {
+	interval := rotation / 24
+	if interval < time.Minute {
+		interval = time.Minute
+	}
+	if interval > time.Hour {
+		interval = time.Hour
+	}
+	return interval
+}
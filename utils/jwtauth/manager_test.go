@@ -0,0 +1,242 @@
+package jwtauth
+
+import (
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/json"
+	"errors"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+)
+
+func newTestManager(t *testing.T, algorithm, keysDir string) *Manager {
+	t.Helper()
+	m, err := NewManager(Config{
+		Algorithm:  algorithm,
+		Secret:     "test-secret",
+		Issuer:     "workflow-approval",
+		Audience:   "workflow-approval-api",
+		Expiration: time.Hour,
+		KeysDir:    keysDir,
+	})
+	if err != nil {
+		t.Fatalf("NewManager failed: %v", err)
+	}
+	return m
+}
+
+// backdate pretends the key with kid was created age ago
+func backdate(m *Manager, kid string, age time.Duration) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.keys[kid].createdAt = time.Now().Add(-age)
+}
+
+// signRaw signs claims with the manager's current key, bypassing Sign's defaults
+func signRaw(t *testing.T, m *Manager, method jwt.SigningMethod, claims jwt.Claims) string {
+	t.Helper()
+	token := jwt.NewWithClaims(method, claims)
+	token.Header["kid"] = m.current.id
+	signed, err := token.SignedString(m.current.private)
+	if err != nil {
+		t.Fatalf("Failed to sign token: %v", err)
+	}
+	return signed
+}
+
+func TestValidateAcrossRotation(t *testing.T) {
+	for _, algorithm := range []string{AlgRS256, AlgEdDSA} {
+		t.Run(algorithm, func(t *testing.T) {
+			dir := t.TempDir()
+			m := newTestManager(t, algorithm, dir)
+			first := m.current.id
+
+			token, err := m.Sign(&Claims{UserID: "user-1"})
+			if err != nil {
+				t.Fatalf("Sign failed: %v", err)
+			}
+
+			// A token signed before the rotation keeps validating
+			if err := m.Rotate(); err != nil {
+				t.Fatalf("Rotate failed: %v", err)
+			}
+			if m.current.id == first {
+				t.Fatal("Expected a new signing key after rotation")
+			}
+			claims, err := m.Validate(token)
+			if err != nil {
+				t.Fatalf("Expected the token signed before the rotation to validate, got %v", err)
+			}
+			if claims.UserID != "user-1" {
+				t.Errorf("Expected user-1, got %s", claims.UserID)
+			}
+
+			// Another instance sharing the directory picks up both keys
+			if _, err := newTestManager(t, algorithm, dir).Validate(token); err != nil {
+				t.Errorf("Expected a second instance to validate the token, got %v", err)
+			}
+
+			// Once its successor outlived the token lifetime the old key is pruned
+			backdate(m, first, 3*time.Hour)
+			backdate(m, m.current.id, 2*time.Hour)
+			if err := m.Rotate(); err != nil {
+				t.Fatalf("Rotate failed: %v", err)
+			}
+			if _, err := m.Validate(token); !errors.Is(err, ErrUnknownSigningKey) {
+				t.Errorf("Expected ErrUnknownSigningKey after the key was pruned, got %v", err)
+			}
+			if _, err := os.Stat(keyFilePath(dir, first)); !errors.Is(err, os.ErrNotExist) {
+				t.Errorf("Expected the pruned key file to be removed, got %v", err)
+			}
+		})
+	}
+}
+
+func TestValidateRejectsForeignTokens(t *testing.T) {
+	m := newTestManager(t, AlgRS256, "")
+	now := time.Now()
+	claims := func(issuer, audience string) *Claims {
+		return &Claims{
+			UserID: "user-1",
+			RegisteredClaims: jwt.RegisteredClaims{
+				Issuer:    issuer,
+				Audience:  jwt.ClaimStrings{audience},
+				IssuedAt:  jwt.NewNumericDate(now),
+				ExpiresAt: jwt.NewNumericDate(now.Add(time.Hour)),
+			},
+		}
+	}
+
+	if _, err := m.Validate(signRaw(t, m, jwt.SigningMethodRS256, claims("workflow-approval", "workflow-approval-api"))); err != nil {
+		t.Fatalf("Expected a well-formed token to validate, got %v", err)
+	}
+
+	// HS256 keyed with the public key, the classic algorithm confusion attack
+	publicDER, err := x509.MarshalPKIXPublicKey(m.current.public.(*rsa.PublicKey))
+	if err != nil {
+		t.Fatalf("Failed to marshal public key: %v", err)
+	}
+	confused := jwt.NewWithClaims(jwt.SigningMethodHS256, claims("workflow-approval", "workflow-approval-api"))
+	confused.Header["kid"] = m.current.id
+	confusedToken, err := confused.SignedString(publicDER)
+	if err != nil {
+		t.Fatalf("Failed to sign token: %v", err)
+	}
+
+	unsigned := jwt.NewWithClaims(jwt.SigningMethodNone, claims("workflow-approval", "workflow-approval-api"))
+	unsigned.Header["kid"] = m.current.id
+	noneToken, err := unsigned.SignedString(jwt.UnsafeAllowNoneSignatureType)
+	if err != nil {
+		t.Fatalf("Failed to sign token: %v", err)
+	}
+
+	expired := claims("workflow-approval", "workflow-approval-api")
+	expired.ExpiresAt = jwt.NewNumericDate(now.Add(-time.Minute))
+	noExpiry := claims("workflow-approval", "workflow-approval-api")
+	noExpiry.ExpiresAt = nil
+
+	tests := []struct {
+		name  string
+		token string
+		want  uint32
+	}{
+		{"Wrong issuer", signRaw(t, m, jwt.SigningMethodRS256, claims("someone-else", "workflow-approval-api")), jwt.ValidationErrorIssuer},
+		{"Wrong audience", signRaw(t, m, jwt.SigningMethodRS256, claims("workflow-approval", "another-api")), jwt.ValidationErrorAudience},
+		{"Expired", signRaw(t, m, jwt.SigningMethodRS256, expired), jwt.ValidationErrorExpired},
+		{"No expiry", signRaw(t, m, jwt.SigningMethodRS256, noExpiry), jwt.ValidationErrorClaimsInvalid},
+		// keyFunc refuses a token whose alg differs from its key's
+		{"Wrong algorithm", confusedToken, jwt.ValidationErrorUnverifiable},
+		{"alg none", noneToken, jwt.ValidationErrorUnverifiable},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := m.Validate(tt.token)
+			var validationErr *jwt.ValidationError
+			if !errors.As(err, &validationErr) {
+				t.Fatalf("Expected a *jwt.ValidationError, got %v", err)
+			}
+			if validationErr.Errors&tt.want == 0 {
+				t.Errorf("Expected validation error %d, got %d (%v)", tt.want, validationErr.Errors, err)
+			}
+			if tt.want == jwt.ValidationErrorUnverifiable && !errors.Is(err, jwt.ErrSignatureInvalid) {
+				t.Errorf("Expected the algorithm mismatch reported as an invalid signature, got %v", err)
+			}
+		})
+	}
+
+	t.Run("alg none without kid on HS256", func(t *testing.T) {
+		hs := newTestManager(t, AlgHS256, "")
+		unsigned := jwt.NewWithClaims(jwt.SigningMethodNone, claims("workflow-approval", "workflow-approval-api"))
+		token, err := unsigned.SignedString(jwt.UnsafeAllowNoneSignatureType)
+		if err != nil {
+			t.Fatalf("Failed to sign token: %v", err)
+		}
+		if _, err := hs.Validate(token); err == nil {
+			t.Error("Expected an unsigned token to be rejected")
+		}
+	})
+
+	t.Run("Unknown kid", func(t *testing.T) {
+		other := newTestManager(t, AlgRS256, "")
+		token, err := other.Sign(&Claims{UserID: "user-1"})
+		if err != nil {
+			t.Fatalf("Sign failed: %v", err)
+		}
+		if _, err := m.Validate(token); !errors.Is(err, ErrUnknownSigningKey) {
+			t.Errorf("Expected ErrUnknownSigningKey, got %v", err)
+		}
+	})
+}
+
+func TestJWKSPublishesOnlyPublicKeys(t *testing.T) {
+	// Members of a private JWK that must never be published
+	private := []string{"d", "p", "q", "dp", "dq", "qi", "k"}
+
+	for _, algorithm := range []string{AlgRS256, AlgEdDSA} {
+		t.Run(algorithm, func(t *testing.T) {
+			m := newTestManager(t, algorithm, "")
+			if err := m.Rotate(); err != nil {
+				t.Fatalf("Rotate failed: %v", err)
+			}
+
+			data, err := json.Marshal(m.JWKS())
+			if err != nil {
+				t.Fatalf("Failed to marshal JWKS: %v", err)
+			}
+			var set struct {
+				Keys []map[string]interface{} `json:"keys"`
+			}
+			if err := json.Unmarshal(data, &set); err != nil {
+				t.Fatalf("Failed to unmarshal JWKS: %v", err)
+			}
+			if len(set.Keys) != 2 {
+				t.Fatalf("Expected both keys published, got %d", len(set.Keys))
+			}
+			for _, key := range set.Keys {
+				for _, member := range private {
+					if _, ok := key[member]; ok {
+						t.Errorf("Expected no %q member in %s", member, data)
+					}
+				}
+				if key["use"] != "sig" || key["alg"] != algorithm || key["kid"] == "" {
+					t.Errorf("Expected a signing key for %s, got %v", algorithm, key)
+				}
+			}
+		})
+	}
+
+	t.Run(AlgHS256, func(t *testing.T) {
+		m := newTestManager(t, AlgHS256, "")
+		data, err := json.Marshal(m.JWKS())
+		if err != nil {
+			t.Fatalf("Failed to marshal JWKS: %v", err)
+		}
+		if string(data) != `{"keys":[]}` || strings.Contains(string(data), "test-secret") {
+			t.Errorf("Expected the shared secret never published, got %s", data)
+		}
+	})
+}
@@ -9,39 +9,27 @@ import (
 	"github.com/golang-jwt/jwt/v4"
 
 	"workflow-approval/package/user/domain"
+	"workflow-approval/utils/jwtauth"
 )
 
-// JWTClaims represents the JWT claims structure
-type JWTClaims struct {
-	UserID  string `json:"user_id"`
-	Email   string `json:"email"`
-	IsAdmin bool   `json:"is_admin"`
-	ActorID string `json:"actor_id"`
-	jwt.RegisteredClaims
-}
+// JWTClaims represents the JWT claims structure (shared with the JWT middleware)
+type JWTClaims = jwtauth.Claims
 
 // JWTHelper provides JWT utility functions
 type JWTHelper struct {
-	secret     string
-	expiration time.Duration
+	manager *jwtauth.Manager
 }
 
 // NewJWTHelper creates a new JWTHelper instance
-func NewJWTHelper(secret string, expiration time.Duration) *JWTHelper {
+func NewJWTHelper(manager *jwtauth.Manager) *JWTHelper {
 	return &JWTHelper{
-		secret:     secret,
-		expiration: expiration,
+		manager: manager,
 	}
 }
 
-// GetSecret returns the JWT secret
-func (h *JWTHelper) GetSecret() string {
-	return h.secret
-}
-
 // GetExpiration returns the JWT expiration duration
 func (h *JWTHelper) GetExpiration() time.Duration {
-	return h.expiration
+	return h.manager.Expiration()
 }
 
 // ParseBasicAuth parses the Authorization header for Basic authentication
@@ -81,65 +69,35 @@ func ParseBasicAuth(authHeader string) (string, string, error) {
 
 // GenerateJWT generates a JWT token for the user
 func (h *JWTHelper) GenerateJWT(user *domain.User) (string, error) {
-	actorID := ""
-	if user.ActorID != nil {
-		actorID = *user.ActorID
-	}
-
-	claims := jwt.MapClaims{
-		"user_id":  user.ID,
-		"email":    user.Email,
-		"is_admin": user.IsAdmin,
-		"actor_id": actorID,
-		"exp":      time.Now().Add(h.expiration).Unix(),
-		"iat":      time.Now().Unix(),
-		"iss":      "workflow-approval-system",
-	}
-
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	return token.SignedString([]byte(h.secret))
+	return h.manager.Sign(claimsFor(user))
 }
 
-// ValidateToken validates a JWT token and returns the claims
-func (h *JWTHelper) ValidateToken(tokenString string) (*JWTClaims, error) {
-	token, err := jwt.ParseWithClaims(tokenString, &JWTClaims{}, func(token *jwt.Token) (interface{}, error) {
-		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-			return nil, errors.New("unexpected signing method")
-		}
-		return []byte(h.secret), nil
-	})
-
-	if err != nil {
-		return nil, err
-	}
-
-	claims, ok := token.Claims.(*JWTClaims)
-	if !ok || !token.Valid {
-		return nil, errors.New("invalid token claims")
-	}
-
-	return claims, nil
+// GenerateMFAJWT generates a JWT token recording that the user passed a second factor at verifiedAt
+func (h *JWTHelper) GenerateMFAJWT(user *domain.User, verifiedAt time.Time) (string, error) {
+	claims := claimsFor(user)
+	claims.MFAAt = jwt.NewNumericDate(verifiedAt)
+	return h.manager.Sign(claims)
 }
 
-// ValidateTokenMapClaims validates a JWT token and returns map claims
-func (h *JWTHelper) ValidateTokenMapClaims(tokenString string) (map[string]interface{}, error) {
-	token, err := jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
-		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-			return nil, errors.New("unexpected signing method")
-		}
-		return []byte(h.secret), nil
-	})
+// claimsFor builds the identity claims of a user
+func claimsFor(user *domain.User) *JWTClaims {
+	return &JWTClaims{
+		UserID:   user.ID,
+		Email:    user.Email,
+		IsAdmin:  user.IsAdmin,
+		ActorID:  user.PrimaryActorID(),
+		ActorIDs: user.ActorIDs(),
 
-	if err != nil {
-		return nil, err
-	}
+		TenantID:   user.TenantID,
+		SuperAdmin: user.IsSuperAdmin,
 
-	claims, ok := token.Claims.(jwt.MapClaims)
-	if !ok || !token.Valid {
-		return nil, errors.New("invalid token claims")
+		PasswordChangeRequired: user.MustChangePassword,
 	}
+}
 
-	return claims, nil
+// ValidateToken validates a JWT token and returns the claims
+func (h *JWTHelper) ValidateToken(tokenString string) (*JWTClaims, error) {
+	return h.manager.Validate(tokenString)
 }
 
 // ExtractToken extracts the JWT token from the Authorization header
@@ -160,8 +118,8 @@ func ExtractToken(authHeader string) (string, error) {
 var globalJWTHelper *JWTHelper
 
 // InitJWTHelper initializes the global JWT helper
-func InitJWTHelper(secret string, expiration time.Duration) {
-	globalJWTHelper = NewJWTHelper(secret, expiration)
+func InitJWTHelper(manager *jwtauth.Manager) {
+	globalJWTHelper = NewJWTHelper(manager)
 }
 
 // GetJWTHelper returns the global JWT helper instance
@@ -0,0 +1,119 @@
+// Package mailer delivers transactional email such as password reset and
+// email verification links. The backend is pluggable: SMTP for real delivery,
+// or a log backend that prints messages for development and tests.
+package mailer
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net"
+	"net/smtp"
+	"strconv"
+	"strings"
+)
+
+// Drivers selectable in configuration
+const (
+	DriverLog  = "log"
+	DriverSMTP = "smtp"
+)
+
+// Message is a plain-text email
+type Message struct {
+	To      string
+	Subject string
+	Body    string
+}
+
+// Mailer sends email messages
+type Mailer interface {
+	Send(ctx context.Context, msg Message) error
+}
+
+// Config selects and configures a Mailer backend
+type Config struct {
+	Driver   string // "log" (default) or "smtp"
+	Host     string
+	Port     int
+	Username string // Optional, enables PLAIN auth
+	Password string
+	From     string
+}
+
+// New creates the Mailer selected by cfg.Driver
+func New(cfg Config) (Mailer, error) {
+	switch cfg.Driver {
+	case "", DriverLog:
+		return NewLogMailer(), nil
+	case DriverSMTP:
+		if cfg.Host == "" || cfg.From == "" {
+			return nil, fmt.Errorf("mailer: smtp driver requires host and from")
+		}
+		return NewSMTPMailer(cfg), nil
+	}
+	return nil, fmt.Errorf("mailer: unknown driver %q", cfg.Driver)
+}
+
+// LogMailer writes messages to the application log instead of sending them
+type LogMailer struct{}
+
+// NewLogMailer creates a new LogMailer instance
+func NewLogMailer() *LogMailer {
+	return &LogMailer{}
+}
+
+// Send logs the message
+func (m *LogMailer) Send(ctx context.Context, msg Message) error {
+	log.Printf("Mail to %s: %s\n%s", msg.To, msg.Subject, msg.Body)
+	return nil
+}
+
+// SMTPMailer sends messages through an SMTP server
+type SMTPMailer struct {
+	addr string
+	host string
+	auth smtp.Auth
+	from string
+}
+
+// NewSMTPMailer creates a new SMTPMailer instance
+func NewSMTPMailer(cfg Config) *SMTPMailer {
+	port := cfg.Port
+	if port == 0 {
+		port = 587
+	}
+
+	var auth smtp.Auth
+	if cfg.Username != "" {
+		auth = smtp.PlainAuth("", cfg.Username, cfg.Password, cfg.Host)
+	}
+
+	return &SMTPMailer{
+		addr: net.JoinHostPort(cfg.Host, strconv.Itoa(port)),
+		host: cfg.Host,
+		auth: auth,
+		from: cfg.From,
+	}
+}
+
+// Send delivers the message; STARTTLS is used when the server offers it
+func (m *SMTPMailer) Send(ctx context.Context, msg Message) error {
+	if strings.ContainsAny(msg.To, "\r\n") || strings.ContainsAny(msg.Subject, "\r\n") {
+		return fmt.Errorf("mailer: invalid header value")
+	}
+	return smtp.SendMail(m.addr, m.auth, m.from, []string{msg.To}, m.compose(msg))
+}
+
+// compose renders the RFC 5322 message
+func (m *SMTPMailer) compose(msg Message) []byte {
+	var b strings.Builder
+	b.WriteString("From: " + m.from + "\r\n")
+	b.WriteString("To: " + msg.To + "\r\n")
+	b.WriteString("Subject: " + msg.Subject + "\r\n")
+	b.WriteString("MIME-Version: 1.0\r\n")
+	b.WriteString("Content-Type: text/plain; charset=\"utf-8\"\r\n")
+	b.WriteString("\r\n")
+	b.WriteString(strings.ReplaceAll(msg.Body, "\n", "\r\n"))
+	return []byte(b.String())
+}
@@ -0,0 +1,32 @@
+package metrics
+
+import (
+	"context"
+	"database/sql"
+)
+
+// RegisterDBStats exposes the connection pool statistics of db, read on each
+// scrape. Totals kept by database/sql are reported as counters.
+func (r *Registry) RegisterDBStats(db *sql.DB) {
+	maxOpen := r.NewGaugeVec("db_pool_max_open_connections", "Maximum number of open connections to the database.")
+	open := r.NewGaugeVec("db_pool_open_connections", "Established connections, in use and idle.")
+	inUse := r.NewGaugeVec("db_pool_in_use_connections", "Connections currently in use.")
+	idle := r.NewGaugeVec("db_pool_idle_connections", "Idle connections.")
+	waitCount := r.NewCounterVec("db_pool_wait_count_total", "Connections waited for.")
+	waitDuration := r.NewCounterVec("db_pool_wait_duration_seconds_total", "Time blocked waiting for a new connection.")
+	closedIdle := r.NewCounterVec("db_pool_max_idle_closed_total", "Connections closed due to the idle connection limit.")
+	closedLifetime := r.NewCounterVec("db_pool_max_lifetime_closed_total", "Connections closed due to the connection lifetime limit.")
+
+	r.OnScrape(func(ctx context.Context) error {
+		stats := db.Stats()
+		maxOpen.With().Set(float64(stats.MaxOpenConnections))
+		open.With().Set(float64(stats.OpenConnections))
+		inUse.With().Set(float64(stats.InUse))
+		idle.With().Set(float64(stats.Idle))
+		waitCount.With().v.set(float64(stats.WaitCount))
+		waitDuration.With().v.set(stats.WaitDuration.Seconds())
+		closedIdle.With().v.set(float64(stats.MaxIdleClosed))
+		closedLifetime.With().v.set(float64(stats.MaxLifetimeClosed))
+		return nil
+	})
+}
@@ -0,0 +1,389 @@
+// Package metrics keeps counters, gauges and histograms in memory and writes
+// them in the Prometheus text exposition format (version 0.0.4), so /metrics
+// can be scraped without a client library. Metrics are registered once, at
+// package initialisation or startup, and are safe for concurrent use.
+package metrics
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// ContentType is the content type of the text exposition format
+const ContentType = "text/plain; version=0.0.4; charset=utf-8"
+
+// DurationBuckets suit request and query latencies, in seconds
+var DurationBuckets = []float64{0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10}
+
+// SizeBuckets suit HTTP body sizes, in bytes: 100B to 10MB
+var SizeBuckets = []float64{100, 1000, 10000, 100000, 1000000, 10000000}
+
+// Registry holds metric families and the hooks refreshing gauges before a scrape
+type Registry struct {
+	mu       sync.Mutex
+	families map[string]family
+	hooks    []func(ctx context.Context) error
+}
+
+// Default is the registry metrics are registered with unless given another
+var Default = NewRegistry()
+
+// NewRegistry creates an empty Registry
+func NewRegistry() *Registry {
+	return &Registry{families: make(map[string]family)}
+}
+
+// OnScrape adds a hook run before each scrape, e.g. to set gauges read from
+// the database. A failing hook is reported after the metrics are written.
+func (r *Registry) OnScrape(hook func(ctx context.Context) error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.hooks = append(r.hooks, hook)
+}
+
+// Write runs the scrape hooks and writes every family, sorted by name. The
+// metrics are written even when a hook fails; the first hook error is returned.
+func (r *Registry) Write(ctx context.Context, w io.Writer) error {
+	r.mu.Lock()
+	hooks := append([]func(context.Context) error(nil), r.hooks...)
+	r.mu.Unlock()
+
+	var hookErr error
+	for _, hook := range hooks {
+		if err := hook(ctx); err != nil && hookErr == nil {
+			hookErr = err
+		}
+	}
+
+	r.mu.Lock()
+	names := make([]string, 0, len(r.families))
+	for name := range r.families {
+		names = append(names, name)
+	}
+	families := make([]family, len(names))
+	sort.Strings(names)
+	for i, name := range names {
+		families[i] = r.families[name]
+	}
+	r.mu.Unlock()
+
+	bw := bufio.NewWriter(w)
+	for _, f := range families {
+		f.write(bw)
+	}
+	if err := bw.Flush(); err != nil {
+		return err
+	}
+	return hookErr
+}
+
+func (r *Registry) register(f family) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	name := f.metricName()
+	if _, ok := r.families[name]; ok {
+		panic("metrics: duplicate metric " + name)
+	}
+	r.families[name] = f
+}
+
+type family interface {
+	metricName() string
+	write(w *bufio.Writer)
+}
+
+// desc is what the families have in common: a name, help text and label names,
+// with one series per combination of label values
+type desc struct {
+	name   string
+	help   string
+	labels []string
+	kind   string
+
+	mu     sync.Mutex
+	series map[string]interface{}
+}
+
+func newDesc(name, help, kind string, labels []string) *desc {
+	return &desc{name: name, help: help, kind: kind, labels: labels, series: make(map[string]interface{})}
+}
+
+func (d *desc) metricName() string {
+	return d.name
+}
+
+// get returns the series of the label values, created by create on first use
+func (d *desc) get(values []string, create func() interface{}) interface{} {
+	if len(values) != len(d.labels) {
+		panic(fmt.Sprintf("metrics: %s has %d labels, got %d values", d.name, len(d.labels), len(values)))
+	}
+	key := strings.Join(values, "\xff")
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	s, ok := d.series[key]
+	if !ok {
+		s = create()
+		d.series[key] = s
+	}
+	return s
+}
+
+// each calls fn for every series in a stable order with its label values
+func (d *desc) each(fn func(values []string, series interface{})) {
+	d.mu.Lock()
+	keys := make([]string, 0, len(d.series))
+	for key := range d.series {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	series := make([]interface{}, len(keys))
+	for i, key := range keys {
+		series[i] = d.series[key]
+	}
+	d.mu.Unlock()
+
+	for i, key := range keys {
+		var values []string
+		if len(d.labels) > 0 {
+			values = strings.Split(key, "\xff")
+		}
+		fn(values, series[i])
+	}
+}
+
+func (d *desc) writeHeader(w *bufio.Writer) {
+	fmt.Fprintf(w, "# HELP %s %s\n# TYPE %s %s\n", d.name, escapeHelp(d.help), d.name, d.kind)
+}
+
+// value holds a float guarded by a mutex, shared by counters and gauges
+type value struct {
+	mu sync.Mutex
+	v  float64
+}
+
+func (v *value) add(delta float64) {
+	v.mu.Lock()
+	v.v += delta
+	v.mu.Unlock()
+}
+
+func (v *value) set(x float64) {
+	v.mu.Lock()
+	v.v = x
+	v.mu.Unlock()
+}
+
+func (v *value) get() float64 {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	return v.v
+}
+
+// Counter is a value that only goes up
+type Counter struct{ v value }
+
+// Inc adds 1
+func (c *Counter) Inc() { c.v.add(1) }
+
+// Add adds delta, which must not be negative
+func (c *Counter) Add(delta float64) {
+	if delta < 0 {
+		panic("metrics: counters cannot decrease")
+	}
+	c.v.add(delta)
+}
+
+// Value returns the current count
+func (c *Counter) Value() float64 { return c.v.get() }
+
+// CounterVec is a family of counters partitioned by labels
+type CounterVec struct{ *desc }
+
+// NewCounterVec registers a counter family with r
+func (r *Registry) NewCounterVec(name, help string, labels ...string) *CounterVec {
+	c := &CounterVec{newDesc(name, help, "counter", labels)}
+	r.register(c)
+	return c
+}
+
+// With returns the counter of the label values, in the order of the label names
+func (c *CounterVec) With(values ...string) *Counter {
+	return c.get(values, func() interface{} { return &Counter{} }).(*Counter)
+}
+
+func (c *CounterVec) write(w *bufio.Writer) {
+	c.writeHeader(w)
+	c.each(func(values []string, s interface{}) {
+		writeSample(w, c.name, c.labels, values, "", "", s.(*Counter).Value())
+	})
+}
+
+// Gauge is a value that can go up and down
+type Gauge struct{ v value }
+
+// Set sets the gauge to x
+func (g *Gauge) Set(x float64) { g.v.set(x) }
+
+// Add adds delta, which may be negative
+func (g *Gauge) Add(delta float64) { g.v.add(delta) }
+
+// Value returns the current value
+func (g *Gauge) Value() float64 { return g.v.get() }
+
+// GaugeVec is a family of gauges partitioned by labels
+type GaugeVec struct{ *desc }
+
+// NewGaugeVec registers a gauge family with r
+func (r *Registry) NewGaugeVec(name, help string, labels ...string) *GaugeVec {
+	g := &GaugeVec{newDesc(name, help, "gauge", labels)}
+	r.register(g)
+	return g
+}
+
+// With returns the gauge of the label values, in the order of the label names
+func (g *GaugeVec) With(values ...string) *Gauge {
+	return g.get(values, func() interface{} { return &Gauge{} }).(*Gauge)
+}
+
+// Reset removes every series, for gauges rebuilt from scratch on each scrape
+// so label values that disappeared aren't reported any more
+func (g *GaugeVec) Reset() {
+	g.mu.Lock()
+	g.series = make(map[string]interface{})
+	g.mu.Unlock()
+}
+
+func (g *GaugeVec) write(w *bufio.Writer) {
+	g.writeHeader(w)
+	g.each(func(values []string, s interface{}) {
+		writeSample(w, g.name, g.labels, values, "", "", s.(*Gauge).Value())
+	})
+}
+
+// Histogram counts observations in cumulative buckets
+type Histogram struct {
+	mu      sync.Mutex
+	bounds  []float64
+	buckets []uint64 // Observations at or below each bound, not cumulative
+	count   uint64
+	sum     float64
+}
+
+// Observe records one observation
+func (h *Histogram) Observe(x float64) {
+	i := sort.SearchFloat64s(h.bounds, x)
+	h.mu.Lock()
+	if i < len(h.buckets) {
+		h.buckets[i]++
+	}
+	h.count++
+	h.sum += x
+	h.mu.Unlock()
+}
+
+// Count returns the number of observations
+func (h *Histogram) Count() uint64 {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.count
+}
+
+// HistogramVec is a family of histograms partitioned by labels
+type HistogramVec struct {
+	*desc
+	bounds []float64
+}
+
+// NewHistogramVec registers a histogram family with r; bounds are the upper
+// bounds of the buckets in ascending order, +Inf is added
+func (r *Registry) NewHistogramVec(name, help string, bounds []float64, labels ...string) *HistogramVec {
+	if !sort.Float64sAreSorted(bounds) {
+		panic("metrics: histogram bounds of " + name + " are not sorted")
+	}
+	h := &HistogramVec{desc: newDesc(name, help, "histogram", labels), bounds: bounds}
+	r.register(h)
+	return h
+}
+
+// With returns the histogram of the label values, in the order of the label names
+func (h *HistogramVec) With(values ...string) *Histogram {
+	return h.get(values, func() interface{} {
+		return &Histogram{bounds: h.bounds, buckets: make([]uint64, len(h.bounds))}
+	}).(*Histogram)
+}
+
+func (h *HistogramVec) write(w *bufio.Writer) {
+	h.writeHeader(w)
+	h.each(func(values []string, s interface{}) {
+		hist := s.(*Histogram)
+		hist.mu.Lock()
+		buckets := append([]uint64(nil), hist.buckets...)
+		count, sum := hist.count, hist.sum
+		hist.mu.Unlock()
+
+		var cumulative uint64
+		for i, bound := range h.bounds {
+			cumulative += buckets[i]
+			writeSample(w, h.name+"_bucket", h.labels, values, "le", formatFloat(bound), float64(cumulative))
+		}
+		writeSample(w, h.name+"_bucket", h.labels, values, "le", "+Inf", float64(count))
+		writeSample(w, h.name+"_sum", h.labels, values, "", "", sum)
+		writeSample(w, h.name+"_count", h.labels, values, "", "", float64(count))
+	})
+}
+
+// writeSample writes one line; extraName/extraValue add the le label of histogram buckets
+func writeSample(w *bufio.Writer, name string, labels, values []string, extraName, extraValue string, v float64) {
+	w.WriteString(name)
+	if len(labels) > 0 || extraName != "" {
+		w.WriteByte('{')
+		for i, label := range labels {
+			if i > 0 {
+				w.WriteByte(',')
+			}
+			fmt.Fprintf(w, `%s="%s"`, label, escapeLabel(values[i]))
+		}
+		if extraName != "" {
+			if len(labels) > 0 {
+				w.WriteByte(',')
+			}
+			fmt.Fprintf(w, `%s="%s"`, extraName, extraValue)
+		}
+		w.WriteByte('}')
+	}
+	w.WriteByte(' ')
+	w.WriteString(formatFloat(v))
+	w.WriteByte('\n')
+}
+
+func formatFloat(v float64) string {
+	switch {
+	case math.IsInf(v, 1):
+		return "+Inf"
+	case math.IsInf(v, -1):
+		return "-Inf"
+	case math.IsNaN(v):
+		return "NaN"
+	}
+	return strconv.FormatFloat(v, 'g', -1, 64)
+}
+
+var (
+	helpEscaper  = strings.NewReplacer(`\`, `\\`, "\n", `\n`)
+	labelEscaper = strings.NewReplacer(`\`, `\\`, "\n", `\n`, `"`, `\"`)
+)
+
+func escapeHelp(s string) string {
+	return helpEscaper.Replace(s)
+}
+
+func escapeLabel(s string) string {
+	return labelEscaper.Replace(s)
+}
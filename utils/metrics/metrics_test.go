@@ -0,0 +1,85 @@
+package metrics
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestRegistryWritesTextFormat(t *testing.T) {
+	r := NewRegistry()
+	created := r.NewCounterVec("requests_created_total", "Requests created.", "workflow_id")
+	pending := r.NewGaugeVec("requests_pending", "Pending requests.")
+	latency := r.NewHistogramVec("decision_seconds", "Decision latency.", []float64{1, 5}, "workflow_id")
+
+	created.With("wf-1").Inc()
+	created.With("wf-1").Add(2)
+	created.With(`we"ird`).Inc()
+	pending.With().Set(4)
+	latency.With("wf-1").Observe(0.5)
+	latency.With("wf-1").Observe(3)
+	latency.With("wf-1").Observe(10)
+
+	var buf bytes.Buffer
+	if err := r.Write(context.Background(), &buf); err != nil {
+		t.Fatalf("Write failed: %v", err)
+	}
+
+	want := `# HELP decision_seconds Decision latency.
+# TYPE decision_seconds histogram
+decision_seconds_bucket{workflow_id="wf-1",le="1"} 1
+decision_seconds_bucket{workflow_id="wf-1",le="5"} 2
+decision_seconds_bucket{workflow_id="wf-1",le="+Inf"} 3
+decision_seconds_sum{workflow_id="wf-1"} 13.5
+decision_seconds_count{workflow_id="wf-1"} 3
+# HELP requests_created_total Requests created.
+# TYPE requests_created_total counter
+requests_created_total{workflow_id="we\"ird"} 1
+requests_created_total{workflow_id="wf-1"} 3
+# HELP requests_pending Pending requests.
+# TYPE requests_pending gauge
+requests_pending 4
+`
+	if buf.String() != want {
+		t.Errorf("Unexpected output:\n%s\nwant:\n%s", buf.String(), want)
+	}
+}
+
+func TestRegistryRunsScrapeHooks(t *testing.T) {
+	r := NewRegistry()
+	pending := r.NewGaugeVec("pending", "Pending.", "workflow_id")
+	pending.With("gone").Set(1)
+
+	hookErr := errors.New("db down")
+	r.OnScrape(func(ctx context.Context) error {
+		pending.Reset()
+		pending.With("wf-1").Set(2)
+		return nil
+	})
+	r.OnScrape(func(ctx context.Context) error { return hookErr })
+
+	var buf bytes.Buffer
+	if err := r.Write(context.Background(), &buf); !errors.Is(err, hookErr) {
+		t.Fatalf("Expected the hook error, got %v", err)
+	}
+	out := buf.String()
+	if strings.Contains(out, "gone") {
+		t.Errorf("Expected reset series to be dropped, got:\n%s", out)
+	}
+	if !strings.Contains(out, `pending{workflow_id="wf-1"} 2`) {
+		t.Errorf("Expected the hook's gauge to be written, got:\n%s", out)
+	}
+}
+
+func TestRegistryRefusesDuplicates(t *testing.T) {
+	r := NewRegistry()
+	r.NewCounterVec("dup_total", "Dup.")
+	defer func() {
+		if recover() == nil {
+			t.Error("Expected a duplicate metric to panic")
+		}
+	}()
+	r.NewGaugeVec("dup_total", "Dup.")
+}
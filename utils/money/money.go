@@ -0,0 +1,306 @@
+// Package money represents monetary amounts as fixed-point decimals, so
+// comparisons against limits such as 10000.00 are exact, together with
+// ISO-4217 currencies and the exchange rates used to convert between them.
+package money
+
+import (
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+const (
+	// Scale is the number of decimal places a Decimal keeps
+	Scale = 4
+	// RateScale is the number of decimal places a Rate keeps
+	RateScale = 8
+
+	decimalFactor = 10000
+	rateFactor    = 100000000
+
+	// maxIntegerDigits keeps amounts within the DECIMAL(18,4) columns
+	maxIntegerDigits = 14
+	// maxRateDigits keeps rates within the DECIMAL(18,8) columns
+	maxRateDigits = 10
+)
+
+var (
+	ErrInvalidDecimal  = errors.New("money: invalid decimal")
+	ErrOutOfRange      = errors.New("money: value out of range")
+	ErrTooManyDecimals = errors.New("money: too many decimal places")
+	ErrInvalidRate     = errors.New("money: exchange rate must be greater than 0")
+)
+
+// Decimal is an amount with four decimal places, kept as an integer count of
+// ten-thousandths so sums and comparisons are exact. The zero value is 0.
+type Decimal struct {
+	units int64
+}
+
+// NewDecimal returns the whole amount n
+func NewDecimal(n int64) Decimal {
+	return Decimal{units: n * decimalFactor}
+}
+
+// ParseDecimal parses a plain decimal such as "10000", "-3.5" or "10000.00".
+// Digits beyond four decimal places are refused rather than rounded.
+func ParseDecimal(s string) (Decimal, error) {
+	v, err := parseFixed(s, Scale, maxIntegerDigits)
+	return Decimal{units: v}, err
+}
+
+// FromFloat converts a float such as a configuration value, rounding to four
+// decimal places. Amounts coming from users should go through ParseDecimal.
+func FromFloat(f float64) Decimal {
+	return Decimal{units: int64(math.Round(f * decimalFactor))}
+}
+
+// String formats the decimal with at least two decimal places, e.g. "10000.00"
+func (d Decimal) String() string {
+	return formatFixed(d.units, Scale, 2)
+}
+
+// Cmp compares d and o, returning -1, 0 or +1
+func (d Decimal) Cmp(o Decimal) int {
+	switch {
+	case d.units < o.units:
+		return -1
+	case d.units > o.units:
+		return 1
+	}
+	return 0
+}
+
+// Sign returns -1, 0 or +1 depending on the sign of d
+func (d Decimal) Sign() int {
+	return d.Cmp(Decimal{})
+}
+
+// IsZero reports whether d is 0
+func (d Decimal) IsZero() bool {
+	return d.units == 0
+}
+
+// Add returns d + o
+func (d Decimal) Add(o Decimal) Decimal {
+	return Decimal{units: d.units + o.units}
+}
+
+// Decimals returns the number of significant decimal places, 0 for whole amounts
+func (d Decimal) Decimals() int {
+	v := d.units
+	if v < 0 {
+		v = -v
+	}
+	places := Scale
+	for places > 0 && v%10 == 0 {
+		v /= 10
+		places--
+	}
+	return places
+}
+
+// MarshalJSON writes the decimal as a JSON number without going through float64
+func (d Decimal) MarshalJSON() ([]byte, error) {
+	return []byte(d.String()), nil
+}
+
+// UnmarshalJSON accepts a JSON number or a numeric string
+func (d *Decimal) UnmarshalJSON(data []byte) error {
+	v, err := ParseDecimal(strings.Trim(string(data), `"`))
+	if err != nil {
+		return err
+	}
+	*d = v
+	return nil
+}
+
+// Value implements driver.Valuer interface for GORM
+func (d Decimal) Value() (driver.Value, error) {
+	return formatFixed(d.units, Scale, Scale), nil
+}
+
+// Scan implements sql.Scanner interface for GORM
+func (d *Decimal) Scan(value interface{}) error {
+	v, err := scanFixed(value, Scale, maxIntegerDigits)
+	if err != nil {
+		return err
+	}
+	*d = Decimal{units: v}
+	return nil
+}
+
+// Rate is an exchange rate with eight decimal places: the number of base
+// currency units one unit of another currency is worth. The zero value means
+// no rate.
+type Rate struct {
+	units int64
+}
+
+// RateOne converts a currency to itself
+var RateOne = Rate{units: rateFactor}
+
+// ParseRate parses a positive exchange rate such as "15750.5" or "0.00006349"
+func ParseRate(s string) (Rate, error) {
+	v, err := parseFixed(s, RateScale, maxRateDigits)
+	if err != nil {
+		return Rate{}, err
+	}
+	if v <= 0 {
+		return Rate{}, ErrInvalidRate
+	}
+	return Rate{units: v}, nil
+}
+
+// IsZero reports whether no rate is set
+func (r Rate) IsZero() bool {
+	return r.units == 0
+}
+
+// Convert returns the amount in the base currency, rounded half away from zero
+func (r Rate) Convert(d Decimal) (Decimal, error) {
+	product := new(big.Int).Mul(big.NewInt(d.units), big.NewInt(r.units))
+	half := big.NewInt(rateFactor / 2)
+	if product.Sign() < 0 {
+		half.Neg(half)
+	}
+	product.Add(product, half)
+	product.Quo(product, big.NewInt(rateFactor))
+	if !product.IsInt64() {
+		return Decimal{}, ErrOutOfRange
+	}
+	converted := product.Int64()
+	if converted >= pow10(maxIntegerDigits+Scale) || converted <= -pow10(maxIntegerDigits+Scale) {
+		return Decimal{}, ErrOutOfRange
+	}
+	return Decimal{units: converted}, nil
+}
+
+// String formats the rate without trailing zeros, e.g. "15750.5"
+func (r Rate) String() string {
+	return formatFixed(r.units, RateScale, 0)
+}
+
+// MarshalJSON writes the rate as a JSON number
+func (r Rate) MarshalJSON() ([]byte, error) {
+	return []byte(r.String()), nil
+}
+
+// UnmarshalJSON accepts a JSON number or a numeric string
+func (r *Rate) UnmarshalJSON(data []byte) error {
+	v, err := ParseRate(strings.Trim(string(data), `"`))
+	if err != nil {
+		return err
+	}
+	*r = v
+	return nil
+}
+
+// Value implements driver.Valuer interface for GORM
+func (r Rate) Value() (driver.Value, error) {
+	return formatFixed(r.units, RateScale, RateScale), nil
+}
+
+// Scan implements sql.Scanner interface for GORM
+func (r *Rate) Scan(value interface{}) error {
+	v, err := scanFixed(value, RateScale, maxRateDigits)
+	if err != nil {
+		return err
+	}
+	*r = Rate{units: v}
+	return nil
+}
+
+// parseFixed parses s into an integer count of 10^-scale units
+func parseFixed(s string, scale, maxDigits int) (int64, error) {
+	s = strings.TrimSpace(s)
+	negative := false
+	switch {
+	case strings.HasPrefix(s, "-"):
+		negative, s = true, s[1:]
+	case strings.HasPrefix(s, "+"):
+		s = s[1:]
+	}
+
+	whole, frac, hasPoint := strings.Cut(s, ".")
+	if whole == "" && frac == "" || hasPoint && frac == "" || !isDigits(whole) || !isDigits(frac) {
+		return 0, fmt.Errorf("%w: %q", ErrInvalidDecimal, s)
+	}
+
+	frac = strings.TrimRight(frac, "0")
+	if len(frac) > scale {
+		return 0, fmt.Errorf("%w: at most %d allowed", ErrTooManyDecimals, scale)
+	}
+	whole = strings.TrimLeft(whole, "0")
+	if len(whole) > maxDigits {
+		return 0, ErrOutOfRange
+	}
+
+	digits := whole + frac + strings.Repeat("0", scale-len(frac))
+	v, err := strconv.ParseInt("0"+digits, 10, 64)
+	if err != nil {
+		return 0, ErrOutOfRange
+	}
+	if negative {
+		v = -v
+	}
+	return v, nil
+}
+
+// formatFixed formats v units of 10^-scale with at least minFrac decimal places
+func formatFixed(v int64, scale, minFrac int) string {
+	sign := ""
+	u := uint64(v)
+	if v < 0 {
+		sign, u = "-", uint64(-v)
+	}
+	factor := uint64(pow10(scale))
+	frac := fmt.Sprintf("%0*d", scale, u%factor)
+	trimmed := strings.TrimRight(frac, "0")
+	if len(trimmed) < minFrac {
+		trimmed = frac[:minFrac]
+	}
+	if trimmed == "" {
+		return sign + strconv.FormatUint(u/factor, 10)
+	}
+	return sign + strconv.FormatUint(u/factor, 10) + "." + trimmed
+}
+
+// scanFixed reads a DECIMAL column, which drivers return as text
+func scanFixed(value interface{}, scale, maxDigits int) (int64, error) {
+	switch v := value.(type) {
+	case nil:
+		return 0, nil
+	case []byte:
+		return parseFixed(string(v), scale, maxDigits)
+	case string:
+		return parseFixed(v, scale, maxDigits)
+	case int64:
+		return parseFixed(strconv.FormatInt(v, 10), scale, maxDigits)
+	case float64:
+		return parseFixed(strconv.FormatFloat(v, 'f', scale, 64), scale, maxDigits)
+	default:
+		return 0, fmt.Errorf("money: cannot scan %T", value)
+	}
+}
+
+func isDigits(s string) bool {
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+func pow10(n int) int64 {
+	p := int64(1)
+	for i := 0; i < n; i++ {
+		p *= 10
+	}
+	return p
+}
@@ -0,0 +1,146 @@
+package money_test
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"workflow-approval/utils/money"
+)
+
+func TestParseDecimal(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    string
+		wantErr error
+	}{
+		{"10000", "10000.00", nil},
+		{"10000.00", "10000.00", nil},
+		{"0.1", "0.10", nil},
+		{"-3.5", "-3.50", nil},
+		{"1.2345", "1.2345", nil},
+		{"1.23450000", "1.2345", nil},
+		{"1.23456", "", money.ErrTooManyDecimals},
+		{"99999999999999.9999", "99999999999999.9999", nil},
+		{"100000000000000", "", money.ErrOutOfRange},
+		{"1e6", "", money.ErrInvalidDecimal},
+		{"12.", "", money.ErrInvalidDecimal},
+		{"", "", money.ErrInvalidDecimal},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			d, err := money.ParseDecimal(tt.in)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Expected %v, got %v", tt.wantErr, err)
+			}
+			if err == nil && d.String() != tt.want {
+				t.Errorf("Expected %s, got %s", tt.want, d)
+			}
+		})
+	}
+}
+
+func TestDecimalIsExact(t *testing.T) {
+	// 0.1 + 0.2 is not 0.3 in float64; summing tenths as Decimals is
+	var sum money.Decimal
+	tenth, _ := money.ParseDecimal("0.1")
+	for i := 0; i < 100000; i++ {
+		sum = sum.Add(tenth)
+	}
+	if sum != money.NewDecimal(10000) {
+		t.Errorf("Expected exactly 10000.00, got %s", sum)
+	}
+
+	limit, _ := money.ParseDecimal("10000.00")
+	justBelow, _ := money.ParseDecimal("9999.99")
+	if justBelow.Cmp(limit) >= 0 || sum.Cmp(limit) < 0 {
+		t.Error("Expected comparisons at the limit to be exact")
+	}
+}
+
+func TestDecimalJSON(t *testing.T) {
+	var body struct {
+		Number money.Decimal `json:"number"`
+		String money.Decimal `json:"string"`
+	}
+	if err := json.Unmarshal([]byte(`{"number": 10000.10, "string": "0.3"}`), &body); err != nil {
+		t.Fatalf("Unmarshal: %v", err)
+	}
+	out, _ := json.Marshal(body)
+	if string(out) != `{"number":10000.10,"string":0.30}` {
+		t.Errorf("Unexpected JSON %s", out)
+	}
+	if err := json.Unmarshal([]byte(`{"number": 1.00001}`), &body); !errors.Is(err, money.ErrTooManyDecimals) {
+		t.Errorf("Expected ErrTooManyDecimals, got %v", err)
+	}
+}
+
+func TestDecimalSQL(t *testing.T) {
+	d, _ := money.ParseDecimal("1500000.5")
+	v, _ := d.Value()
+	if v != "1500000.5000" {
+		t.Errorf("Expected 1500000.5000, got %v", v)
+	}
+	var scanned money.Decimal
+	if err := scanned.Scan([]byte("1500000.5000")); err != nil || scanned != d {
+		t.Errorf("Expected to scan %s back, got %s (%v)", d, scanned, err)
+	}
+}
+
+func TestRateConvert(t *testing.T) {
+	usd, _ := money.ParseRate("15750.25")
+	amount, _ := money.ParseDecimal("12.34")
+	converted, err := usd.Convert(amount)
+	if err != nil {
+		t.Fatalf("Convert: %v", err)
+	}
+	if converted.String() != "194358.085" {
+		t.Errorf("Expected 194358.085, got %s", converted)
+	}
+
+	idr, _ := money.ParseRate("0.00006349")
+	converted, _ = idr.Convert(money.NewDecimal(1000000))
+	if converted.String() != "63.49" {
+		t.Errorf("Expected 63.49, got %s", converted)
+	}
+
+	// Rounds half away from zero at the fourth decimal place
+	half, _ := money.ParseRate("0.00005")
+	if converted, _ := half.Convert(money.NewDecimal(1)); converted.String() != "0.0001" {
+		t.Errorf("Expected 0.0001, got %s", converted)
+	}
+
+	if _, err := money.ParseRate("0"); !errors.Is(err, money.ErrInvalidRate) {
+		t.Errorf("Expected ErrInvalidRate, got %v", err)
+	}
+	if _, err := money.RateOne.Convert(money.NewDecimal(1)); err != nil {
+		t.Errorf("Expected RateOne to convert, got %v", err)
+	}
+	big, _ := money.ParseRate("1000000")
+	if _, err := big.Convert(money.NewDecimal(99999999999)); !errors.Is(err, money.ErrOutOfRange) {
+		t.Errorf("Expected ErrOutOfRange, got %v", err)
+	}
+}
+
+func TestCurrency(t *testing.T) {
+	usd, err := money.ParseCurrency("usd")
+	if err != nil || usd != "USD" {
+		t.Fatalf("Expected USD, got %q (%v)", usd, err)
+	}
+	if _, err := money.ParseCurrency("XYZ"); !errors.Is(err, money.ErrUnknownCurrency) {
+		t.Errorf("Expected ErrUnknownCurrency, got %v", err)
+	}
+
+	cents, _ := money.ParseDecimal("10.5")
+	fils, _ := money.ParseDecimal("10.505")
+	if !usd.Fits(cents) || usd.Fits(fils) {
+		t.Error("Expected USD to allow two decimal places")
+	}
+	if money.Currency("JPY").Fits(cents) || !money.Currency("JPY").Fits(money.NewDecimal(500)) {
+		t.Error("Expected JPY to allow whole amounts only")
+	}
+	if !money.Currency("KWD").Fits(fils) {
+		t.Error("Expected KWD to allow three decimal places")
+	}
+}
@@ -0,0 +1,92 @@
+package oidc
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Claims holds the verified claims of an ID token
+type Claims map[string]interface{}
+
+// Subject returns the "sub" claim
+func (c Claims) Subject() string {
+	return c.String("sub")
+}
+
+// Email returns the "email" claim
+func (c Claims) Email() string {
+	return strings.TrimSpace(c.String("email"))
+}
+
+// EmailVerified reports whether the provider asserted email ownership
+func (c Claims) EmailVerified() bool {
+	switch v := c["email_verified"].(type) {
+	case bool:
+		return v
+	case string:
+		return v == "true"
+	}
+	return false
+}
+
+// Name returns the "name" claim, falling back to given/family name
+func (c Claims) Name() string {
+	if name := strings.TrimSpace(c.String("name")); name != "" {
+		return name
+	}
+	return strings.TrimSpace(c.String("given_name") + " " + c.String("family_name"))
+}
+
+// String returns a claim as a string, or "" when missing or not a scalar
+func (c Claims) String(name string) string {
+	switch v := c[name].(type) {
+	case string:
+		return v
+	case bool, float64:
+		return fmt.Sprint(v)
+	}
+	return ""
+}
+
+// Strings returns a claim as a list of strings; scalars become one-element lists.
+// Nested claims can be addressed with dots, e.g. "realm_access.roles".
+func (c Claims) Strings(name string) []string {
+	value := c.lookup(name)
+	switch v := value.(type) {
+	case string:
+		return []string{v}
+	case bool, float64:
+		return []string{fmt.Sprint(v)}
+	case []interface{}:
+		values := make([]string, 0, len(v))
+		for _, item := range v {
+			switch s := item.(type) {
+			case string:
+				values = append(values, s)
+			case bool, float64:
+				values = append(values, fmt.Sprint(s))
+			}
+		}
+		return values
+	case []string:
+		return v
+	}
+	return nil
+}
+
+// lookup resolves a possibly dotted claim path
+func (c Claims) lookup(name string) interface{} {
+	if value, ok := c[name]; ok {
+		return value
+	}
+
+	var current interface{} = map[string]interface{}(c)
+	for _, part := range strings.Split(name, ".") {
+		object, ok := current.(map[string]interface{})
+		if !ok {
+			return nil
+		}
+		current = object[part]
+	}
+	return current
+}
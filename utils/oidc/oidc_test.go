@@ -0,0 +1,74 @@
+package oidc
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"errors"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+
+	"workflow-approval/utils/oidc/oidctest"
+)
+
+// countingTransport counts the JWKS downloads going through it
+type countingTransport struct {
+	jwksFetches int32
+}
+
+func (t *countingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if strings.HasSuffix(req.URL.Path, "/jwks") {
+		atomic.AddInt32(&t.jwksFetches, 1)
+	}
+	return http.DefaultTransport.RoundTrip(req)
+}
+
+func TestUnknownKidRefetchCooldown(t *testing.T) {
+	provider, err := oidctest.NewProvider("client", "secret")
+	if err != nil {
+		t.Fatalf("Failed to start fake provider: %v", err)
+	}
+	defer provider.Close()
+
+	transport := &countingTransport{}
+	client := NewClient(Config{IssuerURL: provider.Issuer(), ClientID: "client"}, &http.Client{Transport: transport})
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("Failed to generate key: %v", err)
+	}
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, jwt.MapClaims{
+		"iss": provider.Issuer(),
+		"aud": "client",
+		"sub": "someone",
+		"exp": time.Now().Add(time.Minute).Unix(),
+	})
+	token.Header["kid"] = "made-up"
+	rawToken, err := token.SignedString(key)
+	if err != nil {
+		t.Fatalf("Failed to sign token: %v", err)
+	}
+
+	for i := 0; i < 5; i++ {
+		if _, err := client.VerifyIDToken(context.Background(), rawToken, ""); !errors.Is(err, ErrUnknownSigningKey) {
+			t.Fatalf("Expected ErrUnknownSigningKey, got %v", err)
+		}
+	}
+	if fetches := atomic.LoadInt32(&transport.jwksFetches); fetches != 1 {
+		t.Errorf("Expected one JWKS download within the cooldown, got %d", fetches)
+	}
+
+	// Once the cooldown has passed an unknown kid refreshes the keys again
+	client.mu.Lock()
+	client.jwksFetchedAt = time.Now().Add(-jwksRefetchCooldown)
+	client.mu.Unlock()
+	client.VerifyIDToken(context.Background(), rawToken, "")
+	if fetches := atomic.LoadInt32(&transport.jwksFetches); fetches != 2 {
+		t.Errorf("Expected a refresh after the cooldown, got %d downloads", fetches)
+	}
+}
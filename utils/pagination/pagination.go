@@ -0,0 +1,247 @@
+// Package pagination pages through listings either by offset or by opaque
+// keyset cursors over (created_at, id). Cursor pages stay consistent while
+// rows are inserted and don't slow down as clients page deeper.
+package pagination
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"net/url"
+	"strconv"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+const (
+	DefaultLimit = 10
+	MaxLimit     = 100
+)
+
+var (
+	ErrInvalidCursor = errors.New("cursor is invalid")
+	ErrPageAndCursor = errors.New("page cannot be combined with cursor")
+	ErrInvalidTotal  = errors.New("total must be true or false")
+)
+
+// Cursor points at a row of a listing; the page it selects starts right
+// after the row, or ends right before it when Before is set
+type Cursor struct {
+	CreatedAt time.Time
+	ID        string
+	Before    bool
+}
+
+type cursorJSON struct {
+	CreatedAt time.Time `json:"t"`
+	ID        string    `json:"id"`
+	Before    bool      `json:"b,omitempty"`
+}
+
+// Encode returns the opaque form of the cursor handed to clients
+func (c Cursor) Encode() string {
+	data, _ := json.Marshal(cursorJSON(c))
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// DecodeCursor parses a cursor returned by Encode
+func DecodeCursor(s string) (Cursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return Cursor{}, ErrInvalidCursor
+	}
+	var c cursorJSON
+	if err := json.Unmarshal(data, &c); err != nil || c.ID == "" || c.CreatedAt.IsZero() {
+		return Cursor{}, ErrInvalidCursor
+	}
+	return Cursor(c), nil
+}
+
+// Page selects a page of a listing: by Number in offset mode, or relative to
+// Cursor when one is given
+type Page struct {
+	Number int
+	Limit  int
+	Cursor *Cursor
+	// WithTotal counts every matching row; it costs a separate COUNT(*) so
+	// it defaults to on in offset mode only
+	WithTotal bool
+}
+
+// Parse reads the page, limit, cursor and total query parameters. Missing or
+// malformed page and limit values fall back to the defaults.
+func Parse(page, limit, cursor, total string) (Page, error) {
+	p := Page{}
+	p.Number, _ = strconv.Atoi(page)
+	p.Limit, _ = strconv.Atoi(limit)
+
+	if cursor != "" {
+		if page != "" {
+			return p, ErrPageAndCursor
+		}
+		c, err := DecodeCursor(cursor)
+		if err != nil {
+			return p, err
+		}
+		p.Cursor = &c
+	}
+
+	p.WithTotal = p.Cursor == nil
+	if total != "" {
+		withTotal, err := strconv.ParseBool(total)
+		if err != nil {
+			return p, ErrInvalidTotal
+		}
+		p.WithTotal = withTotal
+	}
+	return p.Normalized(), nil
+}
+
+// Normalized returns the page with its number and limit within bounds
+func (p Page) Normalized() Page {
+	if p.Number < 1 || p.Cursor != nil {
+		p.Number = 1
+	}
+	if p.Limit < 1 {
+		p.Limit = DefaultLimit
+	}
+	if p.Limit > MaxLimit {
+		p.Limit = MaxLimit
+	}
+	return p
+}
+
+// Offset returns the number of rows skipped before the page
+func (p Page) Offset() int {
+	return (p.Number - 1) * p.Limit
+}
+
+// Apply orders query by (created_at, id), newest first when desc, and
+// restricts it to the page. One row more than the limit is fetched so Finish
+// can tell whether another page follows.
+func Apply(query *gorm.DB, p Page, desc bool) *gorm.DB {
+	if p.Cursor == nil {
+		return query.Order(order(desc)).Offset(p.Offset()).Limit(p.Limit + 1)
+	}
+
+	// Pages before the cursor are read backwards from it, then reversed
+	forward := !p.Cursor.Before
+	if forward == desc {
+		query = query.Where("(created_at < ? OR (created_at = ? AND id < ?))", p.Cursor.CreatedAt, p.Cursor.CreatedAt, p.Cursor.ID)
+	} else {
+		query = query.Where("(created_at > ? OR (created_at = ? AND id > ?))", p.Cursor.CreatedAt, p.Cursor.CreatedAt, p.Cursor.ID)
+	}
+	return query.Order(order(desc == forward)).Limit(p.Limit + 1)
+}
+
+func order(desc bool) string {
+	if desc {
+		return "created_at DESC, id DESC"
+	}
+	return "created_at ASC, id ASC"
+}
+
+// Result describes a fetched page
+type Result struct {
+	Page  Page
+	Total int64   // Only counted with Page.WithTotal
+	Next  *Cursor // Nil on the last page
+	Prev  *Cursor // Nil on the first page
+}
+
+// Finish trims the extra row fetched by Apply, restores the listing order of
+// pages read backwards, and sets the cursors of the neighbouring pages from
+// the first and last rows. key returns a row's created_at and ID.
+func Finish[T any](rows []T, p Page, key func(T) (time.Time, string)) ([]T, Result) {
+	result := Result{Page: p}
+	more := len(rows) > p.Limit
+	if more {
+		rows = rows[:p.Limit]
+	}
+	backward := p.Cursor != nil && p.Cursor.Before
+	if backward {
+		for i, j := 0, len(rows)-1; i < j; i, j = i+1, j-1 {
+			rows[i], rows[j] = rows[j], rows[i]
+		}
+	}
+	if len(rows) == 0 {
+		return rows, result
+	}
+
+	first, last := cursorAt(rows[0], key), cursorAt(rows[len(rows)-1], key)
+	first.Before = true
+	switch {
+	case backward:
+		result.Next = &last
+		if more {
+			result.Prev = &first
+		}
+	default:
+		if more {
+			result.Next = &last
+		}
+		if p.Cursor != nil || p.Number > 1 {
+			result.Prev = &first
+		}
+	}
+	return rows, result
+}
+
+// Trimmed drops the extra row fetched for a page that can't be paged by
+// cursor, e.g. one sorted by another column
+func Trimmed[T any](rows []T, p Page) ([]T, Result) {
+	if len(rows) > p.Limit {
+		rows = rows[:p.Limit]
+	}
+	return rows, Result{Page: p}
+}
+
+func cursorAt[T any](row T, key func(T) (time.Time, string)) Cursor {
+	createdAt, id := key(row)
+	return Cursor{CreatedAt: createdAt, ID: id}
+}
+
+// Meta returns the paging fields of a list response. Links repeat the
+// request's path and raw query with the cursor of the neighbouring page.
+func (r Result) Meta(path, rawQuery string) map[string]interface{} {
+	query, _ := url.ParseQuery(rawQuery)
+	meta := map[string]interface{}{
+		"limit":       r.Page.Limit,
+		"next_cursor": encode(r.Next),
+		"prev_cursor": encode(r.Prev),
+		"links": map[string]interface{}{
+			"next": link(path, query, r.Next),
+			"prev": link(path, query, r.Prev),
+		},
+	}
+	if r.Page.Cursor == nil {
+		meta["page"] = r.Page.Number
+	}
+	if r.Page.WithTotal {
+		meta["total"] = r.Total
+	}
+	return meta
+}
+
+func encode(c *Cursor) *string {
+	if c == nil {
+		return nil
+	}
+	s := c.Encode()
+	return &s
+}
+
+func link(path string, query url.Values, c *Cursor) *string {
+	if c == nil {
+		return nil
+	}
+	q := url.Values{}
+	for k, v := range query {
+		q[k] = v
+	}
+	q.Del("page")
+	q.Set("cursor", c.Encode())
+	s := path + "?" + q.Encode()
+	return &s
+}
@@ -0,0 +1,141 @@
+package pagination
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+type row struct {
+	ID        string
+	CreatedAt time.Time
+}
+
+func rowKey(r row) (time.Time, string) {
+	return r.CreatedAt, r.ID
+}
+
+// rows returns n rows one minute apart, newest first
+func rows(n int) []row {
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	out := make([]row, n)
+	for i := range out {
+		out[i] = row{ID: fmt.Sprintf("r%02d", n-i), CreatedAt: start.Add(time.Duration(n-i) * time.Minute)}
+	}
+	return out
+}
+
+func TestParse(t *testing.T) {
+	p, err := Parse("", "", "", "")
+	if err != nil || p.Number != 1 || p.Limit != DefaultLimit || p.Cursor != nil || !p.WithTotal {
+		t.Errorf("Expected the first offset page with a total, got %+v (%v)", p, err)
+	}
+
+	cursor := Cursor{CreatedAt: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC), ID: "r01", Before: true}
+	p, err = Parse("", "500", cursor.Encode(), "")
+	if err != nil || p.Cursor == nil || *p.Cursor != cursor || p.Limit != MaxLimit || p.WithTotal {
+		t.Errorf("Expected a cursor page without a total, got %+v (%v)", p, err)
+	}
+
+	if _, err := Parse("2", "", cursor.Encode(), ""); !errors.Is(err, ErrPageAndCursor) {
+		t.Errorf("Expected ErrPageAndCursor, got %v", err)
+	}
+	if _, err := Parse("", "", "not-a-cursor", ""); !errors.Is(err, ErrInvalidCursor) {
+		t.Errorf("Expected ErrInvalidCursor, got %v", err)
+	}
+	if _, err := Parse("", "", "", "maybe"); !errors.Is(err, ErrInvalidTotal) {
+		t.Errorf("Expected ErrInvalidTotal, got %v", err)
+	}
+}
+
+func TestFinish(t *testing.T) {
+	all := rows(5) // r05 (newest) .. r01
+
+	// First page of 2 fetched 3 rows: more follow, nothing precedes
+	page := Page{Number: 1, Limit: 2}
+	got, result := Finish(append([]row(nil), all[:3]...), page, rowKey)
+	if len(got) != 2 || got[0].ID != "r05" || result.Prev != nil || result.Next == nil || result.Next.ID != "r04" {
+		t.Fatalf("Unexpected first page %v %+v", got, result)
+	}
+
+	// The page after r04 is r03, r02 with more after it
+	page = Page{Number: 1, Limit: 2, Cursor: result.Next}
+	got, result = Finish(append([]row(nil), all[2:5]...), page, rowKey)
+	if got[0].ID != "r03" || result.Next.ID != "r02" || result.Prev == nil || result.Prev.ID != "r03" || !result.Prev.Before {
+		t.Fatalf("Unexpected second page %v %+v", got, result)
+	}
+
+	// The page before r03 is read backwards (r04, r05) and reversed; it is the first
+	page = Page{Number: 1, Limit: 2, Cursor: result.Prev}
+	got, result = Finish([]row{all[1], all[0]}, page, rowKey)
+	if got[0].ID != "r05" || got[1].ID != "r04" || result.Prev != nil || result.Next.ID != "r04" {
+		t.Fatalf("Unexpected previous page %v %+v", got, result)
+	}
+
+	// The last page has no next cursor
+	page = Page{Number: 3, Limit: 2}
+	_, result = Finish([]row{all[4]}, page, rowKey)
+	if result.Next != nil || result.Prev == nil {
+		t.Errorf("Unexpected last page %+v", result)
+	}
+}
+
+func TestApply(t *testing.T) {
+	db, err := gorm.Open(mysql.New(mysql.Config{DSN: "user:pass@tcp(localhost:3306)/test", SkipInitializeWithVersion: true}), &gorm.Config{
+		DryRun:               true,
+		DisableAutomaticPing: true,
+		Logger:               logger.Default.LogMode(logger.Silent),
+	})
+	if err != nil {
+		t.Fatalf("failed to open dry-run DB: %v", err)
+	}
+	at := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name string
+		page Page
+		desc bool
+		want string
+	}{
+		{"Offset", Page{Number: 3, Limit: 10}, true, "ORDER BY created_at DESC, id DESC LIMIT ? OFFSET ?"},
+		{"After, newest first", Page{Limit: 10, Cursor: &Cursor{CreatedAt: at, ID: "x"}}, true,
+			"WHERE (created_at < ? OR (created_at = ? AND id < ?)) ORDER BY created_at DESC, id DESC LIMIT ?"},
+		{"Before, newest first", Page{Limit: 10, Cursor: &Cursor{CreatedAt: at, ID: "x", Before: true}}, true,
+			"WHERE (created_at > ? OR (created_at = ? AND id > ?)) ORDER BY created_at ASC, id ASC LIMIT ?"},
+		{"After, oldest first", Page{Limit: 10, Cursor: &Cursor{CreatedAt: at, ID: "x"}}, false,
+			"WHERE (created_at > ? OR (created_at = ? AND id > ?)) ORDER BY created_at ASC, id ASC LIMIT ?"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stmt := Apply(db.Table("items"), tt.page, tt.desc).Find(&[]row{}).Statement
+			if sql := stmt.SQL.String(); !strings.HasSuffix(sql, tt.want) {
+				t.Errorf("Expected SQL ending in %q, got %q", tt.want, sql)
+			}
+			// One row more than the limit tells whether another page follows
+			if limit := stmt.Vars[len(stmt.Vars)-1]; tt.page.Cursor != nil && limit != 11 {
+				t.Errorf("Expected to fetch 11 rows, got %v", limit)
+			}
+		})
+	}
+}
+
+func TestMetaLinks(t *testing.T) {
+	next := Cursor{CreatedAt: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC), ID: "r01"}
+	meta := Result{Page: Page{Number: 2, Limit: 10, WithTotal: true}, Total: 25, Next: &next}.
+		Meta("/api/requests", "page=2&status=PENDING")
+
+	links := meta["links"].(map[string]interface{})
+	if got := *links["next"].(*string); got != "/api/requests?cursor="+next.Encode()+"&status=PENDING" {
+		t.Errorf("Unexpected next link %s", got)
+	}
+	if links["prev"].(*string) != nil || meta["total"] != int64(25) || meta["page"] != 2 {
+		t.Errorf("Unexpected meta %v", meta)
+	}
+}
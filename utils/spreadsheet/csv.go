@@ -0,0 +1,49 @@
+package spreadsheet
+
+import (
+	"encoding/csv"
+	"io"
+)
+
+// CSVWriter writes RFC 4180 CSV
+type CSVWriter struct {
+	w      *csv.Writer
+	record []string
+}
+
+// NewCSVWriter creates a CSVWriter writing to w
+func NewCSVWriter(w io.Writer) *CSVWriter {
+	return &CSVWriter{w: csv.NewWriter(w)}
+}
+
+// WriteRow writes one record. Text starting like a formula is prefixed with
+// a quote so spreadsheet applications opening the file show it as text
+// instead of evaluating it (CSV injection).
+func (cw *CSVWriter) WriteRow(cells []Cell) error {
+	cw.record = cw.record[:0]
+	for _, cell := range cells {
+		value := cell.Value
+		if !cell.Number && isFormula(value) {
+			value = "'" + value
+		}
+		cw.record = append(cw.record, value)
+	}
+	return cw.w.Write(cw.record)
+}
+
+// Close flushes buffered records
+func (cw *CSVWriter) Close() error {
+	cw.w.Flush()
+	return cw.w.Error()
+}
+
+func isFormula(s string) bool {
+	if s == "" {
+		return false
+	}
+	switch s[0] {
+	case '=', '+', '-', '@', '\t', '\r':
+		return true
+	}
+	return false
+}
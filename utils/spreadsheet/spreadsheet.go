@@ -0,0 +1,31 @@
+// Package spreadsheet writes tabular exports row by row as CSV or XLSX, so
+// exports of any size stream through a fixed amount of memory.
+package spreadsheet
+
+// Cell is one value of a row
+type Cell struct {
+	Value  string
+	Number bool // Written as a numeric cell; Value must be a plain decimal
+}
+
+// Text returns a text cell
+func Text(s string) Cell {
+	return Cell{Value: s}
+}
+
+// Number returns a numeric cell from a decimal such as "-12.50"
+func Number(s string) Cell {
+	return Cell{Value: s, Number: true}
+}
+
+// Writer appends rows to a single sheet
+type Writer interface {
+	WriteRow(cells []Cell) error
+	// Close completes the file; it doesn't close the underlying writer
+	Close() error
+}
+
+var (
+	_ Writer = (*CSVWriter)(nil)
+	_ Writer = (*XLSXWriter)(nil)
+)
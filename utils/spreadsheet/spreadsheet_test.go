@@ -0,0 +1,95 @@
+package spreadsheet
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/csv"
+	"encoding/xml"
+	"io"
+	"testing"
+)
+
+func TestCSVWriterEscapesFormulas(t *testing.T) {
+	var buf bytes.Buffer
+	w := NewCSVWriter(&buf)
+	if err := w.WriteRow([]Cell{Text("=HYPERLINK(\"x\")"), Text("a,b"), Number("-12.50"), Text("-5")}); err != nil {
+		t.Fatalf("WriteRow failed: %v", err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+
+	record, err := csv.NewReader(&buf).Read()
+	if err != nil {
+		t.Fatalf("Invalid CSV: %v", err)
+	}
+	want := []string{"'=HYPERLINK(\"x\")", "a,b", "-12.50", "'-5"}
+	for i := range want {
+		if record[i] != want[i] {
+			t.Errorf("Column %d: expected %q, got %q", i, want[i], record[i])
+		}
+	}
+}
+
+func TestXLSXWriterWritesWorkbook(t *testing.T) {
+	var buf bytes.Buffer
+	w, err := NewXLSXWriter(&buf, "Requests")
+	if err != nil {
+		t.Fatalf("NewXLSXWriter failed: %v", err)
+	}
+	w.WriteRow([]Cell{Text("title"), Text("amount")})
+	w.WriteRow([]Cell{Text("Laptop <16\" & dock>"), Number("1250.5")})
+	if err := w.Close(); err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+
+	zr, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatalf("Invalid zip: %v", err)
+	}
+	parts := make(map[string]*zip.File)
+	for _, f := range zr.File {
+		parts[f.Name] = f
+	}
+	for _, name := range []string{"[Content_Types].xml", "_rels/.rels", "xl/workbook.xml", "xl/_rels/workbook.xml.rels", "xl/worksheets/sheet1.xml"} {
+		if parts[name] == nil {
+			t.Fatalf("Missing part %s", name)
+		}
+	}
+
+	rc, _ := parts["xl/worksheets/sheet1.xml"].Open()
+	defer rc.Close()
+	body, _ := io.ReadAll(rc)
+	var sheet struct {
+		Rows []struct {
+			R     string `xml:"r,attr"`
+			Cells []struct {
+				R      string `xml:"r,attr"`
+				T      string `xml:"t,attr"`
+				V      string `xml:"v"`
+				Inline string `xml:"is>t"`
+			} `xml:"c"`
+		} `xml:"sheetData>row"`
+	}
+	if err := xml.Unmarshal(body, &sheet); err != nil {
+		t.Fatalf("Invalid worksheet XML: %v", err)
+	}
+	if len(sheet.Rows) != 2 || sheet.Rows[1].R != "2" {
+		t.Fatalf("Expected 2 rows, got %+v", sheet.Rows)
+	}
+	cells := sheet.Rows[1].Cells
+	if cells[0].R != "A2" || cells[0].T != "inlineStr" || cells[0].Inline != "Laptop <16\" & dock>" {
+		t.Errorf("Unexpected text cell %+v", cells[0])
+	}
+	if cells[1].R != "B2" || cells[1].T != "" || cells[1].V != "1250.5" {
+		t.Errorf("Unexpected numeric cell %+v", cells[1])
+	}
+}
+
+func TestColumnName(t *testing.T) {
+	for i, want := range map[int]string{0: "A", 25: "Z", 26: "AA", 51: "AZ", 52: "BA", 701: "ZZ", 702: "AAA"} {
+		if got := columnName(i); got != want {
+			t.Errorf("columnName(%d) = %s, expected %s", i, got, want)
+		}
+	}
+}
@@ -0,0 +1,204 @@
+// Package s3test provides an in-process S3-compatible object store for tests.
+// It serves path-style PUT, GET and DELETE object requests for one bucket and
+// verifies their Signature Version 4 against the configured credentials.
+package s3test
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Object is a stored object
+type Object struct {
+	Data        []byte
+	ContentType string
+}
+
+// Server is a fake S3 endpoint backed by httptest.Server
+type Server struct {
+	Bucket    string
+	Region    string
+	AccessKey string
+	SecretKey string
+
+	server *httptest.Server
+
+	mu      sync.Mutex
+	objects map[string]Object
+}
+
+// NewServer starts a fake S3 endpoint holding a single empty bucket
+func NewServer(bucket, region, accessKey, secretKey string) *Server {
+	s := &Server{
+		Bucket:    bucket,
+		Region:    region,
+		AccessKey: accessKey,
+		SecretKey: secretKey,
+		objects:   make(map[string]Object),
+	}
+	s.server = httptest.NewServer(http.HandlerFunc(s.handle))
+	return s
+}
+
+// URL returns the endpoint to configure path-style clients with
+func (s *Server) URL() string {
+	return s.server.URL
+}
+
+// Close shuts down the server
+func (s *Server) Close() {
+	s.server.Close()
+}
+
+// Object returns a stored object by key
+func (s *Server) Object(key string) (Object, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	obj, ok := s.objects[key]
+	return obj, ok
+}
+
+func (s *Server) handle(w http.ResponseWriter, r *http.Request) {
+	if code, msg := s.verify(r); code != "" {
+		writeError(w, http.StatusForbidden, code, msg)
+		return
+	}
+
+	bucket, key, _ := strings.Cut(strings.TrimPrefix(r.URL.Path, "/"), "/")
+	if bucket != s.Bucket {
+		writeError(w, http.StatusNotFound, "NoSuchBucket", "The specified bucket does not exist")
+		return
+	}
+	if key == "" {
+		writeError(w, http.StatusBadRequest, "InvalidRequest", "Object key is required")
+		return
+	}
+
+	switch r.Method {
+	case http.MethodPut:
+		if r.ContentLength < 0 {
+			writeError(w, http.StatusLengthRequired, "MissingContentLength", "You must provide the Content-Length HTTP header")
+			return
+		}
+		data, err := io.ReadAll(r.Body)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, "IncompleteBody", err.Error())
+			return
+		}
+		s.mu.Lock()
+		s.objects[key] = Object{Data: data, ContentType: r.Header.Get("Content-Type")}
+		s.mu.Unlock()
+		w.WriteHeader(http.StatusOK)
+	case http.MethodGet:
+		obj, ok := s.Object(key)
+		if !ok {
+			writeError(w, http.StatusNotFound, "NoSuchKey", "The specified key does not exist")
+			return
+		}
+		if obj.ContentType != "" {
+			w.Header().Set("Content-Type", obj.ContentType)
+		}
+		w.Write(obj.Data)
+	case http.MethodDelete:
+		s.mu.Lock()
+		delete(s.objects, key)
+		s.mu.Unlock()
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		writeError(w, http.StatusMethodNotAllowed, "MethodNotAllowed", "The specified method is not allowed")
+	}
+}
+
+// verify recomputes the request's SigV4 signature from what was received and
+// returns an S3 error code when it does not match
+func (s *Server) verify(r *http.Request) (string, string) {
+	auth := r.Header.Get("Authorization")
+	rest, ok := strings.CutPrefix(auth, "AWS4-HMAC-SHA256 ")
+	if !ok {
+		return "AccessDenied", "Missing or unsupported Authorization header"
+	}
+	params := map[string]string{}
+	for _, part := range strings.Split(rest, ",") {
+		k, v, _ := strings.Cut(strings.TrimSpace(part), "=")
+		params[k] = v
+	}
+
+	credential := strings.SplitN(params["Credential"], "/", 2)
+	if len(credential) != 2 || credential[0] != s.AccessKey {
+		return "InvalidAccessKeyId", "The access key does not exist"
+	}
+	scope := credential[1]
+	scopeParts := strings.Split(scope, "/")
+	if len(scopeParts) != 4 || scopeParts[1] != s.Region || scopeParts[2] != "s3" || scopeParts[3] != "aws4_request" {
+		return "AuthorizationHeaderMalformed", "Invalid credential scope " + scope
+	}
+
+	amzDate := r.Header.Get("X-Amz-Date")
+	payloadHash := r.Header.Get("X-Amz-Content-Sha256")
+	if amzDate == "" || payloadHash == "" {
+		return "AccessDenied", "Missing x-amz-date or x-amz-content-sha256"
+	}
+
+	signed := strings.Split(params["SignedHeaders"], ";")
+	var headers strings.Builder
+	for _, name := range signed {
+		value := r.Header.Get(name)
+		if name == "host" {
+			value = r.Host
+		}
+		headers.WriteString(name + ":" + strings.TrimSpace(value) + "\n")
+	}
+
+	canonical := strings.Join([]string{
+		r.Method,
+		r.URL.EscapedPath(),
+		canonicalQuery(r.URL.Query()),
+		headers.String(),
+		params["SignedHeaders"],
+		payloadHash,
+	}, "\n")
+	hashed := sha256.Sum256([]byte(canonical))
+	stringToSign := "AWS4-HMAC-SHA256\n" + amzDate + "\n" + scope + "\n" + hex.EncodeToString(hashed[:])
+
+	key := sum([]byte("AWS4"+s.SecretKey), scopeParts[0])
+	for _, part := range scopeParts[1:] {
+		key = sum(key, part)
+	}
+	expected := hex.EncodeToString(sum(key, stringToSign))
+	if !hmac.Equal([]byte(expected), []byte(params["Signature"])) {
+		return "SignatureDoesNotMatch", "The request signature we calculated does not match the signature you provided"
+	}
+	return "", ""
+}
+
+func sum(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
+
+func canonicalQuery(values url.Values) string {
+	var parts []string
+	for k, vs := range values {
+		for _, v := range vs {
+			parts = append(parts, url.QueryEscape(k)+"="+url.QueryEscape(v))
+		}
+	}
+	sort.Strings(parts)
+	return strings.ReplaceAll(strings.Join(parts, "&"), "+", "%20")
+}
+
+func writeError(w http.ResponseWriter, status int, code, message string) {
+	w.Header().Set("Content-Type", "application/xml")
+	w.WriteHeader(status)
+	fmt.Fprintf(w, `<?xml version="1.0" encoding="UTF-8"?><Error><Code>%s</Code><Message>%s</Message></Error>`, code, message)
+}
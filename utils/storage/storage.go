@@ -0,0 +1,70 @@
+// Package storage keeps uploaded files such as request attachments. The
+// backend is pluggable: a directory on the local filesystem, or any
+// S3-compatible object store (AWS S3, MinIO, Ceph, ...).
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Drivers selectable in configuration
+const (
+	DriverLocal = "local"
+	DriverS3    = "s3"
+)
+
+var (
+	ErrNotFound   = errors.New("storage: object not found")
+	ErrInvalidKey = errors.New("storage: invalid object key")
+)
+
+// Storage stores objects under slash-separated keys
+type Storage interface {
+	// Put stores size bytes read from r under key, replacing an existing object
+	Put(ctx context.Context, key string, r io.Reader, size int64, contentType string) error
+	// Get opens the object; the caller must close it. Missing objects return ErrNotFound.
+	Get(ctx context.Context, key string) (io.ReadCloser, error)
+	// Delete removes the object; deleting a missing object is not an error
+	Delete(ctx context.Context, key string) error
+}
+
+// Config selects and configures a Storage backend
+type Config struct {
+	Driver   string // "local" (default) or "s3"
+	LocalDir string // Root directory of the local driver
+	S3       S3Config
+}
+
+// New creates the Storage selected by cfg.Driver
+func New(cfg Config) (Storage, error) {
+	switch cfg.Driver {
+	case "", DriverLocal:
+		if cfg.LocalDir == "" {
+			return nil, fmt.Errorf("storage: local driver requires a directory")
+		}
+		return NewLocalStorage(cfg.LocalDir), nil
+	case DriverS3:
+		if cfg.S3.Endpoint == "" || cfg.S3.Bucket == "" {
+			return nil, fmt.Errorf("storage: s3 driver requires endpoint and bucket")
+		}
+		return NewS3Storage(cfg.S3, nil), nil
+	}
+	return nil, fmt.Errorf("storage: unknown driver %q", cfg.Driver)
+}
+
+// validKey rejects keys that could escape the storage root or are ambiguous
+func validKey(key string) bool {
+	if key == "" || strings.HasPrefix(key, "/") || strings.Contains(key, "\\") {
+		return false
+	}
+	for _, part := range strings.Split(key, "/") {
+		if part == "" || part == "." || part == ".." {
+			return false
+		}
+	}
+	return true
+}
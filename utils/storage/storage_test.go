@@ -0,0 +1,92 @@
+package storage_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"workflow-approval/utils/storage"
+	"workflow-approval/utils/storage/s3test"
+)
+
+// exerciseStorage runs the behaviour every backend must share
+func exerciseStorage(t *testing.T, store storage.Storage) {
+	ctx := context.Background()
+	key := "tenant-1/req-1/quote (final).pdf"
+	content := []byte("%PDF-1.4 quote")
+
+	if err := store.Put(ctx, key, bytes.NewReader(content), int64(len(content)), "application/pdf"); err != nil {
+		t.Fatalf("Put: %v", err)
+	}
+
+	r, err := store.Get(ctx, key)
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	got, _ := io.ReadAll(r)
+	r.Close()
+	if !bytes.Equal(got, content) {
+		t.Errorf("Expected %q, got %q", content, got)
+	}
+
+	if err := store.Delete(ctx, key); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+	if _, err := store.Get(ctx, key); !errors.Is(err, storage.ErrNotFound) {
+		t.Errorf("Expected ErrNotFound after delete, got %v", err)
+	}
+	if err := store.Delete(ctx, key); err != nil {
+		t.Errorf("Expected deleting a missing object to succeed, got %v", err)
+	}
+
+	for _, bad := range []string{"../escape", "/absolute", "a//b", ""} {
+		if err := store.Put(ctx, bad, strings.NewReader("x"), 1, ""); !errors.Is(err, storage.ErrInvalidKey) {
+			t.Errorf("Expected ErrInvalidKey for %q, got %v", bad, err)
+		}
+	}
+}
+
+func TestLocalStorage(t *testing.T) {
+	exerciseStorage(t, storage.NewLocalStorage(t.TempDir()))
+}
+
+func TestS3Storage(t *testing.T) {
+	server := s3test.NewServer("attachments", "eu-west-1", "test-access", "test-secret")
+	defer server.Close()
+
+	cfg := storage.S3Config{
+		Endpoint:  server.URL(),
+		Region:    "eu-west-1",
+		Bucket:    "attachments",
+		AccessKey: "test-access",
+		SecretKey: "test-secret",
+		PathStyle: true,
+	}
+
+	t.Run("Objects round-trip", func(t *testing.T) {
+		exerciseStorage(t, storage.NewS3Storage(cfg, nil))
+	})
+
+	t.Run("Content type is stored", func(t *testing.T) {
+		store := storage.NewS3Storage(cfg, nil)
+		if err := store.Put(context.Background(), "a/b.png", strings.NewReader("png"), 3, "image/png"); err != nil {
+			t.Fatalf("Put: %v", err)
+		}
+		obj, ok := server.Object("a/b.png")
+		if !ok || obj.ContentType != "image/png" {
+			t.Errorf("Expected stored image/png object, got %+v", obj)
+		}
+	})
+
+	t.Run("Wrong secret is rejected", func(t *testing.T) {
+		bad := cfg
+		bad.SecretKey = "wrong"
+		err := storage.NewS3Storage(bad, nil).Put(context.Background(), "a/c.txt", strings.NewReader("x"), 1, "text/plain")
+		if err == nil || !strings.Contains(err.Error(), "SignatureDoesNotMatch") {
+			t.Errorf("Expected SignatureDoesNotMatch, got %v", err)
+		}
+	})
+}
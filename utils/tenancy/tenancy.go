@@ -0,0 +1,152 @@
+// Package tenancy carries the caller's tenant through context.Context and
+// scopes database access to it. The Plugin filters every query, update and
+// delete on models with a TenantID field to the tenant in the statement's
+// context, and stamps that tenant on created rows, so repositories only need
+// to pass their ctx to db.WithContext. It fails closed: reading or changing a
+// tenant-owned model from a context without a tenant is an error unless the
+// context explicitly spans all tenants.
+package tenancy
+
+import (
+	"context"
+	"errors"
+	"reflect"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/schema"
+)
+
+// ErrNoTenantScope is returned for statements on tenant-owned models whose
+// context is neither restricted to a tenant nor marked WithAllTenants
+var ErrNoTenantScope = errors.New("tenancy: statement on a tenant-owned model without a tenant scope")
+
+// DefaultTenantID owns the data that existed before multi-tenancy and rows
+// created outside of a tenant (e.g. users provisioned from an OIDC login)
+const DefaultTenantID = "00000000-0000-0000-0000-000000000001"
+
+// Scope is the tenant a context is restricted to
+type Scope struct {
+	TenantID   string
+	AllTenants bool // Super-admin access across every tenant
+}
+
+// scopeKey is the context key of the Scope
+type scopeKey struct{}
+
+// ScopeKey is also the fiber Locals key the auth middleware stores the Scope
+// under: fasthttp's RequestCtx.Value reads Locals, so handlers passing
+// c.Context() to services carry the scope down to the repositories.
+var ScopeKey = scopeKey{}
+
+// WithTenant restricts ctx to a tenant
+func WithTenant(ctx context.Context, tenantID string) context.Context {
+	return context.WithValue(ctx, ScopeKey, Scope{TenantID: tenantID})
+}
+
+// WithAllTenants lifts the tenant restriction of ctx (super-admin endpoints,
+// background jobs and lookups made before the caller's tenant is known)
+func WithAllTenants(ctx context.Context) context.Context {
+	return context.WithValue(ctx, ScopeKey, Scope{AllTenants: true})
+}
+
+// FromContext returns the scope of ctx; false when ctx carries none
+func FromContext(ctx context.Context) (Scope, bool) {
+	if ctx == nil {
+		return Scope{}, false
+	}
+	scope, ok := ctx.Value(ScopeKey).(Scope)
+	return scope, ok
+}
+
+// TenantID returns the tenant ctx is restricted to, empty when unrestricted
+func TenantID(ctx context.Context) string {
+	scope, _ := FromContext(ctx)
+	return scope.TenantID
+}
+
+// Plugin is the GORM plugin applying tenant scopes; register it with db.Use
+type Plugin struct{}
+
+// Name implements gorm.Plugin
+func (Plugin) Name() string {
+	return "tenancy"
+}
+
+// Initialize implements gorm.Plugin
+func (Plugin) Initialize(db *gorm.DB) error {
+	callbacks := db.Callback()
+	if err := callbacks.Create().Before("gorm:create").Register("tenancy:create", assignTenant); err != nil {
+		return err
+	}
+	if err := callbacks.Query().Before("gorm:query").Register("tenancy:query", scopeStatement); err != nil {
+		return err
+	}
+	if err := callbacks.Update().Before("gorm:update").Register("tenancy:update", scopeStatement); err != nil {
+		return err
+	}
+	if err := callbacks.Delete().Before("gorm:delete").Register("tenancy:delete", scopeStatement); err != nil {
+		return err
+	}
+	return callbacks.Row().Before("gorm:row").Register("tenancy:row", scopeStatement)
+}
+
+// tenantField returns the TenantID field of the statement's model, nil if it has none
+func tenantField(db *gorm.DB) *schema.Field {
+	if db.Statement.Schema == nil {
+		return nil
+	}
+	return db.Statement.Schema.LookUpField("TenantID")
+}
+
+// scopeStatement adds "<table>.tenant_id = ?" for tenant-restricted contexts
+// and refuses statements whose context carries no tenant
+func scopeStatement(db *gorm.DB) {
+	field := tenantField(db)
+	if field == nil {
+		return
+	}
+	scope, ok := FromContext(db.Statement.Context)
+	if ok && scope.AllTenants {
+		return
+	}
+	if !ok || scope.TenantID == "" {
+		_ = db.AddError(ErrNoTenantScope)
+		return
+	}
+
+	db.Statement.AddClause(clause.Where{Exprs: []clause.Expression{
+		clause.Eq{Column: clause.Column{Table: clause.CurrentTable, Name: field.DBName}, Value: scope.TenantID},
+	}})
+}
+
+// assignTenant fills an empty TenantID with the context's tenant, or the default tenant
+func assignTenant(db *gorm.DB) {
+	field := tenantField(db)
+	if field == nil {
+		return
+	}
+	tenantID := TenantID(db.Statement.Context)
+	if tenantID == "" {
+		tenantID = DefaultTenantID
+	}
+
+	ctx := db.Statement.Context
+	setIfEmpty := func(rv reflect.Value) {
+		if _, zero := field.ValueOf(ctx, rv); zero {
+			if err := field.Set(ctx, rv, tenantID); err != nil {
+				_ = db.AddError(err)
+			}
+		}
+	}
+
+	rv := db.Statement.ReflectValue
+	switch rv.Kind() {
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < rv.Len(); i++ {
+			setIfEmpty(reflect.Indirect(rv.Index(i)))
+		}
+	case reflect.Struct:
+		setIfEmpty(rv)
+	}
+}
@@ -0,0 +1,143 @@
+package tenancy
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+type scopedModel struct {
+	ID       string
+	TenantID string
+	Name     string
+}
+
+type globalModel struct {
+	ID   string
+	Name string
+}
+
+// newDryRunDB returns a DB that builds SQL without connecting to MySQL
+func newDryRunDB(t *testing.T) *gorm.DB {
+	t.Helper()
+	db, err := gorm.Open(mysql.New(mysql.Config{DSN: "user:pass@tcp(localhost:3306)/test", SkipInitializeWithVersion: true}), &gorm.Config{
+		DryRun:                 true,
+		DisableAutomaticPing:   true,
+		SkipDefaultTransaction: true, // Updates and deletes would otherwise connect to begin one
+		Logger:                 logger.Default.LogMode(logger.Silent),
+	})
+	if err != nil {
+		t.Fatalf("failed to open dry-run DB: %v", err)
+	}
+	if err := db.Use(Plugin{}); err != nil {
+		t.Fatalf("failed to register plugin: %v", err)
+	}
+	return db
+}
+
+func TestPluginScopesQueries(t *testing.T) {
+	db := newDryRunDB(t)
+	tenantCtx := WithTenant(context.Background(), "tenant-a")
+
+	tests := []struct {
+		name      string
+		ctx       context.Context
+		run       func(tx *gorm.DB) *gorm.DB
+		wantScope bool
+	}{
+		{"Query in tenant", tenantCtx, func(tx *gorm.DB) *gorm.DB { return tx.First(&scopedModel{}, "id = ?", "1") }, true},
+		{"Count in tenant", tenantCtx, func(tx *gorm.DB) *gorm.DB { var n int64; return tx.Model(&scopedModel{}).Count(&n) }, true},
+		{"Update in tenant", tenantCtx, func(tx *gorm.DB) *gorm.DB {
+			return tx.Model(&scopedModel{ID: "1"}).Update("name", "x")
+		}, true},
+		{"Delete in tenant", tenantCtx, func(tx *gorm.DB) *gorm.DB { return tx.Delete(&scopedModel{}, "id = ?", "1") }, true},
+		{"All tenants", WithAllTenants(context.Background()), func(tx *gorm.DB) *gorm.DB { return tx.Find(&[]scopedModel{}) }, false},
+		{"Model without tenant", tenantCtx, func(tx *gorm.DB) *gorm.DB { return tx.Find(&[]globalModel{}) }, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stmt := tt.run(db.WithContext(tt.ctx)).Statement
+			sql := stmt.SQL.String()
+			scoped := strings.Contains(sql, ".`tenant_id` = ?")
+			if scoped != tt.wantScope {
+				t.Fatalf("Expected tenant scope %v, got SQL %q", tt.wantScope, sql)
+			}
+			if scoped && !containsVar(stmt.Vars, "tenant-a") {
+				t.Errorf("Expected tenant-a bound, got %v", stmt.Vars)
+			}
+		})
+	}
+}
+
+func TestPluginRefusesUnscopedStatements(t *testing.T) {
+	db := newDryRunDB(t)
+
+	tests := []struct {
+		name string
+		ctx  context.Context
+		run  func(tx *gorm.DB) *gorm.DB
+	}{
+		{"Query without scope", context.Background(), func(tx *gorm.DB) *gorm.DB { return tx.Find(&[]scopedModel{}) }},
+		{"Count without scope", context.Background(), func(tx *gorm.DB) *gorm.DB { var n int64; return tx.Model(&scopedModel{}).Count(&n) }},
+		{"Update without scope", context.Background(), func(tx *gorm.DB) *gorm.DB {
+			return tx.Model(&scopedModel{ID: "1"}).Update("name", "x")
+		}},
+		{"Delete without scope", context.Background(), func(tx *gorm.DB) *gorm.DB { return tx.Delete(&scopedModel{}, "id = ?", "1") }},
+		{"Empty tenant", WithTenant(context.Background(), ""), func(tx *gorm.DB) *gorm.DB { return tx.Find(&[]scopedModel{}) }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.run(db.WithContext(tt.ctx)).Error; !errors.Is(err, ErrNoTenantScope) {
+				t.Errorf("Expected ErrNoTenantScope, got %v", err)
+			}
+		})
+	}
+
+	// GORM drops a subquery's error and renders it empty, which MySQL rejects
+	tx := db.WithContext(WithTenant(context.Background(), "tenant-a")).
+		Where("id IN (?)", db.Model(&scopedModel{}).Select("id")).Find(&[]scopedModel{})
+	if sql := tx.Statement.SQL.String(); strings.Contains(sql, "SELECT id FROM") {
+		t.Errorf("Expected the unscoped subquery left out, got %q", sql)
+	}
+
+	// Models without a tenant are not affected
+	if err := db.WithContext(context.Background()).Find(&[]globalModel{}).Error; err != nil {
+		t.Errorf("Expected no error for a model without tenant, got %v", err)
+	}
+}
+
+func TestPluginAssignsTenantOnCreate(t *testing.T) {
+	db := newDryRunDB(t)
+
+	row := &scopedModel{ID: "1"}
+	db.WithContext(WithTenant(context.Background(), "tenant-a")).Create(row)
+	if row.TenantID != "tenant-a" {
+		t.Errorf("Expected tenant-a, got %q", row.TenantID)
+	}
+
+	rows := []scopedModel{{ID: "2"}, {ID: "3", TenantID: "tenant-b"}}
+	db.WithContext(WithTenant(context.Background(), "tenant-a")).Create(&rows)
+	if rows[0].TenantID != "tenant-a" || rows[1].TenantID != "tenant-b" {
+		t.Errorf("Expected only empty tenants filled, got %+v", rows)
+	}
+
+	system := &scopedModel{ID: "4"}
+	db.WithContext(context.Background()).Create(system)
+	if system.TenantID != DefaultTenantID {
+		t.Errorf("Expected default tenant outside a scope, got %q", system.TenantID)
+	}
+}
+
+func containsVar(vars []interface{}, want string) bool {
+	for _, v := range vars {
+		if v == want {
+			return true
+		}
+	}
+	return false
+}